@@ -12,7 +12,13 @@ var commands = []string{
 	"add",
 	"edit",
 	"remove",
+	"clean",
+	"export",
+	"import",
 	"list",
+	"tree",
+	"why",
+	"graph",
 	"sync",
 	"update",
 	"pull",
@@ -31,6 +37,7 @@ var commands = []string{
 	"create",
 	"delete",
 	"rename",
+	"mv",
 	"add-mapping",
 	"remove-mapping",
 	"list-mappings",
@@ -39,6 +46,12 @@ var commands = []string{
 	"check",
 	"preview",
 	"config",
+	"normalize",
+	"schema",
+	"lock",
+	"pin",
+	"unpin",
+	"bump",
 }
 
 // DeprecatedCommands maps deprecated command names to their replacement
@@ -66,22 +79,55 @@ _git_vendor_completions() {
     # Command-specific options
     case "${prev}" in
         pull)
-            opts="--locked --prune --keep-local --interactive --force --no-cache --commit --local --verbose -v"
+            opts="--locked --prune --keep-local --interactive --force --no-cache --commit --local --retry-on-stale --report-unmanaged --unmanaged-root --snapshot --offline --only-positions --check-license --strict-license --relocate --include-pinned --allow-hooks --hardlink --since --retries --timeout --explain-plan --dry-run --no-symlinks --exclude-vendor --only --verbose -v"
             ;;
         sync)
-            opts="--dry-run --force --no-cache --group --parallel --workers --verbose -v"
+            opts="--dry-run --force --no-cache --group --only --exclude-vendor --retries --timeout --parallel --workers --verbose -v"
             ;;
         update)
-            opts="--parallel --workers --verbose -v"
+            opts="--dry-run --json --parallel --workers --exclude-vendor --relocate --include-pinned --since --retries --timeout --verbose -v"
+            ;;
+        init)
+            opts="--format --gitignore --readme --config --quiet -q --json"
+            ;;
+        lock)
+            opts="--regenerate --local --include-pinned --rehash --quiet -q --json"
+            ;;
+        bump)
+            opts="--from --no-sync --local --quiet -q --json"
+            ;;
+        pin)
+            opts="--json"
+            ;;
+        unpin)
+            opts="--ref --json"
             ;;
         remove)
+            opts="--yes -y --quiet -q --json --dry-run"
+            ;;
+        clean)
+            opts="--dry-run --yes -y --quiet -q --json"
+            ;;
+        export)
+            opts="--output -o --quiet -q --json"
+            ;;
+        import)
             opts="--yes -y --quiet -q --json"
             ;;
-        list|validate|check-updates)
+        list)
+            opts="--quiet -q --json --format"
+            ;;
+        tree|why|check-updates|normalize)
             opts="--quiet -q --json"
             ;;
+        graph)
+            opts="--format"
+            ;;
+        validate)
+            opts="--quiet -q --json --check-only --policy"
+            ;;
         status)
-            opts="--quiet -q --json --offline --remote-only --strict-only --compliance= --format"
+            opts="--quiet -q --json --offline --remote-only --since --strict-only --strict --fail-on --positions-only --files-only --exclude-vendor --group-by --baseline-update --accept --timeout --quick --fix --compliance= --format"
             ;;
         completion)
             opts="bash zsh fish powershell"
@@ -89,13 +135,16 @@ _git_vendor_completions() {
         diff|watch)
             opts=""
             ;;
+        add)
+            opts="--url --ref --from --to --name --license --dir-per-file --local --yes -y --quiet -q --json"
+            ;;
         create)
             opts="--ref --license --json"
             ;;
         delete)
-            opts="--yes -y --quiet -q --json"
+            opts="--yes -y --quiet -q --json --dry-run"
             ;;
-        rename)
+        rename|mv)
             opts="--json"
             ;;
         add-mapping)
@@ -168,6 +217,26 @@ _git_vendor() {
                         '--no-cache[Skip incremental cache]' \
                         '--commit[Auto-commit after pull]' \
                         '--local[Allow local paths]' \
+                        '--retry-on-stale[Update a vendor with a stale locked commit and retry once]' \
+                        '--report-unmanaged[List files not produced by any mapping]' \
+                        '--unmanaged-root[Directory to scan for unmanaged files]:directory:_files -/' \
+                        '--snapshot[Archive fetched trees for offline restore]' \
+                        '--offline[Restore locked commits from snapshots]' \
+                        '--only-positions[Re-place position mappings from the source cache]' \
+                        '--check-license[Warn when an upstream license changed]' \
+                        '--strict-license[Fail when an upstream license changed]' \
+                        '--relocate[Follow position snippets that moved upstream]' \
+                        '--include-pinned[Also update pinned vendors]' \
+                        '--allow-hooks[Run each vendor post_sync command]' \
+                        '--hardlink[Hard-link identical vendored files]' \
+                        '--since[Only update vendors with upstream commits within a duration]:duration:' \
+                        '--retries[Retry transient fetch failures N times]:retries:' \
+                        '--timeout[Abort after a duration]:duration:' \
+                        '--explain-plan[Show write order and winner for contested destinations]' \
+                        '--dry-run[Preview lock updates (with --prune: mappings pruned)]' \
+                        '--no-symlinks[Skip all symlinks when copying directories]' \
+                        '--exclude-vendor[Skip vendors matching name or glob]:pattern:' \
+                        '--only[Only vendors matching name or glob]:pattern:' \
                         '--verbose[Show git commands]' \
                         '-v[Show git commands]'
                     ;;
@@ -177,6 +246,10 @@ _git_vendor() {
                         '--force[Re-download even if synced]' \
                         '--no-cache[Skip incremental cache]' \
                         '--group[Sync vendor group]:group:' \
+                        '--only[Only vendors matching name or glob]:pattern:' \
+                        '--exclude-vendor[Skip vendors matching name or glob]:pattern:' \
+                        '--retries[Retry transient fetch failures N times]:retries:' \
+                        '--timeout[Abort after a duration]:duration:' \
                         '--parallel[Enable parallel processing]' \
                         '--workers[Number of parallel workers]:workers:' \
                         '--verbose[Show git commands]' \
@@ -184,25 +257,116 @@ _git_vendor() {
                     ;;
                 update)
                     _arguments \
+                        '--dry-run[List vendors whose lock would move, without writing]' \
+                        '--json[JSON output]' \
                         '--parallel[Enable parallel processing]' \
                         '--workers[Number of parallel workers]:workers:' \
+                        '--exclude-vendor[Skip vendors matching name or glob]:pattern:' \
+                        '--relocate[Follow position snippets that moved upstream]' \
+                        '--include-pinned[Also update pinned vendors]' \
+                        '--since[Only update vendors with upstream commits within a duration]:duration:' \
+                        '--retries[Retry transient fetch failures N times]:retries:' \
+                        '--timeout[Abort after a duration]:duration:' \
                         '--verbose[Show git commands]' \
                         '-v[Show git commands]'
                     ;;
                 remove)
                     _arguments \
+                        '--yes[Skip confirmation]' \
+                        '-y[Skip confirmation]' \
+                        '--quiet[Minimal output]' \
+                        '-q[Minimal output]' \
+                        '--json[JSON output]' \
+                        '--dry-run[List what would be deleted without deleting]'
+                    ;;
+                init)
+                    _arguments \
+                        '--format[Config file format]:format:(yaml toml)' \
+                        '--gitignore[Add git-vendor temp patterns to .gitignore]' \
+                        '--readme[Write a README into the vendor directory]' \
+                        '--config[Vendor directory to use]:dir:_files -/' \
+                        '--quiet[Minimal output]' \
+                        '-q[Minimal output]' \
+                        '--json[JSON output]'
+                    ;;
+                pin)
+                    _arguments '--json[JSON output]'
+                    ;;
+                unpin)
+                    _arguments \
+                        '--ref[Branch to restore]:ref:' \
+                        '--json[JSON output]'
+                    ;;
+                lock)
+                    _arguments \
+                        '--regenerate[Re-fetch every vendor and rewrite the lock]' \
+                        '--rehash[Rewrite the lock checksum after a hand edit]' \
+                        '--local[Allow local paths]' \
+                        '--include-pinned[Also re-fetch pinned vendors]' \
+                        '--quiet[Exit code only]' \
+                        '-q[Exit code only]' \
+                        '--json[JSON output]'
+                    ;;
+                bump)
+                    _arguments \
+                        '--from[Current ref of the spec to move]:ref:' \
+                        '--no-sync[Only rewrite vendor.yml]' \
+                        '--local[Allow local paths]' \
+                        '--quiet[Minimal output]' \
+                        '-q[Minimal output]' \
+                        '--json[JSON output]'
+                    ;;
+                clean)
+                    _arguments \
+                        '--dry-run[List orphaned files without deleting]' \
                         '--yes[Skip confirmation]' \
                         '-y[Skip confirmation]' \
                         '--quiet[Minimal output]' \
                         '-q[Minimal output]' \
                         '--json[JSON output]'
                     ;;
-                list|validate|check-updates)
+                export)
                     _arguments \
+                        '--output[Archive path]:file:_files' \
+                        '-o[Archive path]:file:_files' \
                         '--quiet[Minimal output]' \
                         '-q[Minimal output]' \
                         '--json[JSON output]'
                     ;;
+                import)
+                    _arguments \
+                        '1:archive:_files' \
+                        '--yes[Skip confirmation]' \
+                        '-y[Skip confirmation]' \
+                        '--quiet[Minimal output]' \
+                        '-q[Minimal output]' \
+                        '--json[JSON output]'
+                    ;;
+                list)
+                    _arguments \
+                        '--quiet[Minimal output]' \
+                        '-q[Minimal output]' \
+                        '--json[JSON output]' \
+                        '--format[Output format]:format:(yaml)'
+                    ;;
+                tree|why|check-updates|normalize)
+                    _arguments \
+                        '--quiet[Minimal output]' \
+                        '-q[Minimal output]' \
+                        '--json[JSON output]'
+                    ;;
+                graph)
+                    _arguments \
+                        '--format[Output format]:format:(dot)'
+                    ;;
+                validate)
+                    _arguments \
+                        '--quiet[Minimal output]' \
+                        '-q[Minimal output]' \
+                        '--json[JSON output]' \
+                        '--check-only[Run all gate checks and fail on any issue]' \
+                        '--policy[License policy file]:file:_files'
+                    ;;
                 status)
                     _arguments \
                         '--quiet[Minimal output]' \
@@ -210,13 +374,41 @@ _git_vendor() {
                         '--json[JSON output]' \
                         '--offline[Skip remote checks]' \
                         '--remote-only[Skip disk checks]' \
+                        '--since[Only report vendors with upstream commits within a duration]:duration:' \
                         '--strict-only[Only check strict vendors]' \
+                        '--strict[Fail on warnings too]' \
+                        '--fail-on[Statuses that fail the run]:statuses:' \
+                        '--positions-only[Only verify position snippets]' \
+                        '--files-only[Only verify whole files]' \
+                        '--exclude-vendor[Skip vendors matching name or glob]:pattern:' \
+                        '--group-by[Add a per-vendor verify rollup]:key:(vendor)' \
+                        '--baseline-update[Accept current disk hashes into the lock]' \
+                        '--quick[Check lock and file presence only, no hashing]' \
+                        '--fix[Restore modified and deleted files to their locked content]' \
+                        '--accept[Path glob to rebaseline]:glob:' \
+                        '--timeout[Abort checks after a duration]:duration:' \
                         '--compliance=[Override compliance level]:level:(strict lenient info)' \
-                        '--format=[Output format]:format:(table json)'
+                        '--format=[Output format]:format:(table json github)'
                     ;;
                 completion)
                     _arguments '1:shell:(bash zsh fish powershell)'
                     ;;
+                add)
+                    _arguments \
+                        '--url[Repository URL]:url:' \
+                        '--ref[Git ref to track]:ref:' \
+                        '--from[Source path in the repository]:path:' \
+                        '--to[Destination path]:path:_files' \
+                        '--name[Vendor name (default: from URL)]:name:' \
+                        '--license[SPDX license override]:license:' \
+                        '--dir-per-file[Place each --from file under --to]' \
+                        '--local[Allow a local path or file:// URL]' \
+                        '--yes[Skip the wizard and accept license prompts]' \
+                        '-y[Skip the wizard and accept license prompts]' \
+                        '--quiet[Suppress output]' \
+                        '-q[Suppress output]' \
+                        '--json[JSON output]'
+                    ;;
                 create)
                     _arguments \
                         '--ref[Git ref to track]:ref:' \
@@ -229,9 +421,10 @@ _git_vendor() {
                         '-y[Skip confirmation]' \
                         '--quiet[Minimal output]' \
                         '-q[Minimal output]' \
-                        '--json[JSON output]'
+                        '--json[JSON output]' \
+                        '--dry-run[List what would be deleted without deleting]'
                     ;;
-                rename)
+                rename|mv)
                     _arguments '--json[JSON output]'
                     ;;
                 add-mapping)
@@ -288,6 +481,26 @@ func GenerateFishCompletion() string {
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l no-cache -d 'Skip incremental cache'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l commit -d 'Auto-commit after pull'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l local -d 'Allow local paths'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l retry-on-stale -d 'Update a vendor with a stale locked commit and retry once'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l report-unmanaged -d 'List files not produced by any mapping'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l unmanaged-root -r -d 'Directory to scan for unmanaged files'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l snapshot -d 'Archive fetched trees for offline restore'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l offline -d 'Restore locked commits from snapshots'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l only-positions -d 'Re-place position mappings from the source cache'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l check-license -d 'Warn when an upstream license changed'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l strict-license -d 'Fail when an upstream license changed'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l relocate -d 'Follow position snippets that moved upstream'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l include-pinned -d 'Also update pinned vendors'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l allow-hooks -d 'Run each vendor post_sync command'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l hardlink -d 'Hard-link identical vendored files'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l since -r -d 'Only update vendors with upstream commits within a duration'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l retries -r -d 'Retry transient fetch failures N times'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l timeout -r -d 'Abort after a duration'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l explain-plan -d 'Show write order and winner for contested destinations'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l dry-run -d 'Preview lock updates (with --prune: mappings pruned)'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l no-symlinks -d 'Skip all symlinks when copying directories'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l exclude-vendor -r -d 'Skip vendors matching name or glob'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l only -r -d 'Only vendors matching name or glob'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pull' -l verbose -s v -d 'Show git commands'")
 
 	completions = append(completions, "# sync command flags")
@@ -295,42 +508,120 @@ func GenerateFishCompletion() string {
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l force -d 'Re-download even if synced'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l no-cache -d 'Skip incremental cache'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l group -d 'Sync vendor group' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l only -r -d 'Only vendors matching name or glob'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l exclude-vendor -r -d 'Skip vendors matching name or glob'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l retries -r -d 'Retry transient fetch failures N times'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l timeout -r -d 'Abort after a duration'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l parallel -d 'Enable parallel processing'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l workers -d 'Number of parallel workers' -r")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from sync' -l verbose -s v -d 'Show git commands'")
 
 	completions = append(completions, "# update command flags")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l dry-run -d 'List vendors whose lock would move, without writing'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l json -d 'JSON output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l parallel -d 'Enable parallel processing'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l workers -d 'Number of parallel workers' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l exclude-vendor -r -d 'Skip vendors matching name or glob'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l relocate -d 'Follow position snippets that moved upstream'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l include-pinned -d 'Also update pinned vendors'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l since -r -d 'Only update vendors with upstream commits within a duration'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l retries -r -d 'Retry transient fetch failures N times'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l timeout -r -d 'Abort after a duration'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from update' -l verbose -s v -d 'Show git commands'")
 
+	completions = append(completions, "# init command flags")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from init' -l format -r -a 'yaml toml' -d 'Config file format'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from init' -l gitignore -d 'Add git-vendor temp patterns to .gitignore'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from init' -l readme -d 'Write a README into the vendor directory'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from init' -l config -r -d 'Vendor directory to use'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from init' -l quiet -s q -d 'Minimal output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from init' -l json -d 'JSON output'")
+	completions = append(completions, "# pin/unpin command flags")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from pin unpin' -l json -d 'JSON output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from unpin' -l ref -r -d 'Branch to restore'")
+	completions = append(completions, "# lock command flags")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from lock' -l regenerate -d 'Re-fetch every vendor and rewrite the lock'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from lock' -l rehash -d 'Rewrite the lock checksum after a hand edit'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from lock' -l local -d 'Allow local paths'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from lock' -l include-pinned -d 'Also re-fetch pinned vendors'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from lock' -l quiet -s q -d 'Exit code only'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from lock' -l json -d 'JSON output'")
+
+	completions = append(completions, "# bump command flags")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from bump' -l from -r -d 'Current ref of the spec to move'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from bump' -l no-sync -d 'Only rewrite vendor.yml'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from bump' -l local -d 'Allow local paths'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from bump' -l quiet -s q -d 'Minimal output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from bump' -l json -d 'JSON output'")
+
 	completions = append(completions, "# remove command flags")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from remove' -l yes -s y -d 'Skip confirmation'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from remove' -l quiet -s q -d 'Minimal output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from remove' -l json -d 'JSON output'")
-
-	completions = append(completions, "# list/validate/check-updates flags")
-	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from list validate check-updates' -l quiet -s q -d 'Minimal output'")
-	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from list validate check-updates' -l json -d 'JSON output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from remove' -l dry-run -d 'List what would be deleted without deleting'")
+
+	completions = append(completions, "# clean command flags")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from clean' -l dry-run -d 'List orphaned files without deleting'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from clean' -l yes -s y -d 'Skip confirmation'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from clean' -l quiet -s q -d 'Minimal output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from clean' -l json -d 'JSON output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from export' -l output -s o -r -d 'Archive path'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from export' -l quiet -s q -d 'Minimal output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from export' -l json -d 'JSON output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from import' -l yes -s y -d 'Skip confirmation'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from import' -l quiet -s q -d 'Minimal output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from import' -l json -d 'JSON output'")
+
+	completions = append(completions, "# list/tree/why/validate/check-updates/normalize flags")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from list tree why validate check-updates normalize' -l quiet -s q -d 'Minimal output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from list tree why validate check-updates normalize' -l json -d 'JSON output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from list' -l format -r -a yaml -d 'Output format'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from graph' -l format -r -a dot -d 'Output format'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from validate' -l check-only -d 'Run all gate checks and fail on any issue'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from validate' -l policy -r -d 'License policy file'")
 	completions = append(completions, "# status command flags")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l quiet -s q -d 'Minimal output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l json -d 'JSON output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l offline -d 'Skip remote checks'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l remote-only -d 'Skip disk checks'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l since -r -d 'Only report vendors with upstream commits within a duration'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l strict-only -d 'Only check strict vendors'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l strict -d 'Fail on warnings too'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l fail-on -r -d 'Statuses that fail the run'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l positions-only -d 'Only verify position snippets'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l baseline-update -d 'Accept current disk hashes into the lock'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l quick -d 'Check lock and file presence only, no hashing'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l fix -d 'Restore modified and deleted files to their locked content'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l accept -r -d 'Path glob to rebaseline'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l timeout -r -d 'Abort checks after a duration'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l files-only -d 'Only verify whole files'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l exclude-vendor -r -d 'Skip vendors matching name or glob'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l group-by -r -a 'vendor' -d 'Add a per-vendor verify rollup'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l compliance -d 'Override compliance level' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from status' -l format -r -a 'table json github' -d 'Output format'")
 
 	completions = append(completions, "# completion command shells")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from completion' -f -a 'bash zsh fish powershell'")
 
 	completions = append(completions, "# LLM-friendly command flags (Spec 072)")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l url -d 'Repository URL' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l ref -d 'Git ref to track' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l from -d 'Source path in the repository' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l to -d 'Destination path' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l name -d 'Vendor name (default: from URL)' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l license -d 'SPDX license override' -r")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l dir-per-file -d 'Place each --from file under --to'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l local -d 'Allow a local path or file:// URL'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l yes -s y -d 'Skip the wizard and accept license prompts'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add' -l json -d 'JSON output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from create' -l ref -d 'Git ref to track' -r")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from create' -l license -d 'SPDX license identifier' -r")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from create' -l json -d 'JSON output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from delete' -l yes -s y -d 'Skip confirmation'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from delete' -l quiet -s q -d 'Minimal output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from delete' -l json -d 'JSON output'")
-	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from rename' -l json -d 'JSON output'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from delete' -l dry-run -d 'List what would be deleted without deleting'")
+	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from rename mv' -l json -d 'JSON output'")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add-mapping' -l to -d 'Destination path' -r")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add-mapping' -l ref -d 'Target ref' -r")
 	completions = append(completions, "complete -c git-vendor -n '__fish_seen_subcommand_from add-mapping' -l json -d 'JSON output'")
@@ -376,37 +667,103 @@ Register-ArgumentCompleter -Native -CommandName git-vendor -ScriptBlock {
 
         switch ($subcommand) {
             'pull' {
-                @('--locked', '--prune', '--keep-local', '--interactive', '--force', '--no-cache', '--commit', '--local', '--verbose', '-v') |
+                @('--locked', '--prune', '--keep-local', '--interactive', '--force', '--no-cache', '--commit', '--local', '--retry-on-stale', '--report-unmanaged', '--unmanaged-root', '--snapshot', '--offline', '--only-positions', '--check-license', '--strict-license', '--relocate', '--include-pinned', '--allow-hooks', '--hardlink', '--since', '--retries', '--timeout', '--explain-plan', '--dry-run', '--no-symlinks', '--exclude-vendor', '--only', '--verbose', '-v') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
             }
             'sync' {
-                @('--dry-run', '--force', '--no-cache', '--group', '--parallel', '--workers', '--verbose', '-v') |
+                @('--dry-run', '--force', '--no-cache', '--group', '--only', '--exclude-vendor', '--retries', '--timeout', '--parallel', '--workers', '--verbose', '-v') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
             }
             'update' {
-                @('--parallel', '--workers', '--verbose', '-v') |
+                @('--dry-run', '--json', '--parallel', '--workers', '--exclude-vendor', '--relocate', '--include-pinned', '--since', '--retries', '--timeout', '--verbose', '-v') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'init' {
+                @('--format', '--gitignore', '--readme', '--config', '--quiet', '-q', '--json') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'pin' {
+                @('--json') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'unpin' {
+                @('--ref', '--json') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'lock' {
+                @('--regenerate', '--local', '--include-pinned', '--rehash', '--quiet', '-q', '--json') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'bump' {
+                @('--from', '--no-sync', '--local', '--quiet', '-q', '--json') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
             }
             'remove' {
+                @('--yes', '-y', '--quiet', '-q', '--json', '--dry-run') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'clean' {
+                @('--dry-run', '--yes', '-y', '--quiet', '-q', '--json') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'export' {
+                @('--output', '-o', '--quiet', '-q', '--json') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'import' {
                 @('--yes', '-y', '--quiet', '-q', '--json') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
             }
-            { $_ -in 'list','validate','check-updates' } {
+            'list' {
+                @('--quiet', '-q', '--json', '--format') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            { $_ -in 'tree','why','check-updates','normalize' } {
                 @('--quiet', '-q', '--json') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
             }
+            'graph' {
+                @('--format') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
+            'validate' {
+                @('--quiet', '-q', '--json', '--check-only', '--policy') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
             'status' {
-                @('--quiet', '-q', '--json', '--offline', '--remote-only', '--strict-only', '--compliance=', '--format') |
+                @('--quiet', '-q', '--json', '--offline', '--remote-only', '--since', '--strict-only', '--strict', '--fail-on', '--positions-only', '--files-only', '--exclude-vendor', '--group-by', '--baseline-update', '--accept', '--timeout', '--quick', '--fix', '--compliance=', '--format') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
@@ -417,6 +774,12 @@ Register-ArgumentCompleter -Native -CommandName git-vendor -ScriptBlock {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
             }
+            'add' {
+                @('--url', '--ref', '--from', '--to', '--name', '--license', '--dir-per-file', '--local', '--yes', '-y', '--quiet', '-q', '--json') |
+                    Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+                        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+                    }
+            }
             'create' {
                 @('--ref', '--license', '--json') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
@@ -424,12 +787,12 @@ Register-ArgumentCompleter -Native -CommandName git-vendor -ScriptBlock {
                     }
             }
             'delete' {
-                @('--yes', '-y', '--quiet', '-q', '--json') |
+                @('--yes', '-y', '--quiet', '-q', '--json', '--dry-run') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
                     }
             }
-            { $_ -in 'rename','remove-mapping','list-mappings','show','check','preview' } {
+            { $_ -in 'rename','mv','remove-mapping','list-mappings','show','check','preview' } {
                 @('--json') |
                     Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                         [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
@@ -473,7 +836,13 @@ func getCommandDescription(cmd string) string {
 		"add":            "Add vendor dependency",
 		"edit":           "Edit vendor configuration",
 		"remove":         "Remove vendor dependency",
+		"clean":          "Delete orphaned vendored files",
+		"export":         "Archive vendored files, config, lock and licenses",
+		"import":         "Extract an export archive into place",
 		"list":           "List all vendors",
+		"tree":           "Show destination layout by vendor",
+		"why":            "Explain which vendor owns a path",
+		"graph":          "Emit vendors and conflicts as Graphviz DOT",
 		"sync":           "Sync at locked versions (DEPRECATED: use pull --locked)",
 		"update":         "Update lockfile (DEPRECATED: use pull)",
 		"pull":           "Fetch and sync vendor dependencies",
@@ -489,6 +858,7 @@ func getCommandDescription(cmd string) string {
 		"create":         "Create vendor (non-interactive)",
 		"delete":         "Delete vendor (alias for remove)",
 		"rename":         "Rename a vendor",
+		"mv":             "Move a vendor's destination",
 		"add-mapping":    "Add path mapping to vendor",
 		"remove-mapping": "Remove path mapping from vendor",
 		"list-mappings":  "List path mappings for vendor",
@@ -499,6 +869,12 @@ func getCommandDescription(cmd string) string {
 		"compliance":     "Show effective compliance levels",
 		"hook":           "Generate vendor guard hook scripts",
 		"config":         "Get or set configuration values",
+		"normalize":      "Rewrite vendor.yml in canonical form",
+		"schema":         "Print JSON Schema for vendor.yml",
+		"lock":           "Check or regenerate vendor.lock",
+		"pin":            "Freeze vendor at its locked commit",
+		"unpin":          "Return pinned vendor to a branch",
+		"bump":           "Move vendor to a new ref and re-sync",
 	}
 
 	if desc, ok := descriptions[cmd]; ok {
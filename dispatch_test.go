@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/core"
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// runMainEnv marks a re-executed test binary that should dispatch its
+// arguments through main instead of running tests.
+const runMainEnv = "GIT_VENDOR_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) == "1" {
+		os.Args[0] = "git-vendor"
+		main()
+		os.Exit(core.ExitSuccess)
+	}
+	os.Exit(m.Run())
+}
+
+// runMain runs git-vendor with args in dir through a re-executed test binary
+// and returns its exit code and stdout.
+func runMain(t *testing.T, dir string, args ...string) (int, string) {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(exe, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), runMainEnv+"=1")
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), string(out)
+	}
+	if err != nil {
+		t.Fatalf("run git-vendor %v: %v", args, err)
+	}
+	return core.ExitSuccess, string(out)
+}
+
+// TestDispatch_DeclinedRemovalExitsCancelled verifies that declining the
+// removal confirmation (non-interactive mode without --yes) exits with
+// ExitCancelled rather than the general error code, and removes nothing.
+func TestDispatch_DeclinedRemovalExitsCancelled(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantJSON string // substring expected on stdout, if any
+	}{
+		{name: "remove quiet", args: []string{"remove", "lib", "--quiet"}},
+		{name: "remove json", args: []string{"remove", "lib", "--json"}},
+		{name: "delete json", args: []string{"delete", "lib", "--json"}, wantJSON: `"code": "CANCELLED"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := core.NewFileConfigStore(filepath.Join(dir, core.VendorDir))
+			if err := os.MkdirAll(filepath.Join(dir, core.VendorDir), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Save(types.VendorConfig{Vendors: []types.VendorSpec{{
+				Name:  "lib",
+				URL:   "https://github.com/owner/lib",
+				Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: "lib"}}}},
+			}}}); err != nil {
+				t.Fatal(err)
+			}
+
+			code, out := runMain(t, dir, tt.args...)
+			if code != core.ExitCancelled {
+				t.Errorf("exit code = %d, want %d (ExitCancelled)\n%s", code, core.ExitCancelled, out)
+			}
+			if tt.wantJSON != "" && !strings.Contains(out, tt.wantJSON) {
+				t.Errorf("stdout = %q, want it to contain %q", out, tt.wantJSON)
+			}
+
+			cfg, err := store.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cfg.Vendors) != 1 {
+				t.Errorf("vendors after declined removal = %d, want 1", len(cfg.Vendors))
+			}
+		})
+	}
+}
+
+// TestDispatch_ConfigFlagRootsInit verifies that the global --config flag
+// points init (and the commands after it) at an alternate vendor directory.
+func TestDispatch_ConfigFlagRootsInit(t *testing.T) {
+	dir := t.TempDir()
+
+	if code, out := runMain(t, dir, "init", "--config", "vendor2/", "--quiet"); code != core.ExitSuccess {
+		t.Fatalf("init --config exit code = %d\n%s", code, out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "vendor2", core.ConfigFile)); err != nil {
+		t.Errorf("vendor2/%s not created: %v", core.ConfigFile, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, core.VendorDir)); !os.IsNotExist(err) {
+		t.Errorf("%s should not be created, stat err = %v", core.VendorDir, err)
+	}
+
+	if code, out := runMain(t, dir, "--config=vendor2", "list", "--quiet"); code != core.ExitSuccess {
+		t.Errorf("list --config=vendor2 exit code = %d, want 0 (initialized)\n%s", code, out)
+	}
+	if code, _ := runMain(t, dir, "list", "--quiet"); code == core.ExitSuccess {
+		t.Error("list without --config should fail: the default vendor directory is not initialized")
+	}
+}
+
+// TestDispatch_AddNonInteractiveJSON verifies that add with --url/--ref/--from/--to
+// and --yes skips the wizard, saves the vendor, and reports it as JSON.
+func TestDispatch_AddNonInteractiveJSON(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	upstream := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(upstream, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(upstream, "src", "lib.go"), []byte("package lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(upstream, "src", "util.go"), []byte("package lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = upstream
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	dir := t.TempDir()
+	if code, out := runMain(t, dir, "init", "--quiet"); code != core.ExitSuccess {
+		t.Fatalf("init exit code = %d\n%s", code, out)
+	}
+
+	code, out := runMain(t, dir, "add",
+		"--url", "file://"+filepath.ToSlash(upstream), "--ref", "main",
+		"--from", "src", "--to=lib", "--name", "mylib", "--license", "MIT",
+		"--local", "--yes", "--json")
+	if code != core.ExitSuccess {
+		t.Fatalf("add exit code = %d\n%s", code, out)
+	}
+	var result core.JSONOutput
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not a single JSON document: %v\n%s", err, out)
+	}
+	if result.Status != "success" {
+		t.Errorf("status = %q, want success", result.Status)
+	}
+	vendor, _ := result.Data["vendor"].(map[string]interface{})
+	if vendor["name"] != "mylib" {
+		t.Errorf("data.vendor = %v, want name mylib", result.Data["vendor"])
+	}
+
+	cfg, err := core.NewFileConfigStore(filepath.Join(dir, core.VendorDir)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Vendors) != 1 || cfg.Vendors[0].Name != "mylib" || cfg.Vendors[0].Specs[0].Mapping[0].To != "lib" {
+		t.Errorf("saved vendors = %+v, want mylib mapping src -> lib", cfg.Vendors)
+	}
+
+	// --dir-per-file: each --from auto-names under --to via default_target
+	if code, out := runMain(t, dir, "add",
+		"--url", "file://"+filepath.ToSlash(upstream), "--ref", "main",
+		"--from", "src/lib.go", "--from", "src/util.go", "--to", "third_party/files", "--name", "files", "--license", "MIT",
+		"--dir-per-file", "--local", "--yes", "--quiet"); code != core.ExitSuccess {
+		t.Fatalf("add --dir-per-file exit code = %d\n%s", code, out)
+	}
+	cfg, err = core.NewFileConfigStore(filepath.Join(dir, core.VendorDir)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := cfg.Vendors[len(cfg.Vendors)-1]
+	if files.Name != "files" || files.Specs[0].DefaultTarget != "third_party/files" || len(files.Specs[0].Mapping) != 2 || files.Specs[0].Mapping[0].To != "" {
+		t.Errorf("saved vendor = %+v, want empty-To mappings under default_target third_party/files", files)
+	}
+	if code, out := runMain(t, dir, "add", "--url", "file://"+upstream, "--ref", "main", "--from", "a", "--from", "b", "--to", "x", "--yes"); code != core.ExitInvalidArguments {
+		t.Errorf("several --from without --dir-per-file exit code = %d, want %d\n%s", code, core.ExitInvalidArguments, out)
+	}
+
+	if code, out := runMain(t, dir, "add", "--url", "file://"+upstream, "--json"); code != core.ExitInvalidArguments {
+		t.Errorf("add with missing flags exit code = %d, want %d\n%s", code, core.ExitInvalidArguments, out)
+	}
+}
+
+// TestDispatch_ExcludeVendorNeedsValue verifies that --exclude-vendor without
+// a vendor name or glob is rejected as invalid arguments instead of ignored.
+func TestDispatch_ExcludeVendorNeedsValue(t *testing.T) {
+	for _, args := range [][]string{
+		{"status", "--exclude-vendor"},
+		{"status", "--exclude-vendor", "--offline"},
+		{"pull", "--exclude-vendor="},
+	} {
+		if code, out := runMain(t, t.TempDir(), append(args, "--quiet")...); code != core.ExitInvalidArguments {
+			t.Errorf("%v exit code = %d, want %d\n%s", args, code, core.ExitInvalidArguments, out)
+		}
+	}
+}
+
+// TestDispatch_CheckWithoutVendorPointsToGate verifies that "check" without a
+// vendor, which stays the per-vendor status command, names the repo-wide
+// "validate --check-only" gate in its usage error.
+func TestDispatch_CheckWithoutVendorPointsToGate(t *testing.T) {
+	code, out := runMain(t, t.TempDir(), "check", "--json")
+	if code != core.ExitInvalidArguments {
+		t.Errorf("exit code = %d, want %d\n%s", code, core.ExitInvalidArguments, out)
+	}
+	if !strings.Contains(out, "validate --check-only") {
+		t.Errorf("usage error does not mention validate --check-only:\n%s", out)
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
@@ -23,6 +24,12 @@ type AcceptResult struct {
 	ClearedFiles  []string `json:"cleared_files,omitempty"`  // Files whose accepted drift was cleared (empty for non-clear)
 }
 
+// BaselineResult holds the outcome of a baseline update.
+// BaselineResult lists the lock file_hashes entries rewritten to the on-disk hash.
+type BaselineResult struct {
+	UpdatedFiles []string `json:"updated_files"` // Files whose lock hash now matches disk, sorted
+}
+
 // AcceptService handles drift acceptance for vendored files.
 // AcceptService re-hashes local files and writes accepted_drift entries to the lockfile,
 // allowing verify/status to report those files as "accepted" rather than "modified".
@@ -62,7 +69,7 @@ func (s *AcceptService) Accept(opts AcceptOptions) (*AcceptResult, error) {
 		}
 	}
 	if lockEntry == nil {
-		return nil, fmt.Errorf("vendor %q not found in lockfile", opts.VendorName)
+		return nil, &vendorMissingError{msg: fmt.Sprintf("vendor %q not found in lockfile", opts.VendorName)}
 	}
 
 	if opts.Clear {
@@ -152,3 +159,57 @@ func (s *AcceptService) clearDrift(lock types.VendorLock, lockEntry *types.LockD
 
 	return result, nil
 }
+
+// UpdateBaseline makes the current on-disk content of modified files the new
+// expected state ("verify --baseline-update --accept <glob>"). For every external
+// lock entry, each file_hashes path matching one of patterns (MatchesExclude
+// globs) whose local hash differs is rewritten to the local hash, and any
+// accepted_drift entry for it is dropped. Unlike Accept, the upstream hash is
+// replaced rather than recorded alongside, so verify reports the file as
+// verified. Files that cannot be hashed (e.g. deleted) are left untouched.
+// Internal vendors are skipped: their drift is resolved by syncing source and
+// destination. Returns an error when no modified file matches.
+func (s *AcceptService) UpdateBaseline(patterns []string) (*BaselineResult, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("at least one --accept pattern is required")
+	}
+
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+
+	result := &BaselineResult{}
+	for i := range lock.Vendors {
+		entry := &lock.Vendors[i]
+		if entry.Source == SourceInternal {
+			continue
+		}
+		for path, expectedHash := range entry.FileHashes {
+			if !MatchesExclude(path, patterns) {
+				continue
+			}
+			actualHash, err := s.cache.ComputeFileChecksum(path)
+			if err != nil || actualHash == expectedHash {
+				continue
+			}
+			entry.FileHashes[path] = actualHash
+			delete(entry.AcceptedDrift, path)
+			if len(entry.AcceptedDrift) == 0 {
+				entry.AcceptedDrift = nil
+			}
+			result.UpdatedFiles = append(result.UpdatedFiles, path)
+		}
+	}
+
+	if len(result.UpdatedFiles) == 0 {
+		return nil, fmt.Errorf("no modified files match %v", patterns)
+	}
+	sort.Strings(result.UpdatedFiles)
+
+	if err := s.lockStore.Save(lock); err != nil {
+		return nil, fmt.Errorf("save lockfile: %w", err)
+	}
+
+	return result, nil
+}
@@ -460,3 +460,124 @@ func TestVerify_AcceptedDrift(t *testing.T) {
 		t.Errorf("expected result WARN (accepted drift), got %s", result.Summary.Result)
 	}
 }
+
+// baselineTestStores returns a lock store whose Save feeds later Loads and a
+// config mapping both lib/patched.go and lib/other.go for vendor mylib.
+func baselineTestStores(ctrl *gomock.Controller, initial types.VendorLock) (*MockConfigStore, *MockLockStore) {
+	config := NewMockConfigStore(ctrl)
+	config.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{{
+			Name: "mylib",
+			URL:  "https://example.com/mylib",
+			Specs: []types.BranchSpec{{
+				Ref: "main",
+				Mapping: []types.PathMapping{
+					{From: "src/patched.go", To: "lib/patched.go"},
+					{From: "src/other.go", To: "lib/other.go"},
+				},
+			}},
+		}},
+	}, nil).AnyTimes()
+
+	current := initial
+	lockStore := NewMockLockStore(ctrl)
+	lockStore.EXPECT().Load().DoAndReturn(func() (types.VendorLock, error) { return current, nil }).AnyTimes()
+	lockStore.EXPECT().Save(gomock.Any()).DoAndReturn(func(saved types.VendorLock) error {
+		current = saved
+		return nil
+	}).AnyTimes()
+	return config, lockStore
+}
+
+// TestUpdateBaseline_AcceptedFileVerifiesUnacceptedStillFails verifies that
+// UpdateBaseline rewrites the lock hash of a matching modified file so verify
+// reports it verified, while a modified file outside the globs keeps failing.
+func TestUpdateBaseline_AcceptedFileVerifiesUnacceptedStillFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config, lockStore := baselineTestStores(ctrl, acceptTestLock("mylib", "main", "abc123", map[string]string{
+		"lib/patched.go": "upstream_patched",
+		"lib/other.go":   "upstream_other",
+	}))
+	cache := newMockCacheStore()
+	cache.files["lib/patched.go"] = "local_patched"
+	cache.files["lib/other.go"] = "local_other"
+	fs := NewMockFileSystem(ctrl)
+	fs.EXPECT().Stat(gomock.Any()).Return(nil, os.ErrNotExist).AnyTimes()
+
+	result, err := NewAcceptService(lockStore, cache).UpdateBaseline([]string{"lib/patch*.go"})
+	if err != nil {
+		t.Fatalf("UpdateBaseline returned error: %v", err)
+	}
+	if len(result.UpdatedFiles) != 1 || result.UpdatedFiles[0] != "lib/patched.go" {
+		t.Fatalf("expected only lib/patched.go updated, got %v", result.UpdatedFiles)
+	}
+
+	saved, _ := lockStore.Load()
+	if got := saved.Vendors[0].FileHashes["lib/patched.go"]; got != "local_patched" {
+		t.Errorf("expected lock hash rewritten to local_patched, got %q", got)
+	}
+	if got := saved.Vendors[0].FileHashes["lib/other.go"]; got != "upstream_other" {
+		t.Errorf("unaccepted file hash changed to %q", got)
+	}
+
+	verify, err := NewVerifyService(config, lockStore, cache, fs, ".").Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	statuses := make(map[string]string)
+	for _, f := range verify.Files {
+		statuses[f.Path] = f.Status
+	}
+	if statuses["lib/patched.go"] != "verified" {
+		t.Errorf("lib/patched.go status = %q, want verified", statuses["lib/patched.go"])
+	}
+	if statuses["lib/other.go"] != "modified" {
+		t.Errorf("lib/other.go status = %q, want modified", statuses["lib/other.go"])
+	}
+	if verify.Summary.Result != "FAIL" {
+		t.Errorf("expected FAIL while lib/other.go is unaccepted, got %s", verify.Summary.Result)
+	}
+
+	// Accepting the remaining file makes verify pass
+	if _, err := NewAcceptService(lockStore, cache).UpdateBaseline([]string{"lib/**"}); err != nil {
+		t.Fatalf("second UpdateBaseline returned error: %v", err)
+	}
+	verify, err = NewVerifyService(config, lockStore, cache, fs, ".").Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if verify.Summary.Result != "PASS" {
+		t.Errorf("expected PASS after rebaselining every modified file, got %s", verify.Summary.Result)
+	}
+}
+
+// TestUpdateBaseline_DropsAcceptedDrift verifies that rebaselining a file
+// replaces its accepted_drift entry, and that no match is an error.
+func TestUpdateBaseline_DropsAcceptedDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	initial := acceptTestLock("mylib", "main", "abc123", map[string]string{"lib/patched.go": "upstream_patched"})
+	initial.Vendors[0].AcceptedDrift = map[string]string{"lib/patched.go": "local_patched"}
+	_, lockStore := baselineTestStores(ctrl, initial)
+	cache := newMockCacheStore()
+	cache.files["lib/patched.go"] = "local_patched"
+
+	svc := NewAcceptService(lockStore, cache)
+	if _, err := svc.UpdateBaseline([]string{"vendor/**"}); err == nil {
+		t.Error("expected error when no modified file matches")
+	}
+	if _, err := svc.UpdateBaseline([]string{"lib/patched.go"}); err != nil {
+		t.Fatalf("UpdateBaseline returned error: %v", err)
+	}
+
+	saved, _ := lockStore.Load()
+	if saved.Vendors[0].AcceptedDrift != nil {
+		t.Errorf("expected accepted_drift cleared, got %v", saved.Vendors[0].AcceptedDrift)
+	}
+	if _, err := svc.UpdateBaseline(nil); err == nil {
+		t.Error("expected error without patterns")
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// checkMappingSources fetches each of spec's refs and confirms every mapping's
+// from path exists in that tree (file or directory), so a mistyped path fails
+// add with a PathNotFoundError instead of surfacing at sync. Position
+// specifiers and blob/<ref>/ prefixes are stripped first. Internal vendors
+// and specs without mappings are skipped. Local URLs need local, as sync
+// needs --local.
+func (s *VendorSyncer) checkMappingSources(ctx context.Context, spec *types.VendorSpec, local bool) error {
+	if spec.Source == SourceInternal {
+		return nil
+	}
+	for _, branch := range spec.Specs {
+		if len(branch.Mapping) == 0 {
+			continue
+		}
+		if err := s.checkRefSources(ctx, spec, branch, local); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRefSources checks one ref's mapping sources against a fresh fetch.
+func (s *VendorSyncer) checkRefSources(ctx context.Context, spec *types.VendorSpec, branch types.BranchSpec, local bool) error {
+	urls, err := s.localGatedURLs(spec, local)
+	if err != nil {
+		return err
+	}
+	tempDir, err := s.fs.CreateTemp("", "git-vendor-add-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = s.fs.RemoveAll(tempDir) //nolint:errcheck // cleanup in defer
+	}()
+	if err := s.gitClient.Init(ctx, tempDir); err != nil {
+		return fmt.Errorf("failed to init temp repo: %w", err)
+	}
+	if _, err := FetchWithFallback(ctx, s.gitClient, s.fs, s.ui, s.logger, tempDir, urls, branch.Ref, refFetchDepth(branch)); err != nil {
+		return fmt.Errorf("fetch %s@%s to check mapping paths: %w", spec.Name, branch.Ref, err)
+	}
+
+	listings := make(map[string]map[string]bool) // parent dir -> entries ("name" or "name/")
+	for _, mapping := range branch.Mapping {
+		src, _, err := types.ParsePathPosition(cleanMappingSource(mapping.From, branch.Ref))
+		if err != nil {
+			return fmt.Errorf("invalid source position in mapping for %s: %w", spec.Name, err)
+		}
+		src = strings.Trim(path.Clean("/"+src), "/")
+		if src == "" {
+			continue // Repository root
+		}
+		dir, name := path.Split(src)
+		dir = strings.TrimSuffix(dir, "/")
+		entries, ok := listings[dir]
+		if !ok {
+			items, err := s.gitClient.ListTree(ctx, tempDir, FetchHead, dir)
+			if err != nil && dir == "" {
+				return fmt.Errorf("list %s@%s: %w", spec.Name, branch.Ref, err)
+			}
+			// A missing parent directory lists nothing; the path is reported below
+			entries = make(map[string]bool, len(items))
+			for _, item := range items {
+				entries[item] = true
+			}
+			listings[dir] = entries
+		}
+		if !entries[name] && !entries[name+"/"] {
+			return NewPathNotFoundError(mapping.From, spec.Name, branch.Ref)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// AncestryServiceInterface defines the contract for locked-commit reachability checks.
+// ctx is accepted for cancellation of network operations (git fetch).
+type AncestryServiceInterface interface {
+	// CheckAncestry verifies that each locked commit is still an ancestor of
+	// (or equal to) the current tip of its configured ref.
+	CheckAncestry(ctx context.Context) (*types.AncestryResult, error)
+}
+
+// Compile-time interface satisfaction check.
+var _ AncestryServiceInterface = (*AncestryService)(nil)
+
+// AncestryService detects orphaned lock entries: commits that are no longer
+// reachable from the ref they were locked from, typically after an upstream
+// force-push. Orphaned commits may be garbage-collected upstream, breaking
+// future syncs at the locked hash.
+type AncestryService struct {
+	configStore ConfigStore
+	lockStore   LockStore
+	gitClient   GitClient
+	fs          FileSystem
+	ui          UICallback
+	logger      *loggerSlot // Set by NewVendorSyncer; nil = no logging
+}
+
+// NewAncestryService creates a new AncestryService with the given dependencies.
+func NewAncestryService(
+	configStore ConfigStore,
+	lockStore LockStore,
+	gitClient GitClient,
+	fs FileSystem,
+	ui UICallback,
+) *AncestryService {
+	if ui == nil {
+		ui = &SilentUICallback{}
+	}
+	return &AncestryService{
+		configStore: configStore,
+		lockStore:   lockStore,
+		gitClient:   gitClient,
+		fs:          fs,
+		ui:          ui,
+	}
+}
+
+// CheckAncestry fetches the full history of each locked ref and runs
+// merge-base --is-ancestor for the locked commit. Internal vendors and lock
+// entries without a matching config vendor are skipped. A failure for one
+// entry is recorded as an AncestryError finding and does not abort the others.
+func (s *AncestryService) CheckAncestry(ctx context.Context) (*types.AncestryResult, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+
+	vendors := make(map[string]*types.VendorSpec, len(config.Vendors))
+	for i := range config.Vendors {
+		vendors[config.Vendors[i].Name] = &config.Vendors[i]
+	}
+
+	result := &types.AncestryResult{Entries: make([]types.AncestryEntry, 0, len(lock.Vendors))}
+	for _, lockEntry := range lock.Vendors {
+		vendor, ok := vendors[lockEntry.Name]
+		if !ok || vendor.Source == SourceInternal || lockEntry.Source == SourceInternal || lockEntry.CommitHash == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("ancestry check cancelled: %w", err)
+		}
+
+		entry := types.AncestryEntry{
+			Vendor:     lockEntry.Name,
+			Ref:        lockEntry.Ref,
+			CommitHash: lockEntry.CommitHash,
+		}
+		reachable, checkErr := s.isReachable(ctx, vendor, lockEntry.Ref, lockEntry.CommitHash)
+		switch {
+		case checkErr != nil:
+			entry.Status = types.AncestryError
+			entry.Error = checkErr.Error()
+			result.Summary.Errors++
+		case reachable:
+			entry.Status = types.AncestryReachable
+			result.Summary.Reachable++
+		default:
+			entry.Status = types.AncestryOrphaned
+			result.Summary.Orphaned++
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	result.Summary.Total = len(result.Entries)
+	result.Summary.Result = types.AuditResultPass
+	if result.Summary.Orphaned > 0 || result.Summary.Errors > 0 {
+		result.Summary.Result = types.AuditResultWarn
+	}
+	return result, nil
+}
+
+// isReachable reports whether commit is reachable from the upstream tip of ref.
+// A ref that is itself the locked commit (pinned SHA) is trivially reachable.
+func (s *AncestryService) isReachable(ctx context.Context, vendor *types.VendorSpec, ref, commit string) (bool, error) {
+	if len(ref) >= 7 && strings.HasPrefix(commit, ref) {
+		return true, nil
+	}
+
+	tempDir, err := s.fs.CreateTemp("", "ancestry-*")
+	if err != nil {
+		return false, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer func() { _ = s.fs.RemoveAll(tempDir) }() //nolint:errcheck // cleanup in defer
+
+	if err := s.gitClient.Init(ctx, tempDir); err != nil {
+		return false, fmt.Errorf("init temp repo: %w", err)
+	}
+	// Depth 0 = full history: a shallow fetch would report every older commit as orphaned
+	if _, err := FetchWithFallback(ctx, s.gitClient, s.fs, s.ui, s.logger, tempDir, ResolveVendorURLs(vendor), ref, 0); err != nil {
+		return false, fmt.Errorf("fetch ref '%s': %w", ref, err)
+	}
+
+	reachable, err := s.gitClient.IsAncestor(ctx, tempDir, commit, FetchHead)
+	if err != nil {
+		return false, fmt.Errorf("check ancestry of %s: %w", commit, err)
+	}
+	return reachable, nil
+}
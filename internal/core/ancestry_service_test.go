@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ============================================================================
+// AncestryService Tests - audit --ancestry
+// ============================================================================
+
+const (
+	ancestryTestHash = "abc1234567890abcdef1234567890abcdef12345"
+	ancestryTestDir  = "/tmp/ancestry-test"
+)
+
+// expectAncestryFetch sets up the temp repo + full-history fetch for one lock entry.
+func expectAncestryFetch(git *MockGitClient, fs *MockFileSystem, url, ref string) {
+	fs.EXPECT().CreateTemp("", "ancestry-*").Return(ancestryTestDir, nil)
+	fs.EXPECT().RemoveAll(ancestryTestDir).Return(nil)
+	git.EXPECT().Init(gomock.Any(), ancestryTestDir).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), ancestryTestDir, "origin", url).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), ancestryTestDir, "origin", 0, ref).Return(nil)
+}
+
+func TestAncestryService_ReachableCommit(t *testing.T) {
+	ctrl, git, fs, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{createTestLockEntry("test-vendor", "main", ancestryTestHash)}}, nil)
+	expectAncestryFetch(git, fs, "https://github.com/owner/repo", "main")
+	git.EXPECT().IsAncestor(gomock.Any(), ancestryTestDir, ancestryTestHash, FetchHead).Return(true, nil)
+
+	svc := NewAncestryService(config, lock, git, fs, nil)
+	result, err := svc.CheckAncestry(context.Background())
+	if err != nil {
+		t.Fatalf("CheckAncestry() error = %v", err)
+	}
+
+	if result.Summary.Result != types.AuditResultPass {
+		t.Errorf("Result = %q, want PASS", result.Summary.Result)
+	}
+	if result.Summary.Reachable != 1 || result.Summary.Orphaned != 0 {
+		t.Errorf("Summary = %+v, want 1 reachable", result.Summary)
+	}
+	if len(result.Entries) != 1 || result.Entries[0].Status != types.AncestryReachable {
+		t.Errorf("Entries = %+v, want one reachable entry", result.Entries)
+	}
+}
+
+func TestAncestryService_OrphanedCommit(t *testing.T) {
+	ctrl, git, fs, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{createTestLockEntry("test-vendor", "main", ancestryTestHash)}}, nil)
+	expectAncestryFetch(git, fs, "https://github.com/owner/repo", "main")
+	git.EXPECT().IsAncestor(gomock.Any(), ancestryTestDir, ancestryTestHash, FetchHead).Return(false, nil)
+
+	svc := NewAncestryService(config, lock, git, fs, nil)
+	result, err := svc.CheckAncestry(context.Background())
+	if err != nil {
+		t.Fatalf("CheckAncestry() error = %v", err)
+	}
+
+	if result.Summary.Result != types.AuditResultWarn {
+		t.Errorf("Result = %q, want WARN", result.Summary.Result)
+	}
+	if result.Summary.Orphaned != 1 {
+		t.Errorf("Orphaned = %d, want 1", result.Summary.Orphaned)
+	}
+	entry := result.Entries[0]
+	if entry.Status != types.AncestryOrphaned || entry.Vendor != "test-vendor" || entry.CommitHash != ancestryTestHash {
+		t.Errorf("entry = %+v, want orphaned test-vendor@main", entry)
+	}
+}
+
+func TestAncestryService_FetchErrorRecordedPerEntry(t *testing.T) {
+	ctrl, git, fs, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{createTestLockEntry("test-vendor", "main", ancestryTestHash)}}, nil)
+	fs.EXPECT().CreateTemp("", "ancestry-*").Return(ancestryTestDir, nil)
+	fs.EXPECT().RemoveAll(ancestryTestDir).Return(nil)
+	git.EXPECT().Init(gomock.Any(), ancestryTestDir).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), ancestryTestDir, "origin", gomock.Any()).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), ancestryTestDir, "origin", 0, "main").Return(errors.New("network down"))
+
+	svc := NewAncestryService(config, lock, git, fs, nil)
+	result, err := svc.CheckAncestry(context.Background())
+	if err != nil {
+		t.Fatalf("CheckAncestry() error = %v", err)
+	}
+
+	if result.Summary.Errors != 1 || result.Summary.Result != types.AuditResultWarn {
+		t.Errorf("Summary = %+v, want 1 error and WARN", result.Summary)
+	}
+	if !strings.Contains(result.Entries[0].Error, "network down") {
+		t.Errorf("Error = %q, want fetch failure detail", result.Entries[0].Error)
+	}
+}
+
+func TestAncestryService_SkipsInternalAndPinnedRefs(t *testing.T) {
+	ctrl, git, fs, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	internal := types.VendorSpec{Name: "shared", Source: SourceInternal, Specs: []types.BranchSpec{{Ref: RefLocal}}}
+	pinned := createTestVendorSpec("pinned", "https://github.com/owner/pinned", ancestryTestHash[:12])
+	config.EXPECT().Load().Return(createTestConfig(internal, pinned), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "shared", Ref: RefLocal, Source: SourceInternal, CommitHash: ancestryTestHash},
+		createTestLockEntry("pinned", ancestryTestHash[:12], ancestryTestHash),
+	}}, nil)
+	// No git or fs expectations: pinned SHAs need no fetch, internal vendors are skipped
+
+	svc := NewAncestryService(config, lock, git, fs, nil)
+	result, err := svc.CheckAncestry(context.Background())
+	if err != nil {
+		t.Fatalf("CheckAncestry() error = %v", err)
+	}
+
+	if result.Summary.Total != 1 || result.Summary.Reachable != 1 {
+		t.Errorf("Summary = %+v, want only the pinned entry, reachable", result.Summary)
+	}
+	if result.Entries[0].Vendor != "pinned" {
+		t.Errorf("Entries[0].Vendor = %q, want pinned", result.Entries[0].Vendor)
+	}
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// attributionRule separates vendor sections in the text attribution report.
+var attributionRule = strings.Repeat("=", 80)
+
+// AttributionReportGenerator builds a consolidated third-party notices report
+// from vendor.lock and the license files captured on sync.
+type AttributionReportGenerator struct {
+	lockStore   LockStore
+	configStore ConfigStore
+}
+
+// NewAttributionReportGenerator creates a new AttributionReportGenerator
+func NewAttributionReportGenerator(lockStore LockStore, configStore ConfigStore) *AttributionReportGenerator {
+	return &AttributionReportGenerator{
+		lockStore:   lockStore,
+		configStore: configStore,
+	}
+}
+
+// Build returns one entry per external lock entry, in lockfile order.
+// Each entry reads the captured files recorded in LockDetails.LicenseFiles,
+// falling back to LicensePath for locks written before license_files existed.
+// Files missing on disk are left out of the entry rather than failing the
+// report. Internal vendors are skipped: their content is not third-party.
+func (g *AttributionReportGenerator) Build() (*types.AttributionReport, error) {
+	lock, err := g.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+	config, err := g.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	specs := make(map[string]types.VendorSpec, len(config.Vendors))
+	for _, v := range config.Vendors {
+		specs[v.Name] = v
+	}
+
+	report := &types.AttributionReport{Vendors: []types.AttributionEntry{}}
+	for i := range lock.Vendors {
+		l := &lock.Vendors[i]
+		if l.Source == SourceInternal {
+			continue
+		}
+
+		spec := specs[l.Name]
+		license := l.LicenseSPDX
+		if license == "" {
+			license = ResolveVendorLicense(spec)
+		}
+
+		entry := types.AttributionEntry{
+			Name:       l.Name,
+			URL:        spec.URL,
+			License:    license,
+			Ref:        l.Ref,
+			CommitHash: l.CommitHash,
+			Files:      []types.AttributionFile{},
+		}
+
+		paths := l.LicenseFiles
+		if len(paths) == 0 && l.LicensePath != "" {
+			paths = []string{l.LicensePath}
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read license file for %s: %w", l.Name, err)
+			}
+			entry.Files = append(entry.Files, types.AttributionFile{Path: path, Text: string(data)})
+		}
+
+		report.Vendors = append(report.Vendors, entry)
+	}
+
+	return report, nil
+}
+
+// Write writes the report built by Build to w as plain text: a header per
+// vendor (name, URL, SPDX id, ref and locked commit) followed by the
+// concatenated license and notice texts, suitable for a THIRD_PARTY_NOTICES file.
+func (g *AttributionReportGenerator) Write(w io.Writer) error {
+	report, err := g.Build()
+	if err != nil {
+		return err
+	}
+	return writeAttributionReport(w, report)
+}
+
+// writeAttributionReport renders report as the plain-text notices document.
+func writeAttributionReport(w io.Writer, report *types.AttributionReport) error {
+	var b strings.Builder
+	b.WriteString("THIRD-PARTY SOFTWARE NOTICES\n\n")
+	b.WriteString("This file lists the third-party code vendored with git-vendor and its license terms.\n")
+
+	for _, e := range report.Vendors {
+		license := e.License
+		if license == "" {
+			license = "UNKNOWN"
+		}
+		url := e.URL
+		if url == "" {
+			url = "(not in vendor.yml)"
+		}
+
+		fmt.Fprintf(&b, "\n%s\n%s\n", attributionRule, e.Name)
+		fmt.Fprintf(&b, "  URL:     %s\n", url)
+		fmt.Fprintf(&b, "  License: %s\n", license)
+		fmt.Fprintf(&b, "  Ref:     %s\n", e.Ref)
+		fmt.Fprintf(&b, "  Commit:  %s\n", e.CommitHash)
+		fmt.Fprintf(&b, "%s\n\n", attributionRule)
+
+		if len(e.Files) == 0 {
+			b.WriteString("No license file was captured for this vendor.\n")
+			continue
+		}
+		for i, f := range e.Files {
+			if i > 0 {
+				fmt.Fprintf(&b, "\n--- %s ---\n\n", f.Path)
+			}
+			b.WriteString(f.Text)
+			if !strings.HasSuffix(f.Text, "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
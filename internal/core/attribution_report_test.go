@@ -0,0 +1,111 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestAttributionReport_ContainsEveryVendorAndLicenseText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	licenseDir := t.TempDir()
+	writeLicense := func(name, text string) string {
+		path := filepath.Join(licenseDir, name)
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	alphaLicense := writeLicense("alpha.txt", "MIT License\nCopyright (c) Alpha Authors\n")
+	betaLicense := writeLicense("beta.txt", "Apache License\nVersion 2.0, January 2004\n")
+	betaNotice := writeLicense("beta.NOTICE", "Beta\nCopyright 2024 The Beta Project\n")
+
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{Name: "alpha", URL: "https://github.com/owner/alpha", License: "MIT"},
+			{Name: "beta", URL: "https://github.com/owner/beta", License: "Apache-2.0"},
+			{Name: "shared", URL: "", Source: SourceInternal},
+		},
+	}, nil).AnyTimes()
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{
+			{Name: "alpha", Ref: "main", CommitHash: "aaaa111", LicensePath: alphaLicense, LicenseSPDX: "MIT"},
+			{Name: "beta", Ref: "v2.0.0", CommitHash: "bbbb222", LicensePath: betaLicense,
+				LicenseFiles: []string{betaLicense, betaNotice}},
+			{Name: "shared", Ref: RefLocal, Source: SourceInternal},
+		},
+	}, nil).AnyTimes()
+
+	generator := NewAttributionReportGenerator(lockStore, configStore)
+	var buf bytes.Buffer
+	if err := generator.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"alpha", "https://github.com/owner/alpha", "MIT", "aaaa111", "Copyright (c) Alpha Authors",
+		"beta", "https://github.com/owner/beta", "Apache-2.0", "bbbb222", "Version 2.0, January 2004",
+		"Copyright 2024 The Beta Project",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "shared") {
+		t.Errorf("internal vendor should not appear in third-party notices:\n%s", out)
+	}
+
+	report, err := generator.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(report.Vendors) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(report.Vendors))
+	}
+	beta := report.Vendors[1]
+	if beta.License != "Apache-2.0" {
+		t.Errorf("beta license = %q, want the vendor.yml license when the lock has none", beta.License)
+	}
+	if len(beta.Files) != 2 || beta.Files[1].Path != betaNotice {
+		t.Errorf("beta files = %+v, want license then NOTICE", beta.Files)
+	}
+}
+
+func TestAttributionReport_MissingLicenseFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{{Name: "nolicense", URL: "https://github.com/owner/nolicense"}},
+	}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{
+			{Name: "nolicense", Ref: "main", CommitHash: "cccc333", LicensePath: filepath.Join(t.TempDir(), "nolicense.txt")},
+		},
+	}, nil)
+
+	var buf bytes.Buffer
+	if err := NewAttributionReportGenerator(lockStore, configStore).Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "nolicense") || !strings.Contains(out, "UNKNOWN") {
+		t.Errorf("expected header with UNKNOWN license:\n%s", out)
+	}
+	if !strings.Contains(out, "No license file was captured") {
+		t.Errorf("expected missing-license note:\n%s", out)
+	}
+}
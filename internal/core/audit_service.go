@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -14,6 +15,8 @@ type AuditOptions struct {
 	SkipScan    bool   // Skip vulnerability scan
 	SkipLicense bool   // Skip license compliance check
 	SkipDrift   bool   // Skip drift detection
+	Ancestry    bool   // Run locked-commit reachability check (opt-in: fetches full history per ref)
+	Inventory   bool   // Run the lock inventory (opt-in: refs, commits, licenses, orphaned entries)
 
 	ScanFailOn        string // Severity threshold for scan (critical|high|medium|low)
 	LicenseFailOn     string // License fail level: "deny" (default) or "warn"
@@ -22,7 +25,7 @@ type AuditOptions struct {
 
 // AuditServiceInterface defines the contract for the unified audit command.
 type AuditServiceInterface interface {
-	// Audit runs all enabled sub-checks (verify, scan, license, drift) and
+	// Audit runs all enabled sub-checks (verify, scan, license, drift, ancestry) and
 	// returns a combined AuditResult. A failed sub-check does NOT abort the others.
 	// ctx controls cancellation for network-dependent operations (scan, drift).
 	Audit(ctx context.Context, opts AuditOptions) (*types.AuditResult, error)
@@ -37,6 +40,7 @@ type AuditService struct {
 	verifyService VerifyServiceInterface
 	vulnScanner   VulnScannerInterface
 	driftService  DriftServiceInterface
+	ancestry      AncestryServiceInterface
 	configStore   ConfigStore
 	lockStore     LockStore
 }
@@ -46,6 +50,7 @@ func NewAuditService(
 	verifyService VerifyServiceInterface,
 	vulnScanner VulnScannerInterface,
 	driftService DriftServiceInterface,
+	ancestry AncestryServiceInterface,
 	configStore ConfigStore,
 	lockStore LockStore,
 ) *AuditService {
@@ -53,6 +58,7 @@ func NewAuditService(
 		verifyService: verifyService,
 		vulnScanner:   vulnScanner,
 		driftService:  driftService,
+		ancestry:      ancestry,
 		configStore:   configStore,
 		lockStore:     lockStore,
 	}
@@ -156,6 +162,41 @@ func (s *AuditService) Audit(ctx context.Context, opts AuditOptions) (*types.Aud
 		}
 	}
 
+	// Ancestry sub-check (opt-in)
+	if opts.Ancestry {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("audit cancelled: %w", err)
+		}
+		checks++
+		ancestryResult, err := s.ancestry.CheckAncestry(ctx)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("ancestry: %s", err.Error()))
+		} else {
+			result.Ancestry = ancestryResult
+			if ancestryResult.Summary.Result == types.AuditResultPass {
+				passed++
+			} else { // WARN: orphaned commits or per-entry errors
+				warnings++
+			}
+		}
+	}
+
+	// Inventory sub-check (opt-in): local lock/config cross-reference, needs both stores
+	if opts.Inventory && s.lockStore != nil && s.configStore != nil {
+		checks++
+		inventoryResult, err := s.runInventory()
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("inventory: %s", err.Error()))
+		} else {
+			result.Inventory = inventoryResult
+			if inventoryResult.Summary.Result == types.AuditResultPass {
+				passed++
+			} else { // WARN: missing licenses or entries not in config
+				warnings++
+			}
+		}
+	}
+
 	// Compute combined result: FAIL > WARN > PASS
 	overallResult := types.AuditResultPass
 	if warnings > 0 {
@@ -198,6 +239,67 @@ func (s *AuditService) runLicenseCheck(opts AuditOptions) (*types.LicenseReportR
 	return svc.GenerateReport(failOn)
 }
 
+// runInventory lists every lock entry in lockfile order. External entries whose
+// license file is unset or missing on disk are flagged InventoryMissingLicense;
+// entries whose vendor is absent from vendor.yml are flagged InventoryNotInConfig.
+// Internal vendors carry no license and are never flagged for one.
+func (s *AuditService) runInventory() (*types.InventoryResult, error) {
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	configured := make(map[string]bool, len(config.Vendors))
+	for _, v := range config.Vendors {
+		configured[v.Name] = true
+	}
+
+	result := &types.InventoryResult{Entries: []types.InventoryEntry{}}
+	for i := range lock.Vendors {
+		l := &lock.Vendors[i]
+		entry := types.InventoryEntry{
+			Vendor:      l.Name,
+			Ref:         l.Ref,
+			CommitHash:  l.CommitHash,
+			License:     l.LicenseSPDX,
+			LicensePath: l.LicensePath,
+			Updated:     l.Updated,
+			Synced:      l.LastSyncedAt,
+		}
+		if l.Source != SourceInternal {
+			if l.LicensePath == "" {
+				entry.Issues = append(entry.Issues, types.InventoryMissingLicense)
+			} else if _, err := os.Stat(l.LicensePath); err != nil {
+				entry.Issues = append(entry.Issues, types.InventoryMissingLicense)
+			}
+		}
+		if !configured[l.Name] {
+			entry.Issues = append(entry.Issues, types.InventoryNotInConfig)
+		}
+
+		for _, issue := range entry.Issues {
+			switch issue {
+			case types.InventoryMissingLicense:
+				result.Summary.MissingLicense++
+			case types.InventoryNotInConfig:
+				result.Summary.NotInConfig++
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	result.Summary.Total = len(result.Entries)
+	result.Summary.Result = types.AuditResultPass
+	if result.Summary.MissingLicense > 0 || result.Summary.NotInConfig > 0 {
+		result.Summary.Result = types.AuditResultWarn
+	}
+	return result, nil
+}
+
 // FormatAuditTable formats an AuditResult as a human-readable table string.
 func FormatAuditTable(result *types.AuditResult) string {
 	var out string
@@ -239,6 +341,38 @@ func FormatAuditTable(result *types.AuditResult) string {
 		out += formatCheckLine("Drift", "SKIP", "skipped")
 	}
 
+	// Ancestry is opt-in, so the row appears only when the check ran or errored
+	if result.Ancestry != nil {
+		out += formatCheckLine("Ancestry", result.Ancestry.Summary.Result,
+			ancestryDetail(result.Ancestry))
+		for _, e := range result.Ancestry.Entries {
+			switch e.Status {
+			case types.AncestryOrphaned:
+				hash := e.CommitHash
+				if len(hash) > 7 {
+					hash = hash[:7]
+				}
+				out += fmt.Sprintf("    %s@%s: locked commit %s is not reachable from %s\n",
+					e.Vendor, e.Ref, hash, e.Ref)
+			case types.AncestryError:
+				out += fmt.Sprintf("    %s@%s: %s\n", e.Vendor, e.Ref, e.Error)
+			}
+		}
+	} else if !isSkipped(result, "ancestry") {
+		out += formatCheckLine("Ancestry", "ERROR", "could not complete")
+	}
+
+	// Inventory is opt-in too
+	if result.Inventory != nil {
+		out += formatCheckLine("Inventory", result.Inventory.Summary.Result,
+			inventoryDetail(result.Inventory))
+		for _, e := range result.Inventory.Entries {
+			out += formatInventoryEntry(e)
+		}
+	} else if !isSkipped(result, "inventory") {
+		out += formatCheckLine("Inventory", "ERROR", "could not complete")
+	}
+
 	out += fmt.Sprintf("\nResult: %s\n", result.Summary.Result)
 
 	if len(result.Summary.Errors) > 0 {
@@ -309,6 +443,67 @@ func driftDetail(r *types.DriftResult) string {
 		Pluralize(r.Summary.TotalDependencies, "vendor", "vendors"))
 }
 
+func ancestryDetail(r *types.AncestryResult) string {
+	if r.Summary.Orphaned > 0 {
+		return fmt.Sprintf("%s, %d orphaned",
+			Pluralize(r.Summary.Total, "locked ref", "locked refs"),
+			r.Summary.Orphaned)
+	}
+	if r.Summary.Errors > 0 {
+		return fmt.Sprintf("%s, %d could not be checked",
+			Pluralize(r.Summary.Total, "locked ref", "locked refs"),
+			r.Summary.Errors)
+	}
+	return fmt.Sprintf("%s, all reachable",
+		Pluralize(r.Summary.Total, "locked ref", "locked refs"))
+}
+
+func inventoryDetail(r *types.InventoryResult) string {
+	detail := Pluralize(r.Summary.Total, "locked ref", "locked refs")
+	if r.Summary.MissingLicense > 0 {
+		detail += fmt.Sprintf(", %d missing license", r.Summary.MissingLicense)
+	}
+	if r.Summary.NotInConfig > 0 {
+		detail += fmt.Sprintf(", %d not in config", r.Summary.NotInConfig)
+	}
+	return detail
+}
+
+// formatInventoryEntry renders one inventory entry with its flagged issues.
+func formatInventoryEntry(e types.InventoryEntry) string {
+	hash := e.CommitHash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	license := e.License
+	if license == "" {
+		license = "-"
+	}
+	licensePath := e.LicensePath
+	if licensePath == "" {
+		licensePath = "-"
+	}
+	updated := e.Updated
+	if updated == "" {
+		updated = "-"
+	}
+	synced := e.Synced
+	if synced == "" {
+		synced = "-"
+	}
+
+	out := fmt.Sprintf("    %s@%s  %s  %s  %s  updated %s  synced %s\n", e.Vendor, e.Ref, hash, license, licensePath, updated, synced)
+	for _, issue := range e.Issues {
+		switch issue {
+		case types.InventoryMissingLicense:
+			out += "      ! no license file captured; re-sync with 'git-vendor pull --locked'\n"
+		case types.InventoryNotInConfig:
+			out += "      ! vendor not in vendor.yml; 'git-vendor pull' drops its lock entry\n"
+		}
+	}
+	return out
+}
+
 // driftResultToPassFail maps drift-specific result strings to PASS/FAIL for audit display.
 func driftResultToPassFail(driftResult string) string {
 	if driftResult == types.DriftResultClean {
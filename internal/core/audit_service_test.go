@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -24,6 +26,10 @@ func (s *stubAuditVerifyService) Verify(_ context.Context) (*types.VerifyResult,
 	return s.result, s.err
 }
 
+func (s *stubAuditVerifyService) VerifyWithOptions(ctx context.Context, _ VerifyOptions) (*types.VerifyResult, error) {
+	return s.Verify(ctx)
+}
+
 // stubAuditVulnScanner implements VulnScannerInterface for audit tests.
 type stubAuditVulnScanner struct {
 	result *types.ScanResult
@@ -52,6 +58,18 @@ func (s *stubAuditDriftService) Drift(_ context.Context, _ DriftOptions) (*types
 	return s.result, s.err
 }
 
+// stubAuditAncestryService implements AncestryServiceInterface for audit tests.
+type stubAuditAncestryService struct {
+	result *types.AncestryResult
+	err    error
+	called bool
+}
+
+func (s *stubAuditAncestryService) CheckAncestry(_ context.Context) (*types.AncestryResult, error) {
+	s.called = true
+	return s.result, s.err
+}
+
 // ============================================================================
 // Helper constructors
 // ============================================================================
@@ -148,7 +166,7 @@ func newTestAuditService(
 	scanner VulnScannerInterface,
 	drift DriftServiceInterface,
 ) *AuditService {
-	return NewAuditService(verify, scanner, drift, nil, nil)
+	return NewAuditService(verify, scanner, drift, nil, nil, nil)
 }
 
 // ============================================================================
@@ -536,3 +554,127 @@ func TestAuditService_VerifyWarnResult(t *testing.T) {
 		t.Errorf("warnings = %d, want 1", result.Summary.Warnings)
 	}
 }
+
+func TestAuditService_AncestryOptIn(t *testing.T) {
+	ancestry := &stubAuditAncestryService{result: &types.AncestryResult{
+		Entries: []types.AncestryEntry{
+			{Vendor: "lib", Ref: "main", CommitHash: "abc1234567890", Status: types.AncestryOrphaned},
+		},
+		Summary: types.AncestrySummary{Total: 1, Orphaned: 1, Result: types.AuditResultWarn},
+	}}
+	newSvc := func() *AuditService {
+		return NewAuditService(
+			&stubAuditVerifyService{result: passingVerifyResult()},
+			&stubAuditVulnScanner{result: passingScanResult()},
+			&stubAuditDriftService{result: cleanDriftResult()},
+			ancestry, nil, nil)
+	}
+
+	// Default: ancestry does not run (it fetches full history per ref)
+	result, err := newSvc().Audit(context.Background(), AuditOptions{SkipLicense: true})
+	if err != nil {
+		t.Fatalf("Audit() unexpected error: %v", err)
+	}
+	if ancestry.called || result.Ancestry != nil {
+		t.Error("ancestry check should not run without AuditOptions.Ancestry")
+	}
+	if contains(FormatAuditTable(result), "Ancestry") {
+		t.Error("table should omit the Ancestry row when the check was not requested")
+	}
+
+	result, err = newSvc().Audit(context.Background(), AuditOptions{SkipLicense: true, Ancestry: true})
+	if err != nil {
+		t.Fatalf("Audit() unexpected error: %v", err)
+	}
+	if !ancestry.called || result.Ancestry == nil {
+		t.Fatal("ancestry check should run with AuditOptions.Ancestry")
+	}
+	if result.Summary.Checks != 4 || result.Summary.Warnings != 1 {
+		t.Errorf("summary = %+v, want 4 checks with 1 warning", result.Summary)
+	}
+	if result.Summary.Result != types.AuditResultWarn {
+		t.Errorf("result = %q, want WARN for orphaned commit", result.Summary.Result)
+	}
+
+	output := FormatAuditTable(result)
+	if !contains(output, "1 orphaned") {
+		t.Errorf("output missing orphaned count:\n%s", output)
+	}
+	if !contains(output, "lib@main: locked commit abc1234 is not reachable from main") {
+		t.Errorf("output missing orphaned entry detail:\n%s", output)
+	}
+}
+
+func TestAuditService_InventoryFlagsMissingLicenseAndOrphanedEntries(t *testing.T) {
+	licensePath := filepath.Join(t.TempDir(), "good.txt")
+	if err := os.WriteFile(licensePath, []byte("MIT License\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configStore := &stubConfigStore{config: types.VendorConfig{Vendors: []types.VendorSpec{
+		{Name: "good"}, {Name: "unlicensed"}, {Name: "shared", Source: SourceInternal},
+	}}}
+	lockStore := &stubLockStore{lock: types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "good", Ref: "v1.0.0", CommitHash: "aaaa1111bbbb", LicenseSPDX: "MIT", LicensePath: licensePath, Updated: "2025-01-02T03:04:05Z"},
+		{Name: "unlicensed", Ref: "main", CommitHash: "cccc2222dddd", LicensePath: filepath.Join(t.TempDir(), "missing.txt"), Updated: "2025-01-02T03:04:05Z"},
+		{Name: "shared", Ref: RefLocal, Source: SourceInternal},
+		{Name: "removed", Ref: "main", CommitHash: "eeee3333ffff", LicensePath: licensePath},
+	}}}
+
+	svc := NewAuditService(
+		&stubAuditVerifyService{result: passingVerifyResult()},
+		&stubAuditVulnScanner{result: passingScanResult()},
+		&stubAuditDriftService{result: cleanDriftResult()},
+		nil, configStore, lockStore)
+	result, err := svc.Audit(context.Background(), AuditOptions{SkipLicense: true, Inventory: true})
+	if err != nil {
+		t.Fatalf("Audit() unexpected error: %v", err)
+	}
+
+	inv := result.Inventory
+	if inv == nil {
+		t.Fatal("expected inventory result")
+	}
+	if inv.Summary.Total != 4 || inv.Summary.MissingLicense != 1 || inv.Summary.NotInConfig != 1 {
+		t.Errorf("inventory summary = %+v, want 4 total, 1 missing license, 1 not in config", inv.Summary)
+	}
+	issues := make(map[string][]string)
+	for _, e := range inv.Entries {
+		issues[e.Vendor] = e.Issues
+	}
+	if len(issues["good"]) != 0 || len(issues["shared"]) != 0 {
+		t.Errorf("unexpected issues: good=%v shared=%v", issues["good"], issues["shared"])
+	}
+	if len(issues["unlicensed"]) != 1 || issues["unlicensed"][0] != types.InventoryMissingLicense {
+		t.Errorf("unlicensed issues = %v, want missing_license", issues["unlicensed"])
+	}
+	if len(issues["removed"]) != 1 || issues["removed"][0] != types.InventoryNotInConfig {
+		t.Errorf("removed issues = %v, want not_in_config", issues["removed"])
+	}
+	if result.Summary.Checks != 4 || result.Summary.Result != types.AuditResultWarn {
+		t.Errorf("summary = %+v, want 4 checks and WARN", result.Summary)
+	}
+
+	output := FormatAuditTable(result)
+	for _, want := range []string{
+		"Inventory", "4 locked refs, 1 missing license, 1 not in config",
+		"good@v1.0.0  aaaa111  MIT  " + licensePath + "  updated 2025-01-02T03:04:05Z",
+		"no license file captured", "not in vendor.yml",
+	} {
+		if !contains(output, want) {
+			t.Errorf("table missing %q:\n%s", want, output)
+		}
+	}
+
+	// Without --inventory the section is left out and the result is unaffected
+	result, err = svc.Audit(context.Background(), AuditOptions{SkipLicense: true})
+	if err != nil {
+		t.Fatalf("Audit() unexpected error: %v", err)
+	}
+	if result.Inventory != nil || result.Summary.Checks != 3 || result.Summary.Result != types.AuditResultPass {
+		t.Errorf("inventory ran without Inventory: %+v", result.Summary)
+	}
+	if output := FormatAuditTable(result); contains(output, "Inventory") {
+		t.Errorf("table shows Inventory without Inventory:\n%s", output)
+	}
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// BumpOptions configures a bump operation.
+type BumpOptions struct {
+	VendorName string // Exact vendor name
+	Ref        string // New ref to track
+	// From selects the spec to move by its current ref. Required only when
+	// the vendor tracks more than one ref.
+	From   string
+	NoSync bool // Only rewrite vendor.yml; leave the lock and files untouched
+	Local  bool // Allow file:// and local path vendor URLs when re-syncing
+}
+
+// BumpResult describes the spec ref a bump rewrote.
+type BumpResult struct {
+	Vendor string `json:"vendor"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	// Commit is the commit the new ref resolved to: the locked commit after
+	// a re-sync, or the ls-remote hash with NoSync.
+	Commit string `json:"commit,omitempty"`
+	Synced bool   `json:"synced"`
+}
+
+// BumpVendor moves one spec of opts.VendorName to opts.Ref. The new ref is
+// checked with ls-remote against the vendor URL and its mirrors before
+// vendor.yml is touched; full commit hashes can't be listed by ls-remote, so
+// they are left for the fetch to verify. Unless opts.NoSync, the vendor is
+// then pulled alone, replacing its lock entry for the old ref. Bumping a
+// pinned spec clears the pin.
+func (s *VendorSyncer) BumpVendor(ctx context.Context, opts BumpOptions) (*BumpResult, error) {
+	if opts.Ref == "" {
+		return nil, fmt.Errorf("a new ref is required")
+	}
+	cfg, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	idx := FindVendorIndex(cfg.Vendors, opts.VendorName)
+	if idx < 0 {
+		return nil, NewVendorNotFoundError(opts.VendorName)
+	}
+	v := &cfg.Vendors[idx]
+	if v.Source == SourceInternal {
+		return nil, fmt.Errorf("internal vendor '%s' has no remote refs to bump", v.Name)
+	}
+
+	spec, err := bumpTarget(v, opts.From)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Ref == opts.Ref {
+		return nil, fmt.Errorf("vendor '%s' already tracks %s", v.Name, opts.Ref)
+	}
+	for _, other := range v.Specs {
+		if other.Ref == opts.Ref {
+			return nil, fmt.Errorf("vendor '%s' already has a spec for %s", v.Name, opts.Ref)
+		}
+	}
+
+	result := &BumpResult{Vendor: v.Name, From: spec.Ref, To: opts.Ref}
+	if !isFullCommitHash(opts.Ref) {
+		hash, err := lsRemoteWithFallback(ctx, s.gitClient, ResolveVendorURLs(v), opts.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("ref '%s' not found for vendor '%s': %w", opts.Ref, v.Name, err)
+		}
+		result.Commit = hash
+	}
+
+	spec.Ref = opts.Ref
+	spec.Pinned = false
+	spec.PinnedFrom = ""
+	if err := s.configStore.Save(cfg); err != nil {
+		return nil, fmt.Errorf("save config: %w", err)
+	}
+	if opts.NoSync {
+		return result, nil
+	}
+
+	if _, err := s.PullVendors(ctx, PullOptions{VendorName: v.Name, Local: opts.Local}); err != nil {
+		return nil, err
+	}
+	result.Synced = true
+	if lock, err := s.lockStore.Load(); err == nil {
+		if entry := findLockEntry(lock, v.Name, opts.Ref); entry != nil {
+			result.Commit = entry.CommitHash
+		}
+	}
+	return result, nil
+}
+
+// bumpTarget returns the spec of v tracking from, or v's only spec when from
+// is empty.
+func bumpTarget(v *types.VendorSpec, from string) (*types.BranchSpec, error) {
+	if from == "" {
+		if len(v.Specs) != 1 {
+			return nil, fmt.Errorf("vendor '%s' tracks %d refs; pass --from <ref> to choose one", v.Name, len(v.Specs))
+		}
+		return &v.Specs[0], nil
+	}
+	for i := range v.Specs {
+		if v.Specs[i].Ref == from {
+			return &v.Specs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("vendor '%s' has no spec for ref %s", v.Name, from)
+}
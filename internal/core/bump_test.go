@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+func TestBumpVendor_ChangesRefAndLocksNewCommit(t *testing.T) {
+	env := setupPullTestEnv(t)
+	ctrl := gomock.NewController(t)
+	git := NewMockGitClient(ctrl)
+	env.syncer.gitClient = git
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "v1.2.0")
+	env.writeConfig(createTestConfig(vendor))
+	env.writeLock(types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "lib", Ref: "v1.2.0", CommitHash: "old123", FileHashes: map[string]string{"lib/file.go": "h"}},
+	}})
+
+	newCommit := strings.Repeat("d", 40)
+	git.EXPECT().LsRemote(gomock.Any(), vendor.URL, "v1.3.0").Return(newCommit, nil)
+	// The update phase sees the bumped config and relocks the vendor alone
+	env.updateSvc.onUpdate = func(opts UpdateOptions) error {
+		if opts.VendorName != "lib" {
+			t.Errorf("update VendorName = %q, want lib", opts.VendorName)
+		}
+		cfg, err := env.syncer.configStore.Load()
+		if err != nil {
+			return err
+		}
+		return env.syncer.lockStore.Save(types.VendorLock{Vendors: []types.LockDetails{
+			{Name: "lib", Ref: cfg.Vendors[0].Specs[0].Ref, CommitHash: newCommit, FileHashes: map[string]string{"lib/file.go": "h2"}},
+		}})
+	}
+
+	result, err := env.syncer.BumpVendor(context.Background(), BumpOptions{VendorName: "lib", Ref: "v1.3.0"})
+	if err != nil {
+		t.Fatalf("BumpVendor: %v", err)
+	}
+	if result.From != "v1.2.0" || result.To != "v1.3.0" || result.Commit != newCommit || !result.Synced {
+		t.Errorf("result = %+v", result)
+	}
+
+	cfg, _ := env.syncer.configStore.Load()
+	if ref := cfg.Vendors[0].Specs[0].Ref; ref != "v1.3.0" {
+		t.Errorf("config ref = %q, want v1.3.0", ref)
+	}
+	lock, _ := env.syncer.lockStore.Load()
+	if len(lock.Vendors) != 1 || lock.Vendors[0].Ref != "v1.3.0" || lock.Vendors[0].CommitHash != newCommit {
+		t.Errorf("lock = %+v, want lib@v1.3.0 at %s", lock.Vendors, newCommit)
+	}
+	if !env.syncSvc.syncCalled {
+		t.Error("expected the vendor to be re-synced")
+	}
+}
+
+func TestBumpVendor_UnknownRefLeavesConfig(t *testing.T) {
+	env := setupPullTestEnv(t)
+	ctrl := gomock.NewController(t)
+	git := NewMockGitClient(ctrl)
+	env.syncer.gitClient = git
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "v1.2.0")
+	vendor.Mirrors = []string{"https://mirror.example.com/lib"}
+	env.writeConfig(createTestConfig(vendor))
+
+	missing := errors.New(`no matching ref "v9.9.9" in ls-remote output`)
+	git.EXPECT().LsRemote(gomock.Any(), vendor.URL, "v9.9.9").Return("", missing)
+	git.EXPECT().LsRemote(gomock.Any(), vendor.Mirrors[0], "v9.9.9").Return("", missing)
+
+	_, err := env.syncer.BumpVendor(context.Background(), BumpOptions{VendorName: "lib", Ref: "v9.9.9"})
+	if err == nil || !strings.Contains(err.Error(), "ref 'v9.9.9' not found") {
+		t.Fatalf("BumpVendor error = %v, want ref not found", err)
+	}
+	cfg, _ := env.syncer.configStore.Load()
+	if ref := cfg.Vendors[0].Specs[0].Ref; ref != "v1.2.0" {
+		t.Errorf("config ref = %q, want unchanged v1.2.0", ref)
+	}
+	if env.updateSvc.callCount != 0 {
+		t.Error("update should not run for an unknown ref")
+	}
+}
+
+func TestBumpVendor_NoSyncOnlyRewritesConfig(t *testing.T) {
+	env := setupPullTestEnv(t)
+	ctrl := gomock.NewController(t)
+	git := NewMockGitClient(ctrl)
+	env.syncer.gitClient = git
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "v1.2.0")
+	vendor.Specs = append(vendor.Specs, types.BranchSpec{Ref: "main", Mapping: vendor.Specs[0].Mapping})
+	env.writeConfig(createTestConfig(vendor))
+
+	if _, err := env.syncer.BumpVendor(context.Background(), BumpOptions{VendorName: "lib", Ref: "v1.3.0"}); err == nil || !strings.Contains(err.Error(), "--from") {
+		t.Fatalf("BumpVendor without --from = %v, want a --from hint", err)
+	}
+
+	git.EXPECT().LsRemote(gomock.Any(), vendor.URL, "v1.3.0").Return("abc", nil)
+	result, err := env.syncer.BumpVendor(context.Background(), BumpOptions{VendorName: "lib", Ref: "v1.3.0", From: "v1.2.0", NoSync: true})
+	if err != nil {
+		t.Fatalf("BumpVendor: %v", err)
+	}
+	if result.Synced || result.Commit != "abc" {
+		t.Errorf("result = %+v, want unsynced at the ls-remote hash", result)
+	}
+	cfg, _ := env.syncer.configStore.Load()
+	if refs := []string{cfg.Vendors[0].Specs[0].Ref, cfg.Vendors[0].Specs[1].Ref}; refs[0] != "v1.3.0" || refs[1] != "main" {
+		t.Errorf("config refs = %v, want [v1.3.0 main]", refs)
+	}
+	if env.updateSvc.callCount != 0 || env.syncSvc.syncCalled {
+		t.Error("--no-sync should not update or sync")
+	}
+}
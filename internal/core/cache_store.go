@@ -23,6 +23,9 @@ type CacheStore interface {
 	BuildCache(vendorName, ref, commitHash string, files []string) (types.IncrementalSyncCache, error)
 }
 
+// maxCacheFiles caps the number of file checksums recorded per vendor@ref.
+const maxCacheFiles = 1000
+
 // FileCacheStore implements CacheStore using JSON files in vendor/.cache/
 type FileCacheStore struct {
 	fs      FileSystem
@@ -118,6 +121,11 @@ func (s *FileCacheStore) Delete(vendorName, ref string) error {
 
 // ComputeFileChecksum computes SHA-256 hash of a file
 func (s *FileCacheStore) ComputeFileChecksum(path string) (string, error) {
+	return fileSHA256(path)
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 of the file at path.
+func fileSHA256(path string) (string, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return "", err
@@ -143,7 +151,6 @@ func (s *FileCacheStore) BuildCache(vendorName, ref, commitHash string, files []
 	}
 
 	// Limit cache size to prevent excessive memory usage
-	const maxCacheFiles = 1000
 	if len(files) > maxCacheFiles {
 		files = files[:maxCacheFiles]
 	}
@@ -75,7 +75,8 @@ type VendorConfigWithCascade struct {
 // CascadeService discovers sibling repos, builds a DAG from vendor relationships,
 // topologically sorts, and runs pull in order.
 type CascadeService struct {
-	rootDir string // The root directory containing sibling repos
+	rootDir string      // The root directory containing sibling repos
+	logger  *loggerSlot // Passed on to each project's syncer (nil = no logging)
 }
 
 // NewCascadeService creates a CascadeService rooted at the given directory.
@@ -86,7 +87,7 @@ func NewCascadeService(rootDir string) *CascadeService {
 }
 
 // siblingsWithVendor discovers sibling project directories under rootDir
-// that contain a .git-vendor/vendor.yml file. Returns a map of project
+// that contain a .git-vendor/vendor.yml (or vendor.toml) file. Returns a map of project
 // name (directory base name) to absolute directory path.
 func (cs *CascadeService) siblingsWithVendor() (map[string]string, error) {
 	entries, err := os.ReadDir(cs.rootDir)
@@ -101,20 +102,30 @@ func (cs *CascadeService) siblingsWithVendor() (map[string]string, error) {
 		}
 		name := entry.Name()
 		dir := filepath.Join(cs.rootDir, name)
-		vendorYML := filepath.Join(dir, VendorDir, ConfigFile)
-		if _, err := os.Stat(vendorYML); err == nil {
+		if _, err := os.Stat(NewFileConfigStore(filepath.Join(dir, VendorDir)).Path()); err == nil {
 			siblings[name] = dir
 		}
 	}
 	return siblings, nil
 }
 
-// loadSiblingConfig loads the VendorConfigWithCascade from a sibling project's vendor.yml.
+// loadSiblingConfig loads the VendorConfigWithCascade from a sibling project's
+// vendor.yml, or its vendor.toml when that is the config in use.
 func loadSiblingConfig(dir string) (*VendorConfigWithCascade, error) {
-	store := NewYAMLStore[VendorConfigWithCascade](filepath.Join(dir, VendorDir), ConfigFile, false)
-	cfg, err := store.Load()
+	configDir := filepath.Join(dir, VendorDir)
+	configStore := NewFileConfigStore(configDir)
+	var cfg VendorConfigWithCascade
+	var err error
+	if configStore.Format() == ConfigFormatTOML {
+		var data []byte
+		if data, err = readTOMLFile(configStore.Path()); err == nil {
+			cfg, err = unmarshalTOMLAs[VendorConfigWithCascade](data)
+		}
+	} else {
+		cfg, err = NewYAMLStore[VendorConfigWithCascade](configDir, ConfigFile, false).Load()
+	}
 	if err != nil {
-		return nil, fmt.Errorf("cascade: load vendor.yml in %s: %w", dir, err)
+		return nil, fmt.Errorf("cascade: load %s in %s: %w", filepath.Base(configStore.Path()), dir, err)
 	}
 	return &cfg, nil
 }
@@ -568,7 +579,7 @@ func (cs *CascadeService) runPullInProject(ctx context.Context, dir string) (*Pu
 
 	configStore := NewFileConfigStore(vendorDir)
 	lockStore := NewFileLockStore(vendorDir)
-	gitClient := NewSystemGitClient(Verbose)
+	gitClient := NewSystemGitClient()
 	fs := NewRootedFileSystem(dir)
 
 	ui := &SilentUICallback{}
@@ -578,6 +589,7 @@ func (cs *CascadeService) runPullInProject(ctx context.Context, dir string) (*Pu
 	// during AddVendor (which requires an explicit license check). Cascade pull
 	// only fetches and copies files for already-configured vendors.
 	syncer := NewVendorSyncer(configStore, lockStore, gitClient, fs, nil, vendorDir, ui, nil)
+	syncer.SetLogger(cs.logger.get())
 	mgr := NewManagerWithSyncer(syncer)
 
 	pullOpts := PullOptions{}
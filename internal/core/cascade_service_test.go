@@ -216,6 +216,55 @@ vendors:
 	}
 }
 
+// TestBuildDAG_TOMLSibling verifies BuildDAG discovers a sibling whose
+// config is vendor.toml and reads its vendors and cascade section.
+func TestBuildDAG_TOMLSibling(t *testing.T) {
+	root := t.TempDir()
+	mkVendorYML(t, root, "project-a", "vendors: []\n")
+
+	vendorDir := filepath.Join(root, "project-b", VendorDir)
+	if err := os.MkdirAll(vendorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	toml := `[cascade]
+verify_command = "make test"
+
+[[vendors]]
+name = "project-a"
+url = "https://github.com/myorg/project-a"
+license = "MIT"
+
+[[vendors.specs]]
+ref = "main"
+
+[[vendors.specs.mapping]]
+from = "shared.go"
+to = "vendored/shared.go"
+`
+	if err := os.WriteFile(filepath.Join(vendorDir, ConfigFileTOML), []byte(toml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	graph, dirs, err := NewCascadeService(root).BuildDAG()
+	if err != nil {
+		t.Fatalf("BuildDAG returned error: %v", err)
+	}
+	if len(dirs) != 2 {
+		t.Fatalf("BuildDAG found %d projects, want 2", len(dirs))
+	}
+	if deps := graph["project-b"]; len(deps) != 1 || deps[0] != "project-a" {
+		t.Errorf("project-b deps = %v, want [project-a]", deps)
+	}
+
+	cfg, err := loadSiblingConfig(dirs["project-b"])
+	if err != nil {
+		t.Fatalf("loadSiblingConfig: %v", err)
+	}
+	if cfg.Cascade == nil || cfg.Cascade.VerifyCommand != "make test" {
+		t.Errorf("cascade = %+v, want verify_command from vendor.toml", cfg.Cascade)
+	}
+}
+
 // TestBuildDAG_NoDirs verifies BuildDAG returns empty graph when root
 // directory has no sibling projects.
 func TestBuildDAG_NoDirs(t *testing.T) {
@@ -414,6 +463,7 @@ vendors: []
 //   - Pull failures are captured in result.Failed
 //   - The result contains correct project names and failure counts
 func TestCascade_ExecutionFailsGracefully(t *testing.T) {
+	withoutFetchRetryDelay(t) // fetches fail without network; skip the backoff waits
 	root := t.TempDir()
 
 	// Create three projects: gamma depends on beta depends on alpha
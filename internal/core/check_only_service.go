@@ -0,0 +1,226 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// CheckOnlyServiceInterface defines the contract for the validate --check-only gate.
+type CheckOnlyServiceInterface interface {
+	// Run executes every gate check and returns one consolidated report.
+	// A failing check does not prevent the others from running.
+	Run(ctx context.Context) (*types.CheckOnlyResult, error)
+}
+
+// Compile-time interface satisfaction check.
+var _ CheckOnlyServiceInterface = (*CheckOnlyService)(nil)
+
+// CheckOnlyService composes config validation, conflict detection, lock
+// coherence, and license policy evaluation into a single pass/fail report
+// intended for pre-merge gates. Any finding, including warnings, fails the gate.
+type CheckOnlyService struct {
+	validation    ValidationServiceInterface
+	verifyService VerifyServiceInterface
+	licensePolicy LicensePolicyServiceInterface
+}
+
+// NewCheckOnlyService creates a new CheckOnlyService with injected sub-services.
+func NewCheckOnlyService(
+	validation ValidationServiceInterface,
+	verifyService VerifyServiceInterface,
+	licensePolicy LicensePolicyServiceInterface,
+) *CheckOnlyService {
+	return &CheckOnlyService{
+		validation:    validation,
+		verifyService: verifyService,
+		licensePolicy: licensePolicy,
+	}
+}
+
+// Run executes the checks in order: config, conflicts, coherence, license.
+// Errors from a sub-check are reported as issues rather than returned;
+// only context cancellation aborts the run.
+func (s *CheckOnlyService) Run(ctx context.Context) (*types.CheckOnlyResult, error) {
+	result := &types.CheckOnlyResult{Issues: []types.CheckOnlyIssue{}}
+
+	if err := s.validation.ValidateConfig(); err != nil {
+		result.Issues = append(result.Issues, types.CheckOnlyIssue{
+			Check:       types.CheckOnlyConfig,
+			Severity:    types.CheckOnlySeverityError,
+			Message:     err.Error(),
+			Remediation: fmt.Sprintf("Fix the reported field in %s (see docs/CONFIGURATION.md for the schema)", ConfigPath),
+		})
+	}
+
+	conflicts, err := s.validation.DetectConflicts()
+	if err != nil {
+		result.Issues = append(result.Issues, types.CheckOnlyIssue{
+			Check:       types.CheckOnlyConflict,
+			Severity:    types.CheckOnlySeverityError,
+			Message:     fmt.Sprintf("conflict detection failed: %v", err),
+			Remediation: fmt.Sprintf("Make sure %s parses, then re-run the check", ConfigPath),
+		})
+	}
+	for _, c := range conflicts {
+		message := fmt.Sprintf("%s (%s) and %s (%s) both write %s",
+			c.Vendor1, c.Mapping1.From, c.Vendor2, c.Mapping2.From, c.Path)
+		switch c.Reason {
+		case types.ConflictNestedPath:
+			message = fmt.Sprintf("%s (%s) writes %s inside the directory of %s (%s → %s)",
+				c.Vendor2, c.Mapping2.From, c.Path, c.Vendor1, c.Mapping1.From, c.Mapping1.To)
+		case types.ConflictAutoNamed:
+			message = fmt.Sprintf("%s (%s) and %s (%s) both auto-name to %s",
+				c.Vendor1, c.Mapping1.From, c.Vendor2, c.Mapping2.From, c.Path)
+		}
+		result.Issues = append(result.Issues, types.CheckOnlyIssue{
+			Check:    types.CheckOnlyConflict,
+			Severity: types.CheckOnlySeverityError,
+			Vendor:   c.Vendor1,
+			Path:     c.Path,
+			Message:  message,
+			Remediation: fmt.Sprintf("Change the 'to' path of one mapping in %s or %s so the destinations no longer overlap",
+				c.Vendor1, c.Vendor2),
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("check cancelled: %w", err)
+	}
+	result.Issues = append(result.Issues, s.coherenceIssues(ctx)...)
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("check cancelled: %w", err)
+	}
+	result.Issues = append(result.Issues, s.licenseIssues()...)
+
+	for _, issue := range result.Issues {
+		if issue.Severity == types.CheckOnlySeverityError {
+			result.Summary.Errors++
+		} else {
+			result.Summary.Warnings++
+		}
+	}
+	result.Summary.Total = len(result.Issues)
+	result.Summary.Result = types.AuditResultPass
+	if result.Summary.Total > 0 {
+		result.Summary.Result = types.AuditResultFail
+	}
+	return result, nil
+}
+
+// coherenceIssues reports config destinations missing from the lock (stale)
+// and lock entries no longer referenced by config (orphaned).
+func (s *CheckOnlyService) coherenceIssues(ctx context.Context) []types.CheckOnlyIssue {
+	verifyResult, err := s.verifyService.Verify(ctx)
+	if err != nil {
+		return []types.CheckOnlyIssue{{
+			Check:       types.CheckOnlyCoherence,
+			Severity:    types.CheckOnlySeverityError,
+			Message:     fmt.Sprintf("coherence check failed: %v", err),
+			Remediation: fmt.Sprintf("Run 'git-vendor pull' to create or refresh %s", LockPath),
+		}}
+	}
+
+	var issues []types.CheckOnlyIssue
+	for _, file := range verifyResult.Files {
+		if file.Type != "coherence" {
+			continue
+		}
+		vendor := ""
+		if file.Vendor != nil {
+			vendor = *file.Vendor
+		}
+		issue := types.CheckOnlyIssue{
+			Check:    types.CheckOnlyCoherence,
+			Severity: types.CheckOnlySeverityWarning,
+			Vendor:   vendor,
+			Path:     file.Path,
+		}
+		if file.Status == "orphaned" {
+			issue.Message = fmt.Sprintf("%s is locked but no mapping in %s references it", file.Path, ConfigPath)
+			issue.Remediation = fmt.Sprintf("Restore the mapping or run 'git-vendor pull %s' to drop the stale lock entry", vendor)
+		} else {
+			issue.Message = fmt.Sprintf("%s is mapped in %s but has no lock entry", file.Path, ConfigPath)
+			issue.Remediation = fmt.Sprintf("Run 'git-vendor pull %s' to sync the destination and record it", vendor)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// licenseIssues reports vendors whose license is denied (error) or flagged
+// for review (warning) by the license policy.
+func (s *CheckOnlyService) licenseIssues() []types.CheckOnlyIssue {
+	report, err := s.licensePolicy.GenerateReport(types.PolicyWarn)
+	if err != nil {
+		return []types.CheckOnlyIssue{{
+			Check:       types.CheckOnlyLicense,
+			Severity:    types.CheckOnlySeverityError,
+			Message:     fmt.Sprintf("license check failed: %v", err),
+			Remediation: fmt.Sprintf("Fix %s and re-run the check", s.licensePolicy.PolicyFile()),
+		}}
+	}
+
+	var issues []types.CheckOnlyIssue
+	for _, v := range report.Vendors {
+		switch v.Decision {
+		case types.PolicyDeny:
+			issues = append(issues, types.CheckOnlyIssue{
+				Check:       types.CheckOnlyLicense,
+				Severity:    types.CheckOnlySeverityError,
+				Vendor:      v.Name,
+				Message:     fmt.Sprintf("%s: %s", v.Name, v.Reason),
+				Remediation: fmt.Sprintf("Remove or replace %s, or move %s to the allow list in %s", v.Name, v.License, s.licensePolicy.PolicyFile()),
+			})
+		case types.PolicyWarn:
+			issues = append(issues, types.CheckOnlyIssue{
+				Check:       types.CheckOnlyLicense,
+				Severity:    types.CheckOnlySeverityWarning,
+				Vendor:      v.Name,
+				Message:     fmt.Sprintf("%s: %s", v.Name, v.Reason),
+				Remediation: fmt.Sprintf("Review %s's license and add %s to the allow or deny list in %s", v.Name, v.License, s.licensePolicy.PolicyFile()),
+			})
+		}
+	}
+	return issues
+}
+
+// FormatCheckOnlyReport formats a CheckOnlyResult as a per-check status table
+// followed by the remediation list.
+func FormatCheckOnlyReport(result *types.CheckOnlyResult) string {
+	var out string
+	out += "=== Validate (check-only) ===\n\n"
+
+	for _, check := range []struct{ key, name string }{
+		{types.CheckOnlyConfig, "Config"},
+		{types.CheckOnlyConflict, "Conflicts"},
+		{types.CheckOnlyCoherence, "Coherence"},
+		{types.CheckOnlyLicense, "License"},
+	} {
+		count := 0
+		for _, issue := range result.Issues {
+			if issue.Check == check.key {
+				count++
+			}
+		}
+		status := types.AuditResultPass
+		if count > 0 {
+			status = types.AuditResultFail
+		}
+		out += formatCheckLine(check.name, status, Pluralize(count, "issue", "issues"))
+	}
+
+	out += fmt.Sprintf("\nResult: %s\n", result.Summary.Result)
+
+	if len(result.Issues) > 0 {
+		out += "\nRemediation:\n"
+		for i, issue := range result.Issues {
+			out += fmt.Sprintf("  %d. [%s] %s\n", i+1, issue.Check, issue.Message)
+			out += fmt.Sprintf("     Fix: %s\n", issue.Remediation)
+		}
+	}
+
+	return out
+}
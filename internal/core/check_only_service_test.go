@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ============================================================================
+// CheckOnlyService Tests - validate --check-only
+// ============================================================================
+
+// newTestCheckOnlyService wires real validation and license policy services
+// to the mocked config/lock stores, with a stubbed verify for coherence.
+func newTestCheckOnlyService(config ConfigStore, lock LockStore, verify VerifyServiceInterface, rules types.LicensePolicyRules) *CheckOnlyService {
+	policy := types.LicensePolicy{LicensePolicy: rules}
+	return NewCheckOnlyService(
+		NewValidationService(config),
+		verify,
+		NewLicensePolicyService(&policy, "test-policy.yml", config, lock),
+	)
+}
+
+func TestCheckOnlyService_ConflictAndDeniedLicense(t *testing.T) {
+	ctrl, _, _, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	// Both vendors map src/file.go → lib/file.go; vendor-b is GPL-3.0
+	vendorA := createTestVendorSpec("vendor-a", "https://github.com/owner/a", "main")
+	vendorB := createTestVendorSpec("vendor-b", "https://github.com/owner/b", "main")
+	vendorB.License = "GPL-3.0"
+	config.EXPECT().Load().Return(createTestConfig(vendorA, vendorB), nil).AnyTimes()
+	config.EXPECT().Path().Return(ConfigPath).AnyTimes()
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil).AnyTimes()
+
+	verify := &stubAuditVerifyService{result: &types.VerifyResult{}}
+	svc := newTestCheckOnlyService(config, lock, verify, types.LicensePolicyRules{
+		Allow:   []string{"MIT"},
+		Deny:    []string{"GPL-3.0"},
+		Unknown: types.PolicyWarn,
+	})
+
+	result, err := svc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if result.Summary.Result != types.AuditResultFail {
+		t.Errorf("Result = %q, want FAIL", result.Summary.Result)
+	}
+	if result.Summary.Errors != 2 {
+		t.Errorf("Errors = %d, want 2 (conflict + license), issues = %+v", result.Summary.Errors, result.Issues)
+	}
+
+	var conflict, license *types.CheckOnlyIssue
+	for i := range result.Issues {
+		switch result.Issues[i].Check {
+		case types.CheckOnlyConflict:
+			conflict = &result.Issues[i]
+		case types.CheckOnlyLicense:
+			license = &result.Issues[i]
+		}
+	}
+	if conflict == nil {
+		t.Fatal("expected a conflict issue")
+	}
+	if conflict.Path != "lib/file.go" || !strings.Contains(conflict.Remediation, "'to' path") {
+		t.Errorf("conflict = %+v, want lib/file.go with a 'to' path fix", conflict)
+	}
+	if license == nil {
+		t.Fatal("expected a license issue")
+	}
+	if license.Vendor != "vendor-b" || !strings.Contains(license.Remediation, "test-policy.yml") {
+		t.Errorf("license = %+v, want vendor-b with a policy-file fix", license)
+	}
+	if !verify.called {
+		t.Error("expected coherence check to run verify")
+	}
+
+	report := FormatCheckOnlyReport(result)
+	if !strings.Contains(report, "Remediation:") || strings.Count(report, "Fix: ") != 2 {
+		t.Errorf("report missing remediation list:\n%s", report)
+	}
+}
+
+func TestCheckOnlyService_CoherenceWarningFailsGate(t *testing.T) {
+	ctrl, _, _, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	config.EXPECT().Load().Return(createTestConfig(createTestVendorSpec("vendor-a", "https://github.com/owner/a", "main")), nil).AnyTimes()
+	config.EXPECT().Path().Return(ConfigPath).AnyTimes()
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil).AnyTimes()
+
+	vendor := "vendor-a"
+	verify := &stubAuditVerifyService{result: &types.VerifyResult{
+		Files: []types.FileStatus{
+			{Path: "lib/file.go", Vendor: &vendor, Status: "modified", Type: "file"},
+			{Path: "lib/old.go", Vendor: &vendor, Status: "orphaned", Type: "coherence"},
+		},
+	}}
+	svc := newTestCheckOnlyService(config, lock, verify, types.LicensePolicyRules{
+		Allow:   []string{"MIT"},
+		Unknown: types.PolicyWarn,
+	})
+
+	result, err := svc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// Only the coherence finding counts; modified files are verify's concern
+	if result.Summary.Total != 1 || result.Summary.Warnings != 1 {
+		t.Fatalf("Summary = %+v, want exactly one warning", result.Summary)
+	}
+	if result.Summary.Result != types.AuditResultFail {
+		t.Errorf("Result = %q, want FAIL (check-only blocks on warnings)", result.Summary.Result)
+	}
+	issue := result.Issues[0]
+	if issue.Check != types.CheckOnlyCoherence || issue.Path != "lib/old.go" || !strings.Contains(issue.Remediation, "git-vendor pull vendor-a") {
+		t.Errorf("issue = %+v, want orphaned lib/old.go with a pull fix", issue)
+	}
+}
+
+func TestCheckOnlyService_CleanConfigPasses(t *testing.T) {
+	ctrl, _, _, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	config.EXPECT().Load().Return(createTestConfig(createTestVendorSpec("vendor-a", "https://github.com/owner/a", "main")), nil).AnyTimes()
+	config.EXPECT().Path().Return(ConfigPath).AnyTimes()
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil).AnyTimes()
+
+	svc := newTestCheckOnlyService(config, lock, &stubAuditVerifyService{result: &types.VerifyResult{}}, types.LicensePolicyRules{
+		Allow:   []string{"MIT"},
+		Unknown: types.PolicyWarn,
+	})
+
+	result, err := svc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Summary.Result != types.AuditResultPass || len(result.Issues) != 0 {
+		t.Errorf("result = %+v, want PASS with no issues", result)
+	}
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// PlanClean lists the orphaned vendored files clean would delete: lock
+// FileHashes paths no longer produced by any config mapping (verify's
+// "orphaned" coherence status) that still exist on disk as regular files.
+// Orphans that fail ValidateDestPath are never planned, so clean cannot reach
+// outside the project or into git-vendor state files. Targets are sorted by
+// path. PlanClean deletes nothing.
+func (s *VendorSyncer) PlanClean() (*types.PrunePlan, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("PlanClean: load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("PlanClean: load lock: %w", err)
+	}
+
+	plan := &types.PrunePlan{DryRun: true, Targets: []types.PruneTarget{}}
+	for path, vendorName := range orphanedLockPaths(configDestinations(config), lock) {
+		if ValidateDestPath(path) != nil {
+			continue
+		}
+		info, err := s.fs.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		plan.Targets = append(plan.Targets, types.PruneTarget{
+			Kind:   types.PruneKindFile,
+			Path:   path,
+			Vendor: vendorName,
+			Reason: types.PruneReasonOrphanedByConfig,
+		})
+	}
+	sort.Slice(plan.Targets, func(i, j int) bool { return plan.Targets[i].Path < plan.Targets[j].Path })
+	return plan, nil
+}
+
+// Clean deletes the files in plan (from PlanClean) and drops every orphaned
+// FileHashes entry from vendor.lock, so verify no longer reports them. Files
+// already gone are skipped. Clean marks plan as executed (DryRun false) and
+// returns the number of files deleted.
+func (s *VendorSyncer) Clean(plan *types.PrunePlan) (int, error) {
+	removed := 0
+	for _, target := range plan.Targets {
+		if target.Kind != types.PruneKindFile {
+			continue
+		}
+		if err := ValidateDestPath(target.Path); err != nil {
+			return removed, fmt.Errorf("clean %s: %w", target.Path, err)
+		}
+		if err := s.fs.Remove(target.Path); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return removed, fmt.Errorf("clean %s: %w", target.Path, err)
+		}
+		removed++
+	}
+	plan.DryRun = false
+
+	config, err := s.configStore.Load()
+	if err != nil {
+		return removed, fmt.Errorf("clean: load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return removed, nil
+		}
+		return removed, fmt.Errorf("clean: load lock: %w", err)
+	}
+	orphans := orphanedLockPaths(configDestinations(config), lock)
+	if len(orphans) == 0 {
+		return removed, nil
+	}
+	for i := range lock.Vendors {
+		if lock.Vendors[i].Source == SourceInternal {
+			continue
+		}
+		for path := range lock.Vendors[i].FileHashes {
+			if _, ok := orphans[path]; ok {
+				delete(lock.Vendors[i].FileHashes, path)
+			}
+		}
+	}
+	if err := s.lockStore.Save(lock); err != nil {
+		return removed, fmt.Errorf("clean: save lock: %w", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// setupCleanTestEnv returns a pull test env whose working directory is the
+// project root, with one vendor mapping lib/kept.go and a lock that also
+// records lib/dropped.go and lib/gone.go (dropped from config; gone.go is
+// already missing on disk). Restores the working directory on cleanup.
+func setupCleanTestEnv(t *testing.T) *pullTestEnv {
+	t.Helper()
+	env := setupPullTestEnv(t)
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(env.configDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	env.writeConfig(createTestConfig(types.VendorSpec{
+		Name: "test-vendor",
+		URL:  "https://github.com/owner/repo",
+		Specs: []types.BranchSpec{
+			{Ref: "main", Mapping: []types.PathMapping{{From: "src/kept.go", To: "lib/kept.go"}}},
+		},
+	}))
+	env.writeLock(types.VendorLock{
+		SchemaVersion: "1.1",
+		Vendors: []types.LockDetails{
+			{
+				Name:       "test-vendor",
+				Ref:        "main",
+				CommitHash: "abc123",
+				Updated:    "2024-01-01T00:00:00Z",
+				FileHashes: map[string]string{"lib/kept.go": "h1", "lib/dropped.go": "h2", "lib/gone.go": "h3"},
+			},
+		},
+	})
+
+	if err := os.MkdirAll("lib", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"lib/kept.go", "lib/dropped.go", "lib/manual.go"} {
+		if err := os.WriteFile(name, []byte("package lib"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return env
+}
+
+func TestPlanClean_ListsOrphanedFilesOnly(t *testing.T) {
+	env := setupCleanTestEnv(t)
+
+	plan, err := env.syncer.PlanClean()
+	if err != nil {
+		t.Fatalf("PlanClean() error = %v", err)
+	}
+	want := types.PruneTarget{Kind: types.PruneKindFile, Path: "lib/dropped.go", Vendor: "test-vendor", Reason: types.PruneReasonOrphanedByConfig}
+	if len(plan.Targets) != 1 || plan.Targets[0] != want {
+		t.Errorf("targets = %+v, want only %+v", plan.Targets, want)
+	}
+
+	for _, name := range []string{"lib/kept.go", "lib/dropped.go", "lib/manual.go"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("%s missing after PlanClean: %v", name, err)
+		}
+	}
+}
+
+// TestPlanClean_KeepsFilesOfNonCanonicalDestinations verifies that mappings
+// whose "to" is written as "./lib/", "lib/" or "." still own their locked files.
+func TestPlanClean_KeepsFilesOfNonCanonicalDestinations(t *testing.T) {
+	for _, to := range []string{"./lib/", "lib/", "."} {
+		t.Run(to, func(t *testing.T) {
+			env := setupCleanTestEnv(t)
+			// "." resolves to the auto path, the source directory's basename
+			from := "src"
+			if to == "." {
+				from = "lib"
+			}
+			spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{{From: from, To: to}}}
+			env.writeConfig(createTestConfig(types.VendorSpec{
+				Name:  "test-vendor",
+				URL:   "https://github.com/owner/repo",
+				Specs: []types.BranchSpec{spec},
+			}))
+
+			plan, err := env.syncer.PlanClean()
+			if err != nil {
+				t.Fatalf("PlanClean() error = %v", err)
+			}
+			if len(plan.Targets) != 0 {
+				t.Errorf("targets = %+v, want none: the mapping still owns lib/", plan.Targets)
+			}
+		})
+	}
+}
+
+func TestClean_RemovesOrphansAndLockEntries(t *testing.T) {
+	env := setupCleanTestEnv(t)
+
+	plan, err := env.syncer.PlanClean()
+	if err != nil {
+		t.Fatalf("PlanClean() error = %v", err)
+	}
+	removed, err := env.syncer.Clean(plan)
+	if err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("removed = %d, want 1", removed)
+	}
+	if plan.DryRun {
+		t.Error("plan.DryRun = true after Clean, want false")
+	}
+
+	if _, err := os.Stat("lib/dropped.go"); !os.IsNotExist(err) {
+		t.Errorf("lib/dropped.go should be deleted, stat err = %v", err)
+	}
+	// Still-mapped files and files never recorded in the lock are untouched
+	for _, name := range []string{"lib/kept.go", "lib/manual.go"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("%s should be kept: %v", name, err)
+		}
+	}
+
+	lock, err := env.syncer.lockStore.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes := lock.Vendors[0].FileHashes
+	if len(hashes) != 1 || hashes["lib/kept.go"] != "h1" {
+		t.Errorf("FileHashes = %v, want only lib/kept.go", hashes)
+	}
+}
+
+func TestPlanClean_SkipsUnsafeOrphanPaths(t *testing.T) {
+	env := setupCleanTestEnv(t)
+
+	outside := filepath.Join(filepath.Dir(env.configDir), "outside.go")
+	if err := os.WriteFile(outside, []byte("package outside"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Remove(outside) })
+
+	env.writeLock(types.VendorLock{
+		SchemaVersion: "1.1",
+		Vendors: []types.LockDetails{
+			{
+				Name:       "test-vendor",
+				Ref:        "main",
+				CommitHash: "abc123",
+				Updated:    "2024-01-01T00:00:00Z",
+				FileHashes: map[string]string{"lib/kept.go": "h1", "../outside.go": "h2", ConfigPath: "h3"},
+			},
+		},
+	})
+
+	plan, err := env.syncer.PlanClean()
+	if err != nil {
+		t.Fatalf("PlanClean() error = %v", err)
+	}
+	if len(plan.Targets) != 0 {
+		t.Errorf("targets = %+v, want none (paths outside the project and state files are never cleaned)", plan.Targets)
+	}
+}
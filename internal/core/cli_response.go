@@ -39,6 +39,9 @@ const (
 	ExitInvalidArguments = 3
 	ExitValidationFailed = 4
 	ExitNetworkError     = 5
+	// ExitCancelled is returned when the user declines a confirmation or
+	// aborts an interactive wizard: nothing was changed, but nothing was done.
+	ExitCancelled = 6
 )
 
 // CLI error codes for structured JSON error responses.
@@ -55,6 +58,10 @@ const (
 	ErrCodeInternalError    = "INTERNAL_ERROR"
 	ErrCodeRefNotFound      = "REF_NOT_FOUND"
 	ErrCodeInvalidKey       = "INVALID_KEY"
+	ErrCodeCancelled        = "CANCELLED"
+	// ErrCodeDestinationConflict reports a move onto paths owned by another
+	// vendor or already present on disk.
+	ErrCodeDestinationConflict = "DESTINATION_CONFLICT"
 )
 
 // EmitCLISuccess writes a successful CLIResponse as JSON to stdout and exits with code 0.
@@ -97,6 +104,8 @@ func CLIErrorCodeForError(err error) string {
 		return ErrCodeVendorNotFound
 	case IsValidationError(err):
 		return ErrCodeValidationFailed
+	case IsDestinationConflict(err):
+		return ErrCodeDestinationConflict
 	default:
 		return ErrCodeInternalError
 	}
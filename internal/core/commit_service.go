@@ -58,7 +58,7 @@ func ExtractVendorTouch(specs []*types.VendorSpec) []string {
 			for _, m := range bs.Mapping {
 				dest := m.To
 				if dest == "" {
-					dest = filepath.Base(m.From)
+					dest = ComputeAutoPath(m.From, bs.DefaultTarget, spec.Name)
 				}
 				// Strip position specifier before extracting area
 				destFile, _, err := types.ParsePathPosition(dest)
@@ -247,7 +247,7 @@ func VendorNoteJSON(locks []types.LockDetails, specMap map[string]*types.VendorS
 //
 // Flow:
 //  1. Load config + lock
-//  2. Filter lock entries by vendorFilter (empty = all)
+//  2. Filter lock entries by vendorFilter (exact name or glob, empty = all)
 //  3. Collect all affected paths across all matching vendors
 //  4. Stage all paths in one git add
 //  5. Build multi-valued trailers (one Vendor-Name/Ref/Commit group per vendor)
@@ -259,6 +259,13 @@ func VendorNoteJSON(locks []types.LockDetails, specMap map[string]*types.VendorS
 // For per-vendor provenance, consumers read the structured trailers or note.
 func CommitVendorChanges(ctx context.Context, gitClient GitClient, configStore ConfigStore,
 	lockStore LockStore, rootDir, operation, vendorFilter string) error {
+	return commitVendorChangesIn(ctx, gitClient, configStore, lockStore, rootDir, VendorDir, operation, vendorFilter)
+}
+
+// commitVendorChangesIn is CommitVendorChanges for a vendor directory other
+// than VendorDir, given relative to rootDir.
+func commitVendorChangesIn(ctx context.Context, gitClient GitClient, configStore ConfigStore,
+	lockStore LockStore, rootDir, vendorDir, operation, vendorFilter string) error {
 
 	config, err := configStore.Load()
 	if err != nil {
@@ -281,7 +288,7 @@ func CommitVendorChanges(ctx context.Context, gitClient GitClient, configStore C
 	var allPaths []string
 
 	for _, lockEntry := range lock.Vendors {
-		if vendorFilter != "" && lockEntry.Name != vendorFilter {
+		if !MatchVendorFilter(lockEntry.Name, vendorFilter) {
 			continue
 		}
 
@@ -291,7 +298,7 @@ func CommitVendorChanges(ctx context.Context, gitClient GitClient, configStore C
 		}
 
 		matchedLocks = append(matchedLocks, lockEntry)
-		allPaths = append(allPaths, collectVendorPaths(spec, lockEntry, rootDir)...)
+		allPaths = append(allPaths, collectVendorPaths(spec, lockEntry, rootDir, vendorDir)...)
 	}
 
 	if len(matchedLocks) == 0 {
@@ -400,7 +407,7 @@ func AnnotateVendorCommit(ctx context.Context, gitClient GitClient, configStore
 
 	var matchedLocks []types.LockDetails
 	for _, lockEntry := range lock.Vendors {
-		if vendorFilter != "" && lockEntry.Name != vendorFilter {
+		if !MatchVendorFilter(lockEntry.Name, vendorFilter) {
 			continue
 		}
 		matchedLocks = append(matchedLocks, lockEntry)
@@ -425,17 +432,17 @@ func AnnotateVendorCommit(ctx context.Context, gitClient GitClient, configStore
 // collectVendorPaths gathers all file paths that should be staged for a vendor commit.
 // collectVendorPaths returns paths for:
 //   - Mapping destination paths (from config)
-//   - The vendor.lock file
-//   - The vendor.yml config file
+//   - The vendor.lock file in vendorDir
+//   - The vendor.yml (or vendor.toml) config file in vendorDir
 //   - The vendor's license file (if LicensePath is set)
-func collectVendorPaths(spec *types.VendorSpec, lock types.LockDetails, rootDir string) []string {
+func collectVendorPaths(spec *types.VendorSpec, lock types.LockDetails, rootDir, vendorDir string) []string {
 	var paths []string
 
 	for _, branchSpec := range spec.Specs {
 		for _, mapping := range branchSpec.Mapping {
 			dest := mapping.To
 			if dest == "" {
-				dest = filepath.Base(mapping.From)
+				dest = ComputeAutoPath(mapping.From, branchSpec.DefaultTarget, spec.Name)
 			}
 			// Strip position specifier for staging
 			destFile, _, err := types.ParsePathPosition(dest)
@@ -446,7 +453,12 @@ func collectVendorPaths(spec *types.VendorSpec, lock types.LockDetails, rootDir
 		}
 	}
 
-	paths = append(paths, LockPath, ConfigPath)
+	// Stage vendor.toml instead when it is the config in use (FileConfigStore.Format)
+	configPath := vendorDir + "/" + ConfigFile
+	if NewFileConfigStore(filepath.Join(rootDir, vendorDir)).Format() == ConfigFormatTOML {
+		configPath = vendorDir + "/" + ConfigFileTOML
+	}
+	paths = append(paths, vendorDir+"/"+LockFile, configPath)
 
 	// Only stage the license file if it actually exists on disk.
 	// Upstream repos without a LICENSE file won't have one copied,
@@ -471,7 +483,7 @@ func collectDestPaths(spec *types.VendorSpec) []string {
 		for _, m := range bs.Mapping {
 			dest := m.To
 			if dest == "" {
-				dest = filepath.Base(m.From)
+				dest = ComputeAutoPath(m.From, bs.DefaultTarget, spec.Name)
 			}
 			paths = append(paths, dest)
 		}
@@ -214,7 +214,7 @@ func TestCollectVendorPaths_Basic(t *testing.T) {
 		LicensePath: ".git-vendor/licenses/my-lib.txt",
 	}
 
-	paths := collectVendorPaths(spec, lock, tmpDir)
+	paths := collectVendorPaths(spec, lock, tmpDir, VendorDir)
 	// 2 mapping paths + LockPath + ConfigPath + license = 5
 	if len(paths) != 5 {
 		t.Errorf("expected 5 paths, got %d: %v", len(paths), paths)
@@ -253,7 +253,7 @@ func TestCollectVendorPaths_NoLicense(t *testing.T) {
 	}
 	lock := types.LockDetails{Name: "bare"}
 
-	paths := collectVendorPaths(spec, lock, ".")
+	paths := collectVendorPaths(spec, lock, ".", VendorDir)
 	// 1 mapping + LockPath + ConfigPath = 3
 	if len(paths) != 3 {
 		t.Errorf("expected 3 paths, got %d: %v", len(paths), paths)
@@ -269,7 +269,7 @@ func TestCollectVendorPaths_AutoNamed(t *testing.T) {
 	}
 	lock := types.LockDetails{Name: "auto"}
 
-	paths := collectVendorPaths(spec, lock, ".")
+	paths := collectVendorPaths(spec, lock, ".", VendorDir)
 	found := false
 	for _, p := range paths {
 		if p == "file.go" {
@@ -299,7 +299,7 @@ func TestCollectVendorPaths_Dedup(t *testing.T) {
 	// collectVendorPaths does NOT dedup — dedup is applied at the commit level.
 	// But the raw output has: vendor/x.go, vendor/x.go, LockPath, ConfigPath = 4
 	// After dedup in CommitVendorChanges it would be 3.
-	paths := collectVendorPaths(spec, lock, ".")
+	paths := collectVendorPaths(spec, lock, ".", VendorDir)
 	if len(paths) != 4 {
 		t.Errorf("expected 4 raw paths, got %d: %v", len(paths), paths)
 	}
@@ -522,6 +522,53 @@ func TestCommitVendorChanges_VendorFilter(t *testing.T) {
 	}
 }
 
+// TestCommitVendorChanges_VendorFilterGlob verifies a glob filter (--only
+// "lib-*") selects every matching vendor, like sync and pull do.
+func TestCommitVendorChanges_VendorFilterGlob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGit := NewMockGitClient(ctrl)
+	mockConfig := NewMockConfigStore(ctrl)
+	mockLock := NewMockLockStore(ctrl)
+
+	config := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{Name: "lib-a", Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "a.go", To: "va.go"}}}}},
+			{Name: "lib-b", Specs: []types.BranchSpec{{Ref: "v2", Mapping: []types.PathMapping{{From: "b.go", To: "vb.go"}}}}},
+			{Name: "other", Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "o.go", To: "vo.go"}}}}},
+		},
+	}
+	lock := types.VendorLock{
+		Vendors: []types.LockDetails{
+			{Name: "lib-a", Ref: "main", CommitHash: "aaaa000000000000000000000000000000000000"},
+			{Name: "lib-b", Ref: "v2", CommitHash: "bbbb000000000000000000000000000000000000"},
+			{Name: "other", Ref: "main", CommitHash: "cccc000000000000000000000000000000000000"},
+		},
+	}
+
+	mockConfig.EXPECT().Load().Return(config, nil)
+	mockLock.EXPECT().Load().Return(lock, nil)
+
+	mockGit.EXPECT().Add(gomock.Any(), ".", gomock.Any()).Return(nil)
+	mockGit.EXPECT().Commit(gomock.Any(), ".", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, opts types.CommitOptions) error {
+			names := filterTrailerValues(opts.Trailers, "Vendor-Name")
+			if len(names) != 2 || names[0] != "lib-a" || names[1] != "lib-b" {
+				t.Errorf("expected lib-a and lib-b, got Vendor-Name values: %v", names)
+			}
+			return nil
+		},
+	)
+	mockGit.EXPECT().GetHeadHash(gomock.Any(), ".").Return("1111111111111111111111111111111111111111", nil)
+	mockGit.EXPECT().AddNote(gomock.Any(), ".", VendorNoteRef, gomock.Any(), gomock.Any()).Return(nil)
+
+	err := CommitVendorChanges(context.Background(), mockGit, mockConfig, mockLock, ".", "sync", "lib-*")
+	if err != nil {
+		t.Fatalf("CommitVendorChanges returned error: %v", err)
+	}
+}
+
 func TestCommitVendorChanges_AddFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -839,6 +886,22 @@ func TestExtractVendorTouch_AutoNamedDest(t *testing.T) {
 	}
 }
 
+func TestExtractVendorTouch_AutoNamedUnderDefaultTarget(t *testing.T) {
+	specs := []*types.VendorSpec{
+		{
+			Name: "auto",
+			Specs: []types.BranchSpec{
+				{Ref: "main", DefaultTarget: "pkg/auto", Mapping: []types.PathMapping{{From: "src/deep/file.go"}}},
+			},
+		},
+	}
+
+	got := ExtractVendorTouch(specs)
+	if len(got) != 1 || got[0] != "pkg.auto" {
+		t.Errorf("ExtractVendorTouch = %v, want [pkg.auto]", got)
+	}
+}
+
 func TestMergeTouch_VendorOnly(t *testing.T) {
 	vendorTouch := []string{"pkg.git-plumbing", "claude.hooks"}
 	merged, filtered := mergeTouch(vendorTouch, nil)
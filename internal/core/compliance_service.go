@@ -399,8 +399,8 @@ func (s *ComplianceService) updatePositionSpecs(vendorName string, path string,
 						continue
 					}
 
-					// Skip: ToEOF (auto-expands), single-line, column specs (too complex)
-					if pos.ToEOF || pos.IsSingleLine() || pos.HasColumns() {
+					// Skip: ToEOF (auto-expands), single-line, column specs (too complex), anchors (self-locating)
+					if pos.ToEOF || pos.IsSingleLine() || pos.HasColumns() || pos.IsAnchored() {
 						continue
 					}
 
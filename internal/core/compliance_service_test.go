@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -795,6 +796,7 @@ func TestValidateInternalVendor_ValidConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Path().Return(ConfigPath).AnyTimes()
 
 	mockConfig.EXPECT().Load().Return(types.VendorConfig{
 		Vendors: []types.VendorSpec{
@@ -958,6 +960,7 @@ func TestValidateConfig_AcceptsValidComplianceConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Path().Return(ConfigPath).AnyTimes()
 
 	mockConfig.EXPECT().Load().Return(types.VendorConfig{
 		Compliance: &types.ComplianceConfig{Default: EnforcementStrict, Mode: ComplianceModeOverride},
@@ -1035,6 +1038,7 @@ func TestDetectInternalCycles_NoCycle(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Path().Return(ConfigPath).AnyTimes()
 
 	// Create temp files so validateInternalVendor passes os.Stat checks
 	tmpDir := t.TempDir()
@@ -1133,6 +1137,7 @@ func TestDetectInternalCycles_SkipsExternalVendors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Path().Return(ConfigPath).AnyTimes()
 
 	tmpDir := t.TempDir()
 	aFile := filepath.Join(tmpDir, "a.go")
@@ -1227,7 +1232,7 @@ func TestVerifyInternalEntries_DetectsDrift(t *testing.T) {
 	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{isDir: false}, nil).AnyTimes()
 
 	service := NewVerifyService(configStore, lockStore, cache, fs, "/test")
-	result, err := service.Verify(nil)
+	result, err := service.Verify(context.Background())
 	if err != nil {
 		t.Fatalf("Verify() error: %v", err)
 	}
@@ -1401,7 +1406,7 @@ func TestVerifyInternalEntries_MixedInternalExternal(t *testing.T) {
 	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{isDir: false}, nil).AnyTimes()
 
 	service := NewVerifyService(configStore, lockStore, cache, fs, "/test")
-	result, err := service.Verify(nil)
+	result, err := service.Verify(context.Background())
 	if err != nil {
 		t.Fatalf("Verify() error: %v", err)
 	}
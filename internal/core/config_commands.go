@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -75,6 +76,16 @@ func (s *VendorSyncer) RenameVendor(oldName, newName string) error {
 		return fmt.Errorf("save config: %w", err)
 	}
 
+	// License and notice copies move with the vendor (old path -> new path)
+	licenseDir := ResolveLicenseDir(s.rootDir, cfg)
+	licenseFiles := ResolveLicenseFiles(cfg)
+	oldCopies := vendorLicenseCopies(licenseDir, oldName, licenseFiles)
+	newCopies := vendorLicenseCopies(licenseDir, newName, licenseFiles)
+	renamedCopies := make(map[string]string, len(oldCopies))
+	for i := range oldCopies {
+		renamedCopies[filepath.ToSlash(oldCopies[i])] = filepath.ToSlash(newCopies[i])
+	}
+
 	// Update lockfile entries (best-effort — lockfile may not exist)
 	lock, err := s.lockStore.Load()
 	if err == nil {
@@ -82,6 +93,11 @@ func (s *VendorSyncer) RenameVendor(oldName, newName string) error {
 		for i := range lock.Vendors {
 			if lock.Vendors[i].Name == oldName {
 				lock.Vendors[i].Name = newName
+				for j, path := range lock.Vendors[i].LicenseFiles {
+					if renamed, ok := renamedCopies[path]; ok {
+						lock.Vendors[i].LicenseFiles[j] = renamed
+					}
+				}
 				changed = true
 			}
 		}
@@ -92,14 +108,33 @@ func (s *VendorSyncer) RenameVendor(oldName, newName string) error {
 		}
 	}
 
-	// Rename license file (best-effort)
-	oldLicense := filepath.Join(s.rootDir, LicensesDir, oldName+".txt")
-	newLicense := filepath.Join(s.rootDir, LicensesDir, newName+".txt")
-	_ = os.Rename(oldLicense, newLicense) //nolint:errcheck
+	// Rename license and notice files (best-effort)
+	for i := range oldCopies {
+		_ = os.Rename(oldCopies[i], newCopies[i]) //nolint:errcheck
+	}
 
 	return nil
 }
 
+// NormalizeConfig loads vendor.yml, canonicalizes it with NormalizeConfig, and
+// saves it only when the canonical form differs. Returns true when the file changed.
+func (s *VendorSyncer) NormalizeConfig() (bool, error) {
+	cfg, err := s.configStore.Load()
+	if err != nil {
+		return false, fmt.Errorf("load config: %w", err)
+	}
+
+	normalized := NormalizeConfig(cfg)
+	if reflect.DeepEqual(cfg, normalized) {
+		return false, nil
+	}
+
+	if err := s.configStore.Save(normalized); err != nil {
+		return false, fmt.Errorf("save config: %w", err)
+	}
+	return true, nil
+}
+
 // AddMappingToVendor adds a path mapping to an existing vendor's ref.
 // If ref is empty, the mapping is added to the first (default) BranchSpec.
 func (s *VendorSyncer) AddMappingToVendor(vendorName, from, to, ref string) error {
@@ -310,6 +345,9 @@ func (s *VendorSyncer) ShowVendor(name string) (map[string]interface{}, error) {
 			if entry.VendoredBy != "" {
 				specData["vendored_by"] = entry.VendoredBy
 			}
+			if entry.Updated != "" {
+				specData["updated"] = entry.Updated
+			}
 			if entry.LastSyncedAt != "" {
 				specData["last_synced_at"] = entry.LastSyncedAt
 			}
@@ -506,7 +544,7 @@ func (s *VendorSyncer) CheckVendorStatus(vendorName string) (map[string]interfac
 		for _, m := range spec.Mapping {
 			dest := m.To
 			if dest == "" {
-				dest = filepath.Base(m.From)
+				dest = ComputeAutoPath(m.From, spec.DefaultTarget, vendor.Name)
 			}
 			// Strip position specifier
 			dest, _, _ = types.ParsePathPosition(dest)
@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -198,6 +199,79 @@ func TestRenameVendor_NoLockfile(t *testing.T) {
 	assertNoError(t, err, "RenameVendor with no lockfile")
 }
 
+// ============================================================================
+// MoveVendor Tests
+// ============================================================================
+
+func TestMoveVendor_SingleFile(t *testing.T) {
+	chdirUnmanagedTest(t)
+	ctrl, _, _, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	writeFixTestFile(t, filepath.Join("lib", "util", "util.go"), "package util\n")
+	spec := createTestVendorSpec("util", "https://github.com/org/util", "main")
+	spec.Specs[0].Mapping = []types.PathMapping{{From: "util.go", To: "lib/util/util.go"}}
+	entry := createTestLockEntry("util", "main", "abc123")
+	entry.FileHashes = map[string]string{"lib/util/util.go": "h1"}
+
+	config.EXPECT().Load().Return(createTestConfig(spec), nil)
+	config.EXPECT().Save(gomock.Any()).DoAndReturn(func(cfg types.VendorConfig) error {
+		if to := cfg.Vendors[0].Specs[0].Mapping[0].To; to != "third_party/util/util.go" {
+			t.Errorf("mapping to = %q, want third_party/util/util.go", to)
+		}
+		return nil
+	})
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{entry}}, nil)
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		hashes := l.Vendors[0].FileHashes
+		if len(hashes) != 1 || hashes["third_party/util/util.go"] != "h1" {
+			t.Errorf("file_hashes = %v, want only third_party/util/util.go", hashes)
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(NewMockGitClient(ctrl), NewMockFileSystem(ctrl), config, lock, NewMockLicenseChecker(ctrl))
+	result, err := syncer.MoveVendor("util", "third_party/util")
+	assertNoError(t, err, "MoveVendor")
+	if result.From != "lib/util" || result.PathsMoved != 1 {
+		t.Errorf("result = %+v, want From lib/util and 1 path moved", result)
+	}
+
+	if data, err := os.ReadFile(filepath.Join("third_party", "util", "util.go")); err != nil || string(data) != "package util\n" {
+		t.Errorf("moved file = %q (%v)", data, err)
+	}
+	if _, err := os.Stat("lib"); !os.IsNotExist(err) {
+		t.Errorf("old destination should be removed once empty, stat err = %v", err)
+	}
+}
+
+func TestMoveVendor_ConflictAborts(t *testing.T) {
+	chdirUnmanagedTest(t)
+	ctrl, _, _, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	writeFixTestFile(t, filepath.Join("lib", "a", "a.go"), "package a\n")
+	a := createTestVendorSpec("a", "https://github.com/org/a", "main")
+	a.Specs[0].Mapping = []types.PathMapping{{From: "a.go", To: "lib/a/a.go"}}
+	b := createTestVendorSpec("b", "https://github.com/org/b", "main")
+	b.Specs[0].Mapping = []types.PathMapping{{From: "a.go", To: "lib/b/a.go"}}
+
+	config.EXPECT().Load().Return(createTestConfig(a, b), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{}, os.ErrNotExist)
+
+	syncer := createMockSyncer(NewMockGitClient(ctrl), NewMockFileSystem(ctrl), config, lock, NewMockLicenseChecker(ctrl))
+	_, err := syncer.MoveVendor("a", "lib/b")
+	if !IsDestinationConflict(err) {
+		t.Fatalf("expected DestinationConflictError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "written by b") {
+		t.Errorf("error should name the other vendor, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("lib", "a", "a.go")); err != nil {
+		t.Errorf("files must stay put on conflict: %v", err)
+	}
+}
+
 // ============================================================================
 // AddMappingToVendor Tests
 // ============================================================================
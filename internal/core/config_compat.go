@@ -0,0 +1,206 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/EmundoT/git-vendor/internal/version"
+)
+
+// configKeyVersions maps vendor.yml keys added after v1.0.0 to the git-vendor
+// release that introduced them. An unrecognized key listed here names the
+// upgrade target in the unknown-key warning; keys missing from the table get
+// a generic warning. No release after v1.0.0 is recorded in this tree, so
+// keys that are not yet in a tagged release are configKeyUnreleased; the
+// release that ships them replaces it with its version (RELEASE_PROCESS.md).
+var configKeyVersions = map[string]string{
+	"allowed_licenses":   configKeyUnreleased,
+	"block_on_drift":     configKeyUnreleased,
+	"block_on_stale":     configKeyUnreleased,
+	"compliance":         configKeyUnreleased,
+	"default_target":     configKeyUnreleased,
+	"depth":              configKeyUnreleased,
+	"direction":          configKeyUnreleased,
+	"exclude":            configKeyUnreleased,
+	"include":            configKeyUnreleased,
+	"license_dir":        configKeyUnreleased,
+	"license_files":      configKeyUnreleased,
+	"license_override":   configKeyUnreleased,
+	"max_depth":          configKeyUnreleased,
+	"max_staleness_days": configKeyUnreleased,
+	"mirrors":            configKeyUnreleased,
+	"pinned":             configKeyUnreleased,
+	"pinned_from":        configKeyUnreleased,
+	"policy":             configKeyUnreleased,
+	"post_sync":          configKeyUnreleased,
+	"recursive":          configKeyUnreleased,
+	"ref_aliases":        configKeyUnreleased,
+	"source":             configKeyUnreleased,
+	"spdx_headers":       configKeyUnreleased,
+	"transforms":         configKeyUnreleased,
+}
+
+// configKeyUnreleased marks a configKeyVersions key that no tagged release
+// has shipped yet.
+const configKeyUnreleased = ""
+
+// configExtraTopLevelKeys lists top-level vendor.yml keys decoded outside
+// VendorConfig (cascade is parsed by CascadeService).
+var configExtraTopLevelKeys = map[string]bool{
+	"cascade": true,
+}
+
+// unknownConfigKey is a vendor.yml mapping key that no VendorConfig field decodes.
+type unknownConfigKey struct {
+	Path string // Parent location, e.g. "vendors[0].specs[0]" ("" = top level)
+	Key  string
+	Line int // 0 when unknown (vendor.toml)
+}
+
+// findUnknownConfigKeys walks a parsed vendor.yml document against the YAML
+// field names of types.VendorConfig and returns every key the typed decode
+// would silently drop, in document order. Free-form maps (e.g. ref_aliases)
+// are not descended into.
+func findUnknownConfigKeys(doc *yaml.Node) []unknownConfigKey {
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	var unknown []unknownConfigKey
+	walkUnknownConfigKeys(node, reflect.TypeOf(types.VendorConfig{}), "", &unknown)
+	return unknown
+}
+
+// walkUnknownConfigKeys records keys of node that t has no YAML field for,
+// recursing into struct and slice-of-struct fields.
+func walkUnknownConfigKeys(node *yaml.Node, t reflect.Type, path string, unknown *[]unknownConfigKey) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			walkUnknownConfigKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := yamlFieldTypes(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			fieldType, ok := fields[key.Value]
+			if !ok {
+				if path == "" && configExtraTopLevelKeys[key.Value] {
+					continue
+				}
+				*unknown = append(*unknown, unknownConfigKey{Path: path, Key: key.Value, Line: key.Line})
+				continue
+			}
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+			walkUnknownConfigKeys(value, fieldType, childPath, unknown)
+		}
+	}
+}
+
+// yamlFieldTypes maps each YAML key decoded by struct type t to its field type,
+// following yaml.v3 naming (tag name, else the lowercased field name).
+func yamlFieldTypes(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// warnUnknownConfigKeys writes one warning per key in vendor.yml data that this
+// git-vendor does not understand. Keys in configKeyVersions name the release
+// that introduced them so the user knows which version to upgrade to.
+// Unparseable data writes nothing (the typed decode reports the error).
+func warnUnknownConfigKeys(data []byte, warnWriter io.Writer) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return
+	}
+	writeUnknownConfigKeys(warnWriter, ConfigFile, findUnknownConfigKeys(&doc))
+}
+
+// warnUnknownTOMLConfigKeys is warnUnknownConfigKeys for vendor.toml data. The
+// document is checked in the YAML form unmarshalConfigTOML decodes, so the
+// warnings carry no line numbers.
+func warnUnknownTOMLConfigKeys(data []byte, warnWriter io.Writer) {
+	var tree map[string]interface{}
+	if err := toml.Unmarshal(data, &tree); err != nil {
+		return
+	}
+	yamlData, err := yaml.Marshal(tree)
+	if err != nil {
+		return
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlData, &doc); err != nil {
+		return
+	}
+	unknown := findUnknownConfigKeys(&doc)
+	for i := range unknown {
+		unknown[i].Line = 0
+	}
+	writeUnknownConfigKeys(warnWriter, ConfigFileTOML, unknown)
+}
+
+// writeUnknownConfigKeys writes the warnings for keys found in configFile.
+func writeUnknownConfigKeys(warnWriter io.Writer, configFile string, unknown []unknownConfigKey) {
+	for _, k := range unknown {
+		location := "top level"
+		if k.Path != "" {
+			location = k.Path
+		}
+		if k.Line > 0 {
+			location += fmt.Sprintf(" (line %d)", k.Line)
+		}
+		if introduced, ok := configKeyVersions[k.Key]; ok {
+			since := "a release after v1.0.0"
+			if introduced != configKeyUnreleased {
+				since = "v" + introduced
+			}
+			//nolint:errcheck // Warning output - error is non-actionable
+			fmt.Fprintf(warnWriter,
+				"Warning: %s key %q at %s was ignored: it requires a newer git-vendor version (introduced in %s, running %s)\n"+
+					"  Upgrade git-vendor to use this config.\n",
+				configFile, k.Key, location, since, version.GetVersion())
+			continue
+		}
+		//nolint:errcheck // Warning output - error is non-actionable
+		fmt.Fprintf(warnWriter,
+			"Warning: %s key %q at %s is unknown and was ignored\n"+
+				"  Check for a typo, or upgrade git-vendor if the config was written for a newer version.\n",
+			configFile, k.Key, location)
+	}
+}
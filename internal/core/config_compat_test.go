@@ -0,0 +1,148 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestWarnUnknownConfigKeys_NewerVersionKey(t *testing.T) {
+	// exclude is only understood on mappings; on a spec it came from a newer schema
+	data := []byte(`vendors:
+  - name: lib
+    url: https://github.com/owner/lib
+    specs:
+      - ref: main
+        exclude: ["*.md"]
+        mapping:
+          - from: src
+            to: lib
+`)
+	var buf bytes.Buffer
+	warnUnknownConfigKeys(data, &buf)
+
+	out := buf.String()
+	for _, want := range []string{`key "exclude"`, "vendors[0].specs[0]", "line 6", "requires a newer git-vendor version", "introduced in a release after v1.0.0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("warning missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "Warning:"); n != 1 {
+		t.Errorf("got %d warnings, want 1:\n%s", n, out)
+	}
+}
+
+func TestWarnUnknownConfigKeys_UnlistedKeyIsGeneric(t *testing.T) {
+	data := []byte("vendors:\n  - name: lib\n    urll: https://github.com/owner/lib\n")
+	var buf bytes.Buffer
+	warnUnknownConfigKeys(data, &buf)
+
+	out := buf.String()
+	if !strings.Contains(out, `key "urll" at vendors[0] (line 3) is unknown`) {
+		t.Errorf("unexpected warning:\n%s", out)
+	}
+	if strings.Contains(out, "requires a newer") {
+		t.Errorf("unlisted key should not name a version:\n%s", out)
+	}
+}
+
+func TestWarnUnknownConfigKeys_KnownConfigIsSilent(t *testing.T) {
+	data := []byte(`cascade:
+  root: ..
+ref_aliases:
+  release/1.x:
+    main: v1.4.0
+vendors:
+  - name: lib
+    url: https://github.com/owner/lib
+    license_override: MIT
+    hooks:
+      post_sync: make
+    specs:
+      - ref: main
+        mapping:
+          - from: src
+            to: [lib/a, lib/b]
+            exclude: ["*.md"]
+`)
+	var buf bytes.Buffer
+	warnUnknownConfigKeys(data, &buf)
+	if buf.Len() != 0 {
+		t.Errorf("expected no warnings for a valid config, got:\n%s", buf.String())
+	}
+}
+
+func TestFileConfigStore_LoadWarnsUnknownKeysOnce(t *testing.T) {
+	dir := t.TempDir()
+	content := "allowed_licences: [MIT]\nvendors: []\n"
+	if err := os.WriteFile(filepath.Join(dir, ConfigFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileConfigStore(dir)
+	var buf bytes.Buffer
+	store.warnWriter = &buf
+	for i := 0; i < 2; i++ {
+		if _, err := store.Load(); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+	}
+	if n := strings.Count(buf.String(), "Warning:"); n != 1 {
+		t.Errorf("got %d warnings across two loads, want 1:\n%s", n, buf.String())
+	}
+}
+
+func TestFileConfigStore_LoadWarnsUnknownTOMLKeys(t *testing.T) {
+	dir := t.TempDir()
+	content := "[[vendors]]\nname = \"lib\"\nurl = \"https://github.com/owner/lib\"\ndepth = 1\n"
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileTOML), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileConfigStore(dir)
+	var buf bytes.Buffer
+	store.warnWriter = &buf
+	if _, err := store.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{ConfigFileTOML, `key "depth" at vendors[0] was ignored`, "requires a newer git-vendor version"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("warning missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestConfigKeyVersions_KeysAreConfigKeys verifies that every key in the
+// version table is a key vendor.yml decodes, so the table cannot drift from
+// types.VendorConfig.
+func TestConfigKeyVersions_KeysAreConfigKeys(t *testing.T) {
+	known := map[string]bool{}
+	var collect func(reflect.Type)
+	collect = func(rt reflect.Type) {
+		for rt.Kind() == reflect.Ptr || rt.Kind() == reflect.Slice {
+			rt = rt.Elem()
+		}
+		if rt.Kind() != reflect.Struct {
+			return
+		}
+		for key, fieldType := range yamlFieldTypes(rt) {
+			if !known[key] {
+				known[key] = true
+				collect(fieldType)
+			}
+		}
+	}
+	collect(reflect.TypeOf(types.VendorConfig{}))
+
+	for key := range configKeyVersions {
+		if !known[key] {
+			t.Errorf("configKeyVersions lists %q, which no vendor.yml field decodes", key)
+		}
+	}
+}
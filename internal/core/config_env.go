@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// configEnvRef matches ${VAR} and ${VAR:-default} in config values.
+var configEnvRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandConfigEnv substitutes environment variables into the url, ref, and
+// mapping from/to of every vendor in cfg: ${VAR} takes VAR's value, and
+// ${VAR:-default} takes default when VAR is unset or empty. A ${VAR} whose
+// variable is unset returns a ValidationError naming it.
+//
+// Each expanded field keeps its written form on the spec that owns it
+// (VendorSpec.URLTemplate, BranchSpec.RefTemplate, PathMapping.FromTemplate
+// and ToTemplate), so the template follows the vendor through renames and
+// reordering and RestoreConfigEnv can write it back.
+func expandConfigEnv(cfg *types.VendorConfig) error {
+	expand := func(vendor, ref, field string, value, template *string) error {
+		expanded, err := expandEnvRefs(*value)
+		if err != nil {
+			return NewValidationError(vendor, ref, field, err.Error())
+		}
+		if expanded != *value {
+			*template = *value
+			*value = expanded
+		}
+		return nil
+	}
+
+	for vi := range cfg.Vendors {
+		v := &cfg.Vendors[vi]
+		if err := expand(v.Name, "", "url", &v.URL, &v.URLTemplate); err != nil {
+			return err
+		}
+		for si := range v.Specs {
+			spec := &v.Specs[si]
+			if err := expand(v.Name, spec.Ref, "ref", &spec.Ref, &spec.RefTemplate); err != nil {
+				return err
+			}
+			for mi := range spec.Mapping {
+				m := &spec.Mapping[mi]
+				if err := expand(v.Name, spec.Ref, "from", &m.From, &m.FromTemplate); err != nil {
+					return err
+				}
+				if err := expand(v.Name, spec.Ref, "to", &m.To, &m.ToTemplate); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreConfigEnv returns cfg with every field that still holds the
+// expansion of its recorded template set back to its ${...} form, as written
+// in vendor.yml. Fields changed since Load are kept as they are. cfg's slices
+// are copied, never modified.
+//
+// Save writes the result; commands that print the config use it too, so
+// expanded secrets never reach their output.
+func RestoreConfigEnv(cfg types.VendorConfig) types.VendorConfig {
+	cfg.Vendors = append([]types.VendorSpec(nil), cfg.Vendors...)
+	for vi := range cfg.Vendors {
+		cfg.Vendors[vi] = RestoreVendorEnv(cfg.Vendors[vi])
+	}
+	return cfg
+}
+
+// RestoreVendorEnv is RestoreConfigEnv for one vendor.
+func RestoreVendorEnv(v types.VendorSpec) types.VendorSpec {
+	v.URL = restoreEnvValue(v.URL, v.URLTemplate)
+	v.Specs = append([]types.BranchSpec(nil), v.Specs...)
+	for si := range v.Specs {
+		spec := &v.Specs[si]
+		spec.Ref = restoreEnvValue(spec.Ref, spec.RefTemplate)
+		spec.Mapping = append([]types.PathMapping(nil), spec.Mapping...)
+		for mi := range spec.Mapping {
+			m := &spec.Mapping[mi]
+			m.From = restoreEnvValue(m.From, m.FromTemplate)
+			m.To = restoreEnvValue(m.To, m.ToTemplate)
+		}
+	}
+	return v
+}
+
+// restoreEnvValue returns template when value is still what template expands
+// to, otherwise value.
+func restoreEnvValue(value, template string) string {
+	if template == "" {
+		return value
+	}
+	if expanded, err := expandEnvRefs(template); err == nil && expanded == value {
+		return template
+	}
+	return value
+}
+
+// expandEnvRefs expands the ${VAR} and ${VAR:-default} references in s.
+func expandEnvRefs(s string) (string, error) {
+	var missing string
+	expanded := configEnvRef.ReplaceAllStringFunc(s, func(ref string) string {
+		parts := configEnvRef.FindStringSubmatch(ref)
+		name, hasDefault, def := parts[1], parts[2] != "", parts[3]
+		value, ok := os.LookupEnv(name)
+		switch {
+		case hasDefault && value == "":
+			return def
+		case !ok && missing == "":
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("environment variable %s is not set and %q gives no default (use ${%s:-<default>})", missing, s, missing)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ConfigSchemaID is the $id of the schema returned by ConfigSchema.
+const ConfigSchemaID = "https://github.com/EmundoT/git-vendor/schema/vendor.schema.json"
+
+// schemaEnums lists the accepted values of string fields that are validated
+// against fixed sets, keyed by "<Go type>.<yaml key>".
+var schemaEnums = map[string][]string{
+	"VendorSpec.source":        {"external", SourceInternal},
+	"VendorSpec.direction":     {ComplianceSourceCanonical, ComplianceBidirectional},
+	"VendorSpec.compliance":    {EnforcementStrict, EnforcementLenient, EnforcementInfo},
+	"ComplianceConfig.default": {EnforcementStrict, EnforcementLenient, EnforcementInfo},
+	"ComplianceConfig.mode":    {ComplianceModeDefault, ComplianceModeOverride},
+}
+
+// schemaRequired lists the keys a config must set, keyed by Go type. yaml
+// tags can't express this: fields without omitempty (e.g. license, to) are
+// still optional in hand-written configs.
+var schemaRequired = map[string][]string{
+	"VendorConfig": {"vendors"},
+	"VendorSpec":   {"name", "specs"},
+	"BranchSpec":   {"ref"},
+	"PathMapping":  {"from"},
+	"Transform":    {"pattern"},
+}
+
+// schemaOverrides replaces the reflected schema of fields whose YAML form
+// differs from their Go type, keyed like schemaEnums.
+var schemaOverrides = map[string]map[string]interface{}{
+	// PathMapping decoding accepts a destination list (see types.PathMapping)
+	"PathMapping.to": {"oneOf": []interface{}{
+		map[string]interface{}{"type": "string"},
+		map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+	}},
+}
+
+// ConfigSchema returns a JSON Schema (draft 2020-12) for vendor.yml. The
+// properties are generated by reflection from the yaml tags of
+// types.VendorConfig and the types it contains, so new config fields appear
+// without editing the schema; enums, required keys and overrides above add
+// what the tags can't express. Unknown keys are allowed, matching the loader.
+func ConfigSchema() []byte {
+	defs := make(map[string]interface{})
+	root := schemaForStruct(reflect.TypeOf(types.VendorConfig{}), defs)
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["$id"] = ConfigSchemaID
+	root["title"] = "git-vendor configuration (vendor.yml)"
+	root["$defs"] = defs
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		// Only maps, slices and strings are marshaled; failure is a programming error
+		panic("config schema: " + err.Error())
+	}
+	return append(data, '\n')
+}
+
+// schemaForStruct returns an object schema for struct type t, registering
+// nested struct types in defs under their Go type name.
+func schemaForStruct(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := yamlKey(field)
+		if key == "" {
+			continue
+		}
+		name := t.Name() + "." + key
+		if override, ok := schemaOverrides[name]; ok {
+			properties[key] = override
+			continue
+		}
+		prop := schemaForType(field.Type, defs)
+		if values, ok := schemaEnums[name]; ok {
+			prop["enum"] = values
+		}
+		properties[key] = prop
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if required, ok := schemaRequired[t.Name()]; ok {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// schemaForType maps a Go type to its JSON Schema.
+func schemaForType(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaForType(t.Elem(), defs)}
+	case reflect.Struct:
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = nil // Reserve the name first so recursive types terminate
+			defs[t.Name()] = schemaForStruct(t, defs)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	}
+	return map[string]interface{}{}
+}
+
+// yamlKey returns the YAML key of a struct field as yaml.v3 names it (tag, or
+// the lowercased field name when untagged), or "" when the field is not
+// serialized (yaml:"-" or unexported).
+func yamlKey(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch key {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	}
+	return key
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConfigSchema_ValidJSONWithVendors(t *testing.T) {
+	var schema struct {
+		Schema     string                     `json:"$schema"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+		Defs       map[string]struct {
+			Properties map[string]struct {
+				Ref   string            `json:"$ref"`
+				Enum  []string          `json:"enum"`
+				OneOf []json.RawMessage `json:"oneOf"`
+			} `json:"properties"`
+			Required []string `json:"required"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(ConfigSchema(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if schema.Schema == "" {
+		t.Error("missing $schema")
+	}
+	if _, ok := schema.Properties["vendors"]; !ok {
+		t.Fatal("schema has no vendors property")
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "vendors" {
+		t.Errorf("required = %v, want [vendors]", schema.Required)
+	}
+
+	vendor := schema.Defs["VendorSpec"]
+	if got := vendor.Properties["source"].Enum; len(got) != 2 || got[1] != SourceInternal {
+		t.Errorf("VendorSpec.source enum = %v", got)
+	}
+	if got := vendor.Properties["compliance"].Enum; len(got) != 3 {
+		t.Errorf("VendorSpec.compliance enum = %v", got)
+	}
+	if vendor.Properties["specs"].Ref != "" {
+		t.Errorf("specs should be an array schema, got $ref %q", vendor.Properties["specs"].Ref)
+	}
+
+	// Generated from yaml tags: yaml:"-" fields stay out, list-valued "to" is allowed
+	branch := schema.Defs["BranchSpec"]
+	if _, ok := branch.Properties["BaseRef"]; ok {
+		t.Error("yaml:\"-\" field BaseRef leaked into the schema")
+	}
+	if _, ok := branch.Properties["default_target"]; !ok {
+		t.Error("BranchSpec.default_target missing")
+	}
+	if len(schema.Defs["PathMapping"].Properties["to"].OneOf) != 2 {
+		t.Error("PathMapping.to should accept a string or a list")
+	}
+}
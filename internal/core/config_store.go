@@ -2,6 +2,13 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
 	"github.com/EmundoT/git-vendor/internal/types"
 )
 
@@ -12,29 +19,142 @@ type ConfigStore interface {
 	Path() string
 }
 
-// FileConfigStore implements ConfigStore using YAMLStore
+// FileConfigStore implements ConfigStore using YAMLStore, or TOML when the
+// config lives in vendor.toml (see Format).
 type FileConfigStore struct {
-	store *YAMLStore[types.VendorConfig]
+	store      *YAMLStore[types.VendorConfig]
+	rootDir    string
+	format     string    // Forced format; "" = detect from the files present
+	warnWriter io.Writer // Unknown-key warnings (default: os.Stderr)
+	warnOnce   sync.Once
 }
 
 // NewFileConfigStore creates a new FileConfigStore
 func NewFileConfigStore(rootDir string) *FileConfigStore {
 	return &FileConfigStore{
-		store: NewYAMLStore[types.VendorConfig](rootDir, ConfigFile, true), // allowMissing=true
+		store:      NewYAMLStore[types.VendorConfig](rootDir, ConfigFile, true), // allowMissing=true
+		rootDir:    rootDir,
+		warnWriter: os.Stderr,
+	}
+}
+
+// Format returns the config format in use: the one set by SetFormat, else
+// ConfigFormatTOML when only vendor.toml exists, else ConfigFormatYAML
+// (vendor.yml wins when both exist).
+func (s *FileConfigStore) Format() string {
+	if s.format != "" {
+		return s.format
+	}
+	if _, err := os.Stat(s.store.Path()); errors.Is(err, os.ErrNotExist) {
+		if _, err := os.Stat(filepath.Join(s.rootDir, ConfigFileTOML)); err == nil {
+			return ConfigFormatTOML
+		}
 	}
+	return ConfigFormatYAML
+}
+
+// SetFormat forces the config format instead of detecting it, e.g. so init
+// creates vendor.toml.
+func (s *FileConfigStore) SetFormat(format string) error {
+	if err := ValidateConfigFormat(format); err != nil {
+		return err
+	}
+	s.format = format
+	return nil
 }
 
 // Path returns the config file path
 func (s *FileConfigStore) Path() string {
+	if s.Format() == ConfigFormatTOML {
+		return filepath.Join(s.rootDir, ConfigFileTOML)
+	}
 	return s.store.Path()
 }
 
-// Load reads and parses vendor.yml
+// Load reads and parses vendor.yml (or vendor.toml).
+// On the first successful Load of either file, keys the typed decode would
+// silently drop (e.g. fields from a newer git-vendor) are reported to
+// warnWriter, naming the release that introduced them when known (see
+// configKeyVersions).
+//
+// ${VAR} and ${VAR:-default} in vendor url, ref, and mapping from/to are
+// expanded against the environment (see expandConfigEnv); Save writes
+// unchanged expanded values back in their ${...} form. Backslash separators
+// in mapping paths are read as forward slashes (slashConfigPaths).
 func (s *FileConfigStore) Load() (types.VendorConfig, error) {
-	return s.store.Load()
+	var cfg types.VendorConfig
+	var err error
+	if s.Format() == ConfigFormatTOML {
+		cfg, err = s.loadTOML()
+	} else {
+		cfg, err = s.store.Load()
+	}
+	if err != nil {
+		return cfg, err
+	}
+	s.warnOnce.Do(func() {
+		// Size was checked by the load above; a read failure here only skips the warning
+		data, readErr := os.ReadFile(s.Path())
+		if readErr != nil || s.warnWriter == nil {
+			return
+		}
+		if s.Format() == ConfigFormatTOML {
+			warnUnknownTOMLConfigKeys(data, s.warnWriter)
+		} else {
+			warnUnknownConfigKeys(data, s.warnWriter)
+		}
+	})
+
+	cfg = slashConfigPaths(cfg)
+	if err := expandConfigEnv(&cfg); err != nil {
+		return types.VendorConfig{}, err
+	}
+	return cfg, nil
 }
 
-// Save writes vendor.yml
+// Save writes vendor.yml (or vendor.toml), with forward-slash mapping paths.
 func (s *FileConfigStore) Save(cfg types.VendorConfig) error {
+	cfg = RestoreConfigEnv(cfg)
+	cfg = slashConfigPaths(cfg)
+	if s.Format() == ConfigFormatTOML {
+		data, err := marshalConfigTOML(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", ConfigFileTOML, err)
+		}
+		if err := os.WriteFile(s.Path(), data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", ConfigFileTOML, err)
+		}
+		return nil
+	}
 	return s.store.Save(cfg)
 }
+
+// loadTOML reads vendor.toml with the same size limit and missing-file
+// handling as YAMLStore.Load.
+func (s *FileConfigStore) loadTOML() (types.VendorConfig, error) {
+	data, err := readTOMLFile(s.Path())
+	if err != nil || data == nil {
+		return types.VendorConfig{}, err
+	}
+	cfg, err := unmarshalConfigTOML(data)
+	if err != nil {
+		return types.VendorConfig{}, fmt.Errorf("invalid %s: %w", ConfigFileTOML, err)
+	}
+	return cfg, nil
+}
+
+// readTOMLFile reads the vendor.toml at path, rejecting files over
+// maxYAMLFileSize. A missing file returns nil data and no error.
+func readTOMLFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if info.Size() > maxYAMLFileSize {
+		return nil, fmt.Errorf("%s exceeds maximum size (%d bytes > %d byte limit)", ConfigFileTOML, info.Size(), maxYAMLFileSize)
+	}
+	return os.ReadFile(path)
+}
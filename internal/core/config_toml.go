@@ -0,0 +1,80 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// Config file formats accepted by FileConfigStore and "init --format".
+const (
+	ConfigFormatYAML = "yaml"
+	ConfigFormatTOML = "toml"
+)
+
+// ConfigFormatForPath returns the config format implied by path's extension:
+// ConfigFormatTOML for ".toml", ConfigFormatYAML otherwise.
+func ConfigFormatForPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		return ConfigFormatTOML
+	}
+	return ConfigFormatYAML
+}
+
+// ValidateConfigFormat returns an error unless format is ConfigFormatYAML or
+// ConfigFormatTOML.
+func ValidateConfigFormat(format string) error {
+	switch format {
+	case ConfigFormatYAML, ConfigFormatTOML:
+		return nil
+	}
+	return fmt.Errorf("unsupported config format %q (expected %s or %s)", format, ConfigFormatYAML, ConfigFormatTOML)
+}
+
+// marshalConfigTOML encodes cfg as TOML. The config is first marshaled to
+// YAML and decoded into a generic map, so TOML keys, omitempty handling and
+// field names all come from the existing yaml struct tags.
+func marshalConfigTOML(cfg types.VendorConfig) ([]byte, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalConfigTOML decodes TOML data into a VendorConfig by way of the
+// same YAML decoding FileConfigStore uses for vendor.yml, so both formats
+// produce identical in-memory configs.
+func unmarshalConfigTOML(data []byte) (types.VendorConfig, error) {
+	return unmarshalTOMLAs[types.VendorConfig](data)
+}
+
+// unmarshalTOMLAs decodes TOML data into T through T's yaml struct tags, as
+// unmarshalConfigTOML does for VendorConfig.
+func unmarshalTOMLAs[T any](data []byte) (T, error) {
+	var cfg T
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return cfg, err
+	}
+	yamlData, err := yaml.Marshal(doc)
+	if err != nil {
+		return cfg, err
+	}
+	err = yaml.Unmarshal(yamlData, &cfg)
+	return cfg, err
+}
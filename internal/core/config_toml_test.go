@@ -0,0 +1,143 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func richTestConfig() types.VendorConfig {
+	block := true
+	days := 30
+	return types.VendorConfig{
+		Policy:          &types.VendorPolicy{BlockOnDrift: &block, MaxStalenessDays: &days},
+		LicenseDir:      "third_party/licenses",
+		AllowedLicenses: []string{"MIT", "Apache-2.0"},
+		RefAliases:      map[string]map[string]string{"release": {"main": "stable"}},
+		Vendors: []types.VendorSpec{
+			{
+				Name:    "lib",
+				URL:     "https://github.com/owner/lib",
+				Mirrors: []string{"https://gitlab.com/owner/lib"},
+				License: "MIT",
+				Groups:  []string{"core"},
+				Hooks:   &types.HookConfig{PostSync: "make generate"},
+				Specs: []types.BranchSpec{
+					{Ref: "main", Depth: -1, Mapping: []types.PathMapping{
+						{From: "src", To: "vendor/lib", Include: []string{"*.go"}, Exclude: []string{"*_test.go"}},
+						{From: "api.go:L5-L20", To: "vendor/api.go"},
+					}},
+					{Ref: "v2", DefaultTarget: "vendor/lib2", Mapping: []types.PathMapping{{From: "README.md"}}},
+				},
+			},
+			{Name: "tools", URL: "https://github.com/owner/tools", License: "Apache-2.0", Source: SourceInternal, Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{}}}},
+		},
+	}
+}
+
+func TestFileConfigStore_TOMLRoundTripMatchesYAML(t *testing.T) {
+	yamlDir, tomlDir := t.TempDir(), t.TempDir()
+	cfg := richTestConfig()
+
+	if err := NewFileConfigStore(yamlDir).Save(cfg); err != nil {
+		t.Fatalf("save yaml: %v", err)
+	}
+	tomlStore := NewFileConfigStore(tomlDir)
+	if err := tomlStore.SetFormat(ConfigFormatTOML); err != nil {
+		t.Fatal(err)
+	}
+	if err := tomlStore.Save(cfg); err != nil {
+		t.Fatalf("save toml: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tomlDir, ConfigFile)); !os.IsNotExist(err) {
+		t.Errorf("TOML store must not write %s (stat err %v)", ConfigFile, err)
+	}
+
+	// A fresh store detects vendor.toml on its own
+	detected := NewFileConfigStore(tomlDir)
+	if detected.Format() != ConfigFormatTOML || filepath.Base(detected.Path()) != ConfigFileTOML {
+		t.Errorf("detected format %q path %q, want toml", detected.Format(), detected.Path())
+	}
+	fromTOML, err := detected.Load()
+	if err != nil {
+		t.Fatalf("load toml: %v", err)
+	}
+	fromYAML, err := NewFileConfigStore(yamlDir).Load()
+	if err != nil {
+		t.Fatalf("load yaml: %v", err)
+	}
+	if !reflect.DeepEqual(fromTOML, fromYAML) {
+		t.Errorf("TOML config differs from YAML config:\ntoml: %+v\nyaml: %+v", fromTOML, fromYAML)
+	}
+	if !reflect.DeepEqual(fromTOML, cfg) {
+		t.Errorf("TOML config differs from the saved config:\ngot:  %+v\nwant: %+v", fromTOML, cfg)
+	}
+}
+
+func TestFileConfigStore_LoadsHandWrittenTOML(t *testing.T) {
+	dir := t.TempDir()
+	data := `license_files = ["LICENSE", "NOTICE"]
+
+[[vendors]]
+name = "lib"
+url = "https://github.com/owner/lib"
+license = "MIT"
+
+  [[vendors.specs]]
+  ref = "main"
+
+    [[vendors.specs.mapping]]
+    from = "util.go#Parse"
+    to = "internal/parse.go"
+`
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileTOML), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewFileConfigStore(dir).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.LicenseFiles) != 2 || len(cfg.Vendors) != 1 {
+		t.Fatalf("config = %+v", cfg)
+	}
+	m := cfg.Vendors[0].Specs[0].Mapping
+	if len(m) != 1 || m[0].From != "util.go#Parse" || m[0].To != "internal/parse.go" {
+		t.Errorf("mapping = %+v", m)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileTOML), []byte("vendors = ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileConfigStore(dir).Load(); err == nil || !strings.Contains(err.Error(), "invalid vendor.toml") {
+		t.Errorf("malformed TOML: err = %v", err)
+	}
+}
+
+func TestInitFormat_TOML(t *testing.T) {
+	rootDir := filepath.Join(t.TempDir(), VendorDir)
+	syncer := &VendorSyncer{configStore: NewFileConfigStore(rootDir), fs: NewOSFileSystem(), rootDir: rootDir}
+	if err := syncer.InitFormat(ConfigFormatTOML); err != nil {
+		t.Fatalf("InitFormat: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, ConfigFileTOML)); err != nil {
+		t.Errorf("expected %s: %v", ConfigFileTOML, err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, ConfigFile)); !os.IsNotExist(err) {
+		t.Errorf("%s should not be created (stat err %v)", ConfigFile, err)
+	}
+
+	// vendor.yml would shadow vendor.toml, so switching formats is refused
+	yamlRoot := filepath.Join(t.TempDir(), VendorDir)
+	if err := (&VendorSyncer{configStore: NewFileConfigStore(yamlRoot), fs: NewOSFileSystem(), rootDir: yamlRoot}).Init(); err != nil {
+		t.Fatal(err)
+	}
+	err := (&VendorSyncer{configStore: NewFileConfigStore(yamlRoot), fs: NewOSFileSystem(), rootDir: yamlRoot}).InitFormat(ConfigFormatTOML)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("InitFormat over vendor.yml: err = %v", err)
+	}
+}
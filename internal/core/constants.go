@@ -8,12 +8,20 @@ const (
 	VendorDir = ".git-vendor"
 	// ConfigFile is the vendor configuration filename
 	ConfigFile = "vendor.yml"
+	// ConfigFileTOML is the TOML alternative to ConfigFile (see FileConfigStore)
+	ConfigFileTOML = "vendor.toml"
 	// LockFile is the vendor lock filename
 	LockFile = "vendor.lock"
 	// LicensesDir is the directory containing cached license files
 	LicensesDir = "licenses"
 	// CacheDir is the directory for incremental sync cache
 	CacheDir = ".cache"
+	// SnapshotsDir is the directory holding per-commit tar.gz snapshots for offline restore
+	SnapshotsDir = ".snapshots"
+	// SourcesCacheDir is the cache subdirectory holding upstream source files keyed by commit
+	SourcesCacheDir = "sources"
+	// CheckpointsDir is the cache subdirectory holding CopyDir resume manifests
+	CheckpointsDir = "checkpoints"
 )
 
 // Full paths relative to project root.
@@ -27,6 +35,8 @@ const (
 	LicensesPath = VendorDir + "/" + LicensesDir
 	// CachePath is the full path to the cache directory
 	CachePath = VendorDir + "/" + CacheDir
+	// SnapshotsPath is the full path to the snapshots directory
+	SnapshotsPath = VendorDir + "/" + SnapshotsDir
 )
 
 // Project-root configuration files (outside .git-vendor/).
@@ -74,7 +84,8 @@ const (
 )
 
 // AllowedLicenses defines the list of open-source licenses permitted by default.
-// AllowedLicenses uses SPDX license identifiers.
+// AllowedLicenses uses SPDX license identifiers. A non-empty allowed_licenses
+// list in vendor.yml replaces it (ResolveAllowedLicenses).
 var AllowedLicenses = []string{
 	"MIT",
 	"Apache-2.0",
@@ -85,6 +96,56 @@ var AllowedLicenses = []string{
 	"CC0-1.0",
 }
 
+// KnownSPDXLicenses lists the SPDX license identifiers accepted as a vendor's
+// license_override. KnownSPDXLicenses covers the licenses git-vendor can
+// detect plus other widely used SPDX IDs; matching is case-insensitive.
+var KnownSPDXLicenses = []string{
+	"0BSD",
+	"AGPL-3.0",
+	"AGPL-3.0-only",
+	"AGPL-3.0-or-later",
+	"Apache-1.1",
+	"Apache-2.0",
+	"Artistic-2.0",
+	"BSD-2-Clause",
+	"BSD-3-Clause",
+	"BSD-4-Clause",
+	"BSL-1.0",
+	"CC-BY-4.0",
+	"CC-BY-SA-4.0",
+	"CC0-1.0",
+	"CDDL-1.0",
+	"EPL-1.0",
+	"EPL-2.0",
+	"EUPL-1.2",
+	"GPL-2.0",
+	"GPL-2.0-only",
+	"GPL-2.0-or-later",
+	"GPL-3.0",
+	"GPL-3.0-only",
+	"GPL-3.0-or-later",
+	"ISC",
+	"LGPL-2.1",
+	"LGPL-2.1-only",
+	"LGPL-2.1-or-later",
+	"LGPL-3.0",
+	"LGPL-3.0-only",
+	"LGPL-3.0-or-later",
+	"MIT",
+	"MIT-0",
+	"MPL-1.1",
+	"MPL-2.0",
+	"MS-PL",
+	"NCSA",
+	"OFL-1.1",
+	"PostgreSQL",
+	"Python-2.0",
+	"Unlicense",
+	"UPL-1.0",
+	"WTFPL",
+	"Zlib",
+}
+
 // LicenseFileNames lists standard filenames checked when searching for repository licenses.
 // LicenseFileNames entries are checked in order when detecting licenses via file content.
 var LicenseFileNames = []string{
@@ -93,3 +154,16 @@ var LicenseFileNames = []string{
 	"LICENSE.md",
 	"COPYING",
 }
+
+// LicenseCaptureFiles lists the repository-root files copied into the license
+// directory on sync, in priority order: the first present is the vendor's
+// primary license (<vendor>.txt), the rest are kept as <vendor>.<filename>.
+// A non-empty license_files list in vendor.yml replaces it (ResolveLicenseFiles).
+var LicenseCaptureFiles = []string{
+	"LICENSE",
+	"LICENSE.md",
+	"LICENSE.txt",
+	"COPYING",
+	"NOTICE",
+	"COPYRIGHT",
+}
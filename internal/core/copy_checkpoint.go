@@ -0,0 +1,158 @@
+package core
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// checkpointPath returns the manifest CopyDir (and copyDirFiltered) keeps in dir while copying into
+// dst. Each line records one file already copied, so a retried copy after a
+// failure skips completed files. Manifests are named by the SHA-256 of dst's
+// absolute path, so copies into different directories never share one. The
+// manifest is removed once the copy finishes; its presence marks an
+// incomplete copy.
+func checkpointPath(dir, dst string) string {
+	if abs, err := filepath.Abs(dst); err == nil {
+		dst = abs
+	}
+	sum := sha256.Sum256([]byte(filepath.ToSlash(dst)))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".jsonl")
+}
+
+// checkpointEntry is one line of the checkpoint manifest.
+type checkpointEntry struct {
+	Path   string `json:"path"`             // Path relative to the copied directory
+	SHA256 string `json:"sha256"`           // Hash of the file as copied
+	Source string `json:"source,omitempty"` // Hash of the source when transforms rewrote the copy
+}
+
+// copyCheckpoint tracks the files already copied into one destination directory.
+type copyCheckpoint struct {
+	path    string
+	done    map[string]string // relative path → hash at copy time
+	sources map[string]string // relative path → source hash, for rewritten copies
+	file    *os.File
+}
+
+// openCopyCheckpoint loads the manifest in dir left by an interrupted copy
+// into dst, if any, and opens it for appending. A truncated final line (the
+// copy died while recording it) is ignored; that file is simply copied again.
+// An empty dir disables checkpointing: the result is nil, and a nil
+// *copyCheckpoint records nothing and completes nothing.
+func openCopyCheckpoint(dir, dst string) (*copyCheckpoint, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	cp := &copyCheckpoint{
+		path:    checkpointPath(dir, dst),
+		done:    make(map[string]string),
+		sources: make(map[string]string),
+	}
+
+	if f, err := os.Open(cp.path); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var entry checkpointEntry
+			if json.Unmarshal(scanner.Bytes(), &entry) == nil && entry.Path != "" {
+				cp.done[entry.Path] = entry.SHA256
+				if entry.Source != "" {
+					cp.sources[entry.Path] = entry.Source
+				}
+			}
+		}
+		_ = f.Close()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(cp.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	cp.file = f
+	return cp, nil
+}
+
+// completed reports whether relPath was copied by an earlier attempt and is
+// still intact: the recorded hash must match both the source and the file
+// currently at destPath (the source must match the recorded source hash
+// instead for a rewritten copy). Any mismatch (tampered destination, changed
+// source) means the file is copied again. Returns the recorded hash when intact.
+func (cp *copyCheckpoint) completed(relPath, srcPath, destPath string) (string, bool) {
+	if cp == nil {
+		return "", false
+	}
+	recorded, ok := cp.done[relPath]
+	if !ok {
+		return "", false
+	}
+	destHash, err := fileSHA256(destPath)
+	if err != nil || destHash != recorded {
+		return "", false
+	}
+	wantSrc := recorded
+	if source, ok := cp.sources[relPath]; ok {
+		wantSrc = source
+	}
+	srcHash, err := fileSHA256(srcPath)
+	if err != nil || srcHash != wantSrc {
+		return "", false
+	}
+	return recorded, true
+}
+
+// record appends relPath to the manifest once it has been copied; hash is the
+// SHA-256 CopyFile computed while writing it.
+func (cp *copyCheckpoint) record(relPath, hash string) error {
+	return cp.recordEntry(checkpointEntry{Path: relPath, SHA256: hash})
+}
+
+// recordRewritten is record for a copy that transforms rewrote after CopyFile:
+// hash is the file as rewritten and sourceHash the source it was copied from.
+func (cp *copyCheckpoint) recordRewritten(relPath, hash, sourceHash string) error {
+	if hash == sourceHash {
+		return cp.record(relPath, hash)
+	}
+	return cp.recordEntry(checkpointEntry{Path: relPath, SHA256: hash, Source: sourceHash})
+}
+
+// recordEntry appends entry to the manifest.
+func (cp *copyCheckpoint) recordEntry(entry checkpointEntry) error {
+	if cp == nil {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = cp.file.Write(append(line, '\n'))
+	return err
+}
+
+// close releases the manifest, leaving it on disk for the next attempt.
+func (cp *copyCheckpoint) close() {
+	if cp == nil {
+		return
+	}
+	_ = cp.file.Close()
+}
+
+// finish removes the manifest after a successful copy.
+func (cp *copyCheckpoint) finish() error {
+	if cp == nil {
+		return nil
+	}
+	cp.close()
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
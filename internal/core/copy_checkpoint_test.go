@@ -0,0 +1,198 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// setupInterruptedCopy writes a four-file source tree and runs a CopyDir that
+// fails at c.txt (a directory sits at its destination), leaving a checkpoint
+// for a.txt and b.txt. Returns the checkpointing filesystem, src, dst and the
+// blocking directory.
+func setupInterruptedCopy(t *testing.T) (*OSFileSystem, string, string, string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	fs := &OSFileSystem{checkpointDir: filepath.Join(tempDir, "checkpoints")}
+	srcDir := filepath.Join(tempDir, "src")
+	destDir := filepath.Join(tempDir, "dest")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte("content of "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	blocker := filepath.Join(destDir, "c.txt")
+	if err := os.MkdirAll(filepath.Join(blocker, "keep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fs.CopyDir(srcDir, destDir); err == nil {
+		t.Fatal("expected the first CopyDir to fail at c.txt")
+	}
+	if _, err := os.Stat(checkpointPath(fs.checkpointDir, destDir)); err != nil {
+		t.Fatalf("expected checkpoint manifest after failed copy: %v", err)
+	}
+	return fs, srcDir, destDir, blocker
+}
+
+func TestCopyDir_ResumesFromCheckpoint(t *testing.T) {
+	fs, srcDir, destDir, blocker := setupInterruptedCopy(t)
+
+	// Backdate a.txt so a re-copy would be visible as a fresh mtime
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	aPath := filepath.Join(destDir, "a.txt")
+	if err := os.Chtimes(aPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(blocker); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := fs.CopyDir(srcDir, destDir)
+	if err != nil {
+		t.Fatalf("resumed CopyDir failed: %v", err)
+	}
+	if stats.Resumed != 2 {
+		t.Errorf("Resumed = %d, want 2 (a.txt and b.txt from the checkpoint)", stats.Resumed)
+	}
+	if stats.FileCount != 4 {
+		t.Errorf("FileCount = %d, want 4 (resumed files still count as present)", stats.FileCount)
+	}
+
+	info, err := os.Stat(aPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("a.txt was re-copied (mtime %v, want %v)", info.ModTime(), old)
+	}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		data, err := os.ReadFile(filepath.Join(destDir, name))
+		if err != nil || string(data) != "content of "+name {
+			t.Errorf("%s = %q, %v; want copied content", name, data, err)
+		}
+	}
+	if len(stats.FileHashes) != 4 {
+		t.Errorf("FileHashes = %v, want a hash for every file, resumed ones included", stats.FileHashes)
+	}
+	if _, err := os.Stat(checkpointPath(fs.checkpointDir, destDir)); !os.IsNotExist(err) {
+		t.Errorf("checkpoint manifest should be removed after a successful copy, stat err = %v", err)
+	}
+}
+
+func TestCopyDir_CheckpointRecopiesTamperedFiles(t *testing.T) {
+	fs, srcDir, destDir, blocker := setupInterruptedCopy(t)
+
+	if err := os.WriteFile(filepath.Join(destDir, "a.txt"), []byte("edited after the failure"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(blocker); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := fs.CopyDir(srcDir, destDir)
+	if err != nil {
+		t.Fatalf("resumed CopyDir failed: %v", err)
+	}
+	if stats.Resumed != 1 {
+		t.Errorf("Resumed = %d, want 1 (only b.txt still matches its checkpoint hash)", stats.Resumed)
+	}
+	data, err := os.ReadFile(filepath.Join(destDir, "a.txt"))
+	if err != nil || string(data) != "content of a.txt" {
+		t.Errorf("a.txt = %q, %v; want it re-copied from source", data, err)
+	}
+}
+
+// TestCopyDirFiltered_ResumesTransformedCopy verifies that a filtered copy
+// with transforms checkpoints like CopyDir: the retry resumes the rewritten
+// files, whose hashes differ from their sources, instead of copying them again.
+func TestCopyDirFiltered_ResumesTransformedCopy(t *testing.T) {
+	tempDir := t.TempDir()
+	svc := NewFileCopyService(&OSFileSystem{checkpointDir: filepath.Join(tempDir, "checkpoints")})
+	srcDir := filepath.Join(tempDir, "src")
+	destDir := filepath.Join(tempDir, "dest")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte("content of "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	blocker := filepath.Join(destDir, "c.txt")
+	if err := os.MkdirAll(filepath.Join(blocker, "keep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	transform, err := compileTransforms([]types.Transform{{Pattern: "content", Replacement: "text"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.copyDirFiltered(srcDir, destDir, nil, nil, 0, nil, transform); err == nil {
+		t.Fatal("expected the first copy to fail at c.txt")
+	}
+	if err := os.RemoveAll(blocker); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := svc.copyDirFiltered(srcDir, destDir, nil, nil, 0, nil, transform)
+	if err != nil {
+		t.Fatalf("resumed copy failed: %v", err)
+	}
+	if stats.Resumed != 2 {
+		t.Errorf("Resumed = %d, want 2 (a.txt and b.txt from the checkpoint)", stats.Resumed)
+	}
+	for _, name := range []string{"a.txt", "c.txt"} {
+		data, err := os.ReadFile(filepath.Join(destDir, name))
+		if err != nil || string(data) != "text of "+name {
+			t.Errorf("%s = %q, %v; want the transformed content", name, data, err)
+		}
+	}
+	if _, err := os.Stat(checkpointPath(filepath.Join(tempDir, "checkpoints"), destDir)); !os.IsNotExist(err) {
+		t.Errorf("checkpoint manifest should be removed after a successful copy, stat err = %v", err)
+	}
+}
+
+func TestOpenCopyCheckpoint_IgnoresTruncatedLine(t *testing.T) {
+	checkpointDir, destDir := t.TempDir(), t.TempDir()
+	manifest := `{"path":"a.txt","sha256":"abc"}` + "\n" + `{"path":"b.tx`
+	if err := os.WriteFile(checkpointPath(checkpointDir, destDir), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err := openCopyCheckpoint(checkpointDir, destDir)
+	if err != nil {
+		t.Fatalf("openCopyCheckpoint() error = %v", err)
+	}
+	defer cp.close()
+
+	if len(cp.done) != 1 || cp.done["a.txt"] != "abc" {
+		t.Errorf("done = %v, want only the complete a.txt entry", cp.done)
+	}
+}
+
+// TestCopyDir_CheckpointStaysOutOfDestination verifies that the manifest of an
+// interrupted copy is kept in checkpointDir, never among the copied files.
+func TestCopyDir_CheckpointStaysOutOfDestination(t *testing.T) {
+	fs, _, destDir, _ := setupInterruptedCopy(t)
+
+	entries, err := os.ReadDir(destDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".jsonl" {
+			t.Errorf("checkpoint manifest %s written into the destination", e.Name())
+		}
+	}
+	if filepath.Dir(checkpointPath(fs.checkpointDir, destDir)) != fs.checkpointDir {
+		t.Errorf("checkpointPath() = %s, want a file in %s", checkpointPath(fs.checkpointDir, destDir), fs.checkpointDir)
+	}
+}
@@ -56,7 +56,7 @@ func (s *VendorSyncer) DiffVendorWithOptions(opts DiffOptions) ([]types.VendorDi
 
 	// If a specific vendor was requested but not found, return an error
 	if opts.VendorName != "" && len(vendors) == 0 {
-		return nil, fmt.Errorf("vendor '%s' not found", opts.VendorName)
+		return nil, &vendorMissingError{msg: fmt.Sprintf("vendor '%s' not found", opts.VendorName)}
 	}
 	// If a group was requested but no vendors matched, return an error
 	if opts.Group != "" && len(vendors) == 0 {
@@ -112,7 +112,7 @@ func (s *VendorSyncer) DiffVendorWithOptions(opts DiffOptions) ([]types.VendorDi
 				// reachable in the shallow history.
 				const shallowDepth = 20
 				urls := ResolveVendorURLs(vendor)
-				if _, err := FetchWithFallback(ctx, s.gitClient, s.fs, s.ui, tempDir, urls, spec.Ref, shallowDepth); err != nil {
+				if _, err := FetchWithFallback(ctx, s.gitClient, s.fs, s.ui, s.logger, tempDir, urls, spec.Ref, shallowDepth); err != nil {
 					return fmt.Errorf("failed to fetch ref '%s': %w", spec.Ref, err)
 				}
 
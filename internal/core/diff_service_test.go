@@ -118,6 +118,9 @@ func TestDiffVendor_VendorNotFound(t *testing.T) {
 	if err.Error() != expectedMsg {
 		t.Errorf("Expected error message '%s', got '%s'", expectedMsg, err.Error())
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrVendorNotFound), got '%s'", err.Error())
+	}
 }
 
 func TestDiffVendor_NotLocked(t *testing.T) {
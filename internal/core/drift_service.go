@@ -37,6 +37,7 @@ type DriftService struct {
 	fs          FileSystem
 	ui          UICallback
 	rootDir     string
+	logger      *loggerSlot // Set by NewVendorSyncer; nil = no logging
 }
 
 // NewDriftService creates a new DriftService with the given dependencies.
@@ -154,18 +155,7 @@ func (s *DriftService) driftForVendorRef(
 		Files:        make([]types.DriftFile, 0),
 	}
 
-	// Skip whole-file mappings that have position specifiers — those are handled
-	// by the verify command's position-level checks instead.
-	var wholeMappings []types.PathMapping
-	for _, m := range spec.Mapping {
-		_, srcPos, parseErr := types.ParsePathPosition(m.From)
-		if parseErr == nil && srcPos != nil {
-			continue // Position-extracted mapping, skip for drift
-		}
-		wholeMappings = append(wholeMappings, m)
-	}
-
-	if len(wholeMappings) == 0 {
+	if len(spec.Mapping) == 0 {
 		dep.DriftScore = 0
 		return dep, nil
 	}
@@ -184,7 +174,7 @@ func (s *DriftService) driftForVendorRef(
 	// Fetch full history (need both locked commit and potentially HEAD).
 	// FetchWithFallback handles AddRemote + mirror fallback. Depth 0 = full fetch.
 	urls := ResolveVendorURLs(vendor)
-	if _, err := FetchWithFallback(ctx, s.gitClient, s.fs, s.ui, tempDir, urls, spec.Ref, 0); err != nil {
+	if _, err := FetchWithFallback(ctx, s.gitClient, s.fs, s.ui, s.logger, tempDir, urls, spec.Ref, 0); err != nil {
 		return nil, fmt.Errorf("fetch ref '%s': %w", spec.Ref, err)
 	}
 
@@ -193,20 +183,20 @@ func (s *DriftService) driftForVendorRef(
 		return nil, fmt.Errorf("checkout locked commit %s: %w", lockEntry.CommitHash[:7], err)
 	}
 
-	originals := make(map[string]string) // mapping.From → content
-	for _, m := range wholeMappings {
-		srcPath := filepath.Join(tempDir, m.From)
-		data, err := os.ReadFile(srcPath)
-		if err != nil {
-			// Source file doesn't exist at locked commit (shouldn't happen for valid lockfile)
-			originals[m.From] = ""
-			continue
-		}
-		originals[m.From] = string(data)
+	// Directory mappings expand to one target per file present at the locked commit
+	targets, err := expandDriftTargets(tempDir, vendor, spec, lockEntry.Transformed)
+	if err != nil {
+		return nil, fmt.Errorf("list locked files: %w", err)
+	}
+
+	originals := make([]string, len(targets))
+	for i, t := range targets {
+		// Source missing at locked commit (shouldn't happen for valid lockfile) reads as ""
+		originals[i] = readDriftSource(tempDir, t)
 	}
 
 	// Phase 2: If not offline, read upstream files at latest commit
-	upstreams := make(map[string]string)
+	var upstreams []string
 	if !opts.Offline {
 		if err := s.gitClient.Checkout(ctx, tempDir, "FETCH_HEAD"); err != nil {
 			// FETCH_HEAD may not exist if we fetched a specific commit; try the ref
@@ -222,15 +212,10 @@ func (s *DriftService) driftForVendorRef(
 				dep.LatestCommit = latestHash
 			}
 
-			for _, m := range wholeMappings {
-				srcPath := filepath.Join(tempDir, m.From)
-				data, err := os.ReadFile(srcPath)
-				if err != nil {
-					// File deleted upstream
-					upstreams[m.From] = ""
-					continue
-				}
-				upstreams[m.From] = string(data)
+			upstreams = make([]string, len(targets))
+			for i, t := range targets {
+				// File deleted upstream reads as ""
+				upstreams[i] = readDriftSource(tempDir, t)
 			}
 		}
 	}
@@ -242,22 +227,16 @@ func (s *DriftService) driftForVendorRef(
 	var upstreamChanged, upstreamUnchanged int
 	var totalOrigLines int
 
-	for _, m := range wholeMappings {
-		original := originals[m.From]
-
-		// Compute destination path
-		destPath := m.To
-		if destPath == "" || destPath == "." {
-			srcClean := filepath.Clean(m.From)
-			destPath = ComputeAutoPath(srcClean, spec.DefaultTarget, vendor.Name)
-		}
+	for i, t := range targets {
+		original := originals[i]
+		destPath := t.label
 
 		df := types.DriftFile{
 			Path: destPath,
 		}
 
-		// Read local file
-		localData, localErr := os.ReadFile(destPath)
+		// Read local file (or the destination range for position mappings)
+		localData, localErr := readDriftLocal(t)
 
 		// --- Local drift ---
 		switch {
@@ -271,10 +250,10 @@ func (s *DriftService) driftForVendorRef(
 			// Original didn't exist but local does (added locally — unusual)
 			df.LocalStatus = types.DriftStatusAdded
 			df.LocalDriftPct = 100
-			df.LocalLinesAdded = countLines(string(localData))
+			df.LocalLinesAdded = countLines(localData)
 			localChanged++
 		default:
-			local := string(localData)
+			local := localData
 			if local == original {
 				df.LocalStatus = types.DriftStatusUnchanged
 				df.LocalDriftPct = 0
@@ -288,7 +267,8 @@ func (s *DriftService) driftForVendorRef(
 				localChanged++
 
 				if opts.Detail {
-					df.DiffOutput = generateSimpleDiff(destPath, original, local, "locked", "local")
+					df.Hunks = computeDiffHunks(original, local)
+					df.DiffOutput = formatUnifiedDiff(destPath, "locked", "local", df.Hunks)
 				}
 			}
 		}
@@ -297,9 +277,9 @@ func (s *DriftService) driftForVendorRef(
 
 		// --- Upstream drift ---
 		if !opts.Offline {
-			upstream, hasUpstream := upstreams[m.From]
+			upstream := upstreams[i]
 			switch {
-			case !hasUpstream || (upstream == "" && original != ""):
+			case upstream == "" && original != "":
 				// File deleted upstream
 				df.UpstreamStatus = types.DriftStatusDeleted
 				df.UpstreamDriftPct = 100
@@ -373,6 +353,125 @@ func (s *DriftService) driftForVendorRef(
 	return dep, nil
 }
 
+// driftTarget is one file or position range compared by drift: its source in
+// the cloned repository and the local destination it was vendored to.
+type driftTarget struct {
+	src     string              // Source file relative to the clone root
+	srcPos  *types.PositionSpec // Range extracted from src; nil for the whole file
+	dest    string              // Local destination file
+	destPos *types.PositionSpec // Range of dest holding the content; nil for the whole file
+	label   string              // DriftFile.Path: the destination as written in the mapping
+	// transform is the mapping's transforms and SPDX header, applied to the
+	// source so it compares like the file sync wrote; nil for position mappings
+	transform *contentTransform
+}
+
+// expandDriftTargets resolves spec's mappings against the checked-out clone in
+// tempDir. Whole-directory mappings expand to one target per file, honoring the
+// mapping's include/exclude filters and the project's .vendorignore, and
+// skipping .git and symlinks. When transformed (the lock entry's Transformed
+// flag), whole-file targets carry the mapping's transforms (mappingTransform),
+// as the sync that wrote them applied them.
+func expandDriftTargets(tempDir string, vendor *types.VendorSpec, spec *types.BranchSpec, transformed bool) ([]driftTarget, error) {
+	ignore, err := LoadVendorIgnore(".")
+	if err != nil {
+		return nil, err
+	}
+	var targets []driftTarget
+	for _, m := range spec.Mapping {
+		from := strings.Replace(m.From, "blob/"+spec.Ref+"/", "", 1)
+		from = strings.Replace(from, "tree/"+spec.Ref+"/", "", 1)
+		srcFile, srcPos, err := types.ParsePathPosition(from)
+		if err != nil {
+			srcFile, srcPos = from, nil
+		}
+
+		destRaw := m.To
+		if destRaw == "" || destRaw == "." {
+			destRaw = autoDestPath(m.From, *spec, vendor.Name)
+		}
+		destFile, destPos, err := types.ParsePathPosition(destRaw)
+		if err != nil {
+			destFile, destPos = destRaw, nil
+		}
+
+		var transform *contentTransform
+		if transformed && srcPos == nil {
+			if transform, err = mappingTransform(vendor, m); err != nil {
+				return nil, fmt.Errorf("invalid mapping for %s: %w", vendor.Name, err)
+			}
+		}
+
+		srcRoot := filepath.Join(tempDir, srcFile)
+		if info, statErr := os.Stat(srcRoot); srcPos == nil && statErr == nil && info.IsDir() {
+			walkErr := filepath.Walk(srcRoot, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				relPath, err := filepath.Rel(srcRoot, path)
+				if err != nil || relPath == "." {
+					return err
+				}
+				if info.Name() == ".git" || MatchesExclude(relPath, m.Exclude) || ignore.Ignored(relPath, info.IsDir()) {
+					if info.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if info.IsDir() && beyondMaxDepth(relPath, m.MaxDepth) {
+					return filepath.SkipDir
+				}
+				if info.IsDir() || info.Mode()&os.ModeSymlink != 0 {
+					return nil
+				}
+				if len(m.Include) > 0 && !MatchesExclude(relPath, m.Include) {
+					return nil
+				}
+				dest := filepath.Join(destFile, relPath)
+				targets = append(targets, driftTarget{src: filepath.Join(srcFile, relPath), dest: dest, label: dest, transform: transform})
+				return nil
+			})
+			if walkErr != nil {
+				return nil, walkErr
+			}
+			continue
+		}
+
+		targets = append(targets, driftTarget{src: srcFile, srcPos: srcPos, dest: destFile, destPos: destPos, label: destRaw, transform: transform})
+	}
+	return targets, nil
+}
+
+// readDriftSource returns the content of t's source in the clone, extracting
+// the position range when set and applying t's transforms otherwise. A missing
+// or unreadable source reads as "".
+func readDriftSource(tempDir string, t driftTarget) string {
+	srcPath := filepath.Join(tempDir, t.src)
+	if t.srcPos != nil {
+		content, _, err := ExtractPosition(srcPath, t.srcPos)
+		if err != nil {
+			return ""
+		}
+		return content
+	}
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return ""
+	}
+	return t.transform.apply(t.dest, string(data))
+}
+
+// readDriftLocal returns the vendored content of t on disk: the destination
+// range for position destinations, otherwise the whole destination file.
+func readDriftLocal(t driftTarget) (string, error) {
+	if t.destPos != nil {
+		content, _, err := ExtractPosition(t.dest, t.destPos)
+		return content, err
+	}
+	data, err := os.ReadFile(t.dest)
+	return string(data), err
+}
+
 // computeDriftSummary aggregates per-dependency stats into a summary.
 func computeDriftSummary(deps []types.DriftDependency) types.DriftSummary {
 	s := types.DriftSummary{
@@ -500,49 +599,6 @@ func aggregateDriftPct(totalAdded, totalRemoved, totalOrigLines int) float64 {
 	return pct
 }
 
-// generateSimpleDiff generates a simple unified-diff-like output for --detail mode.
-func generateSimpleDiff(path, original, current, oldLabel, newLabel string) string {
-	origLines := strings.Split(original, "\n")
-	currLines := strings.Split(current, "\n")
-
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("--- a/%s (%s)\n", path, oldLabel))
-	sb.WriteString(fmt.Sprintf("+++ b/%s (%s)\n", path, newLabel))
-
-	// Simple line-by-line comparison (not a true unified diff, but useful for --detail)
-	maxLines := len(origLines)
-	if len(currLines) > maxLines {
-		maxLines = len(currLines)
-	}
-
-	for i := 0; i < maxLines; i++ {
-		var origLine, currLine string
-		hasOrig := i < len(origLines)
-		hasCurr := i < len(currLines)
-
-		if hasOrig {
-			origLine = origLines[i]
-		}
-		if hasCurr {
-			currLine = currLines[i]
-		}
-
-		switch {
-		case hasOrig && hasCurr && origLine == currLine:
-			sb.WriteString(fmt.Sprintf(" %s\n", origLine))
-		case hasOrig && hasCurr:
-			sb.WriteString(fmt.Sprintf("-%s\n", origLine))
-			sb.WriteString(fmt.Sprintf("+%s\n", currLine))
-		case hasOrig:
-			sb.WriteString(fmt.Sprintf("-%s\n", origLine))
-		case hasCurr:
-			sb.WriteString(fmt.Sprintf("+%s\n", currLine))
-		}
-	}
-
-	return sb.String()
-}
-
 // FormatDriftOutput formats a DriftDependency for human-readable display.
 func FormatDriftOutput(dep *types.DriftDependency, offline bool) string {
 	var sb strings.Builder
@@ -298,38 +298,6 @@ func TestComputeDriftSummary(t *testing.T) {
 	}
 }
 
-func TestGenerateSimpleDiff(t *testing.T) {
-	original := "line1\nline2\nline3"
-	current := "line1\nmodified\nline3"
-
-	output := generateSimpleDiff("test.go", original, current, "locked", "local")
-
-	if output == "" {
-		t.Fatal("generateSimpleDiff returned empty string")
-	}
-
-	// Verify header
-	if !contains(output, "--- a/test.go (locked)") {
-		t.Error("diff output missing original header")
-	}
-	if !contains(output, "+++ b/test.go (local)") {
-		t.Error("diff output missing current header")
-	}
-
-	// Verify diff markers
-	if !contains(output, "-line2") {
-		t.Error("diff output missing removed line marker")
-	}
-	if !contains(output, "+modified") {
-		t.Error("diff output missing added line marker")
-	}
-
-	// Verify unchanged lines
-	if !contains(output, " line1") {
-		t.Error("diff output missing unchanged line marker")
-	}
-}
-
 func TestFormatDriftOutput(t *testing.T) {
 	dep := &types.DriftDependency{
 		Name:         "test-lib",
@@ -559,57 +527,6 @@ func TestDrift_LockLoadError(t *testing.T) {
 	}
 }
 
-func TestDrift_SkipsPositionMappings(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	configStore := NewMockConfigStore(ctrl)
-	lockStore := NewMockLockStore(ctrl)
-	gitClient := NewMockGitClient(ctrl)
-	fs := NewMockFileSystem(ctrl)
-
-	// Vendor with only position-extracted mappings should produce zero files
-	configStore.EXPECT().Load().Return(types.VendorConfig{
-		Vendors: []types.VendorSpec{
-			{
-				Name: "pos-vendor",
-				URL:  "https://github.com/owner/repo",
-				Specs: []types.BranchSpec{
-					{
-						Ref: "main",
-						Mapping: []types.PathMapping{
-							{From: "file.go:L5-L10", To: "local/snippet.go"},
-						},
-					},
-				},
-			},
-		},
-	}, nil)
-	lockStore.EXPECT().Load().Return(types.VendorLock{
-		Vendors: []types.LockDetails{
-			{Name: "pos-vendor", Ref: "main", CommitHash: "abc123"},
-		},
-	}, nil)
-
-	svc := NewDriftService(configStore, lockStore, gitClient, fs, nil, "/root")
-	result, err := svc.Drift(context.Background(), DriftOptions{})
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	if len(result.Dependencies) != 1 {
-		t.Fatalf("expected 1 dependency, got %d", len(result.Dependencies))
-	}
-
-	// Position-only vendor should have zero tracked files and zero drift
-	if len(result.Dependencies[0].Files) != 0 {
-		t.Errorf("expected 0 files for position-only vendor, got %d", len(result.Dependencies[0].Files))
-	}
-	if result.Dependencies[0].DriftScore != 0 {
-		t.Errorf("expected 0 drift score for position-only vendor, got %.1f", result.Dependencies[0].DriftScore)
-	}
-}
-
 // ============================================================================
 // driftForVendorRef service tests (require real temp files + mocked git)
 // ============================================================================
@@ -798,6 +715,117 @@ func TestDrift_HappyPath_LocalModified(t *testing.T) {
 	}
 }
 
+// TestDrift_TransformedFileUnchanged verifies a mapping's transforms and
+// SPDX header are applied to upstream's file before comparing, so a file
+// left as sync wrote it shows no drift.
+func TestDrift_TransformedFileUnchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	upstream := "package client\n\nimport \"github.com/upstream/client/auth\"\n"
+	synced := "// SPDX-License-Identifier: MIT\n\npackage client\n\nimport \"example.com/ours/auth\"\n"
+	cloneDir, workDir, cleanup := setupDriftTestFiles(t,
+		map[string]string{"src/client.go": upstream},
+		map[string]string{"lib/client.go": synced},
+	)
+	defer cleanup()
+
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+	gitClient := NewMockGitClient(ctrl)
+	fs := NewMockFileSystem(ctrl)
+
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{{
+			Name:        "test-lib",
+			URL:         "https://github.com/owner/repo",
+			License:     "MIT",
+			SPDXHeaders: true,
+			Specs: []types.BranchSpec{{
+				Ref: "main",
+				Mapping: []types.PathMapping{{
+					From:       "src/client.go",
+					To:         filepath.Join(workDir, "lib/client.go"),
+					Transforms: []types.Transform{{Pattern: `github\.com/upstream/client/`, Replacement: "example.com/ours/"}},
+				}},
+			}},
+		}},
+	}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{{Name: "test-lib", Ref: "main", CommitHash: "abc1234567890", Transformed: true}},
+	}, nil)
+
+	expectGitOpsForDrift(t, fs, gitClient, cloneDir, false)
+
+	svc := NewDriftService(configStore, lockStore, gitClient, fs, nil, workDir)
+	result, err := svc.Drift(context.Background(), DriftOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f := result.Dependencies[0].Files[0]
+	if f.LocalStatus != types.DriftStatusUnchanged {
+		t.Errorf("expected unchanged local status for the transformed file, got %q (+%d -%d)", f.LocalStatus, f.LocalLinesAdded, f.LocalLinesRemoved)
+	}
+	if f.UpstreamStatus != types.DriftStatusUnchanged {
+		t.Errorf("expected unchanged upstream status, got %q", f.UpstreamStatus)
+	}
+}
+
+// TestDrift_UntransformedLockComparesUpstreamBytes verifies transforms added
+// to a mapping after its last sync (lock entry not Transformed) are not
+// applied, so drift compares against upstream's bytes as the lock hashed them.
+func TestDrift_UntransformedLockComparesUpstreamBytes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	upstream := "package client\n\nimport \"github.com/upstream/client/auth\"\n"
+	synced := "// SPDX-License-Identifier: MIT\n\npackage client\n\nimport \"example.com/ours/auth\"\n"
+	cloneDir, workDir, cleanup := setupDriftTestFiles(t,
+		map[string]string{"src/client.go": upstream},
+		map[string]string{"lib/client.go": synced},
+	)
+	defer cleanup()
+
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+	gitClient := NewMockGitClient(ctrl)
+	fs := NewMockFileSystem(ctrl)
+
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{{
+			Name:        "test-lib",
+			URL:         "https://github.com/owner/repo",
+			License:     "MIT",
+			SPDXHeaders: true,
+			Specs: []types.BranchSpec{{
+				Ref: "main",
+				Mapping: []types.PathMapping{{
+					From:       "src/client.go",
+					To:         filepath.Join(workDir, "lib/client.go"),
+					Transforms: []types.Transform{{Pattern: `github\.com/upstream/client/`, Replacement: "example.com/ours/"}},
+				}},
+			}},
+		}},
+	}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{{Name: "test-lib", Ref: "main", CommitHash: "abc1234567890"}},
+	}, nil)
+
+	expectGitOpsForDrift(t, fs, gitClient, cloneDir, false)
+
+	svc := NewDriftService(configStore, lockStore, gitClient, fs, nil, workDir)
+	result, err := svc.Drift(context.Background(), DriftOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f := result.Dependencies[0].Files[0]
+	if f.LocalStatus != types.DriftStatusModified {
+		t.Errorf("expected modified local status when the lock records untransformed hashes, got %q", f.LocalStatus)
+	}
+}
+
 func TestDrift_HappyPath_LocalDeleted(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1086,6 +1114,128 @@ func TestDrift_DetailMode(t *testing.T) {
 	if !contains(dep.Files[0].DiffOutput, "+changed") {
 		t.Error("diff output missing added line")
 	}
+	if len(dep.Files[0].Hunks) != 1 || dep.Files[0].Hunks[0].OldStart != 1 {
+		t.Errorf("Hunks = %+v, want one hunk starting at line 1", dep.Files[0].Hunks)
+	}
+}
+
+func TestDrift_PositionMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Lines 2-3 of the source are vendored into snippet.go; line 3 was edited locally
+	cloneDir, workDir, cleanup := setupDriftTestFiles(t,
+		map[string]string{"api/consts.go": "package api\nconst A = 1\nconst B = 2\nconst C = 3\n"},
+		map[string]string{"local/snippet.go": "const A = 1\nconst B = 20"},
+	)
+	defer cleanup()
+
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+	gitClient := NewMockGitClient(ctrl)
+	fs := NewMockFileSystem(ctrl)
+
+	dest := filepath.Join(workDir, "local/snippet.go")
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{{
+			Name: "pos-vendor",
+			URL:  "https://github.com/owner/repo",
+			Specs: []types.BranchSpec{{
+				Ref:     "main",
+				Mapping: []types.PathMapping{{From: "api/consts.go:L2-L3", To: dest}},
+			}},
+		}},
+	}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{{Name: "pos-vendor", Ref: "main", CommitHash: "abc1234567890"}},
+	}, nil)
+
+	expectGitOpsForDrift(t, fs, gitClient, cloneDir, true)
+
+	svc := NewDriftService(configStore, lockStore, gitClient, fs, nil, workDir)
+	result, err := svc.Drift(context.Background(), DriftOptions{Offline: true, Detail: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files := result.Dependencies[0].Files
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file for the position mapping, got %+v", files)
+	}
+	f := files[0]
+	if f.LocalStatus != types.DriftStatusModified {
+		t.Errorf("LocalStatus = %q, want modified", f.LocalStatus)
+	}
+	if !contains(f.DiffOutput, "-const B = 2") || !contains(f.DiffOutput, "+const B = 20") {
+		t.Errorf("diff should compare against the extracted range only:\n%s", f.DiffOutput)
+	}
+	if contains(f.DiffOutput, "package api") {
+		t.Errorf("diff includes lines outside the position range:\n%s", f.DiffOutput)
+	}
+}
+
+func TestDrift_DirectoryMappingDiffsEachFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cloneDir, workDir, cleanup := setupDriftTestFiles(t,
+		map[string]string{
+			"src/a.go":          "package src\n",
+			"src/sub/b.go":      "package sub\n",
+			"src/sub/b_test.go": "package sub\n",
+		},
+		map[string]string{
+			"lib/a.go":     "package src\n",
+			"lib/sub/b.go": "package sub // edited\n",
+		},
+	)
+	defer cleanup()
+
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+	gitClient := NewMockGitClient(ctrl)
+	fs := NewMockFileSystem(ctrl)
+
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{{
+			Name: "dir-vendor",
+			URL:  "https://github.com/owner/repo",
+			Specs: []types.BranchSpec{{
+				Ref: "main",
+				Mapping: []types.PathMapping{{
+					From:    "src",
+					To:      filepath.Join(workDir, "lib"),
+					Exclude: []string{"**/*_test.go"},
+				}},
+			}},
+		}},
+	}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{{Name: "dir-vendor", Ref: "main", CommitHash: "abc1234567890"}},
+	}, nil)
+
+	expectGitOpsForDrift(t, fs, gitClient, cloneDir, true)
+
+	svc := NewDriftService(configStore, lockStore, gitClient, fs, nil, workDir)
+	result, err := svc.Drift(context.Background(), DriftOptions{Offline: true, Detail: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files := result.Dependencies[0].Files
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files (excluded test file dropped), got %+v", files)
+	}
+	status := map[string]string{}
+	for _, f := range files {
+		status[f.Path] = f.LocalStatus
+	}
+	if got := status[filepath.Join(workDir, "lib/a.go")]; got != types.DriftStatusUnchanged {
+		t.Errorf("lib/a.go status = %q, want unchanged", got)
+	}
+	if got := status[filepath.Join(workDir, "lib/sub/b.go")]; got != types.DriftStatusModified {
+		t.Errorf("lib/sub/b.go status = %q, want modified", got)
+	}
 }
 
 func TestDrift_AutoPath(t *testing.T) {
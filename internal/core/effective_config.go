@@ -0,0 +1,128 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ResolveEffectiveConfig returns config as git-vendor actually applies it,
+// leaving the caller's config untouched:
+//   - ref_aliases for hostBranch are applied to vendor refs (ApplyRefAliases)
+//   - built-in defaults fill unset settings: allowed_licenses, license_files,
+//     license_dir, compliance default/mode, and the global policy
+//   - each vendor gets its merged policy (types.ResolvedPolicy), its resolved
+//     compliance level, and license_override in place of the detected license
+//   - credentials embedded in vendor and mirror URLs are redacted (SanitizeURL)
+//
+// The result is for display ("config show --resolved"); it is not meant to be
+// saved back to vendor.yml.
+func ResolveEffectiveConfig(config types.VendorConfig, hostBranch string) types.VendorConfig {
+	enforcement := NewEnforcementService().ResolveVendorEnforcement(&config)
+	resolved := ApplyRefAliases(config, hostBranch)
+
+	resolved.AllowedLicenses = ResolveAllowedLicenses(config)
+	resolved.LicenseFiles = ResolveLicenseFiles(config)
+	if resolved.LicenseDir == "" {
+		resolved.LicenseDir = LicensesPath
+	}
+
+	compliance := types.ComplianceConfig{Default: EnforcementLenient, Mode: ComplianceModeDefault}
+	if config.Compliance != nil {
+		if config.Compliance.Default != "" {
+			compliance.Default = config.Compliance.Default
+		}
+		if config.Compliance.Mode != "" {
+			compliance.Mode = config.Compliance.Mode
+		}
+	}
+	resolved.Compliance = &compliance
+
+	globalPolicy := types.ResolvedPolicy(config.Policy, nil)
+	resolved.Policy = &globalPolicy
+
+	vendors := make([]types.VendorSpec, len(resolved.Vendors))
+	for i, v := range resolved.Vendors {
+		policy := types.ResolvedPolicy(config.Policy, v.Policy)
+		v.Policy = &policy
+		v.Enforcement = enforcement[v.Name]
+		v.License = ResolveVendorLicense(v)
+		vendors[i] = v
+	}
+	resolved.Vendors = vendors
+
+	return RedactConfig(resolved)
+}
+
+// RedactConfig returns config with credentials embedded in vendor and mirror
+// URLs removed, for printing the unresolved vendor.yml ("config show").
+func RedactConfig(config types.VendorConfig) types.VendorConfig {
+	vendors := make([]types.VendorSpec, len(config.Vendors))
+	for i, v := range config.Vendors {
+		v.URL = SanitizeURL(v.URL)
+		if len(v.Mirrors) > 0 {
+			mirrors := make([]string, len(v.Mirrors))
+			for j, m := range v.Mirrors {
+				mirrors[j] = SanitizeURL(m)
+			}
+			v.Mirrors = mirrors
+		}
+		vendors[i] = v
+	}
+	config.Vendors = vendors
+	return config
+}
+
+// MarshalConfigDocument encodes config as "yaml" or "json" using vendor.yml
+// key names in both formats.
+func MarshalConfigDocument(config types.VendorConfig, format string) ([]byte, error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	switch format {
+	case "", "yaml":
+		return data, nil
+	case "json":
+		doc, err := configDocument(data)
+		if err != nil {
+			return nil, err
+		}
+		out, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal config: %w", err)
+		}
+		return append(out, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q (use yaml or json)", format)
+	}
+}
+
+// configDocument decodes YAML config data into generic maps keyed by vendor.yml
+// names, so JSON output matches the file rather than Go field names.
+func configDocument(data []byte) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	return doc, nil
+}
+
+// EffectiveConfig loads vendor.yml and returns ResolveEffectiveConfig for the
+// host repository's current branch.
+func (s *VendorSyncer) EffectiveConfig() (types.VendorConfig, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return types.VendorConfig{}, fmt.Errorf("load config: %w", err)
+	}
+	resolved := ResolveEffectiveConfig(config, GetHostBranch())
+	if config.LicenseDir == "" {
+		// The default license directory follows the vendor directory (--config)
+		resolved.LicenseDir = filepath.ToSlash(ResolveLicenseDir(s.rootDir, config))
+	}
+	return resolved, nil
+}
@@ -45,8 +45,8 @@ func (e *EnforcementService) ResolveVendorEnforcement(config *types.VendorConfig
 // ComputeExitCode determines the process exit code from vendor status details
 // and their resolved enforcement levels.
 //
-// "Drift" for enforcement purposes means modified or deleted files only
-// (FilesModified + FilesDeleted). Added files are not considered enforcement
+// "Drift" for enforcement purposes means modified, deleted or truncated files
+// only (FilesModified + FilesDeleted + FilesTruncated). Added files are not considered enforcement
 // drift — they are handled by the legacy summary as WARN.
 //
 // Exit code semantics:
@@ -61,7 +61,7 @@ func (e *EnforcementService) ComputeExitCode(vendors []types.VendorStatusDetail,
 
 	for i := range vendors {
 		v := &vendors[i]
-		unackedDrift := v.FilesModified + v.FilesDeleted
+		unackedDrift := v.FilesModified + v.FilesDeleted + v.FilesTruncated
 		if unackedDrift == 0 {
 			continue
 		}
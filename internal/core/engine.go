@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -12,8 +13,9 @@ import (
 	"github.com/EmundoT/git-vendor/internal/types"
 )
 
-// Verbose controls whether git commands are logged
-var Verbose = false
+// NoSymlinks makes directory copies skip every symlink (with a warning)
+// instead of recreating in-tree links
+var NoSymlinks = false
 
 // Manager provides the main API for git-vendor operations.
 // Manager delegates to VendorSyncer for all business logic.
@@ -25,23 +27,33 @@ type Manager struct {
 
 // NewManager creates a new Manager with default dependencies
 func NewManager() *Manager {
-	rootDir := VendorDir
+	return NewManagerAt(VendorDir)
+}
 
+// NewManagerAt creates a new Manager with default dependencies whose vendor
+// directory (vendor.yml, vendor.lock, licenses) is rootDir instead of VendorDir,
+// so a monorepo can keep several independent vendor sets.
+func NewManagerAt(rootDir string) *Manager {
 	// Create default implementations of all dependencies
 	configStore := NewFileConfigStore(rootDir)
 	lockStore := NewFileLockStore(rootDir)
-	gitClient := NewSystemGitClient(Verbose)
+	gitClient := NewSystemGitClient()
 	fs := NewRootedFileSystem(".")
 
 	// Create provider registry for multi-platform URL parsing
 	providerRegistry := providers.NewProviderRegistry()
 
+	// Allowed licenses come from vendor.yml allowed_licenses when set.
+	// A missing or unreadable config falls back to the built-in list.
+	//nolint:errcheck // Zero config resolves to the default AllowedLicenses
+	config, _ := configStore.Load()
+
 	// Create multi-platform license checker (supports GitHub, GitLab, Bitbucket, generic)
 	licenseChecker := NewMultiPlatformLicenseChecker(
 		providerRegistry,
 		fs,
 		gitClient,
-		AllowedLicenses,
+		ResolveAllowedLicenses(config),
 	)
 
 	ui := &SilentUICallback{} // Default to silent
@@ -78,9 +90,15 @@ func (m *Manager) LockPath() string {
 	return m.syncer.lockStore.Path()
 }
 
-// LicensePath returns the path for a vendor's license file
+// LicensePath returns the path for a vendor's license file, under the
+// configured license_dir (ResolveLicenseDir). An unreadable config falls
+// back to the default license directory.
 func (m *Manager) LicensePath(name string) string {
-	return m.syncer.rootDir + "/" + LicensesDir + "/" + name + ".txt"
+	config, err := m.syncer.configStore.Load()
+	if err != nil {
+		config = types.VendorConfig{}
+	}
+	return m.syncer.license.GetLicensePath(name, ResolveLicenseDir(m.syncer.rootDir, config))
 }
 
 // IsGitInstalled checks if git is available on the system
@@ -90,18 +108,49 @@ func IsGitInstalled() bool {
 
 // IsVendorInitialized checks if the vendor directory structure exists
 func IsVendorInitialized() bool {
-	info, err := os.Stat(VendorDir)
+	return IsVendorInitializedAt(VendorDir)
+}
+
+// IsVendorInitializedAt checks if the vendor directory dir exists
+func IsVendorInitializedAt(dir string) bool {
+	info, err := os.Stat(dir)
 	if err != nil {
 		return false
 	}
 	return info.IsDir()
 }
 
+// IsInitialized checks if the manager's vendor directory exists
+func (m *Manager) IsInitialized() bool {
+	return IsVendorInitializedAt(m.RootDir)
+}
+
+// requireInitialized returns ErrNotInitialized, wrapped with the vendor
+// directory, when that directory doesn't exist.
+func (m *Manager) requireInitialized() error {
+	if !m.IsInitialized() {
+		return fmt.Errorf("%s: %w", m.RootDir, ErrNotInitialized)
+	}
+	return nil
+}
+
 // Init initializes the vendor directory structure
 func (m *Manager) Init() error {
 	return m.syncer.Init()
 }
 
+// InitFormat initializes the vendor directory with the config written in
+// format ("yaml" or "toml"; "" = detect).
+func (m *Manager) InitFormat(format string) error {
+	return m.syncer.InitFormat(format)
+}
+
+// InitWithOptions initializes the vendor directory, optionally scaffolding
+// the project .gitignore and a vendor directory README.
+func (m *Manager) InitWithOptions(opts InitOptions) error {
+	return m.syncer.InitWithOptions(opts)
+}
+
 // GetRemoteURL returns the sanitized URL for a git remote (e.g. "origin").
 // Returns empty string on any error — not a git repo, no remote configured, etc.
 // SEC-013: Output is sanitized via SanitizeURL to strip embedded credentials.
@@ -131,6 +180,9 @@ func (m *Manager) ListLocalDir(path string) ([]string, error) {
 
 // RemoveVendor removes a vendor by name
 func (m *Manager) RemoveVendor(name string) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.RemoveVendor(name)
 }
 
@@ -144,48 +196,83 @@ func (m *Manager) AddVendor(spec *types.VendorSpec) error {
 	return m.syncer.AddVendor(spec)
 }
 
+// AddVendorWithOptions adds a vendor with opts (e.g. Local for a local path URL).
+func (m *Manager) AddVendorWithOptions(spec *types.VendorSpec, opts AddOptions) error {
+	return m.syncer.AddVendorWithOptions(spec, opts)
+}
+
 // Sync performs locked synchronization.
 // ctx controls cancellation of git operations during sync.
 func (m *Manager) Sync(ctx context.Context) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.Sync(ctx)
 }
 
 // SyncWithOptions performs sync with vendor filter, force, and cache options.
 // ctx controls cancellation of git operations during sync.
 func (m *Manager) SyncWithOptions(ctx context.Context, vendorName string, force, noCache bool) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.SyncWithOptions(ctx, vendorName, force, noCache)
 }
 
 // SyncWithGroup performs sync for all vendors in a group.
 // ctx controls cancellation of git operations during sync.
 func (m *Manager) SyncWithGroup(ctx context.Context, groupName string, force, noCache bool) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.SyncWithGroup(ctx, groupName, force, noCache)
 }
 
 // SyncWithParallel performs sync with parallel processing.
 // ctx controls cancellation of git operations during sync.
 func (m *Manager) SyncWithParallel(ctx context.Context, vendorName string, force, noCache bool, parallelOpts types.ParallelOptions) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.SyncWithParallel(ctx, vendorName, force, noCache, parallelOpts)
 }
 
 // SyncWithFullOptions performs sync using a full SyncOptions struct.
 // Supports InternalOnly and Reverse flags for internal vendor compliance.
 func (m *Manager) SyncWithFullOptions(ctx context.Context, opts SyncOptions) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.SyncWithFullOpts(ctx, opts)
 }
 
 // UpdateAll updates all vendors and regenerates lockfile.
 // ctx controls cancellation of git operations during update.
 func (m *Manager) UpdateAll(ctx context.Context) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.UpdateAll(ctx)
 }
 
 // UpdateAllWithOptions updates all vendors with optional parallel processing and local path support.
 // ctx controls cancellation of git operations during update.
 func (m *Manager) UpdateAllWithOptions(ctx context.Context, opts UpdateOptions) error {
+	if err := m.requireInitialized(); err != nil {
+		return err
+	}
 	return m.syncer.UpdateAllWithOptions(ctx, opts)
 }
 
+// UpdateDryRun previews UpdateAllWithOptions: which vendors would lock a new
+// commit, resolved with ls-remote. Nothing is fetched, copied, or saved.
+func (m *Manager) UpdateDryRun(ctx context.Context, opts UpdateOptions) (*UpdatePlan, error) {
+	if err := m.requireInitialized(); err != nil {
+		return nil, err
+	}
+	return m.syncer.UpdateDryRun(ctx, opts)
+}
+
 // CheckGitHubLicense checks a repository's license via GitHub API
 func (m *Manager) CheckGitHubLicense(rawURL string) (string, error) {
 	return m.syncer.CheckGitHubLicense(rawURL)
@@ -196,6 +283,13 @@ func (m *Manager) GetConfig() (types.VendorConfig, error) {
 	return m.syncer.GetConfig()
 }
 
+// EffectiveConfig returns the fully-resolved configuration git-vendor applies
+// (defaults, overrides and ref aliases for the current branch), with URL
+// credentials redacted. vendor.yml itself is not modified.
+func (m *Manager) EffectiveConfig() (types.VendorConfig, error) {
+	return m.syncer.EffectiveConfig()
+}
+
 // GetLockHash retrieves the locked commit hash for a vendor@ref
 func (m *Manager) GetLockHash(vendorName, ref string) string {
 	return m.syncer.GetLockHash(vendorName, ref)
@@ -217,6 +311,41 @@ func (m *Manager) DetectConflicts() ([]types.PathConflict, error) {
 	return m.syncer.DetectConflicts()
 }
 
+// PlanRemoveVendor lists what RemoveVendor would delete, without deleting anything
+func (m *Manager) PlanRemoveVendor(name string) (*types.PrunePlan, error) {
+	return m.syncer.PlanRemoveVendor(name)
+}
+
+// PlanPruneMappings lists the mappings pull --prune would remove, without removing them
+func (m *Manager) PlanPruneMappings(vendorName string, exclude []string) (*types.PrunePlan, error) {
+	return m.syncer.PlanPruneMappings(vendorName, exclude)
+}
+
+// PlanClean lists the orphaned vendored files clean would delete, without deleting them
+func (m *Manager) PlanClean() (*types.PrunePlan, error) {
+	return m.syncer.PlanClean()
+}
+
+// Clean deletes the files in a PlanClean plan and drops orphaned lock entries
+func (m *Manager) Clean(plan *types.PrunePlan) (int, error) {
+	return m.syncer.Clean(plan)
+}
+
+// Export archives the locked vendored files, config, lock and licenses to output
+func (m *Manager) Export(output string) (*types.ExportManifest, error) {
+	return m.syncer.Export(output)
+}
+
+// Import extracts an Export archive into the project
+func (m *Manager) Import(archive string) (*types.ExportManifest, error) {
+	return m.syncer.Import(archive)
+}
+
+// ExplainPlan reports the write order and winner for destinations targeted by multiple mappings
+func (m *Manager) ExplainPlan() ([]types.DestinationPlan, error) {
+	return m.syncer.ExplainPlan()
+}
+
 // ValidateConfig performs comprehensive config validation
 func (m *Manager) ValidateConfig() error {
 	return m.syncer.ValidateConfig()
@@ -236,6 +365,9 @@ func (m *Manager) CheckUpdates(ctx context.Context) ([]types.UpdateCheckResult,
 // Verify checks all vendored files against the lockfile.
 // ctx is accepted for cancellation support and future network-based verification.
 func (m *Manager) Verify(ctx context.Context) (*types.VerifyResult, error) {
+	if err := m.requireInitialized(); err != nil {
+		return nil, err
+	}
 	return m.syncer.Verify(ctx)
 }
 
@@ -260,6 +392,21 @@ func (m *Manager) LicenseReport(policyPath, failOn string) (*types.LicenseReport
 	return m.syncer.LicenseReport(svc, failOn)
 }
 
+// CheckOnly runs the consolidated pre-merge gate behind "validate --check-only":
+// config validation, conflict detection, lock coherence, and license policy.
+// policyPath overrides the default policy file location; empty string uses PolicyFile constant.
+func (m *Manager) CheckOnly(ctx context.Context, policyPath string) (*types.CheckOnlyResult, error) {
+	if policyPath == "" {
+		policyPath = PolicyFile
+	}
+	policy, err := LoadLicensePolicy(policyPath)
+	if err != nil {
+		return nil, err
+	}
+	svc := NewLicensePolicyService(&policy, policyPath, m.syncer.configStore, m.syncer.lockStore)
+	return m.syncer.CheckOnly(ctx, svc)
+}
+
 // EvaluateLicensePolicy loads the policy and evaluates a single license.
 // EvaluateLicensePolicy is used during "add" to check a license against the policy.
 // policyPath overrides the default policy file location; empty string uses PolicyFile constant.
@@ -287,12 +434,87 @@ func (m *Manager) Outdated(ctx context.Context, opts OutdatedOptions) (*types.Ou
 // outdated (remote/upstream) checks into a single per-vendor report.
 // ctx controls cancellation of verify and ls-remote operations.
 func (m *Manager) Status(ctx context.Context, opts StatusOptions) (*types.StatusResult, error) {
+	if err := m.requireInitialized(); err != nil {
+		return nil, err
+	}
 	return m.syncer.Status(ctx, opts)
 }
 
+// QuickStatus reports per vendor@ref whether it is locked and its destinations
+// exist, without hashing files or contacting remotes ("status --quick").
+func (m *Manager) QuickStatus(excludeVendors []string) (*types.QuickStatusResult, error) {
+	if err := m.requireInitialized(); err != nil {
+		return nil, err
+	}
+	return m.syncer.QuickStatus(excludeVendors)
+}
+
+// PinVendor freezes a vendor's specs at their locked commits.
+func (m *Manager) PinVendor(name string) ([]RefChange, error) {
+	return m.syncer.PinVendor(name)
+}
+
+// UnpinVendor restores a pinned vendor's specs to ref (or their pinned_from).
+func (m *Manager) UnpinVendor(name, ref string) ([]RefChange, error) {
+	return m.syncer.UnpinVendor(name, ref)
+}
+
+// BumpVendor moves a vendor spec to a new ref and re-syncs that vendor.
+// ctx controls cancellation of git operations.
+func (m *Manager) BumpVendor(ctx context.Context, opts BumpOptions) (*BumpResult, error) {
+	return m.syncer.BumpVendor(ctx, opts)
+}
+
+// FixVerify restores modified, deleted and truncated vendored files to their
+// locked content. ctx controls cancellation of git operations.
+func (m *Manager) FixVerify(ctx context.Context, opts VerifyOptions, excludeVendors []string) (*types.VerifyFixResult, error) {
+	return m.syncer.FixVerify(ctx, opts, excludeVendors)
+}
+
+// Why reports which vendor mappings and lock entries produce a destination path.
+func (m *Manager) Why(path string) (*types.WhyResult, error) {
+	return m.syncer.Why(path)
+}
+
+// Tree returns the destination layout of every config mapping as a tree.
+func (m *Manager) Tree() (*types.VendorTreeNode, error) {
+	return m.syncer.Tree()
+}
+
+// GraphDOT returns vendors, their top-level destinations, and path conflicts
+// as a Graphviz DOT digraph.
+func (m *Manager) GraphDOT() (string, error) {
+	return m.syncer.GraphDOT()
+}
+
+// ListYAML returns vendor.yml merged with vendor.lock (resolved destinations
+// and each spec's locked commit) as YAML.
+func (m *Manager) ListYAML() ([]byte, error) {
+	return m.syncer.ListYAML()
+}
+
+// CheckLock reports vendor.lock entries and paths that disagree with vendor.yml.
+func (m *Manager) CheckLock() (*types.LockCheckResult, error) {
+	return m.syncer.CheckLock()
+}
+
+// RehashLock rewrites vendor.lock's checksum for a lock edited by hand.
+func (m *Manager) RehashLock() error {
+	return m.syncer.RehashLock()
+}
+
+// RegenerateLock re-fetches every vendor at its config ref and rewrites vendor.lock.
+// ctx controls cancellation of git operations.
+func (m *Manager) RegenerateLock(ctx context.Context, opts UpdateOptions) error {
+	return m.syncer.RegenerateLock(ctx, opts)
+}
+
 // Drift detects drift between vendored files and their origin.
 // ctx controls cancellation of git operations (clone, fetch, checkout).
 func (m *Manager) Drift(ctx context.Context, opts DriftOptions) (*types.DriftResult, error) {
+	if err := m.requireInitialized(); err != nil {
+		return nil, err
+	}
 	return m.syncer.Drift(ctx, opts)
 }
 
@@ -303,11 +525,23 @@ func (m *Manager) Accept(opts AcceptOptions) (*AcceptResult, error) {
 	return m.syncer.Accept(opts)
 }
 
+// UpdateBaseline blesses the on-disk content of modified files matching patterns
+// by rewriting their lock file hashes, so later verify runs pass for them.
+func (m *Manager) UpdateBaseline(patterns []string) (*BaselineResult, error) {
+	return m.syncer.UpdateBaseline(patterns)
+}
+
 // MigrateLockfile updates an existing lockfile to add missing metadata fields
 func (m *Manager) MigrateLockfile() (int, error) {
 	return m.syncer.MigrateLockfile()
 }
 
+// NormalizeConfig rewrites vendor.yml in canonical form.
+// Returns true when the file changed.
+func (m *Manager) NormalizeConfig() (bool, error) {
+	return m.syncer.NormalizeConfig()
+}
+
 // DiffVendor shows commit differences between locked and latest versions
 // for a single vendor. DiffVendor is a convenience wrapper; use DiffVendorWithOptions
 // for ref/group filtering.
@@ -332,6 +566,19 @@ func (m *Manager) GenerateSBOM(format SBOMFormat, projectName string) ([]byte, e
 	return generator.Generate(format)
 }
 
+// GenerateLicenseReport writes a consolidated third-party notices report to w:
+// a header per vendor (name, URL, SPDX id, locked commit) followed by its
+// captured license and notice files.
+func (m *Manager) GenerateLicenseReport(w io.Writer) error {
+	return NewAttributionReportGenerator(m.syncer.lockStore, m.syncer.configStore).Write(w)
+}
+
+// LicenseAttributions returns the entries of the third-party notices report
+// as structured data (licenses --json).
+func (m *Manager) LicenseAttributions() (*types.AttributionReport, error) {
+	return NewAttributionReportGenerator(m.syncer.lockStore, m.syncer.configStore).Build()
+}
+
 // === Compliance (Spec 070) ===
 
 // ComplianceCheck computes drift state for all internal vendor mappings.
@@ -356,6 +603,11 @@ func (m *Manager) RenameVendor(oldName, newName string) error {
 	return m.syncer.RenameVendor(oldName, newName)
 }
 
+// MoveVendor relocates a vendor's destinations under newDest, moving files on disk.
+func (m *Manager) MoveVendor(name, newDest string) (*MoveResult, error) {
+	return m.syncer.MoveVendor(name, newDest)
+}
+
 // AddMappingToVendor adds a path mapping to an existing vendor.
 func (m *Manager) AddMappingToVendor(vendorName, from, to, ref string) error {
 	return m.syncer.AddMappingToVendor(vendorName, from, to, ref)
@@ -395,8 +647,8 @@ func (m *Manager) CheckVendorStatus(vendorName string) (map[string]interface{},
 // with multi-valued COMMIT-SCHEMA v1 trailers and a git note under refs/notes/vendor.
 // CommitVendorChanges delegates to the package-level CommitVendorChanges function.
 func (m *Manager) CommitVendorChanges(operation, vendorFilter string) error {
-	return CommitVendorChanges(context.Background(), m.syncer.gitClient,
-		m.syncer.configStore, m.syncer.lockStore, ".", operation, vendorFilter)
+	return commitVendorChangesIn(context.Background(), m.syncer.gitClient,
+		m.syncer.configStore, m.syncer.lockStore, ".", m.RootDir, operation, vendorFilter)
 }
 
 // AnnotateVendorCommit retroactively attaches vendor metadata as a git note
@@ -431,6 +683,9 @@ func (m *Manager) ListMirrors(vendorName string) (map[string]interface{}, error)
 // With Prune: remove dead mappings from vendor.yml after sync.
 // With KeepLocal: detect locally modified files and warn (not overwritten).
 func (m *Manager) Pull(ctx context.Context, opts PullOptions) (*PullResult, error) {
+	if err := m.requireInitialized(); err != nil {
+		return nil, err
+	}
 	return m.syncer.PullVendors(ctx, opts)
 }
 
@@ -441,6 +696,9 @@ func (m *Manager) Pull(ctx context.Context, opts PullOptions) (*PullResult, erro
 // source repo, applies diffs via reverse path mapping, and creates a PR (or prints
 // manual instructions if the gh CLI is unavailable).
 func (m *Manager) Push(ctx context.Context, opts PushOptions) (*PushResult, error) {
+	if err := m.requireInitialized(); err != nil {
+		return nil, err
+	}
 	return m.syncer.PushVendor(ctx, opts)
 }
 
@@ -460,14 +718,24 @@ func (m *Manager) Cascade(ctx context.Context, opts CascadeOptions) (*CascadeRes
 		return nil, fmt.Errorf("cascade: resolve root: %w", err)
 	}
 	svc := NewCascadeService(absRoot)
+	svc.logger = m.syncer.logger
 	return svc.Cascade(ctx, opts)
 }
 
-// UpdateVerboseMode updates the verbose flag for git operations
+// SetLogger routes leveled diagnostics (git commands, copied files, sync
+// timings) to logger; see VendorSyncer.SetLogger. nil restores the no-op default.
+func (m *Manager) SetLogger(logger Logger) {
+	m.syncer.SetLogger(logger)
+}
+
+// UpdateVerboseMode logs debug diagnostics to stderr when verbose is true
+// and stops logging otherwise (SetLogger with a stderr writer logger).
 func (m *Manager) UpdateVerboseMode(verbose bool) {
-	// Update the global git client
-	gitClient := NewSystemGitClient(verbose)
-	m.syncer.gitClient = gitClient
+	if !verbose {
+		m.SetLogger(nil)
+		return
+	}
+	m.SetLogger(NewWriterLogger(os.Stderr, LogDebug))
 }
 
 // Test helper methods - these expose internal functionality for testing
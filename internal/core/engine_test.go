@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/EmundoT/git-vendor/internal/types"
 	"github.com/golang/mock/gomock"
 )
 
@@ -65,6 +66,8 @@ func TestManager_LicensePath(t *testing.T) {
 	mockLicense := NewMockLicenseChecker(ctrl)
 	ui := &SilentUICallback{}
 
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{}, nil).AnyTimes()
+
 	syncer := NewVendorSyncer(mockConfig, mockLock, mockGit, mockFS, mockLicense, VendorDir, ui, nil)
 	manager := NewManagerWithSyncer(syncer)
 
@@ -87,6 +90,21 @@ func TestManager_LicensePath(t *testing.T) {
 	}
 }
 
+func TestManager_LicensePath_UsesLicenseDir(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{LicenseDir: "legal"}, nil)
+
+	syncer := NewVendorSyncer(mockConfig, NewMockLockStore(ctrl), NewMockGitClient(ctrl), NewMockFileSystem(ctrl), NewMockLicenseChecker(ctrl), VendorDir, &SilentUICallback{}, nil)
+	manager := NewManagerWithSyncer(syncer)
+
+	if got, want := manager.LicensePath("test-vendor"), filepath.Join("legal", "test-vendor.txt"); got != want {
+		t.Errorf("LicensePath() = %q, want %q", got, want)
+	}
+}
+
 func TestManager_SetUICallback(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -165,6 +183,61 @@ func TestIsVendorInitialized(t *testing.T) {
 	}
 }
 
+func TestNewManagerAt_UsesAlternateVendorDir(t *testing.T) {
+	chdirUnmanagedTest(t)
+	const root = "vendor2"
+
+	m := NewManagerAt(root)
+	if err := m.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if !m.IsInitialized() {
+		t.Error("manager rooted at vendor2 should be initialized after Init")
+	}
+	if IsVendorInitialized() {
+		t.Errorf("Init at %s should not create %s", root, VendorDir)
+	}
+	if _, err := os.Stat(filepath.Join(root, ConfigFile)); err != nil {
+		t.Errorf("config not written under %s: %v", root, err)
+	}
+
+	lock := types.VendorLock{Vendors: []types.LockDetails{{Name: "lib", Ref: "main", CommitHash: "abc123"}}}
+	if err := m.syncer.lockStore.Save(lock); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.LockPath(); got != filepath.Join(root, LockFile) {
+		t.Errorf("LockPath() = %q, want %q", got, filepath.Join(root, LockFile))
+	}
+	loaded, err := NewManagerAt(root).GetLock()
+	if err != nil {
+		t.Fatalf("GetLock: %v", err)
+	}
+	if len(loaded.Vendors) != 1 || loaded.Vendors[0].Name != "lib" {
+		t.Errorf("lock read back from %s = %+v", root, loaded.Vendors)
+	}
+}
+
+func TestManager_EntryPointsRequireInit(t *testing.T) {
+	chdirUnmanagedTest(t)
+	m := NewManagerAt("missing")
+
+	if err := m.Sync(context.Background()); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Sync error = %v, want ErrNotInitialized", err)
+	}
+	if _, err := m.Verify(context.Background()); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Verify error = %v, want ErrNotInitialized", err)
+	}
+	if err := m.RemoveVendor("lib"); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("RemoveVendor error = %v, want ErrNotInitialized", err)
+	}
+	if err := m.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if _, err := m.Verify(context.Background()); errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Verify after Init should not return ErrNotInitialized, got %v", err)
+	}
+}
+
 // ============================================================================
 // Manager Delegation Method Tests
 // ============================================================================
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
 )
 
 // Error format follows ROADMAP 9.5:
@@ -18,12 +20,31 @@ import (
 
 // Sentinel errors for common error conditions.
 // Sentinel errors can be used with errors.Is() for error type checking.
+// Structured errors below match their sentinel too (VendorNotFoundError is
+// ErrVendorNotFound, StaleCommitError is ErrStaleCommit), and other returns
+// wrap one with %w, so callers need not match on message text.
 var (
 	// ErrNotInitialized indicates the vendor directory doesn't exist
 	ErrNotInitialized = errors.New("vendor directory not found. Run 'git-vendor init' first")
 
 	// ErrComplianceFailed indicates a license compliance check failed
 	ErrComplianceFailed = errors.New("compliance check failed")
+
+	// ErrVendorNotFound indicates a vendor name doesn't exist in config or lockfile
+	ErrVendorNotFound = errors.New("vendor not found")
+
+	// ErrNoVendorsConfigured indicates vendor.yml has no vendors
+	ErrNoVendorsConfigured = errors.New("no vendors configured")
+
+	// ErrPathTraversal indicates a destination path escapes the project with ..
+	ErrPathTraversal = errors.New("path traversal with .. is not allowed")
+
+	// ErrLicenseNotAllowed indicates a license was denied by policy or the
+	// allowed list; it is always returned alongside ErrComplianceFailed
+	ErrLicenseNotAllowed = errors.New("license not allowed")
+
+	// ErrStaleCommit indicates a locked commit no longer exists in the remote
+	ErrStaleCommit = errors.New("locked commit no longer exists")
 )
 
 // =============================================================================
@@ -39,11 +60,45 @@ func (e *VendorNotFoundError) Error() string {
 	return fmt.Sprintf("Error: Vendor '%s' not found\n  Context: No vendor with this name exists in %s\n  Fix: Run 'git-vendor list' to see available vendors", e.Name, ConfigPath)
 }
 
+// Is reports whether target is ErrVendorNotFound.
+func (e *VendorNotFoundError) Is(target error) bool {
+	return target == ErrVendorNotFound
+}
+
 // NewVendorNotFoundError creates a VendorNotFoundError.
 func NewVendorNotFoundError(name string) *VendorNotFoundError {
 	return &VendorNotFoundError{Name: name}
 }
 
+// vendorMissingError keeps a one-line service message (e.g. "vendor 'x'
+// not found in lockfile") while matching ErrVendorNotFound.
+type vendorMissingError struct {
+	msg string
+}
+
+func (e *vendorMissingError) Error() string {
+	return e.msg
+}
+
+// Is reports whether target is ErrVendorNotFound.
+func (e *vendorMissingError) Is(target error) bool {
+	return target == ErrVendorNotFound
+}
+
+// NoVendorsMatchedError is returned when a vendor glob pattern matches no vendor in config.
+type NoVendorsMatchedError struct {
+	Pattern string
+}
+
+func (e *NoVendorsMatchedError) Error() string {
+	return fmt.Sprintf("Error: No vendors matched pattern '%s'\n  Context: No vendor name in %s matches this glob\n  Fix: Run 'git-vendor list' to see available vendors, or adjust the pattern", e.Pattern, ConfigPath)
+}
+
+// NewNoVendorsMatchedError creates a NoVendorsMatchedError.
+func NewNoVendorsMatchedError(pattern string) *NoVendorsMatchedError {
+	return &NoVendorsMatchedError{Pattern: pattern}
+}
+
 // GroupNotFoundError is returned when a group doesn't exist in any vendor.
 type GroupNotFoundError struct {
 	Name string
@@ -80,6 +135,20 @@ func NewPathNotFoundError(path, vendorName, ref string) *PathNotFoundError {
 	return &PathNotFoundError{Path: path, VendorName: vendorName, Ref: ref}
 }
 
+// UnmanagedPathError is returned when no vendor mapping or lock entry produces a path.
+type UnmanagedPathError struct {
+	Path string
+}
+
+func (e *UnmanagedPathError) Error() string {
+	return fmt.Sprintf("Error: Path '%s' is not vendored\n  Context: No mapping in %s and no entry in %s produces this path\n  Fix: Run 'git-vendor tree' to see where vendors write", e.Path, ConfigPath, LockPath)
+}
+
+// NewUnmanagedPathError creates an UnmanagedPathError.
+func NewUnmanagedPathError(path string) *UnmanagedPathError {
+	return &UnmanagedPathError{Path: path}
+}
+
 // StaleCommitError is returned when a locked commit no longer exists in the remote.
 type StaleCommitError struct {
 	CommitHash string
@@ -105,6 +174,11 @@ func (e *StaleCommitError) Error() string {
 	return b.String()
 }
 
+// Is reports whether target is ErrStaleCommit.
+func (e *StaleCommitError) Is(target error) bool {
+	return target == ErrStaleCommit
+}
+
 // NewStaleCommitError creates a StaleCommitError.
 func NewStaleCommitError(commitHash, vendorName, ref string) *StaleCommitError {
 	return &StaleCommitError{CommitHash: commitHash, VendorName: vendorName, Ref: ref}
@@ -174,10 +248,89 @@ func NewValidationError(vendorName, ref, field, message string) *ValidationError
 	}
 }
 
+// LicenseChangedError is returned by a strict-license update when the license
+// detected upstream differs from the one recorded for the vendor.
+type LicenseChangedError struct {
+	VendorName string
+	Previous   string // License recorded in the lock (or vendor.yml)
+	Detected   string // License detected upstream during update
+}
+
+func (e *LicenseChangedError) Error() string {
+	return fmt.Sprintf("Error: License of vendor '%s' changed upstream\n  Context: Recorded %s, detected %s\n  Fix: Review the new license, then set license (or license_override) for '%s' in %s, or rerun without --strict-license",
+		e.VendorName, e.Previous, e.Detected, e.VendorName, ConfigPath)
+}
+
+// NewLicenseChangedError creates a LicenseChangedError.
+func NewLicenseChangedError(vendorName, previous, detected string) *LicenseChangedError {
+	return &LicenseChangedError{VendorName: vendorName, Previous: previous, Detected: detected}
+}
+
+// LocalModificationsError is returned when a pull would overwrite vendored
+// files edited since the last sync and the overwrite was not confirmed.
+type LocalModificationsError struct {
+	Files []string // Destination paths whose content no longer matches the lock
+}
+
+func (e *LocalModificationsError) Error() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Error: %s modified since the last sync would be overwritten", Pluralize(len(e.Files), "vendored file", "vendored files")))
+	b.WriteString("\n  Context:")
+	for _, f := range e.Files {
+		b.WriteString("\n    " + f)
+	}
+	b.WriteString("\n  Fix: Rerun with --force to overwrite, --keep-local to preserve the edits, or 'git-vendor accept' to record them")
+	return b.String()
+}
+
+// NewLocalModificationsError creates a LocalModificationsError.
+func NewLocalModificationsError(files []string) *LocalModificationsError {
+	return &LocalModificationsError{Files: files}
+}
+
+// DestinationConflictError is returned when moving a vendor's destination
+// would make it write paths another vendor already writes (or that already
+// exist on disk, for Conflicts with an empty Vendor2).
+type DestinationConflictError struct {
+	VendorName string
+	Dest       string
+	Conflicts  []types.PathConflict
+}
+
+func (e *DestinationConflictError) Error() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Error: Moving '%s' to '%s' would collide with existing paths", e.VendorName, e.Dest))
+	b.WriteString("\n  Context:")
+	for _, c := range e.Conflicts {
+		other := c.Vendor1
+		if other == e.VendorName {
+			other = c.Vendor2
+		}
+		if other == "" {
+			b.WriteString(fmt.Sprintf("\n    %s already exists on disk", c.Path))
+		} else {
+			b.WriteString(fmt.Sprintf("\n    %s is also written by %s (%s)", c.Path, other, c.Reason))
+		}
+	}
+	b.WriteString("\n  Fix: Choose a destination no other vendor writes to, or move the conflicting files first")
+	return b.String()
+}
+
+// NewDestinationConflictError creates a DestinationConflictError.
+func NewDestinationConflictError(vendorName, dest string, conflicts []types.PathConflict) *DestinationConflictError {
+	return &DestinationConflictError{VendorName: vendorName, Dest: dest, Conflicts: conflicts}
+}
+
 // =============================================================================
 // Error Type Checking Helpers
 // =============================================================================
 
+// IsNoVendorsMatched returns true if err is a NoVendorsMatchedError.
+func IsNoVendorsMatched(err error) bool {
+	var e *NoVendorsMatchedError
+	return errors.As(err, &e)
+}
+
 // IsVendorNotFound returns true if err is a VendorNotFoundError.
 func IsVendorNotFound(err error) bool {
 	var e *VendorNotFoundError
@@ -196,6 +349,12 @@ func IsPathNotFound(err error) bool {
 	return errors.As(err, &e)
 }
 
+// IsUnmanagedPath returns true if err is an UnmanagedPathError.
+func IsUnmanagedPath(err error) bool {
+	var e *UnmanagedPathError
+	return errors.As(err, &e)
+}
+
 // IsStaleCommit returns true if err is a StaleCommitError.
 func IsStaleCommit(err error) bool {
 	var e *StaleCommitError
@@ -214,6 +373,24 @@ func IsValidationError(err error) bool {
 	return errors.As(err, &e)
 }
 
+// IsLicenseChanged returns true if err is a LicenseChangedError.
+func IsLicenseChanged(err error) bool {
+	var e *LicenseChangedError
+	return errors.As(err, &e)
+}
+
+// IsLocalModifications returns true if err is a LocalModificationsError.
+func IsLocalModifications(err error) bool {
+	var e *LocalModificationsError
+	return errors.As(err, &e)
+}
+
+// IsDestinationConflict returns true if err is a DestinationConflictError.
+func IsDestinationConflict(err error) bool {
+	var e *DestinationConflictError
+	return errors.As(err, &e)
+}
+
 // HookError is returned when a pre/post-sync hook fails.
 type HookError struct {
 	VendorName string
@@ -30,6 +30,16 @@ func MatchesExclude(relPath string, patterns []string) bool {
 	return false
 }
 
+// beyondMaxDepth reports whether the directory relDir (relative to a mapping's
+// From) is at or below maxDepth, so a walk limited to maxDepth levels of files
+// should skip it. maxDepth <= 0 means unlimited.
+func beyondMaxDepth(relDir string, maxDepth int) bool {
+	if maxDepth <= 0 || relDir == "." || relDir == "" {
+		return false
+	}
+	return strings.Count(filepath.ToSlash(relDir), "/")+1 >= maxDepth
+}
+
 // matchGlob matches a path against a single glob pattern with ** support.
 // Both path and pattern MUST be forward-slash normalized before calling matchGlob.
 // matchGlob handles three cases:
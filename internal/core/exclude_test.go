@@ -3,6 +3,7 @@ package core
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -144,9 +145,9 @@ func TestCopyDir_ExcludeSimpleGlob(t *testing.T) {
 	os.WriteFile(filepath.Join(srcDir, "utils.go"), []byte("package utils"), 0644)
 
 	svc := NewFileCopyService(NewOSFileSystem())
-	stats, err := svc.copyDirWithExcludes(srcDir, dstDir, []string{"*.md"})
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, nil, []string{"*.md"}, 0, nil, nil)
 	if err != nil {
-		t.Fatalf("copyDirWithExcludes failed: %v", err)
+		t.Fatalf("copyDirFiltered failed: %v", err)
 	}
 
 	// 2 .go files copied, 1 .md excluded
@@ -180,9 +181,9 @@ func TestCopyDir_ExcludeDirectoryGlob(t *testing.T) {
 	os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main"), 0644)
 
 	svc := NewFileCopyService(NewOSFileSystem())
-	stats, err := svc.copyDirWithExcludes(srcDir, dstDir, []string{".claude/**"})
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, nil, []string{".claude/**"}, 0, nil, nil)
 	if err != nil {
-		t.Fatalf("copyDirWithExcludes failed: %v", err)
+		t.Fatalf("copyDirFiltered failed: %v", err)
 	}
 
 	// 1 .go file copied, .claude dir skipped entirely via SkipDir
@@ -204,6 +205,53 @@ func TestCopyDir_ExcludeDirectoryGlob(t *testing.T) {
 	}
 }
 
+// TestCopyDir_ExcludeNestedTestdata verifies that "testdata/**" only matches at the
+// mapping root while "**/testdata/**" also skips testdata trees in subpackages.
+func TestCopyDir_ExcludeNestedTestdata(t *testing.T) {
+	srcDir := t.TempDir()
+
+	os.MkdirAll(filepath.Join(srcDir, "testdata"), 0755)
+	os.MkdirAll(filepath.Join(srcDir, "pkg", "parser", "testdata", "golden"), 0755)
+	os.WriteFile(filepath.Join(srcDir, "testdata", "root.json"), []byte("{}"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "pkg", "parser", "testdata", "golden", "out.txt"), []byte("golden"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "pkg", "parser", "parser.go"), []byte("package parser"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main"), 0644)
+
+	svc := NewFileCopyService(NewOSFileSystem())
+
+	// Root-anchored pattern: nested testdata is still copied
+	rootOnly := t.TempDir()
+	stats, err := svc.copyDirFiltered(srcDir, rootOnly, nil, []string{"testdata/**"}, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("copyDirFiltered failed: %v", err)
+	}
+	if stats.FileCount != 3 {
+		t.Errorf("FileCount = %d, want 3", stats.FileCount)
+	}
+	if _, err := os.Stat(filepath.Join(rootOnly, "testdata")); !os.IsNotExist(err) {
+		t.Error("root testdata should have been excluded")
+	}
+	if _, err := os.Stat(filepath.Join(rootOnly, "pkg", "parser", "testdata", "golden", "out.txt")); err != nil {
+		t.Errorf("nested testdata should not match root-anchored pattern: %v", err)
+	}
+
+	// Recursive pattern: every testdata tree is skipped
+	recursive := t.TempDir()
+	stats, err = svc.copyDirFiltered(srcDir, recursive, nil, []string{"**/testdata/**"}, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("copyDirFiltered failed: %v", err)
+	}
+	if stats.FileCount != 2 {
+		t.Errorf("FileCount = %d, want 2", stats.FileCount)
+	}
+	if _, err := os.Stat(filepath.Join(recursive, "pkg", "parser", "testdata")); !os.IsNotExist(err) {
+		t.Error("nested testdata should have been excluded")
+	}
+	if _, err := os.Stat(filepath.Join(recursive, "pkg", "parser", "parser.go")); err != nil {
+		t.Errorf("parser.go should have been copied: %v", err)
+	}
+}
+
 // TestCopyDir_ExcludeMultiplePatterns verifies that multiple exclude patterns
 // are applied together.
 func TestCopyDir_ExcludeMultiplePatterns(t *testing.T) {
@@ -221,9 +269,9 @@ func TestCopyDir_ExcludeMultiplePatterns(t *testing.T) {
 
 	excludes := []string{".claude/**", ".github/**", "README.md"}
 	svc := NewFileCopyService(NewOSFileSystem())
-	stats, err := svc.copyDirWithExcludes(srcDir, dstDir, excludes)
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, nil, excludes, 0, nil, nil)
 	if err != nil {
-		t.Fatalf("copyDirWithExcludes failed: %v", err)
+		t.Fatalf("copyDirFiltered failed: %v", err)
 	}
 
 	// 2 .go files copied; .claude (SkipDir), .github (SkipDir), README.md excluded
@@ -244,7 +292,7 @@ func TestCopyDir_ExcludeMultiplePatterns(t *testing.T) {
 }
 
 // TestCopyDir_NoExcludePatterns verifies backward compatibility — when no exclude
-// patterns are specified, copyDirWithExcludes copies everything (same as CopyDir).
+// patterns are specified, copyDirFiltered copies everything (same as CopyDir).
 func TestCopyDir_NoExcludePatterns(t *testing.T) {
 	srcDir := t.TempDir()
 	dstDir := t.TempDir()
@@ -253,9 +301,9 @@ func TestCopyDir_NoExcludePatterns(t *testing.T) {
 	os.WriteFile(filepath.Join(srcDir, "README.md"), []byte("# readme"), 0644)
 
 	svc := NewFileCopyService(NewOSFileSystem())
-	stats, err := svc.copyDirWithExcludes(srcDir, dstDir, nil)
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, nil, nil, 0, nil, nil)
 	if err != nil {
-		t.Fatalf("copyDirWithExcludes failed: %v", err)
+		t.Fatalf("copyDirFiltered failed: %v", err)
 	}
 
 	if stats.FileCount != 2 {
@@ -277,9 +325,9 @@ func TestCopyDir_ExcludeSkipsGitDirectories(t *testing.T) {
 	os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main"), 0644)
 
 	svc := NewFileCopyService(NewOSFileSystem())
-	stats, err := svc.copyDirWithExcludes(srcDir, dstDir, []string{"*.md"})
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, nil, []string{"*.md"}, 0, nil, nil)
 	if err != nil {
-		t.Fatalf("copyDirWithExcludes failed: %v", err)
+		t.Fatalf("copyDirFiltered failed: %v", err)
 	}
 
 	if stats.FileCount != 1 {
@@ -401,3 +449,244 @@ func TestCopyMappings_ExcludeOnlyAffectsDirectoryMappings(t *testing.T) {
 		t.Errorf("FileCount = %d, want 1", stats.FileCount)
 	}
 }
+
+// ============================================================================
+// Include Filter Tests
+// ============================================================================
+
+// TestCopyDir_IncludeOnlyGoFiles verifies that Include: ["**/*.go"] copies only Go
+// files out of a mixed directory and skips directories left without matches.
+func TestCopyDir_IncludeOnlyGoFiles(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	os.MkdirAll(filepath.Join(srcDir, "internal"), 0755)
+	os.MkdirAll(filepath.Join(srcDir, "docs"), 0755)
+	os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "internal", "util.go"), []byte("package internal"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "README.md"), []byte("# readme"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "go.sum"), []byte("sum"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "docs", "guide.md"), []byte("guide"), 0644)
+
+	svc := NewFileCopyService(NewOSFileSystem())
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, []string{"**/*.go"}, nil, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("copyDirFiltered failed: %v", err)
+	}
+
+	if stats.FileCount != 2 {
+		t.Errorf("FileCount = %d, want 2", stats.FileCount)
+	}
+	if stats.Excluded != 3 {
+		t.Errorf("Excluded = %d, want 3", stats.Excluded)
+	}
+	for _, kept := range []string{"main.go", filepath.Join("internal", "util.go")} {
+		if _, err := os.Stat(filepath.Join(dstDir, kept)); err != nil {
+			t.Errorf("%s should have been copied: %v", kept, err)
+		}
+	}
+	for _, skipped := range []string{"README.md", "go.sum", "docs"} {
+		if _, err := os.Stat(filepath.Join(dstDir, skipped)); !os.IsNotExist(err) {
+			t.Errorf("%s should not be in destination", skipped)
+		}
+	}
+}
+
+// TestCopyDir_IncludeRootOnlyGlob verifies "*.go" matches only files at the mapping
+// root, consistent with exclude pattern semantics.
+func TestCopyDir_IncludeRootOnlyGlob(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	os.MkdirAll(filepath.Join(srcDir, "sub"), 0755)
+	os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "notes.txt"), []byte("notes"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "sub", "nested.go"), []byte("package sub"), 0644)
+
+	svc := NewFileCopyService(NewOSFileSystem())
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, []string{"*.go"}, nil, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("copyDirFiltered failed: %v", err)
+	}
+
+	if stats.FileCount != 1 {
+		t.Errorf("FileCount = %d, want 1", stats.FileCount)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "main.go")); err != nil {
+		t.Errorf("main.go should have been copied: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "sub")); !os.IsNotExist(err) {
+		t.Error("sub/ should not be created when none of its files are included")
+	}
+}
+
+// TestCopyMappings_IncludeAndExcludeCompose verifies a file must match an include
+// and no exclude: Exclude wins when a file matches both.
+func TestCopyMappings_IncludeAndExcludeCompose(t *testing.T) {
+	repoDir := t.TempDir()
+	workDir := t.TempDir()
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	os.MkdirAll(filepath.Join(repoDir, "src", "testdata"), 0755)
+	os.WriteFile(filepath.Join(repoDir, "src", "lib.go"), []byte("package lib"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "src", "lib_test.go"), []byte("package lib"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "src", "testdata", "fixture.go"), []byte("package fixture"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "src", "README.md"), []byte("# readme"), 0644)
+
+	svc := NewFileCopyService(NewOSFileSystem())
+	vendor := &types.VendorSpec{Name: "test-vendor"}
+	spec := types.BranchSpec{
+		Ref: "main",
+		Mapping: []types.PathMapping{
+			{
+				From:    "src",
+				To:      "lib",
+				Include: []string{"**/*.go"},
+				Exclude: []string{"*_test.go", "testdata/**"},
+			},
+		},
+	}
+
+	stats, err := svc.CopyMappings(repoDir, vendor, spec)
+	if err != nil {
+		t.Fatalf("CopyMappings failed: %v", err)
+	}
+
+	if stats.FileCount != 1 {
+		t.Errorf("FileCount = %d, want 1", stats.FileCount)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, "lib", "lib.go")); err != nil {
+		t.Errorf("lib.go should have been copied: %v", err)
+	}
+	for _, skipped := range []string{"lib_test.go", "testdata", "README.md"} {
+		if _, err := os.Stat(filepath.Join(workDir, "lib", skipped)); !os.IsNotExist(err) {
+			t.Errorf("%s should not be in destination", skipped)
+		}
+	}
+}
+
+// ============================================================================
+// max_depth
+// ============================================================================
+
+// TestCopyMappings_MaxDepthOneCopiesTopLevelOnly verifies max_depth: 1 copies
+// only the files directly in From and skips every subdirectory.
+func TestCopyMappings_MaxDepthOneCopiesTopLevelOnly(t *testing.T) {
+	repoDir := t.TempDir()
+	workDir := t.TempDir()
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	os.MkdirAll(filepath.Join(repoDir, "src", "sub", "deep"), 0755)
+	os.WriteFile(filepath.Join(repoDir, "src", "a.go"), []byte("package a"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "src", "b.go"), []byte("package b"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "src", "sub", "c.go"), []byte("package c"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "src", "sub", "deep", "d.go"), []byte("package d"), 0644)
+
+	svc := NewFileCopyService(NewOSFileSystem())
+	vendor := &types.VendorSpec{Name: "test-vendor"}
+	spec := types.BranchSpec{
+		Ref:     "main",
+		Mapping: []types.PathMapping{{From: "src", To: "lib", MaxDepth: 1}},
+	}
+
+	stats, err := svc.CopyMappings(repoDir, vendor, spec)
+	if err != nil {
+		t.Fatalf("CopyMappings failed: %v", err)
+	}
+
+	if stats.FileCount != 2 {
+		t.Errorf("FileCount = %d, want 2", stats.FileCount)
+	}
+	for _, copied := range []string{"a.go", "b.go"} {
+		if _, err := os.Stat(filepath.Join(workDir, "lib", copied)); err != nil {
+			t.Errorf("%s should have been copied: %v", copied, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(workDir, "lib", "sub")); !os.IsNotExist(err) {
+		t.Error("sub/ is below max_depth 1 and should not be in destination")
+	}
+	if len(stats.FileHashes) != 2 {
+		t.Errorf("FileHashes = %v, want only the top-level files", stats.FileHashes)
+	}
+}
+
+// TestCopyDir_MaxDepthTwoIncludesOneSubdirLevel verifies max_depth: 2 copies
+// From's files and its direct subdirectories' files, but nothing deeper.
+func TestCopyDir_MaxDepthTwoIncludesOneSubdirLevel(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	os.MkdirAll(filepath.Join(srcDir, "sub", "deep"), 0755)
+	os.WriteFile(filepath.Join(srcDir, "a.go"), []byte("package a"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "sub", "c.go"), []byte("package c"), 0644)
+	os.WriteFile(filepath.Join(srcDir, "sub", "deep", "d.go"), []byte("package d"), 0644)
+
+	svc := NewFileCopyService(NewOSFileSystem())
+	stats, err := svc.copyDirFiltered(srcDir, dstDir, nil, nil, 2, nil, nil)
+	if err != nil {
+		t.Fatalf("copyDirFiltered failed: %v", err)
+	}
+
+	if stats.FileCount != 2 {
+		t.Errorf("FileCount = %d, want 2", stats.FileCount)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "sub", "c.go")); err != nil {
+		t.Errorf("sub/c.go should have been copied: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "sub", "deep")); !os.IsNotExist(err) {
+		t.Error("sub/deep/ is below max_depth 2 and should not be in destination")
+	}
+}
+
+// TestCopyDir_FilteredPreservesDirectoryPermissions verifies that directories
+// copied by copyDirFiltered keep their source mode, with and without includes.
+func TestCopyDir_FilteredPreservesDirectoryPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits are not supported on Windows")
+	}
+	for _, tc := range []struct {
+		name     string
+		includes []string
+	}{
+		{name: "no includes"},
+		{name: "with includes", includes: []string{"scripts/**"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srcDir := t.TempDir()
+			dstDir := filepath.Join(t.TempDir(), "dst")
+
+			os.MkdirAll(filepath.Join(srcDir, "scripts"), 0755)
+			os.WriteFile(filepath.Join(srcDir, "scripts", "run.sh"), []byte("#!/bin/sh"), 0755)
+			if err := os.Chmod(filepath.Join(srcDir, "scripts"), 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			svc := NewFileCopyService(NewOSFileSystem())
+			if _, err := svc.copyDirFiltered(srcDir, dstDir, tc.includes, nil, 0, nil, nil); err != nil {
+				t.Fatalf("copyDirFiltered failed: %v", err)
+			}
+
+			info, err := os.Stat(filepath.Join(dstDir, "scripts"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := info.Mode().Perm(); got != 0700 {
+				t.Errorf("scripts/ mode = %o, want 700", got)
+			}
+		})
+	}
+}
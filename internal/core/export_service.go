@@ -0,0 +1,328 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// Export archive layout: ExportManifestFile at the root, vendored files under
+// exportFilesDir at their project-relative paths, and the vendor directory's
+// config and lock under exportStateDir, with the license directory
+// (ResolveLicenseDir, wherever license_dir puts it) as its licenses/.
+const (
+	// ExportManifestFile is the manifest's name inside an export archive
+	ExportManifestFile  = "manifest.json"
+	exportFilesDir      = "files"
+	exportStateDir      = "state"
+	exportSchemaVersion = "1.0"
+)
+
+// Export writes a gzip-compressed tarball at output for restoring the vendored
+// tree without network access: every destination vendor.lock records
+// (FileHashes paths and position targets), the config, the lock, the licenses
+// directory and a manifest; a project never pulled exports only its config.
+// Files are staged through the FileSystem in a temp directory under the vendor
+// directory. A recorded destination missing on disk fails the export.
+func (s *VendorSyncer) Export(output string) (*types.ExportManifest, error) {
+	lock, err := s.lockStore.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("Export: load lock: %w", err)
+	}
+
+	staging, err := s.fs.CreateTemp(s.rootDir, ".export-*")
+	if err != nil {
+		return nil, fmt.Errorf("Export: create staging dir: %w", err)
+	}
+	defer func() { _ = s.fs.RemoveAll(staging) }() //nolint:errcheck // cleanup in defer
+
+	manifest := &types.ExportManifest{
+		SchemaVersion: exportSchemaVersion,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+		Vendors:       []types.ExportVendor{},
+		Files:         []types.ExportFile{},
+		State:         []types.ExportFile{},
+	}
+	for _, entry := range lock.Vendors {
+		manifest.Vendors = append(manifest.Vendors, types.ExportVendor{Name: entry.Name, Ref: entry.Ref, CommitHash: entry.CommitHash})
+	}
+
+	for _, dest := range lockedDestinations(lock) {
+		if err := ValidateDestPath(dest); err != nil {
+			return nil, fmt.Errorf("Export: %w", err)
+		}
+		hash, err := s.stageFile(filepath.FromSlash(dest), filepath.Join(staging, exportFilesDir, filepath.FromSlash(dest)))
+		if err != nil {
+			return nil, fmt.Errorf("Export: %s: %w (run 'git-vendor sync' to restore it)", dest, err)
+		}
+		manifest.Files = append(manifest.Files, types.ExportFile{Path: dest, Hash: hash})
+	}
+
+	state := []string{filepath.Base(s.configStore.Path())}
+	if _, err := s.fs.Stat(s.lockStore.Path()); err == nil {
+		state = append(state, filepath.Base(s.lockStore.Path()))
+	}
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("Export: load config: %w", err)
+	}
+	licenseDir := ResolveLicenseDir(s.rootDir, config)
+	licenses, err := s.listFilesUnder(licenseDir, "")
+	if err != nil {
+		return nil, fmt.Errorf("Export: list licenses: %w", err)
+	}
+	stateSources := make(map[string]string, len(state)+len(licenses))
+	for _, rel := range state {
+		stateSources[rel] = filepath.Join(s.rootDir, rel)
+	}
+	for _, rel := range licenses {
+		archived := path.Join(LicensesDir, rel)
+		state = append(state, archived)
+		stateSources[archived] = filepath.Join(licenseDir, filepath.FromSlash(rel))
+	}
+	for _, rel := range state {
+		hash, err := s.stageFile(stateSources[rel], filepath.Join(staging, exportStateDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("Export: %s: %w", rel, err)
+		}
+		manifest.State = append(manifest.State, types.ExportFile{Path: rel, Hash: hash})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("Export: encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, ExportManifestFile), append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("Export: write manifest: %w", err)
+	}
+	if err := writeSnapshot(staging, output); err != nil {
+		return nil, fmt.Errorf("Export: %w", err)
+	}
+	return manifest, nil
+}
+
+// Import extracts an archive written by Export into place: vendored files to
+// their project-relative paths and state files into the vendor directory,
+// replacing what is there. The archive is unpacked into a staging directory
+// first and checked before anything in the project changes:
+//   - a vendored file is written only where the archive's own vendor.lock
+//     records a destination, inside the project root (ValidateDestWithinRoot)
+//     and outside .git/
+//   - state files are limited to the config, the lock and licenses/, which
+//     is written to the license directory the archive's config names
+//   - every staged file must match its manifest SHA-256
+//
+// The manifest hashes come from the archive itself, so they catch damage, not
+// tampering; the path checks are what keep an archive inside its vendored tree.
+func (s *VendorSyncer) Import(archive string) (*types.ExportManifest, error) {
+	if err := s.fs.MkdirAll(s.rootDir, 0755); err != nil {
+		return nil, fmt.Errorf("Import: create vendor directory: %w", err)
+	}
+	staging, err := s.fs.CreateTemp(s.rootDir, ".import-*")
+	if err != nil {
+		return nil, fmt.Errorf("Import: create staging dir: %w", err)
+	}
+	defer func() { _ = s.fs.RemoveAll(staging) }() //nolint:errcheck // cleanup in defer
+
+	if err := extractSnapshot(archive, staging); err != nil {
+		return nil, fmt.Errorf("Import: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Join(staging, ExportManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("Import: %s has no %s; was it written by 'git-vendor export'?", archive, ExportManifestFile)
+	}
+	var manifest types.ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("Import: parse %s: %w", ExportManifestFile, err)
+	}
+	if manifest.SchemaVersion != exportSchemaVersion {
+		return nil, fmt.Errorf("Import: unsupported export schema version %q (expected %s)", manifest.SchemaVersion, exportSchemaVersion)
+	}
+
+	allowed, err := importAllowedFiles(filepath.Join(staging, exportStateDir), len(manifest.Files) > 0)
+	if err != nil {
+		return nil, fmt.Errorf("Import: %w", err)
+	}
+	licenseDir, err := importLicenseDir(filepath.Join(staging, exportStateDir), s.rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("Import: %w", err)
+	}
+	// stateDest maps an archived state path to the directory it is written
+	// under and its path there
+	stateDest := func(rel string) (string, string) {
+		if license, ok := strings.CutPrefix(rel, LicensesDir+"/"); ok {
+			return licenseDir, license
+		}
+		return s.rootDir, rel
+	}
+
+	type importCopy struct{ src, dst string }
+	var copies []importCopy
+	for _, group := range []struct {
+		files   []types.ExportFile
+		dir     string
+		dest    func(string) (string, string)
+		allowed func(string) bool
+	}{
+		{manifest.Files, exportFilesDir, func(p string) (string, string) { return ".", p }, func(p string) bool { return allowed[p] }},
+		{manifest.State, exportStateDir, stateDest, s.isImportStateFile},
+	} {
+		for _, f := range group.files {
+			if err := ValidateDestPath(f.Path); err != nil {
+				return nil, fmt.Errorf("Import: %w", err)
+			}
+			if isGitPath(f.Path) {
+				return nil, fmt.Errorf("Import: %s is inside .git/, which an export never contains", f.Path)
+			}
+			if !group.allowed(f.Path) {
+				return nil, fmt.Errorf("Import: %s is not a vendored destination or state file recorded by the archive's lock", f.Path)
+			}
+			destDir, destRel := group.dest(f.Path)
+			if err := ValidateDestWithinRoot(destDir, filepath.FromSlash(destRel)); err != nil {
+				return nil, fmt.Errorf("Import: %w", err)
+			}
+			src := filepath.Join(staging, group.dir, filepath.FromSlash(f.Path))
+			hash, err := fileSHA256(src)
+			if err != nil {
+				return nil, fmt.Errorf("Import: %s listed in the manifest but not in the archive", f.Path)
+			}
+			if hash != f.Hash {
+				return nil, fmt.Errorf("Import: %s does not match its manifest checksum; the archive is damaged", f.Path)
+			}
+			copies = append(copies, importCopy{src: src, dst: filepath.Join(destDir, filepath.FromSlash(destRel))})
+		}
+	}
+
+	for _, c := range copies {
+		if _, err := s.stageFile(c.src, c.dst); err != nil {
+			return nil, fmt.Errorf("Import: write %s: %w", c.dst, err)
+		}
+	}
+	return &manifest, nil
+}
+
+// importAllowedFiles returns the destinations the staged vendor.lock in
+// stateDir records (lockedDestinations). needLock makes a missing lock an
+// error: vendored files are only restored where a lock says they belong.
+func importAllowedFiles(stateDir string, needLock bool) (map[string]bool, error) {
+	allowed := make(map[string]bool)
+	lock, err := NewFileLockStore(stateDir).Load()
+	if errors.Is(err, os.ErrNotExist) && !needLock {
+		return allowed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read the archive's %s: %w", LockFile, err)
+	}
+	for _, dest := range lockedDestinations(lock) {
+		allowed[dest] = true
+	}
+	return allowed, nil
+}
+
+// importLicenseDir returns the license directory the config staged in
+// stateDir names (ResolveLicenseDir against rootDir), so licenses are
+// restored where that config's sync would write them.
+func importLicenseDir(stateDir, rootDir string) (string, error) {
+	store := NewFileConfigStore(stateDir)
+	store.warnWriter = nil // The config is checked again wherever it is used
+	config, err := store.Load()
+	if err != nil {
+		return "", fmt.Errorf("read the archive's config: %w", err)
+	}
+	return ResolveLicenseDir(rootDir, config), nil
+}
+
+// isImportStateFile reports whether rel names state an export writes under the
+// vendor directory: the config, the lock, or a file under licenses/.
+func (s *VendorSyncer) isImportStateFile(rel string) bool {
+	switch rel {
+	case filepath.Base(s.configStore.Path()), filepath.Base(s.lockStore.Path()):
+		return true
+	}
+	return strings.HasPrefix(rel, LicensesDir+"/")
+}
+
+// isGitPath reports whether the forward-slash path rel is .git or lies
+// under a .git directory at any depth.
+func isGitPath(rel string) bool {
+	for _, part := range strings.Split(rel, "/") {
+		if strings.EqualFold(part, ".git") {
+			return true
+		}
+	}
+	return false
+}
+
+// stageFile copies src to dst through the FileSystem, creating dst's parent
+// directories, and returns the SHA-256 of the copied content.
+func (s *VendorSyncer) stageFile(src, dst string) (string, error) {
+	if err := s.fs.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", err
+	}
+	stats, err := s.fs.CopyFile(src, dst)
+	if err != nil {
+		return "", err
+	}
+	return stats.FileHashes[filepath.ToSlash(dst)], nil
+}
+
+// listFilesUnder returns the regular files below root/rel as forward-slash
+// paths relative to root, sorted. A missing root/rel yields none.
+func (s *VendorSyncer) listFilesUnder(root, rel string) ([]string, error) {
+	entries, err := s.fs.ReadDir(filepath.Join(root, filepath.FromSlash(rel)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, name := range entries {
+		if dir, ok := strings.CutSuffix(name, "/"); ok {
+			nested, err := s.listFilesUnder(root, path.Join(rel, dir))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, nested...)
+			continue
+		}
+		files = append(files, path.Join(rel, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// lockedDestinations returns every destination file lock records, from
+// FileHashes and position targets (position specifiers stripped),
+// deduplicated and sorted.
+func lockedDestinations(lock types.VendorLock) []string {
+	seen := make(map[string]bool)
+	add := func(dest string) {
+		if file, _, err := types.ParsePathPosition(dest); err == nil {
+			dest = file
+		}
+		seen[filepath.ToSlash(dest)] = true
+	}
+	for i := range lock.Vendors {
+		for dest := range lock.Vendors[i].FileHashes {
+			add(dest)
+		}
+		for _, pos := range lock.Vendors[i].Positions {
+			add(pos.To)
+		}
+	}
+	dests := make([]string, 0, len(seen))
+	for dest := range seen {
+		dests = append(dests, dest)
+	}
+	sort.Strings(dests)
+	return dests
+}
@@ -0,0 +1,271 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// setupExportTestEnv returns a pull test env whose working directory is the
+// project root, with two synced vendors (lib-a with two files, lib-b with one)
+// and a license copy for lib-a. Returns the env and the vendored file contents.
+func setupExportTestEnv(t *testing.T) (*pullTestEnv, map[string]string) {
+	t.Helper()
+	env := setupPullTestEnv(t)
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(env.configDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	env.writeConfig(createTestConfig(
+		types.VendorSpec{Name: "lib-a", URL: "https://github.com/owner/a", Specs: []types.BranchSpec{
+			{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: "vendor/a"}}},
+		}},
+		types.VendorSpec{Name: "lib-b", URL: "https://github.com/owner/b", Specs: []types.BranchSpec{
+			{Ref: "v1", Mapping: []types.PathMapping{{From: "b.go", To: "vendor/b/b.go"}}},
+		}},
+	))
+	env.writeLock(types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "lib-a", Ref: "main", CommitHash: "aaa111", Updated: "2024-01-01T00:00:00Z",
+			FileHashes: map[string]string{"vendor/a/one.go": "h1", "vendor/a/sub/two.go": "h2"}},
+		{Name: "lib-b", Ref: "v1", CommitHash: "bbb222", Updated: "2024-01-01T00:00:00Z",
+			FileHashes: map[string]string{"vendor/b/b.go": "h3"}},
+	}})
+
+	files := map[string]string{
+		"vendor/a/one.go":     "package a // one",
+		"vendor/a/sub/two.go": "package sub // two",
+		"vendor/b/b.go":       "package b",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(env.rootDir, LicensesDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(env.rootDir, LicensesDir, "lib-a.txt"), []byte("MIT License"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return env, files
+}
+
+func TestExportImport_RoundTripsTwoVendors(t *testing.T) {
+	env, files := setupExportTestEnv(t)
+	archive := filepath.Join(t.TempDir(), "vendor-snapshot.tar.gz")
+
+	manifest, err := env.syncer.Export(archive)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(manifest.Vendors) != 2 || len(manifest.Files) != 3 {
+		t.Errorf("manifest = %d vendors, %d files, want 2 and 3", len(manifest.Vendors), len(manifest.Files))
+	}
+	wantState := map[string]bool{ConfigFile: true, LockFile: true, "licenses/lib-a.txt": true}
+	for _, f := range manifest.State {
+		delete(wantState, f.Path)
+	}
+	if len(wantState) != 0 {
+		t.Errorf("manifest state = %+v, missing %v", manifest.State, wantState)
+	}
+
+	origConfig, err := os.ReadFile(env.syncer.configStore.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	origLock, err := os.ReadFile(env.syncer.lockStore.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wipe the vendored tree and the vendor directory, then restore from the archive
+	if err := os.RemoveAll("vendor"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(env.rootDir); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.syncer.Import(archive); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	for name, want := range files {
+		got, err := os.ReadFile(name)
+		if err != nil {
+			t.Errorf("%s not restored: %v", name, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	for path, want := range map[string][]byte{
+		env.syncer.configStore.Path():                        origConfig,
+		env.syncer.lockStore.Path():                          origLock,
+		filepath.Join(env.rootDir, LicensesDir, "lib-a.txt"): []byte("MIT License"),
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("%s not restored: %v", path, err)
+			continue
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+	if _, err := env.syncer.lockStore.Load(); err != nil {
+		t.Errorf("restored lock does not load: %v", err)
+	}
+
+	// Staging directories are cleaned up
+	entries, err := os.ReadDir(env.rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != LicensesDir {
+			t.Errorf("unexpected leftover directory %s in vendor dir", e.Name())
+		}
+	}
+}
+
+func TestExportImport_UsesConfiguredLicenseDir(t *testing.T) {
+	env, _ := setupExportTestEnv(t)
+	cfg, err := env.syncer.configStore.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.LicenseDir = "legal"
+	env.writeConfig(cfg)
+	if err := os.MkdirAll("legal", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("legal", "lib-b.txt"), []byte("Apache License"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "vendor-snapshot.tar.gz")
+	manifest, err := env.syncer.Export(archive)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	var licenses []string
+	for _, f := range manifest.State {
+		if f.Path != ConfigFile && f.Path != LockFile {
+			licenses = append(licenses, f.Path)
+		}
+	}
+	if len(licenses) != 1 || licenses[0] != "licenses/lib-b.txt" {
+		t.Errorf("archived licenses = %v, want only legal/lib-b.txt as licenses/lib-b.txt", licenses)
+	}
+
+	if err := os.RemoveAll("legal"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.syncer.Import(archive); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if got, err := os.ReadFile(filepath.Join("legal", "lib-b.txt")); err != nil || string(got) != "Apache License" {
+		t.Errorf("legal/lib-b.txt = %q, %v; want it restored under license_dir", got, err)
+	}
+}
+
+func TestExport_FailsOnMissingDestination(t *testing.T) {
+	env, _ := setupExportTestEnv(t)
+	if err := os.Remove("vendor/b/b.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "out.tar.gz")
+	if _, err := env.syncer.Export(archive); err == nil {
+		t.Fatal("Export() should fail when a locked destination is missing")
+	}
+	if _, err := os.Stat(archive); !os.IsNotExist(err) {
+		t.Errorf("no archive should be written on failure, stat err = %v", err)
+	}
+}
+
+func TestImport_RejectsPathsTheLockDoesNotRecord(t *testing.T) {
+	env, _ := setupExportTestEnv(t)
+	archive := filepath.Join(t.TempDir(), "good.tar.gz")
+	if _, err := env.syncer.Export(archive); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	// Repack the archive with one extra file and a matching manifest entry
+	craft := func(t *testing.T, rel string) string {
+		t.Helper()
+		dir := t.TempDir()
+		if err := extractSnapshot(archive, dir); err != nil {
+			t.Fatal(err)
+		}
+		src := filepath.Join(dir, exportFilesDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(src, []byte("#!/bin/sh\necho pwned\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		hash, err := fileSHA256(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var manifest types.ExportManifest
+		data, err := os.ReadFile(filepath.Join(dir, ExportManifestFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			t.Fatal(err)
+		}
+		manifest.Files = append(manifest.Files, types.ExportFile{Path: rel, Hash: hash})
+		if data, err = json.Marshal(manifest); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ExportManifestFile), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out := filepath.Join(t.TempDir(), "crafted.tar.gz")
+		if err := writeSnapshot(dir, out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	for _, rel := range []string{".git/hooks/pre-commit", "vendor/a/extra.go"} {
+		if _, err := env.syncer.Import(craft(t, rel)); err == nil {
+			t.Errorf("Import() of an archive adding %s should fail", rel)
+		}
+		if _, err := os.Stat(rel); !os.IsNotExist(err) {
+			t.Errorf("%s written by a rejected import (stat err = %v)", rel, err)
+		}
+	}
+
+	// A locked destination reached through a symlinked directory escapes the project
+	outside := t.TempDir()
+	if err := os.RemoveAll("vendor/b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, "vendor/b"); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if _, err := env.syncer.Import(archive); err == nil {
+		t.Error("Import() should refuse to write through a symlink leaving the project")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "b.go")); !os.IsNotExist(err) {
+		t.Errorf("file written outside the project (stat err = %v)", err)
+	}
+}
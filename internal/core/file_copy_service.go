@@ -3,9 +3,11 @@ package core
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
@@ -20,7 +22,8 @@ var _ FileCopyServiceInterface = (*FileCopyService)(nil)
 
 // FileCopyService handles copying files according to path mappings
 type FileCopyService struct {
-	fs FileSystem
+	fs     FileSystem
+	logger *loggerSlot // Set by NewVendorSyncer; nil = no logging
 }
 
 // NewFileCopyService creates a new FileCopyService
@@ -31,24 +34,54 @@ func NewFileCopyService(fs FileSystem) *FileCopyService {
 }
 
 // CopyMappings copies all files according to path mappings for a vendor spec.
+// Directory mappings skip paths matched by the project's .vendorignore
+// (LoadVendorIgnore) as well as their own exclude patterns.
 // Security: CopyMappings validates all destination paths via ValidateDestPath
 // in copyMapping before any file I/O occurs.
 func (s *FileCopyService) CopyMappings(tempDir string, vendor *types.VendorSpec, spec types.BranchSpec) (CopyStats, error) {
 	var totalStats CopyStats
 
+	ignore, err := LoadVendorIgnore(".")
+	if err != nil {
+		return totalStats, err
+	}
+
 	for _, mapping := range spec.Mapping {
-		stats, err := s.copyMapping(tempDir, vendor, spec, mapping)
+		stats, err := s.copyMapping(tempDir, vendor, spec, mapping, ignore)
 		if err != nil {
 			return totalStats, err
 		}
 		totalStats.Add(stats)
+		// A later position splice or source removal changes a file an earlier
+		// mapping copied; its streamed hash no longer describes the disk
+		for _, p := range stats.Positions {
+			if destFile, _, err := types.ParsePathPosition(s.computeDestPath(types.PathMapping{From: p.From, To: p.To}, spec, vendor)); err == nil {
+				delete(totalStats.FileHashes, filepath.ToSlash(destFile))
+			}
+		}
+		for _, removed := range stats.Removed {
+			delete(totalStats.FileHashes, filepath.ToSlash(removed))
+		}
 	}
 
 	return totalStats, nil
 }
 
+// isBinaryFile applies IsBinaryContent to the first 8000 bytes of path without
+// reading the rest, so large vendored assets aren't loaded into memory.
+func isBinaryFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	head := make([]byte, 8000)
+	n, _ := io.ReadFull(f, head) //nolint:errcheck // short files yield ErrUnexpectedEOF
+	return IsBinaryContent(head[:n])
+}
+
 // copyMapping copies a single path mapping
-func (s *FileCopyService) copyMapping(tempDir string, vendor *types.VendorSpec, spec types.BranchSpec, mapping types.PathMapping) (CopyStats, error) {
+func (s *FileCopyService) copyMapping(tempDir string, vendor *types.VendorSpec, spec types.BranchSpec, mapping types.PathMapping, ignore *VendorIgnore) (CopyStats, error) {
 	// Parse position specifiers from source and destination paths
 	srcRaw := s.cleanSourcePath(mapping.From, spec.Ref)
 	srcFile, srcPos, err := types.ParsePathPosition(srcRaw)
@@ -69,12 +102,22 @@ func (s *FileCopyService) copyMapping(tempDir string, vendor *types.VendorSpec,
 	if err := ValidateDestPath(destFile); err != nil {
 		return CopyStats{}, err
 	}
+	if err := ValidateDestWithinRoot(".", destFile); err != nil {
+		return CopyStats{}, err
+	}
 
-	// Position extraction mode: extract specific lines/columns from source
+	// Position extraction mode: extract specific lines/columns from source.
+	// Transforms don't apply: the snippet is placed as extracted
 	if srcPos != nil {
+		s.logger.Debugf("placing %s -> %s", mapping.From, destRaw)
 		return s.copyWithPosition(srcPath, destFile, srcPos, destPos, vendor.Name, spec.Ref, srcFile, mapping.From, mapping.To)
 	}
 
+	transform, err := mappingTransform(vendor, mapping)
+	if err != nil {
+		return CopyStats{}, fmt.Errorf("invalid mapping for %s: %w", vendor.Name, err)
+	}
+
 	// Standard copy (no position specifier) — existing behavior
 	info, err := s.fs.Stat(srcPath)
 	if err != nil {
@@ -88,17 +131,19 @@ func (s *FileCopyService) copyMapping(tempDir string, vendor *types.VendorSpec,
 		if err := s.fs.MkdirAll(destFile, 0755); err != nil {
 			return CopyStats{}, err
 		}
-		if len(mapping.Exclude) > 0 {
-			stats, err := s.copyDirWithExcludes(srcPath, destFile, mapping.Exclude)
-			if err != nil {
-				return CopyStats{}, fmt.Errorf("failed to copy directory %s to %s: %w", srcPath, destFile, err)
-			}
-			return stats, nil
+		s.logger.Debugf("copying directory %s -> %s", srcFile, destFile)
+		start := time.Now()
+		var stats CopyStats
+		if len(mapping.Include) > 0 || len(mapping.Exclude) > 0 || mapping.MaxDepth > 0 || ignore != nil || transform != nil {
+			stats, err = s.copyDirFiltered(srcPath, destFile, mapping.Include, mapping.Exclude, mapping.MaxDepth, ignore, transform)
+		} else {
+			stats, err = s.fs.CopyDir(srcPath, destFile)
 		}
-		stats, err := s.fs.CopyDir(srcPath, destFile)
 		if err != nil {
 			return CopyStats{}, fmt.Errorf("failed to copy directory %s to %s: %w", srcPath, destFile, err)
 		}
+		s.logger.Debugf("copied %s (%d bytes) into %s in %s",
+			Pluralize(stats.FileCount, "file", "files"), stats.ByteCount, destFile, time.Since(start).Round(time.Millisecond))
 		return stats, nil
 	}
 
@@ -110,20 +155,36 @@ func (s *FileCopyService) copyMapping(tempDir string, vendor *types.VendorSpec,
 	// Binary files are allowed (user chose to vendor them) but get a warning to surface
 	// the fact. Uses the same null-byte heuristic as position extraction (first 8000 bytes).
 	var warnings []string
-	if srcData, readErr := os.ReadFile(srcPath); readErr == nil && IsBinaryContent(srcData) {
+	if isBinaryFile(srcPath) {
 		warnings = append(warnings, fmt.Sprintf("%s appears to be a binary file", srcFile))
 	}
 
+	s.logger.Debugf("copying file %s -> %s", srcFile, destFile)
 	stats, err := s.fs.CopyFile(srcPath, destFile)
 	if err != nil {
 		return CopyStats{}, fmt.Errorf("failed to copy file %s to %s: %w", srcPath, destFile, err)
 	}
+	if err := transform.rewrite(destFile, &stats); err != nil {
+		return CopyStats{}, err
+	}
 	stats.Warnings = warnings
 	return stats, nil
 }
 
 // copyWithPosition handles position-based extraction and placement.
 func (s *FileCopyService) copyWithPosition(srcPath, destFile string, srcPos, destPos *types.PositionSpec, vendorName, ref, srcClean string, fromRaw, toRaw string) (CopyStats, error) {
+	// Anchored sources resolve to line numbers first so the lock records where
+	// the snippet was found
+	resolved, err := ResolvePosition(srcPath, srcPos)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return CopyStats{}, fmt.Errorf("extract position from %s: %w", srcClean, err)
+	}
+	sourceLines := ""
+	if err == nil && srcPos.IsAnchored() {
+		sourceLines = lineRange(resolved)
+		srcPos = resolved
+	}
+
 	// Extract content from source at the specified position
 	content, hash, err := ExtractPosition(srcPath, srcPos)
 	if err != nil {
@@ -155,9 +216,10 @@ func (s *FileCopyService) copyWithPosition(srcPath, destFile string, srcPos, des
 		FileCount: 1,
 		ByteCount: int64(len(content)),
 		Positions: []positionRecord{{
-			From:       fromRaw,
-			To:         toRaw,
-			SourceHash: hash,
+			From:        fromRaw,
+			To:          toRaw,
+			SourceHash:  hash,
+			SourceLines: sourceLines,
 		}},
 		Warnings: warnings,
 	}
@@ -212,18 +274,37 @@ func (s *FileCopyService) handleMissingSource(destFile, srcFile, vendorName, ref
 
 // cleanSourcePath removes blob/tree prefixes from source path
 func (s *FileCopyService) cleanSourcePath(path, ref string) string {
+	return cleanMappingSource(path, ref)
+}
+
+// cleanMappingSource removes blob/<ref>/ and tree/<ref>/ prefixes from a mapping source path
+func cleanMappingSource(path, ref string) string {
 	clean := strings.Replace(path, "blob/"+ref+"/", "", 1)
 	clean = strings.Replace(clean, "tree/"+ref+"/", "", 1)
 	return clean
 }
 
-// copyDirWithExcludes walks srcDir and copies files to dstDir, skipping any file
-// whose path relative to srcDir matches an exclude pattern. Also skips .git entries
-// (consistent with OSFileSystem.CopyDir). Returns aggregated CopyStats with Excluded count.
-func (s *FileCopyService) copyDirWithExcludes(srcDir, dstDir string, excludes []string) (CopyStats, error) {
+// copyDirFiltered walks srcDir and copies files to dstDir, skipping any file
+// whose path relative to srcDir matches an exclude pattern, is ignored by
+// ignore (which may be nil) or, when includes is non-empty, matches no include
+// pattern. Directories maxDepth levels down are not descended into (0 =
+// unlimited). Each copied file is rewritten by transform (nil = copied as-is).
+// Also skips .git entries, handles symlinks via copySymlink and gives each
+// copied directory its source permissions (consistent with
+// OSFileSystem.CopyDir). Returns aggregated CopyStats with Excluded count
+// covering all three filters.
+func (s *FileCopyService) copyDirFiltered(srcDir, dstDir string, includes, excludes []string, maxDepth int, ignore *VendorIgnore, transform *contentTransform) (CopyStats, error) {
+	// Interrupted copies resume like CopyDir's, from the same manifests
+	checkpoint, err := openCopyCheckpoint(s.checkpointDir(), dstDir)
+	if err != nil {
+		return CopyStats{}, fmt.Errorf("open copy checkpoint: %w", err)
+	}
+	defer checkpoint.close()
+
 	var stats CopyStats
+	var dirModes []dirMode
 
-	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -237,7 +318,7 @@ func (s *FileCopyService) copyDirWithExcludes(srcDir, dstDir string, excludes []
 		}
 
 		// Check exclude patterns against the relative path
-		if relPath != "." && MatchesExclude(relPath, excludes) {
+		if relPath != "." && (MatchesExclude(relPath, excludes) || ignore.Ignored(relPath, info.IsDir())) {
 			if info.IsDir() {
 				return filepath.SkipDir
 			}
@@ -245,21 +326,97 @@ func (s *FileCopyService) copyDirWithExcludes(srcDir, dstDir string, excludes []
 			return nil
 		}
 
+		if info.IsDir() && beyondMaxDepth(relPath, maxDepth) {
+			return filepath.SkipDir
+		}
+
+		// Includes only filter files: a directory may hold matching files at any depth
+		if !info.IsDir() && len(includes) > 0 && !MatchesExclude(relPath, includes) {
+			stats.Excluded++
+			return nil
+		}
+
 		destPath := filepath.Join(dstDir, relPath)
+		if err := validateCopyTarget(dstDir, relPath, info); err != nil {
+			return err
+		}
 
 		if info.IsDir() {
-			return os.MkdirAll(destPath, info.Mode())
+			dirModes = append(dirModes, dirMode{path: destPath, mode: info.Mode().Perm()})
+			// With includes, directories are created on demand so filtered-out
+			// subtrees don't leave empty directories behind
+			if len(includes) > 0 {
+				return nil
+			}
+			return os.MkdirAll(destPath, 0755)
+		}
+		if len(includes) > 0 {
+			if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+				return err
+			}
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			linkStats, err := copySymlink(srcDir, path, destPath)
+			if err != nil {
+				return err
+			}
+			stats.Add(linkStats)
+			return nil
+		}
+
+		// Already copied by an interrupted attempt and unchanged since
+		if hash, ok := checkpoint.completed(relPath, path, destPath); ok {
+			stats.Add(CopyStats{
+				FileCount:  1,
+				ByteCount:  info.Size(),
+				Resumed:    1,
+				FileHashes: map[string]string{filepath.ToSlash(destPath): hash},
+			})
+			return nil
 		}
 
+		s.logger.Debugf("copying file %s -> %s", relPath, destPath)
 		fileStats, err := s.fs.CopyFile(path, destPath)
 		if err != nil {
 			return err
 		}
+		hashKey := filepath.ToSlash(destPath)
+		sourceHash := fileStats.FileHashes[hashKey]
+		if err := transform.rewrite(destPath, &fileStats); err != nil {
+			return err
+		}
 		stats.Add(fileStats)
-		return nil
+		return checkpoint.recordRewritten(relPath, fileStats.FileHashes[hashKey], sourceHash)
 	})
+	if err != nil {
+		return stats, err
+	}
+	if err := checkpoint.finish(); err != nil {
+		return stats, err
+	}
 
-	return stats, err
+	// Apply directory modes deepest-first once the contents are written, as
+	// CopyDir does; directories skipped for includes were never created
+	for i := len(dirModes) - 1; i >= 0; i-- {
+		if _, err := os.Stat(dirModes[i].path); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := os.Chmod(dirModes[i].path, dirModes[i].mode); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+// checkpointDir returns where the filesystem keeps copy checkpoints; empty
+// (no checkpoints) unless it is an OSFileSystem set up by NewVendorSyncer.
+func (s *FileCopyService) checkpointDir() string {
+	if osFS, ok := s.fs.(*OSFileSystem); ok {
+		return osFS.checkpointDir
+	}
+	return ""
 }
 
 // computeDestPath computes the destination path for a mapping.
@@ -1194,3 +1194,108 @@ func TestCopyStats_Add_Removed(t *testing.T) {
 		t.Errorf("Removed = %v, want [file1.go file2.go file3.go]", a.Removed)
 	}
 }
+
+// ============================================================================
+// Multi-destination mapping tests ("to" as a list)
+// ============================================================================
+
+func TestCopyMappings_MultiDestination_EachTrackedInLock(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+	repoDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoDir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "src", "shared.go"), []byte("package shared\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rootDir := filepath.Join(workDir, VendorDir)
+	if err := os.MkdirAll(rootDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configYAML := `vendors:
+  - name: shared-lib
+    url: https://github.com/owner/shared-lib
+    specs:
+      - ref: main
+        mapping:
+          - from: src/shared.go
+            to: [lib/a.go, lib/b.go]
+`
+	if err := os.WriteFile(filepath.Join(rootDir, ConfigFile), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := NewFileConfigStore(rootDir).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	vendor := &config.Vendors[0]
+
+	osFS := NewOSFileSystem()
+	stats, err := NewFileCopyService(osFS).CopyMappings(repoDir, vendor, vendor.Specs[0])
+	if err != nil {
+		t.Fatalf("CopyMappings() error = %v", err)
+	}
+	if stats.FileCount != 2 {
+		t.Errorf("FileCount = %d, want 2", stats.FileCount)
+	}
+	for _, dest := range []string{"lib/a.go", "lib/b.go"} {
+		data, err := os.ReadFile(dest)
+		if err != nil {
+			t.Fatalf("expected %s to be written: %v", dest, err)
+		}
+		if string(data) != "package shared\n" {
+			t.Errorf("%s content = %q", dest, data)
+		}
+	}
+
+	svc := &UpdateService{cache: NewFileCacheStore(osFS, rootDir)}
+	hashes := svc.computeFileHashes(vendor, "main")
+	if len(hashes) != 2 || hashes["lib/a.go"] == "" || hashes["lib/b.go"] == "" {
+		t.Fatalf("lock FileHashes = %v, want independent entries for lib/a.go and lib/b.go", hashes)
+	}
+
+	// Drift in one destination does not affect the other's recorded hash
+	if err := os.WriteFile("lib/b.go", []byte("package modified\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	after := svc.computeFileHashes(vendor, "main")
+	if after["lib/a.go"] != hashes["lib/a.go"] {
+		t.Error("lib/a.go hash changed after modifying lib/b.go")
+	}
+	if after["lib/b.go"] == hashes["lib/b.go"] {
+		t.Error("lib/b.go hash should reflect its own modification")
+	}
+}
+
+// TestCopyMappings_PositionSpliceDropsStreamedHash verifies that a whole-file
+// hash recorded while copying is dropped once a later position mapping
+// splices into the same destination, so the lock re-reads the final content.
+func TestCopyMappings_PositionSpliceDropsStreamedHash(t *testing.T) {
+	chdirUnmanagedTest(t)
+	tempDir := t.TempDir()
+	writeFixTestFile(t, filepath.Join(tempDir, "a.go"), "line1\nline2\n")
+	writeFixTestFile(t, filepath.Join(tempDir, "b.go"), "package b\n")
+	writeFixTestFile(t, filepath.Join(tempDir, "c.go"), "snippet\n")
+
+	vendor := &types.VendorSpec{Name: "lib"}
+	spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{
+		{From: "a.go", To: "lib/a.go"},
+		{From: "b.go", To: "lib/b.go"},
+		{From: "c.go:L1", To: "lib/a.go:L2"},
+	}}
+	stats, err := NewFileCopyService(NewOSFileSystem()).CopyMappings(tempDir, vendor, spec)
+	if err != nil {
+		t.Fatalf("CopyMappings: %v", err)
+	}
+	if _, ok := stats.FileHashes["lib/a.go"]; ok {
+		t.Error("lib/a.go was spliced after copying; its streamed hash should be dropped")
+	}
+	want, err := fileSHA256("lib/b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stats.FileHashes["lib/b.go"]; got != want {
+		t.Errorf("FileHashes[lib/b.go] = %q, want %q", got, want)
+	}
+}
@@ -1,9 +1,13 @@
 package core
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -15,10 +19,15 @@ import (
 type CopyStats struct {
 	FileCount int
 	ByteCount int64
-	Excluded  int              // Files skipped due to exclude patterns
+	Excluded  int              // Files skipped due to include/exclude patterns
 	Positions []positionRecord // Position-extracted mappings (for lockfile tracking)
 	Warnings  []string         // Non-fatal warnings generated during copy
 	Removed   []string         // Destination paths removed because upstream source was deleted
+	Resumed   int              // Files CopyDir skipped because an interrupted copy's checkpoint showed them intact
+	CacheHits int              // Refs the incremental cache satisfied without a fetch or copy
+	// FileHashes maps each whole file written (forward-slash destination path)
+	// to the SHA-256 computed while copying it, so the lock needn't re-read it
+	FileHashes map[string]string
 }
 
 // positionRecord tracks a single position extraction during copy
@@ -26,6 +35,8 @@ type positionRecord struct {
 	From       string // Source path with position specifier
 	To         string // Destination path with optional position specifier
 	SourceHash string // SHA-256 hash of extracted content
+	// SourceLines is the resolved range of an anchored source position ("" otherwise)
+	SourceLines string
 }
 
 // Add adds another CopyStats to CopyStats, merging all fields.
@@ -36,8 +47,19 @@ func (s *CopyStats) Add(other CopyStats) {
 	s.Positions = append(s.Positions, other.Positions...)
 	s.Warnings = append(s.Warnings, other.Warnings...)
 	s.Removed = append(s.Removed, other.Removed...)
+	s.Resumed += other.Resumed
+	s.CacheHits += other.CacheHits
+	for path, hash := range other.FileHashes {
+		if s.FileHashes == nil {
+			s.FileHashes = make(map[string]string, len(other.FileHashes))
+		}
+		s.FileHashes[path] = hash
+	}
 }
 
+// copyBufferSize bounds the memory CopyFile uses per file, whatever its size.
+const copyBufferSize = 256 * 1024
+
 // FileSystem abstracts file system operations for testing.
 // Implementations that support write validation (e.g., rooted filesystems) SHOULD
 // enforce path containment in ValidateWritePath, CopyFile, and CopyDir.
@@ -61,7 +83,8 @@ type FileSystem interface {
 // that destinations resolve within the root — preventing path traversal even if
 // callers forget to call ValidateDestPath.
 type OSFileSystem struct {
-	projectRoot string // Absolute path; empty = unrooted (no write validation)
+	projectRoot   string // Absolute path; empty = unrooted (no write validation)
+	checkpointDir string // Where CopyDir keeps resume manifests; empty = no checkpoints
 }
 
 // NewOSFileSystem creates an unrooted OSFileSystem with no write validation.
@@ -99,7 +122,10 @@ func (fs *OSFileSystem) ValidateWritePath(path string) error {
 	return nil
 }
 
-// CopyFile copies a single file from src to dst.
+// CopyFile copies a single file from src to dst, applying src's permission bits
+// to dst (so vendored scripts keep their executable bit). The content is
+// streamed through a copyBufferSize buffer and its SHA-256 returned in
+// CopyStats.FileHashes, so large files are never held in memory.
 //
 // Security: When the filesystem is rooted (created via NewRootedFileSystem), CopyFile
 // self-validates that dst resolves within projectRoot. For unrooted filesystems,
@@ -116,21 +142,54 @@ func (fs *OSFileSystem) CopyFile(src, dst string) (CopyStats, error) {
 	}
 	defer func() { _ = source.Close() }()
 
+	info, err := source.Stat()
+	if err != nil {
+		return CopyStats{}, err
+	}
+
+	// Replace rather than truncate dst, so content written to a file
+	// hard-linked by --hardlink never reaches its other names
+	if info, err := os.Lstat(dst); err == nil && info.Mode().IsRegular() {
+		if err := os.Remove(dst); err != nil {
+			return CopyStats{}, err
+		}
+	}
+
 	dest, err := os.Create(dst)
 	if err != nil {
 		return CopyStats{}, err
 	}
 	defer func() { _ = dest.Close() }()
 
-	bytes, err := io.Copy(dest, source)
+	// Stream through a fixed buffer, hashing on the way for the lock's FileHashes
+	hasher := sha256.New()
+	bytes, err := io.CopyBuffer(io.MultiWriter(dest, hasher), source, make([]byte, copyBufferSize))
 	if err != nil {
 		return CopyStats{}, err
 	}
 
-	return CopyStats{FileCount: 1, ByteCount: bytes}, nil
+	// Chmod (not the create mode) so the umask and any pre-existing dst mode don't apply
+	if err := dest.Chmod(info.Mode().Perm()); err != nil {
+		return CopyStats{}, err
+	}
+
+	return CopyStats{
+		FileCount:  1,
+		ByteCount:  bytes,
+		FileHashes: map[string]string{filepath.ToSlash(dst): hex.EncodeToString(hasher.Sum(nil))},
+	}, nil
 }
 
-// CopyDir recursively copies a directory from src to dst.
+// CopyDir recursively copies a directory from src to dst, preserving the
+// permission bits of every copied file and directory. Symlinks are never
+// followed; see copySymlink.
+//
+// While copying, CopyDir records each finished file in a checkpoint manifest
+// for dst under checkpointDir (set by NewVendorSyncer to
+// .git-vendor/.cache/checkpoints/). If the copy fails, the manifest stays
+// behind and the next CopyDir into dst skips files whose hash still matches it
+// (counted in CopyStats.Resumed), copying only the rest. The manifest is
+// removed on success.
 //
 // Security: When the filesystem is rooted (created via NewRootedFileSystem), CopyDir
 // self-validates that dst resolves within projectRoot. For unrooted filesystems,
@@ -141,9 +200,19 @@ func (fs *OSFileSystem) CopyDir(src, dst string) (CopyStats, error) {
 		return CopyStats{}, err
 	}
 
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return CopyStats{}, err
+	}
+	checkpoint, err := openCopyCheckpoint(fs.checkpointDir, dst)
+	if err != nil {
+		return CopyStats{}, fmt.Errorf("open copy checkpoint: %w", err)
+	}
+	defer checkpoint.close()
+
 	var stats CopyStats
+	var dirModes []dirMode
 
-	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -157,9 +226,33 @@ func (fs *OSFileSystem) CopyDir(src, dst string) (CopyStats, error) {
 		}
 
 		destPath := filepath.Join(dst, relPath)
+		if err := validateCopyTarget(dst, relPath, info); err != nil {
+			return err
+		}
 
 		if info.IsDir() {
-			return os.MkdirAll(destPath, info.Mode())
+			dirModes = append(dirModes, dirMode{path: destPath, mode: info.Mode().Perm()})
+			return os.MkdirAll(destPath, 0755)
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			linkStats, err := copySymlink(src, path, destPath)
+			if err != nil {
+				return err
+			}
+			stats.Add(linkStats)
+			return nil
+		}
+
+		// Already copied by an interrupted attempt and unchanged since
+		if hash, ok := checkpoint.completed(relPath, path, destPath); ok {
+			stats.Add(CopyStats{
+				FileCount:  1,
+				ByteCount:  info.Size(),
+				Resumed:    1,
+				FileHashes: map[string]string{filepath.ToSlash(destPath): hash},
+			})
+			return nil
 		}
 
 		// Copy file and add to stats
@@ -169,10 +262,112 @@ func (fs *OSFileSystem) CopyDir(src, dst string) (CopyStats, error) {
 		}
 		stats.Add(fileStats)
 
-		return nil
+		return checkpoint.record(relPath, fileStats.FileHashes[filepath.ToSlash(destPath)])
 	})
+	if err != nil {
+		return stats, err
+	}
+	if err := checkpoint.finish(); err != nil {
+		return stats, err
+	}
+
+	// Apply directory modes deepest-first, after the contents are written,
+	// so a read-only source directory doesn't block copying its children
+	for i := len(dirModes) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirModes[i].path, dirModes[i].mode); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+// validateCopyTarget checks, before a directory copy writes relPath under
+// dst, that the target resolves (symlinks in its existing part followed)
+// inside dst, as ValidateDestWithinRoot does for the project root. Checking
+// only dst itself would miss a destination subdirectory or file that was
+// replaced by a symlink to somewhere else. A source symlink replaces whatever
+// is at its target, so only its parent is checked.
+func validateCopyTarget(dst, relPath string, info os.FileInfo) error {
+	if info.Mode()&os.ModeSymlink != 0 {
+		relPath = filepath.Dir(relPath)
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return fmt.Errorf("resolve destination %s: %w", dst, err)
+	}
+	root, err := resolveExisting(absDst)
+	if err != nil {
+		return fmt.Errorf("resolve destination %s: %w", dst, err)
+	}
+	target, err := resolveExisting(filepath.Join(absDst, relPath))
+	if err != nil {
+		return fmt.Errorf("resolve destination %s: %w", filepath.Join(dst, relPath), err)
+	}
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return fmt.Errorf("invalid destination path: %s (resolves to %s, outside %s) (%w)", filepath.Join(dst, relPath), target, dst, ErrPathTraversal)
+	}
+	return nil
+}
+
+// copySymlink reproduces the symlink at path, found while copying the tree
+// rooted at srcRoot, as destPath. A link whose target stays inside srcRoot is
+// recreated as a relative link; a link that escapes srcRoot (or any link when
+// NoSymlinks is set) is skipped with a warning, so a copy never pulls in
+// content from outside the vendored tree.
+func copySymlink(srcRoot, path, destPath string) (CopyStats, error) {
+	relPath, err := filepath.Rel(srcRoot, path)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	relPath = filepath.ToSlash(relPath)
+	if NoSymlinks {
+		return CopyStats{Warnings: []string{fmt.Sprintf("skipped symlink %s (--no-symlinks)", relPath)}}, nil
+	}
+
+	target, err := os.Readlink(path)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	resolved := target
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(filepath.Dir(path), target)
+	}
+	root, err := filepath.Abs(srcRoot)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	resolved, err = filepath.Abs(resolved)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	if resolved != root && !strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+		return CopyStats{Warnings: []string{fmt.Sprintf("skipped symlink %s: target %q is outside the copied directory", relPath, target)}}, nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	linkTarget, err := filepath.Rel(filepath.Dir(absPath), resolved)
+	if err != nil {
+		return CopyStats{}, err
+	}
+	// Replace whatever a previous sync left at destPath (file or stale link)
+	if err := os.Remove(destPath); err != nil && !os.IsNotExist(err) {
+		return CopyStats{}, err
+	}
+	if err := os.Symlink(linkTarget, destPath); err != nil {
+		return CopyStats{}, err
+	}
+	return CopyStats{FileCount: 1}, nil
+}
 
-	return stats, err
+// dirMode records a destination directory and the permission bits to apply to it.
+type dirMode struct {
+	path string
+	mode os.FileMode
 }
 
 // MkdirAll creates a directory path
@@ -263,9 +458,74 @@ func ValidateDestPath(destPath string) error {
 
 	// Check if path contains .. (path traversal attack)
 	if strings.HasPrefix(cleaned, "..") || strings.Contains(cleaned, string(filepath.Separator)+"..") {
-		return fmt.Errorf("invalid destination path: %s (path traversal with .. is not allowed)", destPath)
+		return fmt.Errorf("invalid destination path: %s (%w)", destPath, ErrPathTraversal)
+	}
+
+	return checkReservedDest(VendorDir, destPath)
+}
+
+// ValidateDestWithinRoot is the last check before a mapping writes destPath
+// (relative to projectRoot): the joined path, with symlinks in its existing
+// part resolved, must stay inside projectRoot. It catches what the string
+// checks in ValidateDestPath cannot see, such as an intermediate directory
+// that is a symlink to somewhere outside the project.
+func ValidateDestWithinRoot(projectRoot, destPath string) error {
+	root, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return fmt.Errorf("resolve project root %q: %w", projectRoot, err)
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	dest, err := resolveExisting(filepath.Join(root, destPath))
+	if err != nil {
+		return fmt.Errorf("resolve destination %s: %w", destPath, err)
+	}
+	rel, err := filepath.Rel(root, dest)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return fmt.Errorf("invalid destination path: %s (resolves to %s, outside the project root) (%w)", destPath, dest, ErrPathTraversal)
+	}
+	return nil
+}
+
+// resolveExisting returns the absolute path p with symlinks resolved in its
+// deepest existing ancestor (p itself when it exists); the part that doesn't
+// exist yet is appended unchanged.
+func resolveExisting(p string) (string, error) {
+	var missing []string
+	for {
+		resolved, err := filepath.EvalSymlinks(p)
+		if err == nil {
+			for i := len(missing) - 1; i >= 0; i-- {
+				resolved = filepath.Join(resolved, missing[i])
+			}
+			return resolved, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return "", err
+		}
+		missing = append(missing, filepath.Base(p))
+		p = parent
 	}
+}
 
+// checkReservedDest rejects destinations that would overwrite git-vendor's own
+// config or lockfile in rootDir, or the license policy. Comparison is
+// case-insensitive so the guard also holds on case-insensitive filesystems
+// (macOS, Windows).
+func checkReservedDest(rootDir, destPath string) error {
+	slashed := filepath.ToSlash(filepath.Clean(destPath))
+	root := filepath.ToSlash(filepath.Clean(rootDir))
+	for _, reserved := range []string{path.Join(root, ConfigFile), path.Join(root, ConfigFileTOML), path.Join(root, LockFile), PolicyFile} {
+		if strings.EqualFold(slashed, reserved) {
+			return fmt.Errorf("invalid destination path: %s (%s is managed by git-vendor)", destPath, reserved)
+		}
+	}
 	return nil
 }
 
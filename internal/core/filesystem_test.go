@@ -1,11 +1,17 @@
 package core
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
 )
 
 // ============================================================================
@@ -177,6 +183,15 @@ func TestValidateDestPath_EdgeCases(t *testing.T) {
 		{"double dot only", "..", true},
 		{"slash only", "/", true},
 		{"backslash only", "\\", true},
+
+		// Invalid: git-vendor's own state files
+		{"config file", ".git-vendor/vendor.yml", true},
+		{"lock file", ".git-vendor/vendor.lock", true},
+		{"lock file uncleaned", "./.git-vendor//vendor.lock", true},
+		{"lock file case-folded", ".Git-Vendor/Vendor.lock", true},
+		{"policy file", ".git-vendor-policy.yml", true},
+		{"config sibling", ".git-vendor/vendor.yml.bak", false},
+		{"similar name outside state dir", "docs/vendor.yml", false},
 	}
 
 	for _, tt := range tests {
@@ -541,6 +556,140 @@ func TestCopyFile_FollowsSymlinks(t *testing.T) {
 	}
 }
 
+// TestCopyFile_PreservesPermissions verifies that CopyFile applies the source's
+// permission bits, so vendored scripts stay executable.
+func TestCopyFile_PreservesPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits are not supported on Windows")
+	}
+	fs := NewOSFileSystem()
+	tempDir := t.TempDir()
+
+	src := filepath.Join(tempDir, "build.sh")
+	if err := os.WriteFile(src, []byte("#!/bin/sh\necho ok\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// WriteFile is subject to the umask; force the exact source mode
+	if err := os.Chmod(src, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pre-existing 0644 destination must be upgraded, not kept
+	dest := filepath.Join(tempDir, "out", "build.sh")
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dest, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fs.CopyFile(src, dest); err != nil {
+		t.Fatalf("CopyFile failed: %v", err)
+	}
+	info, err := os.Stat(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0755 {
+		t.Errorf("dest mode = %o, want 755", got)
+	}
+}
+
+// TestCopyFile_StreamsLargeFileAndHashes verifies a multi-megabyte copy is
+// byte-identical and that the hash returned in FileHashes is its SHA-256.
+func TestCopyFile_StreamsLargeFileAndHashes(t *testing.T) {
+	fs := NewOSFileSystem()
+	tempDir := t.TempDir()
+
+	// 6 MiB of non-repeating content spans many copyBufferSize chunks
+	data := make([]byte, 6<<20)
+	for i := range data {
+		data[i] = byte(i*31 + i>>11)
+	}
+	src := filepath.Join(tempDir, "asset.bin")
+	if err := os.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(tempDir, "out", "asset.bin")
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := fs.CopyFile(src, dest)
+	if err != nil {
+		t.Fatalf("CopyFile failed: %v", err)
+	}
+	if stats.ByteCount != int64(len(data)) {
+		t.Errorf("ByteCount = %d, want %d", stats.ByteCount, len(data))
+	}
+
+	copied, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(copied, data) {
+		t.Fatal("copied content differs from source")
+	}
+	sum := sha256.Sum256(data)
+	want := hex.EncodeToString(sum[:])
+	if got := stats.FileHashes[filepath.ToSlash(dest)]; got != want {
+		t.Errorf("FileHashes[%s] = %q, want %q", dest, got, want)
+	}
+}
+
+// TestCopyDir_PreservesPermissions verifies per-file and directory modes survive CopyDir.
+func TestCopyDir_PreservesPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits are not supported on Windows")
+	}
+	fs := NewOSFileSystem()
+	tempDir := t.TempDir()
+
+	srcDir := filepath.Join(tempDir, "src")
+	if err := os.MkdirAll(filepath.Join(srcDir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]os.FileMode{
+		"README.md":          0644,
+		"scripts/release.sh": 0755,
+		"scripts/private":    0600,
+	}
+	for rel, mode := range files {
+		path := filepath.Join(srcDir, rel)
+		if err := os.WriteFile(path, []byte(rel), mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(filepath.Join(srcDir, "scripts"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := filepath.Join(tempDir, "dest")
+	if _, err := fs.CopyDir(srcDir, destDir); err != nil {
+		t.Fatalf("CopyDir failed: %v", err)
+	}
+
+	for rel, want := range files {
+		info, err := os.Stat(filepath.Join(destDir, rel))
+		if err != nil {
+			t.Fatalf("%s not copied: %v", rel, err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s mode = %o, want %o", rel, got, want)
+		}
+	}
+	info, err := os.Stat(filepath.Join(destDir, "scripts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0750 {
+		t.Errorf("scripts/ mode = %o, want 750", got)
+	}
+}
+
 // TestCopyDir_SkipsGitDirectories verifies that CopyDir skips .git directories,
 // which prevents leaking git metadata during vendor copy operations.
 func TestCopyDir_SkipsGitDirectories(t *testing.T) {
@@ -983,3 +1132,166 @@ func TestRootedFileSystem_RelativePaths(t *testing.T) {
 	}
 }
 
+
+// setupSymlinkTree creates src/ with lib/real.go, an in-tree link lib/alias.go → real.go,
+// and an escaping link leak → ../secret.txt. Returns tempDir and srcDir.
+func setupSymlinkTree(t *testing.T) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires elevated privileges on Windows")
+	}
+	tempDir := t.TempDir()
+	srcDir := filepath.Join(tempDir, "src")
+	if err := os.MkdirAll(filepath.Join(srcDir, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "lib", "real.go"), []byte("package lib"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "secret.txt"), []byte("outside"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("real.go", filepath.Join(srcDir, "lib", "alias.go")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../secret.txt", filepath.Join(srcDir, "leak")); err != nil {
+		t.Fatal(err)
+	}
+	return tempDir, srcDir
+}
+
+func TestCopyDir_InTreeSymlinkRecreated(t *testing.T) {
+	tempDir, srcDir := setupSymlinkTree(t)
+	destDir := filepath.Join(tempDir, "dest")
+
+	if _, err := NewOSFileSystem().CopyDir(srcDir, destDir); err != nil {
+		t.Fatalf("CopyDir failed: %v", err)
+	}
+
+	link := filepath.Join(destDir, "lib", "alias.go")
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatalf("alias.go not copied: %v", err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("alias.go mode = %v, want a symlink", info.Mode())
+	}
+	target, err := os.Readlink(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target != "real.go" {
+		t.Errorf("alias.go target = %q, want relative real.go", target)
+	}
+	content, err := os.ReadFile(link)
+	if err != nil || string(content) != "package lib" {
+		t.Errorf("alias.go resolves to %q (err %v), want the copied real.go", content, err)
+	}
+}
+
+func TestCopyDir_EscapingSymlinkSkippedWithWarning(t *testing.T) {
+	tempDir, srcDir := setupSymlinkTree(t)
+	destDir := filepath.Join(tempDir, "dest")
+
+	stats, err := NewOSFileSystem().CopyDir(srcDir, destDir)
+	if err != nil {
+		t.Fatalf("CopyDir failed: %v", err)
+	}
+
+	if _, err := os.Lstat(filepath.Join(destDir, "leak")); !os.IsNotExist(err) {
+		t.Errorf("escaping symlink should not be copied, Lstat err = %v", err)
+	}
+	if len(stats.Warnings) != 1 || !strings.Contains(stats.Warnings[0], "leak") || !strings.Contains(stats.Warnings[0], "outside") {
+		t.Errorf("Warnings = %v, want one warning naming leak as outside the tree", stats.Warnings)
+	}
+}
+
+func TestCopyDir_NoSymlinksSkipsAll(t *testing.T) {
+	tempDir, srcDir := setupSymlinkTree(t)
+	destDir := filepath.Join(tempDir, "dest")
+
+	NoSymlinks = true
+	defer func() { NoSymlinks = false }()
+
+	stats, err := NewOSFileSystem().CopyDir(srcDir, destDir)
+	if err != nil {
+		t.Fatalf("CopyDir failed: %v", err)
+	}
+
+	if _, err := os.Lstat(filepath.Join(destDir, "lib", "alias.go")); !os.IsNotExist(err) {
+		t.Errorf("in-tree symlink should be skipped with --no-symlinks, Lstat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "lib", "real.go")); err != nil {
+		t.Errorf("regular file should still be copied: %v", err)
+	}
+	if len(stats.Warnings) != 2 {
+		t.Errorf("Warnings = %v, want one per skipped symlink", stats.Warnings)
+	}
+}
+
+// TestValidateDestWithinRoot_SymlinkedDirEscapes covers a destination that
+// passes ValidateDestPath but reaches outside the project through a symlinked
+// intermediate directory.
+func TestValidateDestWithinRoot_SymlinkedDirEscapes(t *testing.T) {
+	chdirUnmanagedTest(t)
+	outside := t.TempDir()
+	if err := os.Symlink(outside, "lib"); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+	dest := filepath.Join("lib", "pkg", "escape.go")
+
+	if err := ValidateDestPath(dest); err != nil {
+		t.Fatalf("ValidateDestPath(%s) = %v; the string check is expected to pass", dest, err)
+	}
+	if err := ValidateDestWithinRoot(".", dest); !errors.Is(err, ErrPathTraversal) {
+		t.Errorf("ValidateDestWithinRoot(%s) = %v, want ErrPathTraversal", dest, err)
+	}
+	if err := ValidateDestWithinRoot(".", filepath.Join("vendor", "inside.go")); err != nil {
+		t.Errorf("ValidateDestWithinRoot(vendor/inside.go) = %v, want nil", err)
+	}
+
+	// The syncer refuses the mapping before anything is written
+	clone := t.TempDir()
+	writeFixTestFile(t, filepath.Join(clone, "escape.go"), "package escape\n")
+	spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{{From: "escape.go", To: "lib/pkg/escape.go"}}}
+	vendor := &types.VendorSpec{Name: "escape", Specs: []types.BranchSpec{spec}}
+	if _, err := NewFileCopyService(NewOSFileSystem()).CopyMappings(clone, vendor, spec); !errors.Is(err, ErrPathTraversal) {
+		t.Errorf("CopyMappings = %v, want ErrPathTraversal", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "pkg", "escape.go")); !os.IsNotExist(err) {
+		t.Errorf("file written outside the project root (stat err = %v)", err)
+	}
+}
+
+// TestCopyDir_SymlinkedSubdirEscapes covers a directory mapping whose
+// destination passes the top-level check but has a subdirectory replaced by
+// a symlink out of the project; both the plain and the filtered copy refuse
+// to write through it.
+func TestCopyDir_SymlinkedSubdirEscapes(t *testing.T) {
+	chdirUnmanagedTest(t)
+	outside := t.TempDir()
+	clone := t.TempDir()
+	writeFixTestFile(t, filepath.Join(clone, "src", "pkg", "escape.go"), "package pkg\n")
+	if err := os.MkdirAll("lib", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join("lib", "pkg")); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+
+	for name, mapping := range map[string]types.PathMapping{
+		"plain":    {From: "src", To: "lib"},
+		"filtered": {From: "src", To: "lib", Include: []string{"*.go"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{mapping}}
+			vendor := &types.VendorSpec{Name: "escape", Specs: []types.BranchSpec{spec}}
+			if _, err := NewFileCopyService(NewOSFileSystem()).CopyMappings(clone, vendor, spec); !errors.Is(err, ErrPathTraversal) {
+				t.Errorf("CopyMappings = %v, want ErrPathTraversal", err)
+			}
+			if _, err := os.Stat(filepath.Join(outside, "escape.go")); !os.IsNotExist(err) {
+				t.Errorf("file written outside the destination (stat err = %v)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	git "github.com/EmundoT/git-plumbing"
+)
+
+// GitTokenEnv holds an access token for the HTTPS git hosts named by
+// GitTokenHostsEnv (github.com by default); other hosts never see it.
+// GitTokenEnv + "_" + host (e.g. GIT_VENDOR_TOKEN_GITLAB_EXAMPLE_COM) scopes a
+// token to one host and takes precedence.
+const GitTokenEnv = "GIT_VENDOR_TOKEN"
+
+// GitTokenHostsEnv lists, comma-separated, the hosts GIT_VENDOR_TOKEN is sent
+// to. Unset means defaultGitTokenHost.
+const GitTokenHostsEnv = "GIT_VENDOR_TOKEN_HOSTS"
+
+// defaultGitTokenHost is the only host GIT_VENDOR_TOKEN goes to when
+// GIT_VENDOR_TOKEN_HOSTS is unset.
+const defaultGitTokenHost = "github.com"
+
+// GitTokenEnvForHost returns the host-scoped token variable for host:
+// uppercased, with every character other than A-Z and 0-9 replaced by "_".
+// It returns "" for a host whose variable would be GIT_VENDOR_TOKEN_HOSTS
+// (a host named "hosts"), so the host list is never read as a token.
+func GitTokenEnvForHost(host string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(host) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := GitTokenEnv + "_" + b.String()
+	if name == GitTokenHostsEnv {
+		return ""
+	}
+	return name
+}
+
+// ResolveGitToken returns the access token configured for host, preferring
+// the host-scoped variable over GIT_VENDOR_TOKEN, which only applies to the
+// hosts in GIT_VENDOR_TOKEN_HOSTS. Empty means no token.
+func ResolveGitToken(host string) string {
+	if host == "" {
+		return ""
+	}
+	if name := GitTokenEnvForHost(host); name != "" {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	hosts := os.Getenv(GitTokenHostsEnv)
+	if hosts == "" {
+		hosts = defaultGitTokenHost
+	}
+	for _, h := range strings.Split(hosts, ",") {
+		if strings.EqualFold(strings.TrimSpace(h), host) {
+			return os.Getenv(GitTokenEnv)
+		}
+	}
+	return ""
+}
+
+// gitAuthEnv returns the environment that makes git send the token for
+// rawURL as an http.<scheme>://<host>/.extraHeader, or nil when rawURL is not
+// HTTPS or no token is configured. Passing the header through GIT_CONFIG_*
+// variables keeps the token out of remote URLs, .git/config, command
+// arguments (and so verbose logs), error messages, vendor.yml and vendor.lock.
+// The entry is appended after any GIT_CONFIG_KEY_n/VALUE_n the environment
+// already sets, so those keep applying.
+func gitAuthEnv(rawURL string) []string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil
+	}
+	token := ResolveGitToken(parsed.Hostname())
+	if token == "" {
+		return nil
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+	n, err := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	if err != nil || n < 0 {
+		n = 0
+	}
+	idx := strconv.Itoa(n)
+	return []string{
+		"GIT_CONFIG_COUNT=" + strconv.Itoa(n+1),
+		"GIT_CONFIG_KEY_" + idx + "=http.https://" + parsed.Host + "/.extraHeader",
+		"GIT_CONFIG_VALUE_" + idx + "=Authorization: Basic " + credentials,
+	}
+}
+
+// gitForURL is gitFor with auth for rawURL applied (gitAuthEnv).
+func (g *SystemGitClient) gitForURL(dir, rawURL string) *git.Git {
+	gc := g.gitFor(dir)
+	gc.Env = gitAuthEnv(rawURL)
+	return gc
+}
+
+// gitForRemote is gitFor with auth applied for the URL of the named remote.
+// remote may also be a URL. The remote URL is only looked up when a token
+// variable is set.
+func (g *SystemGitClient) gitForRemote(ctx context.Context, dir, remote string) *git.Git {
+	if !gitTokenConfigured() {
+		return g.gitFor(dir)
+	}
+	if strings.Contains(remote, "://") {
+		return g.gitForURL(dir, remote)
+	}
+	remoteURL, err := g.gitFor(dir).ConfigGet(ctx, "remote."+remote+".url")
+	if err != nil {
+		return g.gitFor(dir)
+	}
+	return g.gitForURL(dir, remoteURL)
+}
+
+// gitTokenConfigured reports whether GIT_VENDOR_TOKEN or any host-scoped
+// GIT_VENDOR_TOKEN_<HOST> variable is set.
+func gitTokenConfigured() bool {
+	for _, e := range os.Environ() {
+		if strings.HasPrefix(e, GitTokenEnv+"=") && len(e) > len(GitTokenEnv)+1 {
+			return true
+		}
+		if strings.HasPrefix(e, GitTokenEnv+"_") && !strings.HasSuffix(e, "=") && !strings.HasPrefix(e, GitTokenHostsEnv+"=") {
+			return true
+		}
+	}
+	return false
+}
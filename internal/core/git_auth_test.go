@@ -0,0 +1,169 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGitAuthEnv_HostTokenTakesPrecedence(t *testing.T) {
+	t.Setenv(GitTokenEnv, "global-token")
+	t.Setenv("GIT_VENDOR_TOKEN_GITLAB_EXAMPLE_COM", "gitlab-token")
+
+	tests := []struct {
+		url       string
+		wantToken string // "" = no auth env
+	}{
+		{"https://gitlab.example.com/group/repo.git", "gitlab-token"},
+		{"https://github.com/owner/repo", "global-token"},
+		{"http://github.com/owner/repo", ""},
+		{"git@github.com:owner/repo.git", ""},
+		{"ssh://git@github.com/owner/repo.git", ""},
+		{"file:///tmp/repo", ""},
+	}
+	for _, tt := range tests {
+		env := gitAuthEnv(tt.url)
+		if tt.wantToken == "" {
+			if env != nil {
+				t.Errorf("gitAuthEnv(%q) = %v, want none", tt.url, env)
+			}
+			continue
+		}
+		want := "GIT_CONFIG_VALUE_0=Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("x-access-token:"+tt.wantToken))
+		if len(env) != 3 || env[2] != want {
+			t.Errorf("gitAuthEnv(%q) = %v, want header for %s", tt.url, env, tt.wantToken)
+		}
+	}
+
+	if got := GitTokenEnvForHost("git.example.com:8443"); got != "GIT_VENDOR_TOKEN_GIT_EXAMPLE_COM_8443" {
+		t.Errorf("GitTokenEnvForHost = %q", got)
+	}
+}
+
+func TestResolveGitToken_HostNamedHostsIgnoresHostList(t *testing.T) {
+	t.Setenv(GitTokenHostsEnv, "hosts")
+	t.Setenv(GitTokenEnv, "global-token")
+
+	if got := GitTokenEnvForHost("Hosts"); got != "" {
+		t.Errorf("GitTokenEnvForHost(Hosts) = %q, want none (reserved for %s)", got, GitTokenHostsEnv)
+	}
+	if got := ResolveGitToken("hosts"); got != "global-token" {
+		t.Errorf("ResolveGitToken(hosts) = %q, want the global token, not the host list", got)
+	}
+}
+
+func TestResolveGitToken_GlobalTokenScopedToListedHosts(t *testing.T) {
+	t.Setenv(GitTokenEnv, "global-token")
+
+	if got := ResolveGitToken("github.com"); got != "global-token" {
+		t.Errorf("ResolveGitToken(github.com) = %q, want the global token by default", got)
+	}
+	for _, host := range []string{"gitlab.com", "github.evil.example", "api.github.com"} {
+		if got := ResolveGitToken(host); got != "" {
+			t.Errorf("ResolveGitToken(%s) = %q, want none outside %s", host, got, GitTokenHostsEnv)
+		}
+		if env := gitAuthEnv("https://" + host + "/owner/repo"); env != nil {
+			t.Errorf("gitAuthEnv(%s) = %v, want none", host, env)
+		}
+	}
+
+	t.Setenv(GitTokenHostsEnv, "gitlab.example.com, GitHub.com")
+	if ResolveGitToken("gitlab.example.com") != "global-token" || ResolveGitToken("github.com") != "global-token" {
+		t.Errorf("%s should send the global token to each listed host", GitTokenHostsEnv)
+	}
+	if got := ResolveGitToken("gitlab.com"); got != "" {
+		t.Errorf("ResolveGitToken(gitlab.com) = %q, want none", got)
+	}
+}
+
+func TestGitAuthEnv_AppendsAfterExistingGitConfigEntries(t *testing.T) {
+	t.Setenv(GitTokenEnv, "global-token")
+	t.Setenv("GIT_CONFIG_COUNT", "2")
+	t.Setenv("GIT_CONFIG_KEY_0", "core.autocrlf")
+	t.Setenv("GIT_CONFIG_VALUE_0", "false")
+	t.Setenv("GIT_CONFIG_KEY_1", "http.proxy")
+	t.Setenv("GIT_CONFIG_VALUE_1", "http://proxy.internal:3128")
+
+	env := gitAuthEnv("https://github.com/owner/repo")
+	if len(env) != 3 || env[0] != "GIT_CONFIG_COUNT=3" ||
+		env[1] != "GIT_CONFIG_KEY_2=http.https://github.com/.extraHeader" ||
+		!strings.HasPrefix(env[2], "GIT_CONFIG_VALUE_2=Authorization: Basic ") {
+		t.Errorf("gitAuthEnv() = %v, want entry 2 appended with count 3", env)
+	}
+}
+
+func TestSystemGitClient_TokenSentToRemoteAndScrubbedFromOutput(t *testing.T) {
+	const token = "s3cret-token-value"
+
+	var mu sync.Mutex
+	var authHeaders []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		mu.Unlock()
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	t.Setenv("GIT_SSL_NO_VERIFY", "true")
+	t.Setenv(GitTokenEnv, token)
+	t.Setenv(GitTokenHostsEnv, "127.0.0.1")
+	remote := server.URL + "/owner/private.git"
+
+	// Capture verbose command logging
+	var logged bytes.Buffer
+	client := NewSystemGitClient()
+	client.SetLogger(NewWriterLogger(&logged, LogDebug))
+	ctx := context.Background()
+	dir := t.TempDir()
+	_, lsErr := client.LsRemote(ctx, remote, "main")
+	initErr := client.Init(ctx, dir)
+	addErr := client.AddRemote(ctx, dir, "origin", remote)
+	fetchErr := client.Fetch(ctx, dir, "origin", 1, "main")
+
+	if initErr != nil || addErr != nil {
+		t.Fatalf("setup failed: init=%v add=%v", initErr, addErr)
+	}
+	if lsErr == nil || fetchErr == nil {
+		t.Fatalf("expected ls-remote and fetch to fail against the 404 server, got %v / %v", lsErr, fetchErr)
+	}
+
+	// The token reaches the remote as a Basic auth header on every request
+	want := "Basic " + base64.StdEncoding.EncodeToString([]byte("x-access-token:"+token))
+	mu.Lock()
+	defer mu.Unlock()
+	if len(authHeaders) < 2 {
+		t.Fatalf("expected requests from ls-remote and fetch, got %d", len(authHeaders))
+	}
+	for i, h := range authHeaders {
+		if h != want {
+			t.Errorf("request %d Authorization = %q, want token header", i, h)
+		}
+	}
+
+	// ...and nowhere else: not in logs, errors, or the repository's config
+	gitConfig, err := os.ReadFile(filepath.Join(dir, ".git", "config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, text := range map[string]string{
+		"verbose log":     logged.String(),
+		"ls-remote error": lsErr.Error(),
+		"fetch error":     fetchErr.Error(),
+		".git/config":     string(gitConfig),
+	} {
+		if strings.Contains(text, token) || strings.Contains(text, base64.StdEncoding.EncodeToString([]byte("x-access-token:"+token))) {
+			t.Errorf("token leaked into %s:\n%s", name, text)
+		}
+	}
+	if !strings.Contains(string(gitConfig), remote) {
+		t.Errorf("remote URL should be stored without credentials:\n%s", gitConfig)
+	}
+}
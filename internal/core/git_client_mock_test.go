@@ -7,6 +7,7 @@ package core
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	types "github.com/EmundoT/git-vendor/internal/types"
 	gomock "github.com/golang/mock/gomock"
@@ -54,6 +55,20 @@ func (mr *MockGitClientMockRecorder) Add(ctx, dir interface{}, paths ...interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGitClient)(nil).Add), varargs...)
 }
 
+// ExportTree mocks base method.
+func (m *MockGitClient) ExportTree(ctx context.Context, dir, commit, destDir string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTree", ctx, dir, commit, destDir)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTree indicates an expected call of ExportTree.
+func (mr *MockGitClientMockRecorder) ExportTree(ctx, dir, commit, destDir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTree", reflect.TypeOf((*MockGitClient)(nil).ExportTree), ctx, dir, commit, destDir)
+}
+
 // AddNote mocks base method.
 func (m *MockGitClient) AddNote(ctx context.Context, dir, noteRef, commitHash, content string) error {
 	m.ctrl.T.Helper()
@@ -82,6 +97,21 @@ func (mr *MockGitClientMockRecorder) AddRemote(ctx, dir, name, url interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRemote", reflect.TypeOf((*MockGitClient)(nil).AddRemote), ctx, dir, name, url)
 }
 
+// CommitDate mocks base method.
+func (m *MockGitClient) CommitDate(ctx context.Context, dir, ref string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitDate", ctx, dir, ref)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitDate indicates an expected call of CommitDate.
+func (mr *MockGitClientMockRecorder) CommitDate(ctx, dir, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitDate", reflect.TypeOf((*MockGitClient)(nil).CommitDate), ctx, dir, ref)
+}
+
 // Checkout mocks base method.
 func (m *MockGitClient) Checkout(ctx context.Context, dir, ref string) error {
 	m.ctrl.T.Helper()
@@ -269,6 +299,21 @@ func (mr *MockGitClientMockRecorder) Init(ctx, dir interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockGitClient)(nil).Init), ctx, dir)
 }
 
+// IsAncestor mocks base method.
+func (m *MockGitClient) IsAncestor(ctx context.Context, dir, ancestor, descendant string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAncestor", ctx, dir, ancestor, descendant)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAncestor indicates an expected call of IsAncestor.
+func (mr *MockGitClientMockRecorder) IsAncestor(ctx, dir, ancestor, descendant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAncestor", reflect.TypeOf((*MockGitClient)(nil).IsAncestor), ctx, dir, ancestor, descendant)
+}
+
 // ListTree mocks base method.
 func (m *MockGitClient) ListTree(ctx context.Context, dir, ref, subdir string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -313,6 +358,21 @@ func (mr *MockGitClientMockRecorder) Push(ctx, dir, remote, branch interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockGitClient)(nil).Push), ctx, dir, remote, branch)
 }
 
+// ResolveRef mocks base method.
+func (m *MockGitClient) ResolveRef(ctx context.Context, dir, ref string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRef", ctx, dir, ref)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRef indicates an expected call of ResolveRef.
+func (mr *MockGitClientMockRecorder) ResolveRef(ctx, dir, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRef", reflect.TypeOf((*MockGitClient)(nil).ResolveRef), ctx, dir, ref)
+}
+
 // SetRemoteURL mocks base method.
 func (m *MockGitClient) SetRemoteURL(ctx context.Context, dir, name, url string) error {
 	m.ctrl.T.Helper()
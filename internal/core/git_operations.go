@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	git "github.com/EmundoT/git-plumbing"
 
+	"github.com/EmundoT/git-vendor/internal/core/providers"
 	"github.com/EmundoT/git-vendor/internal/types"
 )
 
@@ -37,23 +41,54 @@ type GitClient interface {
 	LsRemote(ctx context.Context, url, ref string) (string, error)
 	Push(ctx context.Context, dir, remote, branch string) error
 	CreateBranch(ctx context.Context, dir, name, startPoint string) error
+	IsAncestor(ctx context.Context, dir, ancestor, descendant string) (bool, error)
+	ResolveRef(ctx context.Context, dir, ref string) (string, error)
+	ExportTree(ctx context.Context, dir, commit, destDir string) error
+	CommitDate(ctx context.Context, dir, ref string) (time.Time, error)
 }
 
 // SystemGitClient implements GitClient using system git commands
 type SystemGitClient struct {
-	verbose bool
+	logger Logger // Receives a debug line per git command; nil = none
 }
 
 // NewSystemGitClient creates a new SystemGitClient
-func NewSystemGitClient(verbose bool) *SystemGitClient {
-	return &SystemGitClient{verbose: verbose}
+func NewSystemGitClient() *SystemGitClient {
+	return &SystemGitClient{}
+}
+
+// SetLogger routes each git command, its duration and any error to logger
+// at debug level. nil stops the logging.
+func (g *SystemGitClient) SetLogger(logger Logger) {
+	g.logger = logger
 }
 
 // gitFor creates a git-plumbing Git instance for the given directory.
 // Cheap allocation (single struct, no I/O) — required because git-vendor
 // passes dir per-call while git-plumbing stores it on the struct.
 func (g *SystemGitClient) gitFor(dir string) *git.Git {
-	return &git.Git{Dir: dir, Verbose: g.verbose}
+	pg := &git.Git{Dir: dir}
+	if g.logger != nil {
+		pg.Trace = func(args []string, elapsed time.Duration, err error) {
+			g.logGitCommand(dir, args, elapsed, err)
+		}
+	}
+	return pg
+}
+
+// logGitCommand writes one debug line for a finished git command.
+func (g *SystemGitClient) logGitCommand(dir string, args []string, elapsed time.Duration, err error) {
+	if g.logger == nil {
+		return
+	}
+	if dir == "" {
+		dir = "."
+	}
+	line := fmt.Sprintf("git %s (in %s) took %s", strings.Join(args, " "), dir, elapsed.Round(time.Millisecond))
+	if err != nil {
+		line += fmt.Sprintf(": %v", err)
+	}
+	g.logger.Debugf("%s", line)
 }
 
 // Init initializes a git repository
@@ -67,13 +102,15 @@ func (g *SystemGitClient) AddRemote(ctx context.Context, dir, name, url string)
 }
 
 // Fetch fetches from the named remote with optional depth.
+// Fetch, FetchAll, Clone, LsRemote and Push authenticate HTTPS remotes with
+// GIT_VENDOR_TOKEN / GIT_VENDOR_TOKEN_<HOST> when set (gitAuthEnv).
 func (g *SystemGitClient) Fetch(ctx context.Context, dir, remote string, depth int, ref string) error {
-	return g.gitFor(dir).Fetch(ctx, remote, ref, depth)
+	return g.gitForRemote(ctx, dir, remote).Fetch(ctx, remote, ref, depth)
 }
 
 // FetchAll fetches all refs from the named remote.
 func (g *SystemGitClient) FetchAll(ctx context.Context, dir, remote string) error {
-	return g.gitFor(dir).FetchAll(ctx, remote)
+	return g.gitForRemote(ctx, dir, remote).FetchAll(ctx, remote)
 }
 
 // SetRemoteURL updates the URL of an existing remote.
@@ -103,7 +140,7 @@ func (g *SystemGitClient) Clone(ctx context.Context, dir, url string, opts *type
 			Depth:      opts.Depth,
 		}
 	}
-	return g.gitFor(dir).Clone(ctx, url, plumbingOpts)
+	return g.gitForURL(dir, url).Clone(ctx, url, plumbingOpts)
 }
 
 // ListTree lists files/directories at a given ref and subdir.
@@ -219,13 +256,13 @@ func (g *SystemGitClient) ConfigGet(ctx context.Context, dir, key string) (strin
 // LsRemote delegates to git-plumbing's LsRemote. Dir is not needed since ls-remote
 // operates on the remote URL, but git-plumbing requires a Git instance; "." is used.
 func (g *SystemGitClient) LsRemote(ctx context.Context, url, ref string) (string, error) {
-	return g.gitFor(".").LsRemote(ctx, url, ref)
+	return g.gitForURL(".", url).LsRemote(ctx, url, ref)
 }
 
 // Push pushes a local branch to a remote.
 // Push delegates to git-plumbing's Push method for the given directory.
 func (g *SystemGitClient) Push(ctx context.Context, dir, remote, branch string) error {
-	return g.gitFor(dir).Push(ctx, remote, branch)
+	return g.gitForRemote(ctx, dir, remote).Push(ctx, remote, branch)
 }
 
 // CreateBranch creates a new branch at the given start point without checking it out.
@@ -234,6 +271,67 @@ func (g *SystemGitClient) CreateBranch(ctx context.Context, dir, name, startPoin
 	return g.gitFor(dir).CreateBranch(ctx, name, startPoint)
 }
 
+// IsAncestor reports whether ancestor is reachable from descendant in dir.
+// IsAncestor delegates to git-plumbing's IsAncestor; an ancestor commit absent
+// from the local object store is reported as unreachable (false, nil).
+func (g *SystemGitClient) IsAncestor(ctx context.Context, dir, ancestor, descendant string) (bool, error) {
+	return g.gitFor(dir).IsAncestor(ctx, ancestor, descendant)
+}
+
+// ResolveRef returns the full commit hash ref names in dir.
+// ResolveRef peels tags to their commit, so annotated tags resolve too.
+func (g *SystemGitClient) ResolveRef(ctx context.Context, dir, ref string) (string, error) {
+	return g.gitFor(dir).ResolveRef(ctx, ref+"^{commit}")
+}
+
+// CommitDate returns the committer timestamp of ref in dir.
+// CommitDate peels tags to their commit, so annotated tags resolve too.
+func (g *SystemGitClient) CommitDate(ctx context.Context, dir, ref string) (time.Time, error) {
+	out, err := g.gitFor(dir).Run(ctx, "log", "-1", "--format=%cI", ref+"^{commit}")
+	if err != nil {
+		return time.Time{}, err
+	}
+	date, err := time.Parse(time.RFC3339, strings.TrimSpace(out))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse commit date of %s: %w", ref, err)
+	}
+	return date, nil
+}
+
+// ExportTree writes the tree of commit in the repository at dir into destDir
+// by reading it into a throwaway index and running checkout-index against
+// destDir, so files come out as a checkout of commit would leave them:
+// unlike "git archive", export-ignore and export-subst attributes are not
+// applied. The repository's own index and work tree are untouched, and bare
+// repositories work too. Only committed content is exported.
+func (g *SystemGitClient) ExportTree(ctx context.Context, dir, commit, destDir string) error {
+	absDest, err := filepath.Abs(destDir)
+	if err != nil {
+		return err
+	}
+	index, err := os.CreateTemp(filepath.Dir(absDest), ".index-*")
+	if err != nil {
+		return fmt.Errorf("create export index: %w", err)
+	}
+	indexPath := index.Name()
+	_ = index.Close()
+	// git rejects an empty index file; read-tree creates it from scratch
+	if err := os.Remove(indexPath); err != nil {
+		return fmt.Errorf("create export index: %w", err)
+	}
+	defer os.Remove(indexPath) //nolint:errcheck // best-effort cleanup
+
+	pg := g.gitFor(dir)
+	pg.Env = []string{"GIT_INDEX_FILE=" + indexPath}
+	if err := pg.RunSilent(ctx, "read-tree", commit); err != nil {
+		return fmt.Errorf("git read-tree %s: %w", commit, err)
+	}
+	if err := pg.RunSilent(ctx, "--work-tree="+absDest, "checkout-index", "--all", "--force"); err != nil {
+		return fmt.Errorf("git checkout-index %s: %w", commit, err)
+	}
+	return nil
+}
+
 // GetGitUserIdentity returns the git user identity in "Name <email>" format.
 // Returns empty string if not configured.
 // Uses git-plumbing with empty Dir to match original behavior (process working directory).
@@ -242,14 +340,30 @@ func GetGitUserIdentity() string {
 	return g.UserIdentity(context.Background())
 }
 
-// ParseSmartURL extracts repository, ref, and path from GitHub URLs
+// GetHostBranch returns the branch checked out in the process working directory.
+// Returns empty string on a detached HEAD or outside a git repository.
+func GetHostBranch() string {
+	g := &git.Git{}
+	branch, err := g.CurrentBranch(context.Background())
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// ParseSmartURL extracts repository, ref, and path from GitHub URLs.
+// SSH URLs (git@host:owner/repo, ssh://...) are returned without ref/path extraction.
 func ParseSmartURL(rawURL string) (baseURL, ref, path string) {
 	rawURL = cleanURL(rawURL)
+	if providers.IsSSHURL(rawURL) {
+		return strings.TrimSuffix(rawURL, ".git"), "", ""
+	}
 	reDeep := regexp.MustCompile(`(github\.com/[^/]+/[^/]+)/(blob|tree)/([^/]+)/(.+)`)
 	matches := reDeep.FindStringSubmatch(rawURL)
 
 	if len(matches) == 5 {
-		return "https://" + matches[1], matches[3], matches[4]
+		ref, path := providers.SplitSlashedRef(matches[3], matches[4])
+		return "https://" + matches[1], ref, path
 	}
 
 	base := strings.TrimSuffix(rawURL, "/")
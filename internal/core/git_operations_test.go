@@ -15,7 +15,7 @@ import (
 // ============================================================================
 
 func TestSystemGitClient_GetCommitLog(t *testing.T) {
-	git := NewSystemGitClient(false)
+	git := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	// Initialize git repository
@@ -106,7 +106,7 @@ func TestSystemGitClient_GetCommitLog(t *testing.T) {
 }
 
 func TestSystemGitClient_GetCommitLog_MaxCount(t *testing.T) {
-	git := NewSystemGitClient(false)
+	git := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	// Initialize git repository
@@ -154,7 +154,7 @@ func TestSystemGitClient_GetCommitLog_MaxCount(t *testing.T) {
 }
 
 func TestSystemGitClient_GetCommitLog_EmptyRange(t *testing.T) {
-	git := NewSystemGitClient(false)
+	git := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	// Initialize git repository
@@ -188,7 +188,7 @@ func TestSystemGitClient_GetCommitLog_EmptyRange(t *testing.T) {
 }
 
 func TestSystemGitClient_GetCommitLog_InvalidRange(t *testing.T) {
-	git := NewSystemGitClient(false)
+	git := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	// Initialize git repository
@@ -215,7 +215,7 @@ func TestSystemGitClient_GetCommitLog_InvalidRange(t *testing.T) {
 // ============================================================================
 
 func TestSystemGitClient_GetTagForCommit_SemverPreference(t *testing.T) {
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	if err := gitClient.Init(context.Background(), tempDir); err != nil {
@@ -248,7 +248,7 @@ func TestSystemGitClient_GetTagForCommit_SemverPreference(t *testing.T) {
 }
 
 func TestSystemGitClient_GetTagForCommit_NonSemverFallback(t *testing.T) {
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	if err := gitClient.Init(context.Background(), tempDir); err != nil {
@@ -279,7 +279,7 @@ func TestSystemGitClient_GetTagForCommit_NonSemverFallback(t *testing.T) {
 }
 
 func TestSystemGitClient_GetTagForCommit_NoTags(t *testing.T) {
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	if err := gitClient.Init(context.Background(), tempDir); err != nil {
@@ -308,7 +308,7 @@ func TestSystemGitClient_GetTagForCommit_NoTags(t *testing.T) {
 }
 
 func TestSystemGitClient_GetTagForCommit_SemverWithoutPrefix(t *testing.T) {
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	if err := gitClient.Init(context.Background(), tempDir); err != nil {
@@ -518,19 +518,18 @@ func TestParseSmartURL_DeepLinkWithNestedPath(t *testing.T) {
 }
 
 func TestParseSmartURL_BranchLikeFeaturePath(t *testing.T) {
-	// Branch names with slashes can't be parsed — documented limitation.
-	// feature/v2/main appears as ref="feature" path="v2/main/src/file.go"
+	// Known branch prefixes absorb exactly one extra segment into the ref.
+	// feature/v2/main is ambiguous: parsed as ref="feature/v2" path="main/src/file.go"
 	base, ref, path := ParseSmartURL("https://github.com/owner/repo/blob/feature/v2/main/src/file.go")
 	if base != "https://github.com/owner/repo" {
 		t.Errorf("Expected base 'https://github.com/owner/repo', got '%s'", base)
 	}
-	// The regex captures the first path segment as ref
-	if ref != "feature" {
-		t.Errorf("Expected ref 'feature' (slash branch limitation), got '%s'", ref)
+	if ref != "feature/v2" {
+		t.Errorf("Expected ref 'feature/v2', got '%s'", ref)
 	}
-	// Rest becomes path
-	if path != "v2/main/src/file.go" {
-		t.Errorf("Expected path 'v2/main/src/file.go', got '%s'", path)
+	// Rest becomes path (refs with two or more slashes still need manual entry)
+	if path != "main/src/file.go" {
+		t.Errorf("Expected path 'main/src/file.go', got '%s'", path)
 	}
 }
 
@@ -620,7 +619,7 @@ func TestParseSmartURL_TableDriven(t *testing.T) {
 // ============================================================================
 
 func TestSystemGitClient_GetCommitLog_DateFormat(t *testing.T) {
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	tempDir := t.TempDir()
 
 	if err := gitClient.Init(context.Background(), tempDir); err != nil {
@@ -714,6 +713,55 @@ func TestIsSemverTag(t *testing.T) {
 	}
 }
 
+func TestSystemGitClient_ExportTree_IgnoresExportAttributes(t *testing.T) {
+	gitClient := NewSystemGitClient()
+	repoDir := t.TempDir()
+
+	if err := gitClient.Init(context.Background(), repoDir); err != nil {
+		t.Fatalf("Failed to init git repo: %v", err)
+	}
+	configureGitUser(t, repoDir)
+
+	// export-subst and export-ignore would rewrite or drop these under git archive
+	os.WriteFile(filepath.Join(repoDir, ".gitattributes"), []byte("version.txt export-subst\nignored.txt export-ignore\n"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "version.txt"), []byte("$Format:%H$\n"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "ignored.txt"), []byte("kept\n"), 0644)
+	runGitSilent(t, repoDir, "add", ".")
+	runGitSilent(t, repoDir, "commit", "-m", "Initial commit")
+
+	hash, err := gitClient.GetHeadHash(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Failed to get commit hash: %v", err)
+	}
+
+	// Uncommitted edits must neither be exported nor disturbed
+	os.WriteFile(filepath.Join(repoDir, "ignored.txt"), []byte("local edit\n"), 0644)
+
+	destDir := filepath.Join(t.TempDir(), "tree")
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := gitClient.ExportTree(context.Background(), repoDir, hash, destDir); err != nil {
+		t.Fatalf("ExportTree failed: %v", err)
+	}
+
+	for name, want := range map[string]string{"version.txt": "$Format:%H$\n", "ignored.txt": "kept\n"} {
+		got, err := os.ReadFile(filepath.Join(destDir, name))
+		if err != nil {
+			t.Fatalf("read exported %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("exported %s = %q, want %q", name, got, want)
+		}
+	}
+	if got, _ := os.ReadFile(filepath.Join(repoDir, "ignored.txt")); string(got) != "local edit\n" {
+		t.Errorf("source work tree changed: ignored.txt = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, ".git")); !os.IsNotExist(err) {
+		t.Errorf("ExportTree wrote .git into destDir (stat err = %v)", err)
+	}
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
@@ -978,21 +1026,35 @@ func TestParseSmartURL_SSHURLs(t *testing.T) {
 }
 
 func TestParseSmartURL_BranchWithSlashes(t *testing.T) {
-	// Branch names with slashes are a documented limitation.
-	// The regex captures the first path segment as the ref, which is incorrect
-	// for branches like "feature/foo". This test documents the limitation.
-	base, ref, path := ParseSmartURL("https://github.com/owner/repo/blob/feature/foo/src/file.go")
-
-	// Due to the regex, "feature" is captured as ref, and "foo/src/file.go" as path.
-	// This is the known limitation documented in CLAUDE.md.
-	if base != "https://github.com/owner/repo" {
-		t.Errorf("base = %q, want 'https://github.com/owner/repo'", base)
-	}
-	if ref != "feature" {
-		t.Errorf("ref = %q, want 'feature' (known limitation: only first segment captured)", ref)
+	// Branch names with a known prefix (feature/, release/, hotfix/, bugfix/)
+	// keep their slash in the ref instead of leaking into the path.
+	tests := []struct {
+		name     string
+		rawURL   string
+		wantRef  string
+		wantPath string
+	}{
+		{"feature branch blob", "https://github.com/owner/repo/blob/feature/foo/src/file.go", "feature/foo", "src/file.go"},
+		{"feature branch tree", "https://github.com/owner/repo/tree/feature/new-feature/src", "feature/new-feature", "src"},
+		{"release branch blob", "https://github.com/owner/repo/blob/release/2.0/README.md", "release/2.0", "README.md"},
+		{"hotfix branch", "https://github.com/owner/repo/blob/hotfix/crash/main.go", "hotfix/crash", "main.go"},
+		{"prefix-only tree root", "https://github.com/owner/repo/tree/feature/new-feature", "feature/new-feature", ""},
+		{"plain branch unaffected", "https://github.com/owner/repo/blob/main/feature/file.go", "main", "feature/file.go"},
 	}
-	if path != "foo/src/file.go" {
-		t.Errorf("path = %q, want 'foo/src/file.go' (known limitation: rest captured as path)", path)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, ref, path := ParseSmartURL(tt.rawURL)
+			if base != "https://github.com/owner/repo" {
+				t.Errorf("base = %q, want 'https://github.com/owner/repo'", base)
+			}
+			if ref != tt.wantRef {
+				t.Errorf("ref = %q, want %q", ref, tt.wantRef)
+			}
+			if path != tt.wantPath {
+				t.Errorf("path = %q, want %q", path, tt.wantPath)
+			}
+		})
 	}
 }
 
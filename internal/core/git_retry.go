@@ -0,0 +1,117 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultFetchAttempts is the number of fetch attempts per URL when
+// SyncOptions.FetchAttempts is unset: the first try plus two retries.
+const DefaultFetchAttempts = 3
+
+// fetchRetryBaseDelay is the wait before the first retry. Each further retry
+// doubles it (1s, 2s, 4s, ...). Tests set it to zero.
+var fetchRetryBaseDelay = time.Second
+
+// nonRetryableGitErrors are git failures that will not go away on retry:
+// bad credentials, missing repositories and unknown refs.
+var nonRetryableGitErrors = []string{
+	"authentication failed",
+	"could not read username",
+	"could not read password",
+	"permission denied",
+	"repository not found",
+	"does not appear to be a git repository",
+	"couldn't find remote ref",
+	"unknown revision",
+	"not our ref",
+	"invalid refspec",
+}
+
+// retryableGitErrors are transient network failures worth retrying.
+var retryableGitErrors = []string{
+	"could not resolve host",
+	"connection refused",
+	"connection reset",
+	"timed out",
+	"network is unreachable",
+	"temporary failure in name resolution",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"unexpected disconnect",
+	"rpc failed",
+	"gnutls_handshake() failed",
+	"ssl_read",
+	"tls handshake timeout",
+	"http/2 stream",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+}
+
+// IsRetryableGitError reports whether err looks like a transient network
+// failure (DNS, connection reset, timeout, 5xx) rather than a permanent one
+// such as an authentication failure or an unknown ref. Context cancellation
+// is never retryable.
+func IsRetryableGitError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range nonRetryableGitErrors {
+		if strings.Contains(msg, s) {
+			return false
+		}
+	}
+	for _, s := range retryableGitErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchWithRetry runs gitClient.Fetch up to attempts times (DefaultFetchAttempts
+// when attempts <= 0), waiting with exponential backoff between tries. Only
+// errors accepted by IsRetryableGitError are retried; any other error, or a
+// cancelled ctx, returns immediately.
+func fetchWithRetry(ctx context.Context, gitClient GitClient, ui UICallback, tempDir, ref string, depth, attempts int) error {
+	if attempts <= 0 {
+		attempts = DefaultFetchAttempts
+	}
+
+	delay := fetchRetryBaseDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = gitClient.Fetch(ctx, tempDir, "origin", depth, ref)
+		if err == nil || attempt >= attempts || !IsRetryableGitError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		if ui != nil {
+			ui.ShowWarning("Fetch Retry", fmt.Sprintf("attempt %d/%d for ref %s failed (%v), retrying in %s", attempt, attempts, ref, err, delay))
+		}
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// sleepContext waits for d or until ctx is done, returning ctx.Err() in the latter case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// githubAnnotationLevels maps verify statuses to GitHub Actions workflow
+// command levels. Statuses that fail verify are errors; coherence issues and
+// untracked files are warnings. Verified and accepted files are not reported.
+var githubAnnotationLevels = map[string]string{
+	"modified":  "error",
+	"deleted":   "error",
+	"truncated": "error",
+	"added":     "warning",
+	"stale":     "warning",
+	"orphaned":  "warning",
+}
+
+// WriteGitHubAnnotations writes one "::error file=...::" or "::warning
+// file=...::" workflow command per reported entry of files, so a GitHub
+// Actions run shows them inline on the pull request. Position entries carry
+// their line range. Returns the number of annotations written.
+func WriteGitHubAnnotations(w io.Writer, files []types.FileStatus) (int, error) {
+	n := 0
+	for _, f := range files {
+		level, ok := githubAnnotationLevels[f.Status]
+		if !ok {
+			continue
+		}
+		path, pos, err := types.ParsePathPosition(f.Path)
+		if err != nil {
+			path, pos = f.Path, nil
+		}
+		props := "file=" + escapeAnnotationProperty(path)
+		if pos != nil && pos.StartLine > 0 {
+			props += fmt.Sprintf(",line=%d", pos.StartLine)
+			if pos.EndLine > pos.StartLine {
+				props += fmt.Sprintf(",endLine=%d", pos.EndLine)
+			}
+		}
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", level, props, escapeAnnotationData(githubAnnotationMessage(f))); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// githubAnnotationMessage describes f for its annotation.
+func githubAnnotationMessage(f types.FileStatus) string {
+	vendor := ""
+	if f.Vendor != nil {
+		vendor = fmt.Sprintf(" (vendor %s)", *f.Vendor)
+	}
+	var msg string
+	switch f.Status {
+	case "modified":
+		msg = "Vendored file modified since last sync"
+	case "deleted":
+		msg = "Vendored file deleted since last sync"
+	case "truncated":
+		msg = "Vendored file is empty but its locked content is not"
+	case "added":
+		msg = "File is not recorded in vendor.lock"
+	case "stale":
+		msg = "Mapped in vendor.yml but not recorded in vendor.lock"
+	case "orphaned":
+		msg = "Recorded in vendor.lock but no vendor.yml mapping produces it"
+	}
+	msg += vendor
+	if f.Hint != "" {
+		msg += ". " + f.Hint
+	}
+	return msg
+}
+
+// escapeAnnotationData escapes a workflow command message.
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a workflow command property value.
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestWriteGitHubAnnotations_ModifiedFileIsError(t *testing.T) {
+	vendor := "mylib"
+	svc := NewStatusService(
+		&statusStubVerify{
+			result: &types.VerifyResult{
+				Summary: types.VerifySummary{TotalFiles: 2, Verified: 1, Modified: 1, Result: "FAIL"},
+				Files: []types.FileStatus{
+					{Path: "vendor/mylib/a.go", Vendor: &vendor, Status: "verified", Type: "file"},
+					{Path: "vendor/mylib/b.go", Vendor: &vendor, Status: "modified", Type: "file"},
+				},
+			},
+		},
+		nil,
+		nil,
+		&statusStubLockStore{
+			lock: types.VendorLock{Vendors: []types.LockDetails{{Name: "mylib", Ref: "main", CommitHash: "abc"}}},
+		},
+	)
+	result, err := svc.Status(context.Background(), StatusOptions{Offline: true})
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := WriteGitHubAnnotations(&buf, result.Files)
+	if err != nil {
+		t.Fatalf("WriteGitHubAnnotations: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("wrote %d annotations, want 1 (verified files are not reported)", n)
+	}
+	if out := buf.String(); !strings.HasPrefix(out, "::error file=vendor/mylib/b.go::") || !strings.Contains(out, "mylib") {
+		t.Errorf("output = %q, want an ::error annotation for vendor/mylib/b.go", out)
+	}
+}
+
+func TestWriteGitHubAnnotations_LevelsAndEscaping(t *testing.T) {
+	vendor := "lib"
+	files := []types.FileStatus{
+		{Path: "lib/api.go:L4-L6", Vendor: &vendor, Status: "deleted", Type: "position"},
+		{Path: "lib/new,file.go", Status: "added", Type: "file"},
+		{Path: "lib/gone.go", Vendor: &vendor, Status: "orphaned", Type: "coherence"},
+		{Path: "lib/ok.go", Vendor: &vendor, Status: "accepted", Type: "file"},
+	}
+
+	var buf bytes.Buffer
+	if _, err := WriteGitHubAnnotations(&buf, files); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"::error file=lib/api.go,line=4,endLine=6::",
+		"::warning file=lib/new%2Cfile.go::",
+		"::warning file=lib/gone.go::",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], prefix)
+		}
+	}
+}
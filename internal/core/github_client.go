@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
-	"regexp"
+	"strings"
 	"time"
+
+	"github.com/EmundoT/git-vendor/internal/hostdetect"
 )
 
 // LicenseChecker checks repository licenses
@@ -15,34 +18,112 @@ type LicenseChecker interface {
 	IsAllowed(license string) bool
 }
 
-// GitHubLicenseChecker implements LicenseChecker for GitHub
+// GitHubAPIURLEnv names the environment variable that points license detection
+// at a GitHub Enterprise API base (e.g. "https://ghe.example.com/api/v3").
+// Repos on that base's host are queried there instead of api.github.com.
+const GitHubAPIURLEnv = "GIT_VENDOR_GITHUB_API_URL"
+
+// gitHubPublicAPIBase is the REST API base for github.com repositories.
+const gitHubPublicAPIBase = "https://api.github.com"
+
+// GitHubLicenseChecker implements LicenseChecker for GitHub and GitHub Enterprise
 type GitHubLicenseChecker struct {
 	httpClient      *http.Client
 	allowedLicenses []string
+	apiBases        map[string]string // Repo host → REST API base
 }
 
-// NewGitHubLicenseChecker creates a new GitHubLicenseChecker
+// NewGitHubLicenseChecker creates a new GitHubLicenseChecker.
+// GIT_VENDOR_GITHUB_API_URL, when set, registers its host as a GitHub Enterprise host.
 func NewGitHubLicenseChecker(httpClient *http.Client, allowedLicenses []string) *GitHubLicenseChecker {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
-	return &GitHubLicenseChecker{
+	c := &GitHubLicenseChecker{
 		httpClient:      httpClient,
 		allowedLicenses: allowedLicenses,
+		apiBases:        map[string]string{"github.com": gitHubPublicAPIBase},
 	}
+	if base := os.Getenv(GitHubAPIURLEnv); base != "" {
+		if u, err := url.Parse(base); err == nil && u.Host != "" {
+			c.SetAPIBase(u.Host, base)
+		}
+	}
+	return c
 }
 
-// CheckLicense queries GitHub API for repository license
-func (c *GitHubLicenseChecker) CheckLicense(rawURL string) (string, error) {
+// SetAPIBase routes license lookups for repositories on host to apiBase
+// (e.g. "ghe.example.com" → "https://ghe.example.com/api/v3").
+func (c *GitHubLicenseChecker) SetAPIBase(host, apiBase string) {
+	c.apiBases[strings.ToLower(host)] = strings.TrimRight(apiBase, "/")
+}
+
+// Handles reports whether repoURL is on github.com, a registered enterprise
+// host, or a host that hostdetect recognizes as GitHub.
+func (c *GitHubLicenseChecker) Handles(repoURL string) bool {
+	host, _, _, ok := parseGitHubRepoURL(repoURL)
+	return ok && c.apiBaseFor(host) != ""
+}
+
+// apiBaseFor returns the REST API base for host, or "" when host is not GitHub.
+// Unregistered hosts that look like GitHub Enterprise (e.g. "github.corp.com")
+// use the GHE convention https://<host>/api/v3, queried without a token.
+func (c *GitHubLicenseChecker) apiBaseFor(host string) string {
+	host = strings.ToLower(host)
+	if base, ok := c.apiBases[host]; ok {
+		return base
+	}
+	if hostdetect.DetectProvider(host) == hostdetect.ProviderGitHub {
+		return "https://" + host + "/api/v3"
+	}
+	return ""
+}
+
+// isRegistered reports whether host's API base was registered (github.com,
+// SetAPIBase or GIT_VENDOR_GITHUB_API_URL). Only registered bases get a token:
+// a host merely named like GitHub could be anyone's.
+func (c *GitHubLicenseChecker) isRegistered(host string) bool {
+	_, ok := c.apiBases[strings.ToLower(host)]
+	return ok
+}
+
+// parseGitHubRepoURL splits an https or scp-style GitHub URL into host, owner
+// and repo. Deep links (/blob/..., /tree/...) are accepted; extra segments are ignored.
+func parseGitHubRepoURL(rawURL string) (host, owner, repo string, ok bool) {
 	clean := cleanURL(rawURL)
-	re := regexp.MustCompile(`github\.com/([^/]+)/([^/\.]+)(\.git)?`)
-	matches := re.FindStringSubmatch(clean)
+	if i := strings.Index(clean, "://"); i != -1 {
+		clean = clean[i+3:]
+	} else if at := strings.Index(clean, "@"); at != -1 {
+		// scp-style: git@host:owner/repo
+		clean = strings.Replace(clean[at+1:], ":", "/", 1)
+	}
+	if at := strings.Index(clean, "@"); at != -1 && at < strings.Index(clean+"/", "/") {
+		clean = clean[at+1:] // userinfo in an https URL
+	}
 
-	if len(matches) < 3 {
+	parts := strings.Split(clean, "/")
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", false
+	}
+	repo = strings.TrimSuffix(parts[2], ".git")
+	if repo == "" {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], repo, true
+}
+
+// CheckLicense queries the GitHub (or GitHub Enterprise) API for repository license
+func (c *GitHubLicenseChecker) CheckLicense(rawURL string) (string, error) {
+	host, owner, repo, ok := parseGitHubRepoURL(rawURL)
+	if !ok {
 		return "", fmt.Errorf("invalid URL format")
 	}
+	apiBase := c.apiBaseFor(host)
+	if apiBase == "" {
+		return "", fmt.Errorf("invalid URL format: %s is not a GitHub host (set %s for GitHub Enterprise)", host, GitHubAPIURLEnv)
+	}
 
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/license", matches[1], matches[2])
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/license", apiBase, owner, repo)
 
 	// Retry with exponential backoff for rate limit errors
 	var lastErr error
@@ -59,8 +140,17 @@ func (c *GitHubLicenseChecker) CheckLicense(rawURL string) (string, error) {
 		}
 		req.Header.Set("User-Agent", "git-vendor-cli")
 
-		// Add GitHub token if available (increases rate limit from 60/hr to 5000/hr)
-		if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		// Add GitHub token if available (increases rate limit from 60/hr to 5000/hr).
+		// GITHUB_TOKEN wins; otherwise the git-vendor token for the host is used.
+		// Hosts only detected as GitHub are queried anonymously.
+		token := ""
+		if c.isRegistered(host) {
+			token = os.Getenv("GITHUB_TOKEN")
+			if token == "" {
+				token = ResolveGitToken(host)
+			}
+		}
+		if token != "" {
 			req.Header.Set("Authorization", "token "+token)
 		}
 
@@ -1,9 +1,11 @@
 package core
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -75,11 +77,19 @@ func TestGitHubLicenseChecker_CheckLicense_WithMockServer(t *testing.T) {
 			}))
 			defer server.Close()
 
-			// Override the GitHub API URL by using httpClient that redirects to test server
-			// This is a limitation - we'd need to refactor github_client.go to accept apiBaseURL
-			// For now, these tests document the expected behavior
-			_ = server
-			t.Skip("Requires refactoring github_client.go to accept configurable API base URL")
+			checker := NewGitHubLicenseChecker(server.Client(), nil)
+			checker.SetAPIBase("github.com", server.URL)
+
+			license, err := checker.CheckLicense(tt.url)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("CheckLicense() error = %v, expectError %v", err, tt.expectError)
+			}
+			if license != tt.expectedLicense {
+				t.Errorf("CheckLicense() = %q, want %q", license, tt.expectedLicense)
+			}
+			if callCount != 1 {
+				t.Errorf("API calls = %d, want 1", callCount)
+			}
 		})
 	}
 }
@@ -132,10 +142,100 @@ func TestGitHubLicenseChecker_CheckLicense_WithToken(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Note: This test also requires refactoring to use configurable API URL
-	_ = tokenReceived
-	_ = server
-	t.Skip("Requires refactoring github_client.go to accept configurable API base URL")
+	checker := NewGitHubLicenseChecker(server.Client(), nil)
+	checker.SetAPIBase("github.com", server.URL)
+	if _, err := checker.CheckLicense("https://github.com/owner/repo"); err != nil {
+		t.Fatalf("CheckLicense() error = %v", err)
+	}
+	if tokenReceived != "token test-token-123" {
+		t.Errorf("Authorization = %q, want token test-token-123", tokenReceived)
+	}
+}
+
+// ============================================================================
+// GitHub Enterprise Tests
+// ============================================================================
+
+func TestGitHubLicenseChecker_EnterpriseHost_QueriesConfiguredBase(t *testing.T) {
+	requestedPath := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"license": {"spdx_id": "Apache-2.0"}}`))
+	}))
+	defer server.Close()
+
+	checker := NewGitHubLicenseChecker(server.Client(), nil)
+	checker.SetAPIBase("ghe.example.com", server.URL+"/api/v3/")
+
+	license, err := checker.CheckLicense("https://ghe.example.com/platform/widgets.git")
+	if err != nil {
+		t.Fatalf("CheckLicense() error = %v", err)
+	}
+	if license != "Apache-2.0" {
+		t.Errorf("CheckLicense() = %q, want Apache-2.0", license)
+	}
+	if requestedPath != "/api/v3/repos/platform/widgets/license" {
+		t.Errorf("requested path = %q, want /api/v3/repos/platform/widgets/license", requestedPath)
+	}
+}
+
+func TestNewGitHubLicenseChecker_EnterpriseBaseFromEnv(t *testing.T) {
+	t.Setenv(GitHubAPIURLEnv, "https://ghe.example.com/api/v3")
+
+	checker := NewGitHubLicenseChecker(nil, nil)
+
+	if got := checker.apiBaseFor("ghe.example.com"); got != "https://ghe.example.com/api/v3" {
+		t.Errorf("apiBaseFor(ghe.example.com) = %q, want the env base", got)
+	}
+	if got := checker.apiBaseFor("github.com"); got != gitHubPublicAPIBase {
+		t.Errorf("apiBaseFor(github.com) = %q, want %q", got, gitHubPublicAPIBase)
+	}
+	if !checker.Handles("git@ghe.example.com:platform/widgets.git") {
+		t.Error("Handles() should accept scp-style URLs on the enterprise host")
+	}
+	if checker.Handles("https://gitlab.com/owner/repo") {
+		t.Error("Handles() should reject non-GitHub hosts")
+	}
+}
+
+func TestGitHubLicenseChecker_DetectedEnterpriseHostUsesV3Convention(t *testing.T) {
+	checker := NewGitHubLicenseChecker(nil, nil)
+
+	if got := checker.apiBaseFor("github.corp.example"); got != "https://github.corp.example/api/v3" {
+		t.Errorf("apiBaseFor(github.corp.example) = %q, want https://github.corp.example/api/v3", got)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestGitHubLicenseChecker_UnregisteredHostGetsNoToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret-token")
+
+	var requested, auth string
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = r.URL.String()
+		auth = r.Header.Get("Authorization")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"license": {"spdx_id": "MIT"}}`)),
+			Header:     make(http.Header),
+		}, nil
+	})}
+
+	checker := NewGitHubLicenseChecker(client, nil)
+	if _, err := checker.CheckLicense("https://github.evil.example/owner/repo"); err != nil {
+		t.Fatalf("CheckLicense() error = %v", err)
+	}
+	if requested != "https://github.evil.example/api/v3/repos/owner/repo/license" {
+		t.Errorf("requested %q, want the GHE convention URL", requested)
+	}
+	if auth != "" {
+		t.Errorf("Authorization = %q, want none for an unregistered host", auth)
+	}
 }
 
 // ============================================================================
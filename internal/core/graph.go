@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// GraphFormatDOT is the Graphviz DOT output of the graph command (the default).
+const GraphFormatDOT = "dot"
+
+// GraphDOT renders the config's vendors, the top-level destinations they
+// write, and their DetectConflicts collisions as DOT; see RenderVendorGraphDOT.
+func (s *VendorSyncer) GraphDOT() (string, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return "", fmt.Errorf("load config: %w", err)
+	}
+	conflicts, err := s.validation.DetectConflicts()
+	if err != nil {
+		return "", err
+	}
+	return RenderVendorGraphDOT(config, conflicts), nil
+}
+
+// RenderVendorGraphDOT returns a Graphviz digraph with one box per vendor and
+// one folder per top-level destination (the first path component of each
+// resolved mapping destination), an edge from each vendor to every top-level
+// destination it writes, and a red undirected edge between the two vendors of
+// each conflict, labeled with the conflicting path and reason. Destinations
+// outside the project root are left out. Output is sorted, so identical
+// configs render identically.
+func RenderVendorGraphDOT(config types.VendorConfig, conflicts []types.PathConflict) string {
+	var b strings.Builder
+	b.WriteString("digraph vendors {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\"];\n")
+
+	vendorNames := make([]string, 0, len(config.Vendors))
+	writes := make(map[string]map[string]bool) // vendor -> top-level destinations
+	tops := make(map[string]bool)
+	for i := range config.Vendors {
+		v := &config.Vendors[i]
+		vendorNames = append(vendorNames, v.Name)
+		for _, spec := range v.Specs {
+			for _, mapping := range spec.Mapping {
+				dest := mappingDestFile(v, spec, mapping)
+				if dest == "." || dest == ".." || strings.HasPrefix(dest, "../") || filepath.IsAbs(filepath.FromSlash(dest)) {
+					continue
+				}
+				top, _, _ := strings.Cut(dest, "/")
+				if writes[v.Name] == nil {
+					writes[v.Name] = make(map[string]bool)
+				}
+				writes[v.Name][top] = true
+				tops[top] = true
+			}
+		}
+	}
+	sort.Strings(vendorNames)
+
+	b.WriteString("\n")
+	for _, name := range vendorNames {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=box];\n", dotQuote("vendor:"+name), dotQuote(name))
+	}
+	for _, top := range sortedKeys(tops) {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=folder];\n", dotQuote("dest:"+top), dotQuote(top))
+	}
+
+	b.WriteString("\n")
+	for _, name := range vendorNames {
+		for _, top := range sortedKeys(writes[name]) {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote("vendor:"+name), dotQuote("dest:"+top))
+		}
+	}
+
+	var conflictEdges []string
+	seen := make(map[string]bool)
+	for _, c := range conflicts {
+		edge := fmt.Sprintf("  %s -> %s [color=red, fontcolor=red, dir=none, label=%s];\n",
+			dotQuote("vendor:"+c.Vendor1), dotQuote("vendor:"+c.Vendor2), dotQuote(filepath.ToSlash(c.Path)+" ("+c.Reason+")"))
+		if !seen[edge] {
+			seen[edge] = true
+			conflictEdges = append(conflictEdges, edge)
+		}
+	}
+	sort.Strings(conflictEdges)
+	if len(conflictEdges) > 0 {
+		b.WriteString("\n")
+		for _, edge := range conflictEdges {
+			b.WriteString(edge)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote renders s as a DOT double-quoted string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// sortedKeys returns the keys of set in ascending order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
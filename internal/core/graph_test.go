@@ -0,0 +1,63 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestGraphDOT_NodesPerVendorAndConflictEdge(t *testing.T) {
+	config := types.VendorConfig{Vendors: []types.VendorSpec{
+		{Name: "vendor-a", URL: "https://github.com/owner/a", Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{
+			{From: "src", To: "lib/shared"},
+		}}}},
+		{Name: "vendor-b", URL: "https://github.com/owner/b", Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{
+			{From: "pkg", To: "lib/shared"},
+			{From: "docs", To: "docs/b"},
+		}}}},
+		{Name: "vendor-c", URL: "https://github.com/owner/c", Specs: []types.BranchSpec{{Ref: "v1", Mapping: []types.PathMapping{
+			{From: "c.go", To: "third_party/c.go"},
+			{From: "escape.go", To: "../outside.go"},
+		}}}},
+	}}
+	syncer := NewVendorSyncer(&stubConfigStore{config: config}, nil, nil, nil, nil, "/mock/vendor", &SilentUICallback{}, nil)
+
+	dot, err := syncer.GraphDOT()
+	if err != nil {
+		t.Fatalf("GraphDOT: %v", err)
+	}
+	if !strings.HasPrefix(dot, "digraph vendors {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("output is not a single digraph:\n%s", dot)
+	}
+	for _, want := range []string{
+		`"vendor:vendor-a" [label="vendor-a", shape=box];`,
+		`"vendor:vendor-b" [label="vendor-b", shape=box];`,
+		`"vendor:vendor-c" [label="vendor-c", shape=box];`,
+		`"dest:lib" [label="lib", shape=folder];`,
+		`"vendor:vendor-b" -> "dest:docs";`,
+		`"vendor:vendor-c" -> "dest:third_party";`,
+		`"vendor:vendor-a" -> "vendor:vendor-b" [color=red, fontcolor=red, dir=none, label="lib/shared (same_path)"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output missing %s:\n%s", want, dot)
+		}
+	}
+	if strings.Contains(dot, "outside") {
+		t.Errorf("destinations outside the project should be left out:\n%s", dot)
+	}
+	if n := strings.Count(dot, "[color=red"); n != 1 {
+		t.Errorf("conflict edges = %d, want 1:\n%s", n, dot)
+	}
+
+	// Same config, same bytes: the output is safe to commit and diff
+	if again, _ := syncer.GraphDOT(); again != dot {
+		t.Errorf("GraphDOT is not deterministic")
+	}
+}
+
+func TestDotQuote_EscapesQuotesAndBackslashes(t *testing.T) {
+	if got, want := dotQuote(`a"b\c`), `"a\"b\\c"`; got != want {
+		t.Errorf("dotQuote = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// linkDuplicateFiles replaces each file in hashes (forward-slash destination
+// path -> SHA-256, as in CopyStats.FileHashes) whose content and mode match
+// an earlier path's with a hard link to that path, so identical vendored
+// copies share one inode (--hardlink). SyncVendor passes one vendor's hashes,
+// so files are only linked within a vendor, never across vendors. Paths are
+// visited in sorted order and the first of each identical set keeps its
+// inode. A link that fails, such as on a filesystem without hard links,
+// leaves that copy in place. Returns the number of files linked.
+//
+// Position mappings (PlaceContent) and keep-local restores rewrite files in
+// place after a copy; they go through writeFileUnlinked so the edit never
+// reaches a file's other links.
+func (s *SyncService) linkDuplicateFiles(hashes map[string]string) int {
+	paths := make([]string, 0, len(hashes))
+	for p := range hashes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	firsts := make(map[string]os.FileInfo) // hash and mode -> first file's info
+	firstPaths := make(map[string]string)
+	linked := 0
+	for _, p := range paths {
+		path := filepath.FromSlash(p)
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		key := hashes[p] + " " + info.Mode().String()
+		first, ok := firsts[key]
+		if !ok {
+			firsts[key], firstPaths[key] = info, path
+			continue
+		}
+		if os.SameFile(first, info) {
+			continue // Linked by an earlier sync
+		}
+
+		// Link beside the copy, then rename over it, so a failed link never
+		// loses the copy
+		tmp := path + ".git-vendor-link"
+		_ = os.Remove(tmp) //nolint:errcheck // leftover from an interrupted run
+		if err := os.Link(firstPaths[key], tmp); err != nil {
+			s.logger.Debugf("hard link %s -> %s failed, keeping the copy: %v", p, firstPaths[key], err)
+			continue
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			_ = os.Remove(tmp) //nolint:errcheck // best-effort cleanup
+			s.logger.Debugf("replace %s with a hard link failed, keeping the copy: %v", p, err)
+			continue
+		}
+		linked++
+	}
+	return linked
+}
+
+// writeFileUnlinked writes data to path like os.WriteFile, but replaces an
+// existing file instead of truncating it, so a file --hardlink linked to
+// identical copies is unlinked from them before it changes. An existing file
+// keeps its permission bits.
+func writeFileUnlinked(path string, data []byte, perm os.FileMode) error {
+	info, err := os.Lstat(path)
+	existed := err == nil && info.Mode().IsRegular()
+	if existed {
+		perm = info.Mode().Perm()
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	if existed {
+		return os.Chmod(path, perm) // WriteFile's mode is subject to the umask
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestLinkDuplicateFiles_IdenticalDestinationsShareInode(t *testing.T) {
+	chdirUnmanagedTest(t)
+	clone := t.TempDir()
+	writeFixTestFile(t, filepath.Join(clone, "include", "shared.h"), "#define SHARED 1\n")
+	writeFixTestFile(t, filepath.Join(clone, "include", "other.h"), "#define OTHER 1\n")
+
+	spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{
+		{From: "include/shared.h", To: "a/shared.h"},
+		{From: "include/shared.h", To: "b/shared.h"},
+		{From: "include/other.h", To: "b/other.h"},
+	}}
+	vendor := &types.VendorSpec{Name: "headers", Specs: []types.BranchSpec{spec}}
+	stats, err := NewFileCopyService(NewOSFileSystem()).CopyMappings(clone, vendor, spec)
+	if err != nil {
+		t.Fatalf("CopyMappings: %v", err)
+	}
+
+	if n := (&SyncService{}).linkDuplicateFiles(stats.FileHashes); n != 1 {
+		t.Fatalf("linkDuplicateFiles linked %d files, want 1", n)
+	}
+	stat := func(p string) os.FileInfo {
+		t.Helper()
+		info, err := os.Stat(filepath.FromSlash(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+	if !os.SameFile(stat("a/shared.h"), stat("b/shared.h")) {
+		t.Error("identical destinations a/shared.h and b/shared.h should share an inode")
+	}
+	if os.SameFile(stat("a/shared.h"), stat("b/other.h")) {
+		t.Error("b/other.h differs and must stay a separate file")
+	}
+
+	// A later copy over one name must not write through the link
+	writeFixTestFile(t, filepath.Join(clone, "include", "shared.h"), "#define SHARED 2\n")
+	if _, err := NewOSFileSystem().CopyFile(filepath.Join(clone, "include", "shared.h"), filepath.FromSlash("b/shared.h")); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(filepath.FromSlash("a/shared.h")); err != nil || string(got) != "#define SHARED 1\n" {
+		t.Errorf("a/shared.h = %q (%v), want it unchanged by copying over b/shared.h", got, err)
+	}
+}
+
+func TestPlaceContent_UnlinksHardLinkedTarget(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.go")
+	second := filepath.Join(dir, "b.go")
+	if err := os.WriteFile(first, []byte("one\ntwo\nthree\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(first, second); err != nil {
+		t.Skipf("hard links unsupported: %v", err)
+	}
+
+	if err := PlaceContent(second, "TWO", &types.PositionSpec{StartLine: 2, EndLine: 2}); err != nil {
+		t.Fatalf("PlaceContent: %v", err)
+	}
+	if got, _ := os.ReadFile(first); string(got) != "one\ntwo\nthree\n" {
+		t.Errorf("linked a.go = %q, want it untouched", got)
+	}
+	if got, _ := os.ReadFile(second); string(got) != "one\nTWO\nthree\n" {
+		t.Errorf("b.go = %q, want line 2 replaced", got)
+	}
+	if info, err := os.Stat(second); err != nil || info.Mode().Perm() != 0o755 {
+		t.Errorf("b.go mode = %v, %v; want 0755 kept", info.Mode().Perm(), err)
+	}
+}
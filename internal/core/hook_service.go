@@ -45,6 +45,10 @@ type HookExecutor interface {
 
 	// ExecutePostSync runs post-sync hook if configured
 	ExecutePostSync(vendor *types.VendorSpec, ctx *types.HookContext) error
+
+	// ExecuteVendorPostSync runs the vendor's post_sync command in dir and
+	// returns its combined output instead of printing it
+	ExecuteVendorPostSync(vendor *types.VendorSpec, dir string, ctx *types.HookContext) (string, error)
 }
 
 // hookService implements HookExecutor for shell command execution
@@ -89,9 +93,37 @@ func (h *hookService) ExecutePostSync(vendor *types.VendorSpec, ctx *types.HookC
 	return nil
 }
 
-// executeHook runs a shell command with environment context and timeout protection.
-// The command is killed after hookTimeout (5 minutes) to prevent indefinite hangs.
+// ExecuteVendorPostSync runs vendor.PostSync with dir as its working
+// directory. Output is returned rather than printed so the caller can record
+// it; a failure returns a HookError alongside whatever the command printed.
+func (h *hookService) ExecuteVendorPostSync(vendor *types.VendorSpec, dir string, ctx *types.HookContext) (string, error) {
+	if vendor.PostSync == "" {
+		return "", nil
+	}
+
+	output, err := h.runCommand(vendor.PostSync, dir, ctx)
+	if err != nil {
+		return string(output), NewHookError(vendor.Name, "post_sync", vendor.PostSync, err)
+	}
+	return string(output), nil
+}
+
+// executeHook runs a shell command in the hook context's root directory and
+// prints its output.
 func (h *hookService) executeHook(command string, hookCtx *types.HookContext) error {
+	output, err := h.runCommand(command, hookCtx.RootDir, hookCtx)
+
+	// Display output to user
+	if len(output) > 0 {
+		fmt.Print(string(output))
+	}
+	return err
+}
+
+// runCommand runs a shell command in dir with environment context and timeout
+// protection, returning its combined stdout and stderr. The command is killed
+// after hookTimeout (5 minutes) to prevent indefinite hangs.
+func (h *hookService) runCommand(command, dir string, hookCtx *types.HookContext) ([]byte, error) {
 	// Build environment variables
 	env := h.buildEnvironment(hookCtx)
 
@@ -109,25 +141,20 @@ func (h *hookService) executeHook(command string, hookCtx *types.HookContext) er
 		cmd = exec.CommandContext(ctx, "sh", "-c", command)
 	}
 	cmd.Env = env
-	cmd.Dir = hookCtx.RootDir
+	cmd.Dir = dir
 
 	// Capture both stdout and stderr
 	output, err := cmd.CombinedOutput()
 
-	// Display output to user
-	if len(output) > 0 {
-		fmt.Print(string(output))
-	}
-
 	if err != nil {
 		// Distinguish timeout from other failures for clearer error messages
 		if ctx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("hook timed out after %s: %w", h.timeout, err)
+			return output, fmt.Errorf("hook timed out after %s: %w", h.timeout, err)
 		}
-		return fmt.Errorf("hook failed: %w", err)
+		return output, fmt.Errorf("hook failed: %w", err)
 	}
 
-	return nil
+	return output, nil
 }
 
 // buildEnvironment creates environment variables for hook execution.
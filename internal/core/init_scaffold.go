@@ -0,0 +1,113 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InitOptions configures "git-vendor init".
+type InitOptions struct {
+	// Format is the config file format (ConfigFormatYAML or ConfigFormatTOML;
+	// "" keeps the config store's detected format).
+	Format string
+	// Gitignore appends gitignoreEntries to the project .gitignore (--gitignore).
+	Gitignore bool
+	// Readme writes a README.md into the vendor directory (--readme).
+	Readme bool
+}
+
+// gitignoreHeader introduces the entries init --gitignore appends.
+const gitignoreHeader = "# git-vendor temporary files"
+
+// gitignoreEntries returns the .gitignore patterns for files git-vendor
+// writes only while it works: the cache under rootDir (incremental sync
+// cache, copy checkpoints left by an interrupted directory copy) and
+// --hardlink temp links. vendorDir is rootDir relative to the project root, forward-slashed.
+func gitignoreEntries(vendorDir string) []string {
+	return []string{
+		"/" + vendorDir + "/" + CacheDir + "/",
+		"*.git-vendor-link",
+	}
+}
+
+// appendGitignore adds each of gitignoreEntries missing from the .gitignore
+// at the project root (rootDir's parent), creating the file if needed.
+// Running it again adds nothing. Returns the entries added.
+func (s *VendorSyncer) appendGitignore() ([]string, error) {
+	projectRoot := filepath.Dir(s.rootDir)
+	vendorDir, err := filepath.Rel(projectRoot, s.rootDir)
+	if err != nil {
+		vendorDir = filepath.Base(s.rootDir)
+	}
+	path := filepath.Join(projectRoot, ".gitignore")
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read .gitignore: %w", err)
+	}
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+
+	var added []string
+	for _, entry := range gitignoreEntries(filepath.ToSlash(vendorDir)) {
+		if !present[entry] {
+			added = append(added, entry)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	b.Write(existing)
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		b.WriteString("\n")
+	}
+	if !present[gitignoreHeader] {
+		if len(existing) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(gitignoreHeader + "\n")
+	}
+	for _, entry := range added {
+		b.WriteString(entry + "\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return nil, fmt.Errorf("write .gitignore: %w", err)
+	}
+	return added, nil
+}
+
+// vendorReadme is the README.md init --readme writes into the vendor directory.
+const vendorReadme = `# Vendored dependencies
+
+This directory is managed by [git-vendor](https://github.com/EmundoT/git-vendor).
+
+- ` + "`vendor.yml`" + ` (or ` + "`vendor.toml`" + `) declares each vendored repository
+  and which of its paths are copied where. Edit it with ` + "`git-vendor add`" + `,
+  ` + "`edit`" + ` and ` + "`remove`" + `, or by hand.
+- ` + "`vendor.lock`" + ` pins the exact commit and file hashes of every vendor. It is
+  written by ` + "`git-vendor pull`" + `; don't edit it by hand.
+- ` + "`licenses/`" + ` holds a copy of each vendor's license.
+
+Run ` + "`git-vendor pull`" + ` to fetch updates, ` + "`git-vendor sync`" + ` to restore files
+from the lock, and ` + "`git-vendor status`" + ` to check vendored files for drift.
+`
+
+// writeReadme writes vendorReadme to rootDir/README.md unless a README.md is
+// already there. Reports whether it was written.
+func (s *VendorSyncer) writeReadme() (bool, error) {
+	path := filepath.Join(s.rootDir, "README.md")
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+	if err := os.WriteFile(path, []byte(vendorReadme), 0644); err != nil {
+		return false, fmt.Errorf("write README: %w", err)
+	}
+	return true, nil
+}
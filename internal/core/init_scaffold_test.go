@@ -0,0 +1,72 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newInitTestSyncer(t *testing.T) (*VendorSyncer, string) {
+	t.Helper()
+	projectRoot := t.TempDir()
+	rootDir := filepath.Join(projectRoot, VendorDir)
+	return &VendorSyncer{configStore: NewFileConfigStore(rootDir), fs: NewOSFileSystem(), rootDir: rootDir, ui: &SilentUICallback{}}, projectRoot
+}
+
+func TestInitWithOptions_GitignoreAppendIsIdempotent(t *testing.T) {
+	syncer, projectRoot := newInitTestSyncer(t)
+	gitignore := filepath.Join(projectRoot, ".gitignore")
+	if err := os.WriteFile(gitignore, []byte("bin/\n/.git-vendor/.cache/"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := syncer.InitWithOptions(InitOptions{Gitignore: true}); err != nil {
+			t.Fatalf("InitWithOptions #%d: %v", i+1, err)
+		}
+	}
+
+	got, err := os.ReadFile(gitignore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "bin/\n/.git-vendor/.cache/\n\n" + gitignoreHeader + "\n*.git-vendor-link\n"
+	if string(got) != want {
+		t.Errorf(".gitignore = %q, want %q", got, want)
+	}
+}
+
+func TestInitWithOptions_Readme(t *testing.T) {
+	syncer, _ := newInitTestSyncer(t)
+	readme := filepath.Join(syncer.rootDir, "README.md")
+
+	if err := syncer.InitWithOptions(InitOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(readme); !os.IsNotExist(err) {
+		t.Fatalf("plain init should not write README.md (stat err %v)", err)
+	}
+
+	if err := syncer.InitWithOptions(InitOptions{Readme: true}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(readme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), "managed by [git-vendor]") {
+		t.Errorf("README.md = %q, want the git-vendor explanation", got)
+	}
+
+	// An existing README is the team's; init keeps it
+	if err := os.WriteFile(readme, []byte("ours\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := syncer.InitWithOptions(InitOptions{Readme: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(readme); string(got) != "ours\n" {
+		t.Errorf("README.md = %q, want the existing content kept", got)
+	}
+}
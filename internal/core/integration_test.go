@@ -105,7 +105,7 @@ func TestIntegration_GitOperations_Clone(t *testing.T) {
 	}
 
 	// Test: clone with filter=blob:none and depth=1
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	opts := &types.CloneOptions{
 		Filter:     "blob:none",
 		Depth:      1,
@@ -149,7 +149,7 @@ func TestIntegration_GitOperations_ListTree(t *testing.T) {
 	runGitOutput(t, repo, "commit", "-m", "Add files")
 
 	// Test: ListTree at root
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	items, err := gitClient.ListTree(context.Background(), repo, "HEAD", "")
 
 	// Verify root listing
@@ -202,7 +202,7 @@ copies of the Software.`
 
 	// Test: FallbackLicenseChecker detects MIT
 	fs := NewOSFileSystem()
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	checker := NewFallbackLicenseChecker(fs, gitClient)
 
 	repoURL := "file://" + repo
@@ -234,7 +234,7 @@ TERMS AND CONDITIONS FOR USE, REPRODUCTION, AND DISTRIBUTION`
 
 	// Test: FallbackLicenseChecker detects Apache-2.0
 	fs := NewOSFileSystem()
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	checker := NewFallbackLicenseChecker(fs, gitClient)
 
 	repoURL := "file://" + repo
@@ -269,7 +269,7 @@ func TestIntegration_GitOperations_Fetch(t *testing.T) {
 	destRepo := createTestRepository(t, "fetch-dest")
 	repoURL := "file://" + srcRepo
 
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 
 	// Test: Init, AddRemote, Fetch
 	err := gitClient.Init(context.Background(), destRepo)
@@ -311,7 +311,7 @@ func TestIntegration_GitOperations_Checkout(t *testing.T) {
 	runGitOutput(t, repo, "add", ".")
 	runGitOutput(t, repo, "commit", "-m", "Second")
 
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 
 	// Test: Checkout first commit
 	err := gitClient.Checkout(context.Background(), repo, firstHash)
@@ -348,7 +348,7 @@ func TestIntegration_GitOperations_GetHeadHash(t *testing.T) {
 	expectedHash := getCommitHash(t, repo)
 
 	// Test: GetHeadHash
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	hash, err := gitClient.GetHeadHash(context.Background(), repo)
 
 	// Verify
@@ -155,7 +155,16 @@ func (s *InternalSyncService) syncInternalMapping(vendorName string, mapping typ
 
 	// Position extraction mode
 	if srcPos != nil {
-		content, hash, extractErr := ExtractPosition(srcPath, srcPos)
+		resolved, resolveErr := ResolvePosition(srcPath, srcPos)
+		if resolveErr != nil {
+			return CopyStats{}, "", fmt.Errorf("extract position from %s: %w", srcFile, resolveErr)
+		}
+		sourceLines := ""
+		if srcPos.IsAnchored() {
+			sourceLines = lineRange(resolved)
+		}
+
+		content, hash, extractErr := ExtractPosition(srcPath, resolved)
 		if extractErr != nil {
 			return CopyStats{}, "", fmt.Errorf("extract position from %s: %w", srcFile, extractErr)
 		}
@@ -173,9 +182,10 @@ func (s *InternalSyncService) syncInternalMapping(vendorName string, mapping typ
 			FileCount: 1,
 			ByteCount: int64(len(content)),
 			Positions: []positionRecord{{
-				From:       mapping.From,
-				To:         mapping.To,
-				SourceHash: hash,
+				From:        mapping.From,
+				To:          mapping.To,
+				SourceHash:  hash,
+				SourceLines: sourceLines,
 			}},
 		}
 		return stats, srcHash, nil
@@ -48,8 +48,12 @@ func (c *GitLabAPIChecker) CheckLicense(repoURL string) (string, error) {
 	}
 
 	// Add authentication if token available
-	if c.token != "" {
-		req.Header.Set("PRIVATE-TOKEN", c.token)
+	token := c.token
+	if token == "" {
+		token = ResolveGitToken(apiHost)
+	}
+	if token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
 	}
 
 	resp, err := c.httpClient.Do(req)
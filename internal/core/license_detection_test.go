@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -402,3 +404,30 @@ func TestMultiPlatformChecker_IsAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiPlatformChecker_EnterpriseHostUsesGitHubAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/platform/widgets/license" {
+			t.Errorf("unexpected API path %q", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"license": {"spdx_id": "BSD-3-Clause"}}`))
+	}))
+	defer server.Close()
+
+	// No fs/git expectations: the enterprise API answers, so the clone fallback never runs
+	checker := NewMultiPlatformLicenseChecker(providers.NewProviderRegistry(), NewMockFileSystem(ctrl), NewMockGitClient(ctrl), nil)
+	checker.githubChecker = NewGitHubLicenseChecker(server.Client(), nil)
+	checker.githubChecker.SetAPIBase("ghe.example.com", server.URL+"/api/v3")
+
+	license, err := checker.CheckLicense("https://ghe.example.com/platform/widgets")
+	if err != nil {
+		t.Fatalf("CheckLicense() error = %v", err)
+	}
+	if license != "BSD-3-Clause" {
+		t.Errorf("CheckLicense() = %q, want BSD-3-Clause", license)
+	}
+}
@@ -33,7 +33,7 @@ func NewMultiPlatformLicenseChecker(
 // CheckLicense detects license using platform-specific API or fallback
 //
 // Strategy:
-//  1. Detect provider from URL (GitHub, GitLab, Bitbucket, or generic)
+//  1. Detect provider from URL (GitHub incl. Enterprise, GitLab, Bitbucket, or generic)
 //  2. Try platform-specific API if available (GitHub, GitLab)
 //  3. If API fails or unavailable, fall back to reading LICENSE file
 //  4. Return normalized SPDX license identifier
@@ -44,7 +44,14 @@ func (c *MultiPlatformLicenseChecker) CheckLicense(url string) (string, error) {
 	var license string
 	var err error
 
-	switch provider.Name() {
+	// GitHub Enterprise hosts (GIT_VENDOR_GITHUB_API_URL) don't match the
+	// github.com provider but are served by the same API
+	providerName := provider.Name()
+	if providerName == "generic" && c.githubChecker.Handles(url) {
+		providerName = "github"
+	}
+
+	switch providerName {
 	case "github":
 		// Try GitHub API
 		license, err = c.githubChecker.CheckLicense(url)
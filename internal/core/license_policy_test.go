@@ -667,6 +667,9 @@ func TestCheckCompliance_PolicyDeniesLicense(t *testing.T) {
 	if !errors.Is(err, ErrComplianceFailed) {
 		t.Errorf("expected ErrComplianceFailed, got %v", err)
 	}
+	if !errors.Is(err, ErrLicenseNotAllowed) {
+		t.Errorf("expected ErrLicenseNotAllowed, got %v", err)
+	}
 	if result != "" {
 		t.Errorf("expected empty result for denied license, got %q", result)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
@@ -13,8 +14,9 @@ import (
 // LicenseServiceInterface enables mocking in tests and alternative license backends.
 type LicenseServiceInterface interface {
 	CheckCompliance(url string) (string, error)
-	CopyLicense(tempDir, vendorName string) error
-	GetLicensePath(vendorName string) string
+	AcceptOverride(license string) (string, error)
+	CopyLicense(tempDir, vendorName, licenseDir string, fileNames []string) ([]string, error)
+	GetLicensePath(vendorName, licenseDir string) string
 	CheckLicense(url string) (string, error)
 }
 
@@ -53,7 +55,12 @@ func (s *LicenseService) CheckCompliance(url string) (string, error) {
 		// If detection failed, use UNKNOWN
 		detectedLicense = "UNKNOWN"
 	}
+	return s.evaluateLicense(detectedLicense)
+}
 
+// evaluateLicense applies CheckCompliance's policy file or allowed-list
+// check to a license that is already known.
+func (s *LicenseService) evaluateLicense(license string) (string, error) {
 	// Check if a policy file exists on disk (not a heuristic — actual stat)
 	_, statErr := os.Stat(PolicyFile)
 	if statErr == nil {
@@ -62,25 +69,37 @@ func (s *LicenseService) CheckCompliance(url string) (string, error) {
 		if policyErr != nil {
 			return "", fmt.Errorf("license policy error: %w", policyErr)
 		}
-		return s.checkWithPolicy(detectedLicense, &policy)
+		return s.checkWithPolicy(license, &policy)
 	}
 	if !errors.Is(statErr, os.ErrNotExist) {
 		return "", fmt.Errorf("check policy file: %w", statErr)
 	}
 
 	// No policy file — legacy AllowedLicenses check
-	if !s.licenseChecker.IsAllowed(detectedLicense) {
+	if !s.licenseChecker.IsAllowed(license) {
 		if !s.ui.AskConfirmation(
-			fmt.Sprintf("Accept %s License?", detectedLicense),
+			fmt.Sprintf("Accept %s License?", license),
 			"This license is not in the allowed list. Continue anyway?",
 		) {
-			return "", ErrComplianceFailed
+			return "", fmt.Errorf("%w: %w: %s", ErrComplianceFailed, ErrLicenseNotAllowed, license)
 		}
 	} else {
-		s.ui.ShowLicenseCompliance(detectedLicense)
+		s.ui.ShowLicenseCompliance(license)
 	}
 
-	return detectedLicense, nil
+	return license, nil
+}
+
+// AcceptOverride accepts a vendor's declared license_override without
+// detecting the license from the hosting platform. The declared license goes
+// through the same policy file or allowed_licenses check as a detected one
+// (see CheckCompliance). Returns an error when license is not a recognized
+// SPDX identifier.
+func (s *LicenseService) AcceptOverride(license string) (string, error) {
+	if !IsKnownSPDXLicense(license) {
+		return "", fmt.Errorf("license_override %q is not a recognized SPDX license identifier", license)
+	}
+	return s.evaluateLicense(license)
 }
 
 // checkWithPolicy evaluates a license using the policy file's deny/warn/allow semantics.
@@ -93,7 +112,7 @@ func (s *LicenseService) checkWithPolicy(license string, policy *types.LicensePo
 	case types.PolicyDeny:
 		s.ui.ShowError("License Denied",
 			fmt.Sprintf("%s is denied by license policy (%s)", license, PolicyFile))
-		return "", ErrComplianceFailed
+		return "", fmt.Errorf("%w: %w: %s", ErrComplianceFailed, ErrLicenseNotAllowed, license)
 
 	case types.PolicyWarn:
 		if !s.ui.AskConfirmation(
@@ -110,49 +129,156 @@ func (s *LicenseService) checkWithPolicy(license string, policy *types.LicensePo
 	}
 }
 
-// CopyLicense copies license file from temp repo to .git-vendor/licenses.
+// CopyLicense copies the license and notice files at the root of tempDir to
+// licenseDir (empty = .git-vendor/licenses; see ResolveLicenseDir for license_dir).
+// fileNames lists the root filenames to capture in priority order (empty =
+// LicenseCaptureFiles; see ResolveLicenseFiles). The first one present is the
+// primary license, copied to <vendor>.txt; every other present file is copied
+// to <vendor>.<filename> (e.g. lib.NOTICE), retaining the NOTICE files
+// Apache-2.0 requires. Returns the copied destinations, primary first; none
+// present returns nil without error (license files are optional).
 // Validates vendorName to prevent path traversal via malicious vendor.yml entries.
-func (s *LicenseService) CopyLicense(tempDir, vendorName string) error {
+func (s *LicenseService) CopyLicense(tempDir, vendorName, licenseDir string, fileNames []string) ([]string, error) {
 	// SEC-001: Validate vendorName before constructing filesystem path.
 	// Without this check, a malicious vendor.yml with name: "../../../etc/cron.d/evil"
 	// would write the license file outside the project directory.
 	if err := ValidateVendorName(vendorName); err != nil {
-		return fmt.Errorf("license copy blocked: %w", err)
+		return nil, fmt.Errorf("license copy blocked: %w", err)
+	}
+	if len(fileNames) == 0 {
+		fileNames = LicenseCaptureFiles
 	}
 
-	// Find license file in temp directory
-	var licenseSrc string
-	for _, name := range LicenseFileNames {
-		path := filepath.Join(tempDir, name)
-		if _, err := s.fs.Stat(path); err == nil {
-			licenseSrc = path
-			break
+	// Find license and notice files in temp directory
+	var found []string
+	for _, name := range fileNames {
+		if err := validateLicenseFileName(name); err != nil {
+			return nil, fmt.Errorf("license copy blocked: %w", err)
+		}
+		if info, err := s.fs.Stat(filepath.Join(tempDir, name)); err == nil && !info.IsDir() {
+			found = append(found, name)
 		}
 	}
 
 	// If no license file found, return without error (optional license)
-	if licenseSrc == "" {
-		return nil
+	if len(found) == 0 {
+		return nil, nil
 	}
 
 	// Ensure license directory exists
-	licenseDir := filepath.Join(s.rootDir, LicensesDir)
+	if licenseDir == "" {
+		licenseDir = filepath.Join(s.rootDir, LicensesDir)
+	}
 	if err := s.fs.MkdirAll(licenseDir, 0755); err != nil {
-		return fmt.Errorf("CopyLicense: create license directory: %w", err)
+		return nil, fmt.Errorf("CopyLicense: create license directory: %w", err)
 	}
 
-	// Copy license file
-	dest := filepath.Join(licenseDir, vendorName+".txt")
-	if _, err := s.fs.CopyFile(licenseSrc, dest); err != nil {
-		return fmt.Errorf("failed to copy license from %s to %s: %w", licenseSrc, dest, err)
+	// Copy license files, primary first
+	copied := make([]string, 0, len(found))
+	for i, name := range found {
+		src := filepath.Join(tempDir, name)
+		dest := filepath.Join(licenseDir, licenseCopyName(vendorName, name, i == 0))
+		if _, err := s.fs.CopyFile(src, dest); err != nil {
+			return copied, fmt.Errorf("failed to copy license from %s to %s: %w", src, dest, err)
+		}
+		copied = append(copied, filepath.ToSlash(dest))
+	}
+
+	return copied, nil
+}
+
+// licenseCopyName returns the license-directory filename for a captured
+// repository file: <vendor>.txt for the primary license, <vendor>.<file> otherwise.
+func licenseCopyName(vendorName, fileName string, primary bool) string {
+	if primary {
+		return vendorName + ".txt"
 	}
+	return vendorName + "." + fileName
+}
+
+// vendorLicenseCopies returns every path in licenseDir that CopyLicense may
+// have written for vendorName under the capture list fileNames: the primary
+// <vendor>.txt followed by one <vendor>.<file> per entry. Used to remove and
+// rename a vendor's license copies.
+func vendorLicenseCopies(licenseDir, vendorName string, fileNames []string) []string {
+	paths := []string{filepath.Join(licenseDir, licenseCopyName(vendorName, "", true))}
+	for _, name := range fileNames {
+		paths = append(paths, filepath.Join(licenseDir, licenseCopyName(vendorName, name, false)))
+	}
+	return paths
+}
 
+// validateLicenseFileName rejects license_files entries that are not plain
+// repository-root filenames, so a capture entry cannot read or write through
+// path separators.
+func validateLicenseFileName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("license file %q must be a plain filename at the repository root", name)
+	}
 	return nil
 }
 
-// GetLicensePath returns the path to a vendor's license file
-func (s *LicenseService) GetLicensePath(vendorName string) string {
-	return filepath.Join(s.rootDir, LicensesDir, vendorName+".txt")
+// GetLicensePath returns the path to a vendor's license file in licenseDir
+// (ResolveLicenseDir); an empty licenseDir means <rootDir>/licenses, as in
+// CopyLicense.
+func (s *LicenseService) GetLicensePath(vendorName, licenseDir string) string {
+	if licenseDir == "" {
+		licenseDir = filepath.Join(s.rootDir, LicensesDir)
+	}
+	return filepath.Join(licenseDir, vendorName+".txt")
+}
+
+// ResolveAllowedLicenses returns the licenses accepted without confirmation:
+// config.AllowedLicenses (vendor.yml allowed_licenses) when set, otherwise
+// the built-in AllowedLicenses. The config list replaces the default rather
+// than extending it, so a policy may ban licenses the default allows.
+func ResolveAllowedLicenses(config types.VendorConfig) []string {
+	if len(config.AllowedLicenses) > 0 {
+		return config.AllowedLicenses
+	}
+	return AllowedLicenses
+}
+
+// ResolveVendorLicense returns the license recorded for v in the lock's
+// license_spdx: its license_override when declared, otherwise the license
+// detected at add time.
+func ResolveVendorLicense(v types.VendorSpec) string {
+	if v.LicenseOverride != "" {
+		return v.LicenseOverride
+	}
+	return v.License
+}
+
+// ResolveLicenseFiles returns the repository-root filenames CopyLicense
+// captures: config.LicenseFiles (vendor.yml license_files) when set, otherwise
+// LicenseCaptureFiles.
+func ResolveLicenseFiles(config types.VendorConfig) []string {
+	if len(config.LicenseFiles) > 0 {
+		return config.LicenseFiles
+	}
+	return LicenseCaptureFiles
+}
+
+// IsKnownSPDXLicense reports whether id is one of KnownSPDXLicenses,
+// ignoring case.
+func IsKnownSPDXLicense(id string) bool {
+	for _, known := range KnownSPDXLicenses {
+		if strings.EqualFold(id, known) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveLicenseDir returns the directory holding vendor license copies.
+// rootDir is the vendor directory (.git-vendor). An empty config.LicenseDir
+// keeps the default <rootDir>/licenses; otherwise license_dir is resolved
+// relative to the project root (the parent of rootDir), e.g. "legal".
+func ResolveLicenseDir(rootDir string, config types.VendorConfig) string {
+	if config.LicenseDir == "" {
+		return filepath.Join(rootDir, LicensesDir)
+	}
+	return filepath.Join(filepath.Dir(rootDir), filepath.Clean(config.LicenseDir))
 }
 
 // CheckLicense checks the license for a URL (delegates to checker)
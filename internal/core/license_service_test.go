@@ -3,7 +3,12 @@ package core
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
 )
 
 // ============================================================================
@@ -40,6 +45,57 @@ func TestIsLicenseAllowed(t *testing.T) {
 	}
 }
 
+// TestIsLicenseAllowed_ConfigOverride verifies that a vendor.yml
+// allowed_licenses list reaches the license checker built by NewManager and
+// replaces the built-in list: it can permit GPL-3.0 and deny MIT.
+func TestIsLicenseAllowed_ConfigOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		license string
+		want    bool
+	}{
+		{"override permits GPL-3.0", []string{"GPL-3.0", "MIT"}, "GPL-3.0", true},
+		{"override denies MIT", []string{"Apache-2.0"}, "MIT", false},
+		{"override keeps listed license", []string{"Apache-2.0"}, "Apache-2.0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			oldDir, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(tmpDir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(oldDir) //nolint:errcheck
+
+			if err := os.MkdirAll(VendorDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := NewFileConfigStore(VendorDir).Save(types.VendorConfig{AllowedLicenses: tt.allowed}); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := NewManager().isLicenseAllowed(tt.license); got != tt.want {
+				t.Errorf("isLicenseAllowed(%q) with allowed_licenses %v = %v, want %v", tt.license, tt.allowed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveAllowedLicenses(t *testing.T) {
+	if got := ResolveAllowedLicenses(types.VendorConfig{}); len(got) != len(AllowedLicenses) {
+		t.Errorf("ResolveAllowedLicenses(empty config) = %v, want the built-in AllowedLicenses", got)
+	}
+	override := []string{"LGPL-3.0"}
+	if got := ResolveAllowedLicenses(types.VendorConfig{AllowedLicenses: override}); len(got) != 1 || got[0] != "LGPL-3.0" {
+		t.Errorf("ResolveAllowedLicenses(override) = %v, want %v", got, override)
+	}
+}
+
 // ============================================================================
 // GitHub License Checker Tests
 // ============================================================================
@@ -169,3 +225,103 @@ func TestCheckCompliance_AllowedLicenseShowsCompliance(t *testing.T) {
 		t.Errorf("Expected ShowLicenseCompliance('MIT'), got '%s'", mockUI.licenseMsg)
 	}
 }
+
+func TestAcceptOverride_UnlistedLicenseAsksConfirmation(t *testing.T) {
+	ctrl, _, fs, _, _, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	// Mock: the declared license is outside allowed_licenses; no detection runs
+	license.EXPECT().IsAllowed("GPL-3.0").Return(false)
+
+	// Mock: User rejects the license
+	mockUI := &capturingUICallback{confirmResp: false}
+	licenseService := NewLicenseService(license, fs, "vendor", mockUI)
+
+	accepted, err := licenseService.AcceptOverride("GPL-3.0")
+	if !errors.Is(err, ErrLicenseNotAllowed) {
+		t.Fatalf("Expected ErrLicenseNotAllowed for a rejected override, got %v", err)
+	}
+	if accepted != "" {
+		t.Errorf("Expected empty license on rejection, got '%s'", accepted)
+	}
+}
+
+// ============================================================================
+// License Directory Tests - license_dir override
+// ============================================================================
+
+func TestResolveLicenseDir(t *testing.T) {
+	rootDir := filepath.Join("project", VendorDir)
+
+	if got, want := ResolveLicenseDir(rootDir, types.VendorConfig{}), filepath.Join(rootDir, LicensesDir); got != want {
+		t.Errorf("default ResolveLicenseDir = %q, want %q", got, want)
+	}
+	got := ResolveLicenseDir(rootDir, types.VendorConfig{LicenseDir: "third_party/licenses/"})
+	if want := filepath.Join("project", "third_party", "licenses"); got != want {
+		t.Errorf("custom ResolveLicenseDir = %q, want %q", got, want)
+	}
+}
+
+func TestCopyLicense_CustomLicenseDir(t *testing.T) {
+	workDir := t.TempDir()
+	rootDir := filepath.Join(workDir, VendorDir)
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "LICENSE"), []byte("MIT License\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewLicenseService(nil, NewOSFileSystem(), rootDir, &SilentUICallback{})
+	licenseDir := ResolveLicenseDir(rootDir, types.VendorConfig{LicenseDir: "legal"})
+	if _, err := svc.CopyLicense(tempDir, "lib-a", licenseDir, nil); err != nil {
+		t.Fatalf("CopyLicense: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(workDir, "legal", "lib-a.txt")); err != nil {
+		t.Errorf("expected license under custom license_dir: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, LicensesDir, "lib-a.txt")); !os.IsNotExist(err) {
+		t.Error("license must not be written to the default location when license_dir is set")
+	}
+}
+
+func TestCopyLicense_CopiesLicenseAndNotice(t *testing.T) {
+	rootDir := filepath.Join(t.TempDir(), VendorDir)
+	tempDir := t.TempDir()
+	for name, content := range map[string]string{
+		"LICENSE": "Apache License\nVersion 2.0\n",
+		"NOTICE":  "Example Project\nCopyright 2024 Example Corp\n",
+	} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := NewLicenseService(nil, NewOSFileSystem(), rootDir, &SilentUICallback{})
+	licenseDir := filepath.Join(rootDir, LicensesDir)
+	copied, err := svc.CopyLicense(tempDir, "lib-a", licenseDir, nil)
+	if err != nil {
+		t.Fatalf("CopyLicense: %v", err)
+	}
+
+	want := []string{
+		filepath.ToSlash(filepath.Join(licenseDir, "lib-a.txt")),
+		filepath.ToSlash(filepath.Join(licenseDir, "lib-a.NOTICE")),
+	}
+	if len(copied) != len(want) || copied[0] != want[0] || copied[1] != want[1] {
+		t.Errorf("copied = %v, want %v", copied, want)
+	}
+	notice, err := os.ReadFile(filepath.Join(licenseDir, "lib-a.NOTICE"))
+	if err != nil || !strings.Contains(string(notice), "Example Corp") {
+		t.Errorf("NOTICE copy = %q, %v; want the upstream NOTICE content", notice, err)
+	}
+	if _, err := os.Stat(filepath.Join(licenseDir, "lib-a.txt")); err != nil {
+		t.Errorf("expected primary license copy: %v", err)
+	}
+}
+
+func TestCopyLicense_RejectsPathInLicenseFiles(t *testing.T) {
+	svc := NewLicenseService(nil, NewOSFileSystem(), t.TempDir(), &SilentUICallback{})
+	if _, err := svc.CopyLicense(t.TempDir(), "lib-a", "", []string{"../NOTICE"}); err == nil {
+		t.Error("expected error for a license_files entry containing a path")
+	}
+}
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ListFormatYAML is the "list --format yaml" output: vendor.yml merged with
+// vendor.lock.
+const ListFormatYAML = "yaml"
+
+// ListDocument is vendor.yml annotated with what vendor.lock records for each
+// spec. It carries every config key, so the document loads back as a config;
+// the "locked" blocks are ignored on load.
+type ListDocument struct {
+	types.VendorConfig              // Top-level config keys; its Vendors is unused
+	Vendors            []ListVendor // Written in place of the config's vendors
+}
+
+// ListVendor is one vendor of a ListDocument: the vendor.yml entry with its
+// specs annotated.
+type ListVendor struct {
+	types.VendorSpec            // Vendor keys; its Specs is unused
+	Specs            []ListSpec // Written in place of the vendor's specs
+}
+
+// ListSpec is one ref of a ListVendor. Mapping destinations are resolved:
+// an empty "to" is replaced by the auto-named path sync writes.
+type ListSpec struct {
+	types.BranchSpec
+	Locked *ListLock // nil: the spec has no vendor.lock entry
+}
+
+// MarshalYAML encodes the config with its vendors replaced by d.Vendors.
+func (d ListDocument) MarshalYAML() (interface{}, error) {
+	return encodeWithKey(d.VendorConfig, "vendors", d.Vendors)
+}
+
+// UnmarshalYAML decodes the config keys and the annotated vendors.
+func (d *ListDocument) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&d.VendorConfig); err != nil {
+		return err
+	}
+	return decodeKey(value, "vendors", &d.Vendors)
+}
+
+// MarshalYAML encodes the vendor with its specs replaced by v.Specs.
+func (v ListVendor) MarshalYAML() (interface{}, error) {
+	return encodeWithKey(v.VendorSpec, "specs", v.Specs)
+}
+
+// UnmarshalYAML decodes the vendor keys and the annotated specs.
+func (v *ListVendor) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&v.VendorSpec); err != nil {
+		return err
+	}
+	return decodeKey(value, "specs", &v.Specs)
+}
+
+// MarshalYAML encodes the spec as vendor.yml does, plus its "locked" block.
+func (s ListSpec) MarshalYAML() (interface{}, error) {
+	if s.Locked == nil {
+		return s.BranchSpec, nil
+	}
+	return encodeWithKey(s.BranchSpec, "locked", s.Locked)
+}
+
+// UnmarshalYAML decodes the spec and its "locked" block.
+func (s *ListSpec) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&s.BranchSpec); err != nil {
+		return err
+	}
+	return decodeKey(value, "locked", &s.Locked)
+}
+
+// encodeWithKey encodes base as a YAML mapping and sets key to value,
+// replacing the value base wrote for key or appending the key.
+func encodeWithKey(base interface{}, key string, value interface{}) (*yaml.Node, error) {
+	var node, valueNode yaml.Node
+	if err := node.Encode(base); err != nil {
+		return nil, err
+	}
+	if err := valueNode.Encode(value); err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = &valueNode
+			return &node, nil
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &valueNode)
+	return &node, nil
+}
+
+// decodeKey decodes the value of key in the YAML mapping node into out. A
+// missing key leaves out unchanged.
+func decodeKey(node *yaml.Node, key string, out interface{}) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Decode(out)
+		}
+	}
+	return nil
+}
+
+// ListLock is the vendor.lock state of a ListSpec.
+type ListLock struct {
+	Commit       string `yaml:"commit"`
+	VersionTag   string `yaml:"version_tag,omitempty"`
+	LicenseSPDX  string `yaml:"license_spdx,omitempty"`
+	LastSyncedAt string `yaml:"last_synced_at,omitempty"`
+	SourceURL    string `yaml:"source_url,omitempty"`
+}
+
+// ListYAML returns the config and lock merged into a ListDocument, encoded as
+// YAML. Values loaded from ${VAR} references are written as in vendor.yml, so
+// expanded secrets stay out of the output. The lock is best effort: without
+// one, specs carry no "locked" block.
+func (s *VendorSyncer) ListYAML() ([]byte, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		lock = types.VendorLock{}
+	}
+	data, err := yaml.Marshal(BuildListDocument(RestoreConfigEnv(config), lock))
+	if err != nil {
+		return nil, fmt.Errorf("marshal list: %w", err)
+	}
+	return data, nil
+}
+
+// BuildListDocument merges config and lock into a ListDocument.
+func BuildListDocument(config types.VendorConfig, lock types.VendorLock) ListDocument {
+	doc := ListDocument{VendorConfig: config, Vendors: make([]ListVendor, 0, len(config.Vendors))}
+	doc.VendorConfig.Vendors = nil
+	for i := range config.Vendors {
+		v := &config.Vendors[i]
+		lv := ListVendor{VendorSpec: *v, Specs: make([]ListSpec, 0, len(v.Specs))}
+		lv.VendorSpec.Specs = nil
+		for _, spec := range v.Specs {
+			ls := ListSpec{BranchSpec: spec}
+			ls.Mapping = make([]types.PathMapping, 0, len(spec.Mapping))
+			for _, mapping := range spec.Mapping {
+				// computeDestPath keeps a position suffix, which "to" may carry
+				mapping.To = filepath.ToSlash((&FileCopyService{}).computeDestPath(mapping, spec, v))
+				ls.Mapping = append(ls.Mapping, mapping)
+			}
+			if entry := findLockEntry(lock, v.Name, spec.Ref); entry != nil {
+				ls.Locked = &ListLock{
+					Commit:       entry.CommitHash,
+					VersionTag:   entry.SourceVersionTag,
+					LicenseSPDX:  entry.LicenseSPDX,
+					LastSyncedAt: entry.LastSyncedAt,
+					SourceURL:    entry.SourceURL,
+				}
+			}
+			lv.Specs = append(lv.Specs, ls)
+		}
+		doc.Vendors = append(doc.Vendors, lv)
+	}
+	return doc
+}
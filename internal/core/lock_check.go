@@ -0,0 +1,117 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// CheckLock reports how vendor.lock disagrees with vendor.yml, without hashing
+// files or contacting a remote:
+//   - stale: a config vendor@ref with no lock entry, or a mapped destination
+//     its lock entry does not record (verify's coherence "stale")
+//   - orphaned: a lock entry for a vendor@ref no longer in config, or a lock
+//     FileHashes path no mapping produces (verify's coherence "orphaned")
+//
+// Issues are sorted by vendor, ref, then path.
+func (s *VendorSyncer) CheckLock() (*types.LockCheckResult, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	config = expandRecursiveConfig(config)
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+
+	result := &types.LockCheckResult{Issues: []types.LockCheckIssue{}}
+
+	configRefs := make(map[string]bool)
+	for _, v := range config.Vendors {
+		for _, spec := range v.Specs {
+			configRefs[v.Name+"@"+spec.Ref] = true
+		}
+	}
+	lockRefs := make(map[string]bool, len(lock.Vendors))
+	for _, l := range lock.Vendors {
+		lockRefs[l.Name+"@"+l.Ref] = true
+		if !configRefs[l.Name+"@"+l.Ref] {
+			result.Issues = append(result.Issues, types.LockCheckIssue{
+				Vendor: l.Name, Ref: l.Ref, Status: "orphaned",
+				Message: fmt.Sprintf("locked but %s has no such vendor@ref", s.configStore.Path()),
+			})
+		}
+	}
+	for _, v := range config.Vendors {
+		for _, spec := range v.Specs {
+			if !lockRefs[v.Name+"@"+spec.Ref] {
+				result.Issues = append(result.Issues, types.LockCheckIssue{
+					Vendor: v.Name, Ref: spec.Ref, Status: "stale",
+					Message: fmt.Sprintf("in %s but has no lock entry", s.configStore.Path()),
+				})
+			}
+		}
+	}
+
+	var coherence types.VerifyResult
+	detectCoherenceIssues(config, lock, &coherence)
+	for _, f := range coherence.Files {
+		issue := types.LockCheckIssue{Path: f.Path, Status: f.Status}
+		if f.Vendor != nil {
+			issue.Vendor = *f.Vendor
+		}
+		if f.Status == "orphaned" {
+			issue.Message = "locked but no mapping produces it"
+		} else {
+			issue.Message = "mapped but not recorded in the lock"
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+
+	sort.Slice(result.Issues, func(i, j int) bool {
+		a, b := result.Issues[i], result.Issues[j]
+		if a.Vendor != b.Vendor {
+			return a.Vendor < b.Vendor
+		}
+		if a.Ref != b.Ref {
+			return a.Ref < b.Ref
+		}
+		return a.Path < b.Path
+	})
+	for _, issue := range result.Issues {
+		if issue.Status == "orphaned" {
+			result.Summary.Orphaned++
+		} else {
+			result.Summary.Stale++
+		}
+	}
+	result.Summary.Result = "PASS"
+	if len(result.Issues) > 0 {
+		result.Summary.Result = "FAIL"
+	}
+	return result, nil
+}
+
+// RegenerateLock rebuilds vendor.lock from vendor.yml: every vendor is
+// re-fetched at its config ref and re-synced, and the lock is rewritten with
+// the resolved commits and the hashes of the files just written. The existing
+// lock only contributes VendoredAt/VendoredBy, the license-change warning and,
+// unless opts.IncludePinned, the entries of pinned specs; it may be missing or
+// unreadable, and its other entries are replaced wholesale.
+func (s *VendorSyncer) RegenerateLock(ctx context.Context, opts UpdateOptions) error {
+	return s.update.UpdateAllWithOptions(ctx, opts)
+}
+
+// RehashLock rewrites vendor.lock with a fresh checksum, accepting its current
+// content as is: the fix after editing the lock by hand, such as resolving a
+// merge conflict in it.
+func (s *VendorSyncer) RehashLock() error {
+	store, ok := s.lockStore.(interface{ Rehash() error })
+	if !ok {
+		return fmt.Errorf("lock store does not support rehashing")
+	}
+	return store.Rehash()
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCheckLock_ReportsStaleAndOrphaned(t *testing.T) {
+	config := types.VendorConfig{Vendors: []types.VendorSpec{
+		{Name: "alpha", URL: "https://github.com/owner/alpha", Specs: []types.BranchSpec{
+			{Ref: "main", Mapping: []types.PathMapping{
+				{From: "a.go", To: "lib/a.go"},
+				{From: "b.go", To: "lib/b.go"},
+			}},
+			{Ref: "v2", Mapping: []types.PathMapping{{From: "a.go", To: "lib/v2/a.go"}}},
+		}},
+	}}
+	lock := types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "alpha", Ref: "main", CommitHash: "aaaa", FileHashes: map[string]string{
+			"lib/a.go":    "h1",
+			"lib/gone.go": "h2",
+		}},
+		{Name: "removed", Ref: "main", CommitHash: "bbbb"},
+	}}
+
+	syncer := &VendorSyncer{
+		configStore: &stubConfigStore{config: config},
+		lockStore:   &stubLockStore{lock: lock},
+	}
+	result, err := syncer.CheckLock()
+	if err != nil {
+		t.Fatalf("CheckLock: %v", err)
+	}
+
+	want := []types.LockCheckIssue{
+		{Vendor: "alpha", Path: "lib/b.go", Status: "stale"},
+		{Vendor: "alpha", Path: "lib/gone.go", Status: "orphaned"},
+		{Vendor: "alpha", Path: "lib/v2/a.go", Status: "stale"},
+		{Vendor: "alpha", Ref: "v2", Status: "stale"},
+		{Vendor: "removed", Ref: "main", Status: "orphaned"},
+	}
+	if len(result.Issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), result.Issues)
+	}
+	for i, w := range want {
+		got := result.Issues[i]
+		if got.Vendor != w.Vendor || got.Ref != w.Ref || got.Path != w.Path || got.Status != w.Status || got.Message == "" {
+			t.Errorf("issue %d = %+v, want %+v with a message", i, got, w)
+		}
+	}
+	if s := result.Summary; s.Stale != 3 || s.Orphaned != 2 || s.Result != "FAIL" {
+		t.Errorf("summary = %+v", s)
+	}
+}
+
+func TestCheckLock_ConsistentLockPasses(t *testing.T) {
+	config := createTestConfig(createTestVendorSpec("lib", "https://github.com/owner/lib", "main"))
+	lock := types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "lib", Ref: "main", CommitHash: "aaaa", FileHashes: map[string]string{"lib/file.go": "h"}},
+	}}
+	syncer := &VendorSyncer{configStore: &stubConfigStore{config: config}, lockStore: &stubLockStore{lock: lock}}
+
+	result, err := syncer.CheckLock()
+	if err != nil {
+		t.Fatalf("CheckLock: %v", err)
+	}
+	if len(result.Issues) != 0 || result.Summary.Result != "PASS" {
+		t.Errorf("expected PASS with no issues, got %+v", result)
+	}
+}
+
+func TestRegenerateLock_PopulatesFileHashes(t *testing.T) {
+	chdirUnmanagedTest(t)
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	// The existing lock is unreadable; regenerate must not need it
+	lock.EXPECT().Load().Return(types.VendorLock{}, errors.New("yaml: corrupt"))
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/regen", nil)
+	fs.EXPECT().RemoveAll("/tmp/regen").Return(nil)
+
+	git.EXPECT().Init(gomock.Any(), "/tmp/regen").Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), "/tmp/regen", "origin", vendor.URL).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/regen", "origin", 1, "main").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), "/tmp/regen", "FETCH_HEAD").Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/regen").Return("abc123def456", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).DoAndReturn(func(_, dst string) (CopyStats, error) {
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return CopyStats{}, err
+		}
+		return CopyStats{FileCount: 1, ByteCount: 12}, os.WriteFile(dst, []byte("package lib\n"), 0644)
+	}).AnyTimes()
+
+	var saved types.VendorLock
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		saved = l
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if err := syncer.RegenerateLock(context.Background(), UpdateOptions{}); err != nil {
+		t.Fatalf("RegenerateLock: %v", err)
+	}
+
+	if len(saved.Vendors) != 1 {
+		t.Fatalf("expected 1 lock entry, got %+v", saved.Vendors)
+	}
+	entry := saved.Vendors[0]
+	if entry.CommitHash != "abc123def456" {
+		t.Errorf("commit = %q, want the fetched head", entry.CommitHash)
+	}
+	if hash := entry.FileHashes["lib/file.go"]; hash == "" {
+		t.Errorf("expected a FileHashes entry for lib/file.go, got %v", entry.FileHashes)
+	}
+}
+
+func TestRegenerateLock_KeepsPinnedEntries(t *testing.T) {
+	chdirUnmanagedTest(t)
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "abc123def456")
+	vendor.Specs[0].Pinned = true
+	pinnedEntry := types.LockDetails{Name: "lib", Ref: "abc123def456", CommitHash: "abc123def456", FileHashes: map[string]string{"lib/file.go": "cafe"}}
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{pinnedEntry}}, nil)
+
+	var saved types.VendorLock
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		saved = l
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if err := syncer.RegenerateLock(context.Background(), UpdateOptions{}); err != nil {
+		t.Fatalf("RegenerateLock: %v", err)
+	}
+
+	if len(saved.Vendors) != 1 || saved.Vendors[0].CommitHash != pinnedEntry.CommitHash || saved.Vendors[0].FileHashes["lib/file.go"] != "cafe" {
+		t.Errorf("pinned entry should be carried forward unchanged, got %+v", saved.Vendors)
+	}
+}
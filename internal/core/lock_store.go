@@ -2,14 +2,18 @@ package core
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/EmundoT/git-vendor/internal/types"
+	"gopkg.in/yaml.v3"
 )
 
 // Schema version constants
@@ -65,7 +69,7 @@ func validateSchemaVersion(version string, warnWriter io.Writer) error {
 		return fmt.Errorf(
 			"lockfile schema version %q requires a newer git-vendor version\n"+
 				"  Your CLI supports schema v%d.x, but lockfile is v%d.x\n"+
-				"  Run 'git vendor version' to check your version, then update git-vendor",
+				"  Run 'git vendor version' to check your version, then upgrade git-vendor",
 			version, MaxSupportedMajor, major)
 	}
 
@@ -82,6 +86,54 @@ func validateSchemaVersion(version string, warnWriter io.Writer) error {
 	return nil
 }
 
+// lockMigration upgrades a lock read at schema version From (or older) to the
+// next version that needed one.
+type lockMigration struct {
+	From  string
+	Apply func(lock *types.VendorLock)
+}
+
+// lockMigrations run in order on every lock older than CurrentSchemaVersion.
+// A step fills only what the lock itself determines exactly; best guesses
+// (VendoredAt, VendoredBy, license) stay with "git-vendor migrate". Versions
+// that only added optional fields, whose zero value means "not recorded"
+// (1.2 Positions, 1.3 SourceURL/AcceptedDrift/Source), need no step.
+var lockMigrations = []lockMigration{
+	// 1.1 added LastSyncedAt; before it, Updated was the last sync
+	{From: "1.0", Apply: func(lock *types.VendorLock) {
+		for i := range lock.Vendors {
+			if lock.Vendors[i].LastSyncedAt == "" {
+				lock.Vendors[i].LastSyncedAt = lock.Vendors[i].Updated
+			}
+		}
+	}},
+}
+
+// migrateLock upgrades lock in place from its SchemaVersion to
+// CurrentSchemaVersion by running the applicable lockMigrations. Locks at or
+// above the current version are left unchanged; validateSchemaVersion has
+// already rejected unsupported majors.
+func migrateLock(lock *types.VendorLock) error {
+	major, minor, err := parseSchemaVersion(lock.SchemaVersion)
+	if err != nil {
+		return fmt.Errorf("parse schema version: %w", err)
+	}
+	if major > MaxSupportedMajor || (major == MaxSupportedMajor && minor >= MaxSupportedMinor) {
+		return nil
+	}
+	for _, m := range lockMigrations {
+		fromMajor, fromMinor, err := parseSchemaVersion(m.From)
+		if err != nil {
+			return fmt.Errorf("lock migration from %q: %w", m.From, err)
+		}
+		if major < fromMajor || (major == fromMajor && minor <= fromMinor) {
+			m.Apply(lock)
+		}
+	}
+	lock.SchemaVersion = CurrentSchemaVersion
+	return nil
+}
+
 // LockStore handles vendor.lock I/O operations
 type LockStore interface {
 	Load() (types.VendorLock, error)
@@ -122,7 +174,7 @@ func (e *LockConflictError) Error() string {
 	for i, c := range e.Conflicts {
 		b.WriteString(fmt.Sprintf("\n  Conflict %d at line %d", i+1, c.LineNumber))
 	}
-	b.WriteString("\n  Fix: Resolve merge conflicts in vendor.lock, then run 'git-vendor update' or manually edit the file")
+	b.WriteString("\n  Fix: Resolve merge conflicts in vendor.lock, then run 'git-vendor lock --rehash'")
 	return b.String()
 }
 
@@ -201,11 +253,143 @@ func detectConflictsInData(data []byte) error {
 	return nil
 }
 
+// ErrLockCorrupted indicates vendor.lock's entries no longer match its checksum.
+var ErrLockCorrupted = errors.New("lockfile corrupted")
+
+// lockChecksum returns the checksum of a lock's vendors section, given as
+// its yaml.Node: the SHA-256 of the node re-encoded from generic values.
+// Working from the node rather than []types.LockDetails keeps fields this
+// binary doesn't know, and yaml.v3 emits map keys sorted, so reordering keys
+// by hand doesn't change the checksum.
+func lockChecksum(vendors *yaml.Node) (string, error) {
+	var generic interface{}
+	if vendors.Kind != 0 {
+		if err := vendors.Decode(&generic); err != nil {
+			return "", fmt.Errorf("decode lock entries: %w", err)
+		}
+	}
+	data, err := yaml.Marshal(generic)
+	if err != nil {
+		return "", fmt.Errorf("encode lock entries: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// entriesChecksum is lockChecksum for vendors about to be saved. The entries
+// go through their YAML text, as Load reads them back.
+func entriesChecksum(vendors []types.LockDetails) (string, error) {
+	data, err := yaml.Marshal(vendors)
+	if err != nil {
+		return "", fmt.Errorf("encode lock entries: %w", err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return "", fmt.Errorf("decode lock entries: %w", err)
+	}
+	if len(node.Content) == 0 {
+		return lockChecksum(&yaml.Node{})
+	}
+	return lockChecksum(node.Content[0])
+}
+
+// verifyLockChecksum returns an ErrLockCorrupted error when the raw lock file
+// data records a checksum its vendors section doesn't match. Locks without a
+// checksum pass, and so do locks from a newer minor schema version, whose
+// checksum this binary may compute differently (validateSchemaVersion only
+// warns about those).
+func verifyLockChecksum(data []byte) error {
+	var raw struct {
+		SchemaVersion string    `yaml:"schema_version"`
+		Checksum      string    `yaml:"checksum"`
+		Vendors       yaml.Node `yaml:"vendors"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("parse %s: %w", LockFile, err)
+	}
+	if raw.Checksum == "" {
+		return nil
+	}
+	if major, minor, err := parseSchemaVersion(raw.SchemaVersion); err == nil && major == MaxSupportedMajor && minor > MaxSupportedMinor {
+		return nil
+	}
+	got, err := lockChecksum(&raw.Vendors)
+	if err != nil {
+		return err
+	}
+	if got != raw.Checksum {
+		return fmt.Errorf("%w: vendor.lock checksum mismatch (recorded %s, computed %s)\n"+
+			"  The lock was edited or damaged outside git-vendor\n"+
+			"  Fix: if you edited it on purpose (e.g. resolving a merge conflict), run 'git-vendor lock --rehash';\n"+
+			"  otherwise restore vendor.lock from version control, or run 'git-vendor lock --regenerate'",
+			ErrLockCorrupted, raw.Checksum, got)
+	}
+	return nil
+}
+
+// slashKeys returns m with every key in forward-slash form (slashPath). When
+// two keys normalize to the same path, an entry already written with forward
+// slashes wins, then the first in key order. m itself is not modified.
+func slashKeys(m map[string]string) map[string]string {
+	var backslashed []string
+	for k := range m {
+		if strings.Contains(k, `\`) {
+			backslashed = append(backslashed, k)
+		}
+	}
+	if len(backslashed) == 0 {
+		return m
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if !strings.Contains(k, `\`) {
+			out[k] = v
+		}
+	}
+	sort.Strings(backslashed)
+	for _, k := range backslashed {
+		if _, ok := out[slashPath(k)]; !ok {
+			out[slashPath(k)] = m[k]
+		}
+	}
+	return out
+}
+
+// slashLockPaths rewrites every path lock records (file hash, accepted drift
+// and source hash keys, position from/to, license paths) in forward-slash
+// form, so a lock written on Windows matches config-derived paths on any OS.
+func slashLockPaths(lock *types.VendorLock) {
+	for i := range lock.Vendors {
+		entry := &lock.Vendors[i]
+		entry.FileHashes = slashKeys(entry.FileHashes)
+		entry.AcceptedDrift = slashKeys(entry.AcceptedDrift)
+		entry.SourceFileHashes = slashKeys(entry.SourceFileHashes)
+		entry.LicensePath = slashPath(entry.LicensePath)
+		if len(entry.LicenseFiles) > 0 {
+			entry.LicenseFiles = append([]string(nil), entry.LicenseFiles...)
+			for j := range entry.LicenseFiles {
+				entry.LicenseFiles[j] = slashPath(entry.LicenseFiles[j])
+			}
+		}
+		if len(entry.Positions) > 0 {
+			entry.Positions = append([]types.PositionLock(nil), entry.Positions...)
+			for j := range entry.Positions {
+				entry.Positions[j].From = slashPath(entry.Positions[j].From)
+				entry.Positions[j].To = slashPath(entry.Positions[j].To)
+			}
+		}
+	}
+}
+
 // Load reads and parses vendor.lock, validating schema version compatibility.
 // Load first checks for git merge conflict markers — returns a LockConflictError
 // if found, providing a clear error instead of a cryptic YAML parse failure.
-// Returns an error if the major version is unsupported.
+// Returns an error if the major version is unsupported, or an
+// ErrLockCorrupted error if the entries don't match the recorded checksum.
 // Writes a warning to stderr if minor version is newer than expected.
+// Older locks (including version-less ones, read as 1.0) are upgraded in
+// memory by migrateLock; the file is rewritten at the current version on the
+// next Save. Paths are returned in forward-slash form (slashLockPaths).
 func (s *FileLockStore) Load() (types.VendorLock, error) {
 	// Check for merge conflicts before attempting YAML parse
 	if err := s.DetectConflicts(); err != nil {
@@ -221,16 +405,54 @@ func (s *FileLockStore) Load() (types.VendorLock, error) {
 	if err := validateSchemaVersion(lock.SchemaVersion, os.Stderr); err != nil {
 		return types.VendorLock{}, err
 	}
+	data, err := os.ReadFile(s.store.Path())
+	if err != nil {
+		return types.VendorLock{}, err
+	}
+	if err := verifyLockChecksum(data); err != nil {
+		return types.VendorLock{}, err
+	}
+	slashLockPaths(&lock)
+	if err := migrateLock(&lock); err != nil {
+		return types.VendorLock{}, err
+	}
 
 	return lock, nil
 }
 
-// Save writes vendor.lock, always setting the current schema version.
+// Save writes vendor.lock, always setting the current schema version, writing
+// paths with forward slashes and recomputing the checksum. The caller's lock
+// is not modified.
 func (s *FileLockStore) Save(lock types.VendorLock) error {
 	lock.SchemaVersion = CurrentSchemaVersion
+	lock.Vendors = append([]types.LockDetails(nil), lock.Vendors...)
+	slashLockPaths(&lock)
+	checksum, err := entriesChecksum(lock.Vendors)
+	if err != nil {
+		return err
+	}
+	lock.Checksum = checksum
 	return s.store.Save(lock)
 }
 
+// Rehash rewrites vendor.lock with a fresh checksum without verifying the
+// recorded one, for a lock edited by hand on purpose (such as after resolving
+// a merge conflict). Merge conflict markers and an unsupported schema version
+// still fail.
+func (s *FileLockStore) Rehash() error {
+	if err := s.DetectConflicts(); err != nil {
+		return err
+	}
+	lock, err := s.store.Load()
+	if err != nil {
+		return err
+	}
+	if err := validateSchemaVersion(lock.SchemaVersion, os.Stderr); err != nil {
+		return err
+	}
+	return s.Save(lock)
+}
+
 // MergeLockEntries merges two VendorLock structs into one.
 // Non-overlapping vendors are combined directly. For overlapping entries
 // (same vendor name + ref), the entry with the later Updated timestamp wins.
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/EmundoT/git-vendor/internal/types"
+	"gopkg.in/yaml.v3"
 )
 
 // ============================================================================
@@ -563,6 +564,309 @@ func TestFileLockStore_Save_SetsSchemaVersion(t *testing.T) {
 	}
 }
 
+func TestFileLockStore_Load_MigratesVersionlessLock(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	lockContent := "vendors:\n" +
+		"  - name: old\n    ref: main\n    commit_hash: abc123\n    updated: '2024-01-01T00:00:00Z'\n" +
+		"  - name: synced\n    ref: main\n    commit_hash: def456\n    updated: '2024-01-01T00:00:00Z'\n    last_synced_at: '2024-03-01T00:00:00Z'\n"
+	if err := os.WriteFile(filepath.Join(vendorDir, LockFile), []byte(lockContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileLockStore(vendorDir)
+	lock, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if lock.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("SchemaVersion = %q, want migrated to %q", lock.SchemaVersion, CurrentSchemaVersion)
+	}
+	if got := lock.Vendors[0].LastSyncedAt; got != "2024-01-01T00:00:00Z" {
+		t.Errorf("LastSyncedAt = %q, want the 1.0 updated timestamp", got)
+	}
+	if got := lock.Vendors[1].LastSyncedAt; got != "2024-03-01T00:00:00Z" {
+		t.Errorf("LastSyncedAt = %q, an existing value must be kept", got)
+	}
+	// Guesses are left to "git-vendor migrate"
+	if lock.Vendors[0].VendoredAt != "" || lock.Vendors[0].VendoredBy != "" {
+		t.Errorf("load migration must not guess VendoredAt/VendoredBy, got %+v", lock.Vendors[0])
+	}
+}
+
+func TestFileLockStore_Load_RejectsNewerSchema(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	lockContent := "schema_version: \"2.0\"\nvendors:\n  - name: test\n    ref: main\n    commit_hash: abc123\n"
+	if err := os.WriteFile(filepath.Join(vendorDir, LockFile), []byte(lockContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := NewFileLockStore(vendorDir).Load()
+	if err == nil {
+		t.Fatalf("Load() should reject schema 2.0, got %+v", lock)
+	}
+	if !strings.Contains(err.Error(), "upgrade git-vendor") {
+		t.Errorf("error should tell the user to upgrade git-vendor, got: %v", err)
+	}
+	if len(lock.Vendors) != 0 {
+		t.Errorf("rejected lock must not be returned, got %+v", lock)
+	}
+}
+
+func TestMigrateLock_CurrentVersionUnchanged(t *testing.T) {
+	lock := types.VendorLock{SchemaVersion: CurrentSchemaVersion, Vendors: []types.LockDetails{{Name: "v", Updated: "2024-01-01T00:00:00Z"}}}
+	if err := migrateLock(&lock); err != nil {
+		t.Fatalf("migrateLock() error = %v", err)
+	}
+	if lock.Vendors[0].LastSyncedAt != "" {
+		t.Errorf("current-version lock must not be migrated, got LastSyncedAt %q", lock.Vendors[0].LastSyncedAt)
+	}
+}
+
+// ============================================================================
+// Checksum Tests
+// ============================================================================
+
+func TestFileLockStore_Checksum_RoundTrip(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	store := NewFileLockStore(vendorDir)
+	lock := types.VendorLock{Vendors: []types.LockDetails{{
+		Name:       "lib",
+		Ref:        "main",
+		CommitHash: "abc123",
+		Updated:    "2024-01-01T00:00:00Z",
+		FileHashes: map[string]string{"lib/b.go": "hash-b", "lib/a.go": "hash-a", "lib/c.go": "hash-c"},
+	}}}
+	if err := store.Save(lock); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(store.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "checksum: sha256:") {
+		t.Fatalf("saved lock has no checksum line:\n%s", data)
+	}
+
+	// Reordering map keys by hand is not a content change
+	reordered := strings.Replace(string(data),
+		"lib/a.go: hash-a\n        lib/b.go: hash-b\n",
+		"lib/b.go: hash-b\n        lib/a.go: hash-a\n", 1)
+	if reordered == string(data) {
+		t.Fatalf("test setup: file_hashes layout not as expected:\n%s", data)
+	}
+	if err := os.WriteFile(store.Path(), []byte(reordered), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.Vendors[0].FileHashes["lib/a.go"]; got != "hash-a" {
+		t.Errorf("FileHashes[lib/a.go] = %q, want hash-a", got)
+	}
+}
+
+func TestFileLockStore_Checksum_RejectsTamperedLock(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	store := NewFileLockStore(vendorDir)
+	if err := store.Save(types.VendorLock{Vendors: []types.LockDetails{{
+		Name: "lib", Ref: "main", CommitHash: "abc123", Updated: "2024-01-01T00:00:00Z",
+	}}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(store.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), "commit_hash: abc123", "commit_hash: evil99", 1)
+	if err := os.WriteFile(store.Path(), []byte(tampered), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := store.Load()
+	if !errors.Is(err, ErrLockCorrupted) {
+		t.Fatalf("Load() error = %v, want ErrLockCorrupted", err)
+	}
+	if !strings.Contains(err.Error(), "lockfile corrupted") {
+		t.Errorf("error should say the lockfile is corrupted, got: %v", err)
+	}
+	if len(lock.Vendors) != 0 {
+		t.Errorf("rejected lock must not be returned, got %+v", lock)
+	}
+}
+
+func TestFileLockStore_Checksum_MissingAccepted(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	lockContent := "schema_version: \"1.3\"\nvendors:\n  - name: test\n    ref: main\n    commit_hash: abc123\n"
+	if err := os.WriteFile(filepath.Join(vendorDir, LockFile), []byte(lockContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileLockStore(vendorDir).Load(); err != nil {
+		t.Errorf("lock written before checksums should load, got %v", err)
+	}
+}
+
+func TestFileLockStore_Checksum_KeepsUnknownFields(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	// A field from a later version that this binary drops when decoding
+	body := "vendors:\n    - name: lib\n      ref: main\n      commit_hash: abc123\n      future_field: x\n"
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &node); err != nil {
+		t.Fatal(err)
+	}
+	sum, err := lockChecksum(node.Content[0].Content[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileLockStore(vendorDir)
+	for _, version := range []string{"1.3", "1.9"} {
+		content := "schema_version: \"" + version + "\"\nchecksum: " + sum + "\n" + body
+		if err := os.WriteFile(store.Path(), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Load(); err != nil {
+			t.Errorf("schema %s: Load() error = %v, want the unknown field covered by the checksum", version, err)
+		}
+	}
+
+	// A newer minor version is trusted even when this binary's checksum differs
+	content := "schema_version: \"1.9\"\nchecksum: sha256:0000\n" + body
+	if err := os.WriteFile(store.Path(), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(); err != nil {
+		t.Errorf("newer minor version: Load() error = %v, want only the schema warning", err)
+	}
+}
+
+func TestFileLockStore_Rehash_AcceptsHandResolvedMerge(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	store := NewFileLockStore(vendorDir)
+	if err := store.Save(types.VendorLock{Vendors: []types.LockDetails{{
+		Name: "lib", Ref: "main", CommitHash: "abc123", Updated: "2024-01-01T00:00:00Z",
+	}}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Resolving a conflict by hand: take the other branch's commit, keep our checksum line
+	data, err := os.ReadFile(store.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved := strings.Replace(string(data), "commit_hash: abc123", "commit_hash: def456", 1)
+	if err := os.WriteFile(store.Path(), []byte(resolved), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Load()
+	if !errors.Is(err, ErrLockCorrupted) || !strings.Contains(err.Error(), "lock --rehash") {
+		t.Fatalf("Load() error = %v, want ErrLockCorrupted pointing at lock --rehash", err)
+	}
+
+	if err := store.Rehash(); err != nil {
+		t.Fatalf("Rehash() error = %v", err)
+	}
+	lock, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() after Rehash() error = %v", err)
+	}
+	if lock.Vendors[0].CommitHash != "def456" {
+		t.Errorf("CommitHash = %s, want the hand-resolved def456", lock.Vendors[0].CommitHash)
+	}
+
+	// Conflict markers are still refused
+	if err := os.WriteFile(store.Path(), []byte("<<<<<<< HEAD\nvendors: []\n=======\nvendors: []\n>>>>>>> other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Rehash(); !IsLockConflictError(err) {
+		t.Errorf("Rehash() error = %v, want a LockConflictError", err)
+	}
+}
+
+// ============================================================================
+// Path Separator Tests
+// ============================================================================
+
+func TestFileLockStore_WindowsPathsCoherentWithSlashConfig(t *testing.T) {
+	vendorDir := filepath.Join(t.TempDir(), VendorDir)
+	_ = os.MkdirAll(vendorDir, 0755)
+
+	// Lock written on Windows: backslashed keys, plus a duplicate of one path in both forms
+	lockContent := `schema_version: "1.3"
+vendors:
+  - name: lib
+    ref: main
+    commit_hash: abc123
+    file_hashes:
+      'vendor\lib\a.go': hash-a
+      'vendor\lib\sub\b.go': hash-b-old
+      vendor/lib/sub/b.go: hash-b
+    positions:
+      - from: 'src\c.go:L1-L5'
+        to: 'vendor\lib\c.go:L1-L5'
+        source_hash: hash-c
+`
+	if err := os.WriteFile(filepath.Join(vendorDir, LockFile), []byte(lockContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileLockStore(vendorDir)
+	lock, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	hashes := lock.Vendors[0].FileHashes
+	if len(hashes) != 2 || hashes["vendor/lib/a.go"] != "hash-a" || hashes["vendor/lib/sub/b.go"] != "hash-b" {
+		t.Errorf("FileHashes = %v, want forward-slash keys with the slash entry winning", hashes)
+	}
+	if pos := lock.Vendors[0].Positions[0]; pos.From != "src/c.go:L1-L5" || pos.To != "vendor/lib/c.go:L1-L5" {
+		t.Errorf("position = %s -> %s, want forward-slash paths", pos.From, pos.To)
+	}
+
+	config := types.VendorConfig{Vendors: []types.VendorSpec{{
+		Name: "lib",
+		URL:  "https://github.com/owner/lib",
+		Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{
+			{From: "src/a.go", To: "vendor/lib/a.go"},
+			{From: "src/sub/b.go", To: "vendor/lib/sub/b.go"},
+		}}},
+	}}}
+	result := &types.VerifyResult{}
+	detectCoherenceIssues(config, lock, result)
+	if result.Summary.Stale != 0 || result.Summary.Orphaned != 0 {
+		t.Errorf("coherence = %d stale, %d orphaned, want none: %+v", result.Summary.Stale, result.Summary.Orphaned, result.Files)
+	}
+
+	// Saving writes forward slashes only
+	if err := store.Save(lock); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, err := os.ReadFile(store.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `\`) {
+		t.Errorf("saved lock still has backslashes:\n%s", data)
+	}
+}
+
 // ============================================================================
 // Merge Conflict Detection Tests
 // ============================================================================
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// LogLevel orders Logger messages; a logger drops messages below its level.
+type LogLevel int
+
+// Log levels, least to most severe.
+const (
+	LogDebug LogLevel = iota // Each git command, file copied, and timing (--verbose)
+	LogInfo                  // Per-vendor progress worth keeping in CI logs
+	LogWarn                  // Recoverable problems (mirror fallback, retries)
+)
+
+// String returns the level's name as printed by the writer logger.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarn:
+		return "WARN"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// Logger receives leveled diagnostics from the syncer and its services.
+// User-facing output still goes through UICallback; Logger is for tracing
+// what a run did. The default is NopLogger.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+// NopLogger discards every message.
+type NopLogger struct{}
+
+// Debugf implements Logger.
+func (NopLogger) Debugf(string, ...interface{}) {}
+
+// Infof implements Logger.
+func (NopLogger) Infof(string, ...interface{}) {}
+
+// Warnf implements Logger.
+func (NopLogger) Warnf(string, ...interface{}) {}
+
+// writerLogger writes "[LEVEL] message" lines at or above level to w.
+type writerLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level LogLevel
+}
+
+// NewWriterLogger returns a Logger writing messages at or above level to w,
+// one "[DEBUG] message" line each. Safe for concurrent use.
+func NewWriterLogger(w io.Writer, level LogLevel) Logger {
+	return &writerLogger{w: w, level: level}
+}
+
+func (l *writerLogger) logf(level LogLevel, format string, args ...interface{}) {
+	if level < l.level {
+		return
+	}
+	msg := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, "[%s] %s\n", level, msg)
+}
+
+// Debugf implements Logger.
+func (l *writerLogger) Debugf(format string, args ...interface{}) { l.logf(LogDebug, format, args...) }
+
+// Infof implements Logger.
+func (l *writerLogger) Infof(format string, args ...interface{}) { l.logf(LogInfo, format, args...) }
+
+// Warnf implements Logger.
+func (l *writerLogger) Warnf(format string, args ...interface{}) { l.logf(LogWarn, format, args...) }
+
+// loggerSlot is the Logger a VendorSyncer shares with its services and git
+// client. Setting it swaps the logger for all of them at once, so -v parsed
+// after the Manager is built still reaches every service. A nil slot or an
+// unset one discards messages.
+type loggerSlot struct {
+	mu     sync.RWMutex
+	logger Logger
+}
+
+// set replaces the slot's logger; nil restores the no-op default.
+func (s *loggerSlot) set(l Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logger = l
+}
+
+// get returns the current logger, never nil.
+func (s *loggerSlot) get() Logger {
+	if s == nil {
+		return NopLogger{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.logger == nil {
+		return NopLogger{}
+	}
+	return s.logger
+}
+
+// Debugf implements Logger.
+func (s *loggerSlot) Debugf(format string, args ...interface{}) { s.get().Debugf(format, args...) }
+
+// Infof implements Logger.
+func (s *loggerSlot) Infof(format string, args ...interface{}) { s.get().Infof(format, args...) }
+
+// Warnf implements Logger.
+func (s *loggerSlot) Warnf(format string, args ...interface{}) { s.get().Warnf(format, args...) }
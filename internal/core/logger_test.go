@@ -0,0 +1,101 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+// capturingLogger records every message with its level.
+type capturingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *capturingLogger) record(level LogLevel, format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, level.String()+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *capturingLogger) Debugf(format string, args ...interface{}) {
+	l.record(LogDebug, format, args...)
+}
+func (l *capturingLogger) Infof(format string, args ...interface{}) {
+	l.record(LogInfo, format, args...)
+}
+func (l *capturingLogger) Warnf(format string, args ...interface{}) {
+	l.record(LogWarn, format, args...)
+}
+
+func TestSetLogger_SyncLogsCopiedFilesAndTimings(t *testing.T) {
+	chdirUnmanagedTest(t)
+	if err := os.MkdirAll(VendorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configStore, lockStore := NewFileConfigStore(VendorDir), NewFileLockStore(VendorDir)
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	vendor.Specs[0].Mapping = []types.PathMapping{{From: "a.go", To: "vendor/lib/a.go"}}
+	if err := configStore.Save(createTestConfig(vendor)); err != nil {
+		t.Fatal(err)
+	}
+
+	git := NewMockGitClient(gomock.NewController(t))
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", gomock.Any()).Return(nil).AnyTimes()
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), "main").Return(nil).AnyTimes()
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, dir, _ string) error {
+		writeFixTestFile(t, filepath.Join(dir, "a.go"), "package a\n")
+		return nil
+	}).AnyTimes()
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return(strings.Repeat("a", 40), nil).AnyTimes()
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	// The logger is set after construction, as main does once -v is parsed
+	syncer := NewVendorSyncer(configStore, lockStore, git, NewOSFileSystem(), nil, VendorDir, &SilentUICallback{}, nil)
+	logger := &capturingLogger{}
+	syncer.SetLogger(logger)
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+
+	logged := strings.Join(logger.lines, "\n")
+	for _, want := range []string{
+		"DEBUG copying file a.go -> " + filepath.Join("vendor", "lib", "a.go"),
+		"INFO lib finished in ",
+	} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log missing %q:\n%s", want, logged)
+		}
+	}
+
+	// nil restores the no-op logger
+	syncer.SetLogger(nil)
+	logger.lines = nil
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+	if len(logger.lines) != 0 {
+		t.Errorf("logged after SetLogger(nil): %q", logger.lines)
+	}
+}
+
+func TestWriterLogger_DropsMessagesBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWriterLogger(&buf, LogInfo)
+	logger.Debugf("git %s", "fetch")
+	logger.Infof("lib finished in %s", "1s")
+	logger.Warnf("trying mirror\n")
+
+	if got, want := buf.String(), "[INFO] lib finished in 1s\n[WARN] trying mirror\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
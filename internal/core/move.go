@@ -0,0 +1,273 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// MoveResult reports what "git-vendor mv" changed.
+type MoveResult struct {
+	VendorName string `json:"vendor"`
+	From       string `json:"from"`        // Previous destination root
+	To         string `json:"to"`          // New destination root
+	Mappings   int    `json:"mappings"`    // Mapping "to" paths rewritten
+	PathsMoved int    `json:"paths_moved"` // Files and directories renamed on disk
+}
+
+// moveTarget is one mapping destination being relocated.
+type moveTarget struct {
+	spec, mapping int
+	oldFile       string // Destination file or directory, position stripped
+	newFile       string
+	newTo         string // New mapping "to", position specifier kept
+}
+
+// MoveVendor relocates every destination of vendor name under newDest,
+// keeping their layout relative to the vendor's destination root (the
+// deepest directory containing all of its mapped files and directories).
+// Mapping "to" paths are rewritten, files already on disk are renamed, and
+// the vendor's lock entries have their file_hashes, positions and
+// accepted_drift paths re-keyed, so no re-sync is needed.
+//
+// Nothing is changed when the moved destinations would collide with another
+// vendor's (DetectConflicts rules) or with paths that already exist on disk;
+// both return a DestinationConflictError.
+func (s *VendorSyncer) MoveVendor(name, newDest string) (*MoveResult, error) {
+	if name == "" || newDest == "" {
+		return nil, fmt.Errorf("vendor name and new destination are required")
+	}
+	if err := ValidateDestPath(newDest); err != nil {
+		return nil, err
+	}
+	newRoot := path.Clean(filepath.ToSlash(newDest))
+
+	cfg, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	idx := FindVendorIndex(cfg.Vendors, name)
+	if idx < 0 {
+		return nil, NewVendorNotFoundError(name)
+	}
+	vendor := &cfg.Vendors[idx]
+
+	// An unreadable or missing lock only means there is nothing to re-key
+	lock, lockErr := s.lockStore.Load()
+	lockHashes := make(map[string]bool)
+	if lockErr == nil {
+		for _, entry := range lock.Vendors {
+			if entry.Name == name {
+				for p := range entry.FileHashes {
+					lockHashes[filepath.ToSlash(p)] = true
+				}
+			}
+		}
+	}
+
+	targets, oldRoot, err := planMove(vendor, newRoot, lockHashes)
+	if err != nil {
+		return nil, err
+	}
+	if oldRoot == newRoot {
+		return nil, fmt.Errorf("vendor '%s' is already at '%s'", name, newRoot)
+	}
+	if pathWithin(newRoot, oldRoot) || pathWithin(oldRoot, newRoot) {
+		return nil, fmt.Errorf("cannot move '%s' from '%s' into '%s': one contains the other", name, oldRoot, newRoot)
+	}
+
+	moved := cfg
+	moved.Vendors = append([]types.VendorSpec(nil), cfg.Vendors...)
+	movedVendor := *vendor
+	movedVendor.Specs = make([]types.BranchSpec, len(vendor.Specs))
+	for i, spec := range vendor.Specs {
+		spec.Mapping = append([]types.PathMapping(nil), spec.Mapping...)
+		movedVendor.Specs[i] = spec
+	}
+	for _, t := range targets {
+		movedVendor.Specs[t.spec].Mapping[t.mapping].To = t.newTo
+	}
+	moved.Vendors[idx] = movedVendor
+
+	var conflicts []types.PathConflict
+	for _, c := range NewValidationService(s.configStore).detectConfigConflicts(moved) {
+		if (c.Vendor1 == name) != (c.Vendor2 == name) {
+			conflicts = append(conflicts, c)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, t := range targets {
+		if seen[t.newFile] {
+			continue
+		}
+		seen[t.newFile] = true
+		if _, err := os.Lstat(t.newFile); err == nil {
+			conflicts = append(conflicts, types.PathConflict{Path: t.newFile, Vendor1: name})
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, NewDestinationConflictError(name, newRoot, conflicts)
+	}
+
+	result := &MoveResult{VendorName: name, From: oldRoot, To: newRoot, Mappings: len(targets)}
+	renamed := make(map[string]bool)
+	for _, t := range targets {
+		if renamed[t.oldFile] {
+			continue // Several position mappings can share one file
+		}
+		renamed[t.oldFile] = true
+		if _, err := os.Lstat(t.oldFile); errors.Is(err, os.ErrNotExist) {
+			continue // Not synced yet
+		}
+		if err := os.MkdirAll(filepath.Dir(t.newFile), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(t.oldFile, t.newFile); err != nil {
+			return nil, fmt.Errorf("move %s to %s: %w", t.oldFile, t.newFile, err)
+		}
+		result.PathsMoved++
+	}
+	removeEmptyParents(oldRoot)
+
+	if err := s.configStore.Save(moved); err != nil {
+		return nil, fmt.Errorf("save config: %w", err)
+	}
+
+	if lockErr == nil {
+		for i := range lock.Vendors {
+			if lock.Vendors[i].Name == name {
+				rekeyLockEntry(&lock.Vendors[i], oldRoot, newRoot)
+			}
+		}
+		if err := s.lockStore.Save(lock); err != nil {
+			return nil, fmt.Errorf("save lockfile: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// planMove resolves vendor's mapping destinations and their new locations
+// under newRoot. A destination counts as a directory when it is one on disk,
+// or, before the first sync, when a lock file_hashes path lies under it.
+func planMove(vendor *types.VendorSpec, newRoot string, lockHashes map[string]bool) ([]moveTarget, string, error) {
+	var targets []moveTarget
+	var parents []string
+	for i, spec := range vendor.Specs {
+		for j, mapping := range spec.Mapping {
+			destRaw := filepath.ToSlash((&FileCopyService{}).computeDestPath(mapping, spec, vendor))
+			fileRaw, _, err := types.ParsePathPosition(destRaw)
+			if err != nil {
+				fileRaw = destRaw
+			}
+			dest := path.Clean(fileRaw)
+			if dest == "." || dest == ".." || strings.HasPrefix(dest, "../") {
+				return nil, "", fmt.Errorf("vendor '%s' maps into the project root; give its mappings a common directory first", vendor.Name)
+			}
+			targets = append(targets, moveTarget{spec: i, mapping: j, oldFile: dest, newTo: destRaw[len(fileRaw):]})
+			if isDestDir(dest, lockHashes) {
+				parents = append(parents, dest)
+			} else {
+				parents = append(parents, path.Dir(dest))
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil, "", fmt.Errorf("vendor '%s' has no mappings to move", vendor.Name)
+	}
+
+	oldRoot := commonDirPrefix(parents)
+	if oldRoot == "." {
+		return nil, "", fmt.Errorf("vendor '%s' has no common destination directory to move", vendor.Name)
+	}
+	for i := range targets {
+		t := &targets[i]
+		t.newFile = path.Join(newRoot, strings.TrimPrefix(t.oldFile, oldRoot))
+		t.newTo = t.newFile + t.newTo
+	}
+	return targets, oldRoot, nil
+}
+
+// isDestDir reports whether dest is a directory destination.
+func isDestDir(dest string, lockHashes map[string]bool) bool {
+	if info, err := os.Stat(dest); err == nil {
+		return info.IsDir()
+	}
+	for p := range lockHashes {
+		if strings.HasPrefix(p, dest+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// commonDirPrefix returns the deepest directory shared by dirs, or ".".
+func commonDirPrefix(dirs []string) string {
+	common := strings.Split(dirs[0], "/")
+	for _, d := range dirs[1:] {
+		parts := strings.Split(d, "/")
+		n := 0
+		for n < len(common) && n < len(parts) && common[n] == parts[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	if len(common) == 0 || common[0] == "." {
+		return "."
+	}
+	return strings.Join(common, "/")
+}
+
+// pathWithin reports whether p equals dir or lies under it.
+func pathWithin(p, dir string) bool {
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// movePath maps p from under oldRoot to under newRoot; other paths are kept.
+func movePath(p, oldRoot, newRoot string) string {
+	slashed := filepath.ToSlash(p)
+	if !pathWithin(slashed, oldRoot) {
+		return p
+	}
+	return newRoot + strings.TrimPrefix(slashed, oldRoot)
+}
+
+// rekeyLockEntry rewrites entry's destination paths from oldRoot to newRoot.
+func rekeyLockEntry(entry *types.LockDetails, oldRoot, newRoot string) {
+	entry.FileHashes = rekeyPaths(entry.FileHashes, oldRoot, newRoot)
+	entry.AcceptedDrift = rekeyPaths(entry.AcceptedDrift, oldRoot, newRoot)
+	for i := range entry.Positions {
+		fileRaw, _, err := types.ParsePathPosition(entry.Positions[i].To)
+		if err != nil {
+			continue
+		}
+		entry.Positions[i].To = movePath(fileRaw, oldRoot, newRoot) + entry.Positions[i].To[len(fileRaw):]
+	}
+}
+
+// rekeyPaths returns m with keys under oldRoot moved under newRoot.
+func rekeyPaths(m map[string]string, oldRoot, newRoot string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for p, v := range m {
+		out[movePath(p, oldRoot, newRoot)] = v
+	}
+	return out
+}
+
+// removeEmptyParents deletes dir and each parent left empty by a move,
+// stopping at the first non-empty directory or the project root.
+func removeEmptyParents(dir string) {
+	for d := dir; d != "." && d != "/" && d != ""; d = path.Dir(d) {
+		if err := os.Remove(d); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// NormalizeConfig returns the canonical form of config so that equivalent
+// configs serialize identically. NormalizeConfig does not modify config.
+//
+// Canonicalization rules:
+//   - Paths in from/to/default_target/license_dir use forward slashes and
+//     are cleaned ("./a//b/" → "a/b"); position specifiers are preserved and
+//     URL-style sources are left untouched. Exclude patterns are kept as
+//     written, since a backslash there is a glob escape.
+//   - An explicit "to" equal to the auto-named destination is dropped
+//     (except inside a multi-destination "to" list).
+//   - default_target is dropped when no mapping in its spec is auto-named.
+//   - Vendors are sorted by name, unless two vendors write the same
+//     destination: their config order decides the write winner, so the
+//     original order is kept.
+func NormalizeConfig(config types.VendorConfig) types.VendorConfig {
+	out := config
+	if config.LicenseDir != "" {
+		out.LicenseDir = normalizeConfigPath(config.LicenseDir)
+	}
+
+	if config.Vendors == nil {
+		return out
+	}
+	out.Vendors = make([]types.VendorSpec, len(config.Vendors))
+	for i := range config.Vendors {
+		out.Vendors[i] = normalizeVendorSpec(config.Vendors[i])
+	}
+
+	if !hasSharedDestination(out.Vendors) {
+		sort.SliceStable(out.Vendors, func(i, j int) bool {
+			return out.Vendors[i].Name < out.Vendors[j].Name
+		})
+	}
+	return out
+}
+
+// normalizeVendorSpec canonicalizes the specs and mappings of one vendor.
+func normalizeVendorSpec(vendor types.VendorSpec) types.VendorSpec {
+	out := vendor
+	if vendor.Specs == nil {
+		return out
+	}
+	out.Specs = make([]types.BranchSpec, len(vendor.Specs))
+	for si, spec := range vendor.Specs {
+		ns := spec
+		if ns.DefaultTarget != "" {
+			ns.DefaultTarget = normalizeConfigPath(ns.DefaultTarget)
+		}
+
+		autoNamed := false
+		if spec.Mapping != nil {
+			ns.Mapping = make([]types.PathMapping, len(spec.Mapping))
+		}
+		for mi, m := range spec.Mapping {
+			nm := m
+			nm.From = normalizeMappingPath(m.From)
+			nm.To = normalizeMappingPath(m.To)
+
+			// Internal vendors ignore default_target, so their auto paths differ.
+			// Entries of a multi-destination "to" list keep their explicit paths.
+			if vendor.Source != SourceInternal && nm.ToGroup == 0 && nm.To != "" && nm.To == autoDestPath(nm.From, ns, vendor.Name) {
+				nm.To = ""
+			}
+			if nm.To == "" || nm.To == "." {
+				autoNamed = true
+			}
+			ns.Mapping[mi] = nm
+		}
+
+		if !autoNamed {
+			ns.DefaultTarget = ""
+		}
+		out.Specs[si] = ns
+	}
+	return out
+}
+
+// autoDestPath returns the destination sync would compute for an empty "to",
+// in forward-slash form (mirrors FileCopyService.computeDestPath).
+func autoDestPath(from string, spec types.BranchSpec, vendorName string) string {
+	src := strings.Replace(from, "blob/"+spec.Ref+"/", "", 1)
+	src = strings.Replace(src, "tree/"+spec.Ref+"/", "", 1)
+	if srcFile, _, err := types.ParsePathPosition(src); err == nil {
+		src = srcFile
+	}
+	return filepath.ToSlash(ComputeAutoPath(src, spec.DefaultTarget, vendorName))
+}
+
+// normalizeMappingPath canonicalizes a from/to value, keeping any position
+// specifier (e.g. ":L5-L10") and leaving URL-style sources untouched.
+func normalizeMappingPath(p string) string {
+	if p == "" || p == "." || strings.Contains(p, "://") {
+		return p
+	}
+	file, pos, err := types.ParsePathPosition(p)
+	if err != nil || pos == nil {
+		return normalizeConfigPath(p)
+	}
+	return normalizeConfigPath(file) + strings.TrimPrefix(p, file)
+}
+
+// slashPath converts the separators of a file path to forward slashes and
+// leaves the rest as written: a position specifier is kept verbatim (its
+// anchors may hold regex escapes) and URL-style values are untouched.
+func slashPath(p string) string {
+	if !strings.Contains(p, `\`) || strings.Contains(p, "://") {
+		return p
+	}
+	file, pos, err := types.ParsePathPosition(p)
+	if err != nil || pos == nil {
+		return strings.ReplaceAll(p, `\`, "/")
+	}
+	return strings.ReplaceAll(file, `\`, "/") + strings.TrimPrefix(p, file)
+}
+
+// slashConfigPaths returns config with its mapping from/to, default_target
+// and license_dir paths in forward-slash form (slashPath), so a config edited
+// on Windows compares equal to lock keys everywhere. config is not modified.
+func slashConfigPaths(config types.VendorConfig) types.VendorConfig {
+	config.LicenseDir = slashPath(config.LicenseDir)
+	if len(config.Vendors) == 0 {
+		return config
+	}
+	config.Vendors = append([]types.VendorSpec(nil), config.Vendors...)
+	for vi := range config.Vendors {
+		v := &config.Vendors[vi]
+		if len(v.Specs) == 0 {
+			continue
+		}
+		v.Specs = append([]types.BranchSpec(nil), v.Specs...)
+		for si := range v.Specs {
+			spec := &v.Specs[si]
+			spec.DefaultTarget = slashPath(spec.DefaultTarget)
+			if len(spec.Mapping) == 0 {
+				continue
+			}
+			spec.Mapping = append([]types.PathMapping(nil), spec.Mapping...)
+			for mi := range spec.Mapping {
+				spec.Mapping[mi].From = slashPath(spec.Mapping[mi].From)
+				spec.Mapping[mi].To = slashPath(spec.Mapping[mi].To)
+			}
+		}
+	}
+	return config
+}
+
+// normalizeConfigPath converts p to a cleaned forward-slash path.
+func normalizeConfigPath(p string) string {
+	return path.Clean(strings.ReplaceAll(p, `\`, "/"))
+}
+
+// hasSharedDestination reports whether two different vendors write to the
+// same destination file (position specifiers are ignored).
+func hasSharedDestination(vendors []types.VendorSpec) bool {
+	owner := make(map[string]string)
+	for _, v := range vendors {
+		for _, spec := range v.Specs {
+			for _, m := range spec.Mapping {
+				dest := m.To
+				if dest == "" || dest == "." {
+					dest = autoDestPath(m.From, spec, v.Name)
+				}
+				if file, _, err := types.ParsePathPosition(dest); err == nil {
+					dest = file
+				}
+				if prev, ok := owner[dest]; ok && prev != v.Name {
+					return true
+				}
+				owner[dest] = v.Name
+			}
+		}
+	}
+	return false
+}
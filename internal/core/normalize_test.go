@@ -0,0 +1,143 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ============================================================================
+// NormalizeConfig Tests - git-vendor normalize
+// ============================================================================
+
+func canonicalNormalizeConfig() types.VendorConfig {
+	return types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name: "alpha",
+				URL:  "https://github.com/owner/alpha",
+				Specs: []types.BranchSpec{{
+					Ref:           "main",
+					DefaultTarget: "vendor/alpha",
+					Mapping: []types.PathMapping{
+						{From: "src/util"},
+						{From: "api.go:L5-L10", To: "internal/api.go:L1-L6"},
+					},
+				}},
+			},
+			{
+				Name: "beta",
+				URL:  "https://github.com/owner/beta",
+				Specs: []types.BranchSpec{{
+					Ref: "v1.0.0",
+					Mapping: []types.PathMapping{
+						{From: "lib", To: "third_party/beta", Exclude: []string{"**/*_test.go"}},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestNormalizeConfig_CanonicalIsNoOp(t *testing.T) {
+	cfg := canonicalNormalizeConfig()
+	if got := NormalizeConfig(cfg); !reflect.DeepEqual(got, cfg) {
+		t.Errorf("canonical config changed:\ngot  %+v\nwant %+v", got, cfg)
+	}
+
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	config.EXPECT().Load().Return(canonicalNormalizeConfig(), nil)
+	// No Save expected: an already-canonical file is left untouched
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	changed, err := syncer.NormalizeConfig()
+	if err != nil {
+		t.Fatalf("NormalizeConfig() error = %v", err)
+	}
+	if changed {
+		t.Error("expected changed=false for canonical config")
+	}
+}
+
+func TestNormalizeConfig_MessyBecomesCanonical(t *testing.T) {
+	messy := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name: "beta",
+				URL:  "https://github.com/owner/beta",
+				Specs: []types.BranchSpec{{
+					Ref:           "v1.0.0",
+					DefaultTarget: "unused/", // Every mapping has an explicit destination
+					Mapping: []types.PathMapping{
+						{From: "./lib/", To: `third_party\beta\`, Exclude: []string{"**/*_test.go"}},
+					},
+				}},
+			},
+			{
+				Name: "alpha",
+				URL:  "https://github.com/owner/alpha",
+				Specs: []types.BranchSpec{{
+					Ref:           "main",
+					DefaultTarget: "vendor//alpha/",
+					Mapping: []types.PathMapping{
+						{From: "src/util/", To: "vendor/alpha/util"}, // Same as auto-named path
+						{From: "./api.go:L5-L10", To: "internal//api.go:L1-L6"},
+					},
+				}},
+			},
+		},
+	}
+
+	got := NormalizeConfig(messy)
+	if want := canonicalNormalizeConfig(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeConfig() =\n%+v\nwant\n%+v", got, want)
+	}
+	if again := NormalizeConfig(got); !reflect.DeepEqual(again, got) {
+		t.Error("NormalizeConfig is not idempotent")
+	}
+	if messy.Vendors[0].Name != "beta" || messy.Vendors[0].Specs[0].Mapping[0].From != "./lib/" {
+		t.Error("NormalizeConfig must not modify its input")
+	}
+
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	config.EXPECT().Load().Return(messy, nil)
+	config.EXPECT().Save(gomock.Any()).DoAndReturn(func(saved types.VendorConfig) error {
+		if !reflect.DeepEqual(saved, canonicalNormalizeConfig()) {
+			t.Errorf("saved non-canonical config: %+v", saved)
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	changed, err := syncer.NormalizeConfig()
+	if err != nil {
+		t.Fatalf("NormalizeConfig() error = %v", err)
+	}
+	if !changed {
+		t.Error("expected changed=true for messy config")
+	}
+}
+
+func TestNormalizeConfig_SharedDestinationKeepsVendorOrder(t *testing.T) {
+	// zeta is listed first and loses to alpha (later writer wins); sorting would flip that
+	cfg := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{Name: "zeta", URL: "https://github.com/owner/zeta", Specs: []types.BranchSpec{{
+				Ref: "main", Mapping: []types.PathMapping{{From: "LICENSE", To: "vendor/LICENSE"}},
+			}}},
+			{Name: "alpha", URL: "https://github.com/owner/alpha", Specs: []types.BranchSpec{{
+				Ref: "main", Mapping: []types.PathMapping{{From: "COPYING", To: "vendor/LICENSE"}},
+			}}},
+		},
+	}
+
+	got := NormalizeConfig(cfg)
+	if got.Vendors[0].Name != "zeta" || got.Vendors[1].Name != "alpha" {
+		t.Errorf("vendor order = [%s %s], want original order [zeta alpha]", got.Vendors[0].Name, got.Vendors[1].Name)
+	}
+}
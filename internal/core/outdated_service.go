@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
@@ -10,6 +11,9 @@ import (
 // OutdatedOptions configures the outdated check.
 type OutdatedOptions struct {
 	Vendor string // Filter to a specific vendor name (empty = all)
+	// Since drops refs whose upstream tip was committed longer ago than Since
+	// (0 = report every ref). Reading the date costs a shallow fetch per ref.
+	Since time.Duration
 }
 
 // OutdatedServiceInterface defines the contract for checking vendor staleness
@@ -41,7 +45,8 @@ func NewOutdatedService(configStore ConfigStore, lockStore LockStore, gitClient
 // Outdated compares locked commit hashes against upstream HEAD for each dependency.
 // Internal vendors (Source == "internal") are skipped. Unsynced vendors (no lock
 // entry) are skipped. LsRemote errors are non-fatal: the vendor is skipped with
-// the Skipped count incremented.
+// the Skipped count incremented. With opts.Since, refs last committed upstream
+// before the cutoff are listed in Filtered instead of being reported.
 func (s *OutdatedService) Outdated(ctx context.Context, opts OutdatedOptions) (*types.OutdatedResult, error) {
 	config, err := s.configStore.Load()
 	if err != nil {
@@ -62,6 +67,7 @@ func (s *OutdatedService) Outdated(ctx context.Context, opts OutdatedOptions) (*
 	}
 
 	result := &types.OutdatedResult{}
+	cutoff := time.Now().Add(-opts.Since)
 
 	for _, vendor := range config.Vendors {
 		// Skip internal vendors — no remote to query
@@ -88,14 +94,32 @@ func (s *OutdatedService) Outdated(ctx context.Context, opts OutdatedOptions) (*
 				continue
 			}
 
+			// An aliased lock entry tracks the ref it was resolved from
+			remoteRef := spec.Ref
+			if lockEntry.RefAlias != "" {
+				remoteRef = lockEntry.RefAlias
+			}
+
 			urls := ResolveVendorURLs(&vendor)
-			latestHash, err := s.lsRemoteWithFallback(ctx, urls, spec.Ref)
+			latestHash, err := lsRemoteWithFallback(ctx, s.gitClient, urls, remoteRef)
 			if err != nil {
 				// Network/auth error — skip, don't fail the entire check
 				result.Skipped++
 				continue
 			}
 
+			if opts.Since > 0 {
+				date, err := upstreamCommitDate(ctx, s.gitClient, urls, remoteRef)
+				if err != nil {
+					result.Skipped++
+					continue
+				}
+				if date.Before(cutoff) {
+					result.Filtered = append(result.Filtered, key)
+					continue
+				}
+			}
+
 			upToDate := latestHash == lockEntry.CommitHash
 			dep := types.UpdateCheckResult{
 				VendorName:  vendor.Name,
@@ -123,10 +147,10 @@ func (s *OutdatedService) Outdated(ctx context.Context, opts OutdatedOptions) (*
 // lsRemoteWithFallback tries LsRemote against each URL in order until one succeeds.
 // lsRemoteWithFallback returns the resolved hash from the first successful URL, or
 // the last error if all URLs fail.
-func (s *OutdatedService) lsRemoteWithFallback(ctx context.Context, urls []string, ref string) (string, error) {
+func lsRemoteWithFallback(ctx context.Context, gitClient GitClient, urls []string, ref string) (string, error) {
 	var lastErr error
 	for _, url := range urls {
-		hash, err := s.gitClient.LsRemote(ctx, url, ref)
+		hash, err := gitClient.LsRemote(ctx, url, ref)
 		if err == nil {
 			return hash, nil
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 	"github.com/golang/mock/gomock"
@@ -61,6 +62,29 @@ func TestOutdated_AllUpToDate(t *testing.T) {
 	}
 }
 
+// TestOutdated_RefAliasQueriesAliasedRef verifies an aliased lock entry is
+// compared against the ref it was resolved from, not the vendor.yml ref.
+func TestOutdated_RefAliasQueriesAliasedRef(t *testing.T) {
+	ctrl, git, _, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	hash := "abc123def456789012345678901234567890abcd"
+	locked := outdatedLock("mylib", "main", hash)
+	locked.Vendors[0].RefAlias = "release/2.x"
+	config.EXPECT().Load().Return(outdatedConfig("mylib", "https://github.com/org/mylib", "main"), nil)
+	lock.EXPECT().Load().Return(locked, nil)
+	git.EXPECT().LsRemote(gomock.Any(), "https://github.com/org/mylib", "release/2.x").Return(hash, nil)
+
+	svc := NewOutdatedService(config, lock, git)
+	result, err := svc.Outdated(context.Background(), OutdatedOptions{})
+	if err != nil {
+		t.Fatalf("Outdated returned error: %v", err)
+	}
+	if result.UpToDate != 1 {
+		t.Errorf("expected 1 up-to-date, got %d", result.UpToDate)
+	}
+}
+
 // TestOutdated_SomeOutdated verifies correct counts when upstream has a newer commit.
 func TestOutdated_SomeOutdated(t *testing.T) {
 	ctrl, git, _, config, lock, _ := setupMocks(t)
@@ -308,3 +332,47 @@ func TestOutdated_ContextCancellation(t *testing.T) {
 		t.Errorf("expected context.Canceled, got %v", err)
 	}
 }
+
+// TestOutdated_SinceFiltersOldCommits verifies that --since drops a vendor
+// whose newest upstream commit predates the cutoff and keeps a recent one.
+func TestOutdated_SinceFiltersOldCommits(t *testing.T) {
+	ctrl, git, _, config, lock, _ := setupMocks(t)
+	defer ctrl.Finish()
+
+	hash := "abc123def456789012345678901234567890abcd"
+	cfg := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{Name: "fresh", URL: "https://github.com/org/fresh", Specs: []types.BranchSpec{{Ref: "main"}}},
+			{Name: "quiet", URL: "https://github.com/org/quiet", Specs: []types.BranchSpec{{Ref: "main"}}},
+		},
+	}
+	lck := types.VendorLock{
+		Vendors: []types.LockDetails{
+			{Name: "fresh", Ref: "main", CommitHash: hash},
+			{Name: "quiet", Ref: "main", CommitHash: hash},
+		},
+	}
+	config.EXPECT().Load().Return(cfg, nil)
+	lock.EXPECT().Load().Return(lck, nil)
+	git.EXPECT().LsRemote(gomock.Any(), gomock.Any(), "main").Return(hash, nil).Times(2)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(nil).Times(2)
+	gomock.InOrder(
+		git.EXPECT().CommitDate(gomock.Any(), gomock.Any(), "FETCH_HEAD").Return(time.Now().Add(-2*time.Hour), nil),
+		git.EXPECT().CommitDate(gomock.Any(), gomock.Any(), "FETCH_HEAD").Return(time.Now().Add(-60*24*time.Hour), nil),
+	)
+
+	svc := NewOutdatedService(config, lock, git)
+	result, err := svc.Outdated(context.Background(), OutdatedOptions{Since: 14 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("Outdated returned error: %v", err)
+	}
+
+	if result.TotalChecked != 1 || len(result.Dependencies) != 1 || result.Dependencies[0].VendorName != "fresh" {
+		t.Fatalf("expected only fresh to be reported, got %+v", result.Dependencies)
+	}
+	if len(result.Filtered) != 1 || result.Filtered[0] != "quiet@main" {
+		t.Errorf("Filtered = %v, want [quiet@main]", result.Filtered)
+	}
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// RefChange records a spec ref rewritten by PinVendor or UnpinVendor.
+type RefChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// IsPinned reports whether spec is pinned to a commit. Pins are per spec: a
+// vendor's other specs keep following their refs.
+func IsPinned(spec types.BranchSpec) bool {
+	return spec.Pinned
+}
+
+// PinVendor freezes every spec of vendor name at its locked commit: the spec's
+// ref becomes the full commit hash from vendor.lock, pinned is set, and the
+// replaced ref is kept in pinned_from for UnpinVendor. The lock entries are
+// re-keyed to the new ref so status keeps matching config. Specs already
+// pinned are left alone; a spec without a locked commit is an error.
+func (s *VendorSyncer) PinVendor(name string) ([]RefChange, error) {
+	cfg, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	idx := FindVendorIndex(cfg.Vendors, name)
+	if idx < 0 {
+		return nil, NewVendorNotFoundError(name)
+	}
+	v := &cfg.Vendors[idx]
+	if v.Source == SourceInternal {
+		return nil, fmt.Errorf("internal vendor '%s' has no commits to pin", name)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+
+	var changes []RefChange
+	for si := range v.Specs {
+		spec := &v.Specs[si]
+		if spec.Pinned {
+			continue
+		}
+		entry := findLockEntry(lock, name, spec.Ref)
+		if entry == nil || entry.CommitHash == "" {
+			return nil, fmt.Errorf("vendor '%s' @ %s has no locked commit; run 'git-vendor pull %s' first", name, spec.Ref, name)
+		}
+		changes = append(changes, RefChange{From: spec.Ref, To: entry.CommitHash})
+		if !isFullCommitHash(spec.Ref) {
+			spec.PinnedFrom = spec.Ref // A commit ref has no branch to return to
+		}
+		spec.Ref = entry.CommitHash
+		spec.Pinned = true
+		entry.Ref = entry.CommitHash
+		entry.RefAlias = "" // The commit itself is fetched from now on
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes, s.saveRefChanges(cfg, lock)
+}
+
+// UnpinVendor reverts PinVendor for vendor name: each pinned spec's ref is set
+// back to ref, or to its pinned_from when ref is empty, and pinned is cleared.
+// The lock keeps the pinned commit (re-keyed to the restored ref) until the
+// next update moves it. ref may only be given when one spec is pinned.
+func (s *VendorSyncer) UnpinVendor(name, ref string) ([]RefChange, error) {
+	cfg, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	idx := FindVendorIndex(cfg.Vendors, name)
+	if idx < 0 {
+		return nil, NewVendorNotFoundError(name)
+	}
+	v := &cfg.Vendors[idx]
+
+	pinned := 0
+	for _, spec := range v.Specs {
+		if spec.Pinned {
+			pinned++
+		}
+	}
+	if ref != "" && pinned > 1 {
+		return nil, fmt.Errorf("vendor '%s' has %d pinned specs; unpin without --ref to restore each one's pinned_from", name, pinned)
+	}
+
+	//nolint:errcheck // Lock file may not exist; config is unpinned regardless
+	lock, _ := s.lockStore.Load()
+	var changes []RefChange
+	for si := range v.Specs {
+		spec := &v.Specs[si]
+		if !spec.Pinned {
+			continue
+		}
+		target := ref
+		if target == "" {
+			target = spec.PinnedFrom
+		}
+		if target == "" {
+			return nil, fmt.Errorf("vendor '%s' @ %s does not record the ref it was pinned from; pass --ref <branch>", name, spec.Ref)
+		}
+		changes = append(changes, RefChange{From: spec.Ref, To: target})
+		if entry := findLockEntry(lock, name, spec.Ref); entry != nil {
+			entry.Ref = target
+		}
+		spec.Ref = target
+		spec.Pinned = false
+		spec.PinnedFrom = ""
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes, s.saveRefChanges(cfg, lock)
+}
+
+// saveRefChanges writes vendor.yml and then vendor.lock after a pin or unpin.
+func (s *VendorSyncer) saveRefChanges(cfg types.VendorConfig, lock types.VendorLock) error {
+	if err := s.configStore.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	if len(lock.Vendors) == 0 {
+		return nil
+	}
+	if err := s.lockStore.Save(lock); err != nil {
+		return fmt.Errorf("save lockfile: %w", err)
+	}
+	return nil
+}
+
+// findLockEntry returns the lock entry for name@ref, or nil.
+func findLockEntry(lock types.VendorLock, name, ref string) *types.LockDetails {
+	for i := range lock.Vendors {
+		if lock.Vendors[i].Name == name && lock.Vendors[i].Ref == ref {
+			return &lock.Vendors[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+func TestPinVendor_SetsRefToLockedCommit(t *testing.T) {
+	dir := t.TempDir()
+	configStore, lockStore := NewFileConfigStore(dir), NewFileLockStore(dir)
+	commit := strings.Repeat("a", 40)
+	if err := configStore.Save(createTestConfig(createTestVendorSpec("lib", "https://github.com/owner/lib", "main"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := lockStore.Save(types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "lib", Ref: "main", CommitHash: commit, FileHashes: map[string]string{"lib/file.go": "h"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	syncer := &VendorSyncer{configStore: configStore, lockStore: lockStore}
+
+	changes, err := syncer.PinVendor("lib")
+	if err != nil {
+		t.Fatalf("PinVendor: %v", err)
+	}
+	if len(changes) != 1 || changes[0] != (RefChange{From: "main", To: commit}) {
+		t.Errorf("changes = %+v", changes)
+	}
+
+	cfg, _ := configStore.Load()
+	spec := cfg.Vendors[0].Specs[0]
+	if spec.Ref != commit || !spec.Pinned || spec.PinnedFrom != "main" {
+		t.Errorf("spec = %+v, want ref %s pinned from main", spec, commit)
+	}
+	lock, _ := lockStore.Load()
+	if lock.Vendors[0].Ref != commit || lock.Vendors[0].CommitHash != commit {
+		t.Errorf("lock entry = %+v, want re-keyed to the commit", lock.Vendors[0])
+	}
+
+	// Pinning again changes nothing
+	if changes, err := syncer.PinVendor("lib"); err != nil || len(changes) != 0 {
+		t.Errorf("second PinVendor = %+v, %v; want no changes", changes, err)
+	}
+
+	changes, err = syncer.UnpinVendor("lib", "")
+	if err != nil {
+		t.Fatalf("UnpinVendor: %v", err)
+	}
+	if len(changes) != 1 || changes[0] != (RefChange{From: commit, To: "main"}) {
+		t.Errorf("unpin changes = %+v", changes)
+	}
+	cfg, _ = configStore.Load()
+	if spec := cfg.Vendors[0].Specs[0]; spec.Ref != "main" || spec.Pinned || spec.PinnedFrom != "" {
+		t.Errorf("unpinned spec = %+v, want ref main", spec)
+	}
+	lock, _ = lockStore.Load()
+	if lock.Vendors[0].Ref != "main" || lock.Vendors[0].CommitHash != commit {
+		t.Errorf("unpinned lock entry = %+v, want main at the pinned commit", lock.Vendors[0])
+	}
+}
+
+func TestPinVendor_RequiresLockedCommit(t *testing.T) {
+	config := createTestConfig(createTestVendorSpec("lib", "https://github.com/owner/lib", "main"))
+	syncer := &VendorSyncer{configStore: &stubConfigStore{config: config}, lockStore: &stubLockStore{}}
+
+	if _, err := syncer.PinVendor("lib"); err == nil || !strings.Contains(err.Error(), "no locked commit") {
+		t.Errorf("PinVendor error = %v, want missing locked commit", err)
+	}
+	if _, err := syncer.PinVendor("missing"); !IsVendorNotFound(err) {
+		t.Errorf("PinVendor error = %v, want VendorNotFoundError", err)
+	}
+}
+
+func TestUpdateAll_SkipsPinnedVendor(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	commit := strings.Repeat("b", 40)
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", commit)
+	vendor.Specs[0].Pinned = true
+	vendor.Specs[0].PinnedFrom = "main"
+	locked := types.LockDetails{Name: "lib", Ref: commit, CommitHash: commit, FileHashes: map[string]string{"lib/file.go": "h"}}
+
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{locked}}, nil)
+	// No git or filesystem calls: the pinned vendor is not fetched
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		if len(l.Vendors) != 1 || l.Vendors[0].Ref != commit || l.Vendors[0].CommitHash != commit {
+			t.Errorf("pinned lock entry should be kept unchanged, got %+v", l.Vendors)
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+}
+
+func TestUpdateAll_SkipsOnlyPinnedSpec(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	pinnedCommit := strings.Repeat("d", 40)
+	latest := strings.Repeat("e", 40)
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	vendor.Specs = append(vendor.Specs, types.BranchSpec{
+		Ref: pinnedCommit, Pinned: true, PinnedFrom: "v1",
+		Mapping: []types.PathMapping{{From: "old.go", To: "lib/old.go"}},
+	})
+	pinnedEntry := types.LockDetails{Name: "lib", Ref: pinnedCommit, CommitHash: pinnedCommit}
+
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "lib", Ref: "main", CommitHash: strings.Repeat("f", 40)},
+		pinnedEntry,
+	}}, nil)
+	// Only the unpinned spec is fetched
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/mixed", nil)
+	fs.EXPECT().RemoveAll("/tmp/mixed").Return(nil)
+	git.EXPECT().Init(gomock.Any(), "/tmp/mixed").Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), "/tmp/mixed", "origin", vendor.URL).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/mixed", "origin", 1, "main").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), "/tmp/mixed", "FETCH_HEAD").Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/mixed").Return(latest, nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		got := make(map[string]string)
+		for _, e := range l.Vendors {
+			got[e.Ref] = e.CommitHash
+		}
+		if len(l.Vendors) != 2 || got["main"] != latest || got[pinnedCommit] != pinnedCommit {
+			t.Errorf("want main updated and the pinned entry kept, got %+v", l.Vendors)
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+}
+
+func TestUpdateAll_IncludePinnedUpdatesPinnedVendor(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	commit := strings.Repeat("c", 40)
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", commit)
+	vendor.Specs[0].Pinned = true
+
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil)
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/pinned", nil)
+	fs.EXPECT().RemoveAll("/tmp/pinned").Return(nil)
+	git.EXPECT().Init(gomock.Any(), "/tmp/pinned").Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), "/tmp/pinned", "origin", vendor.URL).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/pinned", "origin", 1, commit).Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), "/tmp/pinned", "FETCH_HEAD").Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/pinned").Return(commit, nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if err := syncer.UpdateAllWithOptions(context.Background(), UpdateOptions{IncludePinned: true}); err != nil {
+		t.Fatalf("UpdateAllWithOptions: %v", err)
+	}
+}
@@ -67,10 +67,10 @@ func (p *PolicyService) EvaluatePolicy(config *types.VendorConfig, statusResult
 		}
 		resolved := types.ResolvedPolicy(globalPolicy, perVendor)
 
-		// Check drift: unacknowledged modifications and deletions (I6).
+		// Check drift: unacknowledged modifications, deletions and truncations (I6).
 		// Distinguish between modified and deleted files in the violation message
 		// so the commit guard can provide targeted resolution guidance.
-		unackedDrift := v.FilesModified + v.FilesDeleted
+		unackedDrift := v.FilesModified + v.FilesDeleted + v.FilesTruncated
 		if unackedDrift > 0 {
 			severity := "warning"
 			if *resolved.BlockOnDrift {
@@ -83,6 +83,9 @@ func (p *PolicyService) EvaluatePolicy(config *types.VendorConfig, statusResult
 			} else if v.FilesDeleted > 0 {
 				msg = fmt.Sprintf("%s has %d deleted vendored file(s) (restore with 'pull' or remove mapping)",
 					v.Name, v.FilesDeleted)
+			} else if v.FilesTruncated == unackedDrift {
+				msg = fmt.Sprintf("%s has %d truncated (emptied) vendored file(s) (re-sync with 'pull --locked')",
+					v.Name, v.FilesTruncated)
 			}
 			violations = append(violations, types.PolicyViolation{
 				VendorName: v.Name,
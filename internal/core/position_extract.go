@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -40,6 +41,11 @@ func extractFromContent(data string, pos *types.PositionSpec, filePath string) (
 	lines := strings.Split(data, "\n")
 	totalLines := len(lines)
 
+	pos, err := resolveAnchors(lines, pos, filePath)
+	if err != nil {
+		return "", err
+	}
+
 	// Validate start line
 	if pos.StartLine > totalLines {
 		return "", fmt.Errorf("line %d does not exist in %s (%d lines)", pos.StartLine, filePath, totalLines)
@@ -67,6 +73,75 @@ func extractFromContent(data string, pos *types.PositionSpec, filePath string) (
 	return strings.Join(extracted, "\n"), nil
 }
 
+// ResolvePosition reads filePath and returns pos with its regex anchors
+// resolved to line numbers (see resolveAnchors). Non-anchored specs are
+// returned unchanged without reading the file.
+func ResolvePosition(filePath string, pos *types.PositionSpec) (*types.PositionSpec, error) {
+	if !pos.IsAnchored() {
+		return pos, nil
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", filePath, err)
+	}
+	return resolveAnchors(strings.Split(normalizeCRLF(string(data)), "\n"), pos, filePath)
+}
+
+// resolveAnchors converts an anchored PositionSpec into a line range over
+// lines: StartLine is the first line matching StartPattern, EndLine the first
+// later line matching EndPattern (the start line itself when EndPattern is
+// empty). Symbol specs resolve via resolveSymbol. Either anchor failing to
+// match is an error rather than a silent fallback. Non-anchored specs are
+// returned unchanged.
+func resolveAnchors(lines []string, pos *types.PositionSpec, filePath string) (*types.PositionSpec, error) {
+	if !pos.IsAnchored() {
+		return pos, nil
+	}
+	if pos.Symbol != "" {
+		return resolveSymbol(lines, pos.Symbol, filePath)
+	}
+	startRe, err := regexp.Compile(pos.StartPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid anchor pattern /%s/: %w", pos.StartPattern, err)
+	}
+	start := matchLine(lines, startRe, 0)
+	if start < 0 {
+		return nil, fmt.Errorf("start anchor /%s/ not found in %s", pos.StartPattern, filePath)
+	}
+
+	end := start
+	if pos.EndPattern != "" {
+		endRe, err := regexp.Compile(pos.EndPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid anchor pattern /%s/: %w", pos.EndPattern, err)
+		}
+		end = matchLine(lines, endRe, start+1)
+		if end < 0 {
+			return nil, fmt.Errorf("end anchor /%s/ not found after line %d in %s", pos.EndPattern, start+1, filePath)
+		}
+	}
+
+	return &types.PositionSpec{StartLine: start + 1, EndLine: end + 1}, nil
+}
+
+// matchLine returns the 0-indexed first line at or after from matching re, or -1.
+func matchLine(lines []string, re *regexp.Regexp, from int) int {
+	for i := from; i < len(lines); i++ {
+		if re.MatchString(lines[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lineRange formats a resolved line position as "L<n>" or "L<n>-L<m>".
+func lineRange(pos *types.PositionSpec) string {
+	if pos.IsSingleLine() {
+		return fmt.Sprintf("L%d", pos.StartLine)
+	}
+	return fmt.Sprintf("L%d-L%d", pos.StartLine, pos.EndLine)
+}
+
 // extractColumns handles column-precise extraction.
 //
 // StartCol boundary asymmetry (intentional):
@@ -141,7 +216,7 @@ func PlaceContent(filePath string, content string, pos *types.PositionSpec) erro
 
 	if pos == nil {
 		// Replace entire file
-		return os.WriteFile(filePath, []byte(content), 0644)
+		return writeFileUnlinked(filePath, []byte(content), 0644)
 	}
 
 	// Read existing target
@@ -159,7 +234,7 @@ func PlaceContent(filePath string, content string, pos *types.PositionSpec) erro
 		return err
 	}
 
-	return os.WriteFile(filePath, []byte(result), 0644)
+	return writeFileUnlinked(filePath, []byte(result), 0644)
 }
 
 // placeInContent replaces a range in existing content with new content.
@@ -169,6 +244,11 @@ func placeInContent(existing, replacement string, pos *types.PositionSpec, fileP
 	lines := strings.Split(existing, "\n")
 	totalLines := len(lines)
 
+	pos, err := resolveAnchors(lines, pos, filePath)
+	if err != nil {
+		return "", err
+	}
+
 	if pos.StartLine > totalLines {
 		return "", fmt.Errorf("target line %d does not exist in %s (%d lines)", pos.StartLine, filePath, totalLines)
 	}
@@ -2324,3 +2324,85 @@ func TestPlaceContent_LastLine(t *testing.T) {
 		t.Errorf("content = %q, want %q", string(got), "a\nb\nC_REPLACED")
 	}
 }
+
+// ============================================================================
+// Regex Anchor Tests
+// ============================================================================
+
+func TestExtractPosition_Anchors_SnippetShiftedDown(t *testing.T) {
+	tempDir := t.TempDir()
+	srcPath := filepath.Join(tempDir, "api.go")
+	pos := &types.PositionSpec{StartPattern: `^func Foo\(`, EndPattern: `^}`}
+	snippet := "func Foo() int {\n\treturn 42\n}"
+	svc := NewFileCopyService(NewOSFileSystem())
+
+	var hashes, lines []string
+	for _, content := range []string{
+		"package api\n\n" + snippet + "\n",
+		"package api\n\n// Foo answers.\n" + snippet + "\n", // upstream added a comment above
+	} {
+		if err := os.WriteFile(srcPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		destPath := filepath.Join(tempDir, "foo.go")
+		stats, err := svc.copyWithPosition(srcPath, destPath, pos, nil, "v", "main", "api.go", "api.go:/^func Foo\\(/,/^}/", "foo.go")
+		if err != nil {
+			t.Fatalf("copyWithPosition: %v", err)
+		}
+		got, err := os.ReadFile(destPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != snippet {
+			t.Errorf("dest = %q, want %q", got, snippet)
+		}
+		hashes = append(hashes, stats.Positions[0].SourceHash)
+		lines = append(lines, stats.Positions[0].SourceLines)
+	}
+
+	if hashes[0] != hashes[1] {
+		t.Errorf("source hash changed after shift: %s vs %s", hashes[0], hashes[1])
+	}
+	if lines[0] != "L3-L5" || lines[1] != "L4-L6" {
+		t.Errorf("SourceLines = %v, want [L3-L5 L4-L6]", lines)
+	}
+}
+
+func TestExtractPosition_Anchors_NotFound(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "api.go")
+	if err := os.WriteFile(filePath, []byte("package api\n\nfunc Foo() {\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := ExtractPosition(filePath, &types.PositionSpec{StartPattern: "func Bar"})
+	if err == nil || !strings.Contains(err.Error(), "start anchor /func Bar/ not found") {
+		t.Errorf("missing start anchor: err = %v", err)
+	}
+	_, _, err = ExtractPosition(filePath, &types.PositionSpec{StartPattern: "func Foo", EndPattern: "^}"})
+	if err == nil || !strings.Contains(err.Error(), "end anchor /^}/ not found after line 3") {
+		t.Errorf("missing end anchor: err = %v", err)
+	}
+}
+
+func TestResolvePosition_SingleLineAnchor(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "version.go")
+	if err := os.WriteFile(filePath, []byte("package v\r\n\r\nconst Version = \"1.2\"\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := ResolvePosition(filePath, &types.PositionSpec{StartPattern: `^const Version`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.StartLine != 3 || lineRange(resolved) != "L3" {
+		t.Errorf("resolved = %+v (%s), want L3", resolved, lineRange(resolved))
+	}
+
+	// Line-number specs pass through without reading the file
+	plain := &types.PositionSpec{StartLine: 2, EndLine: 4}
+	if got, err := ResolvePosition(filepath.Join(tempDir, "missing.go"), plain); err != nil || got != plain {
+		t.Errorf("ResolvePosition(plain) = %+v, %v", got, err)
+	}
+}
@@ -67,6 +67,13 @@ func (s *stubGitClient) GetCommitLog(_ context.Context, _, _, _ string, _ int) (
 func (s *stubGitClient) GetTagForCommit(_ context.Context, _, _ string) (string, error) {
 	return "", nil
 }
+func (s *stubGitClient) ResolveRef(_ context.Context, _, _ string) (string, error) {
+	return s.headHash, nil
+}
+func (s *stubGitClient) ExportTree(_ context.Context, _, _, _ string) error { return nil }
+func (s *stubGitClient) CommitDate(_ context.Context, _, _ string) (time.Time, error) {
+	return time.Now(), nil
+}
 func (s *stubGitClient) Add(_ context.Context, _ string, _ ...string) error { return nil }
 func (s *stubGitClient) Commit(_ context.Context, _ string, _ types.CommitOptions) error {
 	return nil
@@ -84,6 +91,9 @@ func (s *stubGitClient) LsRemote(_ context.Context, _, _ string) (string, error)
 }
 func (s *stubGitClient) Push(_ context.Context, _, _, _ string) error       { return nil }
 func (s *stubGitClient) CreateBranch(_ context.Context, _, _, _ string) error { return nil }
+func (s *stubGitClient) IsAncestor(_ context.Context, _, _, _ string) (bool, error) {
+	return true, nil
+}
 
 // stubLicenseService and stubHookExecutor are defined in testhelpers_gomock_test.go.
 
@@ -157,7 +167,7 @@ func newPositionTestEnv(t *testing.T, sourceFiles map[string]string, commitHash
 		configStore, lockStore, git, osFS, fileCopy,
 		&stubLicenseService{}, cacheStore, &stubHookExecutor{}, ui, rootDir, nil,
 	)
-	updateSvc := NewUpdateService(configStore, lockStore, syncSvc, nil, cacheStore, ui, rootDir)
+	updateSvc := NewUpdateService(configStore, lockStore, syncSvc, nil, nil, cacheStore, ui, rootDir)
 	verifySvc := NewVerifyService(configStore, lockStore, cacheStore, osFS, rootDir)
 
 	return &positionTestEnv{
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// positionRelocation records a position mapping whose source range moved
+// upstream: From is the mapping's previous "from", To the rewritten one. Ref is
+// the vendor.yml ref of the mapping's spec; UpdateService fills it in.
+type positionRelocation struct {
+	Ref  string
+	From string
+	To   string
+}
+
+// relocatePositions looks for line-range position mappings in spec whose
+// content at the recorded range no longer hashes to the SourceHash in locked
+// (the previous lock's Positions for this ref). For each, the upstream file in
+// tempDir is searched for a block of the same line count with that hash. A
+// unique match rewrites the mapping's "from" to the new range; none or several
+// leave it unchanged and produce a warning.
+//
+// Returns spec with relocated mappings (a copy when anything moved), the
+// relocations, and the warnings. Anchored, column and -EOF positions are not
+// relocated: the first two locate themselves and -EOF ranges change length.
+func relocatePositions(tempDir string, spec types.BranchSpec, locked []types.PositionLock) (types.BranchSpec, []positionRelocation, []string) {
+	lockedHashes := make(map[string]string, len(locked))
+	for _, p := range locked {
+		lockedHashes[p.From] = p.SourceHash
+	}
+
+	var relocations []positionRelocation
+	var warnings []string
+	mappings := spec.Mapping
+	copied := false
+	for i, m := range spec.Mapping {
+		oldHash, ok := lockedHashes[m.From]
+		if !ok {
+			continue
+		}
+		rawFile, pos, err := types.ParsePathPosition(m.From)
+		if err != nil || pos == nil || pos.IsAnchored() || pos.HasColumns() || pos.ToEOF {
+			continue
+		}
+		srcFile, _, err := types.ParsePathPosition(cleanMappingSource(m.From, spec.Ref))
+		if err != nil {
+			continue
+		}
+		srcPath := filepath.Join(tempDir, srcFile)
+		if _, hash, err := ExtractPosition(srcPath, pos); err == nil && hash == oldHash {
+			continue
+		}
+
+		data, err := os.ReadFile(srcPath)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("cannot relocate %s: %v", m.From, err))
+			continue
+		}
+		matches := findHashedBlock(strings.Split(normalizeCRLF(string(data)), "\n"), lineCount(pos), oldHash)
+		switch len(matches) {
+		case 0:
+			warnings = append(warnings, fmt.Sprintf("cannot relocate %s: locked content not found upstream", m.From))
+		case 1:
+			moved := &types.PositionSpec{StartLine: matches[0], EndLine: matches[0] + lineCount(pos) - 1}
+			newFrom := rawFile + ":" + lineRange(moved)
+			if !copied {
+				mappings = append([]types.PathMapping(nil), spec.Mapping...)
+				copied = true
+			}
+			mappings[i].From = newFrom
+			relocations = append(relocations, positionRelocation{From: m.From, To: newFrom})
+		default:
+			warnings = append(warnings, fmt.Sprintf("cannot relocate %s: locked content found at %d places upstream", m.From, len(matches)))
+		}
+	}
+
+	spec.Mapping = mappings
+	return spec, relocations, warnings
+}
+
+// lineCount returns the number of lines a line-range PositionSpec covers.
+func lineCount(pos *types.PositionSpec) int {
+	if pos.IsSingleLine() {
+		return 1
+	}
+	return pos.EndLine - pos.StartLine + 1
+}
+
+// findHashedBlock returns the 1-indexed start line of every block of n
+// consecutive lines whose "\n"-joined content hashes (as ExtractPosition
+// does) to hash.
+func findHashedBlock(lines []string, n int, hash string) []int {
+	var starts []int
+	for i := 0; i+n <= len(lines); i++ {
+		block := strings.Join(lines[i:i+n], "\n")
+		if fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(block))) == hash {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// lockedPositions returns SyncOptions.RelocatePositions for v: the positions
+// recorded in existing (lock entries keyed "name@ref") for each of v's refs.
+func lockedPositions(v *types.VendorSpec, existing map[string]types.LockDetails) map[string][]types.PositionLock {
+	positions := make(map[string][]types.PositionLock)
+	for _, spec := range v.Specs {
+		lockRef, _ := lockRefFor(v, spec.Ref)
+		if entry, ok := existing[v.Name+"@"+lockRef]; ok && len(entry.Positions) > 0 {
+			positions[spec.Ref] = entry.Positions
+		}
+	}
+	return positions
+}
+
+// applyRelocations rewrites the "from" of every relocated mapping in vendor.yml
+// (keyed by vendor name) and saves it. vendor.yml is reloaded rather than
+// reusing the in-memory config, which may carry ref_aliases redirections.
+func (s *UpdateService) applyRelocations(relocations map[string][]positionRelocation) error {
+	if len(relocations) == 0 {
+		return nil
+	}
+	config, err := s.configStore.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	for vi := range config.Vendors {
+		v := &config.Vendors[vi]
+		for _, r := range relocations[v.Name] {
+			for si := range v.Specs {
+				if v.Specs[si].Ref != r.Ref {
+					continue
+				}
+				for mi := range v.Specs[si].Mapping {
+					if v.Specs[si].Mapping[mi].From == r.From {
+						v.Specs[si].Mapping[mi].From = r.To
+					}
+				}
+			}
+			s.ui.ShowSuccess(fmt.Sprintf("Relocated %s: %s -> %s", v.Name, r.From, r.To))
+		}
+	}
+	return s.configStore.Save(config)
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+func snippetHash(content string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(content)))
+}
+
+func TestUpdateAll_Relocate_RewritesMovedSnippet(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+
+	const snippet = "func B() {}\nfunc C() {}"
+	config := types.VendorConfig{Vendors: []types.VendorSpec{{
+		Name: "lib",
+		URL:  "https://github.com/owner/lib",
+		Specs: []types.BranchSpec{{
+			Ref:     "main",
+			Mapping: []types.PathMapping{{From: "api.go:L2-L3", To: "vendor/snippet.go"}},
+		}},
+	}}}
+	previous := types.VendorLock{Vendors: []types.LockDetails{{
+		Name: "lib", Ref: "main", CommitHash: "old",
+		Positions: []types.PositionLock{{From: "api.go:L2-L3", To: "vendor/snippet.go", SourceHash: snippetHash(snippet)}},
+	}}}
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", gomock.Any()).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), FetchHead).DoAndReturn(
+		func(_ context.Context, dir, _ string) error {
+			// Upstream inserted three lines above the snippet
+			content := "package api\n// one\n// two\n// three\n" + snippet + "\n"
+			return os.WriteFile(filepath.Join(dir, "api.go"), []byte(content), 0644)
+		})
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("new123456789", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	var savedConfig types.VendorConfig
+	var savedLock types.VendorLock
+	configStore.EXPECT().Load().Return(config, nil).AnyTimes()
+	configStore.EXPECT().Save(gomock.Any()).DoAndReturn(func(c types.VendorConfig) error {
+		savedConfig = c
+		return nil
+	})
+	lockStore.EXPECT().Load().Return(previous, nil)
+	lockStore.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		savedLock = l
+		return nil
+	})
+
+	rootDir := filepath.Join(workDir, VendorDir)
+	syncer := NewVendorSyncer(configStore, lockStore, git, NewOSFileSystem(), nil, rootDir, &SilentUICallback{}, nil)
+	if err := syncer.update.UpdateAllWithOptions(context.Background(), UpdateOptions{Relocate: true}); err != nil {
+		t.Fatalf("UpdateAllWithOptions: %v", err)
+	}
+
+	if got := savedConfig.Vendors[0].Specs[0].Mapping[0].From; got != "api.go:L5-L6" {
+		t.Errorf("vendor.yml from = %q, want api.go:L5-L6", got)
+	}
+	positions := savedLock.Vendors[0].Positions
+	if len(positions) != 1 || positions[0].From != "api.go:L5-L6" || positions[0].SourceHash != snippetHash(snippet) {
+		t.Errorf("lock positions = %+v, want relocated range with unchanged hash", positions)
+	}
+	data, err := os.ReadFile("vendor/snippet.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != snippet {
+		t.Errorf("destination = %q, want %q", data, snippet)
+	}
+}
+
+func TestRelocatePositions_AmbiguousOrMissingLeftAlone(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "x\nfunc A() {}\ny\nfunc A() {}\nz\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "a.go"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{
+		{From: "a.go:L1", To: "dup.go"},
+		{From: "a.go:L3", To: "gone.go"},
+		{From: "a.go:L5", To: "same.go"},
+		{From: "a.go:L1-EOF", To: "eof.go"},
+	}}
+	locked := []types.PositionLock{
+		{From: "a.go:L1", SourceHash: snippetHash("func A() {}")},
+		{From: "a.go:L3", SourceHash: snippetHash("func Gone() {}")},
+		{From: "a.go:L5", SourceHash: snippetHash("z")},
+		{From: "a.go:L1-EOF", SourceHash: snippetHash("old")},
+	}
+
+	got, relocations, warnings := relocatePositions(tempDir, spec, locked)
+	if len(relocations) != 0 {
+		t.Errorf("relocations = %+v, want none", relocations)
+	}
+	for i, m := range got.Mapping {
+		if m.From != spec.Mapping[i].From {
+			t.Errorf("mapping %d from = %q, want unchanged %q", i, m.From, spec.Mapping[i].From)
+		}
+	}
+	want := []string{
+		"cannot relocate a.go:L1: locked content found at 2 places upstream",
+		"cannot relocate a.go:L3: locked content not found upstream",
+	}
+	if fmt.Sprint(warnings) != fmt.Sprint(want) {
+		t.Errorf("warnings = %q, want %q", warnings, want)
+	}
+}
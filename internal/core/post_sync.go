@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// runPostSync runs v's one post-sync step after it synced: hooks.post_sync,
+// run from the project root as before, or else the post_sync command (see
+// runVendorPostSync). validateVendor rejects a vendor setting both. Reports
+// whether a command ran.
+func (s *SyncService) runPostSync(v *types.VendorSpec, opts SyncOptions, stats *CopyStats, hookCtx types.HookContext) (bool, error) {
+	if v.Hooks != nil && v.Hooks.PostSync != "" {
+		if err := s.hooks.ExecutePostSync(v, &hookCtx); err != nil {
+			return true, fmt.Errorf("post-sync hook failed: %w", err)
+		}
+		return true, nil
+	}
+	return s.runVendorPostSync(v, opts, stats, hookCtx)
+}
+
+// runVendorPostSync runs v's post_sync command in its destination root after
+// the vendor synced successfully. The command only runs with opts.AllowHooks
+// and never on a dry run; without AllowHooks a warning records the skip. Each
+// line the command prints is added to stats.Warnings. Reports whether the
+// command ran, since it may have rewritten files hashed during the copy.
+func (s *SyncService) runVendorPostSync(v *types.VendorSpec, opts SyncOptions, stats *CopyStats, hookCtx types.HookContext) (bool, error) {
+	if v.PostSync == "" || opts.DryRun {
+		return false, nil
+	}
+	if !opts.AllowHooks {
+		w := fmt.Sprintf("post_sync for %s skipped (pass --allow-hooks to run it)", v.Name)
+		stats.Warnings = append(stats.Warnings, w)
+		fmt.Printf("  ⚠ %s\n", w)
+		return false, nil
+	}
+
+	dir := vendorDestinationRoot(v)
+	fmt.Printf("  🪝 Running post_sync in %s...\n", dir)
+	s.logger.Debugf("%s: post_sync in %s: %s", v.Name, dir, v.PostSync)
+	output, err := s.hooks.ExecuteVendorPostSync(v, dir, &hookCtx)
+	for _, line := range strings.Split(strings.TrimRight(output, "\r\n"), "\n") {
+		if line = strings.TrimRight(line, "\r"); line == "" {
+			continue
+		}
+		w := "post_sync: " + line
+		stats.Warnings = append(stats.Warnings, w)
+		fmt.Printf("  ⚠ %s\n", w)
+	}
+	if err != nil {
+		return true, fmt.Errorf("post_sync failed: %w", err)
+	}
+	return true, nil
+}
+
+// vendorDestinationRoot returns the deepest directory containing every
+// destination of v: a directory destination counts itself, a file
+// destination its parent. Returns "." when the destinations share no
+// directory below the project root.
+func vendorDestinationRoot(v *types.VendorSpec) string {
+	var dirs []string
+	for _, spec := range v.Specs {
+		for _, mapping := range spec.Mapping {
+			destRaw := (&FileCopyService{}).computeDestPath(mapping, spec, v)
+			dest, _, err := types.ParsePathPosition(destRaw)
+			if err != nil {
+				dest = destRaw
+			}
+			dest = path.Clean(filepath.ToSlash(dest))
+			if info, err := os.Stat(dest); err == nil && info.IsDir() {
+				dirs = append(dirs, dest)
+			} else {
+				dirs = append(dirs, path.Dir(dest))
+			}
+		}
+	}
+	if len(dirs) == 0 {
+		return "."
+	}
+	return commonDirPrefix(dirs)
+}
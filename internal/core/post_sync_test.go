@@ -0,0 +1,112 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// postSyncTestVendor maps two files under third_party/lib, so the vendor's
+// destination root is third_party/lib.
+func postSyncTestVendor(command string) *types.VendorSpec {
+	return &types.VendorSpec{
+		Name:     "lib",
+		PostSync: command,
+		Specs: []types.BranchSpec{{
+			Ref: "main",
+			Mapping: []types.PathMapping{
+				{From: "a.go", To: "third_party/lib/a.go"},
+				{From: "pkg", To: "third_party/lib/pkg"},
+			},
+		}},
+	}
+}
+
+func pwdCommand() string {
+	if runtime.GOOS == "windows" {
+		return "cd"
+	}
+	return "pwd"
+}
+
+func TestRunVendorPostSync_RunsInDestinationRoot(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+	if err := os.MkdirAll(filepath.Join("third_party", "lib", "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := &SyncService{hooks: NewHookService(&SilentUICallback{})}
+	var stats CopyStats
+	ran, err := svc.runVendorPostSync(postSyncTestVendor(pwdCommand()), SyncOptions{AllowHooks: true}, &stats, types.HookContext{VendorName: "lib"})
+	if err != nil || !ran {
+		t.Fatalf("runVendorPostSync = %v, %v; want ran, nil", ran, err)
+	}
+
+	if len(stats.Warnings) != 1 || !strings.HasPrefix(stats.Warnings[0], "post_sync: ") {
+		t.Fatalf("Warnings = %q, want the command's one line of output", stats.Warnings)
+	}
+	got, err := filepath.EvalSymlinks(strings.TrimPrefix(stats.Warnings[0], "post_sync: "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := filepath.EvalSymlinks(filepath.Join(workDir, "third_party", "lib"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("post_sync ran in %s, want %s", got, want)
+	}
+}
+
+func TestRunVendorPostSync_SkippedOnDryRunAndWithoutAllowHooks(t *testing.T) {
+	chdirUnmanagedTest(t)
+	if err := os.MkdirAll(filepath.Join("third_party", "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	marker, err := filepath.Abs("ran.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendor := postSyncTestVendor("echo ran > " + marker)
+	svc := &SyncService{hooks: NewHookService(&SilentUICallback{})}
+
+	var dryStats CopyStats
+	if ran, err := svc.runVendorPostSync(vendor, SyncOptions{AllowHooks: true, DryRun: true}, &dryStats, types.HookContext{}); ran || err != nil {
+		t.Errorf("dry run: runVendorPostSync = %v, %v; want not run", ran, err)
+	}
+
+	var stats CopyStats
+	if ran, err := svc.runVendorPostSync(vendor, SyncOptions{}, &stats, types.HookContext{}); ran || err != nil {
+		t.Errorf("without --allow-hooks: runVendorPostSync = %v, %v; want not run", ran, err)
+	}
+	if len(stats.Warnings) != 1 || !strings.Contains(stats.Warnings[0], "--allow-hooks") {
+		t.Errorf("Warnings = %q, want a skipped post_sync warning", stats.Warnings)
+	}
+
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("post_sync must not run on dry run or without --allow-hooks (stat err = %v)", err)
+	}
+}
+
+func TestRunPostSync_HooksPostSyncRunsAlone(t *testing.T) {
+	chdirUnmanagedTest(t)
+	if err := os.MkdirAll(filepath.Join("third_party", "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	vendor := postSyncTestVendor("")
+	vendor.Hooks = &types.HookConfig{PostSync: "echo hook"}
+	svc := &SyncService{hooks: NewHookService(&SilentUICallback{})}
+
+	var stats CopyStats
+	ran, err := svc.runPostSync(vendor, SyncOptions{}, &stats, types.HookContext{RootDir: "."})
+	if err != nil || !ran {
+		t.Fatalf("runPostSync = %v, %v; want hooks.post_sync to run", ran, err)
+	}
+	if len(stats.Warnings) != 0 {
+		t.Errorf("Warnings = %q, want none: hooks.post_sync needs no --allow-hooks", stats.Warnings)
+	}
+}
@@ -47,8 +47,7 @@ func (p *BitbucketProvider) ParseURL(rawURL string) (string, string, string, err
 	if matches != nil {
 		owner := matches[2]
 		repo := matches[3]
-		ref := matches[4]
-		path := matches[5]
+		ref, path := SplitSlashedRef(matches[4], matches[5])
 
 		baseURL := fmt.Sprintf("https://bitbucket.org/%s/%s", owner, repo)
 		return baseURL, ref, path, nil
@@ -39,8 +39,7 @@ func (p *GitHubProvider) ParseURL(rawURL string) (string, string, string, error)
 	if len(matches) == 5 {
 		// Deep link with ref and path
 		baseURL := "https://" + matches[1]
-		ref := matches[3]
-		path := matches[4]
+		ref, path := SplitSlashedRef(matches[3], matches[4])
 		return baseURL, ref, path, nil
 	}
 
@@ -59,8 +59,7 @@ func (p *GitLabProvider) ParseURL(rawURL string) (string, string, string, error)
 		host := matches[2]        // gitlab.com or gitlab.example.com
 		projectPath := matches[3] // owner/repo or owner/group/subgroup/repo
 		// matches[4] is blob|tree (not needed)
-		ref := matches[5]
-		path := matches[6]
+		ref, path := SplitSlashedRef(matches[5], matches[6])
 
 		baseURL := protocol + host + "/" + projectPath
 		return baseURL, ref, path, nil
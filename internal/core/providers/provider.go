@@ -1,5 +1,7 @@
 package providers
 
+import "strings"
+
 // GitHostingProvider abstracts git hosting platform-specific operations
 type GitHostingProvider interface {
 	// Name returns the provider identifier ("github", "gitlab", "bitbucket", "generic")
@@ -47,8 +49,68 @@ func (r *ProviderRegistry) DetectProvider(url string) GitHostingProvider {
 }
 
 // ParseURL delegates URL parsing to the appropriate provider based on auto-detection
+// SSH URLs bypass provider parsing: they cannot carry deep-link ref/path segments,
+// and platform providers would otherwise rewrite them with an https:// prefix.
 // Returns: (baseURL, ref, path, error)
 func (r *ProviderRegistry) ParseURL(url string) (string, string, string, error) {
+	if IsSSHURL(url) {
+		return strings.TrimSuffix(cleanURL(url), ".git"), "", "", nil
+	}
 	provider := r.DetectProvider(url)
 	return provider.ParseURL(url)
 }
+
+// IsSSHURL reports whether url uses SSH transport, either as an explicit
+// ssh:// (or git+ssh://) URL or as scp-like syntax (user@host:path).
+// SSH URLs are passed through to git unchanged.
+func IsSSHURL(url string) bool {
+	cleaned := cleanURL(url)
+	lower := strings.ToLower(cleaned)
+	if strings.HasPrefix(lower, "ssh://") || strings.HasPrefix(lower, "git+ssh://") {
+		return true
+	}
+	if strings.Contains(cleaned, "://") {
+		return false
+	}
+
+	// scp-like: user@host:path — "@" must precede the first ":" and the host must be non-empty
+	at := strings.Index(cleaned, "@")
+	colon := strings.Index(cleaned, ":")
+	if at <= 0 || colon < 0 || colon < at+2 {
+		return false
+	}
+	// A "/" before the ":" means a local path containing a colon, not a host
+	return !strings.Contains(cleaned[:colon], "/")
+}
+
+// slashedRefPrefixes lists conventional branch prefixes (git-flow style) whose
+// refs contain a slash. Deep-link URLs cannot otherwise distinguish the slash in
+// "release/2.0" from a path separator.
+var slashedRefPrefixes = []string{"feature", "release", "hotfix", "bugfix"}
+
+// SplitSlashedRef re-splits a deep-link ref/path pair captured as the first path
+// segment and the remainder. When ref is a known branch prefix (feature/, release/,
+// hotfix/, bugfix/), the next path segment is moved into the ref:
+//
+//	SplitSlashedRef("release", "2.0/README.md") => ("release/2.0", "README.md")
+//	SplitSlashedRef("main", "src/file.go")      => ("main", "src/file.go")
+//
+// Refs with more than one slash still require manual entry.
+func SplitSlashedRef(ref, path string) (string, string) {
+	isPrefix := false
+	for _, prefix := range slashedRefPrefixes {
+		if ref == prefix {
+			isPrefix = true
+			break
+		}
+	}
+	if !isPrefix || path == "" {
+		return ref, path
+	}
+
+	next, rest, _ := strings.Cut(path, "/")
+	if next == "" {
+		return ref, path
+	}
+	return ref + "/" + next, rest
+}
@@ -450,6 +450,20 @@ func TestGitHubProvider_ParseURL_EdgeCases(t *testing.T) {
 			wantRef:  "develop",
 			wantPath: "src/components/ui/buttons/",
 		},
+		{
+			name:     "tree link with feature/ branch",
+			url:      "https://github.com/owner/repo/tree/feature/new-feature/src",
+			wantBase: "https://github.com/owner/repo",
+			wantRef:  "feature/new-feature",
+			wantPath: "src",
+		},
+		{
+			name:     "blob link with release/ branch",
+			url:      "https://github.com/owner/repo/blob/release/2.0/README.md",
+			wantBase: "https://github.com/owner/repo",
+			wantRef:  "release/2.0",
+			wantPath: "README.md",
+		},
 	}
 
 	for _, tt := range tests {
@@ -545,6 +559,41 @@ func TestProviderRegistry_ParseURL(t *testing.T) {
 			wantRef:  "",
 			wantPath: "",
 		},
+		{
+			name:     "gitlab deep link with release/ branch",
+			url:      "https://gitlab.com/owner/repo/-/blob/release/2.0/README.md",
+			wantBase: "https://gitlab.com/owner/repo",
+			wantRef:  "release/2.0",
+			wantPath: "README.md",
+		},
+		{
+			name:     "bitbucket deep link with hotfix/ branch",
+			url:      "https://bitbucket.org/owner/repo/src/hotfix/login/app.py",
+			wantBase: "https://bitbucket.org/owner/repo",
+			wantRef:  "hotfix/login",
+			wantPath: "app.py",
+		},
+		{
+			name:     "github scp-style SSH URL",
+			url:      "git@github.com:owner/repo.git",
+			wantBase: "git@github.com:owner/repo",
+			wantRef:  "",
+			wantPath: "",
+		},
+		{
+			name:     "gitlab scp-style SSH URL",
+			url:      "git@gitlab.com:group/subgroup/repo.git",
+			wantBase: "git@gitlab.com:group/subgroup/repo",
+			wantRef:  "",
+			wantPath: "",
+		},
+		{
+			name:     "ssh URL with port",
+			url:      "ssh://git@host:22/owner/repo",
+			wantBase: "ssh://git@host:22/owner/repo",
+			wantRef:  "",
+			wantPath: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -568,6 +617,60 @@ func TestProviderRegistry_ParseURL(t *testing.T) {
 	}
 }
 
+func TestSplitSlashedRef(t *testing.T) {
+	tests := []struct {
+		ref, path         string
+		wantRef, wantPath string
+	}{
+		{"feature", "new-feature/src", "feature/new-feature", "src"},
+		{"release", "2.0/README.md", "release/2.0", "README.md"},
+		{"bugfix", "x", "bugfix/x", ""},
+		{"main", "src/file.go", "main", "src/file.go"},
+		{"feature", "", "feature", ""},
+		{"Feature", "x/y", "Feature", "x/y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref+"/"+tt.path, func(t *testing.T) {
+			ref, path := SplitSlashedRef(tt.ref, tt.path)
+			if ref != tt.wantRef || path != tt.wantPath {
+				t.Errorf("SplitSlashedRef(%q, %q) = (%q, %q), want (%q, %q)",
+					tt.ref, tt.path, ref, path, tt.wantRef, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestIsSSHURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"git@github.com:owner/repo.git", true},
+		{"deploy@git.company.com:team/project", true},
+		{"ssh://git@host:22/owner/repo", true},
+		{"SSH://git@host/owner/repo", true},
+		{"git+ssh://git@github.com/owner/repo", true},
+		{"  git@github.com:owner/repo  ", true},
+		{"https://github.com/owner/repo", false},
+		{"https://user@github.com/owner/repo", false},
+		{"git://git.kernel.org/pub/scm/git/git.git", false},
+		{"github.com/owner/repo", false},
+		{"./local/repo", false},
+		{"/path/with@at:colon", false},
+		{"@host:repo", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := IsSSHURL(tt.url); got != tt.want {
+				t.Errorf("IsSSHURL(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
 // ============================================================================
 // cleanURL Helper Tests
 // ============================================================================
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// PlanRemoveVendor lists what RemoveVendor would delete for the vendor called
+// name: its config entry (vendor.yml or vendor.toml), its license and notice copies (when present),
+// and its vendor.lock entries. PlanRemoveVendor deletes nothing. Returns a
+// VendorNotFoundError when no vendor has that name.
+func (s *VendorSyncer) PlanRemoveVendor(name string) (*types.PrunePlan, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("PlanRemoveVendor: load config: %w", err)
+	}
+	if FindVendorIndex(config.Vendors, name) < 0 {
+		return nil, NewVendorNotFoundError(name)
+	}
+
+	plan := &types.PrunePlan{DryRun: true, Targets: []types.PruneTarget{}}
+	plan.Targets = append(plan.Targets, types.PruneTarget{
+		Kind:   types.PruneKindConfigEntry,
+		Path:   filepath.ToSlash(s.configStore.Path()),
+		Vendor: name,
+		Reason: types.PruneReasonRemovedVendor,
+	})
+
+	for _, licensePath := range vendorLicenseCopies(ResolveLicenseDir(s.rootDir, config), name, ResolveLicenseFiles(config)) {
+		if _, err := s.fs.Stat(licensePath); err != nil {
+			continue
+		}
+		plan.Targets = append(plan.Targets, types.PruneTarget{
+			Kind:   types.PruneKindFile,
+			Path:   filepath.ToSlash(licensePath),
+			Vendor: name,
+			Reason: types.PruneReasonRemovedVendor,
+		})
+	}
+
+	lock, err := s.lockStore.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("PlanRemoveVendor: load lock: %w", err)
+	}
+	for _, l := range lock.Vendors {
+		if l.Name != name {
+			continue
+		}
+		plan.Targets = append(plan.Targets, types.PruneTarget{
+			Kind:   types.PruneKindLockEntry,
+			Path:   filepath.ToSlash(filepath.Join(s.rootDir, LockFile)),
+			Vendor: name,
+			Ref:    l.Ref,
+			Reason: types.PruneReasonRemovedVendor,
+		})
+	}
+	return plan, nil
+}
+
+// PlanPruneMappings lists the mappings pull --prune would remove from
+// the config, using the same rule as pruneDeadMappings: a mapping whose
+// destination has no entry in its vendor@ref lock FileHashes. The plan is
+// computed from the current lock without fetching, so sources removed
+// upstream since the last update are not yet visible. Vendors excluded by
+// vendorName or exclude are skipped. PlanPruneMappings deletes nothing.
+func (s *VendorSyncer) PlanPruneMappings(vendorName string, exclude []string) (*types.PrunePlan, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("PlanPruneMappings: load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("PlanPruneMappings: load lock: %w", err)
+	}
+	lockFileKeys := lockFileKeysByRef(lock)
+	configPath := filepath.ToSlash(s.configStore.Path())
+
+	plan := &types.PrunePlan{DryRun: true, Targets: []types.PruneTarget{}}
+	for _, v := range config.Vendors {
+		if !MatchVendorFilter(v.Name, vendorName) {
+			continue
+		}
+		if MatchVendorPattern(v.Name, exclude) {
+			continue
+		}
+		for _, spec := range v.Specs {
+			destKeys := lockFileKeys[v.Name+":"+spec.Ref]
+			for _, m := range spec.Mapping {
+				if destKeys[m.To] {
+					continue
+				}
+				plan.Targets = append(plan.Targets, types.PruneTarget{
+					Kind:   types.PruneKindMapping,
+					Path:   configPath,
+					Vendor: v.Name,
+					Ref:    spec.Ref,
+					From:   m.From,
+					To:     m.To,
+					Reason: types.PruneReasonOrphanedByConfig,
+				})
+			}
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestPlanPruneMappings_ListsDeadMappingsWithoutSaving(t *testing.T) {
+	env := setupPullTestEnv(t)
+
+	vendor := types.VendorSpec{
+		Name:    "test-vendor",
+		URL:     "https://github.com/owner/repo",
+		License: "MIT",
+		Specs: []types.BranchSpec{
+			{
+				Ref: "main",
+				Mapping: []types.PathMapping{
+					{From: "src/file.go", To: "lib/file.go"},
+					{From: "src/deleted.go", To: "lib/deleted.go"},
+				},
+			},
+		},
+	}
+	env.writeConfig(createTestConfig(vendor))
+	env.writeLock(testLock())
+
+	plan, err := env.syncer.PlanPruneMappings("", nil)
+	if err != nil {
+		t.Fatalf("PlanPruneMappings() error = %v", err)
+	}
+	if !plan.DryRun {
+		t.Error("plan.DryRun = false, want true")
+	}
+	if len(plan.Targets) != 1 {
+		t.Fatalf("got %d targets %+v, want 1", len(plan.Targets), plan.Targets)
+	}
+	got := plan.Targets[0]
+	want := types.PruneTarget{
+		Kind:   types.PruneKindMapping,
+		Path:   filepath.ToSlash(filepath.Join(env.rootDir, ConfigFile)),
+		Vendor: "test-vendor",
+		Ref:    "main",
+		From:   "src/deleted.go",
+		To:     "lib/deleted.go",
+		Reason: types.PruneReasonOrphanedByConfig,
+	}
+	if got != want {
+		t.Errorf("target = %+v, want %+v", got, want)
+	}
+
+	cfg, err := env.syncer.configStore.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(cfg.Vendors[0].Specs[0].Mapping); n != 2 {
+		t.Errorf("config has %d mappings after dry run, want 2 (nothing pruned)", n)
+	}
+}
+
+func TestPlanPruneMappings_HonorsVendorFilterAndExclude(t *testing.T) {
+	env := setupPullTestEnv(t)
+
+	deadVendor := func(name string) types.VendorSpec {
+		return types.VendorSpec{
+			Name: name,
+			URL:  "https://github.com/owner/" + name,
+			Specs: []types.BranchSpec{
+				{Ref: "main", Mapping: []types.PathMapping{{From: "src/gone.go", To: name + "/gone.go"}}},
+			},
+		}
+	}
+	env.writeConfig(types.VendorConfig{Vendors: []types.VendorSpec{deadVendor("vendor-a"), deadVendor("vendor-b"), deadVendor("vendor-c")}})
+	env.writeLock(types.VendorLock{SchemaVersion: "1.1"})
+
+	plan, err := env.syncer.PlanPruneMappings("", []string{"vendor-b"})
+	if err != nil {
+		t.Fatalf("PlanPruneMappings() error = %v", err)
+	}
+	if len(plan.Targets) != 2 || plan.Targets[0].Vendor != "vendor-a" || plan.Targets[1].Vendor != "vendor-c" {
+		t.Errorf("targets = %+v, want vendor-a and vendor-c only", plan.Targets)
+	}
+
+	plan, err = env.syncer.PlanPruneMappings("vendor-c", nil)
+	if err != nil {
+		t.Fatalf("PlanPruneMappings() error = %v", err)
+	}
+	if len(plan.Targets) != 1 || plan.Targets[0].Vendor != "vendor-c" {
+		t.Errorf("targets = %+v, want vendor-c only", plan.Targets)
+	}
+}
+
+func TestPlanRemoveVendor_ListsTargetsWithoutDeleting(t *testing.T) {
+	env := setupPullTestEnv(t)
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	other := createTestVendorSpec("other-vendor", "https://github.com/owner/other", "main")
+	env.writeConfig(types.VendorConfig{Vendors: []types.VendorSpec{vendor, other}})
+	lock := testLock()
+	lock.Vendors = append(lock.Vendors, types.LockDetails{Name: "other-vendor", Ref: "main", CommitHash: "def456", Updated: "2024-01-01T00:00:00Z"})
+	env.writeLock(lock)
+
+	licenseDir := filepath.Join(env.rootDir, LicensesDir)
+	if err := os.MkdirAll(licenseDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	licensePath := filepath.Join(licenseDir, "test-vendor.txt")
+	if err := os.WriteFile(licensePath, []byte("MIT License"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := env.syncer.PlanRemoveVendor("test-vendor")
+	if err != nil {
+		t.Fatalf("PlanRemoveVendor() error = %v", err)
+	}
+
+	wantKinds := []string{types.PruneKindConfigEntry, types.PruneKindFile, types.PruneKindLockEntry}
+	if len(plan.Targets) != len(wantKinds) {
+		t.Fatalf("got %d targets %+v, want %d", len(plan.Targets), plan.Targets, len(wantKinds))
+	}
+	for i, target := range plan.Targets {
+		if target.Kind != wantKinds[i] {
+			t.Errorf("target %d kind = %q, want %q", i, target.Kind, wantKinds[i])
+		}
+		if target.Vendor != "test-vendor" || target.Reason != types.PruneReasonRemovedVendor {
+			t.Errorf("target %d = %+v, want vendor test-vendor with reason %s", i, target, types.PruneReasonRemovedVendor)
+		}
+	}
+	if plan.Targets[1].Path != filepath.ToSlash(licensePath) {
+		t.Errorf("license target path = %q, want %q", plan.Targets[1].Path, filepath.ToSlash(licensePath))
+	}
+
+	// Nothing deleted
+	if _, err := os.Stat(licensePath); err != nil {
+		t.Errorf("license file gone after dry run: %v", err)
+	}
+	cfg, err := env.syncer.configStore.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Vendors) != 2 {
+		t.Errorf("config has %d vendors after dry run, want 2", len(cfg.Vendors))
+	}
+	gotLock, err := env.syncer.lockStore.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotLock.Vendors) != 2 {
+		t.Errorf("lock has %d entries after dry run, want 2", len(gotLock.Vendors))
+	}
+}
+
+func TestPlanRemoveVendor_NotFound(t *testing.T) {
+	env := setupPullTestEnv(t)
+	env.writeConfig(createTestConfig(createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")))
+
+	_, err := env.syncer.PlanRemoveVendor("missing")
+	if !IsVendorNotFound(err) {
+		t.Errorf("PlanRemoveVendor(missing) error = %v, want VendorNotFoundError", err)
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
@@ -16,13 +20,61 @@ type PullOptions struct {
 	Prune       bool   // Remove dead mappings from vendor.yml when upstream file is missing
 	KeepLocal   bool   // Skip overwriting locally modified files (lock hash mismatch)
 	Interactive bool   // Prompt per-file on conflicts (deferred — prints message for now)
-	Force       bool   // Skip cache, force re-fetch
+	Force       bool   // Skip cache, force re-fetch, overwrite local modifications without asking
 	NoCache     bool   // Don't persist cache after pull
-	VendorName  string // Empty = all vendors
+	VendorName  string // Exact name or glob (e.g. "aws-*"); empty = all vendors
 	Local       bool   // Allow file:// and local path vendor URLs
+	// RetryOnStale updates a vendor whose locked commit is gone upstream and retries the sync once.
+	RetryOnStale bool
+	// ReportUnmanaged scans the vendor root after sync for files not produced by any mapping.
+	ReportUnmanaged bool
+	// UnmanagedRoot is the directory scanned by ReportUnmanaged. Empty = the directory of each destination.
+	UnmanagedRoot string
+	// Snapshot archives each fetched tree to .git-vendor/.snapshots/ keyed by commit.
+	Snapshot bool
+	// Offline restores locked commits from snapshots without contacting any remote. Implies Locked.
+	Offline bool
+	// ExcludeVendors skips vendors matching these names/globs after VendorName selection.
+	ExcludeVendors []string
+	// OnlyPositions re-places position mappings only, reading sources from the
+	// position source cache when cached at the locked commit. Implies Locked.
+	OnlyPositions bool
+	// CheckLicense warns when a vendor's upstream license changed since the
+	// last update (UpdateOptions.CheckLicense).
+	CheckLicense bool
+	// StrictLicense fails the update phase on such a change instead. Implies CheckLicense.
+	StrictLicense bool
+	// FetchAttempts caps fetch attempts per URL on transient network errors
+	// in both the update and sync phases (0 = DefaultFetchAttempts).
+	FetchAttempts int
+	// Relocate follows drifted position snippets during the update phase and
+	// rewrites their line ranges in vendor.yml (UpdateOptions.Relocate).
+	Relocate bool
+	// IncludePinned also updates vendors pinned by "git-vendor pin"
+	// (UpdateOptions.IncludePinned).
+	IncludePinned bool
+	// AllowHooks runs each vendor's post_sync command after the sync phase
+	// copies it (SyncOptions.AllowHooks).
+	AllowHooks bool
+	// Hardlink replaces each vendor's byte-identical destination files with
+	// hard links to one copy (SyncOptions.Hardlink).
+	Hardlink bool
+	// Since skips vendors none of whose refs gained an upstream commit within
+	// Since, adding them to ExcludeVendors for both phases (0 = no age filter).
+	// Ignored with Locked.
+	Since time.Duration
 	// NOTE: Commit behavior is handled at the CLI layer (main.go), not in PullVendors.
 }
 
+// selectsVendor reports whether PullOptions' VendorName and ExcludeVendors
+// select the vendor called name.
+func (o PullOptions) selectsVendor(name string) bool {
+	if !MatchVendorFilter(name, o.VendorName) {
+		return false
+	}
+	return !MatchVendorPattern(name, o.ExcludeVendors)
+}
+
 // PullResult summarizes what a pull operation did.
 type PullResult struct {
 	Updated        int      `json:"updated"`                  // Vendors whose lock entries were refreshed
@@ -33,6 +85,11 @@ type PullResult struct {
 	MappingsPruned int      `json:"mappings_pruned"`          // Mappings removed from vendor.yml (--prune)
 	Warnings       []string `json:"warnings,omitempty"`       // Non-fatal warnings
 	DriftCleared   int      `json:"drift_cleared,omitempty"`  // AcceptedDrift entries cleared after overwrite
+	Unmanaged      []string `json:"unmanaged,omitempty"`      // Files under the vendor root not produced by any mapping (--report-unmanaged)
+	BytesWritten   int64    `json:"bytes_written"`            // Bytes copied by the sync phase
+	// Vendors is the sync phase's per-vendor outcome, in sync order. A failed
+	// pull returns the result alongside its error so the failed vendor is listed.
+	Vendors []VendorSyncResult `json:"vendors,omitempty"`
 }
 
 // PullVendors performs the combined update+sync operation.
@@ -44,12 +101,27 @@ type PullResult struct {
 // With --locked:
 //  1. Sync only: use existing lock hashes (deterministic rebuild)
 //
+// Without --force or --keep-local:
+//  1. Before anything is written, list files whose hash no longer matches the lock
+//  2. Ask to overwrite them; if declined, return a LocalModificationsError
+//
 // With --keep-local:
 //  1. Before overwriting, check if local file hash matches lock hash
 //  2. If mismatch (local modification detected), skip that file
 //
 // With --prune:
 //  1. After sync, remove mappings from vendor.yml whose upstream source no longer exists
+//
+// With --snapshot / --offline:
+//  1. --snapshot archives each fetched tree under .git-vendor/.snapshots/
+//  2. --offline restores locked commits from those archives (no network)
+//
+// With --only-positions:
+//  1. Sync only position mappings at their locked commits
+//  2. Sources cached by a previous sync are re-placed without any git operations
+//
+// With --report-unmanaged:
+//  1. After sync, list files under the vendor root that no mapping produced
 func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*PullResult, error) {
 	if opts.Interactive {
 		fmt.Println("Note: --interactive mode is not yet implemented. Using default (overwrite) behavior.")
@@ -57,11 +129,46 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 
 	result := &PullResult{}
 
+	// Offline restores can't resolve new commits, so they always use the lock
+	if opts.Offline {
+		opts.Locked = true
+	}
+
+	// Position re-placement works from locked commits (and their cached sources)
+	if opts.OnlyPositions {
+		opts.Locked = true
+	}
+
+	// Vendors quiet upstream for longer than --since are left as locked
+	if opts.Since > 0 && !opts.Locked {
+		if err := s.excludeStaleVendors(ctx, &opts); err != nil {
+			return nil, err
+		}
+	}
+
+	// Phase 0: Both phases below overwrite destinations, so confirm clobbering
+	// hand edits before either runs (--keep-local preserves them instead)
+	if !opts.Force && !opts.KeepLocal {
+		if err := s.confirmOverwriteLocalModifications(opts); err != nil {
+			return nil, err
+		}
+	}
+
 	// Phase 1: Update lock (unless --locked)
 	if !opts.Locked {
 		updateOpts := UpdateOptions{
 			Local:      opts.Local,
 			VendorName: opts.VendorName,
+			Snapshot:   opts.Snapshot,
+
+			ExcludeVendors: opts.ExcludeVendors,
+			CheckLicense:   opts.CheckLicense,
+			StrictLicense:  opts.StrictLicense,
+			FetchAttempts:  opts.FetchAttempts,
+			Relocate:       opts.Relocate,
+			IncludePinned:  opts.IncludePinned,
+			Hardlink:       opts.Hardlink,
+			AllowHooks:     opts.AllowHooks,
 		}
 		if err := s.update.UpdateAllWithOptions(ctx, updateOpts); err != nil {
 			return nil, fmt.Errorf("pull update phase: %w", err)
@@ -69,13 +176,9 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 		// Count updated vendors
 		lock, err := s.lockStore.Load()
 		if err == nil {
-			result.Updated = len(lock.Vendors)
-			if opts.VendorName != "" {
-				result.Updated = 0
-				for _, l := range lock.Vendors {
-					if l.Name == opts.VendorName {
-						result.Updated++
-					}
+			for _, l := range lock.Vendors {
+				if opts.selectsVendor(l.Name) {
+					result.Updated++
 				}
 			}
 		}
@@ -105,7 +208,7 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 		if preLockErr == nil {
 			for i := range preLock.Vendors {
 				entry := &preLock.Vendors[i]
-				if opts.VendorName != "" && entry.Name != opts.VendorName {
+				if !opts.selectsVendor(entry.Name) {
 					continue
 				}
 				for path := range entry.AcceptedDrift {
@@ -116,15 +219,33 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 	}
 
 	// Phase 3: Sync (lock → disk)
+	report := &SyncReport{}
 	syncOpts := SyncOptions{
+		Report:     report,
 		VendorName: opts.VendorName,
 		Force:      opts.Force,
 		NoCache:    opts.NoCache,
 		Local:      opts.Local,
+
+		RetryOnStale:   opts.RetryOnStale,
+		Snapshot:       opts.Snapshot,
+		Offline:        opts.Offline,
+		ExcludeVendors: opts.ExcludeVendors,
+		OnlyPositions:  opts.OnlyPositions,
+		FetchAttempts:  opts.FetchAttempts,
+		AllowHooks:     opts.AllowHooks,
+		Hardlink:       opts.Hardlink,
+		CheckLicense:   opts.CheckLicense,
+		StrictLicense:  opts.StrictLicense,
 	}
-	if err := s.syncWithAutoUpdate(ctx, syncOpts); err != nil {
+	err := s.syncWithAutoUpdate(ctx, syncOpts)
+	result.Vendors = report.Vendors
+	for _, v := range report.Vendors {
+		result.BytesWritten += v.BytesCopied
+	}
+	if err != nil {
 		cleanupBackups(backups)
-		return nil, fmt.Errorf("pull sync phase: %w", err)
+		return result, fmt.Errorf("pull sync phase: %w", err)
 	}
 
 	// Phase 4: If --keep-local, restore backed-up locally modified files after sync
@@ -145,7 +266,7 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 			driftModified := false
 			for i := range driftLock.Vendors {
 				entry := &driftLock.Vendors[i]
-				if opts.VendorName != "" && entry.Name != opts.VendorName {
+				if !opts.selectsVendor(entry.Name) {
 					continue
 				}
 				if len(entry.AcceptedDrift) == 0 {
@@ -180,7 +301,7 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 	lock, err := s.lockStore.Load()
 	if err == nil {
 		for _, l := range lock.Vendors {
-			if opts.VendorName != "" && l.Name != opts.VendorName {
+			if !opts.selectsVendor(l.Name) {
 				continue
 			}
 			result.Synced++
@@ -196,7 +317,7 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 
 	// Phase 6: If --prune, remove dead mappings from vendor.yml
 	if opts.Prune {
-		pruned, pruneWarnings, err := s.pruneDeadMappings(opts.VendorName)
+		pruned, pruneWarnings, err := s.pruneDeadMappings(opts.VendorName, opts.ExcludeVendors)
 		if err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("prune: %s", err))
 		}
@@ -204,6 +325,15 @@ func (s *VendorSyncer) PullVendors(ctx context.Context, opts PullOptions) (*Pull
 		result.Warnings = append(result.Warnings, pruneWarnings...)
 	}
 
+	// Phase 7: If --report-unmanaged, scan the vendor root for stray files
+	if opts.ReportUnmanaged {
+		unmanaged, err := s.FindUnmanagedFiles(opts.UnmanagedRoot)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("report-unmanaged: %s", err))
+		}
+		result.Unmanaged = unmanaged
+	}
+
 	return result, nil
 }
 
@@ -220,7 +350,7 @@ func (s *VendorSyncer) snapshotLocalFileHashes(vendorName string) (map[string]st
 	modified := make(map[string]string)
 
 	for _, l := range lock.Vendors {
-		if vendorName != "" && l.Name != vendorName {
+		if !MatchVendorFilter(l.Name, vendorName) {
 			continue
 		}
 		for destPath, lockHash := range l.FileHashes {
@@ -241,6 +371,48 @@ func (s *VendorSyncer) snapshotLocalFileHashes(vendorName string) (map[string]st
 	return modified, nil
 }
 
+// confirmOverwriteLocalModifications lists the destinations of the vendors
+// opts selects whose content no longer matches their lock hash and asks the
+// UI whether to overwrite them. Paths with accepted drift are left out (pull
+// warns about those separately). A declined prompt (or a non-interactive run
+// without --yes) returns a LocalModificationsError naming the files.
+func (s *VendorSyncer) confirmOverwriteLocalModifications(opts PullOptions) error {
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil // No lock yet, so nothing vendored to clobber
+	}
+
+	cache := NewFileCacheStore(s.fs, s.rootDir)
+	var modified []string
+	for _, l := range lock.Vendors {
+		if !opts.selectsVendor(l.Name) {
+			continue
+		}
+		for destPath, lockHash := range l.FileHashes {
+			if _, accepted := l.AcceptedDrift[destPath]; accepted {
+				continue
+			}
+			currentHash, err := cache.ComputeFileChecksum(destPath)
+			if err != nil || currentHash == lockHash {
+				continue // Missing files are simply re-created
+			}
+			modified = append(modified, destPath)
+		}
+	}
+	if len(modified) == 0 {
+		return nil
+	}
+	sort.Strings(modified)
+	modified = slices.Compact(modified)
+
+	message := fmt.Sprintf("%s changed since the last sync and will be overwritten:\n  %s",
+		Pluralize(len(modified), "vendored file", "vendored files"), strings.Join(modified, "\n  "))
+	if s.ui.AskConfirmation("Overwrite local modifications?", message) {
+		return nil
+	}
+	return NewLocalModificationsError(modified)
+}
+
 // backupLocallyModified copies locally modified files to temporary locations
 // before sync can overwrite them. Returns a map of destPath -> temp backup path
 // for files that were backed up.
@@ -274,7 +446,7 @@ func (s *VendorSyncer) restoreLocallyModified(backups map[string]string) (int, e
 		if err != nil {
 			continue // backup lost, nothing to restore
 		}
-		if err := os.WriteFile(destPath, data, 0644); err != nil {
+		if err := writeFileUnlinked(destPath, data, 0644); err != nil {
 			return restored, fmt.Errorf("restore keep-local file %s: %w", destPath, err)
 		}
 		restored++
@@ -295,7 +467,8 @@ func cleanupBackups(backups map[string]string) {
 // pruneDeadMappings removes mappings from vendor.yml where the source file no longer exists
 // upstream (detected by the mapping not having a corresponding lock FileHashes entry after sync).
 // pruneDeadMappings returns the count of pruned mappings and any warnings.
-func (s *VendorSyncer) pruneDeadMappings(vendorName string) (int, []string, error) {
+// Vendors excluded by name/glob in exclude are never pruned.
+func (s *VendorSyncer) pruneDeadMappings(vendorName string, exclude []string) (int, []string, error) {
 	config, err := s.configStore.Load()
 	if err != nil {
 		return 0, nil, fmt.Errorf("load config for prune: %w", err)
@@ -306,17 +479,7 @@ func (s *VendorSyncer) pruneDeadMappings(vendorName string) (int, []string, erro
 		return 0, nil, fmt.Errorf("load lock for prune: %w", err)
 	}
 
-	// Build set of lock file hash keys per vendor+ref
-	lockFileKeys := make(map[string]map[string]bool) // "vendor:ref" -> set of dest paths
-	for _, l := range lock.Vendors {
-		key := l.Name + ":" + l.Ref
-		if lockFileKeys[key] == nil {
-			lockFileKeys[key] = make(map[string]bool)
-		}
-		for destPath := range l.FileHashes {
-			lockFileKeys[key][destPath] = true
-		}
-	}
+	lockFileKeys := lockFileKeysByRef(lock)
 
 	pruned := 0
 	var warnings []string
@@ -324,19 +487,21 @@ func (s *VendorSyncer) pruneDeadMappings(vendorName string) (int, []string, erro
 
 	for vi := range config.Vendors {
 		v := &config.Vendors[vi]
-		if vendorName != "" && v.Name != vendorName {
+		if !MatchVendorFilter(v.Name, vendorName) {
+			continue
+		}
+		if MatchVendorPattern(v.Name, exclude) {
 			continue
 		}
 
 		for si := range v.Specs {
 			spec := &v.Specs[si]
-			key := v.Name + ":" + spec.Ref
-			destKeys := lockFileKeys[key]
+			destKeys := lockFileKeys[v.Name+":"+spec.Ref]
 
 			var kept []types.PathMapping
 			for _, m := range spec.Mapping {
 				// Check if this mapping's destination exists in the lock
-				if destKeys != nil && destKeys[m.To] {
+				if destKeys[m.To] {
 					kept = append(kept, m)
 				} else {
 					// Mapping has no corresponding lock entry — source was removed upstream
@@ -357,3 +522,19 @@ func (s *VendorSyncer) pruneDeadMappings(vendorName string) (int, []string, erro
 
 	return pruned, warnings, nil
 }
+
+// lockFileKeysByRef returns the set of locked destination paths per "vendor:ref".
+func lockFileKeysByRef(lock types.VendorLock) map[string]map[string]bool {
+	keys := make(map[string]map[string]bool)
+	for _, l := range lock.Vendors {
+		key := l.Name + ":" + l.Ref
+		if keys[key] == nil {
+			keys[key] = make(map[string]bool)
+		}
+		for destPath := range l.FileHashes {
+			keys[key][destPath] = true
+		}
+	}
+	return keys
+}
+
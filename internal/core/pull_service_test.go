@@ -2,12 +2,15 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
 )
 
 // ============================================================================
@@ -295,6 +298,28 @@ func TestPullVendors_LocalFlag_PassedThrough(t *testing.T) {
 	}
 }
 
+// TestPullVendors_AllowHooks_PassedThrough verifies that --allow-hooks reaches
+// the update phase too, so post_sync runs before the lock hashes the files.
+func TestPullVendors_AllowHooks_PassedThrough(t *testing.T) {
+	env := setupPullTestEnv(t)
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	env.writeConfig(createTestConfig(vendor))
+	env.writeLock(testLock())
+
+	_, err := env.syncer.PullVendors(context.Background(), PullOptions{AllowHooks: true})
+	if err != nil {
+		t.Fatalf("PullVendors returned error: %v", err)
+	}
+
+	if !env.updateSvc.lastOpts.AllowHooks {
+		t.Error("Expected update AllowHooks=true")
+	}
+	if !env.syncSvc.syncOpts.AllowHooks {
+		t.Error("Expected sync AllowHooks=true")
+	}
+}
+
 // TestPullVendors_KeepLocal_DoesNotError verifies that --keep-local flag passes
 // through without error. The full file preservation flow (C1) requires real
 // filesystem paths that match lock entries, which needs integration-level testing.
@@ -317,6 +342,128 @@ func TestPullVendors_KeepLocal_DoesNotError(t *testing.T) {
 	}
 }
 
+// TestPullVendors_LocalModifications_PromptsBeforeOverwrite verifies that a
+// destination edited since the last sync is listed in a confirmation prompt
+// before anything is fetched or copied, and that declining aborts the pull.
+func TestPullVendors_LocalModifications_PromptsBeforeOverwrite(t *testing.T) {
+	env := setupPullTestEnv(t)
+	chdirUnmanagedTest(t)
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	env.writeConfig(createTestConfig(vendor))
+	writeFixTestFile(t, "lib/file.go", "package lib\n")
+	writeFixTestFile(t, "lib/edited.go", "package lib // edited by hand\n")
+	cleanHash, err := fileSHA256("lib/file.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock := testLock()
+	lock.Vendors[0].FileHashes = map[string]string{"lib/file.go": cleanHash, "lib/edited.go": "deadbeef"}
+	env.writeLock(lock)
+
+	ui := &capturingUICallback{}
+	env.syncer.ui = ui
+	_, err = env.syncer.PullVendors(context.Background(), PullOptions{Locked: true})
+	if !IsLocalModifications(err) {
+		t.Fatalf("PullVendors error = %v, want a LocalModificationsError", err)
+	}
+	if !contains(ui.confirmMsg, "lib/edited.go") || contains(ui.confirmMsg, "lib/file.go") {
+		t.Errorf("confirmation = %q, want only lib/edited.go listed", ui.confirmMsg)
+	}
+	if env.syncSvc.syncCalled {
+		t.Error("sync ran although the overwrite was declined")
+	}
+
+	// Confirming, or passing --force, lets the pull overwrite the edit
+	ui.confirmResp = true
+	if _, err := env.syncer.PullVendors(context.Background(), PullOptions{Locked: true}); err != nil {
+		t.Fatalf("confirmed PullVendors returned error: %v", err)
+	}
+	ui.confirmResp, ui.confirmMsg = false, ""
+	if _, err := env.syncer.PullVendors(context.Background(), PullOptions{Locked: true, Force: true}); err != nil {
+		t.Fatalf("PullVendors --force returned error: %v", err)
+	}
+	if ui.confirmMsg != "" {
+		t.Errorf("--force should not prompt, got %q", ui.confirmMsg)
+	}
+}
+
+// TestPullVendors_ReportsPerVendorSyncResults verifies that the sync phase's
+// per-vendor outcome and byte counts reach PullResult and its JSON encoding,
+// and that a second locked pull served by the cache reports vendors skipped.
+func TestPullVendors_ReportsPerVendorSyncResults(t *testing.T) {
+	chdirUnmanagedTest(t)
+	if err := os.MkdirAll(VendorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configStore, lockStore := NewFileConfigStore(VendorDir), NewFileLockStore(VendorDir)
+	lib := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	lib.Specs[0].Mapping = []types.PathMapping{{From: "a.go", To: "vendor/lib/a.go"}, {From: "b.go", To: "vendor/lib/b.go"}}
+	tool := createTestVendorSpec("tool", "https://github.com/owner/tool", "main")
+	tool.Specs[0].Mapping = []types.PathMapping{{From: "tool.go", To: "vendor/tool/tool.go"}}
+	if err := configStore.Save(createTestConfig(lib, tool)); err != nil {
+		t.Fatal(err)
+	}
+
+	git := NewMockGitClient(gomock.NewController(t))
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", gomock.Any()).Return(nil).AnyTimes()
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, dir, _ string) error {
+		for name, content := range map[string]string{"a.go": "package a\n", "b.go": "package b\n", "tool.go": "package tool\n"} {
+			writeFixTestFile(t, filepath.Join(dir, name), content)
+		}
+		return nil
+	}).AnyTimes()
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return(strings.Repeat("a", 40), nil).AnyTimes()
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	syncer := NewVendorSyncer(configStore, lockStore, git, NewOSFileSystem(), nil, VendorDir, &SilentUICallback{}, nil)
+
+	result, err := syncer.PullVendors(context.Background(), PullOptions{})
+	if err != nil {
+		t.Fatalf("PullVendors: %v", err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		BytesWritten int64 `json:"bytes_written"`
+		Vendors      []struct {
+			Name        string `json:"name"`
+			Status      string `json:"status"`
+			FilesCopied int    `json:"files_copied"`
+			BytesCopied int64  `json:"bytes_copied"`
+		} `json:"vendors"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	var got []string
+	for _, v := range decoded.Vendors {
+		got = append(got, fmt.Sprintf("%s:%s:%d:%d", v.Name, v.Status, v.FilesCopied, v.BytesCopied))
+	}
+	if want := "lib:synced:2:20,tool:synced:1:13"; strings.Join(got, ",") != want {
+		t.Errorf("vendors = %s, want %s (JSON %s)", strings.Join(got, ","), want, data)
+	}
+	if decoded.BytesWritten != 33 {
+		t.Errorf("bytes_written = %d, want 33", decoded.BytesWritten)
+	}
+
+	result, err = syncer.PullVendors(context.Background(), PullOptions{Locked: true})
+	if err != nil {
+		t.Fatalf("locked PullVendors: %v", err)
+	}
+	if len(result.Vendors) != 2 {
+		t.Fatalf("locked pull vendors = %+v, want lib and tool", result.Vendors)
+	}
+	for _, v := range result.Vendors {
+		if v.Status != SyncStatusSkipped || v.FilesCopied != 0 {
+			t.Errorf("cached vendor %s = %+v, want skipped with no files copied", v.Name, v)
+		}
+	}
+}
+
 // TestBackupRestore_RoundTrip verifies that backupLocallyModified and
 // restoreLocallyModified correctly preserve file content through a backup/restore
 // cycle. This tests the core C1 mechanism independent of the full pull pipeline.
@@ -489,7 +636,7 @@ func TestPruneDeadMappings_NoDeadMappings(t *testing.T) {
 	env.writeConfig(createTestConfig(vendor))
 	env.writeLock(testLock())
 
-	pruned, warnings, err := env.syncer.pruneDeadMappings("")
+	pruned, warnings, err := env.syncer.pruneDeadMappings("", nil)
 	if err != nil {
 		t.Fatalf("pruneDeadMappings returned error: %v", err)
 	}
@@ -543,7 +690,7 @@ func TestPruneDeadMappings_VendorFilter(t *testing.T) {
 	})
 
 	// Prune only vendor-a
-	pruned, _, err := env.syncer.pruneDeadMappings("vendor-a")
+	pruned, _, err := env.syncer.pruneDeadMappings("vendor-a", nil)
 	if err != nil {
 		t.Fatalf("pruneDeadMappings returned error: %v", err)
 	}
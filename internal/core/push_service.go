@@ -60,7 +60,7 @@ func (s *VendorSyncer) PushVendor(ctx context.Context, opts PushOptions) (*PushR
 		}
 	}
 	if vendor == nil {
-		return nil, fmt.Errorf("vendor %q not found in config", opts.VendorName)
+		return nil, &vendorMissingError{msg: fmt.Sprintf("vendor %q not found in config", opts.VendorName)}
 	}
 
 	// Internal vendors cannot be pushed — they use compliance propagation
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -342,6 +343,9 @@ func TestPushVendor_VendorNotFound(t *testing.T) {
 	if !containsSubstring(err.Error(), "not found") {
 		t.Errorf("expected 'not found' in error, got: %s", err.Error())
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("expected errors.Is(err, ErrVendorNotFound), got: %s", err.Error())
+	}
 }
 
 // TestPushVendor_InternalVendorRejected verifies PushVendor rejects internal vendors.
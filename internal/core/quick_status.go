@@ -0,0 +1,141 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// Quick status states (types.QuickStatusEntry.State).
+const (
+	QuickStatusInSync       = "in-sync"
+	QuickStatusMissingFiles = "missing-files"
+	QuickStatusNotSynced    = "not-synced"
+)
+
+// QuickStatus reports, for each config vendor@ref, whether vendor.lock has a
+// commit for it and whether every destination still exists on disk. Unlike
+// Status it never hashes files or contacts a remote, so it stays fast on
+// large trees; content drift is left to "status --offline".
+//
+// An entry is not-synced when the lock has no commit for the ref, or when the
+// ref pins a full commit SHA that differs from the locked one. Otherwise it is
+// missing-files when any lock FileHashes path or config mapping destination
+// fails to Stat, and in-sync when all of them exist. Vendors matching
+// excludeVendors are left out.
+func (s *VendorSyncer) QuickStatus(excludeVendors []string) (*types.QuickStatusResult, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+
+	locked := make(map[string]*types.LockDetails, len(lock.Vendors))
+	for i := range lock.Vendors {
+		l := &lock.Vendors[i]
+		locked[l.Name+"@"+l.Ref] = l
+	}
+
+	result := &types.QuickStatusResult{Vendors: []types.QuickStatusEntry{}}
+	for i := range config.Vendors {
+		v := &config.Vendors[i]
+		if MatchVendorPattern(v.Name, excludeVendors) {
+			continue
+		}
+		for _, spec := range v.Specs {
+			entry := types.QuickStatusEntry{Name: v.Name, Ref: spec.Ref}
+			l := locked[v.Name+"@"+spec.Ref]
+			switch {
+			case l == nil || (l.CommitHash == "" && v.Source != SourceInternal):
+				entry.State = QuickStatusNotSynced
+				entry.Detail = "no locked commit for this ref"
+			case isFullCommitHash(spec.Ref) && !strings.EqualFold(spec.Ref, l.CommitHash):
+				entry.State = QuickStatusNotSynced
+				entry.CommitHash = l.CommitHash
+				entry.Detail = fmt.Sprintf("vendor.yml pins %s but the lock has %s", shortHash(spec.Ref), shortHash(l.CommitHash))
+			default:
+				entry.CommitHash = l.CommitHash
+				entry.MissingPaths = s.missingDestinations(v, spec, l)
+				entry.State = QuickStatusInSync
+				if len(entry.MissingPaths) > 0 {
+					entry.State = QuickStatusMissingFiles
+				}
+			}
+			result.Vendors = append(result.Vendors, entry)
+		}
+	}
+
+	summary := &result.Summary
+	for _, e := range result.Vendors {
+		summary.Total++
+		switch e.State {
+		case QuickStatusInSync:
+			summary.InSync++
+		case QuickStatusMissingFiles:
+			summary.MissingFiles++
+		case QuickStatusNotSynced:
+			summary.NotSynced++
+		}
+	}
+	summary.Result = "PASS"
+	if summary.InSync != summary.Total {
+		summary.Result = "FAIL"
+	}
+	return result, nil
+}
+
+// missingDestinations returns, sorted, the destinations of v@spec that do not
+// exist on disk: every external lock FileHashes path plus each mapping's
+// destination file (position specifier stripped, auto path when "to" is empty).
+func (s *VendorSyncer) missingDestinations(v *types.VendorSpec, spec types.BranchSpec, l *types.LockDetails) []string {
+	paths := make(map[string]bool)
+	if v.Source != SourceInternal {
+		for path := range l.FileHashes {
+			paths[path] = true
+		}
+	}
+	for _, m := range spec.Mapping {
+		dest := m.To
+		if dest == "" || dest == "." {
+			dest = autoDestPath(m.From, spec, v.Name)
+		} else if file, _, err := types.ParsePathPosition(dest); err == nil {
+			dest = file
+		}
+		paths[dest] = true
+	}
+
+	var missing []string
+	for path := range paths {
+		if _, err := s.fs.Stat(path); err != nil {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// isFullCommitHash reports whether ref is a 40-character hex commit SHA.
+func isFullCommitHash(ref string) bool {
+	if len(ref) != 40 {
+		return false
+	}
+	for _, r := range ref {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// shortHash returns the 7-character form of a commit hash.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestQuickStatus_ReportsStatePerRefWithoutHashing(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "lib", "present.go")
+	snippet := filepath.Join(dir, "lib", "snippet.go")
+	gone := filepath.Join(dir, "lib", "gone.go")
+	if err := os.MkdirAll(filepath.Dir(present), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{present, snippet} {
+		if err := os.WriteFile(p, []byte("package lib\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pinned := strings.Repeat("b", 40)
+	config := types.VendorConfig{Vendors: []types.VendorSpec{
+		{Name: "alpha", URL: "https://github.com/owner/alpha", Specs: []types.BranchSpec{
+			{Ref: "main", Mapping: []types.PathMapping{
+				{From: "present.go", To: present},
+				{From: "snippet.go:L1-L2", To: snippet + ":L1-L2"},
+			}},
+			{Ref: "v2", Mapping: []types.PathMapping{{From: "gone.go", To: gone}}},
+			{Ref: "next", Mapping: []types.PathMapping{{From: "present.go", To: present}}},
+		}},
+		{Name: "beta", URL: "https://github.com/owner/beta", Specs: []types.BranchSpec{
+			{Ref: pinned, Mapping: []types.PathMapping{{From: "present.go", To: present}}},
+		}},
+		{Name: "skipped", URL: "https://github.com/owner/skipped", Specs: []types.BranchSpec{{Ref: "main"}}},
+	}}
+	// Hashes are deliberately wrong: quick status must not compare content
+	lock := types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "alpha", Ref: "main", CommitHash: "aaaa1111", FileHashes: map[string]string{present: "bogus"}},
+		{Name: "alpha", Ref: "v2", CommitHash: "cccc3333", FileHashes: map[string]string{gone: "bogus"}},
+		{Name: "beta", Ref: pinned, CommitHash: strings.Repeat("d", 40)},
+	}}
+
+	syncer := &VendorSyncer{
+		configStore: &stubConfigStore{config: config},
+		lockStore:   &stubLockStore{lock: lock},
+		fs:          NewOSFileSystem(),
+	}
+	result, err := syncer.QuickStatus([]string{"skipped"})
+	if err != nil {
+		t.Fatalf("QuickStatus: %v", err)
+	}
+
+	want := map[string]string{
+		"alpha@main":     QuickStatusInSync,
+		"alpha@v2":       QuickStatusMissingFiles,
+		"alpha@next":     QuickStatusNotSynced,
+		"beta@" + pinned: QuickStatusNotSynced,
+	}
+	if len(result.Vendors) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), result.Vendors)
+	}
+	for _, e := range result.Vendors {
+		key := e.Name + "@" + e.Ref
+		if e.State != want[key] {
+			t.Errorf("%s state = %q, want %q (%s)", key, e.State, want[key], e.Detail)
+		}
+		if key == "alpha@v2" && (len(e.MissingPaths) != 1 || e.MissingPaths[0] != gone) {
+			t.Errorf("alpha@v2 missing = %v, want [%s]", e.MissingPaths, gone)
+		}
+		if strings.HasPrefix(key, "beta@") && !strings.Contains(e.Detail, "pins bbbbbbb") {
+			t.Errorf("beta detail = %q, want pinned-commit mismatch", e.Detail)
+		}
+	}
+
+	s := result.Summary
+	if s.Total != 4 || s.InSync != 1 || s.MissingFiles != 1 || s.NotSynced != 2 || s.Result != "FAIL" {
+		t.Errorf("summary = %+v", s)
+	}
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// recursiveNameSeparator joins a recursive vendor's name to the names of the
+// vendors it declares ("parent.child"); vendor names can't contain "/".
+const recursiveNameSeparator = "."
+
+// recursiveExpander discovers the vendors declared by recursive vendors' own
+// vendor.yml as their files land on disk, so update and sync can append them
+// to the plan. Each discovered vendor can be recursive in turn.
+type recursiveExpander struct {
+	planned   map[string]bool     // Vendor names already in the plan
+	ancestors map[string][]string // Vendor name -> normalized URLs of it and the vendors it is nested under
+}
+
+// newRecursiveExpander returns an expander for a plan made of vendors.
+func newRecursiveExpander(vendors []types.VendorSpec) *recursiveExpander {
+	e := &recursiveExpander{planned: make(map[string]bool), ancestors: make(map[string][]string)}
+	for _, v := range vendors {
+		e.planned[v.Name] = true
+		e.ancestors[v.Name] = []string{normalizeRepoURL(v.URL)}
+	}
+	return e
+}
+
+// hasRecursiveVendor reports whether any vendor sets recursive: true.
+func hasRecursiveVendor(vendors []types.VendorSpec) bool {
+	return slices.ContainsFunc(vendors, func(v types.VendorSpec) bool { return v.Recursive })
+}
+
+// expand returns the vendors declared by the vendor.yml (or .git-vendor/vendor.yml)
+// at the root of each directory v's mappings vendored, or nil when v is not
+// recursive. Each is namespaced under v.Name and has its destinations rebased
+// onto the directory that declared it. Nested hooks are dropped so upstream
+// never runs commands here, and nested internal vendors are not followed.
+// A vendor whose URL is v's or an ancestor's is a cycle and is skipped, as is
+// one whose name is already planned or whose destination escapes its
+// directory; each skip is described in the returned warnings.
+func (e *recursiveExpander) expand(v *types.VendorSpec) ([]types.VendorSpec, []string, error) {
+	if !v.Recursive {
+		return nil, nil, nil
+	}
+	var nested []types.VendorSpec
+	var warnings []string
+	seenRoots := make(map[string]bool)
+	for _, spec := range v.Specs {
+		for _, mapping := range spec.Mapping {
+			root := mappingDestFile(v, spec, mapping)
+			if root == "." || seenRoots[root] {
+				continue
+			}
+			seenRoots[root] = true
+			if info, err := os.Stat(root); err != nil || !info.IsDir() {
+				continue
+			}
+			config, err := loadNestedConfig(root)
+			if err != nil {
+				return nil, nil, fmt.Errorf("load vendor.yml nested in %s (%s): %w", v.Name, root, err)
+			}
+			for _, child := range config.Vendors {
+				planned, warning := e.rebase(v, root, child)
+				if warning != "" {
+					warnings = append(warnings, warning)
+					continue
+				}
+				nested = append(nested, planned)
+			}
+		}
+	}
+	return nested, warnings, nil
+}
+
+// loadNestedConfig reads the vendor.yml a project vendored at root declares:
+// root/.git-vendor/vendor.yml, else root/vendor.yml. Neither existing yields
+// an empty config.
+func loadNestedConfig(root string) (types.VendorConfig, error) {
+	config, err := NewFileConfigStore(filepath.Join(root, VendorDir)).Load()
+	if err != nil || len(config.Vendors) > 0 {
+		return config, err
+	}
+	return NewFileConfigStore(root).Load()
+}
+
+// rebase turns child, declared by parent's vendor.yml vendored at root, into a
+// vendor of this project, or returns why it is skipped.
+func (e *recursiveExpander) rebase(parent *types.VendorSpec, root string, child types.VendorSpec) (types.VendorSpec, string) {
+	name := parent.Name + recursiveNameSeparator + child.Name
+	if child.Source == SourceInternal {
+		return child, fmt.Sprintf("skipping %s: internal vendors of %s are not followed", name, parent.Name)
+	}
+	if err := ValidateVendorName(name); err != nil {
+		return child, fmt.Sprintf("skipping %s: %v", name, err)
+	}
+	if e.planned[name] {
+		return child, fmt.Sprintf("skipping %s: a vendor with that name is already planned", name)
+	}
+	url := normalizeRepoURL(child.URL)
+	if slices.Contains(e.ancestors[parent.Name], url) {
+		return child, fmt.Sprintf("skipping %s: %s is already vendored above it (cycle)", name, SanitizeURL(child.URL))
+	}
+
+	rebased := child
+	rebased.Name = name
+	// An upstream's commands never run in this project, even with --allow-hooks
+	rebased.Hooks = nil
+	rebased.PostSync = ""
+	rebased.Specs = make([]types.BranchSpec, len(child.Specs))
+	for i, spec := range child.Specs {
+		spec.Mapping = slices.Clone(spec.Mapping)
+		for j, mapping := range spec.Mapping {
+			dest := path.Join(root, filepath.ToSlash((&FileCopyService{}).computeDestPath(mapping, spec, &child)))
+			if dest != root && !strings.HasPrefix(dest, root+"/") {
+				return child, fmt.Sprintf("skipping %s: destination %s escapes %s", name, mapping.To, root)
+			}
+			spec.Mapping[j].To = dest
+		}
+		spec.DefaultTarget = ""
+		rebased.Specs[i] = spec
+	}
+
+	e.planned[name] = true
+	e.ancestors[name] = append(slices.Clone(e.ancestors[parent.Name]), url)
+	return rebased, ""
+}
+
+// expandRecursiveConfig appends the vendors nested in config's recursive
+// vendors, as currently vendored on disk, so commands comparing config with
+// the lock see the entries update and sync wrote for them. Nested configs
+// that fail to load are left out.
+func expandRecursiveConfig(config types.VendorConfig) types.VendorConfig {
+	if !hasRecursiveVendor(config.Vendors) {
+		return config
+	}
+	e := newRecursiveExpander(config.Vendors)
+	vendors := slices.Clone(config.Vendors)
+	for i := 0; i < len(vendors); i++ {
+		//nolint:errcheck // An unreadable nested config contributes no vendors
+		nested, _, _ := e.expand(&vendors[i])
+		vendors = append(vendors, nested...)
+	}
+	config.Vendors = vendors
+	return config
+}
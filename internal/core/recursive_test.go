@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+// nestedVendorYML is a vendored project's own vendor.yml: one vendor to
+// follow, one pointing back at the parent (a cycle), and one internal.
+const nestedVendorYML = `vendors:
+  - name: child
+    url: https://github.com/owner/child
+    hooks:
+      post_sync: echo upstream must not run this
+    post_sync: touch pwned
+    specs:
+      - ref: main
+        mapping:
+          - from: src
+            to: third_party/child
+          - from: README.md
+  - name: back
+    url: https://github.com/owner/parent.git
+    specs:
+      - ref: main
+        mapping:
+          - from: src
+            to: third_party/parent
+  - name: own
+    source: internal
+    specs:
+      - ref: local
+        mapping:
+          - from: a
+            to: b
+`
+
+func TestRecursiveExpander_NamespacesAndRebasesNestedVendors(t *testing.T) {
+	chdirUnmanagedTest(t)
+	writeFixTestFile(t, filepath.Join("vendor", "parent", VendorDir, ConfigFile), nestedVendorYML)
+
+	parent := types.VendorSpec{Name: "parent", URL: "https://github.com/owner/parent", Recursive: true, Specs: []types.BranchSpec{
+		{Ref: "main", Mapping: []types.PathMapping{{From: ".", To: "vendor/parent"}}},
+	}}
+	e := newRecursiveExpander([]types.VendorSpec{parent})
+	nested, warnings, err := e.expand(&parent)
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+
+	if len(nested) != 1 {
+		t.Fatalf("nested = %+v, want only parent.child", nested)
+	}
+	child := nested[0]
+	if child.Name != "parent.child" || child.Hooks != nil || child.PostSync != "" {
+		t.Errorf("child = %+v, want name parent.child and no hooks or post_sync", child)
+	}
+	var dests []string
+	for _, m := range child.Specs[0].Mapping {
+		dests = append(dests, m.To)
+	}
+	if got, want := strings.Join(dests, ","), "vendor/parent/third_party/child,vendor/parent/README.md"; got != want {
+		t.Errorf("rebased destinations = %s, want %s", got, want)
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "cycle") || !strings.Contains(warnings[1], "internal") {
+		t.Errorf("warnings = %q, want the cycle and the internal vendor", warnings)
+	}
+
+	// A second expansion of the same parent plans nothing new
+	if again, _, _ := e.expand(&parent); len(again) != 0 {
+		t.Errorf("second expand = %+v, want nothing (already planned)", again)
+	}
+	plain := parent
+	plain.Recursive = false
+	if none, _, _ := newRecursiveExpander(nil).expand(&plain); none != nil {
+		t.Errorf("non-recursive vendor expanded to %+v", none)
+	}
+}
+
+func TestUpdateAll_RecursiveVendorExpandsNestedVendorYML(t *testing.T) {
+	chdirUnmanagedTest(t)
+	if err := os.MkdirAll(VendorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configStore, lockStore := NewFileConfigStore(VendorDir), NewFileLockStore(VendorDir)
+	parent := types.VendorSpec{Name: "parent", URL: "https://github.com/owner/parent", Recursive: true, Specs: []types.BranchSpec{
+		{Ref: "main", Mapping: []types.PathMapping{{From: ".", To: "vendor/parent"}}},
+	}}
+	if err := configStore.Save(createTestConfig(parent)); err != nil {
+		t.Fatal(err)
+	}
+
+	upstream := map[string]map[string]string{
+		"https://github.com/owner/parent": {
+			"lib.go":   "package parent\n",
+			ConfigFile: nestedVendorYML,
+		},
+		"https://github.com/owner/child": {
+			"src/child.go": "package child\n",
+			"README.md":    "# child\n",
+		},
+	}
+	var mu sync.Mutex
+	remotes := make(map[string]string) // clone dir -> URL
+	git := NewMockGitClient(gomock.NewController(t))
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", gomock.Any()).DoAndReturn(func(_ context.Context, dir, _, url string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		remotes[dir] = url
+		return nil
+	}).AnyTimes()
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), "main").Return(nil).AnyTimes()
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, dir, _ string) error {
+		mu.Lock()
+		files := upstream[remotes[dir]]
+		mu.Unlock()
+		for path, content := range files {
+			writeFixTestFile(t, filepath.Join(dir, path), content)
+		}
+		return nil
+	}).AnyTimes()
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, dir string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasSuffix(remotes[dir], "child") {
+			return strings.Repeat("c", 40), nil
+		}
+		return strings.Repeat("p", 40), nil
+	}).AnyTimes()
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	syncer := NewVendorSyncer(configStore, lockStore, git, NewOSFileSystem(), nil, VendorDir, &SilentUICallback{}, nil)
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join("vendor", "parent", "third_party", "child", "child.go")); string(data) != "package child\n" {
+		t.Errorf("nested vendor file = %q, want it vendored under the parent", data)
+	}
+	lock, err := lockStore.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range lock.Vendors {
+		names = append(names, entry.Name+"@"+entry.CommitHash[:1])
+	}
+	if got, want := strings.Join(names, ","), "parent@p,parent.child@c"; got != want {
+		t.Errorf("lock entries = %s, want %s", got, want)
+	}
+	if entry := findLockEntry(lock, "parent.child", "main"); entry == nil || entry.FileHashes["vendor/parent/README.md"] == "" {
+		t.Errorf("parent.child lock entry = %+v, want a hash for vendor/parent/README.md", entry)
+	}
+
+	// The nested entries are coherent with the config as expanded from disk
+	check, err := syncer.CheckLock()
+	if err != nil {
+		t.Fatalf("CheckLock: %v", err)
+	}
+	if len(check.Issues) != 0 {
+		t.Errorf("lock check issues = %+v, want none", check.Issues)
+	}
+}
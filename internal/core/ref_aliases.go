@@ -0,0 +1,53 @@
+package core
+
+import (
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ApplyRefAliases returns config with vendor refs redirected by the
+// ref_aliases entry for hostBranch, leaving the caller's config untouched.
+// A "vendor@ref" key redirects one vendor and wins over a bare "ref" key,
+// which redirects every vendor tracking that ref. Each redirected BranchSpec
+// keeps its vendor.yml ref in BaseRef so lock entries stay keyed by it.
+// Internal vendors are never aliased. An empty hostBranch (detached HEAD, no
+// repository) or a branch without aliases returns config unchanged.
+func ApplyRefAliases(config types.VendorConfig, hostBranch string) types.VendorConfig {
+	aliases := config.RefAliases[hostBranch]
+	if hostBranch == "" || len(aliases) == 0 {
+		return config
+	}
+
+	vendors := make([]types.VendorSpec, len(config.Vendors))
+	for i, v := range config.Vendors {
+		if v.Source != SourceInternal {
+			specs := make([]types.BranchSpec, len(v.Specs))
+			for j, spec := range v.Specs {
+				target, ok := aliases[v.Name+"@"+spec.Ref]
+				if !ok {
+					target = aliases[spec.Ref]
+				}
+				if target != "" && target != spec.Ref {
+					spec.BaseRef = spec.Ref
+					spec.Ref = target
+				}
+				specs[j] = spec
+			}
+			v.Specs = specs
+		}
+		vendors[i] = v
+	}
+	config.Vendors = vendors
+	return config
+}
+
+// lockRefFor maps a ref synced for v back to the ref its lock entry is keyed
+// by. alias is the effective ref when ApplyRefAliases replaced the vendor.yml
+// ref, "" otherwise.
+func lockRefFor(v *types.VendorSpec, ref string) (lockRef, alias string) {
+	for _, spec := range v.Specs {
+		if spec.Ref == ref && spec.BaseRef != "" {
+			return spec.BaseRef, ref
+		}
+	}
+	return ref, ""
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestApplyRefAliases(t *testing.T) {
+	internal := createTestVendorSpec("internal-lib", "", "main")
+	internal.Source = SourceInternal
+	config := types.VendorConfig{
+		RefAliases: map[string]map[string]string{
+			"release/1.x": {"main": "v1.4.0", "lib-b@main": "v2.0.0"},
+		},
+		Vendors: []types.VendorSpec{
+			createTestVendorSpec("lib-a", "https://github.com/owner/a", "main"),
+			createTestVendorSpec("lib-b", "https://github.com/owner/b", "main"),
+			createTestVendorSpec("lib-c", "https://github.com/owner/c", "develop"),
+			internal,
+		},
+	}
+
+	got := ApplyRefAliases(config, "release/1.x")
+	tests := []struct {
+		vendor, ref, base string
+	}{
+		{"lib-a", "v1.4.0", "main"},  // bare ref alias
+		{"lib-b", "v2.0.0", "main"},  // vendor@ref wins over the bare ref
+		{"lib-c", "develop", ""},     // ref without an alias
+		{"internal-lib", "main", ""}, // internal vendors are never aliased
+	}
+	for i, tt := range tests {
+		spec := got.Vendors[i].Specs[0]
+		if got.Vendors[i].Name != tt.vendor || spec.Ref != tt.ref || spec.BaseRef != tt.base {
+			t.Errorf("%s: Ref = %q, BaseRef = %q; want %q, %q", got.Vendors[i].Name, spec.Ref, spec.BaseRef, tt.ref, tt.base)
+		}
+	}
+
+	for _, v := range config.Vendors {
+		if v.Specs[0].BaseRef != "" || (v.Specs[0].Ref != "main" && v.Specs[0].Ref != "develop") {
+			t.Errorf("input config mutated: %s spec = %+v", v.Name, v.Specs[0])
+		}
+	}
+
+	if other := ApplyRefAliases(config, "main"); other.Vendors[0].Specs[0].Ref != "main" {
+		t.Errorf("branch without aliases redirected lib-a to %q", other.Vendors[0].Specs[0].Ref)
+	}
+	if detached := ApplyRefAliases(config, ""); detached.Vendors[0].Specs[0].Ref != "main" {
+		t.Errorf("empty host branch redirected lib-a to %q", detached.Vendors[0].Specs[0].Ref)
+	}
+}
+
+func TestLockRefFor(t *testing.T) {
+	v := types.VendorSpec{Specs: []types.BranchSpec{{Ref: "v1.4.0", BaseRef: "main"}, {Ref: "dev"}}}
+	if ref, alias := lockRefFor(&v, "v1.4.0"); ref != "main" || alias != "v1.4.0" {
+		t.Errorf("lockRefFor(v1.4.0) = %q, %q; want main, v1.4.0", ref, alias)
+	}
+	if ref, alias := lockRefFor(&v, "dev"); ref != "dev" || alias != "" {
+		t.Errorf("lockRefFor(dev) = %q, %q; want dev, empty", ref, alias)
+	}
+}
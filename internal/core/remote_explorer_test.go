@@ -111,8 +111,48 @@ func TestParseSmartURL(t *testing.T) {
 			wantPath:    "path/file-name_v2.test.js",
 			description: "Should handle filenames with hyphens and underscores",
 		},
-		// Note: Branch names with slashes (e.g., feature/new-feature) are not currently supported
-		// in deep link parsing due to regex limitations. Users should manually enter such refs.
+		{
+			name:        "SCP-style SSH URL",
+			input:       "git@github.com:owner/repo.git",
+			wantURL:     "git@github.com:owner/repo",
+			wantRef:     "",
+			wantPath:    "",
+			description: "Should keep SSH URL without https:// prefix or ref/path extraction",
+		},
+		{
+			name:        "ssh:// URL with port",
+			input:       "ssh://git@host:22/owner/repo",
+			wantURL:     "ssh://git@host:22/owner/repo",
+			wantRef:     "",
+			wantPath:    "",
+			description: "Should keep explicit ssh:// URL untouched",
+		},
+		{
+			name:        "ssh:// URL on github.com with tree segment",
+			input:       "ssh://git@github.com/owner/repo/tree/main/src",
+			wantURL:     "ssh://git@github.com/owner/repo/tree/main/src",
+			wantRef:     "",
+			wantPath:    "",
+			description: "Should not attempt deep-link parsing on SSH URLs",
+		},
+		{
+			name:        "GitHub tree URL with feature/ branch",
+			input:       "https://github.com/owner/repo/tree/feature/new-feature/src",
+			wantURL:     "https://github.com/owner/repo",
+			wantRef:     "feature/new-feature",
+			wantPath:    "src",
+			description: "Should keep known branch prefix in the ref",
+		},
+		{
+			name:        "GitHub blob URL with release/ branch",
+			input:       "https://github.com/owner/repo/blob/release/2.0/README.md",
+			wantURL:     "https://github.com/owner/repo",
+			wantRef:     "release/2.0",
+			wantPath:    "README.md",
+			description: "Should keep release branch version in the ref",
+		},
+		// Note: Branch names with a known prefix (feature/, release/, hotfix/, bugfix/) and a
+		// single slash are recovered heuristically. Other slashed refs need manual entry.
 	}
 
 	for _, tt := range tests {
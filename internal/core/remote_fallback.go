@@ -22,7 +22,8 @@ func ResolveVendorURLs(v *types.VendorSpec) []string {
 
 // FetchWithFallback tries fetching from each URL in order until one succeeds.
 // FetchWithFallback returns the URL that succeeded and nil error, or empty
-// string and the last error if all URLs fail.
+// string and the last error if all URLs fail. Switching to a mirror is logged
+// as a warning to logger (nil = not logged).
 //
 // Strategy: the first URL is added as "origin" via AddRemote. Subsequent URLs
 // are swapped in via SetRemoteURL to avoid multiple named remotes (which would
@@ -32,6 +33,7 @@ func FetchWithFallback(
 	gitClient GitClient,
 	fs FileSystem,
 	ui UICallback,
+	logger Logger,
 	tempDir string,
 	urls []string,
 	ref string,
@@ -59,8 +61,8 @@ func FetchWithFallback(
 				lastErr = fmt.Errorf("set remote URL to %s: %w", SanitizeURL(url), setErr)
 				continue
 			}
-			if Verbose {
-				ui.ShowWarning("Mirror Fallback", fmt.Sprintf("Trying %s", SanitizeURL(url)))
+			if logger != nil {
+				logger.Warnf("%s: trying mirror %s", ref, SanitizeURL(url))
 			}
 		}
 
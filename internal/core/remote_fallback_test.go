@@ -55,7 +55,7 @@ func TestFetchWithFallback_FirstURLSucceeds(t *testing.T) {
 	mockGit.EXPECT().AddRemote(gomock.Any(), "/tmp/repo", "origin", "https://primary.com/repo").Return(nil)
 	mockGit.EXPECT().Fetch(gomock.Any(), "/tmp/repo", "origin", 1, "main").Return(nil)
 
-	usedURL, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{},
+	usedURL, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{}, nil,
 		"/tmp/repo", []string{"https://primary.com/repo", "https://mirror.com/repo"}, "main", 1)
 
 	if err != nil {
@@ -81,7 +81,7 @@ func TestFetchWithFallback_FallsBackToMirror(t *testing.T) {
 	mockGit.EXPECT().SetRemoteURL(gomock.Any(), "/tmp/repo", "origin", "https://mirror.com/repo").Return(nil)
 	mockGit.EXPECT().Fetch(gomock.Any(), "/tmp/repo", "origin", 1, "main").Return(nil)
 
-	usedURL, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{},
+	usedURL, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{}, nil,
 		"/tmp/repo", []string{"https://primary.com/repo", "https://mirror.com/repo"}, "main", 1)
 
 	if err != nil {
@@ -104,7 +104,7 @@ func TestFetchWithFallback_AllURLsFail(t *testing.T) {
 	mockGit.EXPECT().SetRemoteURL(gomock.Any(), "/tmp/repo", "origin", "https://mirror.com/repo").Return(nil)
 	mockGit.EXPECT().Fetch(gomock.Any(), "/tmp/repo", "origin", 1, "main").Return(errors.New("not found"))
 
-	_, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{},
+	_, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{}, nil,
 		"/tmp/repo", []string{"https://primary.com/repo", "https://mirror.com/repo"}, "main", 1)
 
 	if err == nil {
@@ -116,7 +116,7 @@ func TestFetchWithFallback_AllURLsFail(t *testing.T) {
 }
 
 func TestFetchWithFallback_EmptyURLs(t *testing.T) {
-	_, err := FetchWithFallback(context.Background(), nil, nil, nil, "/tmp", nil, "main", 1)
+	_, err := FetchWithFallback(context.Background(), nil, nil, nil, nil, "/tmp", nil, "main", 1)
 	if err == nil {
 		t.Fatal("Expected error for empty URLs")
 	}
@@ -129,7 +129,7 @@ func TestFetchWithFallback_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	_, err := FetchWithFallback(ctx, nil, nil, nil, "/tmp",
+	_, err := FetchWithFallback(ctx, nil, nil, nil, nil, "/tmp",
 		[]string{"https://example.com/repo"}, "main", 1)
 
 	if err == nil {
@@ -150,7 +150,7 @@ func TestFetchWithFallback_SingleURL(t *testing.T) {
 	mockGit.EXPECT().AddRemote(gomock.Any(), "/tmp/repo", "origin", "https://only.com/repo").Return(nil)
 	mockGit.EXPECT().Fetch(gomock.Any(), "/tmp/repo", "origin", 0, "v2").Return(nil)
 
-	usedURL, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{},
+	usedURL, err := FetchWithFallback(context.Background(), mockGit, mockFS, &SilentUICallback{}, nil,
 		"/tmp/repo", []string{"https://only.com/repo"}, "v2", 0)
 
 	if err != nil {
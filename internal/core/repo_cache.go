@@ -0,0 +1,84 @@
+package core
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RepoCache shares one initialized git directory per repository URL across
+// the vendors processed by a single Sync or UpdateAll invocation. Vendors that
+// point at different subpaths (or refs) of the same repository reuse the
+// directory's object store via additional fetches instead of re-cloning.
+//
+// RepoCache is safe for concurrent use. A directory is held exclusively by one
+// vendor at a time, so parallel workers syncing the same URL run one after
+// another while workers for other URLs proceed independently.
+type RepoCache struct {
+	mu      sync.Mutex
+	entries map[string]*repoCacheEntry
+}
+
+// repoCacheEntry is the shared working directory for one normalized URL.
+type repoCacheEntry struct {
+	mu            sync.Mutex
+	dir           string // Initialized repo with "origin" remote; empty until first successful init
+	cleanup       func() // Removes dir; supplied by the service that created it
+	remoteChanged bool   // Mirror fallback may have pointed origin away from the primary URL
+}
+
+// NewRepoCache creates an empty RepoCache. Callers must Close it to remove
+// the cached directories.
+func NewRepoCache() *RepoCache {
+	return &RepoCache{
+		entries: make(map[string]*repoCacheEntry),
+	}
+}
+
+// acquire returns the entry for rawURL with its lock held.
+// The caller must unlock entry.mu when done with the directory.
+func (c *RepoCache) acquire(rawURL string) *repoCacheEntry {
+	key := normalizeRepoURL(rawURL)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &repoCacheEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	return entry
+}
+
+// Close removes every cached directory. Close must not be called while
+// a sync using the cache is still running.
+func (c *RepoCache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.cleanup != nil {
+			entry.cleanup()
+		}
+		delete(c.entries, key)
+	}
+}
+
+// normalizeRepoURL maps equivalent spellings of a repository URL to one cache key:
+// surrounding whitespace, trailing slashes and a trailing ".git" are dropped, and
+// the scheme and host of URL-style addresses are lowercased.
+func normalizeRepoURL(rawURL string) string {
+	normalized := strings.TrimSpace(rawURL)
+	normalized = strings.TrimRight(normalized, "/")
+	normalized = strings.TrimSuffix(normalized, ".git")
+
+	if strings.Contains(normalized, "://") {
+		if u, err := url.Parse(normalized); err == nil {
+			u.Scheme = strings.ToLower(u.Scheme)
+			u.Host = strings.ToLower(u.Host)
+			normalized = u.String()
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,21 @@
+package core
+
+import "testing"
+
+func TestNormalizeRepoURL(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"https://github.com/owner/repo", "https://github.com/owner/repo.git", true},
+		{"https://github.com/owner/repo/", "https://GitHub.com/owner/repo", true},
+		{"git@github.com:owner/repo.git", "git@github.com:owner/repo", true},
+		{"https://github.com/owner/repo", "https://github.com/owner/other", false},
+		{"https://github.com/owner/Repo", "https://github.com/owner/repo", false},
+	}
+	for _, tt := range tests {
+		if got := normalizeRepoURL(tt.a) == normalizeRepoURL(tt.b); got != tt.same {
+			t.Errorf("normalizeRepoURL(%q) == normalizeRepoURL(%q) = %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+}
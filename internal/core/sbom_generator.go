@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -216,7 +217,7 @@ func (g *SBOMGenerator) buildCycloneDXComponent(vendor *types.LockDetails, repoU
 	// Add file hashes
 	if len(vendor.FileHashes) > 0 {
 		hashes := make([]cdx.Hash, 0, len(vendor.FileHashes))
-		for _, hash := range vendor.FileHashes {
+		for _, hash := range fileHashesByPath(vendor.FileHashes) {
 			hashes = append(hashes, cdx.Hash{
 				Algorithm: cdx.HashAlgoSHA256,
 				Value:     hash,
@@ -267,6 +268,21 @@ func (g *SBOMGenerator) buildCycloneDXComponent(vendor *types.LockDetails, repoU
 	return component
 }
 
+// fileHashesByPath returns the values of a lock FileHashes map in path
+// order, so repeated runs over the same lock produce identical SBOMs.
+func fileHashesByPath(fileHashes map[string]string) []string {
+	paths := make([]string, 0, len(fileHashes))
+	for path := range fileHashes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	hashes := make([]string, len(paths))
+	for i, path := range paths {
+		hashes[i] = fileHashes[path]
+	}
+	return hashes
+}
+
 // generateSPDX creates an SPDX 2.3 JSON SBOM
 func (g *SBOMGenerator) generateSPDX(lock *types.VendorLock, urlMap map[string]string) ([]byte, error) {
 	timestamp := time.Now().UTC().Format(time.RFC3339)
@@ -363,7 +379,7 @@ func (g *SBOMGenerator) buildSPDXPackage(vendor *types.LockDetails, repoURL stri
 	// Add checksums (aggregate hash of all file hashes)
 	if len(vendor.FileHashes) > 0 {
 		checksums := make([]common.Checksum, 0, len(vendor.FileHashes))
-		for _, hash := range vendor.FileHashes {
+		for _, hash := range fileHashesByPath(vendor.FileHashes) {
 			checksums = append(checksums, common.Checksum{
 				Algorithm: common.SHA256,
 				Value:     hash,
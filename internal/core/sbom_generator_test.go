@@ -3,6 +3,7 @@ package core
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 	"testing"
@@ -1324,6 +1325,14 @@ func TestGenerateCycloneDX_IncludesFileHashes(t *testing.T) {
 	if hash["alg"] != "SHA-256" {
 		t.Errorf("Expected hash algorithm 'SHA-256', got %v", hash["alg"])
 	}
+
+	// Hashes follow file path order so output is reproducible
+	for i, h := range hashes {
+		want := fmt.Sprintf("sha256hashvalue%d", i+1)
+		if got := h.(map[string]interface{})["content"]; got != want {
+			t.Errorf("hashes[%d] = %v, want %s", i, got, want)
+		}
+	}
 }
 
 func TestGenerateSPDX_IncludesChecksums(t *testing.T) {
@@ -74,7 +74,7 @@ func TestSEC010_HookSanitizeEnvValue(t *testing.T) {
 func TestSEC010_GitArgsNotShellInterpolated(t *testing.T) {
 	// SystemGitClient.gitFor() creates a new git.Git per call with Dir set.
 	// git.Git.Run() uses exec.CommandContext(ctx, "git", args...) — safe.
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 
 	// Verify gitFor returns a non-nil instance (adapter works)
 	g := gitClient.gitFor(t.TempDir())
@@ -166,6 +166,31 @@ func TestSEC011_ValidateVendor_RejectsFileURL(t *testing.T) {
 	}
 }
 
+// TestValidateVendor_AcceptsSSHURLs verifies that scp-like and ssh:// vendor
+// URLs pass config validation (including as mirrors).
+func TestValidateVendor_AcceptsSSHURLs(t *testing.T) {
+	for _, url := range []string{"git@github.com:owner/repo.git", "ssh://git@host:22/owner/repo"} {
+		vendor := &types.VendorSpec{
+			Name:    "private-lib",
+			URL:     url,
+			Mirrors: []string{"git@mirror.example.com:owner/repo.git"},
+			Specs: []types.BranchSpec{
+				{
+					Ref: "main",
+					Mapping: []types.PathMapping{
+						{From: "src/", To: "vendor/private/"},
+					},
+				},
+			},
+		}
+
+		svc := &ValidationService{}
+		if err := svc.validateVendor(vendor); err != nil {
+			t.Errorf("validateVendor(%q) returned error: %v", url, err)
+		}
+	}
+}
+
 // ============================================================================
 // SEC-013: Credential Exposure Prevention
 // ============================================================================
@@ -456,13 +481,9 @@ func TestSEC022_CopyFile_DanglingSymlink(t *testing.T) {
 	}
 }
 
-// TestSEC022_CopyDir_SymlinkToDirectory verifies that CopyDir errors on symlinks
-// to directories. filepath.Walk uses os.Lstat (no symlink following), so a symlink
-// to a directory is seen as a non-directory entry. CopyFile then calls os.Open
-// which follows the symlink to a directory and fails with "is a directory".
-//
-// Preventing symlink traversal in directory copies avoids symlink-based directory
-// escape attacks. Git clone sources rarely contain directory symlinks.
+// TestSEC022_CopyDir_SymlinkToDirectory verifies that CopyDir never descends
+// into a symlinked directory that points outside the copied tree: the link is
+// skipped with a warning instead of pulling in external content.
 func TestSEC022_CopyDir_SymlinkToDirectory(t *testing.T) {
 	fs := NewOSFileSystem()
 	tempDir := t.TempDir()
@@ -480,23 +501,27 @@ func TestSEC022_CopyDir_SymlinkToDirectory(t *testing.T) {
 		t.Skipf("Symlinks not supported: %v", err)
 	}
 
-	// CopyDir errors on symlinks to directories because filepath.Walk does not
-	// descend into symlinked directories — os.Lstat sees the symlink entry,
-	// info.IsDir() returns false, so CopyFile is called, which os.Open follows
-	// to a directory and fails.
 	destDir := filepath.Join(tempDir, "dest")
 	os.MkdirAll(destDir, 0755)
-	_, err := fs.CopyDir(srcDir, destDir)
-	if err == nil {
-		t.Fatal("CopyDir should error on symlink to directory (filepath.Walk limitation)")
+	stats, err := fs.CopyDir(srcDir, destDir)
+	if err != nil {
+		t.Fatalf("CopyDir failed: %v", err)
+	}
+
+	if _, err := os.Lstat(filepath.Join(destDir, "linked")); !os.IsNotExist(err) {
+		t.Errorf("escaping directory symlink should be skipped, Lstat err = %v", err)
 	}
-	if !strings.Contains(err.Error(), "is a directory") {
-		t.Errorf("Expected 'is a directory' error, got: %v", err)
+	if len(stats.Warnings) != 1 || !strings.Contains(stats.Warnings[0], "linked") {
+		t.Errorf("Warnings = %v, want one warning for linked", stats.Warnings)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "normal", "file.txt")); err != nil {
+		t.Errorf("regular file should still be copied: %v", err)
 	}
 }
 
-// TestSEC022_CopyDir_SymlinkToFile verifies that CopyDir follows file symlinks
-// during directory walk and copies the dereferenced content.
+// TestSEC022_CopyDir_SymlinkToFile verifies that CopyDir does not dereference
+// a file symlink pointing outside the copied tree (no external content leaks
+// into the vendored copy).
 func TestSEC022_CopyDir_SymlinkToFile(t *testing.T) {
 	fs := NewOSFileSystem()
 	tempDir := t.TempDir()
@@ -523,14 +548,14 @@ func TestSEC022_CopyDir_SymlinkToFile(t *testing.T) {
 		t.Fatalf("CopyDir failed: %v", err)
 	}
 
-	if stats.FileCount != 2 {
-		t.Errorf("Expected 2 files copied, got %d", stats.FileCount)
+	if stats.FileCount != 1 {
+		t.Errorf("Expected 1 file copied, got %d", stats.FileCount)
 	}
-
-	// Verify symlinked file content was dereferenced
-	data, _ := os.ReadFile(filepath.Join(destDir, "linked.txt"))
-	if string(data) != "external content" {
-		t.Errorf("Symlinked file content: got %q, want 'external content'", string(data))
+	if _, err := os.Lstat(filepath.Join(destDir, "linked.txt")); !os.IsNotExist(err) {
+		t.Errorf("escaping file symlink should be skipped, Lstat err = %v", err)
+	}
+	if len(stats.Warnings) != 1 {
+		t.Errorf("Warnings = %v, want one warning for linked.txt", stats.Warnings)
 	}
 }
 
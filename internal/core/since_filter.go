@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ParseSince parses a --since age: any time.ParseDuration value ("36h") or a
+// whole number of days ("14d"). The age must be positive.
+func ParseSince(s string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --since %q (use a duration like 14d or 36h)", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --since %q (use a duration like 14d or 36h)", s)
+		}
+		d = parsed
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid --since %q (must be positive)", s)
+	}
+	return d, nil
+}
+
+// upstreamCommitDate returns the committer date of ref's tip upstream.
+// upstreamCommitDate shallow-fetches ref from the first reachable of urls into
+// a throwaway repository; ls-remote reports only hashes, not dates.
+func upstreamCommitDate(ctx context.Context, gitClient GitClient, urls []string, ref string) (time.Time, error) {
+	tempDir, err := os.MkdirTemp("", "since-check-*")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }() //nolint:errcheck // cleanup in defer
+
+	if err := gitClient.Init(ctx, tempDir); err != nil {
+		return time.Time{}, fmt.Errorf("init temp repo: %w", err)
+	}
+	if _, err := FetchWithFallback(ctx, gitClient, NewOSFileSystem(), &SilentUICallback{}, nil, tempDir, urls, ref, 1); err != nil {
+		return time.Time{}, fmt.Errorf("fetch ref '%s': %w", ref, err)
+	}
+	return gitClient.CommitDate(ctx, tempDir, "FETCH_HEAD")
+}
+
+// staleVendors returns the names of the external vendors among vendors whose
+// every ref last changed upstream before cutoff. A ref whose date can't be
+// read counts as recent, so the update surfaces the underlying error.
+func staleVendors(ctx context.Context, gitClient GitClient, vendors []types.VendorSpec, cutoff time.Time) ([]string, error) {
+	var stale []string
+	for i := range vendors {
+		vendor := &vendors[i]
+		if vendor.Source == "internal" || len(vendor.Specs) == 0 {
+			continue
+		}
+		recent := false
+		for _, spec := range vendor.Specs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			date, err := upstreamCommitDate(ctx, gitClient, ResolveVendorURLs(vendor), spec.Ref)
+			if err != nil || !date.Before(cutoff) {
+				recent = true
+				break
+			}
+		}
+		if !recent {
+			stale = append(stale, vendor.Name)
+		}
+	}
+	return stale, nil
+}
+
+// excludeStaleVendors adds each vendor opts selects whose refs all predate
+// opts.Since upstream to opts.ExcludeVendors, reporting each one skipped.
+func (s *VendorSyncer) excludeStaleVendors(ctx context.Context, opts *PullOptions) error {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	var selected []types.VendorSpec
+	for _, v := range config.Vendors {
+		if opts.selectsVendor(v.Name) {
+			selected = append(selected, v)
+		}
+	}
+
+	cutoff := time.Now().Add(-opts.Since)
+	stale, err := staleVendors(ctx, s.gitClient, selected, cutoff)
+	if err != nil {
+		return err
+	}
+	for _, name := range stale {
+		s.ui.ShowWarning("Since", fmt.Sprintf("Skipping %s: no upstream commit since %s", name, cutoff.Format("2006-01-02")))
+	}
+	opts.ExcludeVendors = append(opts.ExcludeVendors, stale...)
+	return nil
+}
@@ -0,0 +1,212 @@
+package core
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SnapshotPath returns the tar.gz snapshot location for vendorName at commitHash.
+// vendorDir is the vendor directory (normally VendorDir); snapshots are keyed by
+// commit so one archive restores every ref locked to that commit. Both names
+// go through sanitizeFilename, as FileCacheStore's cache files do.
+func SnapshotPath(vendorDir, vendorName, commitHash string) string {
+	return filepath.Join(vendorDir, SnapshotsDir, sanitizeFilename(vendorName), sanitizeFilename(commitHash)+".tar.gz")
+}
+
+// writeSnapshot archives the checked-out tree at srcDir (excluding .git) into a
+// gzip-compressed tarball at archivePath. The archive is written to a temp file
+// and renamed into place so an interrupted write never leaves a truncated snapshot.
+func writeSnapshot(srcDir, archivePath string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return fmt.Errorf("create snapshot directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(archivePath), ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("create snapshot temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()        //nolint:errcheck // cleanup on failure
+			_ = os.Remove(tmpPath) //nolint:errcheck // cleanup on failure
+		}
+	}()
+
+	gz := gzip.NewWriter(tmp)
+	tw := tar.NewWriter(gz)
+
+	walkErr := filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == srcDir {
+			return nil
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }() //nolint:errcheck // read-only file
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if walkErr != nil {
+		return fmt.Errorf("archive %s: %w", srcDir, walkErr)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("finalize snapshot tar: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("finalize snapshot gzip: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, archivePath); err != nil {
+		return fmt.Errorf("save snapshot: %w", err)
+	}
+	return nil
+}
+
+// extractSnapshot unpacks the tarball at archivePath into destDir.
+// Entries that would escape destDir (absolute paths, ".." components, or
+// symlinks pointing outside destDir) are rejected.
+func extractSnapshot(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("open snapshot: %w", err)
+	}
+	defer func() { _ = f.Close() }() //nolint:errcheck // read-only file
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("read snapshot %s: %w", archivePath, err)
+	}
+	defer func() { _ = gz.Close() }() //nolint:errcheck // read-only stream
+
+	if err := extractTar(tar.NewReader(gz), destDir); err != nil {
+		return fmt.Errorf("read snapshot %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+// extractTar unpacks a tar stream into destDir with the same containment
+// rules as extractSnapshot.
+func extractTar(tr *tar.Reader, destDir string) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := snapshotEntryPath(destDir, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				_ = out.Close() //nolint:errcheck // already failing
+				return fmt.Errorf("extract %s: %w", hdr.Name, err)
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			resolved := hdr.Linkname
+			if !filepath.IsAbs(resolved) {
+				resolved = filepath.Join(filepath.Dir(target), resolved)
+			}
+			if _, err := snapshotEntryPath(destDir, mustRel(destDir, resolved)); err != nil {
+				return fmt.Errorf("snapshot symlink %s escapes the archive root", hdr.Name)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// snapshotEntryPath joins an archive entry name onto destDir, rejecting
+// entries that resolve outside destDir.
+func snapshotEntryPath(destDir, name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("snapshot entry %q escapes the archive root", name)
+	}
+	return filepath.Join(destDir, cleaned), nil
+}
+
+// mustRel returns target relative to base, or target unchanged when no
+// relative path exists (which snapshotEntryPath then rejects as absolute).
+func mustRel(base, target string) string {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return target
+	}
+	return rel
+}
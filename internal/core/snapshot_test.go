@@ -0,0 +1,248 @@
+package core
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ============================================================================
+// Snapshot Tests - pull --snapshot / --offline
+// ============================================================================
+
+const snapshotTestCommit = "1111111111111111111111111111111111111111"
+
+func snapshotTestVendor() types.VendorSpec {
+	return types.VendorSpec{
+		Name: "snap-lib",
+		URL:  "https://github.com/owner/snap-lib",
+		Specs: []types.BranchSpec{
+			{
+				Ref: "main",
+				Mapping: []types.PathMapping{
+					{From: "src", To: "vendor/snap-lib"},
+					{From: "api.go:L2-L3", To: "vendor/api_snippet.go"},
+				},
+			},
+		},
+	}
+}
+
+// writeSnapshotTestTree populates dir with the upstream tree served by the mock git client.
+func writeSnapshotTestTree(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"LICENSE":          "MIT License\n",
+		"src/a.go":         "package a\n",
+		"src/nested/b.go":  "package nested\n",
+		"api.go":           "package api\nfunc A() {}\nfunc B() {}\n",
+		".git/HEAD":        "ref: refs/heads/main\n",
+		"docs/unmapped.md": "not vendored\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newSnapshotTestSyncService builds a SyncService over the real filesystem
+// with rootDir at <workDir>/.git-vendor.
+func newSnapshotTestSyncService(git GitClient, workDir string) *SyncService {
+	osFS := NewOSFileSystem()
+	rootDir := filepath.Join(workDir, VendorDir)
+	return NewSyncService(nil, nil, git, osFS,
+		NewFileCopyService(osFS), NewLicenseService(nil, osFS, rootDir, &SilentUICallback{}),
+		NewFileCacheStore(osFS, rootDir), NewHookService(nil), &SilentUICallback{}, rootDir, nil)
+}
+
+// syncWithSnapshot runs an update-mode SyncVendor with --snapshot against a
+// mock git client that materializes writeSnapshotTestTree on checkout.
+func syncWithSnapshot(t *testing.T, workDir string) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/snap-lib").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), FetchHead).DoAndReturn(
+		func(_ context.Context, dir, _ string) error {
+			writeSnapshotTestTree(t, dir)
+			return nil
+		})
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return(snapshotTestCommit, nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	vendor := snapshotTestVendor()
+	svc := newSnapshotTestSyncService(git, workDir)
+	if _, _, err := svc.SyncVendor(context.Background(), &vendor, nil, SyncOptions{NoCache: true, Snapshot: true}); err != nil {
+		t.Fatalf("SyncVendor with Snapshot: %v", err)
+	}
+}
+
+func TestWriteExtractSnapshot_RoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	writeSnapshotTestTree(t, srcDir)
+
+	archive := filepath.Join(t.TempDir(), "snap", "abc.tar.gz")
+	if err := writeSnapshot(srcDir, archive); err != nil {
+		t.Fatalf("writeSnapshot: %v", err)
+	}
+
+	destDir := t.TempDir()
+	if err := extractSnapshot(archive, destDir); err != nil {
+		t.Fatalf("extractSnapshot: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(destDir, "src", "nested", "b.go"))
+	if err != nil {
+		t.Fatalf("expected nested file restored: %v", err)
+	}
+	if string(got) != "package nested\n" {
+		t.Errorf("restored content = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, ".git")); !os.IsNotExist(err) {
+		t.Error("expected .git to be excluded from snapshot")
+	}
+}
+
+func TestExtractSnapshot_RejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "evil.tar.gz")
+
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	content := []byte("owned")
+	if err := tw.WriteHeader(&tar.Header{Name: "../escape.txt", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	_ = f.Close()
+
+	destDir := filepath.Join(dir, "out")
+	err = extractSnapshot(archive, destDir)
+	if err == nil || !strings.Contains(err.Error(), "escapes the archive root") {
+		t.Fatalf("expected traversal rejection, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "escape.txt")); !os.IsNotExist(statErr) {
+		t.Error("traversal entry must not be written outside destDir")
+	}
+}
+
+func TestSnapshotPath_SanitizesNames(t *testing.T) {
+	got := SnapshotPath(VendorDir, "org/lib", "abc123")
+	want := filepath.Join(VendorDir, SnapshotsDir, "org_lib", "abc123.tar.gz")
+	if got != want {
+		t.Errorf("SnapshotPath = %q, want %q", got, want)
+	}
+}
+
+func TestSyncVendor_SnapshotWrittenOnUpdate(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+
+	syncWithSnapshot(t, workDir)
+
+	archive := SnapshotPath(filepath.Join(workDir, VendorDir), "snap-lib", snapshotTestCommit)
+	if _, err := os.Stat(archive); err != nil {
+		t.Fatalf("expected snapshot at %s: %v", archive, err)
+	}
+	if !strings.HasSuffix(filepath.ToSlash(archive), SnapshotsPath+"/snap-lib/"+snapshotTestCommit+".tar.gz") {
+		t.Errorf("unexpected snapshot location %s", archive)
+	}
+}
+
+func TestSyncVendor_OfflineRestoresFromSnapshot(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+	syncWithSnapshot(t, workDir)
+
+	// Record hashes of the online sync, then wipe the vendored output
+	cache := NewFileCacheStore(NewOSFileSystem(), workDir)
+	dests := []string{"vendor/snap-lib/a.go", "vendor/snap-lib/nested/b.go", "vendor/api_snippet.go"}
+	want := make(map[string]string)
+	for _, d := range dests {
+		h, err := cache.ComputeFileChecksum(d)
+		if err != nil {
+			t.Fatalf("hash %s after online sync: %v", d, err)
+		}
+		want[d] = h
+	}
+	if err := os.RemoveAll("vendor"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(VendorDir, LicensesDir)); err != nil {
+		t.Fatal(err)
+	}
+
+	// No git expectations: any remote call fails the test
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+
+	vendor := snapshotTestVendor()
+	svc := newSnapshotTestSyncService(git, workDir)
+	refs, stats, err := svc.SyncVendor(context.Background(), &vendor,
+		map[string]string{"main": snapshotTestCommit}, SyncOptions{NoCache: true, Offline: true})
+	if err != nil {
+		t.Fatalf("offline SyncVendor: %v", err)
+	}
+	if refs["main"].CommitHash != snapshotTestCommit {
+		t.Errorf("CommitHash = %q, want %q", refs["main"].CommitHash, snapshotTestCommit)
+	}
+	if stats.FileCount == 0 {
+		t.Error("expected restored files to be counted")
+	}
+
+	for _, d := range dests {
+		got, err := cache.ComputeFileChecksum(d)
+		if err != nil {
+			t.Fatalf("expected %s restored: %v", d, err)
+		}
+		if got != want[d] {
+			t.Errorf("%s hash = %s, want %s", d, got, want[d])
+		}
+	}
+	if _, err := os.Stat(filepath.Join(VendorDir, LicensesDir, "snap-lib.txt")); err != nil {
+		t.Errorf("expected license restored from snapshot: %v", err)
+	}
+	if _, err := os.Stat("vendor/unmapped.md"); !os.IsNotExist(err) {
+		t.Error("unmapped snapshot files must not be copied")
+	}
+}
+
+func TestSyncVendor_OfflineMissingSnapshot(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+
+	vendor := snapshotTestVendor()
+	svc := newSnapshotTestSyncService(git, workDir)
+	_, _, err := svc.SyncVendor(context.Background(), &vendor,
+		map[string]string{"main": snapshotTestCommit}, SyncOptions{NoCache: true, Offline: true})
+	if err == nil || !strings.Contains(err.Error(), "no snapshot") {
+		t.Fatalf("expected missing snapshot error, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// SourceCacheDir returns the directory holding cached upstream source files
+// for commitHash. vendorDir is the vendor directory (normally VendorDir); files
+// are stored at their repository-relative paths, so the directory can stand in
+// for a checked-out tree when re-running position mappings.
+func SourceCacheDir(vendorDir, commitHash string) string {
+	return filepath.Join(vendorDir, CacheDir, SourcesCacheDir, commitHash)
+}
+
+// positionOnlyVendor returns a copy of v whose specs keep only position
+// mappings (source path with a line/column specifier). Specs left without
+// mappings are dropped.
+func positionOnlyVendor(v *types.VendorSpec) types.VendorSpec {
+	filtered := *v
+	filtered.Specs = nil
+	for _, spec := range v.Specs {
+		var mappings []types.PathMapping
+		for _, m := range spec.Mapping {
+			if _, ok := positionSourceFile(m, spec.Ref); ok {
+				mappings = append(mappings, m)
+			}
+		}
+		if len(mappings) > 0 {
+			spec.Mapping = mappings
+			filtered.Specs = append(filtered.Specs, spec)
+		}
+	}
+	return filtered
+}
+
+// positionSourceFile returns the repository-relative source file of a
+// position mapping. ok is false for whole-file and directory mappings.
+func positionSourceFile(m types.PathMapping, ref string) (string, bool) {
+	srcFile, srcPos, err := types.ParsePathPosition(cleanMappingSource(m.From, ref))
+	if err != nil || srcPos == nil {
+		return "", false
+	}
+	return srcFile, true
+}
+
+// cachePositionSources copies the source file of every position mapping in
+// spec from the checked-out tree at tempDir into SourceCacheDir for
+// commitHash. Sources missing upstream are skipped; the sync itself reports them.
+func cachePositionSources(tempDir, vendorDir, commitHash string, spec types.BranchSpec) error {
+	cacheDir := SourceCacheDir(vendorDir, commitHash)
+	for _, m := range spec.Mapping {
+		srcFile, ok := positionSourceFile(m, spec.Ref)
+		if !ok {
+			continue
+		}
+		// Source paths come from vendor.yml; keep cached copies inside cacheDir
+		if err := ValidateDestPath(srcFile); err != nil {
+			return fmt.Errorf("cache source %s: %w", srcFile, err)
+		}
+		data, err := os.ReadFile(filepath.Join(tempDir, srcFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read source %s: %w", srcFile, err)
+		}
+		dest := filepath.Join(cacheDir, srcFile)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("create source cache directory: %w", err)
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return fmt.Errorf("write cached source %s: %w", srcFile, err)
+		}
+	}
+	return nil
+}
+
+// hasCachedPositionSources reports whether SourceCacheDir for commitHash holds
+// the source file of every position mapping in spec.
+func hasCachedPositionSources(vendorDir, commitHash string, spec types.BranchSpec) bool {
+	if commitHash == "" {
+		return false
+	}
+	cacheDir := SourceCacheDir(vendorDir, commitHash)
+	for _, m := range spec.Mapping {
+		srcFile, ok := positionSourceFile(m, spec.Ref)
+		if !ok {
+			continue
+		}
+		if ValidateDestPath(srcFile) != nil {
+			return false
+		}
+		if info, err := os.Stat(filepath.Join(cacheDir, srcFile)); err != nil || info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
+// syncPositionsFromCache re-places v's position mappings from the source cache
+// at each ref's locked commit, without any git operations. v must already be
+// reduced by positionOnlyVendor. ok is false when any ref is unlocked or has an
+// uncached source, in which case nothing was written and the caller falls back
+// to a full fetch.
+func (s *SyncService) syncPositionsFromCache(v *types.VendorSpec, lockedRefs map[string]string) (results map[string]RefMetadata, totalStats CopyStats, ok bool, err error) {
+	for _, spec := range v.Specs {
+		if !hasCachedPositionSources(s.rootDir, lockedRefs[spec.Ref], spec) {
+			return nil, CopyStats{}, false, nil
+		}
+	}
+
+	fmt.Printf("⚡ %s (re-placing positions from source cache)\n", v.Name)
+	results = make(map[string]RefMetadata)
+	for _, spec := range v.Specs {
+		hash := lockedRefs[spec.Ref]
+		stats, err := s.fileCopy.CopyMappings(SourceCacheDir(s.rootDir, hash), v, spec)
+		if err != nil {
+			return nil, CopyStats{}, true, err
+		}
+		for _, w := range stats.Warnings {
+			fmt.Printf("  ⚠ %s\n", w)
+		}
+		results[spec.Ref] = RefMetadata{CommitHash: hash, Positions: stats.Positions}
+		totalStats.Add(stats)
+		fmt.Printf("  ✓ %s @ %s (placed %s)\n",
+			v.Name, spec.Ref,
+			Pluralize(len(spec.Mapping), "position", "positions"))
+	}
+	return results, totalStats, true, nil
+}
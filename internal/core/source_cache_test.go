@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// ============================================================================
+// Source Cache Tests - pull --only-positions
+// ============================================================================
+
+// syncPopulatingSourceCache runs a cached update-mode SyncVendor against a mock
+// git client that materializes writeSnapshotTestTree on checkout, filling the
+// position source cache for snapshotTestCommit.
+func syncPopulatingSourceCache(t *testing.T, workDir string) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/snap-lib").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), FetchHead).DoAndReturn(
+		func(_ context.Context, dir, _ string) error {
+			writeSnapshotTestTree(t, dir)
+			return nil
+		})
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return(snapshotTestCommit, nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	vendor := snapshotTestVendor()
+	svc := newSnapshotTestSyncService(git, workDir)
+	if _, _, err := svc.SyncVendor(context.Background(), &vendor, nil, SyncOptions{}); err != nil {
+		t.Fatalf("SyncVendor: %v", err)
+	}
+}
+
+func TestSyncVendor_CachesPositionSources(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+	syncPopulatingSourceCache(t, workDir)
+
+	cacheDir := SourceCacheDir(filepath.Join(workDir, VendorDir), snapshotTestCommit)
+	got, err := os.ReadFile(filepath.Join(cacheDir, "api.go"))
+	if err != nil {
+		t.Fatalf("expected cached position source: %v", err)
+	}
+	if string(got) != "package api\nfunc A() {}\nfunc B() {}\n" {
+		t.Errorf("cached source = %q", got)
+	}
+	// Whole-file and directory mappings are not cached
+	if _, err := os.Stat(filepath.Join(cacheDir, "src")); !os.IsNotExist(err) {
+		t.Error("expected directory mapping source to be excluded from the source cache")
+	}
+}
+
+func TestSyncVendor_OnlyPositionsFromCacheRunsNoGit(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+	syncPopulatingSourceCache(t, workDir)
+
+	// Drift the snippet and remove a whole-file output
+	if err := os.WriteFile("vendor/api_snippet.go", []byte("locally edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll("vendor/snap-lib"); err != nil {
+		t.Fatal(err)
+	}
+
+	// No git expectations: any git operation fails the test
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+
+	vendor := snapshotTestVendor()
+	svc := newSnapshotTestSyncService(git, workDir)
+	refs, stats, err := svc.SyncVendor(context.Background(), &vendor,
+		map[string]string{"main": snapshotTestCommit}, SyncOptions{OnlyPositions: true})
+	if err != nil {
+		t.Fatalf("only-positions SyncVendor: %v", err)
+	}
+
+	got, err := os.ReadFile("vendor/api_snippet.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "func A() {}\nfunc B() {}" {
+		t.Errorf("re-placed snippet = %q", got)
+	}
+	if stats.FileCount != 1 || len(stats.Positions) != 1 {
+		t.Errorf("expected one position placed, got %d files / %d positions", stats.FileCount, len(stats.Positions))
+	}
+	if refs["main"].CommitHash != snapshotTestCommit {
+		t.Errorf("CommitHash = %q, want %q", refs["main"].CommitHash, snapshotTestCommit)
+	}
+	// Whole-file mappings are left alone
+	if _, err := os.Stat("vendor/snap-lib"); !os.IsNotExist(err) {
+		t.Error("expected --only-positions to skip directory mappings")
+	}
+}
+
+func TestSyncVendor_OnlyPositionsFetchesWhenUncached(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/snap-lib").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, snapshotTestCommit).Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), snapshotTestCommit).DoAndReturn(
+		func(_ context.Context, dir, _ string) error {
+			writeSnapshotTestTree(t, dir)
+			return nil
+		})
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return(snapshotTestCommit, nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	vendor := snapshotTestVendor()
+	svc := newSnapshotTestSyncService(git, workDir)
+	if _, _, err := svc.SyncVendor(context.Background(), &vendor,
+		map[string]string{"main": snapshotTestCommit}, SyncOptions{OnlyPositions: true}); err != nil {
+		t.Fatalf("only-positions SyncVendor: %v", err)
+	}
+
+	if _, err := os.Stat("vendor/api_snippet.go"); err != nil {
+		t.Errorf("expected snippet placed after fetch: %v", err)
+	}
+	if _, err := os.Stat("vendor/snap-lib"); !os.IsNotExist(err) {
+		t.Error("expected --only-positions to skip directory mappings")
+	}
+	if _, err := os.Stat(filepath.Join(SourceCacheDir(filepath.Join(workDir, VendorDir), snapshotTestCommit), "api.go")); err != nil {
+		t.Errorf("expected fetch to populate the source cache: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// statusCounts maps each --fail-on status name to its count in a status
+// result. "stale" and "orphaned" are the verify coherence statuses;
+// "outdated" is a vendor behind upstream; "policy" is a blocking policy
+// violation.
+var statusCounts = map[string]func(*types.StatusResult) int{
+	"modified":       func(r *types.StatusResult) int { return r.Summary.Modified },
+	"deleted":        func(r *types.StatusResult) int { return r.Summary.Deleted },
+	"truncated":      func(r *types.StatusResult) int { return r.Summary.Truncated },
+	"added":          func(r *types.StatusResult) int { return r.Summary.Added },
+	"stale":          func(r *types.StatusResult) int { return r.Summary.StaleConfigs },
+	"orphaned":       func(r *types.StatusResult) int { return r.Summary.OrphanedLock },
+	"outdated":       func(r *types.StatusResult) int { return r.Summary.Stale },
+	"upstream-error": func(r *types.StatusResult) int { return r.Summary.UpstreamErrors },
+	"policy":         policyErrorCount,
+}
+
+// ParseFailOn parses a --fail-on list: comma-separated status names from
+// statusCounts. Names are case-insensitive; unknown names are an error.
+func ParseFailOn(list string) ([]string, error) {
+	var statuses []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := statusCounts[name]; !ok {
+			valid := make([]string, 0, len(statusCounts))
+			for n := range statusCounts {
+				valid = append(valid, n)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf("invalid --fail-on status %q (valid: %s)", name, strings.Join(valid, ", "))
+		}
+		statuses = append(statuses, name)
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("--fail-on needs at least one status")
+	}
+	return statuses, nil
+}
+
+// policyErrorCount counts result's policy violations with severity "error".
+func policyErrorCount(result *types.StatusResult) int {
+	n := 0
+	for _, v := range result.PolicyViolations {
+		if v.Severity == "error" {
+			n++
+		}
+	}
+	return n
+}
+
+// StatusExitCode returns the status command's exit code for result:
+// 0=PASS, 1=FAIL, 2=WARN. By default it follows result.Summary.Result. strict turns
+// WARN into a failure. A non-empty failOn (from ParseFailOn) replaces the
+// result's own judgement: exit 1 when any listed status has a count, otherwise
+// 2 for any other discrepancy and 0 for none. The result itself is unchanged.
+func StatusExitCode(result *types.StatusResult, strict bool, failOn []string) int {
+	if len(failOn) > 0 {
+		for _, name := range failOn {
+			if statusCounts[name](result) > 0 {
+				return 1
+			}
+		}
+		if result.Summary.Result == "PASS" {
+			return 0
+		}
+		return 2
+	}
+
+	switch result.Summary.Result {
+	case "PASS":
+		return 0
+	case "WARN":
+		if strict {
+			return 1
+		}
+		return 2
+	default: // FAIL
+		return 1
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestStatusExitCode(t *testing.T) {
+	addedOnly := types.StatusSummary{Added: 1, Result: "WARN"}
+	modified := types.StatusSummary{Modified: 2, Result: "FAIL"}
+	clean := types.StatusSummary{Verified: 3, Result: "PASS"}
+
+	blocking := []types.PolicyViolation{
+		{VendorName: "lib", Type: "drift", Severity: "error"},
+		{VendorName: "lib", Type: "stale", Severity: "warning"},
+	}
+	warningOnly := blocking[1:]
+
+	tests := []struct {
+		name       string
+		summary    types.StatusSummary
+		violations []types.PolicyViolation
+		strict     bool
+		failOn     []string
+		want       int
+	}{
+		{name: "added only warns by default", summary: addedOnly, want: 2},
+		{name: "strict fails an added-only result", summary: addedOnly, strict: true, want: 1},
+		{name: "strict keeps pass", summary: clean, strict: true, want: 0},
+		{name: "modified fails by default", summary: modified, want: 1},
+		{name: "fail-on added fails an added-only result", summary: addedOnly, failOn: []string{"added"}, want: 1},
+		{name: "fail-on excluding modified downgrades to warn", summary: modified, failOn: []string{"added", "orphaned"}, want: 2},
+		{name: "fail-on on a clean result passes", summary: clean, failOn: []string{"modified"}, want: 0},
+		{name: "fail-on orphaned", summary: types.StatusSummary{OrphanedLock: 1, Result: "WARN"}, failOn: []string{"orphaned"}, want: 1},
+		{name: "fail-on outdated", summary: types.StatusSummary{Stale: 1, Result: "FAIL"}, failOn: []string{"outdated"}, want: 1},
+		{name: "fail-on policy fails a blocking violation", summary: modified, violations: blocking, failOn: []string{"policy"}, want: 1},
+		{name: "fail-on policy ignores warning violations", summary: modified, violations: warningOnly, failOn: []string{"policy"}, want: 2},
+		{name: "fail-on policy without violations warns", summary: addedOnly, failOn: []string{"policy"}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &types.StatusResult{Summary: tt.summary, PolicyViolations: tt.violations}
+			if got := StatusExitCode(result, tt.strict, tt.failOn); got != tt.want {
+				t.Errorf("StatusExitCode() = %d, want %d", got, tt.want)
+			}
+			if result.Summary != tt.summary {
+				t.Errorf("StatusExitCode() changed the summary: %+v", result.Summary)
+			}
+		})
+	}
+}
+
+func TestParseFailOn(t *testing.T) {
+	got, err := ParseFailOn("added, Stale,orphaned")
+	if err != nil {
+		t.Fatalf("ParseFailOn() error = %v", err)
+	}
+	if strings.Join(got, ",") != "added,stale,orphaned" {
+		t.Errorf("ParseFailOn() = %v, want [added stale orphaned]", got)
+	}
+
+	if _, err := ParseFailOn("added,bogus"); err == nil || !strings.Contains(err.Error(), "bogus") {
+		t.Errorf("unknown status should be rejected by name, got %v", err)
+	}
+	if _, err := ParseFailOn(" , "); err == nil {
+		t.Error("an empty list should be rejected")
+	}
+}
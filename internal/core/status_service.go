@@ -2,16 +2,24 @@ package core
 
 import (
 	"context"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
 
 // StatusOptions configures the status command behavior.
 type StatusOptions struct {
-	Offline            bool   // Skip remote checks (only lock-vs-disk)
-	RemoteOnly         bool   // Skip disk checks (only lock-vs-upstream)
-	StrictOnly         bool   // Only check vendors with enforcement=strict (Spec 075)
-	ComplianceOverride string // Override all vendors to this enforcement level (Spec 075)
+	Offline            bool     // Skip remote checks (only lock-vs-disk)
+	RemoteOnly         bool     // Skip disk checks (only lock-vs-upstream)
+	StrictOnly         bool     // Only check vendors with enforcement=strict (Spec 075)
+	ComplianceOverride string   // Override all vendors to this enforcement level (Spec 075)
+	PositionsOnly      bool     // Offline checks cover position snippets only
+	FilesOnly          bool     // Offline checks cover whole files only
+	ExcludeVendors     []string // Drop vendors matching these names/globs from the report (--exclude-vendor)
+	GroupByVendor      bool     // Attach a per-vendor verify rollup to StatusResult.ByVendor (--group-by vendor)
+	// Since drops vendor refs whose upstream tip is older than Since from the
+	// report (--since; OutdatedOptions.Since). Ignored with Offline.
+	Since time.Duration
 }
 
 // StatusServiceInterface defines the contract for the unified status command.
@@ -72,6 +80,9 @@ func (s *StatusService) Status(ctx context.Context, opts StatusOptions) (*types.
 
 	for i := range lock.Vendors {
 		entry := &lock.Vendors[i]
+		if MatchVendorPattern(entry.Name, opts.ExcludeVendors) {
+			continue
+		}
 		key := entry.Name + "@" + entry.Ref
 		vendorMap[key] = &types.VendorStatusDetail{
 			Name:        entry.Name,
@@ -87,7 +98,10 @@ func (s *StatusService) Status(ctx context.Context, opts StatusOptions) (*types.
 
 	// Phase 1: Offline checks (verify)
 	if !opts.RemoteOnly {
-		verifyResult, verifyErr := s.verifySvc.Verify(ctx)
+		verifyResult, verifyErr := s.verifySvc.VerifyWithOptions(ctx, VerifyOptions{
+			PositionsOnly: opts.PositionsOnly,
+			FilesOnly:     opts.FilesOnly,
+		})
 		if verifyErr != nil {
 			return nil, verifyErr
 		}
@@ -117,6 +131,10 @@ func (s *StatusService) Status(ctx context.Context, opts StatusOptions) (*types.
 				case "deleted":
 					v.FilesDeleted++
 					v.DeletedPaths = append(v.DeletedPaths, f.Path)
+				case "truncated":
+					v.FilesTruncated++
+					v.TruncatedPaths = append(v.TruncatedPaths, f.Path)
+					v.DriftDetails = append(v.DriftDetails, buildDriftDetail(f, false))
 				case "accepted":
 					v.FilesAccepted++
 					v.AcceptedPaths = append(v.AcceptedPaths, f.Path)
@@ -129,16 +147,57 @@ func (s *StatusService) Status(ctx context.Context, opts StatusOptions) (*types.
 			}
 		}
 
+		for _, f := range verifyResult.Files {
+			if f.Vendor != nil && MatchVendorPattern(*f.Vendor, opts.ExcludeVendors) {
+				continue
+			}
+			result.Files = append(result.Files, f)
+		}
 		verifySummary = &verifyResult.Summary
+
+		if opts.GroupByVendor {
+			for _, row := range GroupVerifyByVendor(verifyResult) {
+				if MatchVendorPattern(row.Vendor, opts.ExcludeVendors) {
+					continue
+				}
+				result.ByVendor = append(result.ByVendor, row)
+			}
+		}
 	}
 
 	// Phase 2: Remote checks (outdated)
 	if !opts.Offline {
-		outdatedResult, outdatedErr := s.outdatedSvc.Outdated(ctx, OutdatedOptions{})
+		outdatedResult, outdatedErr := s.outdatedSvc.Outdated(ctx, OutdatedOptions{Since: opts.Since})
 		if outdatedErr != nil {
 			return nil, outdatedErr
 		}
 
+		// Refs that haven't moved upstream within --since leave the report
+		if len(outdatedResult.Filtered) > 0 {
+			filtered := make(map[string]bool, len(outdatedResult.Filtered))
+			for _, key := range outdatedResult.Filtered {
+				filtered[key] = true
+				delete(vendorMap, key)
+			}
+			kept := vendorOrder[:0]
+			for _, key := range vendorOrder {
+				if !filtered[key] {
+					kept = append(kept, key)
+				}
+			}
+			vendorOrder = kept
+
+			// The verify rows and coherence counts follow the vendor rows, so
+			// --format github and the summary don't report filtered vendors
+			keptNames := make(map[string]bool, len(vendorOrder))
+			for _, key := range vendorOrder {
+				keptNames[vendorMap[key].Name] = true
+			}
+			if verifySummary != nil {
+				verifySummary = dropFilteredVendorFiles(result, keptNames)
+			}
+		}
+
 		for _, dep := range outdatedResult.Dependencies {
 			// Match by name+ref
 			for _, key := range vendorOrder {
@@ -266,6 +325,37 @@ func (s *StatusService) Status(ctx context.Context, opts StatusOptions) (*types.
 	return result, nil
 }
 
+// dropFilteredVendorFiles removes result.Files and result.ByVendor entries of
+// vendors not in keptNames, keeping unattributed ones, and returns a
+// VerifySummary whose Stale/Orphaned counts cover only the remaining files.
+func dropFilteredVendorFiles(result *types.StatusResult, keptNames map[string]bool) *types.VerifySummary {
+	summary := &types.VerifySummary{}
+	files := result.Files[:0]
+	for _, f := range result.Files {
+		if f.Vendor != nil && !keptNames[*f.Vendor] {
+			continue
+		}
+		files = append(files, f)
+		switch f.Status {
+		case "stale":
+			summary.Stale++
+		case "orphaned":
+			summary.Orphaned++
+		}
+	}
+	result.Files = files
+
+	rows := result.ByVendor[:0]
+	for _, row := range result.ByVendor {
+		if row.Vendor != types.UnattributedVendor && !keptNames[row.Vendor] {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	result.ByVendor = rows
+	return summary
+}
+
 // computeStatusSummary aggregates per-vendor details into a StatusSummary.
 // verifySummary is non-nil when offline checks ran; its Stale/Orphaned counts
 // are propagated to StatusSummary.StaleConfigs/OrphanedLock for coherence reporting (I2/VFY-001).
@@ -275,11 +365,12 @@ func computeStatusSummary(vendors []types.VendorStatusDetail, opts StatusOptions
 	}
 
 	for _, v := range vendors {
-		s.TotalFiles += v.FilesVerified + v.FilesModified + v.FilesAdded + v.FilesDeleted + v.FilesAccepted
+		s.TotalFiles += v.FilesVerified + v.FilesModified + v.FilesAdded + v.FilesDeleted + v.FilesTruncated + v.FilesAccepted
 		s.Verified += v.FilesVerified
 		s.Modified += v.FilesModified
 		s.Added += v.FilesAdded
 		s.Deleted += v.FilesDeleted
+		s.Truncated += v.FilesTruncated
 		s.Accepted += v.FilesAccepted
 		if v.UpstreamStale != nil && *v.UpstreamStale {
 			s.Stale++
@@ -296,7 +387,7 @@ func computeStatusSummary(vendors []types.VendorStatusDetail, opts StatusOptions
 	}
 
 	// Determine result code
-	hasFail := s.Modified > 0 || s.Deleted > 0
+	hasFail := s.Modified > 0 || s.Deleted > 0 || s.Truncated > 0
 	if !opts.RemoteOnly {
 		// Disk checks ran — modified/deleted = FAIL
 	}
@@ -4,20 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
 
 // statusStubVerify returns a pre-configured VerifyResult.
 type statusStubVerify struct {
-	result *types.VerifyResult
-	err    error
+	result   *types.VerifyResult
+	err      error
+	lastOpts VerifyOptions
 }
 
 func (s *statusStubVerify) Verify(_ context.Context) (*types.VerifyResult, error) {
 	return s.result, s.err
 }
 
+func (s *statusStubVerify) VerifyWithOptions(_ context.Context, opts VerifyOptions) (*types.VerifyResult, error) {
+	s.lastOpts = opts
+	return s.result, s.err
+}
+
 // statusStubOutdated returns a pre-configured OutdatedResult.
 type statusStubOutdated struct {
 	result *types.OutdatedResult
@@ -244,6 +251,66 @@ func TestStatusService_OfflineSkipsRemote(t *testing.T) {
 	}
 }
 
+func TestStatusService_GroupByVendor(t *testing.T) {
+	vendorA, vendorB := "lib-a", "lib-b"
+	verify := &types.VerifyResult{
+		Summary: types.VerifySummary{TotalFiles: 4, Verified: 1, Modified: 1, Added: 1, Orphaned: 1, Result: "FAIL"},
+		Files: []types.FileStatus{
+			{Path: "a/x.go", Vendor: &vendorA, Status: "verified", Type: "file"},
+			{Path: "a/y.go", Vendor: &vendorA, Status: "modified", Type: "file"},
+			{Path: "b/old.go", Vendor: &vendorB, Status: "orphaned", Type: "coherence"},
+			{Path: "a/new.go", Status: "added", Type: "file"},
+		},
+	}
+	newSvc := func() *StatusService {
+		return NewStatusService(&statusStubVerify{result: verify}, &statusStubOutdated{err: errForTest}, nil,
+			&statusStubLockStore{lock: types.VendorLock{Vendors: []types.LockDetails{
+				{Name: "lib-a", Ref: "main", CommitHash: "abc"},
+				{Name: "lib-b", Ref: "main", CommitHash: "def"},
+			}}})
+	}
+
+	result, err := newSvc().Status(context.Background(), StatusOptions{Offline: true})
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if result.ByVendor != nil {
+		t.Errorf("ByVendor = %+v, want nil without GroupByVendor", result.ByVendor)
+	}
+
+	result, err = newSvc().Status(context.Background(), StatusOptions{Offline: true, GroupByVendor: true})
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if len(result.ByVendor) != 3 {
+		t.Fatalf("ByVendor = %+v, want rows for lib-a, lib-b and unattributed", result.ByVendor)
+	}
+	var total int
+	for _, row := range result.ByVendor {
+		total += row.TotalFiles
+	}
+	if total != verify.Summary.TotalFiles {
+		t.Errorf("rows sum to %d files, want %d", total, verify.Summary.TotalFiles)
+	}
+	if row := result.ByVendor[0]; row.Vendor != types.UnattributedVendor || row.Added != 1 {
+		t.Errorf("ByVendor[0] = %+v, want unattributed with 1 added", row)
+	}
+	if row := result.ByVendor[2]; row.Vendor != "lib-b" || row.Orphaned != 1 {
+		t.Errorf("ByVendor[2] = %+v, want lib-b with 1 orphaned", row)
+	}
+
+	// Excluded vendors are dropped from the rollup like the rest of the report
+	result, err = newSvc().Status(context.Background(), StatusOptions{Offline: true, GroupByVendor: true, ExcludeVendors: []string{"lib-b"}})
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	for _, row := range result.ByVendor {
+		if row.Vendor == "lib-b" {
+			t.Errorf("excluded vendor lib-b present in ByVendor: %+v", result.ByVendor)
+		}
+	}
+}
+
 func TestStatusService_RemoteOnlySkipsDisk(t *testing.T) {
 	svc := NewStatusService(
 		&statusStubVerify{
@@ -444,6 +511,64 @@ func TestStatusService_AcceptedOnly_WARN(t *testing.T) {
 // TestStatusService_CoherenceIssues_Propagated verifies that coherence
 // counts (stale configs, orphaned lock entries) from verify are propagated
 // to StatusSummary (I2/VFY-001).
+// TestStatusService_SinceDropsFilteredVendorFiles verifies that a vendor
+// dropped by --since also leaves the verify rows, the rollup and the
+// coherence counts.
+func TestStatusService_SinceDropsFilteredVendorFiles(t *testing.T) {
+	vendorA, vendorB := "lib-a", "lib-b"
+	verify := &types.VerifyResult{
+		Summary: types.VerifySummary{TotalFiles: 4, Verified: 1, Modified: 1, Orphaned: 1, Added: 1, Result: "FAIL"},
+		Files: []types.FileStatus{
+			{Path: "a/x.go", Vendor: &vendorA, Status: "verified", Type: "file"},
+			{Path: "b/y.go", Vendor: &vendorB, Status: "modified", Type: "file"},
+			{Path: "b/old.go", Vendor: &vendorB, Status: "orphaned", Type: "coherence"},
+			{Path: "a/new.go", Status: "added", Type: "file"},
+		},
+	}
+	svc := NewStatusService(
+		&statusStubVerify{result: verify},
+		&statusStubOutdated{result: &types.OutdatedResult{
+			Dependencies: []types.UpdateCheckResult{{VendorName: "lib-a", Ref: "main", CurrentHash: "abc", LatestHash: "abc", UpToDate: true}},
+			TotalChecked: 1,
+			UpToDate:     1,
+			Filtered:     []string{"lib-b@main"},
+		}},
+		nil,
+		&statusStubLockStore{lock: types.VendorLock{Vendors: []types.LockDetails{
+			{Name: "lib-a", Ref: "main", CommitHash: "abc"},
+			{Name: "lib-b", Ref: "main", CommitHash: "def"},
+		}}},
+	)
+
+	result, err := svc.Status(context.Background(), StatusOptions{GroupByVendor: true, Since: time.Hour})
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+
+	if len(result.Vendors) != 1 || result.Vendors[0].Name != "lib-a" {
+		t.Fatalf("Vendors = %+v, want only lib-a", result.Vendors)
+	}
+	for _, f := range result.Files {
+		if f.Vendor != nil && *f.Vendor == "lib-b" {
+			t.Errorf("filtered vendor lib-b present in Files: %+v", f)
+		}
+	}
+	if len(result.Files) != 2 {
+		t.Errorf("Files = %+v, want lib-a's file and the unattributed one", result.Files)
+	}
+	for _, row := range result.ByVendor {
+		if row.Vendor == "lib-b" {
+			t.Errorf("filtered vendor lib-b present in ByVendor: %+v", result.ByVendor)
+		}
+	}
+	if result.Summary.OrphanedLock != 0 {
+		t.Errorf("OrphanedLock = %d, want 0 once lib-b is filtered", result.Summary.OrphanedLock)
+	}
+	if result.Summary.Modified != 0 || result.Summary.Result != "PASS" {
+		t.Errorf("Summary = %+v, want lib-b's modification left out", result.Summary)
+	}
+}
+
 func TestStatusService_CoherenceIssues_Propagated(t *testing.T) {
 	vendor1 := "mylib"
 	svc := NewStatusService(
@@ -951,3 +1076,88 @@ var errForTest = &testSentinelError{msg: "should not be called"}
 type testSentinelError struct{ msg string }
 
 func (e *testSentinelError) Error() string { return e.msg }
+
+func TestStatusService_PassesVerifyScope(t *testing.T) {
+	verify := &statusStubVerify{
+		result: &types.VerifyResult{Summary: types.VerifySummary{Result: "PASS"}},
+	}
+	svc := NewStatusService(verify, &statusStubOutdated{}, nil, &statusStubLockStore{})
+
+	_, err := svc.Status(context.Background(), StatusOptions{Offline: true, PositionsOnly: true})
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !verify.lastOpts.PositionsOnly || verify.lastOpts.FilesOnly {
+		t.Errorf("VerifyWithOptions opts = %+v, want PositionsOnly only", verify.lastOpts)
+	}
+}
+
+func TestStatusService_ExcludeVendors(t *testing.T) {
+	vendorA, vendorB := "lib-a", "lib-b"
+	verify := &statusStubVerify{
+		result: &types.VerifyResult{
+			Summary: types.VerifySummary{Result: "FAIL"},
+			Files: []types.FileStatus{
+				{Path: "vendor/a.go", Vendor: &vendorA, Status: "verified"},
+				{Path: "vendor/b.go", Vendor: &vendorB, Status: "modified"},
+			},
+		},
+	}
+	lock := types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "lib-a", Ref: "main", CommitHash: "aaa"},
+		{Name: "lib-b", Ref: "main", CommitHash: "bbb"},
+	}}
+	svc := NewStatusService(verify, &statusStubOutdated{}, nil, &statusStubLockStore{lock: lock})
+
+	result, err := svc.Status(context.Background(), StatusOptions{Offline: true, ExcludeVendors: []string{"*-b"}})
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(result.Vendors) != 1 || result.Vendors[0].Name != "lib-a" {
+		t.Fatalf("Vendors = %+v, want only lib-a", result.Vendors)
+	}
+	if result.Summary.Modified != 0 || result.Summary.Result != "PASS" {
+		t.Errorf("excluded vendor drift leaked into summary: %+v", result.Summary)
+	}
+}
+
+func TestStatusService_TruncatedFile(t *testing.T) {
+	vendor1 := "mylib"
+	svc := NewStatusService(
+		&statusStubVerify{
+			result: &types.VerifyResult{
+				Summary: types.VerifySummary{TotalFiles: 2, Verified: 1, Truncated: 1, Result: "FAIL"},
+				Files: []types.FileStatus{
+					{Path: "a.go", Vendor: &vendor1, Status: "verified", Type: "file"},
+					{Path: "b.go", Vendor: &vendor1, Status: "truncated", Type: "file", Hint: truncatedHint},
+				},
+			},
+		},
+		&statusStubOutdated{result: &types.OutdatedResult{}},
+		nil,
+		&statusStubLockStore{
+			lock: types.VendorLock{
+				Vendors: []types.LockDetails{{Name: "mylib", Ref: "main", CommitHash: "abc123"}},
+			},
+		},
+	)
+
+	result, err := svc.Status(context.Background(), StatusOptions{Offline: true})
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+
+	v := result.Vendors[0]
+	if v.FilesTruncated != 1 || len(v.TruncatedPaths) != 1 || v.TruncatedPaths[0] != "b.go" {
+		t.Errorf("vendor = %+v, want b.go as the single truncated path", v)
+	}
+	if v.FilesModified != 0 {
+		t.Errorf("FilesModified = %d, want 0 (truncated is not a generic modification)", v.FilesModified)
+	}
+	if result.Summary.Truncated != 1 || result.Summary.TotalFiles != 2 {
+		t.Errorf("summary = %+v, want 1 truncated of 2 files", result.Summary)
+	}
+	if result.Summary.Result != "FAIL" {
+		t.Errorf("Result = %q, want FAIL", result.Summary.Result)
+	}
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// resolveSymbol parses lines as Go source and returns the line range of the
+// declaration named by symbol: "Name" for a func or type, "Type.Method" for a
+// method (pointer or value receiver). The range starts at the declaration's
+// doc comment when it has one. A type in a grouped "type ( ... )" block
+// resolves to its own spec, not the whole block.
+func resolveSymbol(lines []string, symbol, filePath string) (*types.PositionSpec, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, strings.Join(lines, "\n"), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s for symbol %s: %w", filePath, symbol, err)
+	}
+
+	recv, name := "", symbol
+	if i := strings.Index(symbol, "."); i >= 0 {
+		recv, name = symbol[:i], symbol[i+1:]
+	}
+
+	rangeOf := func(doc *ast.CommentGroup, node ast.Node) *types.PositionSpec {
+		start := node.Pos()
+		if doc != nil {
+			start = doc.Pos()
+		}
+		return &types.PositionSpec{
+			StartLine: fset.Position(start).Line,
+			EndLine:   fset.Position(node.End()).Line,
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Name.Name == name && receiverTypeName(d) == recv {
+				return rangeOf(d.Doc, d), nil
+			}
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE || recv != "" {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != name {
+					continue
+				}
+				if d.Lparen.IsValid() {
+					return rangeOf(ts.Doc, ts), nil
+				}
+				return rangeOf(d.Doc, d), nil
+			}
+		}
+	}
+
+	kind := "func or type"
+	if recv != "" {
+		kind = "method"
+	}
+	return nil, fmt.Errorf("%s %s not found in %s", kind, symbol, filePath)
+}
+
+// receiverTypeName returns the receiver's base type name of a method
+// ("T" for both T and *T, generic parameters stripped), or "" for a func.
+func receiverTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		expr = t.X
+	case *ast.IndexListExpr:
+		expr = t.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
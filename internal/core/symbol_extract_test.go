@@ -0,0 +1,111 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+const symbolSource = `package util
+
+import "strings"
+
+// Upper upper-cases s.
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
+
+type (
+	// Set is a string set.
+	Set map[string]bool
+	List []string
+)
+
+// Add inserts v.
+func (s Set) Add(v string) {
+	s[v] = true
+}
+
+func (l *List) Add(v string) {
+	*l = append(*l, v)
+}
+`
+
+func writeSymbolSource(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "util.go")
+	if err := os.WriteFile(path, []byte(symbolSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExtractPosition_Symbol_Function(t *testing.T) {
+	srcPath := writeSymbolSource(t)
+	_, pos, err := types.ParsePathPosition(srcPath + "#Upper")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	extracted, _, err := ExtractPosition(srcPath, pos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "// Upper upper-cases s.\nfunc Upper(s string) string {\n\treturn strings.ToUpper(s)\n}"
+	if extracted != want {
+		t.Errorf("extracted = %q, want %q", extracted, want)
+	}
+}
+
+func TestExtractPosition_Symbol_MethodAndType(t *testing.T) {
+	srcPath := writeSymbolSource(t)
+	tests := []struct {
+		symbol string
+		want   string
+	}{
+		{"Set.Add", "// Add inserts v.\nfunc (s Set) Add(v string) {\n\ts[v] = true\n}"},
+		{"List.Add", "func (l *List) Add(v string) {\n\t*l = append(*l, v)\n}"},
+		{"Set", "\t// Set is a string set.\n\tSet map[string]bool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			extracted, _, err := ExtractPosition(srcPath, &types.PositionSpec{Symbol: tt.symbol})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if extracted != tt.want {
+				t.Errorf("extracted = %q, want %q", extracted, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractPosition_Symbol_NotFound(t *testing.T) {
+	srcPath := writeSymbolSource(t)
+	for symbol, want := range map[string]string{
+		"Lower":     "func or type Lower not found",
+		"Upper.Add": "method Upper.Add not found",
+	} {
+		_, _, err := ExtractPosition(srcPath, &types.PositionSpec{Symbol: symbol})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", symbol, err, want)
+		}
+	}
+}
+
+func TestCopyWithPosition_Symbol_RecordsResolvedLines(t *testing.T) {
+	srcPath := writeSymbolSource(t)
+	destPath := filepath.Join(t.TempDir(), "add.go")
+	svc := NewFileCopyService(NewOSFileSystem())
+
+	stats, err := svc.copyWithPosition(srcPath, destPath, &types.PositionSpec{Symbol: "Set.Add"}, nil, "v", "main", "util.go", "util.go#Set.Add", "add.go")
+	if err != nil {
+		t.Fatalf("copyWithPosition: %v", err)
+	}
+	if got := stats.Positions[0].SourceLines; got != "L16-L19" {
+		t.Errorf("SourceLines = %q, want L16-L19", got)
+	}
+}
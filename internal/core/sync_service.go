@@ -2,27 +2,54 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
 
 // SyncOptions configures sync operation behavior
 type SyncOptions struct {
-	DryRun       bool
-	VendorName   string // Empty = all vendors
-	GroupName    string // Empty = all groups, filters vendors by group
-	Force        bool
-	NoCache      bool                  // Disable incremental sync cache
-	Parallel     types.ParallelOptions // Parallel processing options
-	Commit       bool                  // Auto-commit after sync with vendor trailers
-	InternalOnly bool                  // Only sync internal vendors (Spec 070)
-	Reverse      bool                  // Propagate dest changes back to source (Spec 070)
-	Local        bool                  // Allow file:// and local path vendor URLs
+	DryRun         bool
+	VendorName     string // Exact name or filepath.Match glob (e.g. "aws-*"); empty = all vendors
+	GroupName      string // Empty = all groups, filters vendors by group
+	Force          bool
+	NoCache        bool                  // Disable incremental sync cache
+	Parallel       types.ParallelOptions // Parallel processing options
+	Commit         bool                  // Auto-commit after sync with vendor trailers
+	InternalOnly   bool                  // Only sync internal vendors (Spec 070)
+	Reverse        bool                  // Propagate dest changes back to source (Spec 070)
+	Local          bool                  // Allow file:// and local path vendor URLs
+	RetryOnStale   bool                  // Update a stale locked vendor and retry once (--retry-on-stale)
+	Snapshot       bool                  // Archive each fetched tree to .git-vendor/.snapshots/ (--snapshot)
+	Offline        bool                  // Restore locked commits from snapshots without any remote (--offline)
+	LicenseDir     string                // Resolved license copy directory (empty = .git-vendor/licenses)
+	LicenseFiles   []string              // Repo-root license/notice filenames to copy (empty = LicenseCaptureFiles)
+	RepoCache      *RepoCache            // Shares clones across vendors with the same URL (nil = clone per vendor)
+	ExcludeVendors []string              // Skip vendors matching these names/globs after positive selection (--exclude-vendor)
+	OnlyPositions  bool                  // Re-place position mappings only, from the source cache when possible (--only-positions)
+	FetchAttempts  int                   // Fetch attempts per URL on transient network errors (0 = DefaultFetchAttempts; --retries N sets N+1)
+	Report         *SyncReport           // Collects each vendor's outcome when non-nil (pull --json)
+	AllowHooks     bool                  // Run each vendor's post_sync command after it syncs (--allow-hooks)
+	Hardlink       bool                  // Hard-link each vendor's identical destination files to one copy (--hardlink)
+	CheckLicense   bool                  // Passed to the RetryOnStale update (UpdateOptions.CheckLicense)
+	StrictLicense  bool                  // Passed to the RetryOnStale update (UpdateOptions.StrictLicense)
+	// RelocatePositions maps ref -> previously locked positions; drifted
+	// line-range mappings are searched for upstream by hash (update --relocate)
+	RelocatePositions map[string][]types.PositionLock
+	// LockedAliases maps "vendor@ref" -> the ref_alias its lock entry was
+	// resolved from. Sync fills it from the lock so a locked commit that can't
+	// be fetched directly is looked for on the aliased ref, not the vendor.yml one.
+	LockedAliases map[string]string
 }
 
 // RefMetadata holds per-ref metadata collected during sync
@@ -31,6 +58,65 @@ type RefMetadata struct {
 	VersionTag string           // Git tag pointing to commit, if any
 	Positions  []positionRecord // Position extractions performed during sync
 	SourceURL  string           // Which mirror URL succeeded (empty = primary URL)
+	// LicenseFiles lists the license and notice copies written for the ref
+	LicenseFiles []string
+	// Relocations lists position mappings moved by SyncOptions.RelocatePositions
+	Relocations []positionRelocation
+	// FileHashes are the SHA-256s of whole files written for the ref, computed
+	// while copying (CopyStats.FileHashes); the lock uses them instead of
+	// re-reading each destination. An empty hash marks a file that was
+	// rewritten afterwards (a later ref or a hook) and must be read again.
+	FileHashes map[string]string
+}
+
+// Vendor sync statuses reported in VendorSyncResult.Status.
+const (
+	SyncStatusSynced  = "synced"
+	SyncStatusSkipped = "skipped" // Every ref matched the incremental cache
+	SyncStatusFailed  = "failed"
+)
+
+// VendorSyncResult is one vendor's outcome in a SyncReport.
+type VendorSyncResult struct {
+	Name         string   `json:"name"`
+	Status       string   `json:"status"` // SyncStatusSynced, SyncStatusSkipped, or SyncStatusFailed
+	FilesCopied  int      `json:"files_copied"`
+	BytesCopied  int64    `json:"bytes_copied"`
+	FilesRemoved int      `json:"files_removed,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// SyncReport collects per-vendor results during Sync, in sync order. A sync
+// that fails stops at the failed vendor, which is the last entry.
+type SyncReport struct {
+	Vendors []VendorSyncResult
+}
+
+// record appends the outcome of syncing vendor v with stats and err. A vendor
+// is skipped only when every one of its specs hit the cache.
+func (r *SyncReport) record(v *types.VendorSpec, stats CopyStats, err error) {
+	if r == nil {
+		return
+	}
+	result := VendorSyncResult{
+		Name:         v.Name,
+		Status:       SyncStatusSynced,
+		FilesCopied:  stats.FileCount,
+		BytesCopied:  stats.ByteCount,
+		FilesRemoved: len(stats.Removed),
+		Warnings:     stats.Warnings,
+	}
+	switch {
+	case err != nil:
+		result.Status = SyncStatusFailed
+		result.Error = err.Error()
+	case len(v.Specs) > 0 && stats.CacheHits == len(v.Specs):
+		// Cached syncs count their mappings as files, but nothing was copied
+		result.Status = SyncStatusSkipped
+		result.FilesCopied = 0
+	}
+	r.Vendors = append(r.Vendors, result)
 }
 
 // SyncServiceInterface defines the contract for vendor synchronization.
@@ -67,6 +153,7 @@ type SyncService struct {
 	ui           UICallback
 	rootDir      string
 	internalSync InternalSyncServiceInterface // Spec 070
+	logger       *loggerSlot                  // Set by NewVendorSyncer; nil = no logging
 }
 
 // NewSyncService creates a new SyncService.
@@ -115,8 +202,31 @@ func (s *SyncService) Sync(ctx context.Context, opts SyncOptions) error {
 
 	// Build lock map for quick lookups
 	lockMap := s.buildLockMap(lock)
+	for _, l := range lock.Vendors {
+		if l.RefAlias != "" {
+			if opts.LockedAliases == nil {
+				opts.LockedAliases = make(map[string]string)
+			}
+			opts.LockedAliases[l.Name+"@"+l.Ref] = l.RefAlias
+		}
+	}
+
+	// A stale-lock retry re-runs Sync; report only the final attempt
+	if opts.Report != nil {
+		opts.Report.Vendors = nil
+	}
+
+	// Honor license_dir from vendor.yml for every license copied during this sync
+	opts.LicenseDir = ResolveLicenseDir(s.rootDir, config)
+	opts.LicenseFiles = ResolveLicenseFiles(config)
 
-	// Validate vendor exists if filtering by name
+	// Fetch each repository once and reuse it for every vendor that references it
+	if opts.RepoCache == nil {
+		opts.RepoCache = NewRepoCache()
+		defer opts.RepoCache.Close()
+	}
+
+	// Validate the vendor name or glob selects something
 	if opts.VendorName != "" {
 		if err := s.validateVendorExists(config, opts.VendorName); err != nil {
 			return err // Already a structured error type
@@ -151,12 +261,18 @@ func (s *SyncService) Sync(ctx context.Context, opts SyncOptions) error {
 		return s.syncDryRun(vendorsToSync, lockMap, lock)
 	}
 
-	// Use parallel or sequential sync based on options
-	if opts.Parallel.Enabled {
-		return s.syncParallel(ctx, vendorsToSync, lockMap, opts)
+	// Use parallel or sequential sync based on options; recursive vendors grow
+	// the plan as they land, which the worker pool can't
+	copied := make(map[string]bool)
+	if opts.Parallel.Enabled && !hasRecursiveVendor(vendorsToSync) {
+		err = s.syncParallel(ctx, vendorsToSync, lockMap, opts, copied)
+	} else {
+		err = s.syncSequential(ctx, vendorsToSync, lockMap, opts, copied)
 	}
-
-	return s.syncSequential(ctx, vendorsToSync, lockMap, opts)
+	if err != nil {
+		return err
+	}
+	return s.recordLastSynced(lock, copied)
 }
 
 // syncDryRun performs dry-run preview (always sequential).
@@ -202,7 +318,8 @@ func buildFileHashMap(lock types.VendorLock) map[string]map[string]string {
 // syncSequential performs sequential sync (original implementation).
 // ctx controls cancellation — checked at each vendor boundary.
 // Internal vendors sync first (no network), then external vendors.
-func (s *SyncService) syncSequential(ctx context.Context, vendors []types.VendorSpec, lockMap map[string]map[string]string, opts SyncOptions) error {
+// Vendors whose files were written (not all-cache hits) are added to copied.
+func (s *SyncService) syncSequential(ctx context.Context, vendors []types.VendorSpec, lockMap map[string]map[string]string, opts SyncOptions, copied map[string]bool) error {
 	// Start progress tracking
 	progress := s.ui.StartProgress(len(vendors), "Syncing vendors")
 	defer progress.Complete()
@@ -222,16 +339,21 @@ func (s *SyncService) syncSequential(ctx context.Context, vendors []types.Vendor
 			return fmt.Errorf("internal sync service not configured for vendor %s", v.Name)
 		}
 		_, stats, err := s.internalSync.SyncInternalVendor(&v, opts)
+		opts.Report.record(&v, stats, err)
 		if err != nil {
 			progress.Fail(err)
 			return fmt.Errorf("sync internal vendor %s: %w", v.Name, err)
 		}
 		totalStats.Add(stats)
+		copied[v.Name] = true
 		progress.Increment(fmt.Sprintf("✓ %s", v.Name))
 	}
 
-	// Phase 2: External vendors (git clone, slower)
-	for _, v := range vendors {
+	// Phase 2: External vendors (git clone, slower); vendors nested in
+	// recursive vendors join after their parent
+	expander := newRecursiveExpander(vendors)
+	for i := 0; i < len(vendors); i++ {
+		v := vendors[i]
 		if v.Source == SourceInternal {
 			continue
 		}
@@ -244,11 +366,28 @@ func (s *SyncService) syncSequential(ctx context.Context, vendors []types.Vendor
 			refs = nil
 		}
 		_, stats, err := s.SyncVendor(ctx, &v, refs, opts)
+		opts.Report.record(&v, stats, err)
 		if err != nil {
 			progress.Fail(err)
 			return fmt.Errorf("sync vendor %s: %w", v.Name, err)
 		}
 		totalStats.Add(stats)
+		if stats.CacheHits < len(v.Specs) {
+			copied[v.Name] = true
+		}
+
+		nested, warnings, err := expander.expand(&v)
+		if err != nil {
+			progress.Fail(err)
+			return fmt.Errorf("sync vendor %s: %w", v.Name, err)
+		}
+		for _, w := range warnings {
+			s.ui.ShowWarning("Recursive Vendor", w)
+		}
+		if len(nested) > 0 {
+			vendors = append(vendors, nested...)
+			progress.SetTotal(len(vendors))
+		}
 		progress.Increment(fmt.Sprintf("✓ %s", v.Name))
 	}
 
@@ -261,7 +400,8 @@ func (s *SyncService) syncSequential(ctx context.Context, vendors []types.Vendor
 // syncParallel performs parallel sync using worker pool.
 // ctx controls cancellation — passed to the parallel executor and each worker.
 // Internal vendors always sync sequentially first (no parallel — may share dest files).
-func (s *SyncService) syncParallel(ctx context.Context, vendors []types.VendorSpec, lockMap map[string]map[string]string, opts SyncOptions) error {
+// Vendors whose files were written are added to copied, as in syncSequential.
+func (s *SyncService) syncParallel(ctx context.Context, vendors []types.VendorSpec, lockMap map[string]map[string]string, opts SyncOptions, copied map[string]bool) error {
 	// Start progress tracking
 	progress := s.ui.StartProgress(len(vendors), "Syncing vendors (parallel)")
 	defer progress.Complete()
@@ -280,11 +420,13 @@ func (s *SyncService) syncParallel(ctx context.Context, vendors []types.VendorSp
 			return fmt.Errorf("internal sync service not configured for vendor %s", v.Name)
 		}
 		_, stats, err := s.internalSync.SyncInternalVendor(&v, opts)
+		opts.Report.record(&v, stats, err)
 		if err != nil {
 			progress.Fail(err)
 			return fmt.Errorf("sync internal vendor %s: %w", v.Name, err)
 		}
 		totalStats.Add(stats)
+		copied[v.Name] = true
 		progress.Increment(fmt.Sprintf("✓ %s", v.Name))
 	}
 
@@ -313,6 +455,19 @@ func (s *SyncService) syncParallel(ctx context.Context, vendors []types.VendorSp
 
 		// Execute parallel sync
 		results, err := executor.ExecuteParallelSync(ctx, externalVendors, lockMap, opts, syncFunc)
+		if opts.Report != nil {
+			// Workers finish in any order; report in plan order
+			order := make(map[string]int, len(externalVendors))
+			for i, v := range externalVendors {
+				order[v.Name] = i
+			}
+			sort.SliceStable(results, func(i, j int) bool {
+				return order[results[i].Vendor.Name] < order[results[j].Vendor.Name]
+			})
+			for i := range results {
+				opts.Report.record(&results[i].Vendor, results[i].Stats, results[i].Error)
+			}
+		}
 		if err != nil {
 			return fmt.Errorf("parallel sync: %w", err)
 		}
@@ -320,6 +475,9 @@ func (s *SyncService) syncParallel(ctx context.Context, vendors []types.VendorSp
 		// Calculate total stats from parallel results
 		for i := range results {
 			totalStats.Add(results[i].Stats)
+			if results[i].Stats.CacheHits < len(results[i].Vendor.Specs) {
+				copied[results[i].Vendor.Name] = true
+			}
 		}
 	}
 
@@ -329,6 +487,26 @@ func (s *SyncService) syncParallel(ctx context.Context, vendors []types.VendorSp
 	return nil
 }
 
+// recordLastSynced stamps LastSyncedAt on the entries of lock belonging to
+// the vendors named in copied and saves it. Updated is left alone: sync writes
+// files at the locked commits without moving them. Vendors served entirely
+// from the incremental cache aren't in copied, so they keep the last real copy.
+func (s *SyncService) recordLastSynced(lock types.VendorLock, copied map[string]bool) error {
+	if len(copied) == 0 {
+		return nil
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for i := range lock.Vendors {
+		if copied[lock.Vendors[i].Name] {
+			lock.Vendors[i].LastSyncedAt = now
+		}
+	}
+	if err := s.lockStore.Save(lock); err != nil {
+		return fmt.Errorf("save lockfile: %w", err)
+	}
+	return nil
+}
+
 // printSyncSummary prints the sync result summary including file counts and removals.
 func (s *SyncService) printSyncSummary(stats CopyStats) {
 	if stats.FileCount > 0 || len(stats.Removed) > 0 {
@@ -357,19 +535,9 @@ func (s *SyncService) buildLockMap(lock types.VendorLock) map[string]map[string]
 	return lockMap
 }
 
-// validateVendorExists checks if a vendor with the given name exists
+// validateVendorExists checks that the vendor name or glob selects a vendor (ValidateVendorFilter)
 func (s *SyncService) validateVendorExists(config types.VendorConfig, vendorName string) error {
-	found := false
-	for _, v := range config.Vendors {
-		if v.Name == vendorName {
-			found = true
-			break
-		}
-	}
-	if !found {
-		return NewVendorNotFoundError(vendorName)
-	}
-	return nil
+	return ValidateVendorFilter(config, vendorName)
 }
 
 // validateGroupExists checks if any vendor has the given group
@@ -400,7 +568,7 @@ func (s *SyncService) shouldSyncVendor(v *types.VendorSpec, opts SyncOptions) bo
 	}
 
 	// If vendor name filter is set, only sync matching vendor
-	if opts.VendorName != "" && v.Name != opts.VendorName {
+	if !MatchVendorFilter(v.Name, opts.VendorName) {
 		return false
 	}
 
@@ -418,6 +586,11 @@ func (s *SyncService) shouldSyncVendor(v *types.VendorSpec, opts SyncOptions) bo
 		}
 	}
 
+	// Exclusions apply after name/group selection
+	if MatchVendorPattern(v.Name, opts.ExcludeVendors) {
+		return false
+	}
+
 	return true
 }
 
@@ -425,7 +598,7 @@ func (s *SyncService) shouldSyncVendor(v *types.VendorSpec, opts SyncOptions) bo
 func (s *SyncService) printSyncHeader(config types.VendorConfig, vendorName string) {
 	vendorCount := 0
 	for _, v := range config.Vendors {
-		if vendorName == "" || v.Name == vendorName {
+		if MatchVendorFilter(v.Name, vendorName) {
 			vendorCount++
 		}
 	}
@@ -534,12 +707,35 @@ func (s *SyncService) classifyMapping(dest string, lockHashes map[string]string)
 // ctx controls cancellation of git operations during sync.
 // Returns a map of ref to RefMetadata and total stats for all synced refs.
 func (s *SyncService) SyncVendor(ctx context.Context, v *types.VendorSpec, lockedRefs map[string]string, opts SyncOptions) (map[string]RefMetadata, CopyStats, error) {
+	start, name := time.Now(), v.Name
+	defer func() { s.logger.Infof("%s finished in %s", name, time.Since(start).Round(time.Millisecond)) }()
+
+	// --only-positions: restrict to position mappings and, when every source
+	// is cached at its locked commit, re-place them without touching git
+	if opts.OnlyPositions {
+		positions := positionOnlyVendor(v)
+		if len(positions.Specs) == 0 {
+			fmt.Printf("  • %s (no position mappings, skipping)\n", v.Name)
+			return map[string]RefMetadata{}, CopyStats{}, nil
+		}
+		v = &positions
+		if !opts.NoCache && !opts.Force && lockedRefs != nil {
+			results, stats, ok, err := s.syncPositionsFromCache(v, lockedRefs)
+			if err != nil {
+				return nil, CopyStats{}, err
+			}
+			if ok {
+				return results, stats, nil
+			}
+		}
+	}
+
 	// Check cache for all refs first (if cache enabled)
 	canSkipClone := false
 	if !opts.NoCache && !opts.Force && lockedRefs != nil {
 		allCached := true
 		for _, spec := range v.Specs {
-			if !s.canSkipSync(v.Name, spec.Ref, lockedRefs[spec.Ref], spec.Mapping) {
+			if !s.canSkipSync(v, spec, lockedRefs[spec.Ref]) {
 				allCached = false
 				break
 			}
@@ -557,7 +753,7 @@ func (s *SyncService) SyncVendor(ctx context.Context, v *types.VendorSpec, locke
 			// For cached syncs, we don't have access to version tag
 			results[spec.Ref] = RefMetadata{CommitHash: lockedRefs[spec.Ref]}
 			// Files already exist, count them
-			stats := CopyStats{FileCount: len(spec.Mapping)}
+			stats := CopyStats{FileCount: len(spec.Mapping), CacheHits: 1}
 			totalStats.Add(stats)
 			fmt.Printf("  ✓ %s @ %s (cached: %s)\n",
 				v.Name, spec.Ref,
@@ -565,25 +761,21 @@ func (s *SyncService) SyncVendor(ctx context.Context, v *types.VendorSpec, locke
 		}
 
 		// Execute post-sync hook even for cached syncs
-		if v.Hooks != nil && v.Hooks.PostSync != "" {
-			ctx := types.HookContext{
-				VendorName:  v.Name,
-				VendorURL:   v.URL,
-				RootDir:     s.rootDir,
-				FilesCopied: totalStats.FileCount,
-			}
-			if err := s.hooks.ExecutePostSync(v, &ctx); err != nil {
-				return nil, CopyStats{}, fmt.Errorf("post-sync hook failed: %w", err)
-			}
+		hookCtx := types.HookContext{VendorName: v.Name, VendorURL: v.URL, RootDir: s.rootDir, FilesCopied: totalStats.FileCount}
+		if _, err := s.runPostSync(v, opts, &totalStats, hookCtx); err != nil {
+			return nil, CopyStats{}, err
 		}
 
 		return results, totalStats, nil
 	}
 
-	// Resolve vendor URLs: primary + mirrors, with --local gating applied to each
+	// Resolve vendor URLs: primary + mirrors, with --local gating applied to each.
+	// Offline restores never contact the URLs, so gating is skipped.
+	// A local primary URL is read in place (localRepo) instead of cloned.
 	urls := ResolveVendorURLs(v)
+	localRepo := ""
 	for i, u := range urls {
-		if IsLocalPath(u) {
+		if IsLocalPath(u) && !opts.Offline {
 			if !opts.Local {
 				return nil, CopyStats{}, fmt.Errorf("vendor %s uses a local path (%s); pass --local to allow local filesystem access", v.Name, u)
 			}
@@ -592,6 +784,9 @@ func (s *SyncService) SyncVendor(ctx context.Context, v *types.VendorSpec, locke
 				return nil, CopyStats{}, fmt.Errorf("resolve local URL for %s: %w", v.Name, err)
 			}
 			urls[i] = resolved
+			if i == 0 {
+				localRepo = filepath.FromSlash(strings.TrimPrefix(resolved, "file://"))
+			}
 		}
 	}
 
@@ -607,21 +802,66 @@ func (s *SyncService) SyncVendor(ctx context.Context, v *types.VendorSpec, locke
 		}
 	}
 
-	fmt.Printf("⠿ %s (cloning repository...)\n", v.Name)
+	switch {
+	case opts.Offline:
+		fmt.Printf("⠿ %s (restoring from snapshot...)\n", v.Name)
+	case localRepo != "":
+		fmt.Printf("⠿ %s (reading local repository...)\n", v.Name)
+	default:
+		fmt.Printf("⠿ %s (cloning repository...)\n", v.Name)
+	}
 
-	// Create temp directory for cloning
-	tempDir, err := s.fs.CreateTemp("", "git-vendor-*")
-	if err != nil {
-		return nil, CopyStats{}, err
+	// Reuse a directory already cloned for this URL during this invocation
+	var cached *repoCacheEntry
+	if opts.RepoCache != nil && !opts.Offline && localRepo == "" {
+		cached = opts.RepoCache.acquire(urls[0])
+		defer cached.mu.Unlock()
 	}
-	defer func() { _ = s.fs.RemoveAll(tempDir) }() //nolint:errcheck // cleanup in defer
 
-	// Initialize git repo and add primary remote
-	if err := s.gitClient.Init(ctx, tempDir); err != nil {
-		return nil, CopyStats{}, fmt.Errorf("failed to initialize git repository for %s: %w", v.Name, err)
+	tempDir := ""
+	if cached != nil && cached.dir != "" {
+		tempDir = cached.dir
+		// A previous vendor's mirror fallback may have repointed origin
+		if cached.remoteChanged {
+			if err := s.gitClient.SetRemoteURL(ctx, tempDir, "origin", urls[0]); err != nil {
+				return nil, CopyStats{}, fmt.Errorf("failed to reset remote for %s (%s): %w", v.Name, SanitizeURL(urls[0]), err)
+			}
+			cached.remoteChanged = false
+		}
+	} else {
+		// Create temp directory for cloning
+		dir, err := s.fs.CreateTemp("", "git-vendor-*")
+		if err != nil {
+			return nil, CopyStats{}, err
+		}
+		tempDir = dir
+		keep := false
+		defer func() {
+			if !keep {
+				_ = s.fs.RemoveAll(tempDir) //nolint:errcheck // cleanup in defer
+			}
+		}()
+
+		// Initialize git repo and add primary remote (not needed when restoring
+		// from snapshots or reading a local repository in place)
+		if !opts.Offline && localRepo == "" {
+			if err := s.gitClient.Init(ctx, tempDir); err != nil {
+				return nil, CopyStats{}, fmt.Errorf("failed to initialize git repository for %s: %w", v.Name, err)
+			}
+			if err := s.gitClient.AddRemote(ctx, tempDir, "origin", urls[0]); err != nil {
+				return nil, CopyStats{}, fmt.Errorf("failed to add remote for %s (%s): %w\n\nPlease verify the repository URL is correct and accessible", v.Name, SanitizeURL(urls[0]), err)
+			}
+		}
+
+		// Hand the initialized directory to the cache; RepoCache.Close removes it
+		if cached != nil {
+			cached.dir = tempDir
+			cached.cleanup = func() { _ = s.fs.RemoveAll(tempDir) } //nolint:errcheck // temp cleanup
+			keep = true
+		}
 	}
-	if err := s.gitClient.AddRemote(ctx, tempDir, "origin", urls[0]); err != nil {
-		return nil, CopyStats{}, fmt.Errorf("failed to add remote for %s (%s): %w\n\nPlease verify the repository URL is correct and accessible", v.Name, SanitizeURL(urls[0]), err)
+	if cached != nil && len(urls) > 1 {
+		cached.remoteChanged = true
 	}
 
 	results := make(map[string]RefMetadata)
@@ -629,10 +869,30 @@ func (s *SyncService) SyncVendor(ctx context.Context, v *types.VendorSpec, locke
 
 	// Sync each ref
 	for _, spec := range v.Specs {
-		metadata, stats, err := s.syncRef(ctx, tempDir, v, spec, lockedRefs, opts, urls)
+		var metadata RefMetadata
+		var stats CopyStats
+		var err error
+		switch {
+		case opts.Offline:
+			metadata, stats, err = s.syncRefFromSnapshot(tempDir, v, spec, lockedRefs, opts)
+		case localRepo != "":
+			metadata, stats, err = s.syncRefFromLocal(ctx, tempDir, localRepo, v, spec, lockedRefs, opts)
+		default:
+			metadata, stats, err = s.syncRef(ctx, tempDir, v, spec, lockedRefs, opts, urls)
+		}
 		if err != nil {
 			return nil, CopyStats{}, err
 		}
+		// A later ref writing the same destination leaves an earlier ref's
+		// streamed hash stale; those fall back to hashing the final file
+		for _, earlier := range results {
+			for path := range stats.FileHashes {
+				if _, ok := earlier.FileHashes[path]; ok {
+					earlier.FileHashes[path] = ""
+				}
+			}
+		}
+		metadata.FileHashes = stats.FileHashes
 		results[spec.Ref] = metadata
 		totalStats.Add(stats)
 
@@ -643,27 +903,35 @@ func (s *SyncService) SyncVendor(ctx context.Context, v *types.VendorSpec, locke
 			Pluralize(stats.FileCount, "file", "files"))
 	}
 
-	// Execute post-sync hook after successful sync
-	if v.Hooks != nil && v.Hooks.PostSync != "" {
-		// Get the first ref's commit hash for context (if multiple refs, use the first)
-		firstHash := ""
-		firstRef := ""
-		for ref, metadata := range results {
-			firstHash = metadata.CommitHash
-			firstRef = ref
-			break
-		}
+	// Execute post-sync hook after successful sync, with the first ref's
+	// commit hash for context (if multiple refs, use the first)
+	hookCtx := types.HookContext{VendorName: v.Name, VendorURL: urls[0], RootDir: s.rootDir, FilesCopied: totalStats.FileCount}
+	for ref, metadata := range results {
+		hookCtx.Ref, hookCtx.CommitHash = ref, metadata.CommitHash
+		break
+	}
+	clearHashes, err := s.runPostSync(v, opts, &totalStats, hookCtx)
+	if err != nil {
+		return nil, CopyStats{}, err
+	}
 
-		hookCtx := types.HookContext{
-			VendorName:  v.Name,
-			VendorURL:   urls[0],
-			Ref:         firstRef,
-			CommitHash:  firstHash,
-			RootDir:     s.rootDir,
-			FilesCopied: totalStats.FileCount,
+	// A hook may have rewritten vendored files after they were hashed: keep
+	// the copied paths, but hash them again from disk. Their copy hashes no
+	// longer tell which files are identical, so nothing is hard-linked either;
+	// linking only after hooks keeps a hook's in-place edit from reaching a
+	// file's other links.
+	if clearHashes {
+		for _, metadata := range results {
+			for path := range metadata.FileHashes {
+				metadata.FileHashes[path] = ""
+			}
 		}
-		if err := s.hooks.ExecutePostSync(v, &hookCtx); err != nil {
-			return nil, CopyStats{}, fmt.Errorf("post-sync hook failed: %w", err)
+		if opts.Hardlink {
+			s.ui.ShowWarning("Hard Links Skipped", fmt.Sprintf("%s: a post-sync hook ran, so its files were not hard-linked", v.Name))
+		}
+	} else if opts.Hardlink {
+		if n := s.linkDuplicateFiles(totalStats.FileHashes); n > 0 {
+			fmt.Printf("  🔗 %s hard-linked to identical copies\n", Pluralize(n, "file", "files"))
 		}
 	}
 
@@ -688,13 +956,33 @@ func (s *SyncService) syncRef(ctx context.Context, tempDir string, v *types.Vend
 	// Fetch and checkout using mirror-aware fallback (origin already added by SyncVendor)
 	fmt.Printf("  ⠿ Fetching ref '%s'...\n", spec.Ref)
 
-	// Shallow fetch first; if that fails for all URLs, try full depth
-	usedURL, fetchErr := s.fetchWithMirrorFallback(ctx, tempDir, urls, spec.Ref, 1)
-	if fetchErr != nil {
-		// Shallow fetch failed across all URLs — try full fetch (depth 0)
-		usedURL, fetchErr = s.fetchWithMirrorFallback(ctx, tempDir, urls, spec.Ref, 0)
+	depth := refFetchDepth(spec)
+	usedURL := ""
+	fetched := false
+
+	// Locked refs fetch the exact commit first. Servers without
+	// uploadpack.allowReachableSHA1InWant reject this, so fall through to the ref.
+	if isLocked {
+		var shaErr error
+		usedURL, shaErr = s.fetchWithMirrorFallback(ctx, tempDir, urls, targetCommit, depth, opts.FetchAttempts)
+		fetched = shaErr == nil
+	}
+
+	if !fetched {
+		// The locked commit was resolved from the alias, so that's where it lives
+		fetchRef := spec.Ref
+		if alias := opts.LockedAliases[v.Name+"@"+spec.Ref]; alias != "" {
+			fetchRef = alias
+		}
+		// Shallow fetch first; if that fails for all URLs, try full depth
+		var fetchErr error
+		usedURL, fetchErr = s.fetchWithMirrorFallback(ctx, tempDir, urls, fetchRef, depth, opts.FetchAttempts)
+		if fetchErr != nil && depth != 0 {
+			// Shallow fetch failed across all URLs — try full fetch (depth 0)
+			usedURL, fetchErr = s.fetchWithMirrorFallback(ctx, tempDir, urls, fetchRef, 0, opts.FetchAttempts)
+		}
 		if fetchErr != nil {
-			return RefMetadata{}, CopyStats{}, fmt.Errorf("failed to fetch ref %s: %w", spec.Ref, fetchErr)
+			return RefMetadata{}, CopyStats{}, fmt.Errorf("failed to fetch ref %s: %w", fetchRef, fetchErr)
 		}
 	}
 
@@ -733,14 +1021,87 @@ func (s *SyncService) syncRef(ctx context.Context, tempDir string, v *types.Vend
 	//nolint:errcheck // Version tag is optional, empty string is acceptable fallback
 	versionTag, _ := s.gitClient.GetTagForCommit(ctx, tempDir, hash)
 
-	// Copy license file (don't count in stats)
-	if err := s.license.CopyLicense(tempDir, v.Name); err != nil {
+	metadata, stats, err := s.placeRefTree(tempDir, v, spec, hash, opts)
+	if err != nil {
 		return RefMetadata{}, CopyStats{}, err
 	}
+	metadata.VersionTag = versionTag
+	metadata.SourceURL = sourceURL
+	return metadata, stats, nil
+}
+
+// syncRefFromLocal syncs a single ref straight from a local repository at
+// repoDir: the locked commit (or spec.Ref when unlocked) is resolved there
+// and its tree exported with ExportTree, so nothing is cloned or fetched and
+// the files match what a fetch and checkout of that commit would produce.
+func (s *SyncService) syncRefFromLocal(ctx context.Context, tempDir, repoDir string, v *types.VendorSpec, spec types.BranchSpec, lockedRefs map[string]string, opts SyncOptions) (RefMetadata, CopyStats, error) {
+	target := lockedRefs[spec.Ref]
+	isLocked := target != ""
+	if !isLocked {
+		target = spec.Ref
+	}
+
+	fmt.Printf("  ⠿ Reading ref '%s' from local repository...\n", spec.Ref)
+	hash, err := s.gitClient.ResolveRef(ctx, repoDir, target)
+	if err != nil {
+		if isLocked {
+			return RefMetadata{}, CopyStats{}, NewStaleCommitError(target, v.Name, spec.Ref)
+		}
+		return RefMetadata{}, CopyStats{}, NewCheckoutError(spec.Ref, v.Name, err)
+	}
+
+	treeDir, err := os.MkdirTemp(tempDir, "local-*")
+	if err != nil {
+		return RefMetadata{}, CopyStats{}, fmt.Errorf("create local tree directory: %w", err)
+	}
+	if err := s.gitClient.ExportTree(ctx, repoDir, hash, treeDir); err != nil {
+		return RefMetadata{}, CopyStats{}, fmt.Errorf("export %s @ %s from %s: %w", v.Name, spec.Ref, repoDir, err)
+	}
+
+	//nolint:errcheck // Version tag is optional, empty string is acceptable fallback
+	versionTag, _ := s.gitClient.GetTagForCommit(ctx, repoDir, hash)
+
+	metadata, stats, err := s.placeRefTree(treeDir, v, spec, hash, opts)
+	if err != nil {
+		return RefMetadata{}, CopyStats{}, err
+	}
+	metadata.VersionTag = versionTag
+	return metadata, stats, nil
+}
+
+// placeRefTree copies a ref's tree at commit hash, already on disk at
+// treeDir, into place: the optional snapshot, license and notice files,
+// position relocation, the mappings themselves, and the sync caches.
+func (s *SyncService) placeRefTree(treeDir string, v *types.VendorSpec, spec types.BranchSpec, hash string, opts SyncOptions) (RefMetadata, CopyStats, error) {
+	// Archive the checked-out tree for later offline restores (one archive per commit)
+	if opts.Snapshot {
+		snapshotPath := SnapshotPath(s.rootDir, v.Name, hash)
+		if _, statErr := os.Stat(snapshotPath); errors.Is(statErr, os.ErrNotExist) {
+			if err := writeSnapshot(treeDir, snapshotPath); err != nil {
+				return RefMetadata{}, CopyStats{}, fmt.Errorf("snapshot %s @ %s: %w", v.Name, spec.Ref, err)
+			}
+		}
+	}
+
+	// Copy license and notice files (don't count in stats)
+	licenseFiles, err := s.license.CopyLicense(treeDir, v.Name, opts.LicenseDir, opts.LicenseFiles)
+	if err != nil {
+		return RefMetadata{}, CopyStats{}, err
+	}
+
+	// Follow position snippets that moved upstream before copying them
+	var relocations []positionRelocation
+	if locked, ok := opts.RelocatePositions[spec.Ref]; ok {
+		var warnings []string
+		spec, relocations, warnings = relocatePositions(treeDir, spec, locked)
+		for _, w := range warnings {
+			fmt.Printf("  ⚠ %s\n", w)
+		}
+	}
 
 	// Copy files according to mappings and collect stats
 	fmt.Printf("  ⠿ Copying files...\n")
-	stats, err := s.fileCopy.CopyMappings(tempDir, v, spec)
+	stats, err := s.fileCopy.CopyMappings(treeDir, v, spec)
 	if err != nil {
 		return RefMetadata{}, CopyStats{}, err
 	}
@@ -752,20 +1113,77 @@ func (s *SyncService) syncRef(ctx context.Context, tempDir string, v *types.Vend
 
 	// Build and save cache (if cache enabled)
 	if !opts.NoCache {
-		if err := s.updateCache(v.Name, spec, hash); err != nil {
-			// Cache update failure shouldn't fail the sync
-			// Just log a warning and continue
-			fmt.Printf("  ⚠ Warning: failed to update cache: %v\n", err)
-		}
+		s.saveRefCaches(treeDir, v, spec, hash, opts)
 	}
 
-	return RefMetadata{CommitHash: hash, VersionTag: versionTag, Positions: stats.Positions, SourceURL: sourceURL}, stats, nil
+	return RefMetadata{CommitHash: hash, Positions: stats.Positions, LicenseFiles: licenseFiles, Relocations: relocations}, stats, nil
+}
+
+// syncRefFromSnapshot restores a single locked ref from its tar.gz snapshot
+// instead of fetching from the remote. The snapshot is extracted into a fresh
+// directory under tempDir, then license and mapping copies run exactly as they
+// do for a fetched tree, so restored files hash identically.
+func (s *SyncService) syncRefFromSnapshot(tempDir string, v *types.VendorSpec, spec types.BranchSpec, lockedRefs map[string]string, opts SyncOptions) (RefMetadata, CopyStats, error) {
+	hash := lockedRefs[spec.Ref]
+	if hash == "" {
+		return RefMetadata{}, CopyStats{}, fmt.Errorf("offline sync of %s @ %s requires a locked commit; run 'git-vendor pull --snapshot' while online first", v.Name, spec.Ref)
+	}
+
+	snapshotPath := SnapshotPath(s.rootDir, v.Name, hash)
+	if _, err := os.Stat(snapshotPath); err != nil {
+		return RefMetadata{}, CopyStats{}, fmt.Errorf("no snapshot for %s @ %s (commit %s) at %s; run 'git-vendor pull --snapshot' while online first", v.Name, spec.Ref, hash, snapshotPath)
+	}
+
+	fmt.Printf("  ⠿ Extracting snapshot for ref '%s'...\n", spec.Ref)
+	treeDir, err := os.MkdirTemp(tempDir, "snapshot-*")
+	if err != nil {
+		return RefMetadata{}, CopyStats{}, fmt.Errorf("create snapshot directory: %w", err)
+	}
+	if err := extractSnapshot(snapshotPath, treeDir); err != nil {
+		return RefMetadata{}, CopyStats{}, fmt.Errorf("restore %s @ %s: %w", v.Name, spec.Ref, err)
+	}
+
+	// Copy license and notice files (don't count in stats)
+	licenseFiles, err := s.license.CopyLicense(treeDir, v.Name, opts.LicenseDir, opts.LicenseFiles)
+	if err != nil {
+		return RefMetadata{}, CopyStats{}, err
+	}
+
+	fmt.Printf("  ⠿ Copying files...\n")
+	stats, err := s.fileCopy.CopyMappings(treeDir, v, spec)
+	if err != nil {
+		return RefMetadata{}, CopyStats{}, err
+	}
+	for _, w := range stats.Warnings {
+		fmt.Printf("  ⚠ %s\n", w)
+	}
+
+	if !opts.NoCache {
+		s.saveRefCaches(treeDir, v, spec, hash, opts)
+	}
+
+	return RefMetadata{CommitHash: hash, Positions: stats.Positions, LicenseFiles: licenseFiles}, stats, nil
+}
+
+// refFetchDepth maps BranchSpec.Depth to a git fetch depth (0 = full history).
+// The default is a shallow depth-1 fetch; a negative Depth requests full history.
+func refFetchDepth(spec types.BranchSpec) int {
+	switch {
+	case spec.Depth < 0:
+		return 0
+	case spec.Depth == 0:
+		return 1
+	default:
+		return spec.Depth
+	}
 }
 
 // fetchWithMirrorFallback tries fetching from each URL in order. Assumes "origin"
 // remote already exists in tempDir (added by SyncVendor). Uses SetRemoteURL for
-// mirror fallback instead of AddRemote. Returns the URL that succeeded.
-func (s *SyncService) fetchWithMirrorFallback(ctx context.Context, tempDir string, urls []string, ref string, depth int) (string, error) {
+// mirror fallback instead of AddRemote. Each URL is retried up to attempts
+// times on transient network errors (fetchWithRetry) before moving to the
+// next mirror. Returns the URL that succeeded.
+func (s *SyncService) fetchWithMirrorFallback(ctx context.Context, tempDir string, urls []string, ref string, depth, attempts int) (string, error) {
 	var lastErr error
 	for i, url := range urls {
 		if i > 0 {
@@ -774,12 +1192,10 @@ func (s *SyncService) fetchWithMirrorFallback(ctx context.Context, tempDir strin
 				lastErr = fmt.Errorf("set remote URL to %s: %w", SanitizeURL(url), setErr)
 				continue
 			}
-			if Verbose {
-				s.ui.ShowWarning("Mirror Fallback", fmt.Sprintf("Trying %s", SanitizeURL(url)))
-			}
+			s.logger.Warnf("%s: trying mirror %s", ref, SanitizeURL(url))
 		}
 
-		fetchErr := s.gitClient.Fetch(ctx, tempDir, "origin", depth, ref)
+		fetchErr := fetchWithRetry(ctx, s.gitClient, s.ui, tempDir, ref, depth, attempts)
 		if fetchErr == nil {
 			return url, nil
 		}
@@ -794,16 +1210,19 @@ func (s *SyncService) fetchWithMirrorFallback(ctx context.Context, tempDir strin
 }
 
 // canSkipSync checks if a vendor@ref can skip sync based on cache.
+// Every mapping destination must be covered by the cache, and every cached
+// file must still exist with its cached checksum. Destinations are relative
+// to the project root, like the paths CopyMappings writes.
 // Returns false (forcing a re-sync) on any cache error, missing files, or checksum mismatch.
-func (s *SyncService) canSkipSync(vendorName, ref, commitHash string, mappings []types.PathMapping) bool {
+func (s *SyncService) canSkipSync(v *types.VendorSpec, spec types.BranchSpec, commitHash string) bool {
 	// Load cache for this vendor@ref
-	cache, err := s.cache.Load(vendorName, ref)
+	cache, err := s.cache.Load(v.Name, spec.Ref)
 	if err != nil {
 		// Log corrupted cache so the user knows why cache was skipped
-		fmt.Printf("  ⚠ Warning: cache error for %s@%s: %v\n", vendorName, ref, err)
+		fmt.Printf("  ⚠ Warning: cache error for %s@%s: %v\n", v.Name, spec.Ref, err)
 		return false
 	}
-	if cache.CommitHash == "" {
+	if cache.CommitHash == "" || len(cache.Files) == 0 {
 		// Cache miss - can't skip
 		return false
 	}
@@ -814,45 +1233,48 @@ func (s *SyncService) canSkipSync(vendorName, ref, commitHash string, mappings [
 		return false
 	}
 
+	// The same commit copied with different mappings, filters or transforms
+	// produces different files, so the mapping config must match too
+	if cache.SpecHash != syncSpecFingerprint(v, spec) {
+		return false
+	}
+
 	// Build a map of cached checksums for quick lookup
 	cachedChecksums := make(map[string]string)
 	for _, fc := range cache.Files {
 		cachedChecksums[fc.Path] = fc.Hash
 	}
 
-	// Validate all destination files exist and match cached checksums
-	for _, mapping := range mappings {
-		destPath := mapping.To
-		if destPath == "" {
-			// Auto-naming not supported in cache check (too complex)
-			return false
+	// Every destination must have been recorded: a file by its own path, a
+	// directory by at least one file beneath it
+	for _, mapping := range spec.Mapping {
+		dest := mappingDestFile(v, spec, mapping)
+		if _, exists := cachedChecksums[dest]; exists {
+			continue
 		}
-
-		// Strip position specifier from destination path for file system access
-		destFile, _, err := types.ParsePathPosition(destPath)
-		if err != nil {
-			destFile = destPath
+		covered := false
+		for path := range cachedChecksums {
+			if strings.HasPrefix(path, dest+"/") {
+				covered = true
+				break
+			}
 		}
-
-		// Check if file exists.
-		// Uses errors.Is instead of os.IsNotExist to correctly handle wrapped errors
-		// (see Legacy Trap in CLAUDE.md: "os.IsNotExist for wrapped errors").
-		fullPath := filepath.Join(s.rootDir, destFile)
-		if _, err := os.Stat(fullPath); errors.Is(err, os.ErrNotExist) {
-			// File missing - can't skip
+		if !covered {
 			return false
 		}
+	}
 
-		// Check checksum
-		currentHash, err := s.cache.ComputeFileChecksum(fullPath)
-		if err != nil {
-			// Can't compute checksum - can't skip
+	// Validate all cached files exist and match their checksums.
+	// Uses errors.Is instead of os.IsNotExist to correctly handle wrapped errors
+	// (see Legacy Trap in CLAUDE.md: "os.IsNotExist for wrapped errors").
+	for path, cachedHash := range cachedChecksums {
+		if _, err := os.Stat(filepath.FromSlash(path)); errors.Is(err, os.ErrNotExist) {
+			// File missing - can't skip
 			return false
 		}
-
-		cachedHash, exists := cachedChecksums[destFile]
-		if !exists || cachedHash != currentHash {
-			// Checksum mismatch or not in cache - can't skip
+		currentHash, err := s.cache.ComputeFileChecksum(filepath.FromSlash(path))
+		if err != nil || currentHash != cachedHash {
+			// Checksum mismatch or unreadable - can't skip
 			return false
 		}
 	}
@@ -861,34 +1283,118 @@ func (s *SyncService) canSkipSync(vendorName, ref, commitHash string, mappings [
 	return true
 }
 
-// updateCache builds and saves cache for a vendor@ref
-func (s *SyncService) updateCache(vendorName string, spec types.BranchSpec, commitHash string) error {
-	// Collect destination file paths
-	var destPaths []string
+// syncSpecFingerprint hashes everything besides the commit that decides what
+// a spec's sync writes: its mappings (from, to, include/exclude, max_depth,
+// transforms), the default target, SPDX header settings and the project's
+// .vendorignore. A cache built under a different fingerprint is stale.
+func syncSpecFingerprint(v *types.VendorSpec, spec types.BranchSpec) string {
+	ignore, err := os.ReadFile(VendorIgnoreFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// Unreadable .vendorignore: return a fingerprint no cache matches
+		return "unreadable"
+	}
+	// Plain strings, ints and slices: Marshal can't fail
+	data, _ := json.Marshal(struct {
+		DefaultTarget string
+		Mapping       []types.PathMapping
+		SPDXHeaders   bool
+		License       string
+		VendorIgnore  string
+	}{spec.DefaultTarget, spec.Mapping, v.SPDXHeaders, v.License, string(ignore)})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// mappingDestFile returns a mapping's destination as CopyMappings resolves
+// it (auto-naming applied, position specifier stripped), in forward-slash form.
+func mappingDestFile(v *types.VendorSpec, spec types.BranchSpec, mapping types.PathMapping) string {
+	destPath := (&FileCopyService{}).computeDestPath(mapping, spec, v)
+	destFile, _, err := types.ParsePathPosition(destPath)
+	if err != nil {
+		destFile = destPath
+	}
+	return filepath.ToSlash(filepath.Clean(destFile))
+}
+
+// cacheDestFiles lists the files a spec's mappings placed on disk: file
+// destinations themselves and every file under directory destinations.
+// Destinations that don't exist are skipped.
+func cacheDestFiles(v *types.VendorSpec, spec types.BranchSpec) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
 	for _, mapping := range spec.Mapping {
-		destPath := mapping.To
-		if destPath == "" {
-			// Skip auto-named files (too complex to track)
+		dest := mappingDestFile(v, spec, mapping)
+		info, err := os.Stat(filepath.FromSlash(dest))
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			add(dest)
 			continue
 		}
-		// Strip position specifier from destination path for file system access
-		destFile, _, err := types.ParsePathPosition(destPath)
+		err = filepath.WalkDir(filepath.FromSlash(dest), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() {
+				add(filepath.ToSlash(path))
+			}
+			return nil
+		})
 		if err != nil {
-			destFile = destPath
+			return nil, fmt.Errorf("walk %s: %w", dest, err)
+		}
+	}
+	return files, nil
+}
+
+// saveRefCaches records the incremental sync cache and the position source
+// cache for a synced vendor@ref. --only-positions syncs a reduced spec, so the
+// destination cache is left for the next full sync to rebuild. Cache failures
+// shouldn't fail the sync; they are logged as warnings.
+func (s *SyncService) saveRefCaches(treeDir string, v *types.VendorSpec, spec types.BranchSpec, commitHash string, opts SyncOptions) {
+	if !opts.OnlyPositions {
+		if err := s.updateCache(v, spec, commitHash); err != nil {
+			fmt.Printf("  ⚠ Warning: failed to update cache: %v\n", err)
+		}
+	}
+	if err := cachePositionSources(treeDir, s.rootDir, commitHash, spec); err != nil {
+		fmt.Printf("  ⚠ Warning: failed to cache position sources: %v\n", err)
+	}
+}
+
+// updateCache builds and saves cache for a vendor@ref. A spec whose
+// destinations hold more than maxCacheFiles files is not cached, since a
+// truncated cache couldn't detect deleted files; its old cache is dropped.
+func (s *SyncService) updateCache(v *types.VendorSpec, spec types.BranchSpec, commitHash string) error {
+	destPaths, err := cacheDestFiles(v, spec)
+	if err != nil {
+		return fmt.Errorf("collect files for %s@%s: %w", v.Name, spec.Ref, err)
+	}
+	if len(destPaths) > maxCacheFiles {
+		if err := s.cache.Delete(v.Name, spec.Ref); err != nil {
+			return fmt.Errorf("delete cache for %s@%s: %w", v.Name, spec.Ref, err)
 		}
-		fullPath := filepath.Join(s.rootDir, destFile)
-		destPaths = append(destPaths, fullPath)
+		return nil
 	}
 
 	// Build cache with checksums
-	cache, err := s.cache.BuildCache(vendorName, spec.Ref, commitHash, destPaths)
+	cache, err := s.cache.BuildCache(v.Name, spec.Ref, commitHash, destPaths)
 	if err != nil {
-		return fmt.Errorf("build cache for %s@%s: %w", vendorName, spec.Ref, err)
+		return fmt.Errorf("build cache for %s@%s: %w", v.Name, spec.Ref, err)
 	}
+	cache.SpecHash = syncSpecFingerprint(v, spec)
 
 	// Save cache
 	if err := s.cache.Save(&cache); err != nil {
-		return fmt.Errorf("save cache for %s@%s: %w", vendorName, spec.Ref, err)
+		return fmt.Errorf("save cache for %s@%s: %w", v.Name, spec.Ref, err)
 	}
 	return nil
 }
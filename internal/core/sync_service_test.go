@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -29,7 +30,7 @@ func TestSyncVendor_HappyPath_LockedRef(t *testing.T) {
 
 	git.EXPECT().Init(gomock.Any(), "/tmp/test-12345").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/test-12345", "origin", "https://github.com/owner/repo").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/test-12345", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/test-12345", "origin", 1, "abc123def456").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/test-12345", "abc123def456").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/test-12345").Return("abc123def456", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -185,6 +186,142 @@ func TestSyncVendor_BothFetchesFail(t *testing.T) {
 	}
 }
 
+// syncVendorWithDepth runs a single-ref SyncVendor for a spec with the given
+// depth, expecting exactly the fetches configured by expectFetch.
+func syncVendorWithDepth(t *testing.T, depth int, lockedRefs map[string]string, expectFetch func(git *MockGitClient)) {
+	t.Helper()
+	syncVendorWithOptions(t, depth, lockedRefs, SyncOptions{NoCache: true}, expectFetch)
+}
+
+// syncVendorWithOptions is syncVendorWithDepth with the SyncOptions given.
+func syncVendorWithOptions(t *testing.T, depth int, lockedRefs map[string]string, opts SyncOptions, expectFetch func(git *MockGitClient)) {
+	t.Helper()
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "v1.0.0")
+	vendor.Specs[0].Depth = depth
+
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-12345", nil)
+	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	expectFetch(git)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("abc123def", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if _, _, err := syncer.sync.SyncVendor(context.Background(), &vendor, lockedRefs, opts); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+}
+
+func TestSyncVendor_SpecDepth_UsedForFetch(t *testing.T) {
+	syncVendorWithDepth(t, 50, nil, func(git *MockGitClient) {
+		git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 50, "v1.0.0").Return(nil)
+	})
+}
+
+func TestSyncVendor_SpecDepthFull_SingleFullFetch(t *testing.T) {
+	// depth: -1 fetches full history directly; there is no shallow attempt to fall back from
+	syncVendorWithDepth(t, -1, nil, func(git *MockGitClient) {
+		git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 0, "v1.0.0").Return(nil)
+	})
+}
+
+func TestSyncVendor_LockedSHAFetchRejected_FallsBackToRef(t *testing.T) {
+	// Server without uploadpack.allowReachableSHA1InWant rejects the SHA fetch
+	syncVendorWithDepth(t, 0, map[string]string{"v1.0.0": "abc123def"}, func(git *MockGitClient) {
+		gomock.InOrder(
+			git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "abc123def").Return(fmt.Errorf("Server does not allow request for unadvertised object")),
+			git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "v1.0.0").Return(nil),
+		)
+	})
+}
+
+func TestSyncVendor_LockedSHAFetchRejected_FallsBackToRefAlias(t *testing.T) {
+	// The lock entry was resolved from a ref_aliases target, so the fallback
+	// fetches the alias rather than the vendor.yml ref
+	opts := SyncOptions{NoCache: true, LockedAliases: map[string]string{"test-vendor@v1.0.0": "release/1.x"}}
+	syncVendorWithOptions(t, 0, map[string]string{"v1.0.0": "abc123def"}, opts, func(git *MockGitClient) {
+		gomock.InOrder(
+			git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "abc123def").Return(fmt.Errorf("Server does not allow request for unadvertised object")),
+			git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "release/1.x").Return(nil),
+		)
+	})
+}
+
+// withoutFetchRetryDelay disables the backoff wait between fetch retries for one test.
+func withoutFetchRetryDelay(t *testing.T) {
+	t.Helper()
+	prev := fetchRetryBaseDelay
+	fetchRetryBaseDelay = 0
+	t.Cleanup(func() { fetchRetryBaseDelay = prev })
+}
+
+func TestSyncVendor_TransientFetchFailure_RetriesThenSucceeds(t *testing.T) {
+	withoutFetchRetryDelay(t)
+	networkErr := fmt.Errorf("git fetch failed: fatal: unable to access 'https://github.com/owner/repo/': Could not resolve host: github.com")
+	syncVendorWithDepth(t, 0, nil, func(git *MockGitClient) {
+		gomock.InOrder(
+			git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "v1.0.0").Return(networkErr).Times(2),
+			git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "v1.0.0").Return(nil),
+		)
+	})
+}
+
+func TestSyncVendor_AuthFailure_NotRetried(t *testing.T) {
+	withoutFetchRetryDelay(t)
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-12345", nil)
+	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	// One attempt per depth: the shallow fetch and the full-depth fallback
+	authErr := fmt.Errorf("fatal: Authentication failed for 'https://github.com/owner/repo/'")
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(authErr).Times(1)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 0, "main").Return(authErr).Times(1)
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	_, _, err := syncer.sync.SyncVendor(context.Background(), &vendor, nil, SyncOptions{FetchAttempts: 5})
+	if err == nil || !contains(err.Error(), "Authentication failed") {
+		t.Fatalf("expected authentication error, got %v", err)
+	}
+}
+
+func TestIsRetryableGitError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("fatal: unable to access 'https://x/': Could not resolve host: x"), true},
+		{fmt.Errorf("error: RPC failed; curl 56 Recv failure: Connection reset by peer"), true},
+		{fmt.Errorf("fatal: the remote end hung up unexpectedly"), true},
+		{fmt.Errorf("The requested URL returned error: 503 Service Unavailable"), true},
+		{fmt.Errorf("fatal: Authentication failed for 'https://x/'"), false},
+		{fmt.Errorf("fatal: couldn't find remote ref refs/heads/nope"), false},
+		{fmt.Errorf("remote: Repository not found."), false},
+		{fmt.Errorf("fetch: %w", context.DeadlineExceeded), false},
+		{fmt.Errorf("shallow fetch failed"), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableGitError(tt.err); got != tt.want {
+			t.Errorf("IsRetryableGitError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
 // TestSyncVendor_MirrorFallback_PrimaryFails_MirrorSucceeds exercises the mirror
 // fallback path end-to-end: primary URL fetch fails, mirror URL succeeds, and
 // SourceURL is recorded in RefMetadata when a mirror was used.
@@ -698,6 +835,7 @@ func TestSync_AllVendors(t *testing.T) {
 
 	config.EXPECT().Load().Return(testConfig, nil)
 	lock.EXPECT().Load().Return(testLock, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	// Each vendor performs git operations + file copy
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test", nil).Times(3)
@@ -727,6 +865,96 @@ func TestSync_AllVendors(t *testing.T) {
 	}
 }
 
+func TestSync_ExcludeVendor_SkipsMatching(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	testConfig := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			createTestVendorSpec("vendor-a", "https://github.com/a/repo", "main"),
+			createTestVendorSpec("vendor-b", "https://github.com/b/repo", "main"),
+			createTestVendorSpec("vendor-c", "https://github.com/c/repo", "main"),
+		},
+	}
+	testLock := types.VendorLock{
+		Vendors: []types.LockDetails{
+			createTestLockEntry("vendor-a", "main", "hash111"),
+			createTestLockEntry("vendor-b", "main", "hash222"),
+			createTestLockEntry("vendor-c", "main", "hash333"),
+		},
+	}
+
+	config.EXPECT().Load().Return(testConfig, nil)
+	lock.EXPECT().Load().Return(testLock, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
+
+	// vendor-b is excluded: only a and c touch git
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test", nil).Times(2)
+	fs.EXPECT().RemoveAll("/tmp/test").Return(nil).Times(2)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/a/repo").Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/c/repo").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("hash111", nil).Times(1)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("hash333", nil).Times(1)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "file", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	syncService := syncer.sync.(*SyncService)
+
+	if err := syncService.Sync(context.Background(), SyncOptions{ExcludeVendors: []string{"vendor-b"}}); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+}
+
+func TestSync_SharedURL_ClonesOnce(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	// Two vendors vendoring different subpaths and refs of the same repository
+	vendorA := createTestVendorSpec("vendor-a", "https://github.com/owner/monorepo", "main")
+	vendorB := createTestVendorSpec("vendor-b", "https://GitHub.com/owner/monorepo.git", "v2")
+	testLock := types.VendorLock{
+		Vendors: []types.LockDetails{
+			createTestLockEntry("vendor-a", "main", "hash111"),
+			createTestLockEntry("vendor-b", "v2", "hash222"),
+		},
+	}
+
+	config.EXPECT().Load().Return(createTestConfig(vendorA, vendorB), nil)
+	lock.EXPECT().Load().Return(testLock, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
+
+	// One clone for both vendors; the second ref is an extra fetch into it
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/shared", nil).Times(1)
+	fs.EXPECT().RemoveAll("/tmp/shared").Return(nil).Times(1)
+	git.EXPECT().Init(gomock.Any(), "/tmp/shared").Return(nil).Times(1)
+	git.EXPECT().AddRemote(gomock.Any(), "/tmp/shared", "origin", "https://github.com/owner/monorepo").Return(nil).Times(1)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/shared", "origin", 1, "hash111").Return(nil).Times(1)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/shared", "origin", 1, "hash222").Return(nil).Times(1)
+	git.EXPECT().Checkout(gomock.Any(), "/tmp/shared", "hash111").Return(nil).Times(1)
+	git.EXPECT().Checkout(gomock.Any(), "/tmp/shared", "hash222").Return(nil).Times(1)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("hash111", nil).Times(1)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("hash222", nil).Times(1)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "file", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	syncService := syncer.sync.(*SyncService)
+
+	if err := syncService.Sync(context.Background(), SyncOptions{}); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+}
+
 func TestSync_SingleVendor_ByName(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
@@ -748,6 +976,7 @@ func TestSync_SingleVendor_ByName(t *testing.T) {
 
 	config.EXPECT().Load().Return(testConfig, nil)
 	lock.EXPECT().Load().Return(testLock, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	// Only vendor-b should be synced (1 set of git operations)
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test", nil).Times(1)
@@ -775,6 +1004,55 @@ func TestSync_SingleVendor_ByName(t *testing.T) {
 	}
 }
 
+// TestSync_RecordsLastSyncedAt verifies that a sync stamps LastSyncedAt on
+// the lock entries it copied and leaves Updated (the last update) alone.
+func TestSync_RecordsLastSyncedAt(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	entry := createTestLockEntry("vendor-a", "main", "hash111")
+	entry.Updated = "2025-01-01T00:00:00Z"
+	entry.LastSyncedAt = "2025-01-01T00:00:00Z"
+
+	config.EXPECT().Load().Return(createTestConfig(createTestVendorSpec("vendor-a", "https://github.com/a/repo", "main")), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{entry}}, nil)
+
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test", nil)
+	fs.EXPECT().RemoveAll("/tmp/test").Return(nil)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("hash111", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "file", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+
+	var saved types.VendorLock
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		saved = l
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if err := syncer.sync.Sync(context.Background(), SyncOptions{NoCache: true}); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+
+	if len(saved.Vendors) != 1 {
+		t.Fatalf("expected 1 saved lock entry, got %d", len(saved.Vendors))
+	}
+	got := saved.Vendors[0]
+	if got.Updated != "2025-01-01T00:00:00Z" {
+		t.Errorf("Updated = %q, want it unchanged by sync", got.Updated)
+	}
+	if got.LastSyncedAt == "" || got.LastSyncedAt == entry.LastSyncedAt {
+		t.Errorf("LastSyncedAt = %q, want a fresh timestamp", got.LastSyncedAt)
+	}
+}
+
 func TestSync_VendorNotFound(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
@@ -803,6 +1081,84 @@ func TestSync_VendorNotFound(t *testing.T) {
 	if !contains(err.Error(), "not found") {
 		t.Errorf("Expected 'not found' error, got: %v", err)
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrVendorNotFound), got: %v", err)
+	}
+}
+
+func TestSync_VendorGlob_MatchesSubset(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	// aws-* matches two of the three vendors
+	testConfig := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			createTestVendorSpec("aws-s3", "https://github.com/aws/s3", "main"),
+			createTestVendorSpec("internal-auth", "https://github.com/corp/auth", "main"),
+			createTestVendorSpec("aws-lambda", "https://github.com/aws/lambda", "main"),
+		},
+	}
+
+	testLock := types.VendorLock{
+		Vendors: []types.LockDetails{
+			createTestLockEntry("aws-s3", "main", "hash111"),
+			createTestLockEntry("internal-auth", "main", "hash222"),
+			createTestLockEntry("aws-lambda", "main", "hash333"),
+		},
+	}
+
+	config.EXPECT().Load().Return(testConfig, nil)
+	lock.EXPECT().Load().Return(testLock, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
+
+	// Only the two aws-* vendors are fetched
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test", nil).Times(2)
+	fs.EXPECT().RemoveAll("/tmp/test").Return(nil).Times(2)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/aws/s3").Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/aws/lambda").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("hash111", nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("hash333", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "file", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	syncService := syncer.sync.(*SyncService)
+
+	if err := syncService.Sync(context.Background(), SyncOptions{VendorName: "aws-*"}); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+}
+
+func TestSync_VendorGlob_NoMatch(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	config.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			createTestVendorSpec("aws-s3", "https://github.com/aws/s3", "main"),
+		},
+	}, nil)
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil)
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	syncService := syncer.sync.(*SyncService)
+
+	err := syncService.Sync(context.Background(), SyncOptions{VendorName: "gcp-*"})
+	if !IsNoVendorsMatched(err) {
+		t.Fatalf("Expected NoVendorsMatchedError, got: %v", err)
+	}
+	if IsVendorNotFound(err) {
+		t.Error("glob with no matches should not be reported as vendor not found")
+	}
+	if !contains(err.Error(), "No vendors matched pattern 'gcp-*'") {
+		t.Errorf("unexpected error message: %v", err)
+	}
 }
 
 func TestSync_DryRun_PreviewMode(t *testing.T) {
@@ -865,6 +1221,7 @@ func TestSync_Force_IgnoresLock(t *testing.T) {
 
 	config.EXPECT().Load().Return(testConfig, nil)
 	lock.EXPECT().Load().Return(testLock, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test", nil).Times(1)
 	fs.EXPECT().RemoveAll("/tmp/test").Return(nil).Times(1)
@@ -1093,6 +1450,9 @@ func TestValidateVendorExists_NotFound(t *testing.T) {
 	if !contains(err.Error(), "not found") {
 		t.Errorf("Expected 'not found' error, got: %v", err)
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrVendorNotFound), got: %v", err)
+	}
 }
 
 func TestValidateVendorExists_EmptyConfig(t *testing.T) {
@@ -1114,6 +1474,9 @@ func TestValidateVendorExists_EmptyConfig(t *testing.T) {
 	if !contains(err.Error(), "not found") {
 		t.Errorf("Expected 'not found' error, got: %v", err)
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrVendorNotFound), got: %v", err)
+	}
 }
 
 // ============================================================================
@@ -1272,13 +1635,14 @@ func TestSync_GroupFilter_SingleGroup(t *testing.T) {
 
 	config.EXPECT().Load().Return(*vendorConfig, nil)
 	lock.EXPECT().Load().Return(*lockData, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	// Expect syncs for vendor-a and vendor-c only (have "frontend" group)
 	// vendor-a expectations
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor-a", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor-a").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor-a", "origin", "https://github.com/test/repo-a").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-a", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-a", "origin", 1, "hash-a").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor-a", "hash-a").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor-a").Return("hash-a", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1292,7 +1656,7 @@ func TestSync_GroupFilter_SingleGroup(t *testing.T) {
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor-c", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor-c").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor-c", "origin", "https://github.com/test/repo-c").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-c", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-c", "origin", 1, "hash-c").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor-c", "hash-c").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor-c").Return("hash-c", nil)
 	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "src", isDir: true}, nil).AnyTimes()
@@ -1367,13 +1731,14 @@ func TestSync_GroupFilter_BackendGroup(t *testing.T) {
 
 	config.EXPECT().Load().Return(*vendorConfig, nil)
 	lock.EXPECT().Load().Return(*lockData, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	// Expect syncs for vendor-b and vendor-c only (have "backend" group)
 	// vendor-b expectations
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor-b", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor-b").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor-b", "origin", "https://github.com/test/repo-b").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-b", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-b", "origin", 1, "hash-b").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor-b", "hash-b").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor-b").Return("hash-b", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1387,7 +1752,7 @@ func TestSync_GroupFilter_BackendGroup(t *testing.T) {
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor-c", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor-c").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor-c", "origin", "https://github.com/test/repo-c").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-c", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-c", "origin", 1, "hash-c").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor-c", "hash-c").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor-c").Return("hash-c", nil)
 	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "src", isDir: true}, nil).AnyTimes()
@@ -1493,12 +1858,13 @@ func TestSync_GroupFilter_VendorWithoutGroups(t *testing.T) {
 
 	config.EXPECT().Load().Return(*vendorConfig, nil)
 	lock.EXPECT().Load().Return(*lockData, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	// Only vendor-with-group should be synced
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor-a", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor-a").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor-a", "origin", "https://github.com/test/repo-a").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-a", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-a", "origin", 1, "hash-a").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor-a", "hash-a").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor-a").Return("hash-a", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1550,12 +1916,13 @@ func TestSync_GroupFilter_MultipleGroups(t *testing.T) {
 
 	config.EXPECT().Load().Return(*vendorConfig, nil)
 	lock.EXPECT().Load().Return(*lockData, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	// Should be synced (matches "mobile" group)
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor", "origin", "https://github.com/test/repo").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor", "origin", 1, "hash-a").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor", "hash-a").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor").Return("hash-a", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1616,13 +1983,14 @@ func TestSync_GroupFilter_EmptyGroupName(t *testing.T) {
 
 	config.EXPECT().Load().Return(*vendorConfig, nil)
 	lock.EXPECT().Load().Return(*lockData, nil)
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
 
 	// Both vendors should be synced (no group filter)
 	// vendor-a expectations
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor-a", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor-a").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor-a", "origin", "https://github.com/test/repo-a").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-a", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-a", "origin", 1, "hash-a").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor-a", "hash-a").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor-a").Return("hash-a", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1636,7 +2004,7 @@ func TestSync_GroupFilter_EmptyGroupName(t *testing.T) {
 	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/vendor-b", nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/vendor-b").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/vendor-b", "origin", "https://github.com/test/repo-b").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-b", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/vendor-b", "origin", 1, "hash-b").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/vendor-b", "hash-b").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/vendor-b").Return("hash-b", nil)
 	fs.EXPECT().CopyDir(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 3, ByteCount: 300}, nil)
@@ -1686,7 +2054,7 @@ func TestSyncVendor_CacheError_ForcesResync(t *testing.T) {
 	fs.EXPECT().RemoveAll("/tmp/sync-test").Return(nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/sync-test").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/sync-test", "origin", "https://github.com/owner/mylib").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "abc123def456").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/sync-test", "abc123def456").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/sync-test").Return("abc123def456", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1739,7 +2107,7 @@ func TestSyncVendor_CacheHit_DestFileDeleted(t *testing.T) {
 	fs.EXPECT().RemoveAll("/tmp/sync-test").Return(nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/sync-test").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/sync-test", "origin", "https://github.com/owner/mylib").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "abc123def456").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/sync-test", "abc123def456").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/sync-test").Return("abc123def456", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1787,7 +2155,7 @@ func TestSyncVendor_CommitHashMismatch_ForcesResync(t *testing.T) {
 	fs.EXPECT().RemoveAll("/tmp/sync-test").Return(nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/sync-test").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/sync-test", "origin", "https://github.com/owner/mylib").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "new_commit_hash_999").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/sync-test", "new_commit_hash_999").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/sync-test").Return("new_commit_hash_999", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1825,7 +2193,7 @@ func TestSyncVendor_NoCacheFlag_BypassesCacheEntirely(t *testing.T) {
 	fs.EXPECT().RemoveAll("/tmp/sync-test").Return(nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/sync-test").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/sync-test", "origin", "https://github.com/owner/mylib").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/sync-test", "origin", 1, "abc123def456").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/sync-test", "abc123def456").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/sync-test").Return("abc123def456", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -1860,7 +2228,7 @@ func TestCanSkipSync_CacheMiss(t *testing.T) {
 		&SilentUICallback{}, tempDir, nil)
 
 	mappings := []types.PathMapping{{From: "src/file.go", To: "lib/file.go"}}
-	result := syncService.canSkipSync("test-vendor", "main", "abc123", mappings)
+	result := syncService.canSkipSync(&types.VendorSpec{Name: "test-vendor"}, types.BranchSpec{Ref: "main", Mapping: mappings}, "abc123")
 	if result {
 		t.Error("expected false for cache miss, got true")
 	}
@@ -1902,7 +2270,7 @@ func TestCanSkipSync_CommitHashMismatch(t *testing.T) {
 		&SilentUICallback{}, tempDir, nil)
 
 	mappings := []types.PathMapping{{From: "src/file.go", To: "lib/file.go"}}
-	result := syncService.canSkipSync("test-vendor", "main", "new-hash-111", mappings)
+	result := syncService.canSkipSync(&types.VendorSpec{Name: "test-vendor"}, types.BranchSpec{Ref: "main", Mapping: mappings}, "new-hash-111")
 	if result {
 		t.Error("expected false for commit hash mismatch, got true")
 	}
@@ -1911,7 +2279,8 @@ func TestCanSkipSync_CommitHashMismatch(t *testing.T) {
 // TestCanSkipSync_MatchingCache verifies that canSkipSync returns true when
 // all conditions are met: cache hit, matching commit hash, all files exist with matching checksums.
 func TestCanSkipSync_MatchingCache(t *testing.T) {
-	tempDir := t.TempDir()
+	// Destinations resolve against the project root (the working directory)
+	tempDir := chdirUnmanagedTest(t)
 	osFS := NewOSFileSystem()
 	cacheStore := NewFileCacheStore(osFS, tempDir)
 
@@ -1930,11 +2299,14 @@ func TestCanSkipSync_MatchingCache(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Save cache with matching commit hash and file checksums
+	// Save cache with matching commit hash, spec fingerprint and file checksums
+	vendor := &types.VendorSpec{Name: "test-vendor"}
+	spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{{From: "src/file.go", To: "lib/file.go"}}}
 	cache := types.IncrementalSyncCache{
 		VendorName: "test-vendor",
 		Ref:        "main",
 		CommitHash: "abc123",
+		SpecHash:   syncSpecFingerprint(vendor, spec),
 		Files: []types.FileChecksum{
 			{Path: "lib/file.go", Hash: checksum},
 		},
@@ -1951,11 +2323,28 @@ func TestCanSkipSync_MatchingCache(t *testing.T) {
 		NewFileCopyService(osFS), nil, cacheStore, NewHookService(nil),
 		&SilentUICallback{}, tempDir, nil)
 
-	mappings := []types.PathMapping{{From: "src/file.go", To: "lib/file.go"}}
-	result := syncService.canSkipSync("test-vendor", "main", "abc123", mappings)
+	result := syncService.canSkipSync(vendor, spec, "abc123")
 	if !result {
 		t.Error("expected true for fully matching cache, got false")
 	}
+
+	// Same commit, different mapping config: the cached files are stale
+	changed := spec
+	changed.Mapping = []types.PathMapping{{From: "src/file.go", To: "lib/file.go", Transforms: []types.Transform{{Pattern: "a", Replacement: "b"}}}}
+	if syncService.canSkipSync(vendor, changed, "abc123") {
+		t.Error("expected false after the mapping's transforms changed, got true")
+	}
+	spdx := *vendor
+	spdx.SPDXHeaders = true
+	if syncService.canSkipSync(&spdx, spec, "abc123") {
+		t.Error("expected false after spdx_headers was enabled, got true")
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, VendorIgnoreFile), []byte("*.go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if syncService.canSkipSync(vendor, spec, "abc123") {
+		t.Error("expected false after .vendorignore changed, got true")
+	}
 }
 
 // TestCanSkipSync_FileMissing verifies that canSkipSync returns false when
@@ -1987,7 +2376,7 @@ func TestCanSkipSync_FileMissing(t *testing.T) {
 		&SilentUICallback{}, tempDir, nil)
 
 	mappings := []types.PathMapping{{From: "src/file.go", To: "lib/missing.go"}}
-	result := syncService.canSkipSync("test-vendor", "main", "abc123", mappings)
+	result := syncService.canSkipSync(&types.VendorSpec{Name: "test-vendor"}, types.BranchSpec{Ref: "main", Mapping: mappings}, "abc123")
 	if result {
 		t.Error("expected false for missing destination file, got true")
 	}
@@ -2019,12 +2408,88 @@ func TestCanSkipSync_AutoNamedPathSkips(t *testing.T) {
 
 	// Empty "To" triggers auto-naming which can't be cache-checked
 	mappings := []types.PathMapping{{From: "src/file.go", To: ""}}
-	result := syncService.canSkipSync("test-vendor", "main", "abc123", mappings)
+	result := syncService.canSkipSync(&types.VendorSpec{Name: "test-vendor"}, types.BranchSpec{Ref: "main", Mapping: mappings}, "abc123")
 	if result {
 		t.Error("expected false for auto-named path, got true")
 	}
 }
 
+// copyCountingFS counts CopyFile and CopyDir calls made through a real filesystem.
+type copyCountingFS struct {
+	FileSystem
+	copies int
+}
+
+func (c *copyCountingFS) CopyFile(src, dst string) (CopyStats, error) {
+	c.copies++
+	return c.FileSystem.CopyFile(src, dst)
+}
+
+func (c *copyCountingFS) CopyDir(src, dst string) (CopyStats, error) {
+	c.copies++
+	return c.FileSystem.CopyDir(src, dst)
+}
+
+// TestSync_UnchangedCommitSkipsCopies verifies the incremental path end to
+// end: a second Sync at the same locked commit, with destinations untouched,
+// is served from the cache without cloning or copying, while --no-cache and a
+// deleted destination file both fall back to a full sync.
+func TestSync_UnchangedCommitSkipsCopies(t *testing.T) {
+	chdirUnmanagedTest(t)
+	commit := strings.Repeat("c", 40)
+	vendor := types.VendorSpec{Name: "lib", URL: "https://github.com/owner/lib", Specs: []types.BranchSpec{{
+		Ref: "main",
+		Mapping: []types.PathMapping{
+			{From: "src/file.go", To: "lib/file.go"},
+			{From: "src/pkg", To: "lib/pkg"},
+		},
+	}}}
+	upstream := map[string]string{"src/file.go": "package lib\n", "src/pkg/a.go": "package pkg\n", "src/pkg/b.go": "package pkg // b\n"}
+	lock := types.VendorLock{Vendors: []types.LockDetails{{Name: "lib", Ref: "main", CommitHash: commit}}}
+
+	// The git mock allows exactly one checkout: the second Sync must not clone
+	fs := &copyCountingFS{FileSystem: NewOSFileSystem()}
+	syncer := newFixTestSyncerFS(t, fs, createTestConfig(vendor), lock, commit, upstream)
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("first Sync: %v", err)
+	}
+	if fs.copies != 2 {
+		t.Fatalf("first Sync copy calls = %d, want 2", fs.copies)
+	}
+
+	fs.copies = 0
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("second Sync: %v", err)
+	}
+	if fs.copies != 0 {
+		t.Errorf("second Sync at unchanged commit made %d copy calls, want 0", fs.copies)
+	}
+
+	// --no-cache bypasses the cache at the same locked commit (--force does
+	// too, but also ignores the lock and fetches the ref)
+	bypass := &copyCountingFS{FileSystem: NewOSFileSystem()}
+	syncer = newFixTestSyncerFS(t, bypass, createTestConfig(vendor), lock, commit, upstream)
+	if err := syncer.SyncWithOptions(context.Background(), "", false, true); err != nil {
+		t.Fatalf("Sync --no-cache: %v", err)
+	}
+	if bypass.copies != 2 {
+		t.Errorf("Sync --no-cache copy calls = %d, want 2", bypass.copies)
+	}
+
+	// A deleted file under a directory destination invalidates the cache
+	if err := os.Remove("lib/pkg/b.go"); err != nil {
+		t.Fatal(err)
+	}
+	resync := &copyCountingFS{FileSystem: NewOSFileSystem()}
+	syncer = newFixTestSyncerFS(t, resync, createTestConfig(vendor), lock, commit, upstream)
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync after deletion: %v", err)
+	}
+	if data, err := os.ReadFile("lib/pkg/b.go"); err != nil || string(data) != "package pkg // b\n" {
+		t.Errorf("lib/pkg/b.go not restored: %q, %v", data, err)
+	}
+}
+
 // ============================================================================
 // SyncVendor — NoCache flag bypasses cache
 // ============================================================================
@@ -2043,7 +2508,7 @@ func TestSyncVendor_NoCache_BypassesCache(t *testing.T) {
 	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil)
 	git.EXPECT().Init(gomock.Any(), "/tmp/test-12345").Return(nil)
 	git.EXPECT().AddRemote(gomock.Any(), "/tmp/test-12345", "origin", "https://github.com/owner/repo").Return(nil)
-	git.EXPECT().Fetch(gomock.Any(), "/tmp/test-12345", "origin", 1, "main").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/test-12345", "origin", 1, "abc123def456").Return(nil)
 	git.EXPECT().Checkout(gomock.Any(), "/tmp/test-12345", "abc123def456").Return(nil)
 	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/test-12345").Return("abc123def456", nil)
 	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -2185,25 +2650,23 @@ func TestSyncVendor_LocalFlag_ResolvesURL(t *testing.T) {
 
 	// Create a real temp directory to use as the local repo source
 	sourceRepo := t.TempDir()
+	workDir := t.TempDir()
 
 	vendor := createTestVendorSpec("local-vendor", sourceRepo, "main")
 
-	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-local", nil)
-	fs.EXPECT().RemoveAll("/tmp/test-local").Return(nil)
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return(workDir, nil)
+	fs.EXPECT().RemoveAll(workDir).Return(nil)
 
-	git.EXPECT().Init(gomock.Any(), "/tmp/test-local").Return(nil)
-	// The resolved URL should be a file:// URL
-	git.EXPECT().AddRemote(gomock.Any(), "/tmp/test-local", "origin", gomock.Any()).DoAndReturn(
-		func(_ context.Context, _, _, url string) error {
-			if !strings.HasPrefix(url, "file://") {
-				t.Errorf("AddRemote URL = %q, want file:// prefix", url)
+	// The local repository is read in place: no Init, AddRemote, Fetch or Checkout
+	git.EXPECT().ResolveRef(gomock.Any(), sourceRepo, "main").Return("abc123def456", nil)
+	git.EXPECT().ExportTree(gomock.Any(), sourceRepo, "abc123def456", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, destDir string) error {
+			if !strings.HasPrefix(destDir, workDir) {
+				t.Errorf("ExportTree destDir = %q, want it under %q", destDir, workDir)
 			}
 			return nil
 		})
-	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil)
-	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("abc123def456", nil)
-	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	git.EXPECT().GetTagForCommit(gomock.Any(), sourceRepo, "abc123def456").Return("", nil).AnyTimes()
 
 	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
 	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -2211,10 +2674,90 @@ func TestSyncVendor_LocalFlag_ResolvesURL(t *testing.T) {
 
 	syncer := createMockSyncer(git, fs, config, lock, license)
 
-	_, _, err := syncer.sync.SyncVendor(context.Background(), &vendor, nil, SyncOptions{Local: true})
+	results, _, err := syncer.sync.SyncVendor(context.Background(), &vendor, nil, SyncOptions{Local: true})
 	if err != nil {
 		t.Fatalf("SyncVendor with --local flag: unexpected error: %v", err)
 	}
+	if got := results["main"].CommitHash; got != "abc123def456" {
+		t.Errorf("CommitHash = %q, want abc123def456", got)
+	}
+}
+
+func TestSyncVendor_LocalLockedCommitMissing(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	sourceRepo := t.TempDir()
+	workDir := t.TempDir()
+	vendor := createTestVendorSpec("local-vendor", sourceRepo, "main")
+
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return(workDir, nil)
+	fs.EXPECT().RemoveAll(workDir).Return(nil)
+	git.EXPECT().ResolveRef(gomock.Any(), sourceRepo, "deadbeef").Return("", fmt.Errorf("unknown revision"))
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+
+	_, _, err := syncer.sync.SyncVendor(context.Background(), &vendor, map[string]string{"main": "deadbeef"}, SyncOptions{Local: true})
+	if !IsStaleCommit(err) {
+		t.Errorf("SyncVendor error = %v, want StaleCommitError", err)
+	}
+}
+
+func TestSyncVendor_LocalRepositoryCopiesLockedTree(t *testing.T) {
+	ctx := context.Background()
+	git := NewSystemGitClient()
+	sourceRepo := t.TempDir()
+	if err := git.Init(ctx, sourceRepo); err != nil {
+		t.Fatalf("init source repo: %v", err)
+	}
+	configureGitUser(t, sourceRepo)
+	writeFixTestFile(t, filepath.Join(sourceRepo, "src", "lib.go"), "package lib // v1\n")
+	writeFixTestFile(t, filepath.Join(sourceRepo, "src", "util", "util.go"), "package util\n")
+	runGitSilent(t, sourceRepo, "add", ".")
+	runGitSilent(t, sourceRepo, "commit", "-m", "v1")
+	first, err := git.GetHeadHash(ctx, sourceRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGitSilent(t, sourceRepo, "branch", "-M", "main")
+	writeFixTestFile(t, filepath.Join(sourceRepo, "src", "lib.go"), "package lib // v2\n")
+	runGitSilent(t, sourceRepo, "commit", "-am", "v2")
+	// Uncommitted edits in the source checkout must not leak into the vendor
+	writeFixTestFile(t, filepath.Join(sourceRepo, "src", "util", "util.go"), "package util // dirty\n")
+
+	chdirUnmanagedTest(t)
+	vendor := types.VendorSpec{Name: "sibling", URL: "file://" + filepath.ToSlash(sourceRepo), Specs: []types.BranchSpec{
+		{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: "vendor/sibling"}}},
+	}}
+	syncer := NewVendorSyncer(NewFileConfigStore(VendorDir), NewFileLockStore(VendorDir), git, NewOSFileSystem(), nil, VendorDir, &SilentUICallback{}, nil)
+
+	for _, tt := range []struct {
+		name    string
+		locked  map[string]string
+		wantLib string
+	}{
+		{name: "unlocked reads the ref", wantLib: "package lib // v2\n"},
+		{name: "locked reads the commit", locked: map[string]string{"main": first}, wantLib: "package lib // v1\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			results, stats, err := syncer.sync.SyncVendor(ctx, &vendor, tt.locked, SyncOptions{Local: true, NoCache: true})
+			if err != nil {
+				t.Fatalf("SyncVendor: %v", err)
+			}
+			if stats.FileCount != 2 {
+				t.Errorf("FileCount = %d, want 2", stats.FileCount)
+			}
+			if tt.locked != nil && results["main"].CommitHash != first {
+				t.Errorf("CommitHash = %q, want %q", results["main"].CommitHash, first)
+			}
+			if data, _ := os.ReadFile(filepath.Join("vendor", "sibling", "lib.go")); string(data) != tt.wantLib {
+				t.Errorf("vendor/sibling/lib.go = %q, want %q", data, tt.wantLib)
+			}
+			if data, _ := os.ReadFile(filepath.Join("vendor", "sibling", "util", "util.go")); string(data) != "package util\n" {
+				t.Errorf("vendor/sibling/util/util.go = %q, want the committed content", data)
+			}
+		})
+	}
 }
 
 func TestSyncVendor_RemoteURL_UnaffectedByLocalFlag(t *testing.T) {
@@ -2496,3 +3039,65 @@ func TestSyncDryRun_RespectsGroupFilter(t *testing.T) {
 // ============================================================================
 // TestUpdateAll - Comprehensive tests for update orchestration
 // ============================================================================
+
+// TestSyncVendor_RenamedFileCreatesNestedDestDir verifies that a whole-file
+// mapping into a directory that doesn't exist yet gets its parents created
+// and lands under the new name, while the raw CopyFile stays strict.
+func TestSyncVendor_RenamedFileCreatesNestedDestDir(t *testing.T) {
+	chdirUnmanagedTest(t)
+	ctrl, git, _, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	osFS := NewOSFileSystem()
+	svc := NewSyncService(config, lock, git, osFS, NewFileCopyService(osFS), NewLicenseService(license, osFS, VendorDir, &SilentUICallback{}),
+		NewFileCacheStore(osFS, VendorDir), NewHookService(nil), &SilentUICallback{}, VendorDir, nil)
+
+	vendor := createTestVendorSpec("mylib", "https://github.com/owner/mylib", "main")
+	vendor.Specs[0].Mapping = []types.PathMapping{{From: "src/a.go", To: "deep/new/dir/renamed.go"}}
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/mylib").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), "main").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), "FETCH_HEAD").DoAndReturn(func(_ context.Context, dir, _ string) error {
+		writeFixTestFile(t, filepath.Join(dir, "src", "a.go"), "package a\n")
+		return nil
+	})
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return(strings.Repeat("a", 40), nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	_, stats, err := svc.SyncVendor(context.Background(), &vendor, nil, SyncOptions{})
+	if err != nil {
+		t.Fatalf("SyncVendor: %v", err)
+	}
+	if stats.FileCount != 1 {
+		t.Errorf("FileCount = %d, want 1", stats.FileCount)
+	}
+	got, err := os.ReadFile(filepath.Join("deep", "new", "dir", "renamed.go"))
+	if err != nil || string(got) != "package a\n" {
+		t.Errorf("renamed destination = %q, %v; want the source content", got, err)
+	}
+
+	// CopyFile on its own still refuses a missing parent directory
+	writeFixTestFile(t, "src.go", "package src\n")
+	if _, err := osFS.CopyFile("src.go", filepath.Join("missing", "dir", "dst.go")); err == nil {
+		t.Error("CopyFile into a missing directory should fail")
+	}
+}
+
+// TestSyncReportRecord_PartialCacheHitIsSynced verifies that a vendor is only
+// reported as skipped when every one of its specs hit the cache.
+func TestSyncReportRecord_PartialCacheHitIsSynced(t *testing.T) {
+	v := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	v.Specs = append(v.Specs, types.BranchSpec{Ref: "v2", Mapping: []types.PathMapping{{From: "b.go", To: "vendor/lib/v2/b.go"}}})
+
+	var report SyncReport
+	report.record(&v, CopyStats{FileCount: 2, CacheHits: 1}, nil)
+	report.record(&v, CopyStats{FileCount: 2, CacheHits: 2}, nil)
+
+	if got := report.Vendors[0].Status; got != SyncStatusSynced {
+		t.Errorf("partial cache hit status = %s, want %s", got, SyncStatusSynced)
+	}
+	if got := report.Vendors[1]; got.Status != SyncStatusSkipped || got.FilesCopied != 0 {
+		t.Errorf("full cache hit = %+v, want skipped with no files copied", got)
+	}
+}
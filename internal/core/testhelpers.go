@@ -106,7 +106,7 @@ func assertError(t interface {
 func newTestManager(vendorDir string) *Manager {
 	config := NewFileConfigStore(vendorDir)
 	lock := NewFileLockStore(vendorDir)
-	git := NewSystemGitClient(false) // not verbose
+	git := NewSystemGitClient()
 	fs := NewOSFileSystem()
 	license := NewGitHubLicenseChecker(nil, AllowedLicenses)
 	ui := &SilentUICallback{}
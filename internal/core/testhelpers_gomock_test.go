@@ -28,6 +28,9 @@ func (s *stubHookExecutor) ExecutePreSync(_ *types.VendorSpec, _ *types.HookCont
 func (s *stubHookExecutor) ExecutePostSync(_ *types.VendorSpec, _ *types.HookContext) error {
 	return nil
 }
+func (s *stubHookExecutor) ExecuteVendorPostSync(_ *types.VendorSpec, _ string, _ *types.HookContext) (string, error) {
+	return "", nil
+}
 
 // stubFileCopyService returns configurable CopyStats from CopyMappings.
 type stubFileCopyService struct {
@@ -49,10 +52,13 @@ func (s *stubFileCopyService) CopyMappings(_ string, _ *types.VendorSpec, spec t
 // stubLicenseService is a no-op LicenseServiceInterface for tests.
 type stubLicenseService struct{}
 
-func (s *stubLicenseService) CheckCompliance(_ string) (string, error) { return "MIT", nil }
-func (s *stubLicenseService) CopyLicense(_, _ string) error            { return nil }
-func (s *stubLicenseService) GetLicensePath(_ string) string           { return "" }
-func (s *stubLicenseService) CheckLicense(_ string) (string, error)    { return "MIT", nil }
+func (s *stubLicenseService) CheckCompliance(_ string) (string, error)      { return "MIT", nil }
+func (s *stubLicenseService) AcceptOverride(license string) (string, error) { return license, nil }
+func (s *stubLicenseService) CopyLicense(_, _, _ string, _ []string) ([]string, error) {
+	return nil, nil
+}
+func (s *stubLicenseService) GetLicensePath(_, _ string) string     { return "" }
+func (s *stubLicenseService) CheckLicense(_ string) (string, error) { return "MIT", nil }
 
 // errCacheStore wraps mockCacheStore to inject a Load error.
 type errCacheStore struct {
@@ -154,6 +160,7 @@ type capturingUICallback struct {
 	successMsg  string
 	warningMsg  string
 	confirmResp bool
+	confirmMsg  string
 	licenseMsg  string
 }
 
@@ -169,7 +176,8 @@ func (c *capturingUICallback) ShowWarning(title, message string) {
 	c.warningMsg = title + ": " + message
 }
 
-func (c *capturingUICallback) AskConfirmation(_, _ string) bool {
+func (c *capturingUICallback) AskConfirmation(title, message string) bool {
+	c.confirmMsg = title + ": " + message
 	return c.confirmResp
 }
 
@@ -0,0 +1,203 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// contentTransform is a mapping's Transforms compiled for FileCopyService,
+// plus the vendor's SPDX header when spdx_headers is set.
+type contentTransform struct {
+	patterns     []*regexp.Regexp
+	replacements []string
+	spdxLicense  string // License for the SPDX-License-Identifier header ("" = none)
+}
+
+// mappingTransform compiles mapping's transforms for vendor and adds the
+// SPDX header when vendor.SPDXHeaders is set. Returns nil when neither
+// applies.
+func mappingTransform(vendor *types.VendorSpec, mapping types.PathMapping) (*contentTransform, error) {
+	t, err := compileTransforms(mapping.Transforms)
+	if err != nil || !vendor.SPDXHeaders {
+		return t, err
+	}
+	license := ResolveVendorLicense(*vendor)
+	if license == "" {
+		return nil, fmt.Errorf("spdx_headers needs a license or license_override")
+	}
+	if t == nil {
+		t = &contentTransform{}
+	}
+	t.spdxLicense = license
+	return t, nil
+}
+
+// compileTransforms compiles transforms in order. It returns nil when there
+// are none, so callers can test for "no rewriting" with a nil check.
+func compileTransforms(transforms []types.Transform) (*contentTransform, error) {
+	if len(transforms) == 0 {
+		return nil, nil
+	}
+	t := &contentTransform{}
+	for i, tr := range transforms {
+		if tr.Pattern == "" {
+			return nil, fmt.Errorf("transforms[%d]: pattern must not be empty", i)
+		}
+		re, err := regexp.Compile(tr.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("transforms[%d]: %w", i, err)
+		}
+		t.patterns = append(t.patterns, re)
+		t.replacements = append(t.replacements, tr.Replacement)
+	}
+	return t, nil
+}
+
+// rewrite applies the transforms to the file just copied to path, then adds
+// the SPDX header, and updates stats (that copy's CopyStats) with the
+// rewritten size and hash, so the lock records the transformed content.
+// Binary files (IsBinaryContent) are left as copied. A nil contentTransform
+// does nothing.
+func (t *contentTransform) rewrite(path string, stats *CopyStats) error {
+	if t == nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	content := t.apply(path, string(data))
+	if content == string(data) {
+		return nil
+	}
+	// WriteFile truncates in place, keeping the mode CopyFile gave the file
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write transformed %s: %w", path, err)
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	stats.ByteCount += int64(len(content) - len(data))
+	if stats.FileHashes == nil {
+		stats.FileHashes = make(map[string]string, 1)
+	}
+	stats.FileHashes[filepath.ToSlash(path)] = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// apply returns content as rewrite leaves a file at path with that content:
+// transforms applied in order, then the SPDX header. Binary content and a nil
+// contentTransform return content unchanged.
+func (t *contentTransform) apply(path, content string) string {
+	if t == nil || IsBinaryContent([]byte(content)) {
+		return content
+	}
+	for i, re := range t.patterns {
+		content = re.ReplaceAllString(content, t.replacements[i])
+	}
+	if t.spdxLicense != "" {
+		content = addSPDXHeader(path, content, t.spdxLicense)
+	}
+	return content
+}
+
+// specHasTransforms reports whether any mapping of vendor's spec for ref
+// declares transforms, or vendor adds SPDX headers (LockDetails.Transformed).
+func specHasTransforms(vendor *types.VendorSpec, ref string) bool {
+	if vendor.SPDXHeaders {
+		return true
+	}
+	for _, spec := range vendor.Specs {
+		if spec.Ref != ref {
+			continue
+		}
+		for _, m := range spec.Mapping {
+			if len(m.Transforms) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spdxCommentStyles maps file extensions to the comment delimiters wrapping
+// an SPDX header line. Files with other extensions get no header.
+var spdxCommentStyles = map[string][2]string{
+	".go": {"// ", ""}, ".c": {"// ", ""}, ".h": {"// ", ""}, ".cc": {"// ", ""},
+	".cpp": {"// ", ""}, ".hpp": {"// ", ""}, ".rs": {"// ", ""}, ".java": {"// ", ""},
+	".kt": {"// ", ""}, ".swift": {"// ", ""}, ".cs": {"// ", ""}, ".scala": {"// ", ""},
+	".js": {"// ", ""}, ".jsx": {"// ", ""}, ".ts": {"// ", ""}, ".tsx": {"// ", ""},
+	".mjs": {"// ", ""}, ".dart": {"// ", ""}, ".proto": {"// ", ""}, ".zig": {"// ", ""},
+	".py": {"# ", ""}, ".sh": {"# ", ""}, ".bash": {"# ", ""}, ".rb": {"# ", ""},
+	".pl": {"# ", ""}, ".r": {"# ", ""}, ".yaml": {"# ", ""}, ".yml": {"# ", ""},
+	".toml": {"# ", ""}, ".cmake": {"# ", ""},
+	".sql": {"-- ", ""}, ".lua": {"-- ", ""}, ".hs": {"-- ", ""},
+	".css": {"/* ", " */"}, ".scss": {"/* ", " */"},
+	".html": {"<!-- ", " -->"}, ".xml": {"<!-- ", " -->"}, ".md": {"<!-- ", " -->"},
+}
+
+// spdxIdentifierTag marks an existing SPDX license header.
+const spdxIdentifierTag = "SPDX-License-Identifier:"
+
+// addSPDXHeader returns content with an SPDX-License-Identifier comment for
+// license prepended, in the comment syntax of path's extension, followed by a
+// blank line (so a Go header never becomes the package doc comment). Lines
+// that must come first stay first (see spdxPreambleEnd). Content that already
+// names an SPDX license, or whose extension has no known comment syntax, is
+// returned unchanged.
+func addSPDXHeader(path, content, license string) string {
+	style, ok := spdxCommentStyles[strings.ToLower(filepath.Ext(path))]
+	if !ok || strings.Contains(content, spdxIdentifierTag) {
+		return content
+	}
+	header := style[0] + spdxIdentifierTag + " " + license + style[1] + "\n\n"
+	end := spdxPreambleEnd(content)
+	if end == 0 {
+		return header + content
+	}
+	preamble := content[:end]
+	if !strings.HasSuffix(preamble, "\n") {
+		preamble += "\n"
+	}
+	return preamble + header + content[end:]
+}
+
+// encodingDeclaration matches a Python or Ruby source encoding comment
+// ("# -*- coding: utf-8 -*-"), which is only honored on line 1 or 2.
+var encodingDeclaration = regexp.MustCompile(`^[ \t\f]*#.*?coding[:=]`)
+
+// spdxPreambleEnd returns the offset just past the leading lines an SPDX
+// header must not precede: an XML declaration ("<?xml ...?>"), or a "#!" line
+// and/or an encoding declaration within the first two lines.
+func spdxPreambleEnd(content string) int {
+	if strings.HasPrefix(content, "<?xml") {
+		if end := strings.Index(content, "?>"); end >= 0 {
+			return lineEnd(content, end)
+		}
+		return len(content)
+	}
+	end := 0
+	for line := 1; line <= 2 && end < len(content); line++ {
+		text := content[end:lineEnd(content, end)]
+		if !(line == 1 && strings.HasPrefix(text, "#!")) && !encodingDeclaration.MatchString(text) {
+			break
+		}
+		end += len(text)
+	}
+	return end
+}
+
+// lineEnd returns the offset just past the newline ending the line that
+// contains offset i, or len(content) on the last line.
+func lineEnd(content string, i int) int {
+	if nl := strings.IndexByte(content[i:], '\n'); nl >= 0 {
+		return i + nl + 1
+	}
+	return len(content)
+}
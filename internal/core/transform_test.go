@@ -0,0 +1,135 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// transformSpecYAML rewrites the upstream module path in both mappings.
+const transformSpecYAML = `ref: main
+mapping:
+  - from: client.go
+    to: third_party/client/client.go
+    transforms:
+      - pattern: '"github\.com/upstream/client/(\w+)'
+        replacement: '"example.com/ours/third_party/client/$1'
+  - from: assets
+    to: third_party/client/assets
+    transforms:
+      - pattern: '"github\.com/upstream/client/(\w+)'
+        replacement: '"example.com/ours/third_party/client/$1'
+`
+
+func TestCopyMappings_TransformsRewriteImportPath(t *testing.T) {
+	chdirUnmanagedTest(t)
+	clone := t.TempDir()
+	writeFixTestFile(t, filepath.Join(clone, "client.go"), "package client\n\nimport (\n\t\"github.com/upstream/client/internal/wire\"\n\t\"github.com/upstream/client/auth\"\n)\n")
+	writeFixTestFile(t, filepath.Join(clone, "assets", "logo.bin"), "\"github.com/upstream/client/auth\x00\x01")
+
+	// Transforms decode through BranchSpec's multi-destination aware decoder
+	var spec types.BranchSpec
+	if err := yaml.Unmarshal([]byte(transformSpecYAML), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Mapping) != 2 || len(spec.Mapping[1].Transforms) != 1 {
+		t.Fatalf("decoded mappings = %+v, want transforms on both", spec.Mapping)
+	}
+
+	vendor := &types.VendorSpec{Name: "client", Specs: []types.BranchSpec{spec}}
+	stats, err := NewFileCopyService(NewOSFileSystem()).CopyMappings(clone, vendor, spec)
+	if err != nil {
+		t.Fatalf("CopyMappings: %v", err)
+	}
+
+	dest := filepath.Join("third_party", "client", "client.go")
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package client\n\nimport (\n\t\"example.com/ours/third_party/client/internal/wire\"\n\t\"example.com/ours/third_party/client/auth\"\n)\n"
+	if string(got) != want {
+		t.Errorf("client.go = %q, want %q", got, want)
+	}
+	// The lock must record the transformed content, which is what verify reads
+	sum := sha256.Sum256(got)
+	if hash := stats.FileHashes[filepath.ToSlash(dest)]; hash != hex.EncodeToString(sum[:]) {
+		t.Errorf("FileHashes[%s] = %s, want the transformed content's hash", dest, hash)
+	}
+
+	// Binary files are copied byte for byte
+	logo, err := os.ReadFile(filepath.Join("third_party", "client", "assets", "logo.bin"))
+	if err != nil || !strings.HasPrefix(string(logo), `"github.com/upstream/client/auth`) {
+		t.Errorf("logo.bin = %q (%v), want it untouched", logo, err)
+	}
+
+	if !specHasTransforms(vendor, "main") || specHasTransforms(vendor, "v2") {
+		t.Error("specHasTransforms should report transforms only for the main spec")
+	}
+}
+
+func TestCompileTransforms_RejectsInvalidPattern(t *testing.T) {
+	if _, err := compileTransforms([]types.Transform{{Pattern: "(unclosed"}}); err == nil || !strings.Contains(err.Error(), "transforms[0]") {
+		t.Errorf("err = %v, want a transforms[0] compile error", err)
+	}
+	if ct, err := compileTransforms(nil); ct != nil || err != nil {
+		t.Errorf("compileTransforms(nil) = %v, %v; want nil, nil", ct, err)
+	}
+}
+
+func TestCopyMappings_SPDXHeaders(t *testing.T) {
+	chdirUnmanagedTest(t)
+	clone := t.TempDir()
+	writeFixTestFile(t, filepath.Join(clone, "lib", "client.go"), "// Package client talks to the API.\npackage client\n")
+	writeFixTestFile(t, filepath.Join(clone, "lib", "tool.py"), "#!/usr/bin/env python3\nprint('hi')\n")
+	writeFixTestFile(t, filepath.Join(clone, "lib", "tagged.go"), "// SPDX-License-Identifier: Apache-2.0\n\npackage client\n")
+	writeFixTestFile(t, filepath.Join(clone, "lib", "logo.png"), "PNG\x00\x01")
+	writeFixTestFile(t, filepath.Join(clone, "lib", "encoded.py"), "#!/usr/bin/env python\n# -*- coding: latin-1 -*-\nprint('hi')\n")
+	writeFixTestFile(t, filepath.Join(clone, "lib", "coding.py"), "# coding=utf-8\nimport os\n")
+	writeFixTestFile(t, filepath.Join(clone, "lib", "pom.xml"), "<?xml version=\"1.0\"?>\n<project/>\n")
+
+	spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{{From: "lib", To: "third_party/lib"}}}
+	vendor := &types.VendorSpec{Name: "lib", License: "MIT", SPDXHeaders: true, Specs: []types.BranchSpec{spec}}
+	stats, err := NewFileCopyService(NewOSFileSystem()).CopyMappings(clone, vendor, spec)
+	if err != nil {
+		t.Fatalf("CopyMappings: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"client.go": "// SPDX-License-Identifier: MIT\n\n// Package client talks to the API.\npackage client\n",
+		"tool.py":   "#!/usr/bin/env python3\n# SPDX-License-Identifier: MIT\n\nprint('hi')\n",
+		"tagged.go": "// SPDX-License-Identifier: Apache-2.0\n\npackage client\n",
+		"logo.png":  "PNG\x00\x01",
+		// The encoding declaration must stay within lines 1-2 and the XML declaration first
+		"encoded.py": "#!/usr/bin/env python\n# -*- coding: latin-1 -*-\n# SPDX-License-Identifier: MIT\n\nprint('hi')\n",
+		"coding.py":  "# coding=utf-8\n# SPDX-License-Identifier: MIT\n\nimport os\n",
+		"pom.xml":    "<?xml version=\"1.0\"?>\n<!-- SPDX-License-Identifier: MIT -->\n\n<project/>\n",
+	} {
+		dest := filepath.Join("third_party", "lib", name)
+		got, err := os.ReadFile(dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+		// The lock must hash the content on disk, header included
+		sum := sha256.Sum256(got)
+		if hash, ok := stats.FileHashes[filepath.ToSlash(dest)]; ok && hash != hex.EncodeToString(sum[:]) {
+			t.Errorf("FileHashes[%s] = %s, want the on-disk content's hash", dest, hash)
+		}
+	}
+	if _, ok := stats.FileHashes["third_party/lib/client.go"]; !ok {
+		t.Error("FileHashes should record the header-augmented client.go")
+	}
+	if !specHasTransforms(vendor, "main") {
+		t.Error("specHasTransforms should report spdx_headers as a transform")
+	}
+}
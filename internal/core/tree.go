@@ -0,0 +1,96 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// Tree builds the destination layout of every config mapping; see BuildVendorTree.
+func (s *VendorSyncer) Tree() (*types.VendorTreeNode, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	return BuildVendorTree(config), nil
+}
+
+// BuildVendorTree arranges config mapping destinations into a tree rooted at
+// the project root (Path "."). Auto-named destinations are resolved as sync
+// resolves them, and position specifiers are dropped, so several snippets
+// placed into one file share its node. Destinations outside the project
+// root (absolute or "..") are left out. Children are sorted by name.
+//
+// A node is a conflict when mappings from two vendors write it, or when it
+// lies inside (or contains) another vendor's destination, matching the
+// same-path and nested-path checks of DetectConflicts.
+func BuildVendorTree(config types.VendorConfig) *types.VendorTreeNode {
+	root := &types.VendorTreeNode{Name: ".", Path: "."}
+	nodes := map[string]*types.VendorTreeNode{".": root}
+
+	var node func(path string) *types.VendorTreeNode
+	node = func(path string) *types.VendorTreeNode {
+		if n, ok := nodes[path]; ok {
+			return n
+		}
+		parent := node(filepath.ToSlash(filepath.Dir(filepath.FromSlash(path))))
+		n := &types.VendorTreeNode{Name: filepath.Base(filepath.FromSlash(path)), Path: path}
+		parent.Children = append(parent.Children, n)
+		nodes[path] = n
+		return n
+	}
+
+	for i := range config.Vendors {
+		v := &config.Vendors[i]
+		for _, spec := range v.Specs {
+			for _, mapping := range spec.Mapping {
+				dest := mappingDestFile(v, spec, mapping)
+				if dest == "." || dest == ".." || strings.HasPrefix(dest, "../") || filepath.IsAbs(filepath.FromSlash(dest)) {
+					continue
+				}
+				n := node(dest)
+				n.Owners = append(n.Owners, types.VendorTreeOwner{Vendor: v.Name, Ref: spec.Ref, From: mapping.From})
+			}
+		}
+	}
+
+	markTreeConflicts(root, nil)
+	sortTree(root)
+	return root
+}
+
+// markTreeConflicts flags conflicting nodes at and below n. ancestors are
+// the owned nodes on the path from the root to n.
+func markTreeConflicts(n *types.VendorTreeNode, ancestors []*types.VendorTreeNode) {
+	if len(n.Owners) > 0 {
+		vendors := make(map[string]bool)
+		for _, o := range n.Owners {
+			vendors[o.Vendor] = true
+		}
+		if len(vendors) > 1 {
+			n.Conflict = true
+		}
+		for _, a := range ancestors {
+			for _, o := range a.Owners {
+				if !vendors[o.Vendor] {
+					n.Conflict, a.Conflict = true, true
+				}
+			}
+		}
+		ancestors = append(ancestors, n)
+	}
+	for _, child := range n.Children {
+		markTreeConflicts(child, ancestors)
+	}
+}
+
+// sortTree orders every node's children by name.
+func sortTree(n *types.VendorTreeNode) {
+	sort.Slice(n.Children, func(i, j int) bool { return n.Children[i].Name < n.Children[j].Name })
+	for _, child := range n.Children {
+		sortTree(child)
+	}
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestBuildVendorTree_TwoVendorsUnderLib(t *testing.T) {
+	config := types.VendorConfig{Vendors: []types.VendorSpec{
+		{Name: "vendor-b", Specs: []types.BranchSpec{{Ref: "v1.0", Mapping: []types.PathMapping{{From: "src", To: "lib/b"}}}}},
+		{Name: "vendor-a", Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "pkg", To: "lib/a"}}}}},
+	}}
+
+	root := BuildVendorTree(config)
+	if root.Path != "." || len(root.Children) != 1 {
+		t.Fatalf("root = %+v, want one child", root)
+	}
+	lib := root.Children[0]
+	if lib.Path != "lib" || len(lib.Owners) != 0 || lib.Conflict {
+		t.Fatalf("lib node = %+v, want an unowned, conflict-free directory", lib)
+	}
+	if len(lib.Children) != 2 {
+		t.Fatalf("lib children = %d, want 2", len(lib.Children))
+	}
+
+	want := []struct{ path, vendor, ref string }{
+		{"lib/a", "vendor-a", "main"},
+		{"lib/b", "vendor-b", "v1.0"},
+	}
+	for i, w := range want {
+		n := lib.Children[i]
+		if n.Path != w.path || len(n.Owners) != 1 || n.Owners[0].Vendor != w.vendor || n.Owners[0].Ref != w.ref {
+			t.Errorf("lib.Children[%d] = %+v, want %s owned by %s@%s", i, n, w.path, w.vendor, w.ref)
+		}
+		if n.Conflict {
+			t.Errorf("%s flagged as a conflict", n.Path)
+		}
+	}
+}
+
+func TestBuildVendorTree_FlagsConflicts(t *testing.T) {
+	config := types.VendorConfig{Vendors: []types.VendorSpec{
+		// vendor-b writes inside vendor-a's lib directory
+		{Name: "vendor-a", Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{
+			{From: "src", To: "lib"},
+			{From: "util.go", To: "pkg/util.go"},
+		}}}},
+		{Name: "vendor-b", Specs: []types.BranchSpec{{Ref: "main", DefaultTarget: "pkg", Mapping: []types.PathMapping{
+			{From: "b", To: "lib/b"},
+			// Auto-named from the source basename: same file as vendor-a's
+			{From: "other/util.go", To: ""},
+		}}}},
+	}}
+
+	nodes := make(map[string]*types.VendorTreeNode)
+	var walk func(n *types.VendorTreeNode)
+	walk = func(n *types.VendorTreeNode) {
+		nodes[n.Path] = n
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	walk(BuildVendorTree(config))
+
+	for path, conflict := range map[string]bool{"lib": true, "lib/b": true, "pkg": false, "pkg/util.go": true} {
+		n, ok := nodes[path]
+		if !ok {
+			t.Errorf("missing node %s", path)
+			continue
+		}
+		if n.Conflict != conflict {
+			t.Errorf("%s conflict = %v, want %v", path, n.Conflict, conflict)
+		}
+	}
+	if owners := nodes["pkg/util.go"].Owners; len(owners) != 2 {
+		t.Errorf("pkg/util.go owners = %+v, want both vendors", owners)
+	}
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// diffContextLines is the number of unchanged lines kept around each change
+// in a hunk, matching the default of diff -u and git diff.
+const diffContextLines = 3
+
+// maxDiffCells caps the LCS table built for the changed middle of two files.
+// Beyond it, the middle is reported as one replacement instead of a minimal
+// edit script, keeping memory bounded for large rewritten files.
+const maxDiffCells = 4_000_000
+
+// diffOp is one line of an edit script: ' ' kept, '-' removed, '+' added.
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// splitDiffLines splits content into lines, ignoring a single trailing newline
+// so that "a\n" and "a" both hold one line.
+func splitDiffLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// diffLines computes a line edit script turning a into b. The common prefix and
+// suffix are matched directly; the remainder uses an LCS table (see maxDiffCells).
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// diffMiddle produces a minimal edit script for a and b via a full LCS table,
+// or a plain remove-all/add-all when the table would exceed maxDiffCells.
+func diffMiddle(a, b []string) []diffOp {
+	var ops []diffOp
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range b {
+			ops = append(ops, diffOp{'+', line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] = LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// computeDiffHunks returns the unified-diff hunks between original and current,
+// each with up to diffContextLines of surrounding context. Hunks whose context
+// would touch are merged. Identical inputs produce no hunks.
+func computeDiffHunks(original, current string) []types.DiffHunk {
+	ops := diffLines(splitDiffLines(original), splitDiffLines(current))
+
+	// Line numbers (1-based) of each op in the old and new file
+	oldLine := make([]int, len(ops))
+	newLine := make([]int, len(ops))
+	o, n := 1, 1
+	for k, op := range ops {
+		oldLine[k], newLine[k] = o, n
+		if op.kind != '+' {
+			o++
+		}
+		if op.kind != '-' {
+			n++
+		}
+	}
+
+	var hunks []types.DiffHunk
+	for k := 0; k < len(ops); {
+		if ops[k].kind == ' ' {
+			k++
+			continue
+		}
+
+		start := k - diffContextLines
+		if start < 0 {
+			start = 0
+		}
+		// Extend end while the next change is within 2*context unchanged lines
+		end := k
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContextLines {
+				end += min(diffContextLines, run-end)
+				break
+			}
+			end = run
+		}
+
+		hunk := types.DiffHunk{OldStart: oldLine[start], NewStart: newLine[start]}
+		for _, op := range ops[start:end] {
+			hunk.Lines = append(hunk.Lines, string(op.kind)+op.text)
+			if op.kind != '+' {
+				hunk.OldLines++
+			}
+			if op.kind != '-' {
+				hunk.NewLines++
+			}
+		}
+		// Empty side starts at the line before, as in diff -u
+		if hunk.OldLines == 0 {
+			hunk.OldStart--
+		}
+		if hunk.NewLines == 0 {
+			hunk.NewStart--
+		}
+		hunks = append(hunks, hunk)
+		k = end
+	}
+	return hunks
+}
+
+// formatUnifiedDiff renders hunks as a unified diff with a/ and b/ headers,
+// labelling each side (e.g. "locked", "local").
+func formatUnifiedDiff(path, oldLabel, newLabel string, hunks []types.DiffHunk) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- a/%s (%s)\n", path, oldLabel))
+	sb.WriteString(fmt.Sprintf("+++ b/%s (%s)\n", path, newLabel))
+	for _, h := range hunks {
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines))
+		for _, line := range h.Lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	original := "line1\nline2\nline3"
+	current := "line1\nmodified\nline3"
+
+	output := formatUnifiedDiff("test.go", "locked", "local", computeDiffHunks(original, current))
+
+	want := "--- a/test.go (locked)\n" +
+		"+++ b/test.go (local)\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" line1\n" +
+		"-line2\n" +
+		"+modified\n" +
+		" line3\n"
+	if output != want {
+		t.Errorf("formatUnifiedDiff() =\n%s\nwant\n%s", output, want)
+	}
+}
+
+func TestComputeDiffHunks(t *testing.T) {
+	// numbered returns lines "1".."n", each newline-terminated
+	numbered := func(n int) []string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = fmt.Sprint(i + 1)
+		}
+		return lines
+	}
+	join := func(lines []string) string { return strings.Join(lines, "\n") + "\n" }
+	replace := func(lines []string, idx int, text string) []string {
+		out := append([]string(nil), lines...)
+		out[idx] = text
+		return out
+	}
+
+	base := numbered(20)
+
+	tests := []struct {
+		name    string
+		current string
+		want    []types.DiffHunk
+	}{
+		{
+			name:    "identical",
+			current: join(base),
+		},
+		{
+			name:    "change in the middle keeps three context lines",
+			current: join(replace(base, 9, "ten")),
+			want: []types.DiffHunk{{OldStart: 7, OldLines: 7, NewStart: 7, NewLines: 7,
+				Lines: []string{" 7", " 8", " 9", "-10", "+ten", " 11", " 12", " 13"}}},
+		},
+		{
+			name:    "nearby changes merge into one hunk",
+			current: join(replace(replace(base, 4, "five"), 10, "eleven")),
+			want:    []types.DiffHunk{{OldStart: 2, OldLines: 13, NewStart: 2, NewLines: 13}},
+		},
+		{
+			name:    "distant changes stay separate",
+			current: join(replace(replace(base, 0, "one"), 19, "twenty")),
+			want: []types.DiffHunk{
+				{OldStart: 1, OldLines: 4, NewStart: 1, NewLines: 4},
+				{OldStart: 17, OldLines: 4, NewStart: 17, NewLines: 4},
+			},
+		},
+		{
+			name:    "pure insertion at end",
+			current: join(append(append([]string(nil), base...), "21")),
+			want: []types.DiffHunk{{OldStart: 18, OldLines: 3, NewStart: 18, NewLines: 4,
+				Lines: []string{" 18", " 19", " 20", "+21"}}},
+		},
+		{
+			name:    "everything deleted",
+			current: "",
+			want:    []types.DiffHunk{{OldStart: 1, OldLines: 20, NewStart: 0, NewLines: 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeDiffHunks(join(base), tt.current)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d hunks %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				g := got[i]
+				if g.OldStart != want.OldStart || g.OldLines != want.OldLines || g.NewStart != want.NewStart || g.NewLines != want.NewLines {
+					t.Errorf("hunk %d = @@ -%d,%d +%d,%d @@, want @@ -%d,%d +%d,%d @@", i,
+						g.OldStart, g.OldLines, g.NewStart, g.NewLines,
+						want.OldStart, want.OldLines, want.NewStart, want.NewLines)
+				}
+				if want.Lines != nil && strings.Join(g.Lines, "|") != strings.Join(want.Lines, "|") {
+					t.Errorf("hunk %d lines = %q, want %q", i, g.Lines, want.Lines)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// managedPaths records every destination path produced by vendor mappings.
+// files holds exact file destinations; dirs holds directory destinations whose
+// entire subtree is considered managed.
+type managedPaths struct {
+	files map[string]bool
+	dirs  []string
+}
+
+// FindUnmanagedFiles walks root and returns files that are not produced by any
+// vendor mapping. Unlike verify's "added" check (which only scans per-vendor
+// destination directories), FindUnmanagedFiles scans the whole vendor root, so
+// stray manual copies and leftovers from removed mappings are reported too.
+//
+// When root is empty, FindUnmanagedFiles scans the directory holding each
+// mapping destination (see destinationRoots) instead of one shared root. An
+// absolute root inside the working directory is made relative so it matches
+// the relative destinations. Expected non-mapping files are never reported:
+// anything under the vendor directory (.git-vendor/ or --config), .git
+// directories, and standard license files (LicenseFileNames). Returned paths
+// use forward slashes and are sorted.
+func (s *VendorSyncer) FindUnmanagedFiles(root string) ([]string, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("FindUnmanagedFiles: load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("FindUnmanagedFiles: load lock: %w", err)
+	}
+	return findUnmanagedFiles(root, s.rootDir, config, lock)
+}
+
+// findUnmanagedFiles implements FindUnmanagedFiles for an already-loaded
+// config and lock, skipping vendorDir.
+func findUnmanagedFiles(root, vendorDir string, config types.VendorConfig, lock types.VendorLock) ([]string, error) {
+	managed := collectManagedPaths(config, lock)
+	vendorDir = filepath.ToSlash(filepath.Clean(vendorDir))
+
+	roots := []string{root}
+	if root == "" {
+		roots = destinationRoots(managed, vendorDir)
+	}
+
+	var unmanaged []string
+	for _, scanRoot := range roots {
+		found, err := walkUnmanaged(relativeScanRoot(scanRoot), vendorDir, managed)
+		if err != nil {
+			return nil, err
+		}
+		unmanaged = append(unmanaged, found...)
+	}
+
+	sort.Strings(unmanaged)
+	return unmanaged, nil
+}
+
+// walkUnmanaged returns the files under root that managed does not cover.
+func walkUnmanaged(root, vendorDir string, managed managedPaths) ([]string, error) {
+	var unmanaged []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("access %s: %w", path, err)
+		}
+		rel := filepath.ToSlash(filepath.Clean(path))
+		if d.IsDir() {
+			if d.Name() == ".git" || rel == vendorDir || strings.HasPrefix(rel, vendorDir+"/") {
+				return filepath.SkipDir
+			}
+			if managed.coversDir(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if managed.files[rel] || managed.coversDir(rel) || isLicenseFileName(d.Name()) {
+			return nil
+		}
+		unmanaged = append(unmanaged, rel)
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("findUnmanagedFiles: walk %s: %w", root, err)
+	}
+	return unmanaged, nil
+}
+
+// relativeScanRoot cleans root and, when it is an absolute path inside the
+// working directory, makes it relative like the managed destinations.
+func relativeScanRoot(root string) string {
+	root = filepath.Clean(root)
+	if !filepath.IsAbs(root) {
+		return root
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return root
+	}
+	base, target := wd, root
+	// Compare resolved paths so a symlinked temp or home dir still matches
+	if resolved, err := filepath.EvalSymlinks(wd); err == nil {
+		base = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		target = resolved
+	}
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return root
+	}
+	return rel
+}
+
+// collectManagedPaths gathers mapping destinations from config plus every
+// destination recorded in the lockfile (file hashes, positions, license copies).
+func collectManagedPaths(config types.VendorConfig, lock types.VendorLock) managedPaths {
+	managed := managedPaths{files: make(map[string]bool)}
+
+	for _, vendor := range config.Vendors {
+		for _, spec := range vendor.Specs {
+			for _, mapping := range spec.Mapping {
+				destPath := mapping.To
+				if destPath == "" || destPath == "." {
+					srcFile, _, err := types.ParsePathPosition(mapping.From)
+					if err != nil {
+						srcFile = mapping.From
+					}
+					destPath = ComputeAutoPath(srcFile, spec.DefaultTarget, vendor.Name)
+				}
+				destFile, _, err := types.ParsePathPosition(destPath)
+				if err != nil {
+					destFile = destPath
+				}
+				destFile = filepath.ToSlash(filepath.Clean(destFile))
+
+				if info, statErr := os.Stat(destFile); statErr == nil && info.IsDir() {
+					managed.dirs = append(managed.dirs, destFile)
+				} else {
+					managed.files[destFile] = true
+				}
+			}
+		}
+	}
+
+	for i := range lock.Vendors {
+		entry := &lock.Vendors[i]
+		for path := range entry.FileHashes {
+			managed.files[filepath.ToSlash(filepath.Clean(path))] = true
+		}
+		for _, pos := range entry.Positions {
+			destFile, _, err := types.ParsePathPosition(pos.To)
+			if err != nil {
+				continue
+			}
+			managed.files[filepath.ToSlash(filepath.Clean(destFile))] = true
+		}
+		if entry.LicensePath != "" {
+			managed.files[filepath.ToSlash(filepath.Clean(entry.LicensePath))] = true
+		}
+	}
+
+	return managed
+}
+
+// coversDir reports whether path equals or lies under a managed directory destination.
+func (m managedPaths) coversDir(path string) bool {
+	for _, dir := range m.dirs {
+		if dir == "." || path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// destinationRoots returns the directories to scan when no root is given:
+// the parent of every managed destination outside vendorDir, minus those
+// nested in another root. Destinations at the project root add no root, so
+// mappings in unrelated trees never widen the scan to the whole project.
+func destinationRoots(managed managedPaths, vendorDir string) []string {
+	parents := map[string]bool{}
+	for path := range managed.files {
+		if strings.HasPrefix(path, vendorDir+"/") {
+			continue // License copies live under vendorDir and never define a root
+		}
+		parents[filepath.ToSlash(filepath.Dir(path))] = true
+	}
+	for _, dir := range managed.dirs {
+		parents[filepath.ToSlash(filepath.Dir(dir))] = true
+	}
+	delete(parents, ".")
+
+	sorted := make([]string, 0, len(parents))
+	for p := range parents {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var roots []string
+	for _, p := range sorted {
+		nested := false
+		for _, root := range roots {
+			if strings.HasPrefix(p, root+"/") {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			roots = append(roots, p)
+		}
+	}
+	return roots
+}
+
+// isLicenseFileName reports whether name is one of the standard license filenames.
+func isLicenseFileName(name string) bool {
+	for _, lf := range LicenseFileNames {
+		if strings.EqualFold(name, lf) {
+			return true
+		}
+	}
+	return false
+}
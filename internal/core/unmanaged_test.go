@@ -0,0 +1,235 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// ============================================================================
+// FindUnmanagedFiles Tests - pull --report-unmanaged
+// ============================================================================
+
+// chdirUnmanagedTest switches into a fresh temp dir so relative mapping
+// destinations resolve against it, restoring the original working dir on cleanup.
+func chdirUnmanagedTest(t *testing.T) string {
+	t.Helper()
+	workDir := t.TempDir()
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	return workDir
+}
+
+func writeUnmanagedTestFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func unmanagedTestConfig() types.VendorConfig {
+	return types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name: "lib-a",
+				URL:  "https://github.com/owner/lib-a",
+				Specs: []types.BranchSpec{
+					{
+						Ref: "main",
+						Mapping: []types.PathMapping{
+							{From: "src", To: "vendor/lib-a"},
+							{From: "util.go", To: "vendor/shared/util.go"},
+							{From: "api.go:L1-L10", To: "vendor/shared/api.go:L5-L14"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFindUnmanagedFiles_ReportsStrayFile(t *testing.T) {
+	chdirUnmanagedTest(t)
+
+	writeUnmanagedTestFile(t, "vendor/lib-a/a.go")
+	writeUnmanagedTestFile(t, "vendor/lib-a/nested/b.go")
+	writeUnmanagedTestFile(t, "vendor/shared/util.go")
+	writeUnmanagedTestFile(t, "vendor/shared/api.go")
+	writeUnmanagedTestFile(t, "vendor/stray.go")
+	writeUnmanagedTestFile(t, "vendor/old-lib/leftover.go")
+
+	unmanaged, err := findUnmanagedFiles("vendor", VendorDir, unmanagedTestConfig(), types.VendorLock{})
+	if err != nil {
+		t.Fatalf("findUnmanagedFiles returned error: %v", err)
+	}
+
+	want := []string{"vendor/old-lib/leftover.go", "vendor/stray.go"}
+	if len(unmanaged) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, unmanaged)
+	}
+	for i := range want {
+		if unmanaged[i] != want[i] {
+			t.Errorf("unmanaged[%d] = %q, want %q", i, unmanaged[i], want[i])
+		}
+	}
+}
+
+func TestFindUnmanagedFiles_AllMappedFilesManaged(t *testing.T) {
+	chdirUnmanagedTest(t)
+
+	writeUnmanagedTestFile(t, "vendor/lib-a/a.go")
+	writeUnmanagedTestFile(t, "vendor/shared/util.go")
+	writeUnmanagedTestFile(t, "vendor/shared/api.go")
+	writeUnmanagedTestFile(t, "vendor/shared/generated.go")
+	writeUnmanagedTestFile(t, "vendor/LICENSE")
+
+	lock := types.VendorLock{
+		Vendors: []types.LockDetails{
+			{
+				Name:       "lib-a",
+				Ref:        "main",
+				FileHashes: map[string]string{"vendor/shared/generated.go": "abc"},
+			},
+		},
+	}
+
+	unmanaged, err := findUnmanagedFiles("vendor", VendorDir, unmanagedTestConfig(), lock)
+	if err != nil {
+		t.Fatalf("findUnmanagedFiles returned error: %v", err)
+	}
+	if len(unmanaged) != 0 {
+		t.Errorf("Expected no unmanaged files, got %v", unmanaged)
+	}
+}
+
+func TestFindUnmanagedFiles_DefaultRootIsCommonParent(t *testing.T) {
+	chdirUnmanagedTest(t)
+
+	writeUnmanagedTestFile(t, "vendor/lib-a/a.go")
+	writeUnmanagedTestFile(t, "vendor/shared/util.go")
+	writeUnmanagedTestFile(t, "vendor/stray.go")
+	writeUnmanagedTestFile(t, "cmd/main.go") // Outside the vendor root
+	writeUnmanagedTestFile(t, filepath.Join(VendorDir, LicensesDir, "lib-a.txt"))
+
+	unmanaged, err := findUnmanagedFiles("", VendorDir, unmanagedTestConfig(), types.VendorLock{})
+	if err != nil {
+		t.Fatalf("findUnmanagedFiles returned error: %v", err)
+	}
+	if len(unmanaged) != 1 || unmanaged[0] != "vendor/stray.go" {
+		t.Errorf("Expected [vendor/stray.go], got %v", unmanaged)
+	}
+}
+
+func TestFindUnmanagedFiles_DefaultRootsScanEachDestinationTree(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+
+	config := unmanagedTestConfig()
+	config.Vendors[0].Specs[0].Mapping = append(config.Vendors[0].Specs[0].Mapping,
+		types.PathMapping{From: "tool.go", To: "third_party/tool/tool.go"},
+		types.PathMapping{From: "README.md", To: "README.vendor.md"})
+	writeUnmanagedTestFile(t, "vendor/shared/util.go")
+	writeUnmanagedTestFile(t, "vendor/stray.go")
+	writeUnmanagedTestFile(t, "third_party/tool/tool.go")
+	writeUnmanagedTestFile(t, "third_party/tool/extra.go")
+	writeUnmanagedTestFile(t, "README.vendor.md")
+	writeUnmanagedTestFile(t, "cmd/main.go") // Between the trees, not under either
+
+	unmanaged, err := findUnmanagedFiles("", VendorDir, config, types.VendorLock{})
+	if err != nil {
+		t.Fatalf("findUnmanagedFiles returned error: %v", err)
+	}
+	if len(unmanaged) != 2 || unmanaged[0] != "third_party/tool/extra.go" || unmanaged[1] != "vendor/stray.go" {
+		t.Errorf("Expected [third_party/tool/extra.go vendor/stray.go], got %v", unmanaged)
+	}
+
+	// An absolute root is reported relative to the project like the default
+	unmanaged, err = findUnmanagedFiles(filepath.Join(workDir, "vendor"), VendorDir, config, types.VendorLock{})
+	if err != nil {
+		t.Fatalf("findUnmanagedFiles returned error: %v", err)
+	}
+	if len(unmanaged) != 1 || unmanaged[0] != "vendor/stray.go" {
+		t.Errorf("Expected [vendor/stray.go] for an absolute root, got %v", unmanaged)
+	}
+}
+
+func TestFindUnmanagedFiles_SkipsVendorDirAtProjectRoot(t *testing.T) {
+	chdirUnmanagedTest(t)
+
+	writeUnmanagedTestFile(t, filepath.Join(VendorDir, ConfigFile))
+	writeUnmanagedTestFile(t, ".git/HEAD")
+	writeUnmanagedTestFile(t, "vendor/shared/util.go")
+	writeUnmanagedTestFile(t, "notes.txt")
+
+	unmanaged, err := findUnmanagedFiles(".", VendorDir, unmanagedTestConfig(), types.VendorLock{})
+	if err != nil {
+		t.Fatalf("findUnmanagedFiles returned error: %v", err)
+	}
+	if len(unmanaged) != 1 || unmanaged[0] != "notes.txt" {
+		t.Errorf("Expected [notes.txt], got %v", unmanaged)
+	}
+}
+
+func TestFindUnmanagedFiles_SkipsConfiguredVendorDir(t *testing.T) {
+	chdirUnmanagedTest(t)
+
+	writeUnmanagedTestFile(t, "vendor2/vendor.yml")
+	writeUnmanagedTestFile(t, "vendor/shared/util.go")
+	writeUnmanagedTestFile(t, "notes.txt")
+
+	unmanaged, err := findUnmanagedFiles(".", "vendor2", unmanagedTestConfig(), types.VendorLock{})
+	if err != nil {
+		t.Fatalf("findUnmanagedFiles returned error: %v", err)
+	}
+	if len(unmanaged) != 1 || unmanaged[0] != "notes.txt" {
+		t.Errorf("Expected [notes.txt], got %v", unmanaged)
+	}
+}
+
+func TestPullVendors_ReportUnmanaged(t *testing.T) {
+	env := setupPullTestEnv(t)
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(env.configDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	env.writeConfig(createTestConfig(vendor))
+	writeUnmanagedTestFile(t, "lib/file.go")
+	writeUnmanagedTestFile(t, "lib/manual-copy.go")
+	// lib/file.go is as last synced, so pull has no local edits to confirm
+	lock := testLock()
+	hash, err := fileSHA256("lib/file.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock.Vendors[0].FileHashes["lib/file.go"] = hash
+	env.writeLock(lock)
+
+	result, err := env.syncer.PullVendors(context.Background(), PullOptions{
+		Locked:          true,
+		ReportUnmanaged: true,
+		UnmanagedRoot:   "lib",
+	})
+	if err != nil {
+		t.Fatalf("PullVendors returned error: %v", err)
+	}
+	if len(result.Unmanaged) != 1 || result.Unmanaged[0] != "lib/manual-copy.go" {
+		t.Errorf("Expected Unmanaged=[lib/manual-copy.go], got %v", result.Unmanaged)
+	}
+}
@@ -24,6 +24,7 @@ type UpdateChecker struct {
 	gitClient   GitClient
 	fs          FileSystem
 	ui          UICallback
+	logger      *loggerSlot // Set by NewVendorSyncer; nil = no logging
 }
 
 // NewUpdateChecker creates a new UpdateChecker
@@ -124,7 +125,7 @@ func (c *UpdateChecker) fetchLatestHash(ctx context.Context, urls []string, ref
 	}
 
 	// Fetch the specific ref with depth 1 via FetchWithFallback (handles AddRemote + mirrors)
-	if _, err := FetchWithFallback(ctx, c.gitClient, c.fs, c.ui, tempDir, urls, ref, 1); err != nil {
+	if _, err := FetchWithFallback(ctx, c.gitClient, c.fs, c.ui, c.logger, tempDir, urls, ref, 1); err != nil {
 		return "", fmt.Errorf("git fetch failed: %w", err)
 	}
 
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// UpdatePlan previews an update: for every selected vendor@ref, the locked
+// commit and the upstream commit an update would lock instead.
+type UpdatePlan struct {
+	Entries []UpdatePlanEntry `json:"entries"`
+}
+
+// UpdatePlanEntry is one vendor@ref of an UpdatePlan.
+type UpdatePlanEntry struct {
+	Vendor string `json:"vendor"`
+	Ref    string `json:"ref"`
+	// CurrentHash is the locked commit (empty = not in the lock yet).
+	CurrentHash string `json:"current_hash,omitempty"`
+	// LatestHash is the commit the ref resolves to upstream.
+	LatestHash  string `json:"latest_hash,omitempty"`
+	WouldUpdate bool   `json:"would_update"`
+	// Pinned marks a spec an update leaves alone (see IsPinned).
+	Pinned bool `json:"pinned,omitempty"`
+	// Error is why the upstream commit could not be resolved.
+	Error string `json:"error,omitempty"`
+}
+
+// Updates returns the entries whose lock an update would move.
+func (p *UpdatePlan) Updates() []UpdatePlanEntry {
+	var moved []UpdatePlanEntry
+	for _, e := range p.Entries {
+		if e.WouldUpdate {
+			moved = append(moved, e)
+		}
+	}
+	return moved
+}
+
+// UpdateDryRun reports what UpdateAllWithOptions would lock without fetching,
+// copying, or saving the lock: each selected spec's ref is resolved with
+// ls-remote against the vendor URL and its mirrors and compared to its lock
+// entry. Full commit hashes resolve to themselves. Internal vendors are
+// skipped and pinned vendors are listed but never moved unless
+// opts.IncludePinned. A ref that fails to resolve is recorded on its entry
+// rather than failing the preview.
+func (s *VendorSyncer) UpdateDryRun(ctx context.Context, opts UpdateOptions) (*UpdatePlan, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	if opts.VendorName != "" {
+		if err := ValidateVendorFilter(config, opts.VendorName); err != nil {
+			return nil, err
+		}
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+	if len(config.RefAliases) > 0 {
+		config = ApplyRefAliases(config, GetHostBranch())
+	}
+
+	plan := &UpdatePlan{Entries: []UpdatePlanEntry{}}
+	for _, v := range (&UpdateService{}).filterVendors(config.Vendors, opts) {
+		if v.Source == SourceInternal {
+			continue
+		}
+		urls, err := s.localGatedURLs(&v, opts.Local)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range v.Specs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			pinned := IsPinned(spec) && !opts.IncludePinned
+			entry := UpdatePlanEntry{Vendor: v.Name, Ref: spec.Ref, Pinned: pinned}
+			if locked := findLockEntry(lock, v.Name, spec.Ref); locked != nil {
+				entry.CurrentHash = locked.CommitHash
+			}
+			if pinned {
+				plan.Entries = append(plan.Entries, entry)
+				continue
+			}
+			if isFullCommitHash(spec.Ref) {
+				entry.LatestHash = spec.Ref
+			} else if entry.LatestHash, err = lsRemoteWithFallback(ctx, s.gitClient, urls, spec.Ref); err != nil {
+				entry.Error = err.Error()
+			}
+			entry.WouldUpdate = entry.LatestHash != "" && !strings.EqualFold(entry.LatestHash, entry.CurrentHash)
+			plan.Entries = append(plan.Entries, entry)
+		}
+	}
+	return plan, nil
+}
+
+// localGatedURLs returns v's URL and mirrors as UpdateDryRun and add's source
+// check query them, applying the same --local gating as SyncVendor.
+func (s *VendorSyncer) localGatedURLs(v *types.VendorSpec, local bool) ([]string, error) {
+	urls := ResolveVendorURLs(v)
+	for i, u := range urls {
+		if !IsLocalPath(u) {
+			continue
+		}
+		if !local {
+			return nil, fmt.Errorf("vendor %s uses a local path (%s); pass --local to allow local filesystem access", v.Name, u)
+		}
+		resolved, err := ResolveLocalURL(u, s.rootDir)
+		if err != nil {
+			return nil, fmt.Errorf("resolve local URL for %s: %w", v.Name, err)
+		}
+		urls[i] = resolved
+	}
+	return urls, nil
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+func TestUpdateDryRun_ReportsMovedVendorsWithoutSavingLock(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	oldHash, newHash := strings.Repeat("a", 40), strings.Repeat("b", 40)
+	current := strings.Repeat("c", 40)
+	pinnedSpec := createTestVendorSpec("pinned", "https://github.com/owner/pinned", "main")
+	pinnedSpec.Specs[0].Pinned = true
+	config.EXPECT().Load().Return(createTestConfig(
+		createTestVendorSpec("moved", "https://github.com/owner/moved", "main"),
+		createTestVendorSpec("current", "https://github.com/owner/current", "main"),
+		createTestVendorSpec("fresh", "https://github.com/owner/fresh", "main"),
+		createTestVendorSpec("broken", "https://github.com/owner/broken", "main"),
+		pinnedSpec,
+	), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "moved", Ref: "main", CommitHash: oldHash},
+		{Name: "current", Ref: "main", CommitHash: current},
+		{Name: "broken", Ref: "main", CommitHash: oldHash},
+		{Name: "pinned", Ref: "main", CommitHash: oldHash},
+	}}, nil)
+	lock.EXPECT().Save(gomock.Any()).Times(0)
+
+	git.EXPECT().LsRemote(gomock.Any(), "https://github.com/owner/moved", "main").Return(newHash, nil)
+	git.EXPECT().LsRemote(gomock.Any(), "https://github.com/owner/current", "main").Return(current, nil)
+	git.EXPECT().LsRemote(gomock.Any(), "https://github.com/owner/fresh", "main").Return(newHash, nil)
+	git.EXPECT().LsRemote(gomock.Any(), "https://github.com/owner/broken", "main").Return("", errors.New("repository not found"))
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	plan, err := syncer.UpdateDryRun(context.Background(), UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateDryRun: %v", err)
+	}
+
+	var moved []string
+	for _, e := range plan.Updates() {
+		moved = append(moved, e.Vendor+":"+shortHash(e.CurrentHash)+"->"+shortHash(e.LatestHash))
+	}
+	if got, want := strings.Join(moved, ","), "moved:aaaaaaa->bbbbbbb,fresh:->bbbbbbb"; got != want {
+		t.Errorf("would update = %s, want %s", got, want)
+	}
+	if len(plan.Entries) != 5 {
+		t.Fatalf("entries = %d, want 5: %+v", len(plan.Entries), plan.Entries)
+	}
+	if e := plan.Entries[3]; e.Vendor != "broken" || e.Error == "" || e.WouldUpdate {
+		t.Errorf("broken entry = %+v, want an unresolved, non-updating entry", e)
+	}
+	if e := plan.Entries[4]; e.Vendor != "pinned" || !e.Pinned || e.WouldUpdate {
+		t.Errorf("pinned entry = %+v, want pinned and not updating", e)
+	}
+}
+
+func TestUpdateDryRun_FiltersVendorsAndResolvesCommitRefs(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+
+	commitRef := strings.Repeat("d", 40)
+	config.EXPECT().Load().Return(createTestConfig(
+		createTestVendorSpec("lib-a", "https://github.com/owner/a", commitRef),
+		createTestVendorSpec("lib-b", "https://github.com/owner/b", "main"),
+		createTestVendorSpec("other", "https://github.com/owner/other", "main"),
+	), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "lib-a", Ref: commitRef, CommitHash: commitRef},
+	}}, nil)
+	// Full commit refs are not listed remotely, and "other" is filtered out
+	git.EXPECT().LsRemote(gomock.Any(), "https://github.com/owner/b", "main").Return(commitRef, nil)
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	plan, err := syncer.UpdateDryRun(context.Background(), UpdateOptions{VendorName: "lib-*"})
+	if err != nil {
+		t.Fatalf("UpdateDryRun: %v", err)
+	}
+	if len(plan.Entries) != 2 {
+		t.Fatalf("entries = %+v, want lib-a and lib-b", plan.Entries)
+	}
+	if plan.Entries[0].WouldUpdate {
+		t.Errorf("lib-a is locked at its commit ref and should not update: %+v", plan.Entries[0])
+	}
+	if !plan.Entries[1].WouldUpdate {
+		t.Errorf("lib-b is not locked and should update: %+v", plan.Entries[1])
+	}
+}
@@ -3,7 +3,10 @@ package core
 import (
 	"context"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -16,8 +19,33 @@ import (
 type UpdateOptions struct {
 	Parallel   types.ParallelOptions
 	Local      bool   // Allow file:// and local path vendor URLs
-	VendorName string // Filter to single vendor by name (empty = all)
+	VendorName string // Filter by vendor name or glob, e.g. "aws-*" (empty = all)
 	Group      string // Filter to vendor group (empty = all)
+	Snapshot   bool   // Archive each fetched tree to .git-vendor/.snapshots/ for offline restore
+	// CheckLicense re-detects each vendor's upstream license (one license API
+	// call per vendor) and warns when it differs from the recorded one.
+	CheckLicense bool
+	// StrictLicense fails the update on such a change instead of warning.
+	// Implies CheckLicense.
+	StrictLicense bool
+	// ExcludeVendors skips vendors matching these names/globs after name/group selection.
+	ExcludeVendors []string
+	// FetchAttempts caps fetch attempts per URL on transient network errors
+	// (0 = DefaultFetchAttempts).
+	FetchAttempts int
+	// Relocate follows position snippets that moved upstream: a line range
+	// whose locked content is found at exactly one other place is rewritten in
+	// vendor.yml (see relocatePositions).
+	Relocate bool
+	// IncludePinned also updates vendors pinned by "git-vendor pin" (default:
+	// skip them and keep their lock entries).
+	IncludePinned bool
+	// Hardlink hard-links each vendor's identical destination files to one
+	// copy (SyncOptions.Hardlink).
+	Hardlink bool
+	// AllowHooks runs each vendor's post_sync command after it syncs
+	// (SyncOptions.AllowHooks); the lock then hashes the files it left.
+	AllowHooks bool
 }
 
 // UpdateServiceInterface defines the contract for update operations and lockfile regeneration.
@@ -37,9 +65,11 @@ type UpdateService struct {
 	lockStore    LockStore
 	syncService  SyncServiceInterface
 	internalSync InternalSyncServiceInterface // Spec 070
+	license      LicenseServiceInterface      // Re-detects upstream licenses (nil = no license-change check)
 	cache        CacheStore
 	ui           UICallback
 	rootDir      string
+	hostBranch   func() string // Current host-repo branch for ref_aliases (GetHostBranch)
 }
 
 // NewUpdateService creates a new UpdateService
@@ -48,6 +78,7 @@ func NewUpdateService(
 	lockStore LockStore,
 	syncService SyncServiceInterface,
 	internalSync InternalSyncServiceInterface,
+	license LicenseServiceInterface,
 	cache CacheStore,
 	ui UICallback,
 	rootDir string,
@@ -57,9 +88,11 @@ func NewUpdateService(
 		lockStore:    lockStore,
 		syncService:  syncService,
 		internalSync: internalSync,
+		license:      license,
 		cache:        cache,
 		ui:           ui,
 		rootDir:      rootDir,
+		hostBranch:   GetHostBranch,
 	}
 }
 
@@ -93,7 +126,13 @@ func (s *UpdateService) UpdateAllWithOptions(ctx context.Context, opts UpdateOpt
 		}
 	}
 
-	if opts.Parallel.Enabled {
+	// Redirect refs for the checked-out host branch; vendor.yml itself is unchanged
+	if len(config.RefAliases) > 0 && s.hostBranch != nil {
+		config = ApplyRefAliases(config, s.hostBranch())
+	}
+
+	// Recursive vendors grow the plan as they land, which the worker pool can't
+	if opts.Parallel.Enabled && !hasRecursiveVendor(config.Vendors) {
 		return s.updateAllParallel(ctx, config, opts)
 	}
 
@@ -123,7 +162,16 @@ func (s *UpdateService) updateAllSequential(ctx context.Context, config types.Ve
 	user := GetGitUserIdentity()
 
 	// Determine which vendors to update
-	vendorsToUpdate := s.filterVendors(config.Vendors, opts)
+	vendorsToUpdate, pinned := s.skipPinned(s.filterVendors(config.Vendors, opts), opts)
+
+	// Catch upstream relicensing before anything is re-synced
+	if err := s.checkLicenseChanges(existingLock, vendorsToUpdate, opts); err != nil {
+		return err
+	}
+
+	// Fetch each repository once and reuse it for every vendor that references it
+	repoCache := NewRepoCache()
+	defer repoCache.Close()
 
 	// Start progress tracking
 	progress := s.ui.StartProgress(len(vendorsToUpdate), "Updating vendors")
@@ -131,9 +179,14 @@ func (s *UpdateService) updateAllSequential(ctx context.Context, config types.Ve
 
 	// Track which vendor names were targeted for update (for lock merge)
 	updatedVendorNames := make(map[string]bool)
+	relocations := make(map[string][]positionRelocation)
+
+	// Vendors nested in recursive vendors join the plan after their parent
+	expander := newRecursiveExpander(config.Vendors)
 
 	// Update each targeted vendor
-	for _, v := range vendorsToUpdate {
+	for i := 0; i < len(vendorsToUpdate); i++ {
+		v := vendorsToUpdate[i]
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
@@ -158,7 +211,11 @@ func (s *UpdateService) updateAllSequential(ctx context.Context, config types.Ve
 			updatedRefs = refs
 		} else {
 			// External vendor: sync via git
-			refs, _, err := s.syncService.SyncVendor(ctx, &v, nil, SyncOptions{Force: true, NoCache: true, Local: opts.Local})
+			syncOpts := SyncOptions{Force: true, NoCache: true, Local: opts.Local, Snapshot: opts.Snapshot, LicenseDir: ResolveLicenseDir(s.rootDir, config), LicenseFiles: ResolveLicenseFiles(config), RepoCache: repoCache, FetchAttempts: opts.FetchAttempts, Hardlink: opts.Hardlink, AllowHooks: opts.AllowHooks}
+			if opts.Relocate {
+				syncOpts.RelocatePositions = lockedPositions(&v, existingEntries)
+			}
+			refs, _, err := s.syncService.SyncVendor(ctx, &v, nil, syncOpts)
 			if err != nil {
 				s.ui.ShowError("Update Failed", fmt.Sprintf("%s: %v", v.Name, err))
 				progress.Increment(fmt.Sprintf("✗ %s (failed)", v.Name))
@@ -169,10 +226,10 @@ func (s *UpdateService) updateAllSequential(ctx context.Context, config types.Ve
 
 		// Add lock entries for each ref
 		for ref, metadata := range updatedRefs {
-			licenseFile := filepath.Join(s.rootDir, LicensesDir, v.Name+".txt")
+			licenseFile := filepath.Join(ResolveLicenseDir(s.rootDir, config), v.Name+".txt")
 
 			// Compute file hashes for all destination files
-			fileHashes := s.computeFileHashes(&v, ref)
+			fileHashes := s.computeFileHashesFrom(&v, ref, metadata.FileHashes)
 
 			// Compute source file hashes for internal vendors
 			var sourceFileHashes map[string]string
@@ -180,8 +237,15 @@ func (s *UpdateService) updateAllSequential(ctx context.Context, config types.Ve
 				sourceFileHashes = s.computeSourceFileHashes(&v, ref)
 			}
 
+			// Lock entries stay keyed by the vendor.yml ref when an alias redirected it
+			lockRef, refAlias := lockRefFor(&v, ref)
+			for _, r := range metadata.Relocations {
+				r.Ref = lockRef
+				relocations[v.Name] = append(relocations[v.Name], r)
+			}
+
 			// Preserve VendoredAt and VendoredBy from existing entry, or set to now
-			key := v.Name + "@" + ref
+			key := v.Name + "@" + lockRef
 			vendoredAt := now
 			vendoredBy := user
 			if existing, ok := existingEntries[key]; ok {
@@ -195,18 +259,21 @@ func (s *UpdateService) updateAllSequential(ctx context.Context, config types.Ve
 
 			entry := types.LockDetails{
 				Name:             v.Name,
-				Ref:              ref,
+				Ref:              lockRef,
 				CommitHash:       metadata.CommitHash,
 				LicensePath:      licenseFile,
 				Updated:          now,
 				FileHashes:       fileHashes,
-				LicenseSPDX:      v.License,
+				LicenseSPDX:      ResolveVendorLicense(v),
 				SourceVersionTag: metadata.VersionTag,
 				VendoredAt:       vendoredAt,
 				VendoredBy:       vendoredBy,
 				LastSyncedAt:     now,
 				Positions:        toPositionLocks(metadata.Positions),
 				SourceURL:        metadata.SourceURL,
+				LicenseFiles:     metadata.LicenseFiles,
+				RefAlias:         refAlias,
+				Transformed:      specHasTransforms(&v, ref),
 			}
 
 			if v.Source == SourceInternal {
@@ -224,18 +291,39 @@ func (s *UpdateService) updateAllSequential(ctx context.Context, config types.Ve
 			s.ui.ShowSuccess(fmt.Sprintf("Updated %s @ %s to commit %s", v.Name, ref, hashDisplay))
 		}
 
+		nested, warnings, err := expander.expand(&v)
+		for _, w := range warnings {
+			s.ui.ShowWarning("Recursive Vendor", w)
+		}
+		if err != nil {
+			s.ui.ShowError("Update Failed", fmt.Sprintf("%s: %v", v.Name, err))
+		}
+		if len(nested) > 0 {
+			vendorsToUpdate = append(vendorsToUpdate, nested...)
+			progress.SetTotal(len(vendorsToUpdate))
+		}
+
 		progress.Increment(fmt.Sprintf("✓ %s", v.Name))
 	}
 
-	// When filtered, carry forward existing lock entries for non-targeted vendors
-	if filtered {
-		for _, entry := range existingLock.Vendors {
-			if !updatedVendorNames[entry.Name] {
-				lock.Vendors = append(lock.Vendors, entry)
-			}
+	// When filtered, carry forward existing lock entries for non-targeted
+	// vendors; skipped pinned specs always keep theirs
+	for _, entry := range existingLock.Vendors {
+		if (filtered && !updatedVendorNames[entry.Name]) || pinned[entry.Name+"@"+entry.Ref] {
+			lock.Vendors = append(lock.Vendors, entry)
 		}
 	}
 
+	// A cancelled or timed-out run must not write a lock missing the aborted vendors
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("update cancelled: %w", err)
+	}
+
+	// The lock now records the relocated ranges; vendor.yml must match it
+	if err := s.applyRelocations(relocations); err != nil {
+		return err
+	}
+
 	// Save the new lockfile
 	return s.lockStore.Save(lock)
 }
@@ -263,7 +351,16 @@ func (s *UpdateService) updateAllParallel(ctx context.Context, config types.Vend
 	user := GetGitUserIdentity()
 
 	// Filter vendors based on options
-	vendorsToUpdate := s.filterVendors(config.Vendors, opts)
+	vendorsToUpdate, pinned := s.skipPinned(s.filterVendors(config.Vendors, opts), opts)
+
+	// Catch upstream relicensing before anything is re-synced
+	if err := s.checkLicenseChanges(existingLock, vendorsToUpdate, opts); err != nil {
+		return err
+	}
+
+	// Fetch each repository once and reuse it for every vendor that references it
+	repoCache := NewRepoCache()
+	defer repoCache.Close()
 
 	// Start progress tracking
 	progress := s.ui.StartProgress(len(vendorsToUpdate), "Updating vendors (parallel)")
@@ -274,6 +371,7 @@ func (s *UpdateService) updateAllParallel(ctx context.Context, config types.Vend
 
 	// Track which vendor names were targeted for update (for lock merge)
 	updatedVendorNames := make(map[string]bool)
+	relocations := make(map[string][]positionRelocation)
 
 	// Phase 1: Internal vendors — sequential (before parallel external vendors)
 	lock := types.VendorLock{}
@@ -299,7 +397,7 @@ func (s *UpdateService) updateAllParallel(ctx context.Context, config types.Vend
 			}
 
 			for ref, metadata := range refs {
-				fileHashes := s.computeFileHashes(&v, ref)
+				fileHashes := s.computeFileHashesFrom(&v, ref, metadata.FileHashes)
 				sourceFileHashes := s.computeSourceFileHashes(&v, ref)
 				key := v.Name + "@" + ref
 				vendoredAt := now
@@ -324,6 +422,7 @@ func (s *UpdateService) updateAllParallel(ctx context.Context, config types.Vend
 					Positions:        toPositionLocks(metadata.Positions),
 					Source:           SourceInternal,
 					SourceFileHashes: sourceFileHashes,
+					Transformed:      specHasTransforms(&v, ref),
 				})
 
 				hashDisplay := metadata.CommitHash
@@ -342,6 +441,15 @@ func (s *UpdateService) updateAllParallel(ctx context.Context, config types.Vend
 	// Define update function for a single vendor
 	updateFunc := func(workerCtx context.Context, v types.VendorSpec, syncOpts SyncOptions) (map[string]RefMetadata, error) {
 		syncOpts.Local = opts.Local
+		syncOpts.Snapshot = opts.Snapshot
+		syncOpts.LicenseDir = ResolveLicenseDir(s.rootDir, config)
+		syncOpts.LicenseFiles = ResolveLicenseFiles(config)
+		syncOpts.RepoCache = repoCache
+		syncOpts.FetchAttempts = opts.FetchAttempts
+		syncOpts.Hardlink = opts.Hardlink
+		if opts.Relocate {
+			syncOpts.RelocatePositions = lockedPositions(&v, existingEntries)
+		}
 		updatedRefs, _, err := s.syncService.SyncVendor(workerCtx, &v, nil, syncOpts)
 		if err != nil {
 			s.ui.ShowError("Update Failed", fmt.Sprintf("%s: %v", v.Name, err))
@@ -370,10 +478,16 @@ func (s *UpdateService) updateAllParallel(ctx context.Context, config types.Vend
 		}
 
 		for ref, metadata := range results[i].UpdatedRefs {
-			licenseFile := filepath.Join(s.rootDir, LicensesDir, results[i].Vendor.Name+".txt")
-			fileHashes := s.computeFileHashes(&results[i].Vendor, ref)
+			licenseFile := filepath.Join(ResolveLicenseDir(s.rootDir, config), results[i].Vendor.Name+".txt")
+			fileHashes := s.computeFileHashesFrom(&results[i].Vendor, ref, metadata.FileHashes)
 
-			key := results[i].Vendor.Name + "@" + ref
+			lockRef, refAlias := lockRefFor(&results[i].Vendor, ref)
+			for _, r := range metadata.Relocations {
+				r.Ref = lockRef
+				relocations[results[i].Vendor.Name] = append(relocations[results[i].Vendor.Name], r)
+			}
+
+			key := results[i].Vendor.Name + "@" + lockRef
 			vendoredAt := now
 			vendoredBy := user
 			if existing, ok := existingEntries[key]; ok {
@@ -387,49 +501,98 @@ func (s *UpdateService) updateAllParallel(ctx context.Context, config types.Vend
 
 			lock.Vendors = append(lock.Vendors, types.LockDetails{
 				Name:             results[i].Vendor.Name,
-				Ref:              ref,
+				Ref:              lockRef,
 				CommitHash:       metadata.CommitHash,
 				LicensePath:      licenseFile,
 				Updated:          now,
 				FileHashes:       fileHashes,
-				LicenseSPDX:      results[i].Vendor.License,
+				LicenseSPDX:      ResolveVendorLicense(results[i].Vendor),
 				SourceVersionTag: metadata.VersionTag,
 				VendoredAt:       vendoredAt,
 				VendoredBy:       vendoredBy,
 				LastSyncedAt:     now,
 				Positions:        toPositionLocks(metadata.Positions),
 				SourceURL:        metadata.SourceURL,
+				LicenseFiles:     metadata.LicenseFiles,
+				RefAlias:         refAlias,
+				Transformed:      specHasTransforms(&results[i].Vendor, ref),
 			})
 		}
 	}
 
-	// When filtered, carry forward existing lock entries for non-targeted vendors
-	if filtered {
-		for _, entry := range existingLock.Vendors {
-			if !updatedVendorNames[entry.Name] {
-				lock.Vendors = append(lock.Vendors, entry)
-			}
+	// When filtered, carry forward existing lock entries for non-targeted
+	// vendors; skipped pinned specs always keep theirs
+	for _, entry := range existingLock.Vendors {
+		if (filtered && !updatedVendorNames[entry.Name]) || pinned[entry.Name+"@"+entry.Ref] {
+			lock.Vendors = append(lock.Vendors, entry)
 		}
 	}
 
+	// A cancelled or timed-out run must not write a lock missing the aborted vendors
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("update cancelled: %w", err)
+	}
+
+	// The lock now records the relocated ranges; vendor.yml must match it
+	if err := s.applyRelocations(relocations); err != nil {
+		return err
+	}
+
 	// Save the new lockfile
 	return s.lockStore.Save(lock)
 }
 
-// isFiltered reports whether the UpdateOptions specify a vendor name or group filter.
+// checkLicenseChanges re-detects the license of each external vendor in
+// vendors and compares it with the license recorded in lock (falling back to
+// vendor.yml). It runs only with opts.CheckLicense or opts.StrictLicense. A
+// change is reported through UICallback.ShowWarning; with opts.StrictLicense
+// the first change fails the update with a LicenseChangedError. Vendors declaring license_override, internal and local
+// vendors, and vendors whose license cannot be detected are not checked.
+func (s *UpdateService) checkLicenseChanges(lock types.VendorLock, vendors []types.VendorSpec, opts UpdateOptions) error {
+	if s.license == nil || !(opts.CheckLicense || opts.StrictLicense) {
+		return nil
+	}
+
+	recorded := make(map[string]string)
+	for i := range lock.Vendors {
+		if l := &lock.Vendors[i]; l.LicenseSPDX != "" && recorded[l.Name] == "" {
+			recorded[l.Name] = l.LicenseSPDX
+		}
+	}
+
+	for _, v := range vendors {
+		if v.Source == SourceInternal || v.LicenseOverride != "" || IsLocalPath(v.URL) {
+			continue
+		}
+		previous := recorded[v.Name]
+		if previous == "" {
+			previous = v.License
+		}
+		if previous == "" {
+			continue
+		}
+		detected, err := s.license.CheckLicense(v.URL)
+		if err != nil || detected == "" || strings.EqualFold(detected, previous) {
+			continue
+		}
+		if opts.StrictLicense {
+			return NewLicenseChangedError(v.Name, previous, detected)
+		}
+		s.ui.ShowWarning("License Changed",
+			fmt.Sprintf("%s: upstream license changed from %s to %s; review it and update the license in %s", v.Name, previous, detected, s.configStore.Path()))
+	}
+	return nil
+}
+
+// isFiltered reports whether the UpdateOptions specify a vendor name, group, or exclusion filter.
 func (s *UpdateService) isFiltered(opts UpdateOptions) bool {
-	return opts.VendorName != "" || opts.Group != ""
+	return opts.VendorName != "" || opts.Group != "" || len(opts.ExcludeVendors) > 0
 }
 
-// validateVendorExists returns a VendorNotFoundError if no vendor with vendorName
-// exists in the config.
+// validateVendorExists returns a VendorNotFoundError if no vendor is named
+// vendorName, or a NoVendorsMatchedError if the glob vendorName matches none.
 func (s *UpdateService) validateVendorExists(config types.VendorConfig, vendorName string) error {
-	for _, v := range config.Vendors {
-		if v.Name == vendorName {
-			return nil
-		}
-	}
-	return NewVendorNotFoundError(vendorName)
+	return ValidateVendorFilter(config, vendorName)
 }
 
 // validateGroupExists returns a GroupNotFoundError if no vendor in the config
@@ -448,13 +611,13 @@ func (s *UpdateService) validateGroupExists(config types.VendorConfig, groupName
 // filterVendors returns the subset of vendors matching the UpdateOptions filters.
 // If no filter is set, all vendors are returned.
 func (s *UpdateService) filterVendors(vendors []types.VendorSpec, opts UpdateOptions) []types.VendorSpec {
-	if opts.VendorName == "" && opts.Group == "" {
+	if !s.isFiltered(opts) {
 		return vendors
 	}
 
 	var filtered []types.VendorSpec
 	for _, v := range vendors {
-		if opts.VendorName != "" && v.Name != opts.VendorName {
+		if !MatchVendorFilter(v.Name, opts.VendorName) {
 			continue
 		}
 		if opts.Group != "" {
@@ -469,11 +632,46 @@ func (s *UpdateService) filterVendors(vendors []types.VendorSpec, opts UpdateOpt
 				continue
 			}
 		}
+		if MatchVendorPattern(v.Name, opts.ExcludeVendors) {
+			continue
+		}
 		filtered = append(filtered, v)
 	}
 	return filtered
 }
 
+// skipPinned drops pinned specs (see IsPinned) from vendors unless
+// opts.IncludePinned, reporting each one skipped; a vendor left without specs
+// is dropped. Returns the vendors to update and the "vendor@ref" lock keys of
+// the skipped specs.
+func (s *UpdateService) skipPinned(vendors []types.VendorSpec, opts UpdateOptions) ([]types.VendorSpec, map[string]bool) {
+	if opts.IncludePinned {
+		return vendors, nil
+	}
+	var kept []types.VendorSpec
+	skipped := make(map[string]bool)
+	for _, v := range vendors {
+		var specs []types.BranchSpec
+		for _, spec := range v.Specs {
+			if !IsPinned(spec) {
+				specs = append(specs, spec)
+				continue
+			}
+			lockRef, _ := lockRefFor(&v, spec.Ref)
+			skipped[v.Name+"@"+lockRef] = true
+			s.ui.ShowWarning("Pinned", fmt.Sprintf("Skipping %s @ %s: pinned to its locked commit (use --include-pinned to update it)", v.Name, lockRef))
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		if len(specs) < len(v.Specs) {
+			v.Specs = specs
+		}
+		kept = append(kept, v)
+	}
+	return kept, skipped
+}
+
 // toPositionLocks converts internal position records to lockfile-safe types.
 func toPositionLocks(records []positionRecord) []types.PositionLock {
 	if len(records) == 0 {
@@ -482,9 +680,10 @@ func toPositionLocks(records []positionRecord) []types.PositionLock {
 	locks := make([]types.PositionLock, len(records))
 	for i, r := range records {
 		locks[i] = types.PositionLock{
-			From:       r.From,
-			To:         r.To,
-			SourceHash: r.SourceHash,
+			From:        r.From,
+			To:          r.To,
+			SourceHash:  r.SourceHash,
+			SourceLines: r.SourceLines,
 		}
 	}
 	return locks
@@ -521,8 +720,20 @@ func (s *UpdateService) computeSourceFileHashes(vendor *types.VendorSpec, ref st
 	return sourceHashes
 }
 
-// computeFileHashes calculates SHA-256 hashes for all destination files of a vendor
+// computeFileHashes calculates SHA-256 hashes for all destination files of a vendor.
+// Directory mappings are walked so every copied file gets its own entry, keyed by
+// its path under the destination directory.
 func (s *UpdateService) computeFileHashes(vendor *types.VendorSpec, ref string) map[string]string {
+	return s.computeFileHashesFrom(vendor, ref, nil)
+}
+
+// computeFileHashesFrom is computeFileHashes taking each file's hash from
+// copied (RefMetadata.FileHashes, computed while syncing) when present and
+// reading only the files it lacks. With copied, a directory mapping records
+// only the files the copy wrote under it, so files added to the destination
+// by hand never enter the lock; an empty hash in copied marks a copied file
+// whose content must be read again.
+func (s *UpdateService) computeFileHashesFrom(vendor *types.VendorSpec, ref string, copied map[string]string) map[string]string {
 	fileHashes := make(map[string]string)
 
 	// Find the matching spec for this ref
@@ -556,7 +767,16 @@ func (s *UpdateService) computeFileHashes(vendor *types.VendorSpec, ref string)
 			destFile = destPath
 		}
 
+		if info, statErr := os.Stat(destFile); statErr == nil && info.IsDir() {
+			s.hashDirectory(destFile, fileHashes, copied)
+			continue
+		}
+
 		// Compute hash for this file
+		if hash := copied[filepath.ToSlash(destFile)]; hash != "" {
+			fileHashes[destFile] = hash
+			continue
+		}
 		hash, err := s.cache.ComputeFileChecksum(destFile)
 		if err == nil {
 			fileHashes[destFile] = hash
@@ -565,3 +785,44 @@ func (s *UpdateService) computeFileHashes(vendor *types.VendorSpec, ref string)
 
 	return fileHashes
 }
+
+// hashDirectory adds a SHA-256 entry to fileHashes for every file under dir
+// that the copy wrote (the keys of copied), reading a file only when its
+// copied hash is empty. Without copied (nil, e.g. lock regeneration), every
+// regular file under dir is read. Keys use forward slashes so lockfiles are
+// identical across platforms.
+func (s *UpdateService) hashDirectory(dir string, fileHashes, copied map[string]string) {
+	if copied != nil {
+		prefix := filepath.ToSlash(filepath.Clean(dir)) + "/"
+		if prefix == "./" {
+			prefix = ""
+		}
+		for path, hash := range copied {
+			if !strings.HasPrefix(path, prefix) {
+				continue
+			}
+			if hash == "" {
+				var err error
+				if hash, err = s.cache.ComputeFileChecksum(filepath.FromSlash(path)); err != nil {
+					continue
+				}
+			}
+			fileHashes[path] = hash
+		}
+		return
+	}
+	//nolint:errcheck // Unreadable entries are skipped, matching single-file hash failures
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if hash, ok := copied[filepath.ToSlash(path)]; ok {
+			fileHashes[filepath.ToSlash(path)] = hash
+			return nil
+		}
+		if hash, hashErr := s.cache.ComputeFileChecksum(path); hashErr == nil {
+			fileHashes[filepath.ToSlash(path)] = hash
+		}
+		return nil
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 func TestUpdateAll_HappyPath_SingleVendor(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	// Setup: Single vendor with one spec
 	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
@@ -72,9 +75,69 @@ func TestUpdateAll_HappyPath_SingleVendor(t *testing.T) {
 	}
 }
 
+func TestUpdateAll_RefAliasRedirectsEffectiveRef(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	cfg := createTestConfig(vendor)
+	cfg.RefAliases = map[string]map[string]string{
+		"release/1.x": {"main": "v1.4.0"},
+	}
+
+	config.EXPECT().Load().Return(cfg, nil)
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil)
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-12345", nil)
+	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil)
+
+	git.EXPECT().Init(gomock.Any(), "/tmp/test-12345").Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), "/tmp/test-12345", "origin", "https://github.com/owner/repo").Return(nil)
+	// The aliased ref is fetched instead of vendor.yml's "main"
+	git.EXPECT().Fetch(gomock.Any(), "/tmp/test-12345", "origin", 1, "v1.4.0").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), "/tmp/test-12345", "FETCH_HEAD").Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), "/tmp/test-12345").Return("abc123def456", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		if len(l.Vendors) != 1 {
+			t.Fatalf("Expected 1 lock entry, got %d", len(l.Vendors))
+		}
+		entry := l.Vendors[0]
+		// Lock stays keyed by the vendor.yml ref so status/verify still match config
+		if entry.Ref != "main" || entry.RefAlias != "v1.4.0" {
+			t.Errorf("lock Ref = %q, RefAlias = %q; want main, v1.4.0", entry.Ref, entry.RefAlias)
+		}
+		if entry.CommitHash != "abc123def456" {
+			t.Errorf("Expected hash 'abc123def456', got '%s'", entry.CommitHash)
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	syncer.update.(*UpdateService).hostBranch = func() string { return "release/1.x" }
+
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+
+	// The loaded config is never rewritten (config.Save is not expected either)
+	if ref := cfg.Vendors[0].Specs[0].Ref; ref != "main" {
+		t.Errorf("base config ref = %q after update, want main", ref)
+	}
+	if base := cfg.Vendors[0].Specs[0].BaseRef; base != "" {
+		t.Errorf("base config BaseRef = %q, want empty", base)
+	}
+}
+
 func TestUpdateAll_HappyPath_MultipleVendors(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	// Setup: 3 vendors
 	vendor1 := createTestVendorSpec("vendor-a", "https://github.com/owner/repo-a", "main")
@@ -127,6 +190,45 @@ func TestUpdateAll_HappyPath_MultipleVendors(t *testing.T) {
 	}
 }
 
+func TestUpdateAll_SharedURL_ClonesOnce(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	vendorA := createTestVendorSpec("vendor-a", "https://github.com/owner/monorepo", "main")
+	vendorB := createTestVendorSpec("vendor-b", "https://github.com/owner/monorepo", "main")
+
+	config.EXPECT().Load().Return(createTestConfig(vendorA, vendorB), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil)
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/shared", nil).Times(1)
+	fs.EXPECT().RemoveAll("/tmp/shared").Return(nil).Times(1)
+
+	initCalls := 0
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ string) error {
+		initCalls++
+		return nil
+	}).AnyTimes()
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("abc1234567", nil).Times(2)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lock.EXPECT().Save(gomock.Any()).Return(nil)
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if initCalls != 1 {
+		t.Errorf("Expected 1 clone for vendors sharing a URL, got %d", initCalls)
+	}
+}
+
 func TestUpdateAll_ConfigLoadFails(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
@@ -151,6 +253,7 @@ func TestUpdateAll_ConfigLoadFails(t *testing.T) {
 func TestUpdateAll_OneVendorFails_OthersContinue(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	// Setup: 3 vendors
 	vendor1 := createTestVendorSpec("vendor-good-1", "https://github.com/owner/repo-a", "main")
@@ -208,6 +311,7 @@ func TestUpdateAll_OneVendorFails_OthersContinue(t *testing.T) {
 func TestUpdateAll_LockSaveFails(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
 
@@ -244,6 +348,37 @@ func TestUpdateAll_LockSaveFails(t *testing.T) {
 	}
 }
 
+func TestUpdateAll_ContextCancelledDuringFetch_AbortsWithoutSavingLock(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config.EXPECT().Load().Return(createTestConfig(vendor), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil)
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-12345", nil)
+	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	// The deadline hits mid-fetch: exec.CommandContext kills git and Fetch returns the context error
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(fetchCtx context.Context, _, _ string, _ int, _ string) error {
+			cancel()
+			return fetchCtx.Err()
+		}).AnyTimes()
+
+	// No lock.Save expectation: a cancelled update must not write a partial lock
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	err := syncer.UpdateAll(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
 func TestUpdateAll_EmptyConfig(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
@@ -273,6 +408,7 @@ func TestUpdateAll_EmptyConfig(t *testing.T) {
 func TestUpdateAll_TimestampFormat(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
 
@@ -319,6 +455,7 @@ func TestUpdateAll_TimestampFormat(t *testing.T) {
 func TestUpdateAll_MultipleSpecsPerVendor(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	// Setup: 1 vendor with 3 specs
 	vendor := types.VendorSpec{
@@ -398,6 +535,7 @@ func TestUpdateAll_MultipleSpecsPerVendor(t *testing.T) {
 func TestUpdateAll_LicensePathSet(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
 
@@ -437,6 +575,99 @@ func TestUpdateAll_LicensePathSet(t *testing.T) {
 	}
 }
 
+func TestUpdateAll_CustomLicenseDir(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
+	cfg := createTestConfig(vendor)
+	cfg.LicenseDir = "LICENSES"
+	expectedPath := filepath.Join("/mock", "LICENSES", "test-vendor.txt")
+
+	config.EXPECT().Load().Return(cfg, nil)
+	lock.EXPECT().Load().Return(types.VendorLock{}, nil)
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-12345", nil)
+	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil)
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("abc123def", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	licenseCopied := false
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).DoAndReturn(func(_, dst string) (CopyStats, error) {
+		if dst == expectedPath {
+			licenseCopied = true
+		}
+		return CopyStats{FileCount: 1, ByteCount: 100}, nil
+	}).AnyTimes()
+
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		if got := l.Vendors[0].LicensePath; got != expectedPath {
+			t.Errorf("Expected license path '%s', got '%s'", expectedPath, got)
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if !licenseCopied {
+		t.Errorf("Expected license copied to %s", expectedPath)
+	}
+}
+
+// TestUpdateAll_SetsUpdatedAndLastSyncedAt verifies that an update, which
+// both moves the lock and copies files, stamps Updated and LastSyncedAt.
+func TestUpdateAll_SetsUpdatedAndLastSyncedAt(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	old := createTestLockEntry("test-vendor", "main", "old123")
+	old.Updated = "2025-01-01T00:00:00Z"
+	old.LastSyncedAt = "2025-01-01T00:00:00Z"
+
+	config.EXPECT().Load().Return(createTestConfig(createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")), nil)
+	lock.EXPECT().Load().Return(types.VendorLock{Vendors: []types.LockDetails{old}}, nil)
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-12345", nil)
+	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil)
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("abc123def", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		got := l.Vendors[0]
+		if got.Updated == "" || got.Updated == old.Updated {
+			t.Errorf("Updated = %q, want a fresh timestamp", got.Updated)
+		}
+		if got.LastSyncedAt != got.Updated {
+			t.Errorf("LastSyncedAt = %q, want %q (update copies files too)", got.LastSyncedAt, got.Updated)
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+}
+
 // ============================================================================
 // toPositionLocks Tests
 // ============================================================================
@@ -568,6 +799,58 @@ func TestComputeFileHashes_SingleFile(t *testing.T) {
 	}
 }
 
+func TestComputeFileHashesFrom_UsesCopiedHashes(t *testing.T) {
+	cache := newMockCacheStore()
+	cache.files["lib/a.go"] = "disk-a"
+	cache.files["lib/b.go"] = "disk-b"
+
+	svc := &UpdateService{cache: cache}
+	vendor := &types.VendorSpec{
+		Name: "test-vendor",
+		Specs: []types.BranchSpec{{
+			Ref: "main",
+			Mapping: []types.PathMapping{
+				{From: "src/a.go", To: "lib/a.go"},
+				{From: "src/b.go", To: "lib/b.go"},
+			},
+		}},
+	}
+
+	// a.go was hashed while copying; b.go (e.g. a position splice) was not
+	result := svc.computeFileHashesFrom(vendor, "main", map[string]string{"lib/a.go": "streamed-a"})
+	if result["lib/a.go"] != "streamed-a" {
+		t.Errorf("lib/a.go = %q, want the streamed hash", result["lib/a.go"])
+	}
+	if result["lib/b.go"] != "disk-b" {
+		t.Errorf("lib/b.go = %q, want the hash read from disk", result["lib/b.go"])
+	}
+}
+
+func TestComputeFileHashesFrom_DirectoryUsesCopiedFilesOnly(t *testing.T) {
+	dir := filepath.ToSlash(t.TempDir())
+	for _, name := range []string{"a.go", "b.go", "local.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cache := newMockCacheStore()
+	cache.files[dir+"/b.go"] = "disk-b"
+	cache.files[dir+"/local.go"] = "disk-local"
+
+	svc := &UpdateService{cache: cache}
+	vendor := &types.VendorSpec{
+		Name:  "test-vendor",
+		Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: dir}}}},
+	}
+
+	// b.go was copied but its streamed hash went stale; local.go was never copied
+	result := svc.computeFileHashesFrom(vendor, "main", map[string]string{dir + "/a.go": "streamed-a", dir + "/b.go": ""})
+	want := map[string]string{dir + "/a.go": "streamed-a", dir + "/b.go": "disk-b"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("hashes = %v, want %v", result, want)
+	}
+}
+
 func TestComputeFileHashes_MultipleMappings(t *testing.T) {
 	cache := newMockCacheStore()
 	cache.files["lib/a.go"] = "hash-a"
@@ -707,6 +990,126 @@ func TestComputeFileHashes_LargeFileConsistency(t *testing.T) {
 	}
 }
 
+func TestComputeFileHashes_DirectoryMapping(t *testing.T) {
+	chdirUnmanagedTest(t)
+	writeUnmanagedTestFile(t, "lib/pkg/a.go")
+	writeUnmanagedTestFile(t, "lib/pkg/nested/b.go")
+
+	svc := &UpdateService{cache: NewFileCacheStore(NewOSFileSystem(), VendorDir)}
+	vendor := &types.VendorSpec{
+		Name: "test-vendor",
+		Specs: []types.BranchSpec{{
+			Ref: "main",
+			Mapping: []types.PathMapping{
+				{From: "src", To: "lib/pkg"},
+			},
+		}},
+	}
+
+	result := svc.computeFileHashes(vendor, "main")
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 per-file hashes, got %d: %v", len(result), result)
+	}
+	for _, path := range []string{"lib/pkg/a.go", "lib/pkg/nested/b.go"} {
+		if len(result[path]) != 64 {
+			t.Errorf("Expected SHA-256 hash for %s, got %q", path, result[path])
+		}
+	}
+	if _, ok := result["lib/pkg"]; ok {
+		t.Error("Directory itself must not be recorded as a file hash")
+	}
+}
+
+// TestUpdateAll_RecordsPerFileHashesAndPositions runs a real update (mock git,
+// real filesystem) and checks the saved lock carries a hash for every copied
+// file plus the position source hash, and that verify passes against that lock.
+func TestUpdateAll_RecordsPerFileHashesAndPositions(t *testing.T) {
+	workDir := chdirUnmanagedTest(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+
+	config := types.VendorConfig{Vendors: []types.VendorSpec{{
+		Name: "hash-lib",
+		URL:  "https://github.com/owner/hash-lib",
+		Specs: []types.BranchSpec{{
+			Ref: "main",
+			Mapping: []types.PathMapping{
+				{From: "src", To: "vendor/hash-lib"},
+				{From: "util.go", To: "vendor/util.go"},
+				{From: "api.go:L2-L3", To: "vendor/api_snippet.go"},
+			},
+		}},
+	}}}
+	configStore := &stubConfigStore{config: config}
+
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/hash-lib").Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), FetchHead).DoAndReturn(
+		func(_ context.Context, dir, _ string) error {
+			for rel, content := range map[string]string{
+				"src/a.go":        "package a\n",
+				"src/nested/b.go": "package nested\n",
+				"util.go":         "package util\n",
+				"api.go":          "package api\nfunc A() {}\nfunc B() {}\n",
+			} {
+				path := filepath.Join(dir, rel)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return err
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("abc123def456", nil)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	var saved types.VendorLock
+	lockStore.EXPECT().Load().Return(types.VendorLock{}, nil)
+	lockStore.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		saved = l
+		return nil
+	})
+
+	osFS := NewOSFileSystem()
+	rootDir := filepath.Join(workDir, VendorDir)
+	syncer := NewVendorSyncer(configStore, lockStore, git, osFS, nil, rootDir, &SilentUICallback{}, nil)
+	if err := syncer.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+
+	if len(saved.Vendors) != 1 {
+		t.Fatalf("Expected 1 lock entry, got %d", len(saved.Vendors))
+	}
+	entry := saved.Vendors[0]
+	for _, path := range []string{"vendor/hash-lib/a.go", "vendor/hash-lib/nested/b.go", "vendor/util.go"} {
+		if entry.FileHashes[path] == "" {
+			t.Errorf("Expected non-empty FileHashes[%s], got %v", path, entry.FileHashes)
+		}
+	}
+	if len(entry.Positions) != 1 || entry.Positions[0].SourceHash == "" {
+		t.Errorf("Expected 1 position lock with SourceHash, got %+v", entry.Positions)
+	}
+
+	verify := NewVerifyService(configStore, &stubLockStore{lock: saved}, NewFileCacheStore(osFS, rootDir), osFS, rootDir)
+	result, err := verify.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if result.Summary.Result != "PASS" {
+		t.Errorf("Expected PASS against freshly written lock, got %s: %+v", result.Summary.Result, result.Files)
+	}
+	if result.Summary.Stale != 0 || result.Summary.Orphaned != 0 || result.Summary.Added != 0 {
+		t.Errorf("Expected no coherence/added issues, got stale=%d orphaned=%d added=%d",
+			result.Summary.Stale, result.Summary.Orphaned, result.Summary.Added)
+	}
+}
+
 // ============================================================================
 // UpdateAllWithOptions (parallel) Tests
 // ============================================================================
@@ -714,6 +1117,7 @@ func TestComputeFileHashes_LargeFileConsistency(t *testing.T) {
 func TestUpdateAllWithOptions_SequentialFallback(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor := createTestVendorSpec("test-vendor", "https://github.com/owner/repo", "main")
 
@@ -751,6 +1155,7 @@ func TestUpdateAllWithOptions_SequentialFallback(t *testing.T) {
 func TestUpdateAllWithOptions_ParallelMultipleVendors(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor1 := createTestVendorSpec("vendor-a", "https://github.com/owner/repo-a", "main")
 	vendor2 := createTestVendorSpec("vendor-b", "https://github.com/owner/repo-b", "main")
@@ -790,6 +1195,7 @@ func TestUpdateAllWithOptions_ParallelMultipleVendors(t *testing.T) {
 func TestUpdateAllWithOptions_ParallelPartialFailure(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor1 := createTestVendorSpec("vendor-ok", "https://github.com/owner/repo-ok", "main")
 	vendor2 := createTestVendorSpec("vendor-fail", "https://github.com/owner/repo-fail", "main")
@@ -841,6 +1247,7 @@ func TestUpdateAllWithOptions_ParallelPartialFailure(t *testing.T) {
 func TestUpdateAllWithOptions_VendorNameFilter(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor1 := createTestVendorSpec("vendor-a", "https://github.com/owner/repo-a", "main")
 	vendor2 := createTestVendorSpec("vendor-b", "https://github.com/owner/repo-b", "main")
@@ -904,6 +1311,7 @@ func TestUpdateAllWithOptions_VendorNameFilter(t *testing.T) {
 func TestUpdateAllWithOptions_GroupFilter(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	// vendor-a in "frontend" group, vendor-b in "backend" group, vendor-c in "frontend" group
 	vendorA := createTestVendorSpec("vendor-a", "https://github.com/owner/repo-a", "main")
@@ -968,6 +1376,75 @@ func TestUpdateAllWithOptions_GroupFilter(t *testing.T) {
 	}
 }
 
+func TestUpdateAllWithOptions_GroupWithExcludeGlob(t *testing.T) {
+	ctrl, git, fs, config, lock, license := setupMocks(t)
+	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
+
+	// Positive selection: "frontend" group (a, c-legacy); exclusion glob drops c-legacy
+	vendorA := createTestVendorSpec("vendor-a", "https://github.com/owner/repo-a", "main")
+	vendorA.Groups = []string{"frontend"}
+	vendorB := createTestVendorSpec("vendor-b", "https://github.com/owner/repo-b", "main")
+	vendorB.Groups = []string{"backend"}
+	vendorC := createTestVendorSpec("vendor-c-legacy", "https://github.com/owner/repo-c", "main")
+	vendorC.Groups = []string{"frontend"}
+
+	config.EXPECT().Load().Return(createTestConfig(vendorA, vendorB, vendorC), nil)
+
+	existingLock := types.VendorLock{
+		Vendors: []types.LockDetails{
+			{Name: "vendor-a", Ref: "main", CommitHash: "old_a"},
+			{Name: "vendor-b", Ref: "main", CommitHash: "old_b"},
+			{Name: "vendor-c-legacy", Ref: "main", CommitHash: "old_c"},
+		},
+	}
+	lock.EXPECT().Load().Return(existingLock, nil)
+
+	// Only vendor-a survives group selection minus the exclusion
+	fs.EXPECT().CreateTemp(gomock.Any(), gomock.Any()).Return("/tmp/test-12345", nil).Times(1)
+	fs.EXPECT().RemoveAll("/tmp/test-12345").Return(nil).Times(1)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/repo-a").Return(nil).Times(1)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return("new_hash_00000", nil).Times(1)
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).Return(&mockFileInfo{name: "LICENSE", isDir: false}, nil).AnyTimes()
+	fs.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(CopyStats{FileCount: 1, ByteCount: 100}, nil).AnyTimes()
+	fs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	lock.EXPECT().Save(gomock.Any()).DoAndReturn(func(l types.VendorLock) error {
+		entryMap := make(map[string]types.LockDetails)
+		for _, e := range l.Vendors {
+			entryMap[e.Name] = e
+		}
+		if len(entryMap) != 3 {
+			t.Errorf("Expected 3 lock entries (1 updated + 2 preserved), got %d", len(l.Vendors))
+		}
+		if entryMap["vendor-a"].CommitHash != "new_hash_00000" {
+			t.Error("vendor-a should have new hash")
+		}
+		if entryMap["vendor-b"].CommitHash != "old_b" {
+			t.Error("vendor-b (outside group) should retain old hash")
+		}
+		if entryMap["vendor-c-legacy"].CommitHash != "old_c" {
+			t.Error("vendor-c-legacy (excluded) should retain old hash")
+		}
+		return nil
+	})
+
+	syncer := createMockSyncer(git, fs, config, lock, license)
+
+	err := syncer.UpdateAllWithOptions(context.Background(), UpdateOptions{
+		Group:          "frontend",
+		ExcludeVendors: []string{"*-legacy"},
+	})
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+}
+
 func TestUpdateAllWithOptions_NonExistentVendorName(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
@@ -1013,6 +1490,7 @@ func TestUpdateAllWithOptions_NoFilter_Regression(t *testing.T) {
 	// Regression: Ensure no-filter update still processes all vendors (original behavior).
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor1 := createTestVendorSpec("vendor-a", "https://github.com/owner/repo-a", "main")
 	vendor2 := createTestVendorSpec("vendor-b", "https://github.com/owner/repo-b", "main")
@@ -1048,3 +1526,60 @@ func TestUpdateAllWithOptions_NoFilter_Regression(t *testing.T) {
 		t.Fatalf("Expected success, got error: %v", err)
 	}
 }
+
+// sequenceLicenseService returns the next license from licenses on each CheckLicense call.
+type sequenceLicenseService struct {
+	stubLicenseService
+	licenses []string
+	calls    int
+}
+
+func (s *sequenceLicenseService) CheckLicense(_ string) (string, error) {
+	license := s.licenses[len(s.licenses)-1]
+	if s.calls < len(s.licenses) {
+		license = s.licenses[s.calls]
+	}
+	s.calls++
+	return license, nil
+}
+
+func TestUpdateAll_WarnsWhenUpstreamLicenseChanges(t *testing.T) {
+	vendor := createTestVendorSpec("relicensed", "https://github.com/owner/relicensed", "main")
+	configStore := &stubConfigStore{config: createTestConfig(vendor)}
+	lockStore := &stubLockStore{lock: types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "relicensed", Ref: "main", CommitHash: "abc123", LicenseSPDX: "MIT"},
+	}}}
+	license := &sequenceLicenseService{licenses: []string{"MIT", "GPL-3.0"}}
+	ui := &capturingUICallback{}
+	svc := NewUpdateService(configStore, lockStore, &stubSyncService{}, nil, license, nil, ui, "/mock/vendor")
+
+	// Without CheckLicense no license API call is made
+	if err := svc.UpdateAll(context.Background()); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+	if license.calls != 0 {
+		t.Errorf("CheckLicense called %d times without CheckLicense", license.calls)
+	}
+
+	// First checked update: upstream still MIT
+	if err := svc.UpdateAllWithOptions(context.Background(), UpdateOptions{CheckLicense: true}); err != nil {
+		t.Fatalf("first checked update: %v", err)
+	}
+	if ui.warningMsg != "" {
+		t.Errorf("unexpected warning on unchanged license: %s", ui.warningMsg)
+	}
+
+	// Second checked update: upstream relicensed to GPL-3.0
+	if err := svc.UpdateAllWithOptions(context.Background(), UpdateOptions{CheckLicense: true}); err != nil {
+		t.Fatalf("second checked update: %v", err)
+	}
+	if !strings.Contains(ui.warningMsg, "MIT") || !strings.Contains(ui.warningMsg, "GPL-3.0") {
+		t.Errorf("expected license change warning, got %q", ui.warningMsg)
+	}
+
+	// Strict mode fails the update instead
+	err := svc.UpdateAllWithOptions(context.Background(), UpdateOptions{StrictLicense: true})
+	if !IsLicenseChanged(err) {
+		t.Fatalf("expected LicenseChangedError with StrictLicense, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -30,6 +31,71 @@ func FindVendorIndex(vendors []types.VendorSpec, name string) int {
 	return -1
 }
 
+// MatchVendorPattern reports whether name matches any of patterns.
+// Each pattern is an exact vendor name or a path.Match glob (e.g. "internal-*").
+// Malformed globs never match; callers validate with ValidateVendorPatterns.
+func MatchVendorPattern(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if p == name {
+			return true
+		}
+		if ok, err := path.Match(p, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsVendorGlob reports whether a vendor filter contains glob metacharacters
+// and therefore selects vendors by filepath.Match rather than exact name.
+func IsVendorGlob(filter string) bool {
+	return strings.ContainsAny(filter, "*?[")
+}
+
+// MatchVendorFilter reports whether name is selected by a single vendor
+// filter: empty selects every vendor, a glob (IsVendorGlob) matches with
+// filepath.Match, anything else must equal name exactly.
+func MatchVendorFilter(name, filter string) bool {
+	if filter == "" || filter == name {
+		return true
+	}
+	if !IsVendorGlob(filter) {
+		return false
+	}
+	ok, err := filepath.Match(filter, name)
+	return err == nil && ok
+}
+
+// ValidateVendorFilter checks that filter selects at least one vendor in
+// config. An exact name that is missing returns VendorNotFoundError; a glob
+// that matches nothing returns NoVendorsMatchedError.
+func ValidateVendorFilter(config types.VendorConfig, filter string) error {
+	if IsVendorGlob(filter) {
+		if _, err := filepath.Match(filter, ""); err != nil {
+			return fmt.Errorf("invalid vendor pattern %q: %w", filter, err)
+		}
+	}
+	for _, v := range config.Vendors {
+		if MatchVendorFilter(v.Name, filter) {
+			return nil
+		}
+	}
+	if IsVendorGlob(filter) {
+		return NewNoVendorsMatchedError(filter)
+	}
+	return NewVendorNotFoundError(filter)
+}
+
+// ValidateVendorPatterns returns an error for the first malformed glob in patterns.
+func ValidateVendorPatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid vendor pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
 // ForEachVendor applies function to each vendor in config.
 // Returns early if function returns an error.
 func ForEachVendor(config types.VendorConfig, fn func(types.VendorSpec) error) error {
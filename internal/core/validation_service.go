@@ -3,7 +3,9 @@ package core
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -14,6 +16,7 @@ import (
 type ValidationServiceInterface interface {
 	ValidateConfig() error
 	DetectConflicts() ([]types.PathConflict, error)
+	ExplainPlan() ([]types.DestinationPlan, error)
 }
 
 // Compile-time interface satisfaction check.
@@ -40,7 +43,37 @@ func (s *ValidationService) ValidateConfig() error {
 
 	// Check for empty vendors
 	if len(config.Vendors) == 0 {
-		return fmt.Errorf("no vendors configured. Run 'git-vendor add' to add your first dependency")
+		return fmt.Errorf("%w. Run 'git-vendor add' to add your first dependency", ErrNoVendorsConfigured)
+	}
+
+	// license_dir must stay inside the project (same rules as mapping destinations)
+	if config.LicenseDir != "" {
+		if err := ValidateDestPath(config.LicenseDir); err != nil {
+			return fmt.Errorf("license_dir: %w", err)
+		}
+	}
+
+	for i, license := range config.AllowedLicenses {
+		if strings.TrimSpace(license) == "" {
+			return fmt.Errorf("allowed_licenses[%d]: license ID must not be empty", i)
+		}
+	}
+
+	for i, name := range config.LicenseFiles {
+		if err := validateLicenseFileName(name); err != nil {
+			return fmt.Errorf("license_files[%d]: %w", i, err)
+		}
+	}
+
+	for branch, aliases := range config.RefAliases {
+		if strings.TrimSpace(branch) == "" {
+			return fmt.Errorf("ref_aliases: host branch name must not be empty")
+		}
+		for from, to := range aliases {
+			if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+				return fmt.Errorf("ref_aliases[%s]: alias %q -> %q must name both refs", branch, from, to)
+			}
+		}
 	}
 
 	// Validate global compliance config (Spec 075)
@@ -88,6 +121,43 @@ func (s *ValidationService) ValidateConfig() error {
 		return fmt.Errorf("ValidateConfig: %w", err)
 	}
 
+	if err := validateReservedDestinations(config, filepath.Dir(s.configStore.Path())); err != nil {
+		return fmt.Errorf("ValidateConfig: %w", err)
+	}
+
+	return nil
+}
+
+// validateReservedDestinations rejects mapping destinations (explicit or
+// auto-named) that resolve to git-vendor's config, lockfile, or license policy
+// in rootDir (the vendor directory, e.g. from --config), or that lie inside
+// the license directory where license copies are written.
+func validateReservedDestinations(config types.VendorConfig, rootDir string) error {
+	licenseDir := path.Join(filepath.ToSlash(rootDir), LicensesDir)
+	if config.LicenseDir != "" {
+		licenseDir = normalizeConfigPath(config.LicenseDir)
+	}
+
+	for _, vendor := range config.Vendors {
+		for _, spec := range vendor.Specs {
+			for _, mapping := range spec.Mapping {
+				dest := normalizeMappingPath(mapping.To)
+				if dest == "" || dest == "." {
+					dest = autoDestPath(mapping.From, spec, vendor.Name)
+				}
+				if file, _, err := types.ParsePathPosition(dest); err == nil {
+					dest = file
+				}
+
+				if err := checkReservedDest(rootDir, dest); err != nil {
+					return fmt.Errorf("vendor %s @ %s: %w", vendor.Name, spec.Ref, err)
+				}
+				if strings.EqualFold(dest, licenseDir) || isAncestorPath(strings.ToLower(licenseDir), strings.ToLower(dest)) {
+					return fmt.Errorf("vendor %s @ %s: destination '%s' is inside the license directory %s (reserved for license copies)", vendor.Name, spec.Ref, dest, licenseDir)
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -116,6 +186,24 @@ func (s *ValidationService) validateVendor(vendor *types.VendorSpec) error {
 		}
 	}
 
+	// license_override must name a recognized SPDX license
+	if vendor.LicenseOverride != "" && !IsKnownSPDXLicense(vendor.LicenseOverride) {
+		return NewValidationError(vendor.Name, "", "license_override",
+			fmt.Sprintf("%q is not a recognized SPDX license identifier", vendor.LicenseOverride))
+	}
+
+	// spdx_headers writes the vendor's license into each file's header
+	if vendor.SPDXHeaders && ResolveVendorLicense(*vendor) == "" {
+		return NewValidationError(vendor.Name, "", "spdx_headers",
+			"spdx_headers needs a license or license_override to write")
+	}
+
+	// post_sync and hooks.post_sync are the same step; only one may be set
+	if vendor.PostSync != "" && vendor.Hooks != nil && vendor.Hooks.PostSync != "" {
+		return NewValidationError(vendor.Name, "", "post_sync",
+			"post_sync and hooks.post_sync cannot both be set; keep one")
+	}
+
 	// Validate per-vendor enforcement level (Spec 075)
 	if vendor.Enforcement != "" && vendor.Enforcement != EnforcementStrict &&
 		vendor.Enforcement != EnforcementLenient && vendor.Enforcement != EnforcementInfo {
@@ -155,11 +243,33 @@ func (s *ValidationService) validateSpec(vendorName string, spec types.BranchSpe
 		return fmt.Errorf("vendor %s @ %s has no path mappings", vendorName, spec.Ref)
 	}
 
+	if spec.Depth < -1 {
+		return fmt.Errorf("vendor %s @ %s has invalid depth %d (use -1 for full history)", vendorName, spec.Ref, spec.Depth)
+	}
+
 	// Validate each mapping
+	groupDests := make(map[int]map[string]bool)
 	for _, mapping := range spec.Mapping {
 		if mapping.From == "" {
 			return fmt.Errorf("vendor %s @ %s has a mapping with empty 'from' path", vendorName, spec.Ref)
 		}
+		if mapping.MaxDepth < 0 {
+			return fmt.Errorf("vendor %s @ %s: mapping from '%s' has invalid max_depth %d (use 0 for unlimited)", vendorName, spec.Ref, mapping.From, mapping.MaxDepth)
+		}
+		if _, err := compileTransforms(mapping.Transforms); err != nil {
+			return fmt.Errorf("vendor %s @ %s: mapping from '%s': %w", vendorName, spec.Ref, mapping.From, err)
+		}
+		if mapping.ToGroup == 0 {
+			continue
+		}
+		// Multi-destination "to" lists must name each destination once
+		if groupDests[mapping.ToGroup] == nil {
+			groupDests[mapping.ToGroup] = make(map[string]bool)
+		}
+		if groupDests[mapping.ToGroup][mapping.To] {
+			return fmt.Errorf("vendor %s @ %s: mapping from '%s' lists destination '%s' more than once", vendorName, spec.Ref, mapping.From, mapping.To)
+		}
+		groupDests[mapping.ToGroup][mapping.To] = true
 	}
 
 	return nil
@@ -171,7 +281,12 @@ func (s *ValidationService) DetectConflicts() ([]types.PathConflict, error) {
 	if err != nil {
 		return nil, fmt.Errorf("DetectConflicts: load config: %w", err)
 	}
+	return s.detectConfigConflicts(config), nil
+}
 
+// detectConfigConflicts is DetectConflicts for an in-memory config, so a
+// pending edit (e.g. "mv") can be checked before it is saved.
+func (s *ValidationService) detectConfigConflicts(config types.VendorConfig) []types.PathConflict {
 	// Build path ownership map
 	pathMap := s.buildPathOwnershipMap(config)
 
@@ -182,7 +297,7 @@ func (s *ValidationService) DetectConflicts() ([]types.PathConflict, error) {
 	overlappingConflicts := s.detectOverlappingPathConflicts(pathMap)
 	conflicts = append(conflicts, overlappingConflicts...)
 
-	return conflicts, nil
+	return conflicts
 }
 
 // PathOwner tracks which vendor owns a path
@@ -232,7 +347,9 @@ func (s *ValidationService) buildPathOwnershipMap(config types.VendorConfig) map
 	return pathMap
 }
 
-// detectExactPathConflicts detects when multiple vendors map to the same path
+// detectExactPathConflicts detects when multiple mappings write the same path.
+// A collision where either mapping has an empty "to" is reported as
+// ConflictAutoNamed, since the shared path comes from the basename of "from".
 func (s *ValidationService) detectExactPathConflicts(pathMap map[string][]PathOwner) []types.PathConflict {
 	var conflicts []types.PathConflict
 
@@ -241,12 +358,17 @@ func (s *ValidationService) detectExactPathConflicts(pathMap map[string][]PathOw
 			// Multiple vendors map to the same path
 			for i := 0; i < len(owners)-1; i++ {
 				for j := i + 1; j < len(owners); j++ {
+					reason := types.ConflictSamePath
+					if isAutoNamedMapping(owners[i].Mapping) || isAutoNamedMapping(owners[j].Mapping) {
+						reason = types.ConflictAutoNamed
+					}
 					conflicts = append(conflicts, types.PathConflict{
 						Path:     path,
 						Vendor1:  owners[i].VendorName,
 						Vendor2:  owners[j].VendorName,
 						Mapping1: owners[i].Mapping,
 						Mapping2: owners[j].Mapping,
+						Reason:   reason,
 					})
 				}
 			}
@@ -256,47 +378,151 @@ func (s *ValidationService) detectExactPathConflicts(pathMap map[string][]PathOw
 	return conflicts
 }
 
-// detectOverlappingPathConflicts detects when one path is a subdirectory of another
+// isAutoNamedMapping reports whether mapping's destination is computed from its source.
+func isAutoNamedMapping(mapping types.PathMapping) bool {
+	return mapping.To == "" || mapping.To == "."
+}
+
+// detectOverlappingPathConflicts detects when one destination is an ancestor
+// directory of another owned by a different vendor, or by another ref of the
+// same vendor (lib/v1 and lib/v2 must not nest): the nested files land inside
+// the ancestor's tree and are clobbered on the next sync of either. Mappings
+// of the same vendor@ref may nest, layering a file over a directory mapping.
+// Ancestry is by path component, so "lib/a" and "lib/ab" do not overlap.
 func (s *ValidationService) detectOverlappingPathConflicts(pathMap map[string][]PathOwner) []types.PathConflict {
 	var conflicts []types.PathConflict
 
-	// Get all paths for comparison
-	var allPaths []string
+	// Sort paths so conflicts are reported in a stable order
+	allPaths := make([]string, 0, len(pathMap))
 	for path := range pathMap {
 		allPaths = append(allPaths, path)
 	}
+	sort.Strings(allPaths)
+
+	for _, ancestor := range allPaths {
+		for _, nested := range allPaths {
+			if !isAncestorPath(ancestor, nested) {
+				continue
+			}
+			for _, outer := range pathMap[ancestor] {
+				for _, inner := range pathMap[nested] {
+					if outer.VendorName == inner.VendorName && outer.Ref == inner.Ref {
+						continue
+					}
+					conflicts = append(conflicts, types.PathConflict{
+						Path:     nested,
+						Vendor1:  outer.VendorName,
+						Vendor2:  inner.VendorName,
+						Mapping1: outer.Mapping,
+						Mapping2: inner.Mapping,
+						Reason:   types.ConflictNestedPath,
+					})
+				}
+			}
+		}
+	}
 
-	// Compare all path pairs
-	for i := 0; i < len(allPaths)-1; i++ {
-		for j := i + 1; j < len(allPaths); j++ {
-			path1 := allPaths[i]
-			path2 := allPaths[j]
+	return conflicts
+}
 
-			// Check if one path is a subdirectory of another
-			if isSubPath(path1, path2) {
-				owners1 := pathMap[path1]
-				owners2 := pathMap[path2]
+// ExplainPlan reports, for every destination written by more than one mapping,
+// the candidates in sync write order and which one determines the final content.
+//
+// Sync writes internal vendors first, then external vendors, each in vendor.yml
+// order (vendor, then ref, then mapping). A whole-file write overwrites anything
+// written before it, so the last whole-file candidate wins; position mappings
+// splice their range into whatever is already on disk. Plans are sorted by path.
+// With --parallel, the relative order of external vendors is not guaranteed.
+func (s *ValidationService) ExplainPlan() ([]types.DestinationPlan, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("ExplainPlan: load config: %w", err)
+	}
 
-				// Skip malformed entries (empty slices)
-				if len(owners1) == 0 || len(owners2) == 0 {
-					continue
+	// Sync order: internal vendors (phase 1) before external vendors (phase 2)
+	var ordered []types.VendorSpec
+	for _, vendor := range config.Vendors {
+		if vendor.Source == SourceInternal {
+			ordered = append(ordered, vendor)
+		}
+	}
+	for _, vendor := range config.Vendors {
+		if vendor.Source != SourceInternal {
+			ordered = append(ordered, vendor)
+		}
+	}
+
+	candidates := make(map[string][]types.WritePlanCandidate)
+	var paths []string
+	order := 0
+	for _, vendor := range ordered {
+		for _, spec := range vendor.Specs {
+			for _, mapping := range spec.Mapping {
+				order++
+				destPath := mapping.To
+				if destPath == "" || destPath == "." {
+					srcFile, _, err := types.ParsePathPosition(mapping.From)
+					if err != nil {
+						srcFile = mapping.From
+					}
+					destPath = ComputeAutoPath(srcFile, spec.DefaultTarget, vendor.Name)
+				}
+				destFile, destPos, err := types.ParsePathPosition(destPath)
+				if err != nil {
+					destFile = destPath
 				}
+				destFile = filepath.Clean(destFile)
 
-				// Only report if different vendors
-				if owners1[0].VendorName != owners2[0].VendorName {
-					conflicts = append(conflicts, types.PathConflict{
-						Path:     fmt.Sprintf("%s overlaps with %s", path1, path2),
-						Vendor1:  owners1[0].VendorName,
-						Vendor2:  owners2[0].VendorName,
-						Mapping1: owners1[0].Mapping,
-						Mapping2: owners2[0].Mapping,
-					})
+				if _, seen := candidates[destFile]; !seen {
+					paths = append(paths, destFile)
 				}
+				candidates[destFile] = append(candidates[destFile], types.WritePlanCandidate{
+					Order:    order,
+					Vendor:   vendor.Name,
+					Ref:      spec.Ref,
+					From:     mapping.From,
+					To:       destPath,
+					Position: destPos != nil,
+				})
 			}
 		}
 	}
 
-	return conflicts
+	sort.Strings(paths)
+	var plans []types.DestinationPlan
+	for _, path := range paths {
+		cands := candidates[path]
+		if len(cands) < 2 {
+			continue
+		}
+		plans = append(plans, types.DestinationPlan{
+			Path:       path,
+			Candidates: cands,
+			Reason:     resolvePlanWinner(cands),
+		})
+	}
+	return plans, nil
+}
+
+// resolvePlanWinner marks the candidate whose write determines the final
+// content of a destination and returns a one-line explanation.
+func resolvePlanWinner(cands []types.WritePlanCandidate) string {
+	winner := -1
+	for i := range cands {
+		if !cands[i].Position {
+			winner = i
+		}
+	}
+	if winner < 0 {
+		return "all candidates are position mappings; each splices its range into the file in write order"
+	}
+	cands[winner].Winner = true
+
+	reason := fmt.Sprintf("last whole-file write in sync order (#%d) overwrites earlier candidates", cands[winner].Order)
+	if later := len(cands) - 1 - winner; later > 0 {
+		reason += fmt.Sprintf("; %s then splice into it", Pluralize(later, "later position mapping", "later position mappings"))
+	}
+	return reason
 }
 
 // validateInternalVendor validates a vendor with Source="internal".
@@ -314,6 +540,9 @@ func (s *ValidationService) validateInternalVendor(vendor *types.VendorSpec) err
 	if vendor.Hooks != nil {
 		return NewValidationError(vendor.Name, "", "hooks", "internal vendors MUST NOT have hooks")
 	}
+	if vendor.SPDXHeaders {
+		return NewValidationError(vendor.Name, "", "spdx_headers", "internal vendors MUST NOT set spdx_headers")
+	}
 	if vendor.Direction != "" && vendor.Direction != ComplianceSourceCanonical && vendor.Direction != ComplianceBidirectional {
 		return NewValidationError(vendor.Name, "", "direction",
 			fmt.Sprintf("direction must be empty, %q, or %q", ComplianceSourceCanonical, ComplianceBidirectional))
@@ -450,22 +679,12 @@ func (s *ValidationService) detectInternalCycles(config types.VendorConfig) erro
 	return nil
 }
 
-// isSubPath checks if path1 is a subdirectory of path2 or vice versa
-func isSubPath(path1, path2 string) bool {
-	path1 = filepath.Clean(path1)
-	path2 = filepath.Clean(path2)
-
-	// Check if path2 is under path1
-	rel, err := filepath.Rel(path1, path2)
-	if err == nil && !strings.HasPrefix(rel, "..") && rel != "." {
-		return true
+// isAncestorPath reports whether nested lies strictly inside ancestor,
+// comparing whole path components.
+func isAncestorPath(ancestor, nested string) bool {
+	rel, err := filepath.Rel(filepath.Clean(ancestor), filepath.Clean(nested))
+	if err != nil || rel == "." || rel == ".." {
+		return false
 	}
-
-	// Check if path1 is under path2
-	rel, err = filepath.Rel(path2, path1)
-	if err == nil && !strings.HasPrefix(rel, "..") && rel != "." {
-		return true
-	}
-
-	return false
+	return !strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
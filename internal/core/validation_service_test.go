@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -176,6 +177,8 @@ func TestValidateDestPath(t *testing.T) {
 					t.Errorf("validateDestPath(%q) expected error containing %q, got nil", tt.destPath, tt.errorMsg)
 				} else if tt.errorMsg != "" && !contains(err.Error(), tt.errorMsg) {
 					t.Errorf("validateDestPath(%q) error = %q, want error containing %q", tt.destPath, err.Error(), tt.errorMsg)
+				} else if contains(tt.errorMsg, "path traversal") && !errors.Is(err, ErrPathTraversal) {
+					t.Errorf("validateDestPath(%q) error = %q, want errors.Is ErrPathTraversal", tt.destPath, err.Error())
 				}
 			} else {
 				if err != nil {
@@ -465,6 +468,28 @@ func TestValidateConfig(t *testing.T) {
 			wantError: true,
 			errorMsg:  "vendor empty-from @ main has a mapping with empty 'from' path",
 		},
+		{
+			name: "Multi-destination mapping listing a destination twice",
+			config: types.VendorConfig{
+				Vendors: []types.VendorSpec{
+					{
+						Name: "dup-dest",
+						URL:  "https://github.com/test/repo",
+						Specs: []types.BranchSpec{
+							{
+								Ref: "main",
+								Mapping: []types.PathMapping{
+									{From: "a.go", To: "lib/a.go", ToGroup: 1},
+									{From: "a.go", To: "lib/a.go", ToGroup: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantError: true,
+			errorMsg:  "vendor dup-dest @ main: mapping from 'a.go' lists destination 'lib/a.go' more than once",
+		},
 	}
 
 	for _, tt := range tests {
@@ -488,6 +513,8 @@ func TestValidateConfig(t *testing.T) {
 					t.Errorf("ValidateConfig() expected error containing %q, got nil", tt.errorMsg)
 				} else if tt.errorMsg != "" && !contains(err.Error(), tt.errorMsg) {
 					t.Errorf("ValidateConfig() error = %q, want error containing %q", err.Error(), tt.errorMsg)
+				} else if len(tt.config.Vendors) == 0 && !errors.Is(err, ErrNoVendorsConfigured) {
+					t.Errorf("ValidateConfig() error = %q, want errors.Is ErrNoVendorsConfigured", err.Error())
 				}
 			} else {
 				if err != nil {
@@ -513,7 +540,7 @@ func TestDetectConflicts_EmptyOwners(t *testing.T) {
 	// Create Manager with proper initialization
 	configStore := NewFileConfigStore(vendorDir)
 	lockStore := NewFileLockStore(vendorDir)
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	fs := NewOSFileSystem()
 	licenseChecker := NewGitHubLicenseChecker(nil, AllowedLicenses)
 	syncer := NewVendorSyncer(configStore, lockStore, gitClient, fs, licenseChecker, vendorDir, nil, nil)
@@ -573,7 +600,7 @@ func TestDetectConflicts_NoPanic(t *testing.T) {
 
 	configStore := NewFileConfigStore(vendorDir)
 	lockStore := NewFileLockStore(vendorDir)
-	gitClient := NewSystemGitClient(false)
+	gitClient := NewSystemGitClient()
 	fs := NewOSFileSystem()
 	licenseChecker := NewGitHubLicenseChecker(nil, AllowedLicenses)
 	syncer := NewVendorSyncer(configStore, lockStore, gitClient, fs, licenseChecker, vendorDir, nil, nil)
@@ -1129,6 +1156,65 @@ func TestValidateConfig_Gomock_MissingURL(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_ReservedDestinations(t *testing.T) {
+	tests := []struct {
+		name          string
+		configPath    string // Config store path; "" = ConfigPath
+		licenseDir    string
+		defaultTarget string
+		mapping       types.PathMapping
+		wantErr       string
+	}{
+		{name: "config path", mapping: types.PathMapping{From: "vendor.yml", To: ".git-vendor/vendor.yml"}, wantErr: "managed by git-vendor"},
+		{name: "toml config path", mapping: types.PathMapping{From: "vendor.toml", To: ".git-vendor/vendor.toml"}, wantErr: "managed by git-vendor"},
+		{name: "lock path with position", mapping: types.PathMapping{From: "a.go:L1-L2", To: ".git-vendor/vendor.lock:L1-L2"}, wantErr: "managed by git-vendor"},
+		{name: "default license dir", mapping: types.PathMapping{From: "LICENSE", To: ".git-vendor/licenses/other.txt"}, wantErr: "license directory"},
+		{name: "custom license dir", licenseDir: "third_party/licenses", mapping: types.PathMapping{From: "src", To: "third_party/licenses/extra"}, wantErr: "license directory"},
+		{name: "auto-named into license dir", licenseDir: "third_party/licenses", defaultTarget: "third_party/licenses", mapping: types.PathMapping{From: "src/util.go"}, wantErr: "license directory"},
+		{name: "lock in --config dir", configPath: "vendor2/vendor.yml", mapping: types.PathMapping{From: "a.go", To: "vendor2/vendor.lock"}, wantErr: "managed by git-vendor"},
+		{name: "license dir in --config dir", configPath: "vendor2/vendor.yml", mapping: types.PathMapping{From: "LICENSE", To: "vendor2/licenses/other.txt"}, wantErr: "license directory"},
+		{name: "sibling of lock file", mapping: types.PathMapping{From: "src", To: ".git-vendor/notes/lock.md"}},
+		{name: "sibling of custom license dir", licenseDir: "third_party/licenses", mapping: types.PathMapping{From: "src", To: "third_party/licenses-extra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockConfig := NewMockConfigStore(ctrl)
+			configPath := tt.configPath
+			if configPath == "" {
+				configPath = ConfigPath
+			}
+			mockConfig.EXPECT().Path().Return(configPath).AnyTimes()
+
+			mockConfig.EXPECT().Load().Return(types.VendorConfig{
+				LicenseDir: tt.licenseDir,
+				Vendors: []types.VendorSpec{
+					{
+						Name: "lib",
+						URL:  "https://github.com/owner/lib",
+						Specs: []types.BranchSpec{
+							{Ref: "main", DefaultTarget: tt.defaultTarget, Mapping: []types.PathMapping{tt.mapping}},
+						},
+					},
+				},
+			}, nil)
+
+			err := NewValidationService(mockConfig).ValidateConfig()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateConfig() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateConfig_Gomock_ConfigLoadError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1184,6 +1270,113 @@ func TestDetectConflicts_Gomock_OverlappingPathsBetweenVendors(t *testing.T) {
 	}
 }
 
+func TestDetectConflicts_Gomock_NestedPathReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		destA    string
+		destB    string
+		wantHit  bool
+		wantPath string
+	}{
+		{name: "parent and child directory", destA: "lib", destB: "lib/pkg", wantHit: true, wantPath: "lib/pkg"},
+		{name: "child listed first", destA: "lib/pkg/file.go", destB: "lib", wantHit: true, wantPath: "lib/pkg/file.go"},
+		{name: "sibling names", destA: "lib1", destB: "lib2"},
+		{name: "shared string prefix only", destA: "lib/a", destB: "lib/ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockConfig := NewMockConfigStore(ctrl)
+
+			mockConfig.EXPECT().Load().Return(types.VendorConfig{
+				Vendors: []types.VendorSpec{
+					{
+						Name:  "vendor-a",
+						URL:   "https://github.com/a/repo",
+						Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: tt.destA}}}},
+					},
+					{
+						Name:  "vendor-b",
+						URL:   "https://github.com/b/repo",
+						Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "pkg", To: tt.destB}}}},
+					},
+				},
+			}, nil)
+
+			conflicts, err := NewValidationService(mockConfig).DetectConflicts()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantHit {
+				if len(conflicts) != 0 {
+					t.Errorf("expected no conflicts for %s vs %s, got %+v", tt.destA, tt.destB, conflicts)
+				}
+				return
+			}
+
+			if len(conflicts) != 1 {
+				t.Fatalf("expected 1 conflict, got %+v", conflicts)
+			}
+			c := conflicts[0]
+			if c.Reason != types.ConflictNestedPath {
+				t.Errorf("Reason = %q, want %q", c.Reason, types.ConflictNestedPath)
+			}
+			if c.Path != tt.wantPath {
+				t.Errorf("Path = %q, want nested destination %q", c.Path, tt.wantPath)
+			}
+			// Vendor1 owns the ancestor directory
+			if ancestor := c.Mapping1.To; len(ancestor) >= len(c.Path) {
+				t.Errorf("Mapping1.To = %q should be the ancestor of %q", ancestor, c.Path)
+			}
+		})
+	}
+}
+
+func TestDetectConflicts_Gomock_AutoNamedBasenameCollision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfig := NewMockConfigStore(ctrl)
+
+	// Both empty-To mappings auto-name to vendor/config.go
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name: "vendor-a",
+				URL:  "https://github.com/a/repo",
+				Specs: []types.BranchSpec{{
+					Ref:           "main",
+					DefaultTarget: "vendor",
+					Mapping: []types.PathMapping{
+						{From: "a/config.go"},
+						{From: "b/config.go"},
+						{From: "b/other.go"},
+					},
+				}},
+			},
+		},
+	}, nil)
+
+	conflicts, err := NewValidationService(mockConfig).DetectConflicts()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %+v", conflicts)
+	}
+	c := conflicts[0]
+	if c.Path != filepath.Join("vendor", "config.go") {
+		t.Errorf("Path = %q, want the resolved auto-named destination", c.Path)
+	}
+	if c.Reason != types.ConflictAutoNamed {
+		t.Errorf("Reason = %q, want %q", c.Reason, types.ConflictAutoNamed)
+	}
+	if c.Mapping1.From != "a/config.go" || c.Mapping2.From != "b/config.go" {
+		t.Errorf("conflict mappings = %q, %q, want a/config.go and b/config.go", c.Mapping1.From, c.Mapping2.From)
+	}
+}
+
 func TestDetectConflicts_Gomock_SameExactPath(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1265,6 +1458,59 @@ func TestDetectConflicts_Gomock_SelfConflictSingleVendor(t *testing.T) {
 	}
 }
 
+func TestDetectConflicts_Gomock_SameVendorCrossRef(t *testing.T) {
+	tests := []struct {
+		name         string
+		destV1       string
+		destV2       string
+		wantConflict bool
+	}{
+		{name: "distinct version directories", destV1: "lib/v1", destV2: "lib/v2"},
+		{name: "v2 nested in v1", destV1: "lib", destV2: "lib/v2", wantConflict: true},
+		{name: "same destination", destV1: "lib", destV2: "lib", wantConflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockConfig := NewMockConfigStore(ctrl)
+
+			mockConfig.EXPECT().Load().Return(types.VendorConfig{
+				Vendors: []types.VendorSpec{{
+					Name: "lib",
+					URL:  "https://github.com/a/lib",
+					Specs: []types.BranchSpec{
+						{Ref: "v1", Mapping: []types.PathMapping{{From: "src", To: tt.destV1}}},
+						// A file layered into its own ref's directory is intended
+						{Ref: "v2", Mapping: []types.PathMapping{{From: "src", To: tt.destV2}, {From: "extra.go", To: tt.destV2 + "/extra.go"}}},
+					},
+				}},
+			}, nil)
+
+			conflicts, err := NewValidationService(mockConfig).DetectConflicts()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantConflict {
+				if len(conflicts) != 0 {
+					t.Errorf("expected no conflicts for %s vs %s, got %+v", tt.destV1, tt.destV2, conflicts)
+				}
+				return
+			}
+			found := false
+			for _, c := range conflicts {
+				if c.Vendor1 == "lib" && c.Vendor2 == "lib" && c.Path == filepath.Clean(tt.destV2) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected a lib@v1 vs lib@v2 conflict at %s, got %+v", tt.destV2, conflicts)
+			}
+		})
+	}
+}
+
 func TestDetectConflicts_Gomock_PositionPathStripped(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1432,6 +1678,7 @@ func TestValidateConfig_MirrorValid(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Path().Return(ConfigPath).AnyTimes()
 
 	mockConfig.EXPECT().Load().Return(types.VendorConfig{
 		Vendors: []types.VendorSpec{
@@ -1469,3 +1716,225 @@ func TestDetectConflicts_Gomock_ConfigLoadError(t *testing.T) {
 		t.Errorf("error = %q, want 'disk error'", err.Error())
 	}
 }
+
+// ============================================================================
+// ExplainPlan Tests - pull --explain-plan
+// ============================================================================
+
+func TestExplainPlan_ConflictListsCandidatesAndConfigOrderWinner(t *testing.T) {
+	config := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name: "vendor-a",
+				URL:  "https://github.com/owner/a",
+				Specs: []types.BranchSpec{{
+					Ref: "main",
+					Mapping: []types.PathMapping{
+						{From: "util.go", To: "lib/util.go"},
+						{From: "only-a.go", To: "lib/only-a.go"},
+					},
+				}},
+			},
+			{
+				Name: "vendor-b",
+				URL:  "https://github.com/owner/b",
+				Specs: []types.BranchSpec{{
+					Ref: "v2",
+					Mapping: []types.PathMapping{
+						{From: "pkg/util.go", To: "lib/util.go"},
+					},
+				}},
+			},
+		},
+	}
+	svc := NewValidationService(&stubConfigStore{config: config})
+
+	plans, err := svc.ExplainPlan()
+	if err != nil {
+		t.Fatalf("ExplainPlan: %v", err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("Expected 1 contested destination, got %d: %+v", len(plans), plans)
+	}
+	plan := plans[0]
+	if plan.Path != "lib/util.go" {
+		t.Errorf("Path = %q, want lib/util.go", plan.Path)
+	}
+	if len(plan.Candidates) != 2 {
+		t.Fatalf("Expected both candidates listed, got %+v", plan.Candidates)
+	}
+	first, second := plan.Candidates[0], plan.Candidates[1]
+	if first.Vendor != "vendor-a" || second.Vendor != "vendor-b" {
+		t.Errorf("Candidates out of config order: %s, %s", first.Vendor, second.Vendor)
+	}
+	if first.Order >= second.Order {
+		t.Errorf("Expected increasing write order, got %d then %d", first.Order, second.Order)
+	}
+	if first.Winner || !second.Winner {
+		t.Errorf("Expected last config-order write (vendor-b) to win, got winners a=%v b=%v", first.Winner, second.Winner)
+	}
+	if second.Ref != "v2" || second.From != "pkg/util.go" {
+		t.Errorf("Winner details = %+v", second)
+	}
+	if !contains(plan.Reason, "overwrites") {
+		t.Errorf("Reason = %q, want overwrite explanation", plan.Reason)
+	}
+}
+
+func TestExplainPlan_InternalVendorsWriteFirst(t *testing.T) {
+	config := types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name: "external",
+				URL:  "https://github.com/owner/ext",
+				Specs: []types.BranchSpec{{
+					Ref:     "main",
+					Mapping: []types.PathMapping{{From: "a.go", To: "lib/a.go"}},
+				}},
+			},
+			{
+				Name:   "internal",
+				Source: SourceInternal,
+				Specs: []types.BranchSpec{{
+					Ref:     RefLocal,
+					Mapping: []types.PathMapping{{From: "src/a.go", To: "lib/a.go"}},
+				}},
+			},
+		},
+	}
+	svc := NewValidationService(&stubConfigStore{config: config})
+
+	plans, err := svc.ExplainPlan()
+	if err != nil {
+		t.Fatalf("ExplainPlan: %v", err)
+	}
+	if len(plans) != 1 || len(plans[0].Candidates) != 2 {
+		t.Fatalf("Expected one plan with 2 candidates, got %+v", plans)
+	}
+	cands := plans[0].Candidates
+	if cands[0].Vendor != "internal" || !cands[1].Winner || cands[1].Vendor != "external" {
+		t.Errorf("Expected internal first and external winning, got %+v", cands)
+	}
+}
+
+func TestExplainPlan_PositionSplicesAfterWinner(t *testing.T) {
+	config := types.VendorConfig{
+		Vendors: []types.VendorSpec{{
+			Name: "vendor-a",
+			URL:  "https://github.com/owner/a",
+			Specs: []types.BranchSpec{{
+				Ref: "main",
+				Mapping: []types.PathMapping{
+					{From: "snippet.go:L1-L2", To: "lib/api.go:L5-L6"},
+					{From: "api.go", To: "lib/api.go"},
+					{From: "extra.go:L3-L4", To: "lib/api.go:L10-L11"},
+				},
+			}},
+		}},
+	}
+	svc := NewValidationService(&stubConfigStore{config: config})
+
+	plans, err := svc.ExplainPlan()
+	if err != nil {
+		t.Fatalf("ExplainPlan: %v", err)
+	}
+	if len(plans) != 1 || len(plans[0].Candidates) != 3 {
+		t.Fatalf("Expected one plan with 3 candidates, got %+v", plans)
+	}
+	cands := plans[0].Candidates
+	if !cands[1].Winner || cands[0].Winner || cands[2].Winner {
+		t.Errorf("Expected the whole-file write to win, got %+v", cands)
+	}
+	if !cands[0].Position || cands[1].Position || !cands[2].Position {
+		t.Errorf("Position flags wrong: %+v", cands)
+	}
+	if !contains(plans[0].Reason, "1 later position mapping") {
+		t.Errorf("Reason = %q, want later splice note", plans[0].Reason)
+	}
+}
+
+func TestExplainPlan_NoConflicts(t *testing.T) {
+	config := createTestConfig(createTestVendorSpec("solo", "https://github.com/owner/solo", "main"))
+	svc := NewValidationService(&stubConfigStore{config: config})
+
+	plans, err := svc.ExplainPlan()
+	if err != nil {
+		t.Fatalf("ExplainPlan: %v", err)
+	}
+	if len(plans) != 0 {
+		t.Errorf("Expected no plans, got %+v", plans)
+	}
+}
+
+func TestValidateConfig_RejectsEmptyAllowedLicense(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfig := NewMockConfigStore(ctrl)
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{
+		AllowedLicenses: []string{"MIT", " "},
+		Vendors:         []types.VendorSpec{vendor},
+	}, nil)
+
+	err := NewValidationService(mockConfig).ValidateConfig()
+	if err == nil || !contains(err.Error(), "allowed_licenses[1]") {
+		t.Errorf("ValidateConfig() error = %v, want allowed_licenses[1] rejection", err)
+	}
+}
+
+func TestValidateConfig_LicenseOverrideMustBeSPDX(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Path().Return(ConfigPath).AnyTimes()
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	vendor.LicenseOverride = "Apache 2"
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{Vendors: []types.VendorSpec{vendor}}, nil)
+
+	err := NewValidationService(mockConfig).ValidateConfig()
+	if err == nil || !contains(err.Error(), "license_override") {
+		t.Errorf("ValidateConfig() error = %v, want license_override rejection", err)
+	}
+
+	vendor.LicenseOverride = "apache-2.0"
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{Vendors: []types.VendorSpec{vendor}}, nil)
+	if err := NewValidationService(mockConfig).ValidateConfig(); err != nil {
+		t.Errorf("ValidateConfig() with SPDX override (any case) error = %v", err)
+	}
+}
+
+func TestValidateConfig_RejectsBothPostSyncHooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfig := NewMockConfigStore(ctrl)
+	mockConfig.EXPECT().Path().Return(ConfigPath).AnyTimes()
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	vendor.PostSync = "gofmt -w ."
+	vendor.Hooks = &types.HookConfig{PostSync: "make generate"}
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{Vendors: []types.VendorSpec{vendor}}, nil)
+
+	err := NewValidationService(mockConfig).ValidateConfig()
+	if err == nil || !contains(err.Error(), "hooks.post_sync") {
+		t.Errorf("ValidateConfig() error = %v, want post_sync/hooks.post_sync rejection", err)
+	}
+}
+
+func TestValidateConfig_RejectsLicenseFilePath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfig := NewMockConfigStore(ctrl)
+
+	vendor := createTestVendorSpec("lib", "https://github.com/owner/lib", "main")
+	mockConfig.EXPECT().Load().Return(types.VendorConfig{
+		LicenseFiles: []string{"LICENSE", "docs/NOTICE"},
+		Vendors:      []types.VendorSpec{vendor},
+	}, nil)
+
+	err := NewValidationService(mockConfig).ValidateConfig()
+	if err == nil || !contains(err.Error(), "license_files[1]") {
+		t.Errorf("ValidateConfig() error = %v, want license_files[1] rejection", err)
+	}
+}
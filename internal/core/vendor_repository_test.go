@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -138,6 +139,7 @@ func TestGetLockHash(t *testing.T) {
 func TestSaveVendor_NewVendor(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	vendor := createTestVendorSpec("new-vendor", "https://github.com/owner/repo", "main")
 
@@ -188,6 +190,7 @@ func TestSaveVendor_NewVendor(t *testing.T) {
 func TestSaveVendor_UpdateExisting(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	// Start with existing vendor - Load() is called multiple times (by repository.Save and update.UpdateAll)
 	existingVendor := createTestVendorSpec("existing-vendor", "https://github.com/owner/old-repo", "main")
@@ -261,6 +264,7 @@ func TestSaveVendor_ConfigSaveFails(t *testing.T) {
 func TestRemoveVendor_HappyPath(t *testing.T) {
 	ctrl, git, fs, config, lock, license := setupMocks(t)
 	defer ctrl.Finish()
+	license.EXPECT().CheckLicense(gomock.Any()).Return("MIT", nil).AnyTimes()
 
 	// Start with 2 vendors
 	vendor1 := createTestVendorSpec("vendor-1", "https://github.com/owner/repo1", "main")
@@ -280,8 +284,8 @@ func TestRemoveVendor_HappyPath(t *testing.T) {
 		return nil
 	})
 
-	// Verify license file removal was attempted
-	fs.EXPECT().Remove(gomock.Any()).Return(nil)
+	// Verify license and notice file removal was attempted
+	fs.EXPECT().Remove(gomock.Any()).Return(nil).Times(1 + len(LicenseCaptureFiles))
 
 	// UpdateAll loads existing lock to preserve metadata
 	lock.EXPECT().Load().Return(types.VendorLock{}, nil).AnyTimes()
@@ -328,6 +332,9 @@ func TestRemoveVendor_VendorNotFound(t *testing.T) {
 	if !contains(err.Error(), "not found") {
 		t.Errorf("Expected 'not found' error, got: %v", err)
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrVendorNotFound), got: %v", err)
+	}
 }
 
 func TestRemoveVendor_ConfigLoadFails(t *testing.T) {
@@ -431,6 +438,9 @@ func TestFind_VendorNotFound(t *testing.T) {
 	if !contains(err.Error(), "not found") {
 		t.Errorf("Expected 'not found' error, got: %v", err)
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrVendorNotFound), got: %v", err)
+	}
 }
 
 func TestFind_ConfigLoadFails(t *testing.T) {
@@ -475,6 +485,9 @@ func TestFind_EmptyConfig(t *testing.T) {
 	if !contains(err.Error(), "not found") {
 		t.Errorf("Expected 'not found' error, got: %v", err)
 	}
+	if !errors.Is(err, ErrVendorNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrVendorNotFound), got: %v", err)
+	}
 }
 
 // ============================================================================
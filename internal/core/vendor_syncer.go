@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -101,6 +102,7 @@ type VendorSyncer struct {
 	fs             FileSystem
 	rootDir        string
 	ui             UICallback
+	logger         *loggerSlot // Shared with the sync and copy services (SetLogger)
 }
 
 // ServiceOverrides allows injecting custom service implementations into VendorSyncer.
@@ -142,22 +144,43 @@ func NewVendorSyncer(
 		overrides = &ServiceOverrides{}
 	}
 
+	// Interrupted directory copies resume from manifests under the vendor dir
+	if osFS, ok := fs.(*OSFileSystem); ok {
+		osFS.checkpointDir = filepath.Join(rootDir, CacheDir, CheckpointsDir)
+	}
+
 	// Build all default concrete services first (preserving internal wiring)
+	logger := &loggerSlot{}
 	repository := NewVendorRepository(configStore)
 	fileCopy := NewFileCopyService(fs)
+	fileCopy.logger = logger
 	license := NewLicenseService(licenseChecker, fs, rootDir, ui)
 	cache := NewFileCacheStore(fs, rootDir)
 	hooks := NewHookService(ui)
 	internalSyncSvc := NewInternalSyncService(configStore, lockStore, fileCopy, cache, fs, rootDir)
 	syncSvc := NewSyncService(configStore, lockStore, gitClient, fs, fileCopy, license, cache, hooks, ui, rootDir, internalSyncSvc)
-	updateSvc := NewUpdateService(configStore, lockStore, syncSvc, internalSyncSvc, cache, ui, rootDir)
+	syncSvc.logger = logger
+	// Pull-only callers (cascade) pass a nil checker; skip the license-change check then
+	var updateLicense LicenseServiceInterface
+	if licenseChecker != nil {
+		updateLicense = license
+	}
+	updateSvc := NewUpdateService(configStore, lockStore, syncSvc, internalSyncSvc, updateLicense, cache, ui, rootDir)
 	validation := NewValidationService(configStore)
 	explorer := NewRemoteExplorer(gitClient, fs)
 	updateChecker := NewUpdateChecker(configStore, lockStore, gitClient, fs, ui)
+	updateChecker.logger = logger
 	verifyService := NewVerifyService(configStore, lockStore, cache, fs, rootDir)
-	vulnScanner := VulnScannerInterface(NewVulnScanner(lockStore, configStore))
-	driftSvc := DriftServiceInterface(NewDriftService(configStore, lockStore, gitClient, fs, ui, rootDir))
-	auditSvc := AuditServiceInterface(NewAuditService(verifyService, vulnScanner, driftSvc, configStore, lockStore))
+	scanner := NewVulnScanner(lockStore, configStore)
+	scanner.cacheDir = filepath.Join(rootDir, cacheSubDir)
+	vulnScanner := VulnScannerInterface(scanner)
+	drift := NewDriftService(configStore, lockStore, gitClient, fs, ui, rootDir)
+	drift.logger = logger
+	driftSvc := DriftServiceInterface(drift)
+	ancestry := NewAncestryService(configStore, lockStore, gitClient, fs, ui)
+	ancestry.logger = logger
+	ancestrySvc := AncestryServiceInterface(ancestry)
+	auditSvc := AuditServiceInterface(NewAuditService(verifyService, vulnScanner, driftSvc, ancestrySvc, configStore, lockStore))
 	complianceSvc := ComplianceServiceInterface(NewComplianceService(configStore, lockStore, cache, fs, rootDir))
 	outdatedSvc := OutdatedServiceInterface(NewOutdatedService(configStore, lockStore, gitClient))
 
@@ -183,6 +206,7 @@ func NewVendorSyncer(
 		fs:             fs,
 		rootDir:        rootDir,
 		ui:             ui,
+		logger:         logger,
 	}
 
 	if overrides.Repository != nil {
@@ -228,12 +252,55 @@ func NewVendorSyncer(
 	return syncer
 }
 
+// SetLogger routes the syncer's leveled diagnostics to logger: each git
+// command run by a SystemGitClient, each file copied, and per-vendor sync
+// timings. nil restores the default no-op logger.
+func (s *VendorSyncer) SetLogger(logger Logger) {
+	if s.logger == nil {
+		s.logger = &loggerSlot{}
+	}
+	s.logger.set(logger)
+	if gc, ok := s.gitClient.(*SystemGitClient); ok {
+		gc.SetLogger(s.logger)
+	}
+}
+
 // Init initializes vendor directory structure and configures git hooks.
 // Init creates the .git-vendor/ tree, saves an empty config, and sets
 // core.hooksPath to .githooks if that directory already exists in the
 // project root. Hook setup is best-effort — failures do not fail Init()
 // since the core vendor directory setup already succeeded.
 func (s *VendorSyncer) Init() error {
+	return s.InitFormat("")
+}
+
+// InitFormat is Init writing the initial config in format (ConfigFormatYAML
+// or ConfigFormatTOML); "" keeps the config store's detected format. TOML is
+// refused while vendor.yml exists, since vendor.yml would take precedence.
+func (s *VendorSyncer) InitFormat(format string) error {
+	return s.InitWithOptions(InitOptions{Format: format})
+}
+
+// InitWithOptions is InitFormat(opts.Format) that can also scaffold the
+// project .gitignore (opts.Gitignore, see appendGitignore) and a README.md in
+// the vendor directory (opts.Readme, see writeReadme).
+func (s *VendorSyncer) InitWithOptions(opts InitOptions) error {
+	format := opts.Format
+	if format != "" {
+		store, ok := s.configStore.(interface{ SetFormat(string) error })
+		if !ok {
+			return fmt.Errorf("config store does not support format %q", format)
+		}
+		if format == ConfigFormatTOML {
+			if _, err := s.fs.Stat(filepath.Join(s.rootDir, ConfigFile)); err == nil {
+				return fmt.Errorf("%s already exists; remove it to switch to %s", filepath.Join(s.rootDir, ConfigFile), ConfigFileTOML)
+			}
+		}
+		if err := store.SetFormat(format); err != nil {
+			return err
+		}
+	}
+
 	if err := s.fs.MkdirAll(s.rootDir, 0755); err != nil {
 		return fmt.Errorf("create vendor directory: %w", err)
 	}
@@ -245,6 +312,25 @@ func (s *VendorSyncer) Init() error {
 		return fmt.Errorf("save initial config: %w", err)
 	}
 
+	if opts.Gitignore {
+		added, err := s.appendGitignore()
+		if err != nil {
+			return err
+		}
+		if len(added) > 0 {
+			s.ui.ShowSuccess(fmt.Sprintf("Added %s to .gitignore", Pluralize(len(added), "entry", "entries")))
+		}
+	}
+	if opts.Readme {
+		written, err := s.writeReadme()
+		if err != nil {
+			return err
+		}
+		if written {
+			s.ui.ShowSuccess("Wrote " + filepath.Join(s.rootDir, "README.md"))
+		}
+	}
+
 	// Set core.hooksPath if .githooks/ exists in the project root.
 	if s.gitClient != nil {
 		projectRoot := filepath.Dir(s.rootDir)
@@ -262,14 +348,65 @@ func (s *VendorSyncer) Init() error {
 	return nil
 }
 
-// AddVendor adds a new vendor with license compliance check
+// AddVendor adds a new vendor with license compliance check.
+// A spec with LicenseOverride skips license detection: the declared license is
+// accepted and recorded as spec.License (and so as the lock's license_spdx).
+// Each ref is fetched first and a mapping whose from path is missing from it
+// fails with PathNotFoundError before vendor.yml is written. Adding a vendor
+// that already exists merges spec's refs into it (mergeVendorSpecs). Uses
+// context.Background() for the same reason as SaveVendor.
 func (s *VendorSyncer) AddVendor(spec *types.VendorSpec) error {
+	return s.AddVendorWithOptions(spec, AddOptions{})
+}
+
+// AddOptions configures AddVendorWithOptions.
+type AddOptions struct {
+	Local bool // Allow file:// and local path vendor URLs, as sync --local does
+}
+
+// AddVendorWithOptions is AddVendor with opts applied to the source check.
+func (s *VendorSyncer) AddVendorWithOptions(spec *types.VendorSpec, opts AddOptions) error {
 	// Check if vendor already exists
 	exists, err := s.repository.Exists(spec.Name)
 	if err != nil {
 		exists = false
 	}
 
+	// Adding another ref of an existing vendor (e.g. v2 beside v1) keeps
+	// the refs it already tracks
+	var merged *types.VendorSpec
+	if exists {
+		if current, err := s.repository.Find(spec.Name); err == nil && current != nil {
+			if merged, err = mergeVendorSpecs(current, spec); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Catch mistyped from paths before anything is written to vendor.yml
+	checked := spec
+	if merged != nil {
+		added := *merged
+		added.Specs = spec.Specs
+		checked = &added
+	}
+	if err := s.checkMappingSources(context.Background(), checked, opts.Local); err != nil {
+		return err
+	}
+	if merged != nil {
+		return s.SaveVendor(merged)
+	}
+
+	// If new vendor with a declared license, accept it without detection
+	if !exists && spec.LicenseOverride != "" {
+		license, err := s.license.AcceptOverride(spec.LicenseOverride)
+		if err != nil {
+			return fmt.Errorf("accept license override for %s: %w", spec.Name, err)
+		}
+		spec.License = license
+		return s.SaveVendor(spec)
+	}
+
 	// If new vendor, check license compliance
 	if !exists {
 		detectedLicense, err := s.license.CheckCompliance(spec.URL)
@@ -282,6 +419,33 @@ func (s *VendorSyncer) AddVendor(spec *types.VendorSpec) error {
 	return s.SaveVendor(spec)
 }
 
+// mergeVendorSpecs returns current with added's specs merged in: a spec for a
+// ref current already tracks replaces that spec, any other ref is appended,
+// so one vendor can hold several versions of a library at distinct
+// destinations. The other vendor fields are current's. Fails when added names
+// a different repository URL.
+func mergeVendorSpecs(current, added *types.VendorSpec) (*types.VendorSpec, error) {
+	if added.URL != "" && added.URL != current.URL {
+		return nil, fmt.Errorf("vendor '%s' already tracks %s, not %s; choose another name or run 'git-vendor edit'", current.Name, SanitizeURL(current.URL), SanitizeURL(added.URL))
+	}
+	merged := *current
+	merged.Specs = append([]types.BranchSpec(nil), current.Specs...)
+	for _, spec := range added.Specs {
+		replaced := false
+		for i := range merged.Specs {
+			if merged.Specs[i].Ref == spec.Ref {
+				merged.Specs[i] = spec
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Specs = append(merged.Specs, spec)
+		}
+	}
+	return &merged, nil
+}
+
 // SaveVendor saves or updates a vendor spec.
 // Uses context.Background() because SaveVendor is called from interactive wizards
 // where signal handling is not wired.
@@ -301,33 +465,55 @@ func (s *VendorSyncer) RemoveVendor(name string) error {
 		return err // Already a structured VendorNotFoundError
 	}
 
-	// Remove license file
-	licensePath := filepath.Join(s.rootDir, LicensesDir, name+".txt")
-	_ = s.fs.Remove(licensePath) //nolint:errcheck // cleanup operation, error not critical
+	// Remove license file from the configured license_dir (default when config can't be read)
+	//nolint:errcheck // Zero config falls back to the default license location
+	config, _ := s.repository.GetConfig()
+	for _, licensePath := range vendorLicenseCopies(ResolveLicenseDir(s.rootDir, config), name, ResolveLicenseFiles(config)) {
+		_ = s.fs.Remove(licensePath) //nolint:errcheck // cleanup operation, error not critical
+	}
 
 	// Update lockfile
 	return s.update.UpdateAll(context.Background())
 }
 
-// syncWithAutoUpdate calls sync.Sync and falls back to UpdateAllWithOptions on stale lockfile errors.
-// When a locked commit no longer exists in the remote (e.g., after force-push),
-// syncWithAutoUpdate regenerates the lockfile via UpdateAllWithOptions, which also re-syncs files.
+// syncWithAutoUpdate calls sync.Sync and, with opts.RetryOnStale, self-heals a
+// stale lockfile: when a locked commit no longer exists in the remote (e.g.,
+// after force-push), the vendor named in the StaleCommitError is re-resolved
+// via UpdateAllWithOptions and the sync is retried once. Without
+// opts.RetryOnStale, the StaleCommitError is returned unchanged with its
+// "run update" guidance.
 func (s *VendorSyncer) syncWithAutoUpdate(ctx context.Context, opts SyncOptions) error {
 	err := s.sync.Sync(ctx, opts)
-	if err == nil {
-		return nil
-	}
-	if !IsStaleCommit(err) {
+	var staleErr *StaleCommitError
+	if err == nil || !opts.RetryOnStale || !errors.As(err, &staleErr) || staleErr.VendorName == "" {
 		return err
 	}
-	fmt.Println("⚠ Stale lockfile detected — auto-updating...")
+	fmt.Printf("⚠ Stale lockfile detected for %s — auto-updating...\n", staleErr.VendorName)
 	if updateErr := s.update.UpdateAllWithOptions(ctx, UpdateOptions{
-		Local:      opts.Local,
-		VendorName: opts.VendorName,
-		Group:      opts.GroupName,
+		Local:         opts.Local,
+		VendorName:    staleErr.VendorName,
+		FetchAttempts: opts.FetchAttempts,
+		AllowHooks:    opts.AllowHooks,
+		CheckLicense:  opts.CheckLicense,
+		StrictLicense: opts.StrictLicense,
 	}); updateErr != nil {
 		return fmt.Errorf("auto-update after stale commit: %w", updateErr)
 	}
+
+	hash := staleErr.CommitHash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	fmt.Printf("✓ Re-resolved %s@%s (locked commit %s was stale) to the latest upstream commit\n",
+		staleErr.VendorName, staleErr.Ref, hash)
+
+	// Retry once from the start; the report only keeps the retried run
+	if opts.Report != nil {
+		opts.Report.Vendors = nil
+	}
+	if err := s.sync.Sync(ctx, opts); err != nil {
+		return fmt.Errorf("sync after re-resolving %s: %w", staleErr.VendorName, err)
+	}
 	return nil
 }
 
@@ -362,6 +548,8 @@ func (s *VendorSyncer) SyncWithFullOpts(ctx context.Context, opts SyncOptions) e
 			Local:      opts.Local,
 			VendorName: opts.VendorName,
 			Group:      opts.GroupName,
+
+			ExcludeVendors: opts.ExcludeVendors,
 		}); err != nil {
 			return fmt.Errorf("generate lockfile: %w", err)
 		}
@@ -433,6 +621,11 @@ func (s *VendorSyncer) DetectConflicts() ([]types.PathConflict, error) {
 	return s.validation.DetectConflicts()
 }
 
+// ExplainPlan reports the write order and winner for destinations targeted by multiple mappings
+func (s *VendorSyncer) ExplainPlan() ([]types.DestinationPlan, error) {
+	return s.validation.ExplainPlan()
+}
+
 // FetchRepoDir fetches directory listing from remote repository.
 // ctx controls cancellation of git clone/fetch/ls-tree operations.
 func (s *VendorSyncer) FetchRepoDir(ctx context.Context, url, ref, subdir string) ([]string, error) {
@@ -573,6 +766,12 @@ func (s *VendorSyncer) LicenseReport(policyService LicensePolicyServiceInterface
 	return policyService.GenerateReport(failOn)
 }
 
+// CheckOnly runs the validate --check-only gate (config, conflicts, coherence,
+// license policy) using the provided policy service.
+func (s *VendorSyncer) CheckOnly(ctx context.Context, policyService LicensePolicyServiceInterface) (*types.CheckOnlyResult, error) {
+	return NewCheckOnlyService(s.validation, s.verifyService, policyService).Run(ctx)
+}
+
 // Drift detects drift between vendored files and their origin.
 // ctx controls cancellation of git operations (clone, fetch, checkout).
 func (s *VendorSyncer) Drift(ctx context.Context, opts DriftOptions) (*types.DriftResult, error) {
@@ -610,6 +809,14 @@ func (s *VendorSyncer) Accept(opts AcceptOptions) (*AcceptResult, error) {
 	return svc.Accept(opts)
 }
 
+// UpdateBaseline rewrites lock file hashes for modified files matching patterns
+// to their current on-disk hashes (verify --baseline-update).
+func (s *VendorSyncer) UpdateBaseline(patterns []string) (*BaselineResult, error) {
+	cache := NewFileCacheStore(s.fs, s.rootDir)
+	svc := NewAcceptService(s.lockStore, cache)
+	return svc.UpdateBaseline(patterns)
+}
+
 // MigrateLockfile updates an existing lockfile to add missing metadata fields.
 // For fields that can't be computed (VendoredAt, VendoredBy), it uses best guesses.
 // Returns the number of entries migrated and any error.
@@ -628,7 +835,7 @@ func (s *VendorSyncer) MigrateLockfile() (int, error) {
 	// Build vendor config map for license lookup
 	vendorLicenses := make(map[string]string)
 	for _, v := range config.Vendors {
-		vendorLicenses[v.Name] = v.License
+		vendorLicenses[v.Name] = ResolveVendorLicense(v)
 	}
 
 	migrated := 0
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/EmundoT/git-vendor/internal/types"
@@ -18,6 +20,8 @@ import (
 type stubRepositoryService struct {
 	existsResult bool
 	existsErr    error
+	found        *types.VendorSpec
+	saved        *types.VendorSpec
 	saveErr      error
 	deleteErr    error
 	config       types.VendorConfig
@@ -25,7 +29,7 @@ type stubRepositoryService struct {
 }
 
 func (s *stubRepositoryService) Find(_ string) (*types.VendorSpec, error) {
-	return nil, nil
+	return s.found, nil
 }
 
 func (s *stubRepositoryService) FindAll() ([]types.VendorSpec, error) {
@@ -36,7 +40,8 @@ func (s *stubRepositoryService) Exists(_ string) (bool, error) {
 	return s.existsResult, s.existsErr
 }
 
-func (s *stubRepositoryService) Save(_ *types.VendorSpec) error {
+func (s *stubRepositoryService) Save(vendor *types.VendorSpec) error {
+	s.saved = vendor
 	return s.saveErr
 }
 
@@ -51,14 +56,20 @@ func (s *stubRepositoryService) GetConfig() (types.VendorConfig, error) {
 // stubSyncService implements SyncServiceInterface for testing.
 type stubSyncService struct {
 	syncErr       error
+	syncErrCalls  int // When > 0, syncErr is returned only by the first syncErrCalls calls
 	syncVendorErr error
 	syncCalled    bool
+	syncCount     int
 	syncOpts      SyncOptions
 }
 
 func (s *stubSyncService) Sync(_ context.Context, opts SyncOptions) error {
 	s.syncCalled = true
+	s.syncCount++
 	s.syncOpts = opts
+	if s.syncErrCalls > 0 && s.syncCount > s.syncErrCalls {
+		return nil
+	}
 	return s.syncErr
 }
 
@@ -70,7 +81,8 @@ func (s *stubSyncService) SyncVendor(_ context.Context, _ *types.VendorSpec, _ m
 type stubUpdateService struct {
 	updateErr error
 	callCount int
-	lastOpts  UpdateOptions // Captures last UpdateOptions passed to UpdateAllWithOptions
+	lastOpts  UpdateOptions             // Captures last UpdateOptions passed to UpdateAllWithOptions
+	onUpdate  func(UpdateOptions) error // Optional: runs in UpdateAllWithOptions, e.g. to write a lock
 }
 
 func (s *stubUpdateService) UpdateAll(_ context.Context) error {
@@ -81,6 +93,9 @@ func (s *stubUpdateService) UpdateAll(_ context.Context) error {
 func (s *stubUpdateService) UpdateAllWithOptions(_ context.Context, opts UpdateOptions) error {
 	s.callCount++
 	s.lastOpts = opts
+	if s.onUpdate != nil {
+		return s.onUpdate(opts)
+	}
 	return s.updateErr
 }
 
@@ -99,6 +114,10 @@ func (s *stubValidationService) DetectConflicts() ([]types.PathConflict, error)
 	return s.conflicts, s.conflictErr
 }
 
+func (s *stubValidationService) ExplainPlan() ([]types.DestinationPlan, error) {
+	return nil, s.conflictErr
+}
+
 // stubUpdateCheckerService implements UpdateCheckerInterface for testing.
 type stubUpdateCheckerService struct {
 	results []types.UpdateCheckResult
@@ -119,6 +138,10 @@ func (s *stubVerifyService) Verify(_ context.Context) (*types.VerifyResult, erro
 	return s.result, s.err
 }
 
+func (s *stubVerifyService) VerifyWithOptions(_ context.Context, _ VerifyOptions) (*types.VerifyResult, error) {
+	return s.result, s.err
+}
+
 // stubVulnScanner implements VulnScannerInterface for testing.
 type stubVulnScanner struct {
 	result *types.ScanResult
@@ -322,6 +345,55 @@ func TestVendorSyncer_AddVendor_NewVendor(t *testing.T) {
 	}
 }
 
+func TestVendorSyncer_AddVendor_LicenseOverrideSkipsDetection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Detector finds nothing; license_override must make that irrelevant
+	checker := NewMockLicenseChecker(ctrl)
+	checker.EXPECT().CheckLicense(gomock.Any()).Return("", nil).AnyTimes()
+	// The declared license still goes through the allowed-list check
+	checker.EXPECT().IsAllowed("MIT").Return(true)
+
+	repo := &stubRepositoryService{existsResult: false}
+	update := &stubUpdateService{}
+	syncer := newTestSyncer(nil, nil, nil, &ServiceOverrides{
+		Repository: repo,
+		License:    NewLicenseService(checker, nil, ".git-vendor", &SilentUICallback{}),
+		Update:     update,
+	})
+
+	spec := &types.VendorSpec{
+		Name:            "new-vendor",
+		URL:             "https://github.com/owner/repo",
+		LicenseOverride: "MIT",
+	}
+
+	if err := syncer.AddVendor(spec); err != nil {
+		t.Fatalf("AddVendor() error = %v", err)
+	}
+	if spec.License != "MIT" || spec.LicenseOverride != "MIT" {
+		t.Errorf("License = %q, LicenseOverride = %q; want both MIT", spec.License, spec.LicenseOverride)
+	}
+	if got := ResolveVendorLicense(*spec); got != "MIT" {
+		t.Errorf("ResolveVendorLicense() = %q, want MIT (recorded as lock license_spdx)", got)
+	}
+}
+
+func TestVendorSyncer_AddVendor_UnknownLicenseOverride(t *testing.T) {
+	syncer := newTestSyncer(nil, nil, nil, &ServiceOverrides{
+		Repository: &stubRepositoryService{existsResult: false},
+		License:    NewLicenseService(nil, nil, ".git-vendor", &SilentUICallback{}),
+		Update:     &stubUpdateService{},
+	})
+
+	spec := &types.VendorSpec{Name: "new-vendor", URL: "https://github.com/owner/repo", LicenseOverride: "NOT-A-LICENSE"}
+	err := syncer.AddVendor(spec)
+	if err == nil || !contains(err.Error(), "not a recognized SPDX license identifier") {
+		t.Errorf("AddVendor() error = %v, want unrecognized SPDX rejection", err)
+	}
+}
+
 func TestVendorSyncer_AddVendor_ExistingVendor(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -351,6 +423,133 @@ func TestVendorSyncer_AddVendor_ExistingVendor(t *testing.T) {
 	}
 }
 
+func TestVendorSyncer_AddVendor_SecondRefMergesIntoExistingVendor(t *testing.T) {
+	current := &types.VendorSpec{
+		Name:    "lib",
+		URL:     "https://github.com/owner/lib",
+		License: "MIT",
+		Specs:   []types.BranchSpec{{Ref: "v1", Mapping: []types.PathMapping{{From: "src", To: "lib/v1"}}}},
+	}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	git := NewMockGitClient(ctrl)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	// The added refs are checked against the existing vendor's URL
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/lib").Return(nil).AnyTimes()
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, gomock.Any()).Return(nil).AnyTimes()
+	git.EXPECT().ListTree(gomock.Any(), gomock.Any(), FetchHead, "").Return([]string{"src/"}, nil).AnyTimes()
+
+	repo := &stubRepositoryService{existsResult: true, found: current}
+	syncer := NewVendorSyncer(nil, nil, git, NewOSFileSystem(), nil, "/test/root", &SilentUICallback{}, &ServiceOverrides{
+		Repository: repo,
+		Update:     &stubUpdateService{},
+	})
+
+	added := &types.VendorSpec{
+		Name:  "lib",
+		URL:   "https://github.com/owner/lib",
+		Specs: []types.BranchSpec{{Ref: "v2", Mapping: []types.PathMapping{{From: "src", To: "lib/v2"}}}},
+	}
+	if err := syncer.AddVendor(added); err != nil {
+		t.Fatalf("AddVendor() error = %v", err)
+	}
+
+	saved := repo.saved
+	if saved == nil || len(saved.Specs) != 2 || saved.Specs[0].Ref != "v1" || saved.Specs[1].Ref != "v2" {
+		t.Fatalf("saved = %+v, want specs v1 and v2", saved)
+	}
+	if saved.Specs[1].Mapping[0].To != "lib/v2" || saved.License != "MIT" {
+		t.Errorf("saved = %+v, want v2 at lib/v2 and the existing license kept", saved)
+	}
+
+	// Re-adding a tracked ref replaces that spec instead of duplicating it
+	repo.found = saved
+	readd := &types.VendorSpec{Name: "lib", Specs: []types.BranchSpec{{Ref: "v1", Mapping: []types.PathMapping{{From: "src", To: "third_party/lib/v1"}}}}}
+	if err := syncer.AddVendor(readd); err != nil {
+		t.Fatalf("AddVendor() re-add error = %v", err)
+	}
+	if got := repo.saved.Specs; len(got) != 2 || got[0].Mapping[0].To != "third_party/lib/v1" {
+		t.Errorf("specs after re-adding v1 = %+v, want v1 replaced in place", got)
+	}
+
+	// A different repository under the same name is refused
+	other := &types.VendorSpec{Name: "lib", URL: "https://github.com/someone/else", Specs: added.Specs}
+	if err := syncer.AddVendor(other); err == nil || !contains(err.Error(), "already tracks") {
+		t.Errorf("AddVendor() with another URL error = %v, want refusal", err)
+	}
+}
+
+func TestVendorSyncer_AddVendor_RejectsMissingFromPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	git := NewMockGitClient(ctrl)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "https://github.com/owner/repo").Return(nil).Times(2)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(nil).Times(2)
+	git.EXPECT().ListTree(gomock.Any(), gomock.Any(), FetchHead, "").Return([]string{"README.md", "src/"}, nil).AnyTimes()
+	git.EXPECT().ListTree(gomock.Any(), gomock.Any(), FetchHead, "src").Return([]string{"util.go"}, nil).AnyTimes()
+
+	repo := &stubRepositoryService{saveErr: errors.New("vendor.yml written")}
+	syncer := NewVendorSyncer(nil, nil, git, NewOSFileSystem(), nil, "/test/root", &SilentUICallback{}, &ServiceOverrides{
+		Repository: repo,
+		License:    &stubLicenseService{},
+		Update:     &stubUpdateService{},
+	})
+
+	spec := &types.VendorSpec{Name: "new-vendor", URL: "https://github.com/owner/repo", Specs: []types.BranchSpec{
+		{Ref: "main", Mapping: []types.PathMapping{{From: "README.md"}, {From: "src/utli.go", To: "lib/util.go"}}},
+	}}
+	err := syncer.AddVendor(spec)
+	var notFound *PathNotFoundError
+	if !errors.As(err, &notFound) || notFound.Path != "src/utli.go" || notFound.Ref != "main" {
+		t.Fatalf("AddVendor() error = %v, want PathNotFoundError for src/utli.go@main before saving", err)
+	}
+
+	// Existing file and directory sources (with a position specifier) pass
+	spec.Specs[0].Mapping = []types.PathMapping{{From: "src"}, {From: "src/util.go:L1-L5", To: "lib/util.go"}}
+	if err := syncer.AddVendor(spec); err == nil || err.Error() != "save vendor new-vendor: vendor.yml written" {
+		t.Errorf("AddVendor() error = %v, want it to reach Save", err)
+	}
+}
+
+func TestVendorSyncer_AddVendor_LocalSiblingSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parent := t.TempDir()
+	project := filepath.Join(parent, "project")
+	sibling := filepath.Join(parent, "sib")
+	for _, dir := range []string{project, sibling} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git := NewMockGitClient(ctrl)
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", "file://"+filepath.ToSlash(sibling)).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", 1, "main").Return(nil)
+	git.EXPECT().ListTree(gomock.Any(), gomock.Any(), FetchHead, "").Return([]string{"src/"}, nil)
+
+	repo := &stubRepositoryService{saveErr: errors.New("vendor.yml written")}
+	syncer := NewVendorSyncer(nil, nil, git, NewOSFileSystem(), nil, filepath.Join(project, VendorDir), &SilentUICallback{}, &ServiceOverrides{
+		Repository: repo,
+		License:    &stubLicenseService{},
+		Update:     &stubUpdateService{},
+	})
+
+	spec := &types.VendorSpec{Name: "sib", URL: "../sib", LicenseOverride: "MIT", Specs: []types.BranchSpec{
+		{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: "lib"}}},
+	}}
+	if err := syncer.AddVendor(spec); err == nil || !strings.Contains(err.Error(), "--local") {
+		t.Fatalf("AddVendor() without Local error = %v, want a --local hint", err)
+	}
+	if err := syncer.AddVendorWithOptions(spec, AddOptions{Local: true}); err == nil || err.Error() != "save vendor sib: vendor.yml written" {
+		t.Errorf("AddVendorWithOptions(Local) error = %v, want it to reach Save", err)
+	}
+}
+
 // ============================================================================
 // VendorSyncer.RemoveVendor tests
 // ============================================================================
@@ -364,7 +563,8 @@ func TestVendorSyncer_RemoveVendor_Success(t *testing.T) {
 	repo := &stubRepositoryService{}
 	update := &stubUpdateService{}
 
-	mockFS.EXPECT().Remove(gomock.Any()).Return(nil) // license file removal
+	// License copy plus one candidate per license/notice capture file
+	mockFS.EXPECT().Remove(gomock.Any()).Return(nil).Times(1 + len(LicenseCaptureFiles))
 
 	syncer := newTestSyncer(nil, nil, mockFS, &ServiceOverrides{
 		Repository: repo,
@@ -377,6 +577,29 @@ func TestVendorSyncer_RemoveVendor_Success(t *testing.T) {
 	}
 }
 
+func TestVendorSyncer_RemoveVendor_CustomLicenseDir(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFS := NewMockFileSystem(ctrl)
+
+	repo := &stubRepositoryService{config: types.VendorConfig{LicenseDir: "legal"}}
+	update := &stubUpdateService{}
+
+	mockFS.EXPECT().Remove(filepath.Join("/test", "legal", "test-vendor.txt")).Return(nil)
+	mockFS.EXPECT().Remove(filepath.Join("/test", "legal", "test-vendor.NOTICE")).Return(nil)
+	mockFS.EXPECT().Remove(gomock.Any()).Return(os.ErrNotExist).Times(len(LicenseCaptureFiles) - 1)
+
+	syncer := newTestSyncer(nil, nil, mockFS, &ServiceOverrides{
+		Repository: repo,
+		Update:     update,
+	})
+
+	if err := syncer.RemoveVendor("test-vendor"); err != nil {
+		t.Fatalf("RemoveVendor() error = %v", err)
+	}
+}
+
 func TestVendorSyncer_RemoveVendor_NotFound(t *testing.T) {
 	repo := &stubRepositoryService{
 		deleteErr: NewVendorNotFoundError("missing"),
@@ -583,7 +806,8 @@ func TestVendorSyncer_Sync_StaleCommitAutoUpdates(t *testing.T) {
 	}, nil)
 
 	syncSvc := &stubSyncService{
-		syncErr: NewStaleCommitError("stale123", "v1", "main"),
+		syncErr:      NewStaleCommitError("stale123", "v1", "main"),
+		syncErrCalls: 1,
 	}
 	updateSvc := &stubUpdateService{}
 
@@ -592,9 +816,9 @@ func TestVendorSyncer_Sync_StaleCommitAutoUpdates(t *testing.T) {
 		Update: updateSvc,
 	})
 
-	err := syncer.Sync(context.Background())
+	err := syncer.SyncWithFullOpts(context.Background(), SyncOptions{RetryOnStale: true})
 	if err != nil {
-		t.Fatalf("Sync() expected nil after auto-update, got: %v", err)
+		t.Fatalf("SyncWithFullOpts(RetryOnStale) expected nil after auto-update, got: %v", err)
 	}
 	if updateSvc.callCount != 1 {
 		t.Errorf("expected UpdateAll called once, got %d", updateSvc.callCount)
@@ -620,9 +844,9 @@ func TestVendorSyncer_Sync_StaleCommitAutoUpdateFails(t *testing.T) {
 		Update: updateSvc,
 	})
 
-	err := syncer.Sync(context.Background())
+	err := syncer.SyncWithFullOpts(context.Background(), SyncOptions{RetryOnStale: true})
 	if err == nil {
-		t.Fatal("Sync() expected error when auto-update fails")
+		t.Fatal("SyncWithFullOpts(RetryOnStale) expected error when auto-update fails")
 	}
 	if !contains(err.Error(), "auto-update after stale commit") {
 		t.Errorf("Sync() error = %q, want containing 'auto-update after stale commit'", err.Error())
@@ -655,7 +879,7 @@ func TestVendorSyncer_Sync_NonStaleErrorDoesNotAutoUpdate(t *testing.T) {
 	}
 }
 
-func TestVendorSyncer_SyncWithOptions_StaleCommitAutoUpdates(t *testing.T) {
+func TestVendorSyncer_SyncWithOptions_StaleCommitFailsWithoutRetry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -675,11 +899,133 @@ func TestVendorSyncer_SyncWithOptions_StaleCommitAutoUpdates(t *testing.T) {
 	})
 
 	err := syncer.SyncWithOptions(context.Background(), "v1", false, false)
+	if !IsStaleCommit(err) {
+		t.Fatalf("SyncWithOptions() error = %v, want StaleCommitError without RetryOnStale", err)
+	}
+	if updateSvc.callCount != 0 {
+		t.Errorf("expected no update without RetryOnStale, got %d calls", updateSvc.callCount)
+	}
+}
+
+func TestVendorSyncer_SyncWithFullOpts_StaleCommitRetriesOnceAndReportsReResolve(t *testing.T) {
+	lock := &stubLockStore{
+		lock: types.VendorLock{
+			Vendors: []types.LockDetails{{Name: "v1", Ref: "main", CommitHash: "stale1234567"}},
+		},
+	}
+	syncSvc := &stubSyncService{
+		syncErr:      NewStaleCommitError("stale1234567", "v1", "main"),
+		syncErrCalls: 1,
+	}
+	updateSvc := &stubUpdateService{}
+
+	syncer := newTestSyncer(nil, lock, nil, &ServiceOverrides{
+		Sync:   syncSvc,
+		Update: updateSvc,
+	})
+
+	// Capture stdout to verify the re-resolve is recorded
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := syncer.SyncWithFullOpts(context.Background(), SyncOptions{RetryOnStale: true})
+
+	w.Close()
+	os.Stdout = old
+
+	buf := make([]byte, 4096)
+	n, _ := r.Read(buf)
+	output := string(buf[:n])
+
 	if err != nil {
-		t.Fatalf("SyncWithOptions() expected nil after auto-update, got: %v", err)
+		t.Fatalf("SyncWithFullOpts() expected nil after self-heal, got: %v", err)
 	}
-	if updateSvc.callCount != 1 {
-		t.Errorf("expected UpdateAll called once, got %d", updateSvc.callCount)
+	if updateSvc.callCount != 1 || updateSvc.lastOpts.VendorName != "v1" {
+		t.Errorf("expected a single update of v1, got %d (vendor %q)", updateSvc.callCount, updateSvc.lastOpts.VendorName)
+	}
+	if syncSvc.syncCount != 2 {
+		t.Errorf("expected the sync to be retried once, got %d syncs", syncSvc.syncCount)
+	}
+	if !contains(output, "Re-resolved v1@main") {
+		t.Errorf("expected output to record re-resolution, got:\n%s", output)
+	}
+}
+
+func TestVendorSyncer_SyncWithFullOpts_StaleCommitUpdateKeepsLicenseOptions(t *testing.T) {
+	lock := &stubLockStore{
+		lock: types.VendorLock{
+			Vendors: []types.LockDetails{{Name: "v1", Ref: "main", CommitHash: "stale123"}},
+		},
+	}
+	syncSvc := &stubSyncService{
+		syncErr:      NewStaleCommitError("stale123", "v1", "main"),
+		syncErrCalls: 1,
+	}
+	updateSvc := &stubUpdateService{}
+
+	syncer := newTestSyncer(nil, lock, nil, &ServiceOverrides{
+		Sync:   syncSvc,
+		Update: updateSvc,
+	})
+
+	err := syncer.SyncWithFullOpts(context.Background(), SyncOptions{RetryOnStale: true, CheckLicense: true, StrictLicense: true})
+	if err != nil {
+		t.Fatalf("SyncWithFullOpts() expected nil after self-heal, got: %v", err)
+	}
+	if !updateSvc.lastOpts.CheckLicense || !updateSvc.lastOpts.StrictLicense {
+		t.Errorf("self-heal update dropped the license options: %+v", updateSvc.lastOpts)
+	}
+}
+
+func TestVendorSyncer_SyncWithFullOpts_StaleCommitRetriedOnlyOnce(t *testing.T) {
+	lock := &stubLockStore{
+		lock: types.VendorLock{
+			Vendors: []types.LockDetails{{Name: "v1", Ref: "main", CommitHash: "stale123"}},
+		},
+	}
+	syncSvc := &stubSyncService{syncErr: NewStaleCommitError("stale123", "v1", "main")}
+	updateSvc := &stubUpdateService{}
+
+	syncer := newTestSyncer(nil, lock, nil, &ServiceOverrides{
+		Sync:   syncSvc,
+		Update: updateSvc,
+	})
+
+	err := syncer.SyncWithFullOpts(context.Background(), SyncOptions{RetryOnStale: true})
+	if !IsStaleCommit(err) || !contains(err.Error(), "sync after re-resolving v1") {
+		t.Fatalf("SyncWithFullOpts() error = %v, want the retry's StaleCommitError", err)
+	}
+	if updateSvc.callCount != 1 || syncSvc.syncCount != 2 {
+		t.Errorf("got %d updates and %d syncs, want 1 and 2", updateSvc.callCount, syncSvc.syncCount)
+	}
+}
+
+func TestVendorSyncer_SyncWithFullOpts_WithoutRetryOnStaleFails(t *testing.T) {
+	lock := &stubLockStore{
+		lock: types.VendorLock{
+			Vendors: []types.LockDetails{{Name: "v1", Ref: "main", CommitHash: "stale123"}},
+		},
+	}
+	syncSvc := &stubSyncService{
+		syncErr: NewStaleCommitError("stale123", "v1", "main"),
+	}
+	updateSvc := &stubUpdateService{}
+
+	syncer := newTestSyncer(nil, lock, nil, &ServiceOverrides{
+		Sync:   syncSvc,
+		Update: updateSvc,
+	})
+
+	err := syncer.SyncWithFullOpts(context.Background(), SyncOptions{})
+	if !IsStaleCommit(err) || !errors.Is(err, ErrStaleCommit) {
+		t.Fatalf("SyncWithFullOpts() error = %v, want StaleCommitError", err)
+	}
+	if !contains(err.Error(), "Run 'git-vendor update'") {
+		t.Errorf("error should keep the existing update guidance, got: %q", err.Error())
+	}
+	if updateSvc.callCount != 0 {
+		t.Errorf("expected no update without RetryOnStale, got %d calls", updateSvc.callCount)
 	}
 }
 
@@ -1074,13 +1420,14 @@ func TestSyncWithFullOpts_NoLockfile_PassesGroupFilter(t *testing.T) {
 }
 
 // TestSyncWithAutoUpdate_StaleCommit_PassesVendorFilter verifies that when
-// sync encounters a stale commit error, the auto-update fallback passes
-// VendorName through to UpdateAllWithOptions.
+// sync encounters a stale commit error, the auto-update fallback updates the
+// vendor named in the error (not the sync's own filter) with Local passed through.
 func TestSyncWithAutoUpdate_StaleCommit_PassesVendorFilter(t *testing.T) {
 	update := &stubUpdateService{}
-	// stubSyncService that returns a StaleCommitError
+	// stubSyncService that returns a StaleCommitError once
 	syncSvc := &stubSyncService{
-		syncErr: &StaleCommitError{CommitHash: "deadbeef", VendorName: "vendor-a", Ref: "main"},
+		syncErr:      &StaleCommitError{CommitHash: "deadbeef", VendorName: "vendor-a", Ref: "main"},
+		syncErrCalls: 1,
 	}
 	lock := &stubLockStore{
 		lock: types.VendorLock{
@@ -1094,8 +1441,9 @@ func TestSyncWithAutoUpdate_StaleCommit_PassesVendorFilter(t *testing.T) {
 	})
 
 	err := syncer.SyncWithFullOpts(context.Background(), SyncOptions{
-		VendorName: "vendor-a",
-		Local:      true,
+		GroupName:    "backend",
+		Local:        true,
+		RetryOnStale: true,
 	})
 	if err != nil {
 		t.Fatalf("SyncWithFullOpts() error = %v", err)
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// VendorIgnoreFile is the project-root file of gitignore-style patterns that
+// every directory mapping skips when copying, on top of its own exclude list.
+const VendorIgnoreFile = ".vendorignore"
+
+// VendorIgnore is a parsed .vendorignore. Patterns are matched against paths
+// relative to a mapping's source directory, the same paths exclude patterns
+// see. A nil *VendorIgnore ignores nothing.
+type VendorIgnore struct {
+	rules []ignoreRule
+}
+
+// ignoreRule is one .vendorignore line.
+type ignoreRule struct {
+	pattern  string // Forward slashes; leading "!", leading "/" and trailing "/" removed
+	negate   bool   // "!pattern": re-include a path an earlier rule ignored
+	dirOnly  bool   // "pattern/": match directories only
+	anchored bool   // Pattern has a "/" before its end: match the whole relative path, not any name in it
+}
+
+// LoadVendorIgnore reads VendorIgnoreFile from projectRoot. A missing file
+// returns nil (nothing ignored) and no error.
+func LoadVendorIgnore(projectRoot string) (*VendorIgnore, error) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, VendorIgnoreFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", VendorIgnoreFile, err)
+	}
+	return ParseVendorIgnore(string(data)), nil
+}
+
+// ParseVendorIgnore parses gitignore syntax: blank lines and "#" comments are
+// skipped, "\#" and "\!" escape a literal first character, "!" negates, a
+// trailing "/" matches only directories, and a pattern containing "/"
+// elsewhere is anchored to the mapping root (a leading "/" only anchors).
+// Globs follow MatchesExclude, including "**".
+func ParseVendorIgnore(data string) *VendorIgnore {
+	ignore := &VendorIgnore{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(strings.TrimSuffix(line, "\r"), " \t")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule ignoreRule
+		switch {
+		case strings.HasPrefix(line, `\`):
+			line = line[1:]
+		case strings.HasPrefix(line, "!"):
+			rule.negate = true
+			line = line[1:]
+		}
+		line = filepath.ToSlash(line)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = line
+		ignore.rules = append(ignore.rules, rule)
+	}
+	return ignore
+}
+
+// Ignored reports whether relPath (relative to the mapping's source
+// directory) is ignored. As in gitignore, the last matching rule wins, and a
+// path inside an ignored directory stays ignored whatever later rules say.
+func (v *VendorIgnore) Ignored(relPath string, isDir bool) bool {
+	if v == nil || len(v.rules) == 0 {
+		return false
+	}
+	relPath = filepath.ToSlash(filepath.Clean(relPath))
+	if relPath == "." {
+		return false
+	}
+	for i := 0; i < len(relPath); i++ {
+		if relPath[i] == '/' && v.match(relPath[:i], true) {
+			return true
+		}
+	}
+	return v.match(relPath, isDir)
+}
+
+// match applies every rule to relPath alone, ignoring its parents.
+func (v *VendorIgnore) match(relPath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range v.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		target := relPath
+		if !rule.anchored {
+			target = path.Base(relPath)
+		}
+		// "dir/**" matches what is inside dir, not dir itself, so a later
+		// "!dir/file" can still re-include
+		if strings.HasSuffix(rule.pattern, "/**") && target == strings.TrimSuffix(rule.pattern, "/**") {
+			continue
+		}
+		if matchGlob(target, rule.pattern) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func TestCopyMappings_VendorIgnoreAppliesToEveryDirectoryMapping(t *testing.T) {
+	chdirUnmanagedTest(t)
+	writeFixTestFile(t, VendorIgnoreFile, "# project-wide\n*.log\n!keep.log\n")
+
+	clone := t.TempDir()
+	for _, path := range []string{
+		"src/main.go", "src/debug.log", "src/nested/trace.log", "src/keep.log",
+		"docs/guide.md", "docs/build.log",
+	} {
+		writeFixTestFile(t, filepath.Join(clone, path), "x")
+	}
+	vendor := &types.VendorSpec{Name: "lib"}
+	spec := types.BranchSpec{Ref: "main", Mapping: []types.PathMapping{
+		{From: "src", To: "vendor/lib/src"},
+		{From: "docs", To: "vendor/lib/docs", Exclude: []string{"guide.md"}},
+	}}
+
+	stats, err := NewFileCopyService(NewOSFileSystem()).CopyMappings(clone, vendor, spec)
+	if err != nil {
+		t.Fatalf("CopyMappings: %v", err)
+	}
+
+	for _, path := range []string{"vendor/lib/src/main.go", "vendor/lib/src/keep.log"} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s should have been copied: %v", path, err)
+		}
+	}
+	for _, path := range []string{
+		"vendor/lib/src/debug.log", "vendor/lib/src/nested/trace.log",
+		"vendor/lib/docs/build.log", "vendor/lib/docs/guide.md",
+	} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s should have been skipped", path)
+		}
+	}
+	if stats.FileCount != 2 || stats.Excluded != 4 {
+		t.Errorf("FileCount = %d, Excluded = %d, want 2 and 4", stats.FileCount, stats.Excluded)
+	}
+}
+
+func TestVendorIgnore_GitignorePrecedence(t *testing.T) {
+	ignore := ParseVendorIgnore("build/\n/root.txt\n*.tmp\n!important.tmp\nlogs/**\n!logs/keep.txt\n\\!bang\n")
+
+	cases := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"build", true, true},
+		{"build", false, false}, // "build/" matches directories only
+		{"pkg/build/out.go", false, true},
+		{"root.txt", false, true},
+		{"pkg/root.txt", false, false}, // leading "/" anchors
+		{"a/b.tmp", false, true},
+		{"a/important.tmp", false, false}, // later negation wins
+		{"logs/keep.txt", false, false},
+		{"logs/other.txt", false, true},
+		{"!bang", false, true},
+		{"main.go", false, false},
+	}
+	for _, tc := range cases {
+		if got := ignore.Ignored(tc.path, tc.isDir); got != tc.want {
+			t.Errorf("Ignored(%q, dir=%v) = %v, want %v", tc.path, tc.isDir, got, tc.want)
+		}
+	}
+
+	var none *VendorIgnore
+	if none.Ignored("a.tmp", false) {
+		t.Error("nil VendorIgnore should ignore nothing")
+	}
+}
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// fixableStatuses are the verify statuses --fix restores. Added and stale
+// entries have no locked content to restore; orphaned ones no mapping.
+var fixableStatuses = map[string]bool{
+	"modified":  true,
+	"deleted":   true,
+	"truncated": true,
+}
+
+// fixGroup collects the broken destinations of one locked vendor@ref.
+type fixGroup struct {
+	entry *types.LockDetails
+	files []types.FileStatus
+}
+
+// FixVerify restores every modified, deleted or truncated destination that
+// verify reports to its locked content. Each affected vendor@ref is fetched
+// once at its locked commit, and only the broken destinations are re-copied
+// (positions are re-placed in their target range); every other file is left
+// alone. Vendors matching excludeVendors are skipped. Destinations that can't
+// be restored are reported in Failed rather than aborting the rest.
+func (s *VendorSyncer) FixVerify(ctx context.Context, opts VerifyOptions, excludeVendors []string) (*types.VerifyFixResult, error) {
+	verifyResult, err := s.verifyService.VerifyWithOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := &types.VerifyFixResult{Restored: []string{}}
+
+	var broken []types.FileStatus
+	for _, f := range verifyResult.Files {
+		if fixableStatuses[f.Status] && f.Vendor != nil && !MatchVendorPattern(*f.Vendor, excludeVendors) {
+			broken = append(broken, f)
+		}
+	}
+	if len(broken) == 0 {
+		return result, nil
+	}
+
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	lock, err := s.lockStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+
+	// Group by the lock entry that recorded each destination, in report order
+	groups := make(map[string]*fixGroup)
+	var order []string
+	for _, f := range broken {
+		entry := lockEntryForDest(lock, *f.Vendor, f)
+		if entry == nil {
+			result.Failed = append(result.Failed, types.VerifyFixFailed{Path: f.Path, Error: "no lock entry records it"})
+			continue
+		}
+		key := entry.Name + "@" + entry.Ref
+		if groups[key] == nil {
+			groups[key] = &fixGroup{entry: entry}
+			order = append(order, key)
+		}
+		groups[key].files = append(groups[key].files, f)
+	}
+
+	for _, key := range order {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		g := groups[key]
+		restored, err := s.restoreLockedDests(ctx, config, g)
+		if err != nil {
+			for _, f := range g.files {
+				result.Failed = append(result.Failed, types.VerifyFixFailed{Path: f.Path, Error: err.Error()})
+			}
+			continue
+		}
+		result.Restored = append(result.Restored, restored...)
+		for _, f := range g.files {
+			if !slices.Contains(restored, f.Path) {
+				result.Failed = append(result.Failed, types.VerifyFixFailed{Path: f.Path, Error: "no mapping produces it"})
+			}
+		}
+	}
+	return result, nil
+}
+
+// restoreLockedDests fetches g's locked commit and re-copies g's files from
+// it, returning the restored paths.
+func (s *VendorSyncer) restoreLockedDests(ctx context.Context, config types.VendorConfig, g *fixGroup) ([]string, error) {
+	idx := FindVendorIndex(config.Vendors, g.entry.Name)
+	if idx < 0 {
+		return nil, NewVendorNotFoundError(g.entry.Name)
+	}
+	vendor := &config.Vendors[idx]
+	if vendor.Source == SourceInternal {
+		return nil, fmt.Errorf("internal vendor '%s' is restored by 'git-vendor pull --locked'", vendor.Name)
+	}
+	var spec *types.BranchSpec
+	for i := range vendor.Specs {
+		if vendor.Specs[i].Ref == g.entry.Ref {
+			spec = &vendor.Specs[i]
+			break
+		}
+	}
+	if spec == nil {
+		return nil, fmt.Errorf("%s has no spec for %s @ %s", s.configStore.Path(), vendor.Name, g.entry.Ref)
+	}
+
+	// Only the broken destinations are copied: whole-file destinations inside
+	// a directory mapping become single-file mappings of their source file.
+	// Transforms and SPDX headers apply only if the locked hashes include them
+	restoreSpec := *spec
+	restoreSpec.Mapping = nil
+	restoreVendor := *vendor
+	if !g.entry.Transformed {
+		restoreVendor.SPDXHeaders = false
+	}
+	var paths []string
+	for _, f := range g.files {
+		if m, ok := restoreMapping(vendor, *spec, f); ok {
+			if !g.entry.Transformed {
+				m.Transforms = nil
+			}
+			restoreSpec.Mapping = append(restoreSpec.Mapping, m)
+			paths = append(paths, f.Path)
+		}
+	}
+	if len(restoreSpec.Mapping) == 0 {
+		return nil, nil
+	}
+
+	tempDir, err := s.fs.CreateTemp("", "git-vendor-fix-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = s.fs.RemoveAll(tempDir) //nolint:errcheck // cleanup in defer
+	}()
+	if err := s.gitClient.Init(ctx, tempDir); err != nil {
+		return nil, fmt.Errorf("failed to init temp repo: %w", err)
+	}
+	commit := g.entry.CommitHash
+	if _, err := FetchWithFallback(ctx, s.gitClient, s.fs, s.ui, s.logger, tempDir, ResolveVendorURLs(vendor), commit, refFetchDepth(*spec)); err != nil {
+		// Servers without uploadpack.allowReachableSHA1InWant reject commit
+		// fetches; the ref's full history contains the commit instead
+		ref := g.entry.Ref
+		if g.entry.RefAlias != "" {
+			ref = g.entry.RefAlias
+		}
+		if err := s.gitClient.Fetch(ctx, tempDir, "origin", 0, ref); err != nil {
+			return nil, fmt.Errorf("failed to fetch %s @ %s: %w", vendor.Name, ref, err)
+		}
+	}
+	if err := s.gitClient.Checkout(ctx, tempDir, commit); err != nil {
+		return nil, NewCheckoutError(commit, vendor.Name, err)
+	}
+
+	stats, err := NewFileCopyService(s.fs).CopyMappings(tempDir, &restoreVendor, restoreSpec)
+	if err != nil {
+		return nil, err
+	}
+	if len(stats.Removed) > 0 {
+		return nil, fmt.Errorf("source missing at locked commit %s", commit)
+	}
+	return paths, nil
+}
+
+// restoreMapping returns a mapping of spec that writes exactly f.Path.
+func restoreMapping(vendor *types.VendorSpec, spec types.BranchSpec, f types.FileStatus) (types.PathMapping, bool) {
+	copier := &FileCopyService{}
+	for _, m := range spec.Mapping {
+		dest := copier.computeDestPath(m, spec, vendor)
+		if f.Type == "position" {
+			if dest == f.Path || m.To == f.Path {
+				return m, true
+			}
+			continue
+		}
+		if _, pos, err := types.ParsePathPosition(cleanMappingSource(m.From, spec.Ref)); err != nil || pos != nil {
+			continue
+		}
+		dest = strings.TrimSuffix(dest, "/")
+		if dest == f.Path {
+			return types.PathMapping{From: m.From, To: f.Path, Transforms: m.Transforms}, true
+		}
+		if rel, ok := strings.CutPrefix(f.Path, dest+"/"); ok {
+			from := path.Join(strings.TrimSuffix(cleanMappingSource(m.From, spec.Ref), "/"), rel)
+			return types.PathMapping{From: from, To: f.Path, Transforms: m.Transforms}, true
+		}
+	}
+	return types.PathMapping{}, false
+}
+
+// lockEntryForDest returns vendor's lock entry that records f: its position
+// list for position entries, otherwise its FileHashes.
+func lockEntryForDest(lock types.VendorLock, vendor string, f types.FileStatus) *types.LockDetails {
+	for i := range lock.Vendors {
+		entry := &lock.Vendors[i]
+		if entry.Name != vendor {
+			continue
+		}
+		if f.Type == "position" {
+			for _, p := range entry.Positions {
+				if p.To == f.Path {
+					return entry
+				}
+			}
+			continue
+		}
+		if _, ok := entry.FileHashes[f.Path]; ok {
+			return entry
+		}
+	}
+	return nil
+}
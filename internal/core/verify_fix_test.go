@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+	"github.com/golang/mock/gomock"
+)
+
+// newFixTestSyncer returns a VendorSyncer over real stores and files in the
+// current directory whose git mock checks out upstream (path → content) at
+// commit, once. The working directory must already be a temp dir.
+func newFixTestSyncer(t *testing.T, config types.VendorConfig, lock types.VendorLock, commit string, upstream map[string]string) *VendorSyncer {
+	t.Helper()
+	return newFixTestSyncerFS(t, NewOSFileSystem(), config, lock, commit, upstream)
+}
+
+// newFixTestSyncerFS is newFixTestSyncer over the given filesystem.
+func newFixTestSyncerFS(t *testing.T, fs FileSystem, config types.VendorConfig, lock types.VendorLock, commit string, upstream map[string]string) *VendorSyncer {
+	t.Helper()
+	if err := os.MkdirAll(VendorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configStore, lockStore := NewFileConfigStore(VendorDir), NewFileLockStore(VendorDir)
+	if err := configStore.Save(config); err != nil {
+		t.Fatal(err)
+	}
+	if err := lockStore.Save(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	git := NewMockGitClient(gomock.NewController(t))
+	git.EXPECT().Init(gomock.Any(), gomock.Any()).Return(nil)
+	git.EXPECT().AddRemote(gomock.Any(), gomock.Any(), "origin", gomock.Any()).Return(nil)
+	git.EXPECT().Fetch(gomock.Any(), gomock.Any(), "origin", gomock.Any(), commit).Return(nil)
+	git.EXPECT().Checkout(gomock.Any(), gomock.Any(), commit).DoAndReturn(func(_ context.Context, dir, _ string) error {
+		for path, content := range upstream {
+			full := filepath.Join(dir, path)
+			if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	git.EXPECT().GetHeadHash(gomock.Any(), gomock.Any()).Return(commit, nil).AnyTimes()
+	git.EXPECT().GetTagForCommit(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	return NewVendorSyncer(configStore, lockStore, git, fs, nil, VendorDir, &SilentUICallback{}, nil)
+}
+
+// writeFixTestFile writes content to path, creating parent directories.
+func writeFixTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFixVerify_RestoresModifiedFile(t *testing.T) {
+	chdirUnmanagedTest(t)
+	const locked = "package lib\n"
+	writeFixTestFile(t, "lib/file.go", locked)
+	hash, err := fileSHA256("lib/file.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFixTestFile(t, "lib/file.go", "package lib // local edit\n")
+
+	commit := strings.Repeat("e", 40)
+	syncer := newFixTestSyncer(t,
+		createTestConfig(createTestVendorSpec("lib", "https://github.com/owner/lib", "main")),
+		types.VendorLock{Vendors: []types.LockDetails{
+			{Name: "lib", Ref: "main", CommitHash: commit, FileHashes: map[string]string{"lib/file.go": hash}},
+		}},
+		commit, map[string]string{"src/file.go": locked})
+
+	result, err := syncer.FixVerify(context.Background(), VerifyOptions{}, nil)
+	if err != nil {
+		t.Fatalf("FixVerify: %v", err)
+	}
+	if len(result.Restored) != 1 || result.Restored[0] != "lib/file.go" || len(result.Failed) != 0 {
+		t.Errorf("result = %+v, want lib/file.go restored", result)
+	}
+	if data, _ := os.ReadFile("lib/file.go"); string(data) != locked {
+		t.Errorf("lib/file.go = %q, want locked content %q", data, locked)
+	}
+
+	verify, err := syncer.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if verify.Summary.Result != "PASS" {
+		t.Errorf("verify after fix = %s, want PASS: %+v", verify.Summary.Result, verify.Files)
+	}
+}
+
+func TestFixVerify_RestoresOnlyBrokenFilesInDirectory(t *testing.T) {
+	chdirUnmanagedTest(t)
+	writeFixTestFile(t, "lib/a.go", "package a\n")
+	writeFixTestFile(t, "lib/b.go", "package b\n")
+	hashA, _ := fileSHA256("lib/a.go")
+	hashB, _ := fileSHA256("lib/b.go")
+	if err := os.Remove("lib/a.go"); err != nil {
+		t.Fatal(err)
+	}
+	writeFixTestFile(t, "lib/extra.go", "package extra\n")
+
+	vendor := types.VendorSpec{Name: "lib", URL: "https://github.com/owner/lib", Specs: []types.BranchSpec{
+		{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: "lib"}}},
+	}}
+	commit := strings.Repeat("f", 40)
+	syncer := newFixTestSyncer(t, createTestConfig(vendor),
+		types.VendorLock{Vendors: []types.LockDetails{
+			{Name: "lib", Ref: "main", CommitHash: commit, FileHashes: map[string]string{"lib/a.go": hashA, "lib/b.go": hashB}},
+		}},
+		// Upstream b.go differs from the lock: it must not be re-copied
+		commit, map[string]string{"src/a.go": "package a\n", "src/b.go": "package b // newer\n", "src/c.go": "package c\n"})
+
+	result, err := syncer.FixVerify(context.Background(), VerifyOptions{}, nil)
+	if err != nil {
+		t.Fatalf("FixVerify: %v", err)
+	}
+	if len(result.Restored) != 1 || result.Restored[0] != "lib/a.go" {
+		t.Errorf("restored = %v, want [lib/a.go]", result.Restored)
+	}
+	if data, _ := os.ReadFile("lib/a.go"); string(data) != "package a\n" {
+		t.Errorf("lib/a.go = %q, want restored", data)
+	}
+	if data, _ := os.ReadFile("lib/b.go"); string(data) != "package b\n" {
+		t.Errorf("lib/b.go = %q, want untouched", data)
+	}
+	if _, err := os.Stat("lib/extra.go"); err != nil {
+		t.Errorf("added file lib/extra.go should be left alone: %v", err)
+	}
+	if _, err := os.Stat("lib/c.go"); !os.IsNotExist(err) {
+		t.Errorf("lib/c.go should not be copied, stat err = %v", err)
+	}
+}
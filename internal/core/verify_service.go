@@ -7,22 +7,42 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 )
 
+// emptyFileSHA256 is the SHA-256 of zero bytes. A destination hashing to it
+// while the lock records any other hash is reported as "truncated".
+const emptyFileSHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// truncatedHint is the FileStatus.Hint for truncated files.
+const truncatedHint = "file is empty but the locked content is not; re-sync with 'git-vendor pull --locked'"
+
 // expectedFileInfo holds expected file metadata for verification
 type expectedFileInfo struct {
 	vendor string
 	hash   string
 }
 
+// VerifyOptions scopes which verification categories run.
+// PositionsOnly and FilesOnly are mutually exclusive; zero value runs everything.
+type VerifyOptions struct {
+	PositionsOnly bool // Only verify position-extracted snippets (skip whole-file, added, and coherence checks)
+	FilesOnly     bool // Only verify whole files (skip position snippet checks)
+	Workers       int  // Concurrent whole-file hashers (0 = NumCPU, 1 = serial)
+}
+
 // VerifyServiceInterface defines the contract for file verification against lockfile.
 // VerifyServiceInterface enables mocking in tests and alternative verification strategies.
 // ctx is accepted for cancellation support and future network-based verification.
 type VerifyServiceInterface interface {
 	Verify(ctx context.Context) (*types.VerifyResult, error)
+	VerifyWithOptions(ctx context.Context, opts VerifyOptions) (*types.VerifyResult, error)
 }
 
 // Compile-time interface satisfaction check.
@@ -56,7 +76,27 @@ func NewVerifyService(
 
 // Verify checks all vendored files against the lockfile.
 // ctx is accepted for cancellation support and future network-based verification.
-func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
+func (s *VerifyService) Verify(ctx context.Context) (*types.VerifyResult, error) {
+	return s.VerifyWithOptions(ctx, VerifyOptions{})
+}
+
+// VerifyWithOptions checks vendored files against the lockfile, limited to the
+// categories selected by opts. Skipped categories contribute nothing to Files,
+// Summary counts, or Result:
+//   - PositionsOnly skips whole-file hashes, internal entries, added-file scan, and coherence checks
+//   - FilesOnly skips position snippet verification
+//
+// Whole-file hashes are computed on up to opts.Workers goroutines and reported
+// in path order, so results don't depend on the worker count.
+//
+// ctx is checked before each file is hashed and at each entry of the added-file
+// walk; once it is cancelled or expires, VerifyWithOptions stops hashing and
+// returns an error wrapping ctx.Err().
+func (s *VerifyService) VerifyWithOptions(ctx context.Context, opts VerifyOptions) (*types.VerifyResult, error) {
+	if opts.PositionsOnly && opts.FilesOnly {
+		return nil, fmt.Errorf("positions-only and files-only are mutually exclusive")
+	}
+
 	// Load lockfile
 	lock, err := s.lockStore.Load()
 	if err != nil {
@@ -68,6 +108,7 @@ func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("load config: %w", err)
 	}
+	config = expandRecursiveConfig(config)
 
 	result := &types.VerifyResult{
 		SchemaVersion: "1.0",
@@ -95,6 +136,16 @@ func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
 		}
 	}
 
+	// Positions-only: whole-file checks are skipped entirely, so verify the
+	// position snippets and compute the result without touching FileHashes.
+	if opts.PositionsOnly {
+		if err := s.verifyPositions(ctx, lock, result); err != nil {
+			return nil, err
+		}
+		finalizeVerifyResult(result)
+		return result, nil
+	}
+
 	// If lockfile has no file hashes, try to use cache as fallback
 	if len(expectedFiles) == 0 {
 		expectedFiles, err = s.buildExpectedFilesFromCache(lock)
@@ -103,13 +154,24 @@ func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
 		}
 	}
 
-	// Check all expected files
-	for path, expected := range expectedFiles {
+	// Hash all expected files, then classify them in path order
+	paths := make([]string, 0, len(expectedFiles))
+	for path := range expectedFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	hashes, err := s.hashFiles(ctx, paths, opts.Workers)
+	if err != nil {
+		return nil, fmt.Errorf("verify cancelled: %w", err)
+	}
+
+	for i, path := range paths {
+		expected := expectedFiles[path]
 		vendorName := expected.vendor
 		expectedHash := expected.hash
 
 		// Check if file exists
-		actualHash, err := s.cache.ComputeFileChecksum(path)
+		actualHash, err := hashes[i].hash, hashes[i].err
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
 				// File was deleted
@@ -149,6 +211,18 @@ func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
 				ActualHash:   &actualHash,
 			})
 			result.Summary.Accepted++
+		} else if actualHash == emptyFileSHA256 && expectedHash != emptyFileSHA256 {
+			// File emptied — typically a botched copy rather than a local edit
+			result.Files = append(result.Files, types.FileStatus{
+				Path:         path,
+				Vendor:       &vendorName,
+				Status:       "truncated",
+				Type:         "file",
+				ExpectedHash: &expectedHash,
+				ActualHash:   &actualHash,
+				Hint:         truncatedHint,
+			})
+			result.Summary.Truncated++
 		} else {
 			// File modified
 			result.Files = append(result.Files, types.FileStatus{
@@ -166,10 +240,17 @@ func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
 	// Verify position-extracted content against lockfile source hashes.
 	// This is a local-only check: read the destination file, extract the
 	// target range, hash it, and compare to the source_hash stored at sync time.
-	s.verifyPositions(lock, result)
+	if !opts.FilesOnly {
+		if err := s.verifyPositions(ctx, lock, result); err != nil {
+			return nil, err
+		}
+	}
 
 	// Verify internal vendor entries — compare source and destination hashes
 	// to detect drift direction (Spec 070).
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("verify cancelled: %w", err)
+	}
 	s.verifyInternalEntries(lock, config, result)
 
 	// Register position-destination files in expectedFiles so findAddedFiles
@@ -193,8 +274,11 @@ func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
 	}
 
 	// Scan for added files (in vendor directories but not in lockfile)
-	addedFiles, err := s.findAddedFiles(config, expectedFiles)
+	addedFiles, err := s.findAddedFiles(ctx, config, expectedFiles)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("verify cancelled: %w", ctx.Err())
+		}
 		return nil, fmt.Errorf("scan for added files: %w", err)
 	}
 	for _, af := range addedFiles {
@@ -203,30 +287,132 @@ func (s *VerifyService) Verify(_ context.Context) (*types.VerifyResult, error) {
 	}
 
 	// Detect config/lock coherence issues (VFY-001)
-	s.detectCoherenceIssues(config, lock, result)
+	detectCoherenceIssues(config, lock, result)
+
+	finalizeVerifyResult(result)
+	return result, nil
+}
+
+// fileHashResult is the checksum of one file, or the error computing it.
+type fileHashResult struct {
+	hash string
+	err  error
+}
+
+// hashFiles computes the checksum of each path on up to workers goroutines
+// (0 = NumCPU) and returns the results in the order of paths. Once ctx is
+// done no further files are hashed and ctx.Err() is returned.
+func (s *VerifyService) hashFiles(ctx context.Context, paths []string, workers int) ([]fileHashResult, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+
+	results := make([]fileHashResult, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				hash, err := s.cache.ComputeFileChecksum(paths[i])
+				results[i] = fileHashResult{hash: hash, err: err}
+			}
+		}()
+	}
 
-	// Compute totals and result
+	for i := range paths {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// finalizeVerifyResult computes Summary.TotalFiles and Summary.Result from the counts.
+func finalizeVerifyResult(result *types.VerifyResult) {
 	result.Summary.TotalFiles = len(result.Files)
 	switch {
-	case result.Summary.Modified > 0 || result.Summary.Deleted > 0:
+	case result.Summary.Modified > 0 || result.Summary.Deleted > 0 || result.Summary.Truncated > 0:
 		result.Summary.Result = "FAIL"
 	case result.Summary.Added > 0 || result.Summary.Accepted > 0 || result.Summary.Stale > 0 || result.Summary.Orphaned > 0:
 		result.Summary.Result = "WARN"
 	default:
 		result.Summary.Result = "PASS"
 	}
+}
 
-	return result, nil
+// GroupVerifyByVendor rolls result.Files up into one VendorVerifySummary per
+// FileStatus.Vendor, sorted by vendor name. Files without a vendor are grouped
+// under types.UnattributedVendor, so the rows always sum to result.Summary.
+func GroupVerifyByVendor(result *types.VerifyResult) []types.VendorVerifySummary {
+	rows := make(map[string]*types.VendorVerifySummary)
+	for _, f := range result.Files {
+		name := types.UnattributedVendor
+		if f.Vendor != nil {
+			name = *f.Vendor
+		}
+		row, ok := rows[name]
+		if !ok {
+			row = &types.VendorVerifySummary{Vendor: name}
+			rows[name] = row
+		}
+		row.TotalFiles++
+		switch f.Status {
+		case "verified":
+			row.Verified++
+		case "modified":
+			row.Modified++
+		case "added":
+			row.Added++
+		case "deleted":
+			row.Deleted++
+		case "truncated":
+			row.Truncated++
+		case "accepted":
+			row.Accepted++
+		case "stale":
+			row.Stale++
+		case "orphaned":
+			row.Orphaned++
+		}
+	}
+
+	grouped := make([]types.VendorVerifySummary, 0, len(rows))
+	for _, row := range rows {
+		grouped = append(grouped, *row)
+	}
+	sort.Slice(grouped, func(i, j int) bool {
+		return grouped[i].Vendor < grouped[j].Vendor
+	})
+	return grouped
 }
 
 // verifyPositions checks position-extracted content against lockfile source hashes.
 // For each PositionLock entry, verifyPositions reads the destination file locally,
 // extracts the target range, and compares the computed hash to PositionLock.SourceHash.
-// No network access required — purely local verification.
-func (s *VerifyService) verifyPositions(lock types.VendorLock, result *types.VerifyResult) {
+// No network access required — purely local verification. Returns an error
+// wrapping ctx.Err() when ctx is cancelled between positions.
+func (s *VerifyService) verifyPositions(ctx context.Context, lock types.VendorLock, result *types.VerifyResult) error {
 	for i := range lock.Vendors {
 		lockEntry := &lock.Vendors[i]
 		for _, pos := range lockEntry.Positions {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("verify cancelled: %w", err)
+			}
 			vendorName := lockEntry.Name
 
 			// Parse destination path and position
@@ -316,6 +502,7 @@ func (s *VerifyService) verifyPositions(lock types.VendorLock, result *types.Ver
 			}
 		}
 	}
+	return nil
 }
 
 // verifyInternalEntries checks internal vendor mappings for source/dest drift.
@@ -414,24 +601,8 @@ func (s *VerifyService) verifyInternalEntries(lock types.VendorLock, config type
 // before comparison, since lock FileHashes keys are bare file paths.
 // Internal vendor entries (Source == "internal") are excluded from orphan detection
 // because their FileHashes track destination files keyed differently.
-func (s *VerifyService) detectCoherenceIssues(config types.VendorConfig, lock types.VendorLock, result *types.VerifyResult) {
-	// Build set of destination paths from config mappings.
-	// Key: bare file path (position spec stripped). Value: vendor name.
-	configDests := make(map[string]string)
-	for _, vendor := range config.Vendors {
-		for _, spec := range vendor.Specs {
-			for _, mapping := range spec.Mapping {
-				if mapping.To == "" {
-					continue
-				}
-				destFile, _, parseErr := types.ParsePathPosition(mapping.To)
-				if parseErr != nil {
-					destFile = mapping.To
-				}
-				configDests[destFile] = vendor.Name
-			}
-		}
-	}
+func detectCoherenceIssues(config types.VendorConfig, lock types.VendorLock, result *types.VerifyResult) {
+	configDests := configDestinations(config)
 
 	// Build set of all lock FileHashes paths across all vendors.
 	// Key: file path. Value: vendor name.
@@ -459,11 +630,12 @@ func (s *VerifyService) detectCoherenceIssues(config types.VendorConfig, lock ty
 	// Only flag a config dest as stale when its vendor has FileHashes populated
 	// in the lock. If the vendor has no FileHashes, its entries were resolved
 	// via cache fallback and stale detection would produce false positives.
-	for destPath, vendorName := range configDests {
+	for _, destPath := range sortedPaths(configDests) {
+		vendorName := configDests[destPath]
 		if !vendorsWithHashes[vendorName] {
 			continue
 		}
-		if _, inLock := lockPaths[destPath]; !inLock {
+		if !lockCoversDest(lockPaths, destPath) {
 			vn := vendorName
 			result.Files = append(result.Files, types.FileStatus{
 				Path:   destPath,
@@ -476,28 +648,100 @@ func (s *VerifyService) detectCoherenceIssues(config types.VendorConfig, lock ty
 	}
 
 	// Orphaned: in lock but not in config (skip internal vendors)
-	internalVendors := make(map[string]bool)
-	for i := range lock.Vendors {
-		if lock.Vendors[i].Source == SourceInternal {
-			internalVendors[lock.Vendors[i].Name] = true
+	orphans := orphanedLockPaths(configDests, lock)
+	for _, lockPath := range sortedPaths(orphans) {
+		vn := orphans[lockPath]
+		result.Files = append(result.Files, types.FileStatus{
+			Path:   lockPath,
+			Vendor: &vn,
+			Status: "orphaned",
+			Type:   "coherence",
+		})
+		result.Summary.Orphaned++
+	}
+}
+
+// sortedPaths returns the keys of a path-keyed map in sorted order.
+func sortedPaths(m map[string]string) []string {
+	paths := make([]string, 0, len(m))
+	for path := range m {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// configDestinations returns the destination paths of every config mapping,
+// keyed by bare, cleaned slash path (position spec stripped, as lock
+// FileHashes keys are) with the vendor name as value. An empty or "." To
+// resolves to its auto path (ComputeAutoPath), as sync writes it.
+func configDestinations(config types.VendorConfig) map[string]string {
+	configDests := make(map[string]string)
+	for _, vendor := range config.Vendors {
+		for _, spec := range vendor.Specs {
+			for _, mapping := range spec.Mapping {
+				dest := mapping.To
+				if dest == "" || dest == "." {
+					dest = autoDestPath(mapping.From, spec, vendor.Name)
+				}
+				destFile, _, parseErr := types.ParsePathPosition(dest)
+				if parseErr != nil {
+					destFile = dest
+				}
+				configDests[normalizeConfigPath(destFile)] = vendor.Name
+			}
 		}
 	}
+	return configDests
+}
 
-	for lockPath, vendorName := range lockPaths {
-		if internalVendors[vendorName] {
+// orphanedLockPaths returns the lock FileHashes paths not covered by any entry
+// in configDests, keyed by path with the owning vendor name as value. Internal
+// vendor entries are never orphaned (their FileHashes are keyed differently).
+func orphanedLockPaths(configDests map[string]string, lock types.VendorLock) map[string]string {
+	orphans := make(map[string]string)
+	for i := range lock.Vendors {
+		lockEntry := &lock.Vendors[i]
+		if lockEntry.Source == SourceInternal {
 			continue
 		}
-		if _, inConfig := configDests[lockPath]; !inConfig {
-			vn := vendorName
-			result.Files = append(result.Files, types.FileStatus{
-				Path:   lockPath,
-				Vendor: &vn,
-				Status: "orphaned",
-				Type:   "coherence",
-			})
-			result.Summary.Orphaned++
+		for path := range lockEntry.FileHashes {
+			if !configCoversPath(configDests, path) {
+				orphans[path] = lockEntry.Name
+			}
+		}
+	}
+	return orphans
+}
+
+// lockCoversDest reports whether the lock records destPath itself or, for a
+// directory mapping, any file beneath it.
+func lockCoversDest(lockPaths map[string]string, destPath string) bool {
+	if _, ok := lockPaths[destPath]; ok {
+		return true
+	}
+	prefix := strings.TrimSuffix(destPath, "/") + "/"
+	for path := range lockPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
 		}
 	}
+	return false
+}
+
+// configCoversPath reports whether lockPath is a config destination or lies
+// under a directory destination.
+func configCoversPath(configDests map[string]string, lockPath string) bool {
+	lockPath = normalizeConfigPath(lockPath)
+	if _, ok := configDests[lockPath]; ok {
+		return true
+	}
+	for dest := range configDests {
+		if dest == "." || strings.HasPrefix(lockPath, strings.TrimSuffix(dest, "/")+"/") {
+			return true
+		}
+	}
+	return false
 }
 
 // buildExpectedFilesFromCache builds expected files map from cache (fallback)
@@ -532,8 +776,9 @@ func (s *VerifyService) buildExpectedFilesFromCache(lock types.VendorLock) (map[
 	return expectedFiles, nil
 }
 
-// findAddedFiles scans vendor destination directories for files not in lockfile
-func (s *VerifyService) findAddedFiles(config types.VendorConfig, expectedFiles map[string]expectedFileInfo) ([]types.FileStatus, error) {
+// findAddedFiles scans vendor destination directories for files not in lockfile.
+// The walk aborts with ctx.Err() as soon as ctx is cancelled.
+func (s *VerifyService) findAddedFiles(ctx context.Context, config types.VendorConfig, expectedFiles map[string]expectedFileInfo) ([]types.FileStatus, error) {
 	var added []types.FileStatus
 
 	// Collect all destination directories from config
@@ -572,6 +817,9 @@ func (s *VerifyService) findAddedFiles(config types.VendorConfig, expectedFiles
 	// Walk each destination directory
 	for destDir := range destDirs {
 		err := filepath.WalkDir(destDir, func(path string, d fs.DirEntry, err error) error {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
 			if err != nil {
 				return fmt.Errorf("findAddedFiles: access %s: %w", path, err)
 			}
@@ -3,10 +3,12 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/EmundoT/git-vendor/internal/types"
 	"github.com/golang/mock/gomock"
@@ -3061,6 +3063,38 @@ func TestVerify_InternalEntries_SourceMismatch(t *testing.T) {
 // TestVerify_DetectCoherence_StaleAndOrphaned verifies that detectCoherenceIssues
 // correctly identifies stale config destinations (in config but not lock) and
 // orphaned lock entries (in lock but not config).
+func TestDetectCoherenceIssues_EmptyToUsesDefaultTarget(t *testing.T) {
+	config := types.VendorConfig{Vendors: []types.VendorSpec{{
+		Name: "ext",
+		URL:  "https://github.com/o/r",
+		Specs: []types.BranchSpec{{
+			Ref:           "main",
+			DefaultTarget: "lib/ext",
+			Mapping: []types.PathMapping{
+				{From: "src/a.go"},
+				{From: "src/deep/b.go"},
+			},
+		}},
+	}}}
+	lock := types.VendorLock{Vendors: []types.LockDetails{{
+		Name:       "ext",
+		Ref:        "main",
+		CommitHash: "abc",
+		FileHashes: map[string]string{"lib/ext/a.go": "hash-a", "lib/ext/b.go": "hash-b"},
+	}}}
+
+	dests := configDestinations(config)
+	if dests["lib/ext/a.go"] != "ext" || dests["lib/ext/b.go"] != "ext" {
+		t.Errorf("configDestinations = %v, want empty-To mappings under lib/ext", dests)
+	}
+
+	result := &types.VerifyResult{}
+	detectCoherenceIssues(config, lock, result)
+	if result.Summary.Stale != 0 || result.Summary.Orphaned != 0 {
+		t.Errorf("coherence = %d stale, %d orphaned, want none: %+v", result.Summary.Stale, result.Summary.Orphaned, result.Files)
+	}
+}
+
 func TestVerify_DetectCoherence_StaleAndOrphaned(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -3138,3 +3172,491 @@ func TestVerify_DetectCoherence_StaleAndOrphaned(t *testing.T) {
 		t.Error("expected orphaned coherence entry for lib/v/orphan.go")
 	}
 }
+
+// ============================================================================
+// VerifyWithOptions scoping tests (--positions-only / --files-only)
+// ============================================================================
+
+// setupScopedVerify builds a vendor with one whole-file mapping (lib/whole.go,
+// tracked in FileHashes) and one position mapping (lib/partial.go:L3-L4,
+// tracked only in Positions). mutate runs after hashes are recorded so tests
+// can introduce drift in one category.
+func setupScopedVerify(t *testing.T, mutate func(wholeFile, posFile string)) *VerifyService {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	wholeFile := filepath.Join(tmpDir, "lib", "whole.go")
+	posFile := filepath.Join(tmpDir, "lib", "partial.go")
+	if err := os.MkdirAll(filepath.Dir(wholeFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(wholeFile, []byte("whole file content\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(posFile, []byte("line1\nline2\nvendored-a\nvendored-b\nline5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	realCache := NewFileCacheStore(NewOSFileSystem(), tmpDir)
+	wholeHash, _ := realCache.ComputeFileChecksum(wholeFile)
+	_, posSourceHash, _ := ExtractPosition(posFile, &types.PositionSpec{StartLine: 3, EndLine: 4})
+
+	mutate(wholeFile, posFile)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{{
+			Name: "scoped-vendor",
+			URL:  "https://github.com/owner/repo",
+			Specs: []types.BranchSpec{{
+				Ref: "main",
+				Mapping: []types.PathMapping{
+					{From: "src/whole.go", To: wholeFile},
+				},
+			}},
+		}},
+	}, nil).AnyTimes()
+
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{{
+			Name:       "scoped-vendor",
+			Ref:        "main",
+			CommitHash: "abc123",
+			FileHashes: map[string]string{wholeFile: wholeHash},
+			Positions: []types.PositionLock{{
+				From:       "src/partial.go:L10-L11",
+				To:         posFile + ":L3-L4",
+				SourceHash: posSourceHash,
+			}},
+		}},
+	}, nil).AnyTimes()
+
+	return NewVerifyService(configStore, lockStore, realCache, NewOSFileSystem(), tmpDir)
+}
+
+func countVerifyTypes(result *types.VerifyResult) (fileCount, posCount int) {
+	for _, f := range result.Files {
+		switch f.Type {
+		case "file":
+			fileCount++
+		case "position":
+			posCount++
+		}
+	}
+	return fileCount, posCount
+}
+
+func TestVerifyWithOptions_PositionsOnlyIgnoresWholeFileModification(t *testing.T) {
+	service := setupScopedVerify(t, func(wholeFile, _ string) {
+		if err := os.WriteFile(wholeFile, []byte("locally edited\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Baseline: a full verify sees the whole-file modification
+	full, err := service.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if full.Summary.Result != "FAIL" || full.Summary.Modified != 1 {
+		t.Fatalf("full verify: expected FAIL with 1 modified, got %s/%d", full.Summary.Result, full.Summary.Modified)
+	}
+
+	result, err := service.VerifyWithOptions(context.Background(), VerifyOptions{PositionsOnly: true})
+	if err != nil {
+		t.Fatalf("VerifyWithOptions: %v", err)
+	}
+	if result.Summary.Result != "PASS" {
+		t.Errorf("Expected PASS, got %s", result.Summary.Result)
+	}
+	if result.Summary.Modified != 0 || result.Summary.Verified != 1 {
+		t.Errorf("Expected 1 verified / 0 modified, got %d / %d", result.Summary.Verified, result.Summary.Modified)
+	}
+	fileCount, posCount := countVerifyTypes(result)
+	if fileCount != 0 || posCount != 1 {
+		t.Errorf("Expected 0 file and 1 position entries, got %d and %d", fileCount, posCount)
+	}
+	if result.Summary.TotalFiles != 1 {
+		t.Errorf("Expected TotalFiles=1, got %d", result.Summary.TotalFiles)
+	}
+}
+
+func TestVerifyWithOptions_FilesOnlyIgnoresPositionDrift(t *testing.T) {
+	service := setupScopedVerify(t, func(_, posFile string) {
+		if err := os.WriteFile(posFile, []byte("line1\nline2\nDRIFTED-a\nDRIFTED-b\nline5\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	full, err := service.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if full.Summary.Result != "FAIL" {
+		t.Fatalf("full verify: expected FAIL for position drift, got %s", full.Summary.Result)
+	}
+
+	result, err := service.VerifyWithOptions(context.Background(), VerifyOptions{FilesOnly: true})
+	if err != nil {
+		t.Fatalf("VerifyWithOptions: %v", err)
+	}
+	if result.Summary.Result != "PASS" {
+		t.Errorf("Expected PASS, got %s", result.Summary.Result)
+	}
+	fileCount, posCount := countVerifyTypes(result)
+	if fileCount != 1 || posCount != 0 {
+		t.Errorf("Expected 1 file and 0 position entries, got %d and %d", fileCount, posCount)
+	}
+	if result.Summary.Added != 0 {
+		t.Errorf("Position destination must not be reported as added, got %d added", result.Summary.Added)
+	}
+}
+
+func TestVerifyWithOptions_MutuallyExclusive(t *testing.T) {
+	service := NewVerifyService(nil, nil, nil, nil, "")
+	_, err := service.VerifyWithOptions(context.Background(), VerifyOptions{PositionsOnly: true, FilesOnly: true})
+	if err == nil {
+		t.Fatal("Expected error when both PositionsOnly and FilesOnly are set")
+	}
+}
+
+// TestGroupVerifyByVendor_SumsToSummary runs a real verify over two vendors
+// with mixed statuses and checks that the per-vendor rollup adds up to the
+// global summary, with the vendorless added file grouped as unattributed.
+func TestGroupVerifyByVendor_SumsToSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current dir: %v", err)
+	}
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+	defer os.Chdir(oldDir) //nolint:errcheck
+
+	dirA := filepath.Join("lib", "a")
+	if err := os.MkdirAll(dirA, 0755); err != nil {
+		t.Fatalf("Failed to create vendor dir: %v", err)
+	}
+	goodPath := filepath.Join(dirA, "good.go")
+	editedPath := filepath.Join(dirA, "edited.go")
+	for path, content := range map[string]string{
+		goodPath:                        "package a\n",
+		editedPath:                      "package a // local edit\n",
+		filepath.Join(dirA, "extra.go"): "package a\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	realCache := NewFileCacheStore(NewOSFileSystem(), ".")
+	goodHash, err := realCache.ComputeFileChecksum(goodPath)
+	if err != nil {
+		t.Fatalf("Failed to compute hash: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+
+	deletedPath := filepath.Join("lib", "b", "gone.go")
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name:  "vendor-a",
+				URL:   "https://github.com/owner/a",
+				Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "src/", To: dirA}}}},
+			},
+			{
+				Name:  "vendor-b",
+				URL:   "https://github.com/owner/b",
+				Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "gone.go", To: deletedPath}}}},
+			},
+		},
+	}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{
+			{
+				Name: "vendor-a", Ref: "main", CommitHash: "aaa111",
+				FileHashes: map[string]string{goodPath: goodHash, editedPath: "0000"},
+			},
+			{
+				Name: "vendor-b", Ref: "main", CommitHash: "bbb222",
+				FileHashes: map[string]string{deletedPath: "1111"},
+			},
+		},
+	}, nil)
+
+	result, err := NewVerifyService(configStore, lockStore, realCache, NewOSFileSystem(), ".").Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	rows := GroupVerifyByVendor(result)
+	var sum types.VendorVerifySummary
+	byName := make(map[string]types.VendorVerifySummary)
+	for _, row := range rows {
+		byName[row.Vendor] = row
+		sum.TotalFiles += row.TotalFiles
+		sum.Verified += row.Verified
+		sum.Modified += row.Modified
+		sum.Added += row.Added
+		sum.Deleted += row.Deleted
+		sum.Truncated += row.Truncated
+		sum.Accepted += row.Accepted
+		sum.Stale += row.Stale
+		sum.Orphaned += row.Orphaned
+	}
+
+	s := result.Summary
+	want := types.VendorVerifySummary{
+		TotalFiles: s.TotalFiles, Verified: s.Verified, Modified: s.Modified, Added: s.Added,
+		Deleted: s.Deleted, Truncated: s.Truncated, Accepted: s.Accepted, Stale: s.Stale, Orphaned: s.Orphaned,
+	}
+	if sum != want {
+		t.Errorf("per-vendor rows sum to %+v, want global summary %+v", sum, want)
+	}
+
+	if a := byName["vendor-a"]; a.Verified != 1 || a.Modified != 1 {
+		t.Errorf("vendor-a row = %+v, want 1 verified and 1 modified", a)
+	}
+	if b := byName["vendor-b"]; b.Deleted != 1 {
+		t.Errorf("vendor-b row = %+v, want 1 deleted", b)
+	}
+	if u := byName[types.UnattributedVendor]; u.Added != 1 {
+		t.Errorf("unattributed row = %+v, want the extra file as added", u)
+	}
+	for i := 1; i < len(rows); i++ {
+		if rows[i-1].Vendor >= rows[i].Vendor {
+			t.Errorf("rows not sorted by vendor: %q before %q", rows[i-1].Vendor, rows[i].Vendor)
+		}
+	}
+}
+
+// TestVerify_TruncatedFile verifies that a destination emptied after sync is
+// reported as "truncated" with a re-sync hint, while a file that is empty
+// upstream still verifies and a non-empty edit is still "modified".
+func TestVerify_TruncatedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current dir: %v", err)
+	}
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+	defer os.Chdir(oldDir) //nolint:errcheck
+
+	if err := os.MkdirAll("lib", 0755); err != nil {
+		t.Fatalf("Failed to create vendor dir: %v", err)
+	}
+	truncatedPath := filepath.Join("lib", "truncated.go")
+	emptyPath := filepath.Join("lib", "empty.go")
+	editedPath := filepath.Join("lib", "edited.go")
+	for path, content := range map[string]string{
+		truncatedPath: "",
+		emptyPath:     "",
+		editedPath:    "package lib // local edit\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+
+	configStore.EXPECT().Load().Return(types.VendorConfig{
+		Vendors: []types.VendorSpec{
+			{
+				Name: "vendor-a",
+				URL:  "https://github.com/owner/a",
+				Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{
+					{From: "truncated.go", To: truncatedPath},
+					{From: "empty.go", To: emptyPath},
+					{From: "edited.go", To: editedPath},
+				}}},
+			},
+		},
+	}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{
+			{
+				Name: "vendor-a", Ref: "main", CommitHash: "aaa111",
+				FileHashes: map[string]string{
+					truncatedPath: "5f2b51ca2fdc5baa31ec02e002f69aec6bbc0a8e1a5a0d7d4b8c3f0a1b2c3d4e",
+					emptyPath:     emptyFileSHA256,
+					editedPath:    "0000",
+				},
+			},
+		},
+	}, nil)
+
+	realCache := NewFileCacheStore(NewOSFileSystem(), ".")
+	result, err := NewVerifyService(configStore, lockStore, realCache, NewOSFileSystem(), ".").Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	statuses := make(map[string]types.FileStatus)
+	for _, f := range result.Files {
+		statuses[f.Path] = f
+	}
+	if got := statuses[truncatedPath]; got.Status != "truncated" || got.Hint == "" {
+		t.Errorf("%s = status %q hint %q, want truncated with a re-sync hint", truncatedPath, got.Status, got.Hint)
+	}
+	if got := statuses[emptyPath].Status; got != "verified" {
+		t.Errorf("%s status = %q, want verified (empty upstream file)", emptyPath, got)
+	}
+	if got := statuses[editedPath].Status; got != "modified" {
+		t.Errorf("%s status = %q, want modified", editedPath, got)
+	}
+	if result.Summary.Truncated != 1 || result.Summary.Modified != 1 {
+		t.Errorf("summary = %+v, want 1 truncated and 1 modified", result.Summary)
+	}
+	if result.Summary.Result != "FAIL" {
+		t.Errorf("Result = %q, want FAIL", result.Summary.Result)
+	}
+}
+
+// cancellingCacheStore cancels the verify context after hashing cancelAfter files.
+type cancellingCacheStore struct {
+	*mockCacheStore
+	cancel      context.CancelFunc
+	cancelAfter int
+	hashed      int
+}
+
+func (c *cancellingCacheStore) ComputeFileChecksum(path string) (string, error) {
+	c.hashed++
+	if c.hashed == c.cancelAfter {
+		c.cancel()
+	}
+	return c.mockCacheStore.ComputeFileChecksum(path)
+}
+
+func TestVerify_CancelledMidVerifyStopsHashing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	configStore := NewMockConfigStore(ctrl)
+	lockStore := NewMockLockStore(ctrl)
+	fs := NewMockFileSystem(ctrl)
+
+	fileHashes := make(map[string]string)
+	cache := newMockCacheStore()
+	for i := 0; i < 5; i++ {
+		path := fmt.Sprintf("lib/file%d.go", i)
+		fileHashes[path] = "hash"
+		cache.files[path] = "hash"
+	}
+	configStore.EXPECT().Load().Return(types.VendorConfig{}, nil)
+	lockStore.EXPECT().Load().Return(types.VendorLock{
+		Vendors: []types.LockDetails{{Name: "big", Ref: "main", CommitHash: "abc123", FileHashes: fileHashes}},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	counting := &cancellingCacheStore{mockCacheStore: cache, cancel: cancel, cancelAfter: 2}
+
+	// Serial hashing: with more workers, files already in flight finish too
+	svc := NewVerifyService(configStore, lockStore, counting, fs, ".")
+	result, err := svc.VerifyWithOptions(ctx, VerifyOptions{Workers: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got result=%v err=%v", result, err)
+	}
+	if counting.hashed != 2 {
+		t.Errorf("hashed %d files, want verify to stop right after cancellation (2)", counting.hashed)
+	}
+}
+
+func TestVerify_ParallelHashingMatchesSerial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fileHashes := make(map[string]string)
+	cache := newMockCacheStore()
+	for i := 0; i < 50; i++ {
+		path := fmt.Sprintf("lib/file%02d.go", i)
+		fileHashes[path] = fmt.Sprintf("hash%02d", i)
+		switch i % 5 {
+		case 0: // deleted: not in the cache store
+		case 1:
+			cache.files[path] = "changed"
+		case 2:
+			cache.files[path] = emptyFileSHA256
+		default:
+			cache.files[path] = fileHashes[path]
+		}
+	}
+	lock := types.VendorLock{Vendors: []types.LockDetails{{Name: "big", Ref: "main", CommitHash: "abc123", FileHashes: fileHashes}}}
+
+	run := func(workers int) *types.VerifyResult {
+		t.Helper()
+		configStore := NewMockConfigStore(ctrl)
+		lockStore := NewMockLockStore(ctrl)
+		configStore.EXPECT().Load().Return(types.VendorConfig{}, nil)
+		lockStore.EXPECT().Load().Return(lock, nil)
+		svc := NewVerifyService(configStore, lockStore, cache, NewMockFileSystem(ctrl), ".")
+		result, err := svc.VerifyWithOptions(context.Background(), VerifyOptions{Workers: workers})
+		if err != nil {
+			t.Fatalf("VerifyWithOptions(Workers: %d): %v", workers, err)
+		}
+		return result
+	}
+
+	serial, parallel := run(1), run(8)
+	if serial.Summary != parallel.Summary {
+		t.Errorf("summary differs: serial %+v, parallel %+v", serial.Summary, parallel.Summary)
+	}
+	if serial.Summary.Verified != 20 || serial.Summary.Deleted != 10 || serial.Summary.Modified != 10 || serial.Summary.Truncated != 10 {
+		t.Errorf("unexpected summary %+v", serial.Summary)
+	}
+	if len(serial.Files) != len(parallel.Files) {
+		t.Fatalf("file count differs: serial %d, parallel %d", len(serial.Files), len(parallel.Files))
+	}
+	for i := range serial.Files {
+		if serial.Files[i].Path != parallel.Files[i].Path || serial.Files[i].Status != parallel.Files[i].Status {
+			t.Errorf("Files[%d]: serial %s (%s), parallel %s (%s)", i,
+				serial.Files[i].Path, serial.Files[i].Status, parallel.Files[i].Path, parallel.Files[i].Status)
+		}
+		// Each pass (whole files, then orphaned lock entries) is in path order
+		if i > 0 && serial.Files[i-1].Type == serial.Files[i].Type && serial.Files[i-1].Path >= serial.Files[i].Path {
+			t.Errorf("Files not sorted by path at %d: %s >= %s", i, serial.Files[i-1].Path, serial.Files[i].Path)
+		}
+	}
+}
+
+func TestVerify_DeadlineExceededStopsAddedFileWalk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "extra.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := NewMockFileSystem(ctrl)
+	fs.EXPECT().Stat(dir).Return(&mockFileInfo{name: "lib", isDir: true}, nil)
+
+	config := types.VendorConfig{Vendors: []types.VendorSpec{{
+		Name:  "lib",
+		Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{{From: "src", To: dir}}}},
+	}}}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	svc := NewVerifyService(nil, nil, newMockCacheStore(), fs, ".")
+	added, err := svc.findAddedFiles(ctx, config, map[string]expectedFileInfo{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded from walk, got added=%v err=%v", added, err)
+	}
+}
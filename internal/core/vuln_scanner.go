@@ -80,6 +80,7 @@ type VulnScanner struct {
 // NewVulnScanner creates a new vulnerability scanner.
 // Reads GIT_VENDOR_OSV_ENDPOINT env var to override the default OSV.dev base URL.
 // Reads GIT_VENDOR_CACHE_TTL env var to override the default 24-hour cache TTL.
+// The cache lives under VendorDir; NewVendorSyncer moves it under its rootDir.
 func NewVulnScanner(lockStore LockStore, configStore ConfigStore) *VulnScanner {
 	// Check for custom cache TTL
 	cacheTTL := defaultCacheTTL
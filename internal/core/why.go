@@ -0,0 +1,91 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// Why reports every vendor@ref that produces the destination target, a path
+// relative to the project root (absolute paths are made relative to the
+// working directory). A mapping matches when its resolved destination (see
+// mappingDestFile) is target itself or a directory containing it. Lock
+// FileHashes entries no mapping accounts for are reported as orphaned.
+// Returns an UnmanagedPathError when nothing produces target.
+func (s *VendorSyncer) Why(target string) (*types.WhyResult, error) {
+	config, err := s.configStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	// Before the first sync there is no lock; mappings alone still answer
+	lock, err := s.lockStore.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load lockfile: %w", err)
+	}
+
+	if filepath.IsAbs(target) {
+		if cwd, cwdErr := os.Getwd(); cwdErr == nil {
+			if rel, relErr := filepath.Rel(cwd, target); relErr == nil {
+				target = rel
+			}
+		}
+	}
+	target = filepath.ToSlash(filepath.Clean(target))
+
+	result := &types.WhyResult{Path: target, Matches: []types.WhyMatch{}}
+	matched := make(map[string]bool) // vendor@ref with a mapping match
+
+	for i := range config.Vendors {
+		v := &config.Vendors[i]
+		for _, spec := range v.Specs {
+			entry := findLockEntry(lock, v.Name, spec.Ref)
+			for _, mapping := range spec.Mapping {
+				dest := mappingDestFile(v, spec, mapping)
+				if target != dest && !strings.HasPrefix(target, dest+"/") {
+					continue
+				}
+				src := cleanMappingSource(mapping.From, spec.Ref)
+				srcFile, _, parseErr := types.ParsePathPosition(src)
+				if parseErr != nil {
+					srcFile = src
+				}
+				match := types.WhyMatch{
+					Vendor:     v.Name,
+					URL:        SanitizeURL(v.URL),
+					Ref:        spec.Ref,
+					From:       mapping.From,
+					To:         dest,
+					SourcePath: path.Join(filepath.ToSlash(srcFile), strings.TrimPrefix(strings.TrimPrefix(target, dest), "/")),
+				}
+				if entry != nil {
+					match.CommitHash = entry.CommitHash
+					match.FileHash = entry.FileHashes[target]
+				}
+				result.Matches = append(result.Matches, match)
+				matched[v.Name+"@"+spec.Ref] = true
+			}
+		}
+	}
+
+	for _, l := range lock.Vendors {
+		hash, ok := l.FileHashes[target]
+		if !ok || matched[l.Name+"@"+l.Ref] {
+			continue
+		}
+		match := types.WhyMatch{Vendor: l.Name, Ref: l.Ref, CommitHash: l.CommitHash, FileHash: hash, Orphaned: true}
+		if v := FindVendor(config.Vendors, l.Name); v != nil {
+			match.URL = SanitizeURL(v.URL)
+		}
+		result.Matches = append(result.Matches, match)
+	}
+
+	if len(result.Matches) == 0 {
+		return nil, NewUnmanagedPathError(target)
+	}
+	return result, nil
+}
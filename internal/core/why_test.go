@@ -0,0 +1,92 @@
+package core
+
+import (
+	"os"
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+func newWhyTestSyncer() *VendorSyncer {
+	config := types.VendorConfig{Vendors: []types.VendorSpec{
+		{Name: "foo", URL: "https://github.com/owner/foo", Specs: []types.BranchSpec{{Ref: "main", Mapping: []types.PathMapping{
+			{From: "README.md", To: "docs/foo.md"},
+			{From: "src/foo", To: "lib/foo"},
+		}}}},
+	}}
+	lock := types.VendorLock{Vendors: []types.LockDetails{
+		{Name: "foo", Ref: "main", CommitHash: "abc123def456", FileHashes: map[string]string{
+			"docs/foo.md":    "h-readme",
+			"lib/foo/bar.go": "h-bar",
+			"lib/old.go":     "h-old",
+		}},
+	}}
+	return &VendorSyncer{
+		configStore: &stubConfigStore{config: config},
+		lockStore:   &stubLockStore{lock: lock},
+	}
+}
+
+func TestWhy_DirectFileMapping(t *testing.T) {
+	result, err := newWhyTestSyncer().Why("./docs/foo.md")
+	if err != nil {
+		t.Fatalf("Why: %v", err)
+	}
+	if result.Path != "docs/foo.md" || len(result.Matches) != 1 {
+		t.Fatalf("result = %+v, want one match for docs/foo.md", result)
+	}
+	want := types.WhyMatch{
+		Vendor: "foo", URL: "https://github.com/owner/foo", Ref: "main",
+		From: "README.md", To: "docs/foo.md", SourcePath: "README.md",
+		CommitHash: "abc123def456", FileHash: "h-readme",
+	}
+	if result.Matches[0] != want {
+		t.Errorf("match = %+v, want %+v", result.Matches[0], want)
+	}
+}
+
+func TestWhy_FileInsideDirectoryMapping(t *testing.T) {
+	result, err := newWhyTestSyncer().Why("lib/foo/bar.go")
+	if err != nil {
+		t.Fatalf("Why: %v", err)
+	}
+	if len(result.Matches) != 1 {
+		t.Fatalf("matches = %+v, want 1", result.Matches)
+	}
+	m := result.Matches[0]
+	if m.From != "src/foo" || m.To != "lib/foo" || m.SourcePath != "src/foo/bar.go" || m.FileHash != "h-bar" || m.Orphaned {
+		t.Errorf("match = %+v, want src/foo/bar.go via src/foo -> lib/foo", m)
+	}
+}
+
+func TestWhy_OrphanedAndUnmanaged(t *testing.T) {
+	syncer := newWhyTestSyncer()
+
+	result, err := syncer.Why("lib/old.go")
+	if err != nil {
+		t.Fatalf("Why(lib/old.go): %v", err)
+	}
+	if len(result.Matches) != 1 || !result.Matches[0].Orphaned || result.Matches[0].FileHash != "h-old" {
+		t.Errorf("matches = %+v, want one orphaned lock entry", result.Matches)
+	}
+
+	// "lib" only contains a destination, and lib/foobar.go merely shares a prefix
+	for _, path := range []string{"lib", "lib/foobar.go", "src/main.go"} {
+		if _, err := syncer.Why(path); !IsUnmanagedPath(err) {
+			t.Errorf("Why(%s) err = %v, want UnmanagedPathError", path, err)
+		}
+	}
+}
+
+func TestWhy_NoLockYet(t *testing.T) {
+	syncer := newWhyTestSyncer()
+	syncer.lockStore = &stubLockStore{err: os.ErrNotExist}
+
+	result, err := syncer.Why("lib/foo/bar.go")
+	if err != nil {
+		t.Fatalf("Why without a lock: %v", err)
+	}
+	if len(result.Matches) != 1 || result.Matches[0].CommitHash != "" || result.Matches[0].SourcePath != "src/foo/bar.go" {
+		t.Errorf("matches = %+v, want the unsynced mapping", result.Matches)
+	}
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+)
+
+// detectedProfile is the terminal's color profile, restored when color is
+// re-enabled after SetColorEnabled(false).
+var detectedProfile = lipgloss.ColorProfile()
+
+// SetColorEnabled turns ANSI styling on or off for every styled helper in the
+// package (StyleTitle, PrintSuccess, PrintError, progress output, ...). All of
+// them render through lipgloss's default renderer, so this is the one toggle.
+func SetColorEnabled(enabled bool) {
+	if enabled {
+		lipgloss.SetColorProfile(detectedProfile)
+		return
+	}
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// ColorEnabled reports whether styled helpers emit ANSI escape sequences.
+func ColorEnabled() bool {
+	return lipgloss.ColorProfile() != termenv.Ascii
+}
+
+// ConfigureColor disables styling when noColor is set (--no-color), when the
+// NO_COLOR environment variable is non-empty (see no-color.org), or when
+// stdout is not a terminal, and enables it otherwise.
+func ConfigureColor(noColor bool) {
+	SetColorEnabled(!noColor && os.Getenv("NO_COLOR") == "" && isatty.IsTerminal(os.Stdout.Fd()))
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// forceColor makes the styled helpers emit escapes regardless of whether the
+// test's stdout is a terminal, restoring the previous profile afterwards.
+func forceColor(t *testing.T) {
+	t.Helper()
+	previous := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(previous) })
+}
+
+func TestSetColorEnabled_DisabledHelpersReturnPlainStrings(t *testing.T) {
+	forceColor(t)
+	if styled := StyleTitle("Vendors"); !strings.Contains(styled, "\x1b[") {
+		t.Fatalf("StyleTitle with color forced = %q, expected ANSI escapes", styled)
+	}
+
+	SetColorEnabled(false)
+	if ColorEnabled() {
+		t.Error("ColorEnabled() = true after SetColorEnabled(false)")
+	}
+	if got := StyleTitle("Vendors"); got != "Vendors" {
+		t.Errorf("StyleTitle = %q, want plain %q", got, "Vendors")
+	}
+	for name, render := range map[string]func() string{
+		"PrintSuccess": func() string { return captureStdout(func() { PrintSuccess("Saved lib") }) },
+		"PrintError":   func() string { return captureStdout(func() { PrintError("Failed", "no such vendor") }) },
+		"PrintWarning": func() string { return captureStdout(func() { PrintWarning("Empty", "No vendors found.") }) },
+	} {
+		if out := render(); strings.Contains(out, "\x1b[") {
+			t.Errorf("%s output contains escape sequences with color disabled: %q", name, out)
+		}
+	}
+}
+
+func TestConfigureColor_NoColorEnv(t *testing.T) {
+	forceColor(t)
+	t.Setenv("NO_COLOR", "1")
+
+	ConfigureColor(false)
+	if ColorEnabled() {
+		t.Error("NO_COLOR set: color should be disabled")
+	}
+	if got := StyleTitle("x"); got != "x" {
+		t.Errorf("StyleTitle = %q, want plain", got)
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -33,6 +34,10 @@ type VendorManager interface {
 }
 
 func check(err error) {
+	if errors.Is(err, huh.ErrUserAborted) {
+		fmt.Println("Aborted.")
+		os.Exit(core.ExitCancelled)
+	}
 	if err != nil {
 		fmt.Println("Aborted.")
 		os.Exit(1)
@@ -87,6 +92,28 @@ func RunAddWizard(mgr interface{}, existingVendors map[string]types.VendorSpec)
 
 	spec := newBaseSpec(name, url, ref)
 
+	licenseSource := "detect"
+	err = huh.NewSelect[string]().
+		Title("License").
+		Options(
+			huh.NewOption("Detect from repository", "detect"),
+			huh.NewOption("I'll specify the license manually", "manual"),
+		).
+		Value(&licenseSource).
+		Run()
+	check(err)
+	if licenseSource == "manual" {
+		err = huh.NewInput().
+			Title("SPDX License ID").
+			Placeholder("MIT").
+			Description("Recorded as license_override; detection is skipped").
+			Value(&spec.LicenseOverride).
+			Validate(validateLicenseOverride).
+			Run()
+		check(err)
+		spec.LicenseOverride = strings.TrimSpace(spec.LicenseOverride)
+	}
+
 	// Handle deep link path if present
 	if isRootSmartPath(smartPath) {
 		useDeep := true
@@ -412,6 +439,9 @@ func PrintWarning(title, msg string) { fmt.Println(styleWarn.Render("! " + title
 // StyleTitle applies title styling to the given text string.
 func StyleTitle(text string) string { return styleTitle.Render(text) }
 
+// StyleWarning applies warning styling to the given text string.
+func StyleWarning(text string) string { return styleWarn.Render(text) }
+
 // PrintComplianceSuccess displays a license compliance success message.
 func PrintComplianceSuccess(license string) {
 	fmt.Println(styleSuccess.Render(fmt.Sprintf("✔ License Verified: %s", license)))
@@ -431,15 +461,29 @@ func PrintHelp() {
 	fmt.Println("\nWorks as: git-vendor <command> or git vendor <command>")
 	fmt.Println("\nCommands:")
 	fmt.Println("  init                Initialize vendor directory")
+	fmt.Println("                      --gitignore: ignore git-vendor temp files; --readme: add a README")
 	fmt.Println("  add                 Add a new vendor dependency (interactive wizard)")
+	fmt.Println("    --url <url> --ref <ref> --from <path> --to <path> [--name <name>] [--license <spdx>] --yes")
+	fmt.Println("                        Add without the wizard (with --json: print the saved vendor)")
+	fmt.Println("    --dir-per-file      Repeat --from; each file goes to <to>/<basename> (default <name>/)")
+	fmt.Println("    --local             Allow a local path or file:// --url")
 	fmt.Println("  edit                Modify existing vendor configuration")
-	fmt.Println("  remove <name>       Remove a vendor by name")
+	fmt.Println("  remove <name>       Remove a vendor by name (--dry-run lists deletions only)")
+	fmt.Println("  clean               Delete orphaned vendored files (--dry-run, --yes)")
+	fmt.Println("  export [-o <file>]  Archive vendored files, config, lock and licenses (default vendor-snapshot.tar.gz)")
+	fmt.Println("  import <file>       Extract an export archive into place (--yes)")
 	fmt.Println("  list                Show all configured vendors with dependency tree")
+	fmt.Println("                      --format yaml: vendor.yml merged with vendor.lock")
+	fmt.Println("  tree                Show where each vendor writes, as a directory tree")
+	fmt.Println("                      Leaves name their vendor@ref; conflicting nodes are flagged (--json)")
+	fmt.Println("  why <path>          Show the vendor, ref, source path, and locked commit behind a file (--json)")
+	fmt.Println("  graph               Print vendors, their top-level destinations, and conflicts as Graphviz DOT")
+	fmt.Println("                      Pipe into dot: git-vendor graph | dot -Tpng -o vendors.png (--format dot)")
 	fmt.Println("  sync [options] [vendor-name]")
 	fmt.Println("                      Download dependencies to locked versions")
 	fmt.Println("                      Supports position extraction (e.g., file.go:L5-L20)")
 	fmt.Println("    --dry-run         Preview what will be synced without making changes")
-	fmt.Println("    --force           Re-download even if already synced")
+	fmt.Println("    --force           Re-download even if already synced, overwriting local edits without asking")
 	fmt.Println("    --no-cache        Disable incremental sync cache")
 	fmt.Println("    --group <name>    Sync only vendors in the specified group")
 	fmt.Println("    --parallel        Enable parallel processing (3-5x faster)")
@@ -448,10 +492,15 @@ func PrintHelp() {
 	fmt.Println("    --reverse         Propagate dest changes to source (requires --internal)")
 	fmt.Println("    --commit          Auto-commit after sync with vendor trailers")
 	fmt.Println("    --local           Allow file:// and local filesystem paths")
+	fmt.Println("    --retry-on-stale  Update a vendor whose locked commit is gone upstream, then retry once")
+	fmt.Println("    --allow-hooks     Run each vendor's post_sync command in its destination")
+	fmt.Println("    --hardlink        Hard-link a vendor's identical files to one copy")
+	fmt.Println("    --since <age>     Only update vendors with upstream commits within age (e.g. 14d)")
 	fmt.Println("    --verbose, -v     Show git commands as they run")
 	fmt.Println("    <vendor-name>     Sync only the specified vendor")
 	fmt.Println("  update [options] [vendor-name]")
 	fmt.Println("                      Fetch latest commits and update lockfile")
+	fmt.Println("    --dry-run         List vendors whose lock would move (old → new), write nothing")
 	fmt.Println("    --group <name>    Update only vendors in the specified group")
 	fmt.Println("    --parallel        Enable parallel processing (3-5x faster)")
 	fmt.Println("    --workers <N>     Number of parallel workers (default: NumCPU)")
@@ -459,9 +508,12 @@ func PrintHelp() {
 	fmt.Println("    --verbose, -v     Show git commands as they run")
 	fmt.Println("    <vendor-name>     Update only the specified vendor")
 	fmt.Println("  validate            Check configuration integrity and detect conflicts")
+	fmt.Println("                      --check-only: also check coherence and licenses, fail on any issue")
 	fmt.Println("  verify [options]    Verify vendored files against lockfile hashes")
 	fmt.Println("                      Checks both whole-file and position-level (L5-L20) hashes")
 	fmt.Println("    --format=<fmt>    Output format: table (default) or json")
+	fmt.Println("    --strict          Exit 1 on warnings too (added, stale, orphaned)")
+	fmt.Println("    --fail-on <list>  Exit 1 only for these statuses, e.g. modified,deleted,added")
 	fmt.Println("    Exit codes: 0=PASS, 1=FAIL (modified/deleted), 2=WARN (added)")
 	fmt.Println("  scan [options]      Scan vendored dependencies for CVE vulnerabilities")
 	fmt.Println("    --format=<fmt>    Output format: table (default) or json")
@@ -477,10 +529,18 @@ func PrintHelp() {
 	fmt.Println("    --format=<fmt>    Output format: table (default) or json")
 	fmt.Println("    --fail-on <level> Fail threshold: deny (default) or warn")
 	fmt.Println("    Exit codes: 0=PASS, 1=FAIL (denied), 2=WARN (warned)")
+	fmt.Println("  licenses [options]  Generate a third-party notices report")
+	fmt.Println("    --output <file>   Write to file instead of stdout")
+	fmt.Println("    --json            Output structured entries as JSON")
 	fmt.Println("  status [options]    Unified inspection: verify + outdated")
 	fmt.Println("    --offline           Skip remote checks (only lock-vs-disk)")
 	fmt.Println("    --remote-only       Skip disk checks (only lock-vs-upstream)")
+	fmt.Println("    --since <age>       Only report vendors with upstream commits within age (e.g. 14d)")
 	fmt.Println("    --format=<fmt>      Output format: table (default) or json")
+	fmt.Println("    --strict            Treat WARN as FAIL for the exit code")
+	fmt.Println("    --fail-on <list>    Statuses that exit 1 (modified, deleted, truncated, added,")
+	fmt.Println("                        stale, orphaned, outdated, upstream-error, policy);")
+	fmt.Println("                        others exit 2")
 	fmt.Println("    Exit codes: 0=PASS, 1=FAIL, 2=WARN")
 	fmt.Println("  outdated [vendor]   Check if locked versions are behind upstream")
 	fmt.Println("    --json              Output as JSON")
@@ -491,11 +551,23 @@ func PrintHelp() {
 	fmt.Println("                      Show commit differences between locked and latest")
 	fmt.Println("  watch               Watch for config changes and auto-sync")
 	fmt.Println("  completion <shell>  Generate shell completion script (bash/zsh/fish/powershell)")
+	fmt.Println("  bump <vendor> <ref> Move a vendor to a new ref and re-sync it (--no-sync: config only)")
+	fmt.Println("  pin <vendor>        Freeze a vendor at its locked commit (update skips it)")
+	fmt.Println("  unpin <vendor> [--ref <branch>]")
+	fmt.Println("                      Return a pinned vendor to the branch it was pinned from")
+	fmt.Println("  lock [--regenerate] Check vendor.lock against vendor.yml (exit 1 on mismatch)")
+	fmt.Println("    --regenerate        Re-fetch every vendor at its config ref and rewrite the lock")
+	fmt.Println("    --include-pinned    With --regenerate, also re-fetch pinned vendors")
+	fmt.Println("    --rehash            Rewrite the lock checksum after editing it by hand (e.g. a merge)")
+	fmt.Println("  schema              Print the vendor.yml JSON Schema (for editor validation)")
 	fmt.Println("\nLLM-Friendly Commands (non-interactive):")
 	fmt.Println("  create <name> <url> [--ref <ref>] [--license <license>]")
 	fmt.Println("                      Add vendor without interactive wizard")
 	fmt.Println("  delete <name>       Remove vendor (alias for remove, same flags)")
 	fmt.Println("  rename <old> <new>  Rename a vendor across config, lock, and license")
+	fmt.Println("  mv <vendor> <new-dest>")
+	fmt.Println("                      Move a vendor's files, mappings, and lock paths under new-dest")
+	fmt.Println("  normalize           Rewrite vendor.yml in canonical form (sorted, clean paths)")
 	fmt.Println("  add-mapping <vendor> <from> --to <to> [--ref <ref>]")
 	fmt.Println("                      Add a path mapping to a vendor")
 	fmt.Println("  remove-mapping <vendor> <from>")
@@ -511,6 +583,8 @@ func PrintHelp() {
 	fmt.Println("  config get <key>    Get a config value (e.g., vendors.mylib.url)")
 	fmt.Println("  config set <key> <value>")
 	fmt.Println("                      Set a config value")
+	fmt.Println("  config show [--resolved] [--format yaml|json]")
+	fmt.Println("                      Print vendor.yml, or the effective config with defaults applied")
 	fmt.Println("  config add-mirror <vendor> <url>")
 	fmt.Println("                      Add a mirror URL to a vendor")
 	fmt.Println("  config remove-mirror <vendor> <url>")
@@ -518,6 +592,10 @@ func PrintHelp() {
 	fmt.Println("  config list-mirrors <vendor>")
 	fmt.Println("                      List primary URL and mirrors for a vendor")
 	fmt.Println("  All LLM commands support --json for structured JSON output.")
+	fmt.Println("\nGlobal Options:")
+	fmt.Println("  --config <dir>      Use <dir> as the vendor directory instead of .git-vendor")
+	fmt.Println("                      (e.g. init --config vendor2/ for a second vendor set)")
+	fmt.Println("  --no-color          Plain output without colors (also NO_COLOR=1, or when piped)")
 	fmt.Println("\nExamples:")
 	fmt.Println("  git-vendor init")
 	fmt.Println("  git-vendor add")
@@ -540,6 +618,7 @@ func PrintHelp() {
 	fmt.Println("  git-vendor license --format=json")
 	fmt.Println("  git-vendor license --fail-on warn")
 	fmt.Println("  git-vendor license --policy custom-policy.yml")
+	fmt.Println("  git-vendor licenses -o THIRD_PARTY_NOTICES")
 	fmt.Println("  git-vendor status")
 	fmt.Println("  git-vendor outdated")
 	fmt.Println("  git-vendor outdated --json")
@@ -549,6 +628,7 @@ func PrintHelp() {
 	fmt.Println("  git-vendor watch")
 	fmt.Println("  git-vendor completion bash > /etc/bash_completion.d/git-vendor")
 	fmt.Println("  git-vendor remove my-vendor")
+	fmt.Println("  git-vendor add --url https://github.com/org/api --ref main --from src --to lib/api --yes --json")
 	fmt.Println("  git-vendor create api-types https://github.com/org/api --ref v2.0.0 --license MIT")
 	fmt.Println("  git-vendor add-mapping api-types src/types/user.ts --to lib/types/user.ts")
 	fmt.Println("  git-vendor show api-types --json")
@@ -23,6 +23,19 @@ func validateURL(s string) error {
 	return nil
 }
 
+// validateLicenseOverride validates the manually entered license in the add
+// wizard. validateLicenseOverride accepts only IDs in core.KnownSPDXLicenses.
+func validateLicenseOverride(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return fmt.Errorf("license cannot be empty")
+	}
+	if !core.IsKnownSPDXLicense(s) {
+		return fmt.Errorf("%q is not a recognized SPDX license identifier", s)
+	}
+	return nil
+}
+
 // formatBranchLabel builds a display label for a BranchSpec in the edit wizard.
 // formatBranchLabel combines ref name, mapping count, and lock status into a single line.
 func formatBranchLabel(ref string, mappingCount int, lockHash string) string {
@@ -64,3 +64,15 @@ func TestValidateToPath_InvalidPosition(t *testing.T) {
 		t.Error("expected error for end line < start line")
 	}
 }
+
+func TestValidateLicenseOverride(t *testing.T) {
+	if err := validateLicenseOverride(" MIT "); err != nil {
+		t.Errorf("unexpected error for MIT: %v", err)
+	}
+	if err := validateLicenseOverride(""); err == nil {
+		t.Error("expected error for empty license")
+	}
+	if err := validateLicenseOverride("Proprietary"); err == nil {
+		t.Error("expected error for non-SPDX license")
+	}
+}
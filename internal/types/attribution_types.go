@@ -0,0 +1,24 @@
+package types
+
+// AttributionReport is the third-party notices report built from the lockfile
+// and the captured license files. AttributionReport is the JSON output of the
+// licenses command; the text output concatenates the same entries.
+type AttributionReport struct {
+	Vendors []AttributionEntry `json:"vendors"`
+}
+
+// AttributionEntry describes one vendored ref and the license text shipped with it.
+type AttributionEntry struct {
+	Name       string            `json:"name"`
+	URL        string            `json:"url"`
+	License    string            `json:"license"` // SPDX license identifier, "" if unknown
+	Ref        string            `json:"ref"`
+	CommitHash string            `json:"commit_hash"`
+	Files      []AttributionFile `json:"files"` // Captured license/notice files, primary license first
+}
+
+// AttributionFile is a captured license or notice file and its contents.
+type AttributionFile struct {
+	Path string `json:"path"`
+	Text string `json:"text"`
+}
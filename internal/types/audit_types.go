@@ -2,8 +2,8 @@
 package types
 
 // AuditResult is the top-level result for the unified audit command.
-// AuditResult aggregates results from verify, scan, license, and drift sub-checks
-// and produces a combined pass/fail summary.
+// AuditResult aggregates results from verify, scan, license, drift, and (opt-in)
+// ancestry sub-checks plus the lock inventory and produces a combined pass/fail summary.
 type AuditResult struct {
 	SchemaVersion string              `json:"schema_version"`
 	Timestamp     string              `json:"timestamp"`
@@ -11,6 +11,8 @@ type AuditResult struct {
 	Scan          *ScanResult         `json:"scan,omitempty"`
 	License       *LicenseReportResult `json:"license,omitempty"`
 	Drift         *DriftResult        `json:"drift,omitempty"`
+	Ancestry      *AncestryResult     `json:"ancestry,omitempty"`
+	Inventory     *InventoryResult    `json:"inventory,omitempty"`
 	Summary       AuditSummary        `json:"summary"`
 }
 
@@ -30,3 +32,69 @@ const (
 	AuditResultFail = "FAIL"
 	AuditResultWarn = "WARN"
 )
+
+// AncestryResult reports whether each locked commit is still reachable from its
+// configured ref (audit --ancestry). An orphaned commit usually means upstream
+// force-pushed or rewrote the branch after the lock was written.
+type AncestryResult struct {
+	Entries []AncestryEntry `json:"entries"`
+	Summary AncestrySummary `json:"summary"`
+}
+
+// AncestryEntry is the reachability finding for one vendor@ref lock entry.
+type AncestryEntry struct {
+	Vendor     string `json:"vendor"`
+	Ref        string `json:"ref"`
+	CommitHash string `json:"commit_hash"`
+	Status     string `json:"status"`          // AncestryReachable, AncestryOrphaned, or AncestryError
+	Error      string `json:"error,omitempty"` // Failure detail when Status is AncestryError
+}
+
+// AncestrySummary contains aggregate counts for an ancestry check.
+type AncestrySummary struct {
+	Total     int    `json:"total"`
+	Reachable int    `json:"reachable"`
+	Orphaned  int    `json:"orphaned"`
+	Errors    int    `json:"errors"`
+	Result    string `json:"result"` // "PASS" or "WARN" (orphaned commits or check errors)
+}
+
+// Ancestry status constants for AncestryEntry.Status.
+const (
+	AncestryReachable = "reachable"
+	AncestryOrphaned  = "orphaned"
+	AncestryError     = "error"
+)
+
+// InventoryResult lists every lock entry with its ref, commit, license, and
+// last update (audit inventory), flagging entries that need attention.
+type InventoryResult struct {
+	Entries []InventoryEntry `json:"entries"`
+	Summary InventorySummary `json:"summary"`
+}
+
+// InventoryEntry describes one vendor@ref lock entry.
+type InventoryEntry struct {
+	Vendor      string   `json:"vendor"`
+	Ref         string   `json:"ref"`
+	CommitHash  string   `json:"commit_hash"`
+	License     string   `json:"license,omitempty"`      // SPDX identifier recorded in the lock
+	LicensePath string   `json:"license_path,omitempty"` // Captured license file
+	Updated     string   `json:"updated"`
+	Synced      string   `json:"synced,omitempty"` // Last time sync or update copied the files (LastSyncedAt)
+	Issues      []string `json:"issues,omitempty"` // InventoryMissingLicense, InventoryNotInConfig
+}
+
+// InventorySummary contains aggregate counts for the lock inventory.
+type InventorySummary struct {
+	Total          int    `json:"total"`
+	MissingLicense int    `json:"missing_license"`
+	NotInConfig    int    `json:"not_in_config"`
+	Result         string `json:"result"` // "PASS" or "WARN" (any flagged entry)
+}
+
+// Inventory issue constants for InventoryEntry.Issues.
+const (
+	InventoryMissingLicense = "missing_license" // External entry without a captured license file on disk
+	InventoryNotInConfig    = "not_in_config"   // Lock entry whose vendor is no longer in vendor.yml
+)
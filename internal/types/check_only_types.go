@@ -0,0 +1,43 @@
+package types
+
+// CheckOnlyResult is the consolidated pre-merge gate report produced by
+// "validate --check-only". CheckOnlyResult lists every issue found by config
+// validation, conflict detection, lock coherence, and the license policy,
+// each with a suggested fix.
+type CheckOnlyResult struct {
+	Issues  []CheckOnlyIssue `json:"issues"`
+	Summary CheckOnlySummary `json:"summary"`
+}
+
+// CheckOnlyIssue is a single finding with its remediation.
+type CheckOnlyIssue struct {
+	Check       string `json:"check"`            // One of the CheckOnly* check constants
+	Severity    string `json:"severity"`         // "error" or "warning"
+	Vendor      string `json:"vendor,omitempty"` // Vendor the issue belongs to, when known
+	Path        string `json:"path,omitempty"`   // Affected destination path, when known
+	Message     string `json:"message"`
+	Remediation string `json:"remediation"`
+}
+
+// CheckOnlySummary counts issues by severity. Result is FAIL when any issue
+// (error or warning) was found, PASS otherwise.
+type CheckOnlySummary struct {
+	Total    int    `json:"total"`
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+	Result   string `json:"result"` // "PASS" or "FAIL"
+}
+
+// Check names for CheckOnlyIssue.Check.
+const (
+	CheckOnlyConfig    = "config"
+	CheckOnlyConflict  = "conflict"
+	CheckOnlyCoherence = "coherence"
+	CheckOnlyLicense   = "license"
+)
+
+// Severity values for CheckOnlyIssue.Severity.
+const (
+	CheckOnlySeverityError   = "error"
+	CheckOnlySeverityWarning = "warning"
+)
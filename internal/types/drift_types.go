@@ -47,17 +47,28 @@ type DriftStats struct {
 
 // DriftFile represents drift analysis for a single vendored file.
 type DriftFile struct {
-	Path                 string  `json:"path"`
-	LocalStatus          string  `json:"local_status"`                     // unchanged, modified, deleted, added
-	UpstreamStatus       string  `json:"upstream_status,omitempty"`        // unchanged, modified, deleted, added
-	LocalLinesAdded      int     `json:"local_lines_added,omitempty"`
-	LocalLinesRemoved    int     `json:"local_lines_removed,omitempty"`
-	LocalDriftPct        float64 `json:"local_drift_pct"`
-	UpstreamLinesAdded   int     `json:"upstream_lines_added,omitempty"`
-	UpstreamLinesRemoved int     `json:"upstream_lines_removed,omitempty"`
-	UpstreamDriftPct     float64 `json:"upstream_drift_pct,omitempty"`
-	HasConflictRisk      bool    `json:"has_conflict_risk,omitempty"`
-	DiffOutput           string  `json:"diff_output,omitempty"` // Populated only with --detail flag
+	Path                 string     `json:"path"`
+	LocalStatus          string     `json:"local_status"`              // unchanged, modified, deleted, added
+	UpstreamStatus       string     `json:"upstream_status,omitempty"` // unchanged, modified, deleted, added
+	LocalLinesAdded      int        `json:"local_lines_added,omitempty"`
+	LocalLinesRemoved    int        `json:"local_lines_removed,omitempty"`
+	LocalDriftPct        float64    `json:"local_drift_pct"`
+	UpstreamLinesAdded   int        `json:"upstream_lines_added,omitempty"`
+	UpstreamLinesRemoved int        `json:"upstream_lines_removed,omitempty"`
+	UpstreamDriftPct     float64    `json:"upstream_drift_pct,omitempty"`
+	HasConflictRisk      bool       `json:"has_conflict_risk,omitempty"`
+	DiffOutput           string     `json:"diff_output,omitempty"` // Populated with --detail or --json
+	Hunks                []DiffHunk `json:"hunks,omitempty"`       // Structured form of DiffOutput (--detail or --json)
+}
+
+// DiffHunk is one hunk of a unified diff between the locked and local content.
+// Lines carry their diff prefix: " " unchanged, "-" removed, "+" added.
+type DiffHunk struct {
+	OldStart int      `json:"old_start"`
+	OldLines int      `json:"old_lines"`
+	NewStart int      `json:"new_start"`
+	NewLines int      `json:"new_lines"`
+	Lines    []string `json:"lines"`
 }
 
 // Drift status constants for DriftFile.LocalStatus and DriftFile.UpstreamStatus.
@@ -0,0 +1,26 @@
+// Package types defines data structures for git-vendor configuration and state management.
+package types
+
+// ExportManifest describes an export archive (git-vendor export): which
+// vendors it captures and every file it holds with its SHA-256, so import can
+// check the archive before and while extracting it.
+type ExportManifest struct {
+	SchemaVersion string         `json:"schema_version"`
+	CreatedAt     string         `json:"created_at"`
+	Vendors       []ExportVendor `json:"vendors"`
+	Files         []ExportFile   `json:"files"` // Vendored destination files, project-relative
+	State         []ExportFile   `json:"state"` // Config, lock and license copies, relative to the vendor directory
+}
+
+// ExportVendor records one locked vendor@ref captured by an export.
+type ExportVendor struct {
+	Name       string `json:"name"`
+	Ref        string `json:"ref"`
+	CommitHash string `json:"commit_hash"`
+}
+
+// ExportFile is one file in an export archive.
+type ExportFile struct {
+	Path string `json:"path"`   // Forward-slash path
+	Hash string `json:"sha256"` // SHA-256 of the content
+}
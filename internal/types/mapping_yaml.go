@@ -0,0 +1,181 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// branchSpecYAML is the on-disk form of BranchSpec. Its mappings may carry a
+// list-valued "to", which BranchSpec expands into one PathMapping per destination.
+type branchSpecYAML struct {
+	Ref           string            `yaml:"ref"`
+	DefaultTarget string            `yaml:"default_target,omitempty"`
+	Depth         int               `yaml:"depth,omitempty"`
+	Pinned        bool              `yaml:"pinned,omitempty"`
+	PinnedFrom    string            `yaml:"pinned_from,omitempty"`
+	Mapping       []pathMappingYAML `yaml:"mapping"`
+}
+
+// pathMappingYAML is the on-disk form of PathMapping with a scalar-or-list "to".
+type pathMappingYAML struct {
+	From       string          `yaml:"from"`
+	To         destinationList `yaml:"to"`
+	Include    []string        `yaml:"include,omitempty"`
+	Exclude    []string        `yaml:"exclude,omitempty"`
+	MaxDepth   int             `yaml:"max_depth,omitempty"`
+	Transforms []Transform     `yaml:"transforms,omitempty"`
+}
+
+// destinationList holds a "to" value written either as a string or as a list.
+type destinationList struct {
+	values []string
+	list   bool // Written as a YAML sequence; preserved so single-item lists round-trip
+}
+
+// UnmarshalYAML accepts a scalar destination or a non-empty list of destinations.
+func (d *destinationList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var dest string
+		if err := value.Decode(&dest); err != nil {
+			return err
+		}
+		d.values = []string{dest}
+	case yaml.SequenceNode:
+		var dests []string
+		if err := value.Decode(&dests); err != nil {
+			return err
+		}
+		if len(dests) == 0 {
+			return fmt.Errorf("line %d: mapping 'to' list must contain at least one destination", value.Line)
+		}
+		d.values = dests
+		d.list = true
+	default:
+		return fmt.Errorf("line %d: mapping 'to' must be a path or a list of paths", value.Line)
+	}
+	return nil
+}
+
+// MarshalYAML writes a list when the destinations came from one, otherwise a scalar.
+func (d destinationList) MarshalYAML() (interface{}, error) {
+	if d.list {
+		return d.values, nil
+	}
+	if len(d.values) == 0 {
+		return "", nil
+	}
+	return d.values[0], nil
+}
+
+// UnmarshalYAML decodes a BranchSpec, expanding each multi-destination mapping
+// into consecutive PathMappings that share From, Include, Exclude, MaxDepth,
+// Transforms and a ToGroup id.
+func (s *BranchSpec) UnmarshalYAML(value *yaml.Node) error {
+	var raw branchSpecYAML
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	*s = BranchSpec{
+		Ref:           raw.Ref,
+		DefaultTarget: raw.DefaultTarget,
+		Depth:         raw.Depth,
+		Pinned:        raw.Pinned,
+		PinnedFrom:    raw.PinnedFrom,
+	}
+	if raw.Mapping == nil {
+		return nil
+	}
+
+	s.Mapping = make([]PathMapping, 0, len(raw.Mapping))
+	group := 0
+	for _, m := range raw.Mapping {
+		if !m.To.list {
+			s.Mapping = append(s.Mapping, PathMapping{
+				From:       m.From,
+				To:         firstOrEmpty(m.To.values),
+				Include:    m.Include,
+				Exclude:    m.Exclude,
+				MaxDepth:   m.MaxDepth,
+				Transforms: m.Transforms,
+			})
+			continue
+		}
+		group++
+		for _, dest := range m.To.values {
+			s.Mapping = append(s.Mapping, PathMapping{
+				From:       m.From,
+				To:         dest,
+				Include:    m.Include,
+				Exclude:    m.Exclude,
+				MaxDepth:   m.MaxDepth,
+				Transforms: m.Transforms,
+				ToGroup:    group,
+			})
+		}
+	}
+	return nil
+}
+
+// MarshalYAML encodes a BranchSpec, collapsing consecutive mappings with the
+// same non-zero ToGroup (and the same source and filters) back into one entry
+// with a list-valued "to".
+func (s BranchSpec) MarshalYAML() (interface{}, error) {
+	raw := branchSpecYAML{
+		Ref:           s.Ref,
+		DefaultTarget: s.DefaultTarget,
+		Depth:         s.Depth,
+		Pinned:        s.Pinned,
+		PinnedFrom:    s.PinnedFrom,
+	}
+	if s.Mapping == nil {
+		return raw, nil
+	}
+
+	raw.Mapping = make([]pathMappingYAML, 0, len(s.Mapping))
+	for i, m := range s.Mapping {
+		if m.ToGroup != 0 && i > 0 && sameDestinationGroup(s.Mapping[i-1], m) {
+			last := &raw.Mapping[len(raw.Mapping)-1]
+			last.To.values = append(last.To.values, m.To)
+			continue
+		}
+		raw.Mapping = append(raw.Mapping, pathMappingYAML{
+			From:       m.From,
+			To:         destinationList{values: []string{m.To}, list: m.ToGroup != 0},
+			Include:    m.Include,
+			Exclude:    m.Exclude,
+			MaxDepth:   m.MaxDepth,
+			Transforms: m.Transforms,
+		})
+	}
+	return raw, nil
+}
+
+// sameDestinationGroup reports whether b continues the multi-destination entry of a.
+func sameDestinationGroup(a, b PathMapping) bool {
+	return a.ToGroup == b.ToGroup && a.From == b.From &&
+		equalStrings(a.Include, b.Include) && equalStrings(a.Exclude, b.Exclude) &&
+		a.MaxDepth == b.MaxDepth && slices.Equal(a.Transforms, b.Transforms)
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
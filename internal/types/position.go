@@ -4,10 +4,28 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // PositionSpec represents a line/column range extracted from a path specifier.
-// Supports: L5, L5-L20, L5:L20, L5-EOF, L5C10:L10C30
+// Supports: L5, L5-L20, L5:L20, L5-EOF, L5C10:L10C30, and regex anchors
+// /start/ or /start/,/end/
+//
+// Anchor semantics:
+// An anchored spec has StartPattern (and optionally EndPattern) instead of line
+// numbers. The range starts at the first line matching StartPattern and ends at
+// the first line after it matching EndPattern (or at the start line when
+// EndPattern is empty), so a snippet is still found after upstream inserts or
+// removes lines above it. A "/" inside a pattern is written "\/". Anchored specs
+// are resolved to line numbers against the file content on every extraction
+// and placement (see core.ResolvePosition).
+//
+// Symbol semantics:
+// "file.go#Name" selects a Go declaration by name instead of by line: a func
+// or type Name, or a method written Type.Method. The range covers the whole
+// declaration including its doc comment, and is resolved by parsing the file
+// with go/parser, so it tracks the declaration when surrounding code changes.
+// Symbols are anchored specs (IsAnchored) and resolve the same way.
 //
 // Column semantics (byte-offset based):
 // Columns use Go string byte indexing, NOT Unicode rune offsets.
@@ -44,11 +62,21 @@ type PositionSpec struct {
 	StartCol  int // 1-indexed byte offset, 0 means no column specified
 	EndCol    int // 1-indexed inclusive byte offset, 0 means no column specified
 	ToEOF     bool
+
+	StartPattern string // Regexp locating the first line; set instead of StartLine for anchored specs
+	EndPattern   string // Regexp locating the last line, searched after the start line ("" = single line)
+	Symbol       string // Go declaration name ("Func", "Type", or "Type.Method"); set instead of line numbers
 }
 
 // IsSingleLine returns true if the position targets a single line (no range).
 func (p *PositionSpec) IsSingleLine() bool {
-	return !p.ToEOF && (p.EndLine == 0 || p.EndLine == p.StartLine)
+	return !p.ToEOF && p.EndPattern == "" && p.Symbol == "" && (p.EndLine == 0 || p.EndLine == p.StartLine)
+}
+
+// IsAnchored returns true if the range is located from the file content (regex
+// anchors or a Go symbol) rather than by line numbers.
+func (p *PositionSpec) IsAnchored() bool {
+	return p.StartPattern != "" || p.Symbol != ""
 }
 
 // HasColumns returns true if column-level precision is specified.
@@ -78,7 +106,13 @@ var (
 //	"src/file.go:L5-L20"    -> ("src/file.go", &PositionSpec{StartLine:5, EndLine:20}, nil)
 //	"src/file.go:L10-EOF"   -> ("src/file.go", &PositionSpec{StartLine:10, ToEOF:true}, nil)
 //	"src/file.go:L5C10:L5C30" -> ("src/file.go", &PositionSpec{...columns...}, nil)
+//	"src/file.go:/func Foo/,/^}/" -> ("src/file.go", &PositionSpec{StartPattern:"func Foo", EndPattern:"^}"}, nil)
+//	"src/file.go#Type.Method" -> ("src/file.go", &PositionSpec{Symbol:"Type.Method"}, nil)
 func ParsePathPosition(path string) (string, *PositionSpec, error) {
+	if filePath, symbol, ok := splitSymbol(path); ok {
+		return filePath, &PositionSpec{Symbol: symbol}, nil
+	}
+
 	// Find the first occurrence of ":L<digit>" which marks the position specifier.
 	// We search for ":L<digit>" rather than ":" to avoid splitting on Windows drive letters
 	// or other colon-containing paths. We use the first match because the position specifier
@@ -103,19 +137,110 @@ func ParsePathPosition(path string) (string, *PositionSpec, error) {
 	return filePath, pos, nil
 }
 
-// findPositionStart finds the index of the first ":L" followed by a digit.
-// Returns -1 if no position specifier is found.
+// findPositionStart finds the index of the first ":L" followed by a digit, or
+// of the first ":/" that opens an anchor. "://" (URL schemes) and a drive
+// letter such as "C:/" are not anchors. Returns -1 if no position specifier is found.
 func findPositionStart(path string) int {
 	for i := 0; i < len(path)-2; i++ {
-		if path[i] == ':' && path[i+1] == 'L' && path[i+2] >= '0' && path[i+2] <= '9' {
+		if path[i] != ':' {
+			continue
+		}
+		if path[i+1] == 'L' && path[i+2] >= '0' && path[i+2] <= '9' {
+			return i
+		}
+		if path[i+1] == '/' && path[i+2] != '/' && !(i == 1 && isDriveLetter(path[0])) {
 			return i
 		}
 	}
 	return -1
 }
 
+// reSymbol matches a Go symbol selector: Name or Type.Name.
+var reSymbol = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// splitSymbol splits "file.go#Symbol" into the file path and symbol. Only a
+// ".go" file followed by a valid selector counts, so other paths containing
+// "#" are left alone.
+func splitSymbol(path string) (string, string, bool) {
+	idx := strings.LastIndex(path, "#")
+	if idx <= 0 {
+		return "", "", false
+	}
+	filePath, symbol := path[:idx], path[idx+1:]
+	if !strings.HasSuffix(filePath, ".go") || !reSymbol.MatchString(symbol) {
+		return "", "", false
+	}
+	return filePath, symbol, true
+}
+
+// isDriveLetter reports whether c is an ASCII letter (Windows drive "C:").
+func isDriveLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parseAnchorSpecifier parses "/start/" or "/start/,/end/" into an anchored
+// PositionSpec, validating both patterns as Go regexps.
+func parseAnchorSpecifier(spec string) (*PositionSpec, error) {
+	start, rest, err := readAnchorPattern(spec)
+	if err != nil {
+		return nil, err
+	}
+	pos := &PositionSpec{StartPattern: start}
+	if rest != "" {
+		if rest[0] != ',' {
+			return nil, fmt.Errorf("unexpected %q after start anchor (expected ,/end/)", rest)
+		}
+		end, tail, err := readAnchorPattern(rest[1:])
+		if err != nil {
+			return nil, err
+		}
+		if tail != "" {
+			return nil, fmt.Errorf("unexpected %q after end anchor", tail)
+		}
+		pos.EndPattern = end
+	}
+	for _, p := range []string{pos.StartPattern, pos.EndPattern} {
+		if p == "" {
+			continue
+		}
+		if _, err := regexp.Compile(p); err != nil {
+			return nil, fmt.Errorf("invalid anchor pattern /%s/: %w", p, err)
+		}
+	}
+	return pos, nil
+}
+
+// readAnchorPattern reads one "/pattern/" from the start of s, turning "\/"
+// into "/", and returns the pattern and the remainder of s.
+func readAnchorPattern(s string) (string, string, error) {
+	if s == "" || s[0] != '/' {
+		return "", "", fmt.Errorf("anchor must start with '/': %s", s)
+	}
+	var b []byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '/':
+			b = append(b, '/')
+			i++
+		case s[i] == '/':
+			if len(b) == 0 {
+				return "", "", fmt.Errorf("empty anchor pattern")
+			}
+			return string(b), s[i+1:], nil
+		default:
+			b = append(b, s[i])
+		}
+	}
+	return "", "", fmt.Errorf("unterminated anchor pattern: %s", s)
+}
+
 // parsePositionSpecifier parses a position string (without the leading colon).
 func parsePositionSpecifier(spec string) (*PositionSpec, error) {
+	// Regex anchors: /start/ or /start/,/end/
+	if strings.HasPrefix(spec, "/") {
+		return parseAnchorSpecifier(spec)
+	}
+
 	// Try column-precise range: L5C10:L10C30
 	if m := reColRange.FindStringSubmatch(spec); m != nil {
 		startLine, err := strconv.Atoi(m[1])
@@ -200,7 +325,7 @@ func parsePositionSpecifier(spec string) (*PositionSpec, error) {
 		}, nil
 	}
 
-	return nil, fmt.Errorf("unrecognized position format: %s (expected L<n>, L<n>-L<m>, L<n>-EOF, or L<n>C<c>:L<m>C<d>)", spec)
+	return nil, fmt.Errorf("unrecognized position format: %s (expected L<n>, L<n>-L<m>, L<n>-EOF, L<n>C<c>:L<m>C<d>, or /start/,/end/)", spec)
 }
 
 // validateLineCol validates column-precise position parameters.
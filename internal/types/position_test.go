@@ -857,3 +857,90 @@ func TestParsePathPosition_WindowsDoubleColonDriveLetter(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePathPosition_Anchors(t *testing.T) {
+	tests := []struct {
+		path      string
+		wantFile  string
+		wantStart string
+		wantEnd   string
+	}{
+		{"file.go:/func Foo/,/^}/", "file.go", "func Foo", "^}"},
+		{"src/api.go:/^const Version/", "src/api.go", "^const Version", ""},
+		{"docs/a.md:/see http:\\/\\/x/,/^$/", "docs/a.md", "see http://x", "^$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			file, pos, err := ParsePathPosition(tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if file != tt.wantFile {
+				t.Errorf("file = %q, want %q", file, tt.wantFile)
+			}
+			if pos == nil || !pos.IsAnchored() {
+				t.Fatalf("expected anchored position, got %+v", pos)
+			}
+			if pos.StartPattern != tt.wantStart || pos.EndPattern != tt.wantEnd {
+				t.Errorf("patterns = %q, %q, want %q, %q", pos.StartPattern, pos.EndPattern, tt.wantStart, tt.wantEnd)
+			}
+			if pos.IsSingleLine() != (tt.wantEnd == "") {
+				t.Errorf("IsSingleLine = %v with EndPattern %q", pos.IsSingleLine(), pos.EndPattern)
+			}
+		})
+	}
+
+	// Not anchors: URL schemes and drive letters
+	for _, path := range []string{"https://example.com/file.go", "C:/src/file.go"} {
+		file, pos, err := ParsePathPosition(path)
+		if err != nil || pos != nil || file != path {
+			t.Errorf("ParsePathPosition(%q) = %q, %+v, %v; want unchanged path", path, file, pos, err)
+		}
+	}
+}
+
+func TestParsePathPosition_AnchorErrors(t *testing.T) {
+	for _, path := range []string{
+		"file.go:/func (/",    // invalid regexp
+		"file.go:/func Foo",   // unterminated
+		"file.go:/a/,//",      // empty pattern
+		"file.go:/a/,/b/junk", // trailing text
+		"file.go:/a/;/b/",     // bad separator
+	} {
+		if _, _, err := ParsePathPosition(path); err == nil {
+			t.Errorf("ParsePathPosition(%q) expected error", path)
+		}
+	}
+}
+
+func TestParsePathPosition_Symbol(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantFile   string
+		wantSymbol string
+	}{
+		{"util.go#FuncName", "util.go", "FuncName"},
+		{"pkg/util.go#Type.Method", "pkg/util.go", "Type.Method"},
+		{"notes#1.md", "notes#1.md", ""},
+		{"util.go#not a symbol", "util.go#not a symbol", ""},
+		{"docs/a.md#Section", "docs/a.md#Section", ""},
+	}
+	for _, tt := range tests {
+		file, pos, err := ParsePathPosition(tt.path)
+		if err != nil {
+			t.Fatalf("ParsePathPosition(%q): %v", tt.path, err)
+		}
+		if file != tt.wantFile {
+			t.Errorf("ParsePathPosition(%q) file = %q, want %q", tt.path, file, tt.wantFile)
+		}
+		if tt.wantSymbol == "" {
+			if pos != nil {
+				t.Errorf("ParsePathPosition(%q) pos = %+v, want nil", tt.path, pos)
+			}
+			continue
+		}
+		if pos == nil || pos.Symbol != tt.wantSymbol || !pos.IsAnchored() || pos.IsSingleLine() {
+			t.Errorf("ParsePathPosition(%q) pos = %+v, want symbol %q", tt.path, pos, tt.wantSymbol)
+		}
+	}
+}
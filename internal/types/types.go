@@ -86,40 +86,94 @@ type ComplianceConfig struct {
 
 // VendorConfig represents the root configuration file (vendor.yml) structure.
 type VendorConfig struct {
-	Policy     *VendorPolicy    `yaml:"policy,omitempty" json:"policy,omitempty"`     // Global policy defaults
-	Compliance *ComplianceConfig `yaml:"compliance,omitempty" json:"compliance,omitempty"` // Global compliance enforcement (Spec 075)
-	Vendors    []VendorSpec     `yaml:"vendors"`
+	Policy          *VendorPolicy                `yaml:"policy,omitempty" json:"policy,omitempty"`                     // Global policy defaults
+	Compliance      *ComplianceConfig            `yaml:"compliance,omitempty" json:"compliance,omitempty"`             // Global compliance enforcement (Spec 075)
+	LicenseDir      string                       `yaml:"license_dir,omitempty" json:"license_dir,omitempty"`           // License copy directory relative to project root (default: .git-vendor/licenses)
+	AllowedLicenses []string                     `yaml:"allowed_licenses,omitempty" json:"allowed_licenses,omitempty"` // Replaces the built-in allowed license list (SPDX IDs) when non-empty
+	LicenseFiles    []string                     `yaml:"license_files,omitempty" json:"license_files,omitempty"`       // Replaces the repo-root license/notice filenames copied on sync when non-empty
+	RefAliases      map[string]map[string]string `yaml:"ref_aliases,omitempty" json:"ref_aliases,omitempty"`           // Host branch -> {"ref" or "vendor@ref" -> effective ref} (see ApplyRefAliases)
+	Vendors         []VendorSpec                 `yaml:"vendors"`
 }
 
 // VendorSpec defines a single vendored dependency with source repository URL and path mappings.
 type VendorSpec struct {
-	Name       string        `yaml:"name"`
-	URL        string        `yaml:"url"`
-	Mirrors    []string      `yaml:"mirrors,omitempty"`    // Fallback URLs, tried in declaration order after URL
-	License    string        `yaml:"license"`
-	Groups     []string      `yaml:"groups,omitempty"`     // Optional groups for batch operations
-	Hooks      *HookConfig   `yaml:"hooks,omitempty"`      // Optional pre/post sync hooks
-	Policy     *VendorPolicy `yaml:"policy,omitempty"`     // Per-vendor policy overrides
-	Source      string        `yaml:"source,omitempty"`      // "" (external, default) or "internal"
-	Direction   string        `yaml:"direction,omitempty"`   // "" (source-canonical) or "bidirectional" (Spec 070 sync direction)
-	Enforcement string        `yaml:"compliance,omitempty"`  // "" (inherits global) or "strict"/"lenient"/"info" (Spec 075)
-	Specs       []BranchSpec  `yaml:"specs"`
+	Name            string        `yaml:"name"`
+	URL             string        `yaml:"url"`
+	Mirrors         []string      `yaml:"mirrors,omitempty"` // Fallback URLs, tried in declaration order after URL
+	License         string        `yaml:"license"`
+	LicenseOverride string        `yaml:"license_override,omitempty"` // Declared SPDX license accepted without platform detection
+	Groups          []string      `yaml:"groups,omitempty"`           // Optional groups for batch operations
+	Hooks           *HookConfig   `yaml:"hooks,omitempty"`            // Optional pre/post sync hooks
+	PostSync        string        `yaml:"post_sync,omitempty"`        // Command run in the vendor's destination after sync; needs --allow-hooks
+	SPDXHeaders     bool          `yaml:"spdx_headers,omitempty"`     // Prepend an SPDX-License-Identifier comment to copied text files
+	Policy          *VendorPolicy `yaml:"policy,omitempty"`           // Per-vendor policy overrides
+	Source          string        `yaml:"source,omitempty"`           // "" (external, default) or "internal"
+	Direction       string        `yaml:"direction,omitempty"`        // "" (source-canonical) or "bidirectional" (Spec 070 sync direction)
+	Enforcement     string        `yaml:"compliance,omitempty"`       // "" (inherits global) or "strict"/"lenient"/"info" (Spec 075)
+	// Recursive also vendors the vendors declared by a vendor.yml found at the
+	// root of this vendor's mapped directories (see core.recursiveExpander).
+	Recursive bool         `yaml:"recursive,omitempty"`
+	Specs     []BranchSpec `yaml:"specs"`
+	// URLTemplate is URL as written in vendor.yml when it held ${VAR}
+	// references that loading expanded; saving writes it back (see
+	// core.expandConfigEnv).
+	URLTemplate string `yaml:"-" json:"-"`
 }
 
 // BranchSpec defines mappings for a specific Git ref (branch, tag, or commit).
 type BranchSpec struct {
 	Ref           string        `yaml:"ref"`
 	DefaultTarget string        `yaml:"default_target,omitempty"`
+	Depth         int           `yaml:"depth,omitempty"` // Fetch depth: 0 = shallow with full fallback, N = depth N, -1 = full history
 	Mapping       []PathMapping `yaml:"mapping"`
+	// Pinned marks Ref as a commit frozen by "git-vendor pin": update skips the
+	// vendor unless --include-pinned. PinnedFrom is the ref it replaced, which
+	// "git-vendor unpin" restores.
+	Pinned      bool   `yaml:"pinned,omitempty"`
+	PinnedFrom  string `yaml:"pinned_from,omitempty"`
+	BaseRef     string `yaml:"-" json:"-"` // Non-empty: Ref was replaced by a ref_aliases entry; BaseRef is the vendor.yml ref
+	RefTemplate string `yaml:"-" json:"-"` // Non-empty: Ref as written in vendor.yml, before ${VAR} expansion
 }
 
 // PathMapping defines a source-to-destination path mapping for vendoring.
-// When From is a directory, Exclude patterns (gitignore-style globs) skip
-// matching files during sync. Exclude has no effect on file-level mappings.
+// When From is a directory, Include and Exclude patterns (gitignore-style globs,
+// matched against the path relative to From) filter the files copied during sync.
+// A file is copied only if it matches at least one Include pattern (or Include is
+// empty) and matches no Exclude pattern; Exclude wins when both match.
+// Include and Exclude have no effect on file-level mappings.
+//
+// In vendor.yml, To may be a list ("to: [lib/a.go, lib/b.go]") to copy one From
+// to several destinations. BranchSpec decoding expands such an entry into one
+// PathMapping per destination, so sync, lock hashing, verify and conflict
+// detection treat each destination independently; ToGroup links the expanded
+// mappings so they are written back as a single list entry.
+//
+// Transforms rewrite the contents of every text file the mapping copies, in
+// order; binary files and position mappings are copied unchanged.
+//
+// MaxDepth limits a directory mapping to files at most MaxDepth levels below
+// From: 1 copies only files directly in From, 2 adds one level of
+// subdirectories, and 0 (unset) copies the whole tree.
 type PathMapping struct {
-	From    string   `yaml:"from"`
-	To      string   `yaml:"to"`
-	Exclude []string `yaml:"exclude,omitempty"`
+	From       string      `yaml:"from"`
+	To         string      `yaml:"to"`
+	Include    []string    `yaml:"include,omitempty"`
+	Exclude    []string    `yaml:"exclude,omitempty"`
+	MaxDepth   int         `yaml:"max_depth,omitempty"`
+	Transforms []Transform `yaml:"transforms,omitempty"`
+	ToGroup    int         `yaml:"-" json:"-"` // Non-zero: expanded from a multi-destination "to" list (shared id per list)
+	// FromTemplate and ToTemplate are From and To as written in vendor.yml
+	// when loading expanded ${VAR} references in them.
+	FromTemplate string `yaml:"-" json:"-"`
+	ToTemplate   string `yaml:"-" json:"-"`
+}
+
+// Transform is a regular-expression substitution applied to copied file
+// contents. Pattern uses Go regexp syntax; Replacement may reference groups
+// as $1 or ${name} (regexp.Regexp.ReplaceAllString).
+type Transform struct {
+	Pattern     string `yaml:"pattern"`
+	Replacement string `yaml:"replacement"`
 }
 
 // VendorLock represents the lock file (vendor.lock) storing resolved commit hashes.
@@ -130,6 +184,7 @@ type PathMapping struct {
 //
 // Version compatibility:
 //   - Missing schema_version is treated as "1.0"
+//   - Older versions: upgraded on load by the core lockMigrations chain
 //   - Unknown minor versions: warning, operation proceeds, unknown fields preserved
 //   - Unknown major versions: error, operation aborts to prevent data corruption
 //
@@ -140,7 +195,10 @@ type PathMapping struct {
 // Migrate via "git-vendor migrate".
 type VendorLock struct {
 	SchemaVersion string        `yaml:"schema_version,omitempty"`
-	Vendors       []LockDetails `yaml:"vendors"`
+	// Checksum is "sha256:<hex>" over the canonical YAML of Vendors, written
+	// on every save and verified on load. Empty in locks written before it.
+	Checksum string        `yaml:"checksum,omitempty"`
+	Vendors  []LockDetails `yaml:"vendors"`
 }
 
 // LockDetails contains the locked state for a specific vendor and ref.
@@ -165,6 +223,15 @@ type LockDetails struct {
 	// Multi-remote provenance (schema v1.3)
 	SourceURL string `yaml:"source_url,omitempty"` // Which URL actually served the content (empty = primary URL)
 
+	// License and notice copies (license_files in vendor.yml)
+	LicenseFiles []string `yaml:"license_files,omitempty"` // Every copied license/NOTICE/COPYRIGHT file, primary license first
+
+	// Ref alias provenance (ref_aliases in vendor.yml)
+	RefAlias string `yaml:"ref_alias,omitempty"` // Effective ref fetched in place of Ref (empty = Ref itself)
+
+	// Content transforms (mapping "transforms" in vendor.yml)
+	Transformed bool `yaml:"transformed,omitempty"` // FileHashes hash file contents after the spec's transforms, not upstream's bytes
+
 	// Accepted drift metadata (CLI-003)
 	AcceptedDrift map[string]string `yaml:"accepted_drift,omitempty"` // path -> SHA-256 of accepted local content
 
@@ -178,15 +245,82 @@ type PositionLock struct {
 	From       string `yaml:"from"`        // Source path with position (e.g., "api/constants.go:L4-L6")
 	To         string `yaml:"to"`          // Destination path with optional position
 	SourceHash string `yaml:"source_hash"` // SHA-256 of extracted content
+	// SourceLines is the line range an anchored From (/start/,/end/) resolved
+	// to at sync time, e.g. "L12-L20". Empty for line-number positions.
+	SourceLines string `yaml:"source_lines,omitempty"`
 }
 
-// PathConflict represents a conflict between two vendors mapping to overlapping paths
+// PathConflict represents a conflict between two vendors mapping to overlapping paths.
+// For ConflictNestedPath, Vendor1/Mapping1 own the ancestor directory and Path is
+// the nested destination owned by Vendor2/Mapping2.
 type PathConflict struct {
 	Path     string
 	Vendor1  string
 	Vendor2  string
 	Mapping1 PathMapping
 	Mapping2 PathMapping
+	Reason   string // ConflictSamePath, ConflictAutoNamed or ConflictNestedPath
+}
+
+// Conflict reasons for PathConflict.Reason.
+const (
+	ConflictSamePath   = "same_path"   // Both mappings write the same destination
+	ConflictAutoNamed  = "auto_named"  // Same destination, reached by auto-naming an empty "to"
+	ConflictNestedPath = "nested_path" // One destination lies inside the other's directory
+)
+
+// WritePlanCandidate is one mapping that writes a destination during sync,
+// listed in the order sync performs the writes.
+type WritePlanCandidate struct {
+	Order    int    `json:"order"` // 1-based position in sync write order
+	Vendor   string `json:"vendor"`
+	Ref      string `json:"ref"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Position bool   `json:"position"` // Destination carries a position spec (splice, not overwrite)
+	Winner   bool   `json:"winner"`   // This write determines the final file content
+}
+
+// DestinationPlan explains how sync resolves a destination written by more than one mapping.
+type DestinationPlan struct {
+	Path       string               `json:"path"`
+	Candidates []WritePlanCandidate `json:"candidates"`
+	Reason     string               `json:"reason"`
+}
+
+// Prune plan target kinds.
+const (
+	PruneKindFile        = "file"         // A file on disk
+	PruneKindMapping     = "mapping"      // A mapping entry in vendor.yml
+	PruneKindConfigEntry = "config_entry" // A whole vendor entry in vendor.yml
+	PruneKindLockEntry   = "lock_entry"   // A vendor@ref entry in vendor.lock
+)
+
+// Prune plan reasons.
+const (
+	// PruneReasonOrphanedByConfig marks a config mapping with no locked file
+	// behind it (its source no longer exists upstream).
+	PruneReasonOrphanedByConfig = "orphaned-by-config"
+	// PruneReasonRemovedVendor marks state belonging to a vendor being removed.
+	PruneReasonRemovedVendor = "removed-vendor"
+)
+
+// PruneTarget is one deletion a prune path would perform.
+type PruneTarget struct {
+	Kind   string `json:"kind"`           // PruneKind* constant
+	Path   string `json:"path"`           // File deleted, or the file holding the deleted entry
+	Vendor string `json:"vendor"`         // Vendor the target belongs to
+	Ref    string `json:"ref,omitempty"`  // Ref, for mapping and lock_entry targets
+	From   string `json:"from,omitempty"` // Mapping source, for mapping targets
+	To     string `json:"to,omitempty"`   // Mapping destination, for mapping targets
+	Reason string `json:"reason"`         // PruneReason* constant
+}
+
+// PrunePlan lists the deletions a prune path would perform. With DryRun set,
+// nothing was deleted.
+type PrunePlan struct {
+	DryRun  bool          `json:"dry_run"`
+	Targets []PruneTarget `json:"targets"`
 }
 
 // LockConflict represents a merge conflict detected in a vendor.lock file.
@@ -263,6 +397,7 @@ type IncrementalSyncCache struct {
 	VendorName string         `json:"vendor_name"`
 	Ref        string         `json:"ref"`
 	CommitHash string         `json:"commit_hash"`
+	SpecHash   string         `json:"spec_hash,omitempty"` // Fingerprint of the mapping config the files were produced with
 	Files      []FileChecksum `json:"files"`
 	CachedAt   string         `json:"cached_at"` // RFC3339 timestamp
 }
@@ -301,6 +436,7 @@ type OutdatedResult struct {
 	Outdated     int                 `json:"outdated"`
 	UpToDate     int                 `json:"up_to_date"`
 	Skipped      int                 `json:"skipped"`
+	Filtered     []string            `json:"filtered,omitempty"` // "name@ref" of refs last committed before --since
 }
 
 // CommitInfo represents a single git commit
@@ -367,12 +503,34 @@ type VerifySummary struct {
 	Modified   int    `json:"modified"`
 	Added      int    `json:"added"`
 	Deleted    int    `json:"deleted"`
-	Accepted   int    `json:"accepted"` // Files with accepted drift (CLI-003)
-	Stale      int    `json:"stale"`    // Config mappings not present in lock FileHashes
-	Orphaned   int    `json:"orphaned"` // Lock FileHashes entries not present in config mappings
-	Result     string `json:"result"`   // PASS, FAIL, WARN
+	Truncated  int    `json:"truncated"` // Empty files whose locked content is not empty
+	Accepted   int    `json:"accepted"`  // Files with accepted drift (CLI-003)
+	Stale      int    `json:"stale"`     // Config mappings not present in lock FileHashes
+	Orphaned   int    `json:"orphaned"`  // Lock FileHashes entries not present in config mappings
+	Result     string `json:"result"`    // PASS, FAIL, WARN
+}
+
+// VendorVerifySummary is one row of the per-vendor verification rollup
+// (status/verify --group-by vendor). Rows are computed from FileStatus.Vendor,
+// so the rows of a rollup sum to the VerifySummary they were built from.
+// Files with no known vendor (added files) are grouped under UnattributedVendor.
+type VendorVerifySummary struct {
+	Vendor     string `json:"vendor"`
+	TotalFiles int    `json:"total_files"`
+	Verified   int    `json:"verified"`
+	Modified   int    `json:"modified"`
+	Added      int    `json:"added"`
+	Deleted    int    `json:"deleted"`
+	Truncated  int    `json:"truncated"`
+	Accepted   int    `json:"accepted"`
+	Stale      int    `json:"stale"`
+	Orphaned   int    `json:"orphaned"`
 }
 
+// UnattributedVendor is the VendorVerifySummary.Vendor value for files whose
+// FileStatus.Vendor is nil.
+const UnattributedVendor = "(unattributed)"
+
 // PositionDetail provides position-level metadata for FileStatus entries
 // that originate from position-extracted mappings.
 type PositionDetail struct {
@@ -385,11 +543,12 @@ type PositionDetail struct {
 type FileStatus struct {
 	Path         string          `json:"path"`
 	Vendor       *string         `json:"vendor"`
-	Status       string          `json:"status"`             // verified, modified, added, deleted, accepted, stale, orphaned
+	Status       string          `json:"status"`             // verified, modified, truncated, added, deleted, accepted, stale, orphaned
 	Type         string          `json:"type"`               // "file", "position", or "coherence"
 	ExpectedHash *string         `json:"expected_hash,omitempty"`
 	ActualHash   *string         `json:"actual_hash,omitempty"`
 	Position     *PositionDetail `json:"position,omitempty"` // Present only for type="position"
+	Hint         string          `json:"hint,omitempty"`     // Suggested fix, e.g. for truncated files
 }
 
 // DriftDetail provides per-file hash comparison for drift detection (GRD-001).
@@ -411,15 +570,17 @@ type VendorStatusDetail struct {
 	Enforcement string `json:"enforcement,omitempty"` // Resolved compliance level: "strict", "lenient", or "info" (Spec 075)
 
 	// Offline (verify) results
-	FilesVerified int      `json:"files_verified"`
-	FilesModified int      `json:"files_modified"`
-	FilesAdded    int      `json:"files_added"`
-	FilesDeleted  int      `json:"files_deleted"`
-	FilesAccepted int      `json:"files_accepted"` // Files with accepted drift (CLI-003)
-	ModifiedPaths []string `json:"modified_paths,omitempty"`
-	AddedPaths    []string `json:"added_paths,omitempty"`
-	DeletedPaths  []string `json:"deleted_paths,omitempty"`
-	AcceptedPaths []string `json:"accepted_paths,omitempty"`
+	FilesVerified  int      `json:"files_verified"`
+	FilesModified  int      `json:"files_modified"`
+	FilesAdded     int      `json:"files_added"`
+	FilesDeleted   int      `json:"files_deleted"`
+	FilesTruncated int      `json:"files_truncated,omitempty"` // Empty files whose locked content is not empty
+	FilesAccepted  int      `json:"files_accepted"`            // Files with accepted drift (CLI-003)
+	ModifiedPaths  []string `json:"modified_paths,omitempty"`
+	AddedPaths     []string `json:"added_paths,omitempty"`
+	DeletedPaths   []string `json:"deleted_paths,omitempty"`
+	TruncatedPaths []string `json:"truncated_paths,omitempty"`
+	AcceptedPaths  []string `json:"accepted_paths,omitempty"`
 
 	// Per-file drift details with hash comparison (GRD-001).
 	// Populated for modified and accepted files when offline checks run.
@@ -444,10 +605,13 @@ type VendorStatusDetail struct {
 // StatusResult holds the combined output of the status command (verify + outdated).
 // StatusResult is the top-level return type for Manager.Status / VendorSyncer.Status.
 type StatusResult struct {
-	Vendors          []VendorStatusDetail `json:"vendors"`
-	Summary          StatusSummary        `json:"summary"`
-	PolicyViolations []PolicyViolation    `json:"policy_violations,omitempty"` // All violations across vendors (GRD-002)
-	ComplianceConfig *ComplianceConfig    `json:"compliance_config,omitempty"` // Global compliance config (Spec 075)
+	Vendors          []VendorStatusDetail  `json:"vendors"`
+	Summary          StatusSummary         `json:"summary"`
+	PolicyViolations []PolicyViolation     `json:"policy_violations,omitempty"` // All violations across vendors (GRD-002)
+	ComplianceConfig *ComplianceConfig     `json:"compliance_config,omitempty"` // Global compliance config (Spec 075)
+	ByVendor         []VendorVerifySummary `json:"by_vendor,omitempty"`         // Per-vendor verify rollup (--group-by vendor)
+	Files            []FileStatus          `json:"-"`                           // Offline verify entries, for --format github annotations
+	Fix              *VerifyFixResult      `json:"fix,omitempty"`               // Files restored before checking (--fix)
 }
 
 // StatusSummary contains aggregate statistics across all vendors for the status command.
@@ -458,10 +622,118 @@ type StatusSummary struct {
 	Modified       int    `json:"modified"`
 	Added          int    `json:"added"`
 	Deleted        int    `json:"deleted"`
-	Accepted       int    `json:"accepted"`        // Files with accepted drift (CLI-003)
-	Stale          int    `json:"stale"`            // Vendors behind upstream
-	UpstreamErrors int    `json:"upstream_errors"`  // Vendors where ls-remote failed
-	StaleConfigs   int    `json:"stale_configs"`    // Config mapping dests with no lock FileHashes entry (VFY-001)
-	OrphanedLock   int    `json:"orphaned_lock"`    // Lock FileHashes entries with no config mapping dest (VFY-001)
-	Result         string `json:"result"`           // PASS, FAIL, WARN
+	Truncated      int    `json:"truncated,omitempty"` // Empty files whose locked content is not empty
+	Accepted       int    `json:"accepted"`            // Files with accepted drift (CLI-003)
+	Stale          int    `json:"stale"`               // Vendors behind upstream
+	UpstreamErrors int    `json:"upstream_errors"`     // Vendors where ls-remote failed
+	StaleConfigs   int    `json:"stale_configs"`       // Config mapping dests with no lock FileHashes entry (VFY-001)
+	OrphanedLock   int    `json:"orphaned_lock"`       // Lock FileHashes entries with no config mapping dest (VFY-001)
+	Result         string `json:"result"`              // PASS, FAIL, WARN
+}
+
+// QuickStatusResult is the output of "status --quick": one entry per config
+// vendor@ref, computed from vendor.yml, vendor.lock and a Stat of each
+// destination. No file is hashed and no remote is contacted.
+type QuickStatusResult struct {
+	Vendors []QuickStatusEntry `json:"vendors"`
+	Summary QuickStatusSummary `json:"summary"`
+}
+
+// QuickStatusEntry is the quick status of one vendor@ref.
+type QuickStatusEntry struct {
+	Name         string   `json:"name"`
+	Ref          string   `json:"ref"`
+	CommitHash   string   `json:"commit_hash,omitempty"` // Locked commit, "" when not yet synced
+	State        string   `json:"state"`                 // "in-sync", "missing-files", or "not-synced"
+	Detail       string   `json:"detail,omitempty"`      // Why the entry is not-synced
+	MissingPaths []string `json:"missing_paths,omitempty"`
+}
+
+// QuickStatusSummary counts QuickStatusResult entries by state.
+type QuickStatusSummary struct {
+	Total        int    `json:"total"`
+	InSync       int    `json:"in_sync"`
+	MissingFiles int    `json:"missing_files"`
+	NotSynced    int    `json:"not_synced"`
+	Result       string `json:"result"` // PASS when every entry is in-sync, otherwise FAIL
+}
+
+// LockCheckResult is the output of "lock" without --regenerate: how vendor.lock
+// disagrees with vendor.yml, found without hashing files or contacting a remote.
+type LockCheckResult struct {
+	Issues  []LockCheckIssue `json:"issues"`
+	Summary LockCheckSummary `json:"summary"`
+}
+
+// LockCheckIssue is one lock/config mismatch. Path is empty for entry-level
+// issues (a whole vendor@ref missing from or extra in the lock).
+type LockCheckIssue struct {
+	Vendor  string `json:"vendor"`
+	Ref     string `json:"ref,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Status  string `json:"status"` // "stale" (in config, not in lock) or "orphaned" (in lock, not in config)
+	Message string `json:"message"`
+}
+
+// LockCheckSummary counts LockCheckResult issues by status.
+type LockCheckSummary struct {
+	Stale    int    `json:"stale"`
+	Orphaned int    `json:"orphaned"`
+	Result   string `json:"result"` // PASS when there are no issues, otherwise FAIL
+}
+
+// VerifyFixResult lists the destinations "verify --fix" restored to their
+// locked content, and those it could not restore.
+type VerifyFixResult struct {
+	Restored []string          `json:"restored"`
+	Failed   []VerifyFixFailed `json:"failed,omitempty"`
+}
+
+// VerifyFixFailed is a broken destination that could not be restored.
+type VerifyFixFailed struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// VendorTreeNode is one directory or destination in the "tree" view of where
+// config mappings write. Owners lists the mappings whose destination is
+// exactly this node; Conflict marks nodes where two vendors write the same
+// path or one vendor writes inside another's destination.
+type VendorTreeNode struct {
+	Name     string            `json:"name"`
+	Path     string            `json:"path"`
+	Owners   []VendorTreeOwner `json:"owners,omitempty"`
+	Conflict bool              `json:"conflict,omitempty"`
+	Children []*VendorTreeNode `json:"children,omitempty"`
+}
+
+// VendorTreeOwner is a mapping that writes a VendorTreeNode.
+type VendorTreeOwner struct {
+	Vendor string `json:"vendor"`
+	Ref    string `json:"ref"`
+	From   string `json:"from"`
+}
+
+// WhyResult is the output of "why <path>": every vendor mapping or lock entry
+// that produces the destination Path.
+type WhyResult struct {
+	Path    string     `json:"path"`
+	Matches []WhyMatch `json:"matches"`
+}
+
+// WhyMatch is one vendor@ref producing a WhyResult path. From and To are the
+// mapping; SourcePath is the file's path upstream (From plus the part of Path
+// below a directory To). CommitHash and FileHash come from the lock and are
+// empty before the first sync. Orphaned matches are recorded in the lock but
+// produced by no config mapping, so From, To, and SourcePath are empty.
+type WhyMatch struct {
+	Vendor     string `json:"vendor"`
+	URL        string `json:"url,omitempty"`
+	Ref        string `json:"ref"`
+	From       string `json:"from,omitempty"`
+	To         string `json:"to,omitempty"`
+	SourcePath string `json:"source_path,omitempty"`
+	CommitHash string `json:"commit_hash,omitempty"`
+	FileHash   string `json:"file_hash,omitempty"`
+	Orphaned   bool   `json:"orphaned,omitempty"`
 }
@@ -246,6 +246,83 @@ func TestPathMapping_YAML_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestBranchSpec_YAML_MultiDestination(t *testing.T) {
+	input := `
+ref: main
+mapping:
+  - from: src/shared.go
+    to: [lib/a.go, lib/b.go]
+  - from: README.md
+    to: docs/README.md
+  - from: src/util.go
+    to:
+      - one/util.go
+`
+	var spec BranchSpec
+	if err := yaml.Unmarshal([]byte(input), &spec); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	want := []PathMapping{
+		{From: "src/shared.go", To: "lib/a.go", ToGroup: 1},
+		{From: "src/shared.go", To: "lib/b.go", ToGroup: 1},
+		{From: "README.md", To: "docs/README.md"},
+		{From: "src/util.go", To: "one/util.go", ToGroup: 2},
+	}
+	if len(spec.Mapping) != len(want) {
+		t.Fatalf("got %d mappings, want %d: %+v", len(spec.Mapping), len(want), spec.Mapping)
+	}
+	for i := range want {
+		got := spec.Mapping[i]
+		if got.From != want[i].From || got.To != want[i].To || got.ToGroup != want[i].ToGroup {
+			t.Errorf("Mapping[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	// Marshal collapses each group back into a single list entry
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var raw struct {
+		Mapping []map[string]interface{} `yaml:"mapping"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("re-parse failed: %v", err)
+	}
+	if len(raw.Mapping) != 3 {
+		t.Fatalf("marshaled %d mapping entries, want 3:\n%s", len(raw.Mapping), data)
+	}
+	if list, ok := raw.Mapping[0]["to"].([]interface{}); !ok || len(list) != 2 {
+		t.Errorf("first entry to = %#v, want 2-item list", raw.Mapping[0]["to"])
+	}
+	if _, ok := raw.Mapping[1]["to"].(string); !ok {
+		t.Errorf("scalar to should stay scalar, got %#v", raw.Mapping[1]["to"])
+	}
+	if list, ok := raw.Mapping[2]["to"].([]interface{}); !ok || len(list) != 1 {
+		t.Errorf("single-item list should round-trip as a list, got %#v", raw.Mapping[2]["to"])
+	}
+}
+
+func TestBranchSpec_YAML_MultiDestinationErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty list", input: "ref: main\nmapping:\n  - from: a.go\n    to: []\n"},
+		{name: "map value", input: "ref: main\nmapping:\n  - from: a.go\n    to: {path: b.go}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec BranchSpec
+			if err := yaml.Unmarshal([]byte(tt.input), &spec); err == nil {
+				t.Errorf("expected error for %s, got mappings %+v", tt.name, spec.Mapping)
+			}
+		})
+	}
+}
+
 // ============================================================================
 // HookConfig Tests
 // ============================================================================
@@ -5,9 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/EmundoT/git-vendor/cmd"
 	"github.com/EmundoT/git-vendor/internal/core"
@@ -114,6 +120,41 @@ func parseCommonFlags(args []string) (core.NonInteractiveFlags, []string) {
 	return flags, remaining
 }
 
+// globalFlags are the flags every command accepts, in any position.
+type globalFlags struct {
+	ConfigDir string // --config <dir>: vendor directory instead of core.VendorDir
+	NoColor   bool   // --no-color: plain output without ANSI styling
+}
+
+// extractGlobalFlags removes the global flags (--config <dir> or
+// --config=<dir>, and --no-color) from args. It runs before dispatch so every
+// command sees the same vendor root and styling.
+func extractGlobalFlags(args []string) (globalFlags, []string, error) {
+	var global globalFlags
+	var remaining []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--config":
+			if i+1 >= len(args) {
+				return global, nil, fmt.Errorf("--config requires a directory")
+			}
+			i++
+			global.ConfigDir = args[i]
+		case strings.HasPrefix(arg, "--config="):
+			global.ConfigDir = strings.TrimPrefix(arg, "--config=")
+			if global.ConfigDir == "" {
+				return global, nil, fmt.Errorf("--config requires a directory")
+			}
+		case arg == "--no-color":
+			global.NoColor = true
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	return global, remaining, nil
+}
+
 // printStatusHuman renders a StatusResult in the human-readable format specified
 // by CLI-REDESIGN.md. Groups output by vendor, showing verify + outdated info.
 func printStatusHuman(result *types.StatusResult) {
@@ -123,10 +164,7 @@ func printStatusHuman(result *types.StatusResult) {
 	}
 
 	for _, v := range result.Vendors {
-		shortHash := v.CommitHash
-		if len(shortHash) > 7 {
-			shortHash = shortHash[:7]
-		}
+		shortHash := shortCommit(v.CommitHash)
 		enfLabel := ""
 		if v.Enforcement != "" {
 			enfLabel = fmt.Sprintf(" (%s)", v.Enforcement)
@@ -134,7 +172,7 @@ func printStatusHuman(result *types.StatusResult) {
 		fmt.Printf("  %s (%s @ %s)%s\n", v.Name, v.Ref, shortHash, enfLabel)
 
 		// Offline results
-		totalChecked := v.FilesVerified + v.FilesModified + v.FilesDeleted
+		totalChecked := v.FilesVerified + v.FilesModified + v.FilesDeleted + v.FilesTruncated
 		if totalChecked > 0 {
 			fmt.Printf("    %s verified\n", core.Pluralize(v.FilesVerified, "file", "files"))
 		}
@@ -144,6 +182,9 @@ func printStatusHuman(result *types.StatusResult) {
 		for _, p := range v.DeletedPaths {
 			fmt.Printf("    1 file deleted locally: %s\n", p)
 		}
+		for _, p := range v.TruncatedPaths {
+			fmt.Printf("    1 file truncated (empty, locked content is not): %s — re-sync with 'git-vendor pull --locked'\n", p)
+		}
 		if v.FilesAdded > 0 {
 			fmt.Printf("    %s added locally\n", core.Pluralize(v.FilesAdded, "file", "files"))
 		}
@@ -152,23 +193,368 @@ func printStatusHuman(result *types.StatusResult) {
 		}
 
 		// Remote results
-		if v.UpstreamStale != nil {
-			if *v.UpstreamStale {
-				fmt.Println("    upstream has new commits available")
-			} else {
-				fmt.Println("    up to date")
-			}
-		} else if v.UpstreamSkipped {
-			fmt.Println("    upstream check skipped (network error)")
+		if line := formatUpstreamLine(v); line != "" {
+			fmt.Printf("    %s\n", line)
 		}
 
 		fmt.Println()
 	}
 
+	// Per-vendor rollup — only present with --group-by vendor
+	if len(result.ByVendor) > 0 {
+		fmt.Println("By vendor:")
+		for _, row := range result.ByVendor {
+			fmt.Println(formatVendorRollup(row))
+		}
+		fmt.Println()
+	}
+
+	// Offline totals (verify counts) — omitted for --remote-only runs
+	if line := formatOfflineSummary(result.Summary); line != "" {
+		fmt.Println(line)
+	}
 	fmt.Printf("Result: %s\n", result.Summary.Result)
 }
 
+// printQuickStatus prints one line per vendor@ref for status --quick, listing
+// missing destinations beneath the vendors that have them.
+func printQuickStatus(result *types.QuickStatusResult) {
+	for _, v := range result.Vendors {
+		label := v.State
+		switch v.State {
+		case core.QuickStatusMissingFiles:
+			label = fmt.Sprintf("%s (%d)", v.State, len(v.MissingPaths))
+		case core.QuickStatusNotSynced:
+			if v.Detail != "" {
+				label = fmt.Sprintf("%s: %s", v.State, v.Detail)
+			}
+		}
+		commit := shortCommit(v.CommitHash)
+		if commit == "" {
+			commit = "-"
+		}
+		fmt.Printf("  %-30s %-20s %-8s %s\n", v.Name, v.Ref, commit, label)
+		for _, p := range v.MissingPaths {
+			fmt.Printf("      missing: %s\n", p)
+		}
+	}
+	fmt.Printf("\n%s: %d in sync, %d missing files, %d not synced\n",
+		core.Pluralize(result.Summary.Total, "ref", "refs"),
+		result.Summary.InSync, result.Summary.MissingFiles, result.Summary.NotSynced)
+	fmt.Printf("Result: %s\n", result.Summary.Result)
+}
+
+// printLockCheck prints one line per lock/config mismatch found by "lock";
+// lockPath and configPath name the files in the summary.
+func printLockCheck(result *types.LockCheckResult, lockPath, configPath string) {
+	for _, issue := range result.Issues {
+		target := issue.Vendor
+		if issue.Ref != "" {
+			target += "@" + issue.Ref
+		}
+		if issue.Path != "" {
+			target += " " + issue.Path
+		}
+		fmt.Printf("  %-9s %s: %s\n", issue.Status, target, issue.Message)
+	}
+	if len(result.Issues) == 0 {
+		fmt.Printf("%s matches %s\n", lockPath, configPath)
+	} else {
+		fmt.Printf("\n%d stale, %d orphaned (run 'git-vendor lock --regenerate' to rebuild the lock)\n",
+			result.Summary.Stale, result.Summary.Orphaned)
+	}
+	fmt.Printf("Result: %s\n", result.Summary.Result)
+}
+
+// printWhy prints each vendor@ref that produces a "why" path.
+func printWhy(result *types.WhyResult) {
+	fmt.Println(result.Path)
+	for _, m := range result.Matches {
+		vendor := m.Vendor
+		if m.URL != "" {
+			vendor += " (" + m.URL + ")"
+		}
+		ref := m.Ref
+		if m.CommitHash != "" {
+			ref += " @ " + shortCommit(m.CommitHash)
+		} else {
+			ref += " (not synced)"
+		}
+		fmt.Printf("  vendor:  %s\n", vendor)
+		fmt.Printf("  ref:     %s\n", ref)
+		if m.Orphaned {
+			fmt.Println("  note:    recorded in the lock, but no mapping produces it (see 'git-vendor clean')")
+		} else {
+			fmt.Printf("  mapping: %s -> %s\n", m.From, m.To)
+			fmt.Printf("  source:  %s\n", m.SourcePath)
+		}
+		if len(result.Matches) > 1 {
+			fmt.Println()
+		}
+	}
+}
+
+// printVendorTree draws the "tree" layout with box-drawing connectors. Owned
+// nodes are annotated with their vendor@ref; conflicting nodes are flagged.
+func printVendorTree(root *types.VendorTreeNode) {
+	if len(root.Children) == 0 {
+		fmt.Println("No mappings configured")
+		return
+	}
+	fmt.Println(root.Name)
+	printVendorTreeChildren(root, "")
+}
+
+func printVendorTreeChildren(n *types.VendorTreeNode, indent string) {
+	for i, child := range n.Children {
+		connector, nextIndent := "├── ", indent+"│   "
+		if i == len(n.Children)-1 {
+			connector, nextIndent = "└── ", indent+"    "
+		}
+		line := indent + connector + child.Name
+		if len(child.Owners) > 0 {
+			owners := make([]string, 0, len(child.Owners))
+			for _, o := range child.Owners {
+				owner := o.Vendor + "@" + o.Ref
+				if !slices.Contains(owners, owner) {
+					owners = append(owners, owner)
+				}
+			}
+			line += "  (" + strings.Join(owners, ", ") + ")"
+		}
+		if child.Conflict {
+			line += "  " + tui.StyleWarning("⚠ conflict")
+		}
+		fmt.Println(line)
+		printVendorTreeChildren(child, nextIndent)
+	}
+}
+
+// vendorSpecJSON renders a vendor's config entry for --json output, in the
+// shape list uses for each vendor (minus lock metadata). Fields loaded from
+// ${VAR} references are shown as written and URL credentials are redacted.
+func vendorSpecJSON(v types.VendorSpec) map[string]interface{} {
+	v = core.RestoreVendorEnv(v)
+	specsData := make([]map[string]interface{}, 0, len(v.Specs))
+	for _, s := range v.Specs {
+		mappingsData := make([]map[string]interface{}, 0, len(s.Mapping))
+		for _, m := range s.Mapping {
+			mappingsData = append(mappingsData, map[string]interface{}{
+				"from": m.From,
+				"to":   m.To,
+			})
+		}
+		specData := map[string]interface{}{
+			"ref":      s.Ref,
+			"mappings": mappingsData,
+		}
+		if s.DefaultTarget != "" {
+			specData["default_target"] = s.DefaultTarget
+		}
+		specsData = append(specsData, specData)
+	}
+	data := map[string]interface{}{
+		"name":    v.Name,
+		"url":     core.SanitizeURL(v.URL),
+		"license": v.License,
+		"specs":   specsData,
+	}
+	if v.LicenseOverride != "" {
+		data["license_override"] = v.LicenseOverride
+	}
+	return data
+}
+
+// conflictJSON renders one path conflict for --json output (validate, add).
+func conflictJSON(c types.PathConflict) map[string]interface{} {
+	return map[string]interface{}{
+		"path":    c.Path,
+		"reason":  c.Reason,
+		"vendor1": c.Vendor1,
+		"vendor2": c.Vendor2,
+		"mapping1": map[string]interface{}{
+			"from": c.Mapping1.From,
+			"to":   c.Mapping1.To,
+		},
+		"mapping2": map[string]interface{}{
+			"from": c.Mapping2.From,
+			"to":   c.Mapping2.To,
+		},
+	}
+}
+
+// formatUpstreamLine renders the remote (outdated) result for one vendor.
+// A vendor behind upstream shows its locked and remote short hashes. Returns ""
+// when no remote check ran, e.g. for status --offline.
+func formatUpstreamLine(v types.VendorStatusDetail) string {
+	switch {
+	case v.UpstreamStale != nil && *v.UpstreamStale:
+		return fmt.Sprintf("behind upstream: locked %s, remote %s", shortCommit(v.CommitHash), shortCommit(v.UpstreamHash))
+	case v.UpstreamStale != nil:
+		return "up to date"
+	case v.UpstreamSkipped:
+		return "upstream check skipped (network error)"
+	}
+	return ""
+}
+
+// shortCommit abbreviates a commit hash to 7 characters for display.
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// formatOfflineSummary renders the aggregate offline verification counts as a
+// single line, with a truncated count appended only when there are any.
+// Returns "" when no offline checks ran (no files and no coherence issues),
+// e.g. for status --remote-only.
+func formatOfflineSummary(s types.StatusSummary) string {
+	if s.TotalFiles == 0 && s.StaleConfigs == 0 && s.OrphanedLock == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("Summary: %d verified, %d modified, %d deleted, %d added, %d stale, %d orphaned",
+		s.Verified, s.Modified, s.Deleted, s.Added, s.StaleConfigs, s.OrphanedLock)
+	if s.Truncated > 0 {
+		line += fmt.Sprintf(", %d truncated", s.Truncated)
+	}
+	return line
+}
+
+// formatVendorRollup renders one --group-by vendor row with the same counts,
+// in the same order, as formatOfflineSummary.
+func formatVendorRollup(row types.VendorVerifySummary) string {
+	line := fmt.Sprintf("  %-30s %d verified, %d modified, %d deleted, %d added, %d stale, %d orphaned",
+		row.Vendor, row.Verified, row.Modified, row.Deleted, row.Added, row.Stale, row.Orphaned)
+	if row.Truncated > 0 {
+		line += fmt.Sprintf(", %d truncated", row.Truncated)
+	}
+	return line
+}
+
+// printExplainPlan renders pull --explain-plan output: one block per contested
+// destination with candidates in sync write order and the winner marked "*".
+func printExplainPlan(plans []types.DestinationPlan) {
+	if len(plans) == 0 {
+		fmt.Println("No destinations are written by more than one mapping.")
+		return
+	}
+	for _, p := range plans {
+		fmt.Printf("%s (%s)\n", p.Path, core.Pluralize(len(p.Candidates), "candidate", "candidates"))
+		for _, c := range p.Candidates {
+			marker := " "
+			if c.Winner {
+				marker = "*"
+			}
+			fmt.Printf("  %s %d. %s@%s %s -> %s\n", marker, c.Order, c.Vendor, c.Ref, c.From, c.To)
+		}
+		fmt.Printf("    reason: %s\n", p.Reason)
+	}
+}
+
+// printPrunePlan renders a --dry-run deletion plan: the JSON envelope in JSON
+// mode, otherwise one line per target with its reason.
+func printPrunePlan(plan *types.PrunePlan, mode core.OutputMode) {
+	if mode == core.OutputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(core.JSONOutput{
+			Status: "success",
+			Data:   map[string]interface{}{"plan": plan},
+		})
+		return
+	}
+	if mode == core.OutputQuiet {
+		return
+	}
+	if len(plan.Targets) == 0 {
+		fmt.Println("Dry run: nothing would be deleted.")
+		return
+	}
+	fmt.Printf("Dry run: would delete %s (nothing deleted):\n", core.Pluralize(len(plan.Targets), "target", "targets"))
+	for _, t := range plan.Targets {
+		fmt.Println("  " + formatPruneTarget(t))
+	}
+}
+
+// printUpdatePlan renders an update --dry-run preview: the JSON envelope in
+// JSON mode, otherwise one line per vendor@ref whose lock would move.
+func printUpdatePlan(plan *core.UpdatePlan, mode core.OutputMode) {
+	if mode == core.OutputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(core.JSONOutput{
+			Status: "success",
+			Data:   map[string]interface{}{"plan": plan, "would_update": len(plan.Updates())},
+		})
+		return
+	}
+	if mode == core.OutputQuiet {
+		return
+	}
+	for _, e := range plan.Entries {
+		if e.Error != "" {
+			fmt.Printf("⚠ %s@%s: could not resolve upstream commit: %s\n", e.Vendor, e.Ref, e.Error)
+		}
+	}
+	updates := plan.Updates()
+	if len(updates) == 0 {
+		fmt.Println("Dry run: no vendor would update (lock not written).")
+		return
+	}
+	fmt.Printf("Dry run: %s would update (lock not written):\n", core.Pluralize(len(updates), "vendor ref", "vendor refs"))
+	for _, e := range updates {
+		from := "(not locked)"
+		if e.CurrentHash != "" {
+			from = shortCommit(e.CurrentHash)
+		}
+		fmt.Printf("  %s@%s %s → %s\n", e.Vendor, e.Ref, from, shortCommit(e.LatestHash))
+	}
+}
+
+// formatPruneTarget renders one prune plan target as "<kind> <what> [reason]".
+func formatPruneTarget(t types.PruneTarget) string {
+	var what string
+	switch t.Kind {
+	case types.PruneKindMapping:
+		what = fmt.Sprintf("%s@%s %s -> %s in %s", t.Vendor, t.Ref, t.From, t.To, t.Path)
+	case types.PruneKindLockEntry:
+		what = fmt.Sprintf("%s@%s in %s", t.Vendor, t.Ref, t.Path)
+	case types.PruneKindConfigEntry:
+		what = fmt.Sprintf("%s in %s", t.Vendor, t.Path)
+	default:
+		what = t.Path
+	}
+	return fmt.Sprintf("%-12s %s [%s]", t.Kind, what, t.Reason)
+}
+
+// excludeVendorValue returns the vendor name or glob of the --exclude-vendor
+// flag at args[i]: its "=" value, or else the next argument. A missing or
+// empty value exits with ExitInvalidArguments. Reports whether the value was
+// the next argument, which the caller then skips.
+func excludeVendorValue(args []string, i int, callback core.UICallback) (string, bool) {
+	if value, ok := strings.CutPrefix(args[i], "--exclude-vendor="); ok {
+		if value != "" {
+			return value, false
+		}
+	} else if i+1 < len(args) && args[i+1] != "" && !strings.HasPrefix(args[i+1], "-") {
+		return args[i+1], true
+	}
+	callback.ShowError("Invalid Flags", "--exclude-vendor requires a vendor name or glob")
+	os.Exit(core.ExitInvalidArguments)
+	return "", false
+}
+
 func main() {
+	global, args, err := extractGlobalFlags(os.Args[1:])
+	tui.ConfigureColor(global.NoColor)
+	if err != nil {
+		tui.PrintError("Usage", err.Error())
+		os.Exit(1)
+	}
+	os.Args = append(os.Args[:1], args...)
+
 	if len(os.Args) < 2 {
 		tui.PrintHelp()
 		os.Exit(0)
@@ -200,14 +586,41 @@ func main() {
 	// documentation but will no longer be reached once rewritten.
 	command = rewriteDeprecatedCommand(command)
 
-	manager := core.NewManager()
+	vendorDir := core.VendorDir
+	if global.ConfigDir != "" {
+		vendorDir = filepath.Clean(global.ConfigDir)
+	}
+	manager := core.NewManagerAt(vendorDir)
 	manager.SetUICallback(tui.NewTUICallback()) // Set TUI for user interaction
 
 	switch command {
 	case "init":
-		flags, _ := parseCommonFlags(os.Args[2:])
+		flags, args := parseCommonFlags(os.Args[2:])
 
-		if err := manager.Init(); err != nil {
+		format := ""
+		gitignore := false
+		readme := false
+		for i := 0; i < len(args); i++ {
+			switch {
+			case args[i] == "--format" && i+1 < len(args):
+				i++
+				format = args[i]
+			case strings.HasPrefix(args[i], "--format="):
+				format = strings.TrimPrefix(args[i], "--format=")
+			case args[i] == "--gitignore":
+				gitignore = true
+			case args[i] == "--readme":
+				readme = true
+			}
+		}
+		if format != "" {
+			if err := core.ValidateConfigFormat(format); err != nil {
+				tui.PrintError("Invalid Flags", err.Error())
+				os.Exit(1)
+			}
+		}
+
+		if err := manager.InitWithOptions(core.InitOptions{Format: format, Gitignore: gitignore, Readme: readme}); err != nil {
 			if flags.Mode == core.OutputJSON {
 				enc := json.NewEncoder(os.Stdout)
 				enc.SetIndent("", "  ")
@@ -232,9 +645,10 @@ func main() {
 		switch flags.Mode {
 		case core.OutputJSON:
 			data := map[string]interface{}{
-				"vendor_dir": core.VendorDir,
-				"has_hooks":  hasHooks == nil,
-				"has_policy": hasPolicy == nil,
+				"vendor_dir":  manager.RootDir,
+				"config_file": manager.ConfigPath(),
+				"has_hooks":   hasHooks == nil,
+				"has_policy":  hasPolicy == nil,
 			}
 			if originURL != "" {
 				data["origin_url"] = originURL
@@ -243,14 +657,14 @@ func main() {
 			enc.SetIndent("", "  ")
 			_ = enc.Encode(core.JSONOutput{
 				Status:  "success",
-				Message: "Initialized in ./" + core.VendorDir + "/",
+				Message: "Initialized in ./" + manager.RootDir + "/",
 				Data:    data,
 			})
 		case core.OutputQuiet:
 			// No output
 		default:
 			tui.PrintInitSummary(tui.InitSummary{
-				VendorDir: core.VendorDir,
+				VendorDir: manager.RootDir,
 				OriginURL: originURL,
 				HasHooks:  hasHooks == nil,
 				HasPolicy: hasPolicy == nil,
@@ -258,7 +672,147 @@ func main() {
 		}
 
 	case "add":
-		if !core.IsVendorInitialized() {
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		// --url/--ref/--from/--to describe the vendor on the command line
+		// instead of through the wizard. With --dir-per-file, --from may repeat
+		// and --to is the spec's default_target: each file lands at
+		// <to>/<basename> (default <name>/<basename>).
+		var addURL, addRef, addTo, addName, addLicense string
+		var addFroms []string
+		dirPerFile := false
+		addLocal := false
+		addFlags := map[string]*string{
+			"--url": &addURL, "--ref": &addRef,
+			"--to": &addTo, "--name": &addName, "--license": &addLicense,
+		}
+		nonInteractive := flags.Yes || flags.Mode != core.OutputNormal
+		for i := 0; i < len(args); i++ {
+			if args[i] == "--dir-per-file" {
+				dirPerFile = true
+				nonInteractive = true
+				continue
+			}
+			if args[i] == "--local" {
+				addLocal = true
+				continue
+			}
+			key, value, hasValue := strings.Cut(args[i], "=")
+			dst, ok := addFlags[key]
+			if !ok && key != "--from" {
+				continue
+			}
+			if !hasValue && i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			if key == "--from" {
+				addFroms = append(addFroms, value)
+			} else {
+				*dst = value
+			}
+			nonInteractive = true
+		}
+
+		if nonInteractive {
+			callback := tui.NewNonInteractiveTUICallback(flags)
+			var missing []string
+			for _, f := range []struct{ flag, value string }{
+				{"--url", addURL}, {"--ref", addRef}, {"--from", strings.Join(addFroms, "")}, {"--to", addTo},
+			} {
+				if f.value == "" && !(dirPerFile && f.flag == "--to") {
+					missing = append(missing, f.flag)
+				}
+			}
+			if len(missing) > 0 {
+				callback.ShowError("Usage", fmt.Sprintf("git-vendor add --url <url> --ref <ref> --from <path> --to <path> [--name <name>] [--license <spdx>] [--dir-per-file] [--local] --yes (missing %s)", strings.Join(missing, ", ")))
+				os.Exit(core.ExitInvalidArguments)
+			}
+			if len(addFroms) > 1 && !dirPerFile {
+				callback.ShowError("Usage", "several --from paths need --dir-per-file (--to is then their directory)")
+				os.Exit(core.ExitInvalidArguments)
+			}
+			if !manager.IsInitialized() {
+				callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
+				os.Exit(1)
+			}
+			if addName == "" {
+				addName = strings.TrimSuffix(path.Base(addURL), ".git")
+			}
+
+			// JSON mode prints one document at the end, so the license check
+			// reports nothing of its own
+			if flags.Mode == core.OutputJSON {
+				manager.SetUICallback(tui.NewNonInteractiveTUICallback(core.NonInteractiveFlags{Yes: flags.Yes, Mode: core.OutputQuiet}))
+			} else {
+				manager.SetUICallback(callback)
+			}
+
+			branch := types.BranchSpec{Ref: addRef}
+			if dirPerFile {
+				// Empty To auto-names each file under DefaultTarget
+				branch.DefaultTarget = addTo
+				if branch.DefaultTarget == "" {
+					branch.DefaultTarget = addName
+				}
+				for _, from := range addFroms {
+					branch.Mapping = append(branch.Mapping, types.PathMapping{From: from})
+				}
+			} else {
+				branch.Mapping = []types.PathMapping{{From: addFroms[0], To: addTo}}
+			}
+			spec := &types.VendorSpec{
+				Name:            addName,
+				URL:             addURL,
+				LicenseOverride: addLicense,
+				Specs:           []types.BranchSpec{branch},
+			}
+			if err := manager.AddVendorWithOptions(spec, core.AddOptions{Local: addLocal}); err != nil {
+				callback.ShowError("Failed", err.Error())
+				os.Exit(1)
+			}
+
+			// Report the vendor as saved (a second ref merges into an existing vendor)
+			if cfg, err := manager.GetConfig(); err == nil {
+				for _, v := range cfg.Vendors {
+					if v.Name == spec.Name {
+						spec = &v
+						break
+					}
+				}
+			}
+			var conflicts []types.PathConflict
+			all, _ := manager.DetectConflicts() //nolint:errcheck // best-effort, like list
+			for _, c := range all {
+				if c.Vendor1 == spec.Name || c.Vendor2 == spec.Name {
+					conflicts = append(conflicts, c)
+				}
+			}
+
+			switch flags.Mode {
+			case core.OutputJSON:
+				conflictsData := make([]map[string]interface{}, 0, len(conflicts))
+				for _, c := range conflicts {
+					conflictsData = append(conflictsData, conflictJSON(c))
+				}
+				_ = callback.FormatJSON(core.JSONOutput{
+					Status:  "success",
+					Message: "Added " + spec.Name,
+					Data: map[string]interface{}{
+						"vendor":         vendorSpecJSON(*spec),
+						"license":        spec.License,
+						"conflicts":      conflictsData,
+						"conflict_count": len(conflicts),
+					},
+				})
+			case core.OutputNormal:
+				callback.ShowSuccess("Added " + spec.Name)
+				tui.ShowConflictWarnings(manager, spec.Name)
+			}
+			return
+		}
+
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -275,10 +829,10 @@ func main() {
 
 		spec := tui.RunAddWizard(manager, existing)
 		if spec == nil {
-			return
+			os.Exit(core.ExitCancelled)
 		}
 
-		if err := manager.AddVendor(spec); err != nil {
+		if err := manager.AddVendorWithOptions(spec, core.AddOptions{Local: addLocal}); err != nil {
 			tui.PrintError("Failed", err.Error())
 			os.Exit(1)
 		}
@@ -294,7 +848,7 @@ func main() {
 		fmt.Println("  git-vendor pull           # Fetch latest commits")
 
 	case "edit":
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -326,28 +880,36 @@ func main() {
 
 		updatedSpec := tui.RunEditVendorWizard(manager, &targetVendor)
 		if updatedSpec == nil {
-			return
+			os.Exit(core.ExitCancelled)
 		}
 
 		if err := manager.SaveVendor(updatedSpec); err != nil {
 			tui.PrintError("Error", err.Error())
-		} else {
-			tui.PrintSuccess("Saved " + updatedSpec.Name)
-			// Show conflict warnings after editing vendor (conflicts already shown in wizard, but show again for clarity)
+			os.Exit(1)
 		}
+		tui.PrintSuccess("Saved " + updatedSpec.Name)
 
 	case "remove":
 		// Parse common flags
 		flags, args := parseCommonFlags(os.Args[2:])
 
-		// Get vendor name from remaining args
-		if len(args) < 1 {
-			tui.PrintError("Usage", "git-vendor remove <name>")
+		// Get vendor name and remove-specific flags from remaining args
+		name := ""
+		dryRun := false
+		for _, arg := range args {
+			switch {
+			case arg == "--dry-run":
+				dryRun = true
+			case !strings.HasPrefix(arg, "--") && name == "":
+				name = arg
+			}
+		}
+		if name == "" {
+			tui.PrintError("Usage", "git-vendor remove <name> [--dry-run]")
 			os.Exit(1)
 		}
-		name := args[0]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -381,6 +943,17 @@ func main() {
 			os.Exit(1)
 		}
 
+		// --dry-run lists what would be deleted and exits without confirming or deleting
+		if dryRun {
+			plan, err := manager.PlanRemoveVendor(name)
+			if err != nil {
+				callback.ShowError("Error", err.Error())
+				os.Exit(1)
+			}
+			printPrunePlan(plan, flags.Mode)
+			os.Exit(0)
+		}
+
 		// Show confirmation via callback
 		confirmed := callback.AskConfirmation(
 			fmt.Sprintf("Remove vendor '%s'?", name),
@@ -391,7 +964,7 @@ func main() {
 			if flags.Mode != core.OutputQuiet {
 				fmt.Println("Cancelled.")
 			}
-			os.Exit(1)
+			os.Exit(core.ExitCancelled)
 		}
 
 		if err := manager.RemoveVendor(name); err != nil {
@@ -400,9 +973,170 @@ func main() {
 		}
 		callback.ShowSuccess("Removed " + name)
 
+	case "clean":
+		// Parse common flags
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		dryRun := false
+		for _, arg := range args {
+			if arg == "--dry-run" {
+				dryRun = true
+			}
+		}
+
+		if !manager.IsInitialized() {
+			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		// Create appropriate callback
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		manager.SetUICallback(callback)
+
+		plan, err := manager.PlanClean()
+		if err != nil {
+			callback.ShowError("Clean Failed", err.Error())
+			os.Exit(1)
+		}
+
+		if dryRun {
+			printPrunePlan(plan, flags.Mode)
+			os.Exit(0)
+		}
+
+		if len(plan.Targets) > 0 {
+			paths := make([]string, len(plan.Targets))
+			for i, t := range plan.Targets {
+				paths[i] = "  " + t.Path
+			}
+			confirmed := callback.AskConfirmation(
+				fmt.Sprintf("Delete %s?", core.Pluralize(len(plan.Targets), "orphaned file", "orphaned files")),
+				"No mapping in vendor.yml produces these files:\n"+strings.Join(paths, "\n"),
+			)
+			if !confirmed {
+				if flags.Mode != core.OutputQuiet {
+					fmt.Println("Cancelled.")
+				}
+				os.Exit(core.ExitCancelled)
+			}
+		}
+
+		removed, err := manager.Clean(plan)
+		if err != nil {
+			callback.ShowError("Clean Failed", err.Error())
+			os.Exit(1)
+		}
+
+		if flags.Mode == core.OutputJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(core.JSONOutput{
+				Status: "success",
+				Data:   map[string]interface{}{"plan": plan, "removed": removed},
+			})
+		} else if removed == 0 {
+			callback.ShowSuccess("No orphaned files to clean")
+		} else {
+			callback.ShowSuccess("Removed " + core.Pluralize(removed, "orphaned file", "orphaned files"))
+		}
+
+	case "export":
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		output := "vendor-snapshot.tar.gz"
+		for i := 0; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case (arg == "--output" || arg == "-o") && i+1 < len(args):
+				i++
+				output = args[i]
+			case strings.HasPrefix(arg, "--output="):
+				output = strings.TrimPrefix(arg, "--output=")
+			}
+		}
+
+		if !manager.IsInitialized() {
+			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		callback := tui.NewNonInteractiveTUICallback(flags)
+		manifest, err := manager.Export(output)
+		if err != nil {
+			callback.ShowError("Export Failed", err.Error())
+			os.Exit(1)
+		}
+
+		if flags.Mode == core.OutputJSON {
+			_ = callback.FormatJSON(core.JSONOutput{
+				Status:  "success",
+				Message: "Exported to " + output,
+				Data:    map[string]interface{}{"output": output, "manifest": manifest},
+			})
+		} else {
+			callback.ShowSuccess(fmt.Sprintf("Exported %s (%s) to %s",
+				core.Pluralize(len(manifest.Vendors), "vendor", "vendors"),
+				core.Pluralize(len(manifest.Files), "file", "files"), output))
+		}
+
+	case "import":
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		archive := ""
+		for _, arg := range args {
+			if !strings.HasPrefix(arg, "-") && archive == "" {
+				archive = arg
+			}
+		}
+
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		if archive == "" {
+			callback.ShowError("Usage", "git-vendor import <archive> [--yes]")
+			os.Exit(core.ExitInvalidArguments)
+		}
+
+		// An initialized project has vendored files and state the archive replaces
+		if manager.IsInitialized() && !callback.AskConfirmation(
+			"Replace vendored files from "+archive+"?",
+			"Every vendored file in the archive, vendor.yml, vendor.lock and the license copies are overwritten.",
+		) {
+			if flags.Mode != core.OutputQuiet {
+				fmt.Println("Cancelled.")
+			}
+			os.Exit(core.ExitCancelled)
+		}
+
+		manifest, err := manager.Import(archive)
+		if err != nil {
+			callback.ShowError("Import Failed", err.Error())
+			os.Exit(1)
+		}
+
+		if flags.Mode == core.OutputJSON {
+			_ = callback.FormatJSON(core.JSONOutput{
+				Status:  "success",
+				Message: "Imported " + archive,
+				Data:    map[string]interface{}{"archive": archive, "manifest": manifest},
+			})
+		} else {
+			callback.ShowSuccess(fmt.Sprintf("Imported %s (%s) from %s",
+				core.Pluralize(len(manifest.Vendors), "vendor", "vendors"),
+				core.Pluralize(len(manifest.Files), "file", "files"), archive))
+		}
+
 	case "list":
 		// Parse common flags
-		flags, _ := parseCommonFlags(os.Args[2:])
+		flags, args := parseCommonFlags(os.Args[2:])
 
 		// Create appropriate callback
 		var callback core.UICallback
@@ -413,11 +1147,40 @@ func main() {
 		}
 		manager.SetUICallback(callback)
 
-		if !core.IsVendorInitialized() {
+		format := ""
+		for i := 0; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case arg == "--format" && i+1 < len(args):
+				i++
+				format = args[i]
+			case strings.HasPrefix(arg, "--format="):
+				format = strings.TrimPrefix(arg, "--format=")
+			default:
+				callback.ShowError("Invalid Flags", fmt.Sprintf("unknown argument %q\nUsage: git-vendor list [--format yaml]", arg))
+				os.Exit(1)
+			}
+		}
+		if format != "" && format != core.ListFormatYAML {
+			callback.ShowError("Invalid Flags", fmt.Sprintf("unsupported --format %q (supported: yaml)", format))
+			os.Exit(1)
+		}
+
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
 
+		if format == core.ListFormatYAML {
+			data, err := manager.ListYAML()
+			if err != nil {
+				callback.ShowError("Error", err.Error())
+				os.Exit(1)
+			}
+			fmt.Print(string(data))
+			break
+		}
+
 		cfg, err := manager.GetConfig()
 		if err != nil {
 			callback.ShowError("Error", err.Error())
@@ -468,6 +1231,7 @@ func main() {
 						specData["source_version_tag"] = entry.SourceVersionTag
 						specData["vendored_at"] = entry.VendoredAt
 						specData["vendored_by"] = entry.VendoredBy
+						specData["updated"] = entry.Updated
 						specData["last_synced_at"] = entry.LastSyncedAt
 					}
 					specsData = append(specsData, specData)
@@ -530,6 +1294,9 @@ func main() {
 							}
 							fmt.Printf("    Vendored: %s\n", vendoredInfo)
 						}
+						if entry.Updated != "" {
+							fmt.Printf("    Updated:  %s\n", formatShortDate(entry.Updated))
+						}
 						if entry.LastSyncedAt != "" {
 							fmt.Printf("    Synced:   %s\n", formatShortDate(entry.LastSyncedAt))
 						}
@@ -586,6 +1353,24 @@ func main() {
 		noCache := false
 		commit := false
 		local := false
+		retryOnStale := false
+		reportUnmanaged := false
+		unmanagedRoot := ""
+		snapshot := false
+		offline := false
+		onlyPositions := false
+		checkLicense := false
+		strictLicense := false
+		relocate := false
+		includePinned := false
+		allowHooks := false
+		hardlink := false
+		retriesFlag := ""
+		timeoutFlag := ""
+		sinceFlag := ""
+		explainPlan := false
+		dryRun := false
+		var excludeVendors []string
 		vendorName := ""
 
 		for i := 0; i < len(args); i++ {
@@ -607,8 +1392,70 @@ func main() {
 				commit = true
 			case arg == "--local":
 				local = true
+			case arg == "--retry-on-stale":
+				retryOnStale = true
+			case arg == "--report-unmanaged":
+				reportUnmanaged = true
+			case arg == "--unmanaged-root":
+				if i+1 < len(args) {
+					unmanagedRoot = args[i+1]
+					i++
+				}
+			case strings.HasPrefix(arg, "--unmanaged-root="):
+				unmanagedRoot = strings.TrimPrefix(arg, "--unmanaged-root=")
+			case arg == "--snapshot":
+				snapshot = true
+			case arg == "--offline":
+				offline = true
+			case arg == "--only-positions":
+				onlyPositions = true
+			case arg == "--check-license":
+				checkLicense = true
+			case arg == "--strict-license":
+				strictLicense = true
+			case arg == "--relocate":
+				relocate = true
+			case arg == "--include-pinned":
+				includePinned = true
+			case arg == "--allow-hooks":
+				allowHooks = true
+			case arg == "--hardlink":
+				hardlink = true
+			case arg == "--since" && i+1 < len(args):
+				i++
+				sinceFlag = args[i]
+			case strings.HasPrefix(arg, "--since="):
+				sinceFlag = strings.TrimPrefix(arg, "--since=")
+			case arg == "--retries" && i+1 < len(args):
+				i++
+				retriesFlag = args[i]
+			case strings.HasPrefix(arg, "--retries="):
+				retriesFlag = strings.TrimPrefix(arg, "--retries=")
+			case arg == "--timeout" && i+1 < len(args):
+				i++
+				timeoutFlag = args[i]
+			case strings.HasPrefix(arg, "--timeout="):
+				timeoutFlag = strings.TrimPrefix(arg, "--timeout=")
+			case arg == "--explain-plan":
+				explainPlan = true
+			case arg == "--dry-run":
+				dryRun = true
+			case arg == "--no-symlinks":
+				core.NoSymlinks = true
+			case arg == "--exclude-vendor" || strings.HasPrefix(arg, "--exclude-vendor="):
+				pattern, next := excludeVendorValue(args, i, callback)
+				excludeVendors = append(excludeVendors, pattern)
+				if next {
+					i++
+				}
+			case arg == "--only":
+				if i+1 < len(args) {
+					vendorName = args[i+1]
+					i++
+				}
+			case strings.HasPrefix(arg, "--only="):
+				vendorName = strings.TrimPrefix(arg, "--only=")
 			case arg == "--verbose" || arg == "-v":
-				core.Verbose = true
 				manager.UpdateVerboseMode(true)
 			case !strings.HasPrefix(arg, "--"):
 				vendorName = arg
@@ -621,14 +1468,128 @@ func main() {
 			os.Exit(1)
 		}
 
-		if !core.IsVendorInitialized() {
+		// --offline restores from snapshots and can't detect upstream removals either
+		if offline && prune {
+			callback.ShowError("Invalid Options", "--offline and --prune are mutually exclusive")
+			os.Exit(1)
+		}
+
+		// --only-positions re-places snippets at locked commits, so it can't prune either
+		if onlyPositions && prune {
+			callback.ShowError("Invalid Options", "--only-positions and --prune are mutually exclusive")
+			os.Exit(1)
+		}
+
+		// --relocate searches freshly fetched upstream files, so it needs the update phase
+		if relocate && (locked || offline || onlyPositions) {
+			callback.ShowError("Invalid Options", "--relocate cannot be combined with --locked, --offline or --only-positions")
+			os.Exit(1)
+		}
+
+		// --dry-run previews the update phase (or, with --prune, the prune step)
+		if dryRun && !prune && (locked || offline || onlyPositions) {
+			callback.ShowError("Invalid Options", "--dry-run previews lock updates and cannot be combined with --locked, --offline or --only-positions")
+			os.Exit(1)
+		}
+
+		if err := core.ValidateVendorPatterns(excludeVendors); err != nil {
+			callback.ShowError("Invalid Options", err.Error())
+			os.Exit(1)
+		}
+
+		// --retries N allows N retries after the first fetch attempt (0 disables retry)
+		fetchAttempts := 0
+		if retriesFlag != "" {
+			n, err := strconv.Atoi(retriesFlag)
+			if err != nil || n < 0 {
+				callback.ShowError("Invalid Flags", fmt.Sprintf("invalid --retries %q (use a non-negative integer)", retriesFlag))
+				os.Exit(1)
+			}
+			fetchAttempts = n + 1
+		}
+
+		var since time.Duration
+		if sinceFlag != "" {
+			d, err := core.ParseSince(sinceFlag)
+			if err != nil {
+				callback.ShowError("Invalid Flags", err.Error())
+				os.Exit(1)
+			}
+			since = d
+		}
+
+		var timeout time.Duration
+		if timeoutFlag != "" {
+			d, err := time.ParseDuration(timeoutFlag)
+			if err != nil || d <= 0 {
+				callback.ShowError("Invalid Flags", fmt.Sprintf("invalid --timeout %q (use a positive duration like 30s or 2m)", timeoutFlag))
+				os.Exit(1)
+			}
+			timeout = d
+		}
+
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
 
+		// --prune --dry-run lists the mappings prune would remove and exits without syncing
+		if dryRun && prune {
+			plan, err := manager.PlanPruneMappings(vendorName, excludeVendors)
+			if err != nil {
+				callback.ShowError("Prune Plan Failed", err.Error())
+				os.Exit(1)
+			}
+			printPrunePlan(plan, flags.Mode)
+			os.Exit(0)
+		}
+
+		// --explain-plan previews conflict resolution order and exits without syncing
+		if explainPlan {
+			plans, err := manager.ExplainPlan()
+			if err != nil {
+				callback.ShowError("Explain Plan Failed", err.Error())
+				os.Exit(1)
+			}
+			if flags.Mode == core.OutputJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				_ = enc.Encode(core.JSONOutput{
+					Status: "success",
+					Data:   map[string]interface{}{"plans": plans},
+				})
+			} else {
+				printExplainPlan(plans)
+			}
+			os.Exit(0)
+		}
+
 		// Create signal-aware context for Ctrl+C cancellation
 		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 		defer stop()
+		// --timeout bounds the whole pull; expiry kills any running git subprocess
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		// --dry-run resolves each ref with ls-remote and lists the vendors whose
+		// lock would move, without fetching, copying, or writing the lock
+		if dryRun {
+			plan, err := manager.UpdateDryRun(ctx, core.UpdateOptions{
+				Local:          local,
+				VendorName:     vendorName,
+				ExcludeVendors: excludeVendors,
+				IncludePinned:  includePinned,
+			})
+			if err != nil {
+				callback.ShowError("Update Preview Failed", err.Error())
+				os.Exit(1)
+			}
+			printUpdatePlan(plan, flags.Mode)
+			os.Exit(0)
+		}
 
 		pullOpts := core.PullOptions{
 			Locked:      locked,
@@ -639,27 +1600,67 @@ func main() {
 			NoCache:     noCache,
 			VendorName:  vendorName,
 			Local:       local,
+
+			RetryOnStale:    retryOnStale,
+			ReportUnmanaged: reportUnmanaged || unmanagedRoot != "",
+			UnmanagedRoot:   unmanagedRoot,
+			Snapshot:        snapshot,
+			Offline:         offline,
+			OnlyPositions:   onlyPositions,
+			CheckLicense:    checkLicense,
+			StrictLicense:   strictLicense,
+			Relocate:        relocate,
+			IncludePinned:   includePinned,
+			AllowHooks:      allowHooks,
+			Hardlink:        hardlink,
+			Since:           since,
+			ExcludeVendors:  excludeVendors,
+			FetchAttempts:   fetchAttempts,
 		}
 
 		result, err := manager.Pull(ctx, pullOpts)
 		if err != nil {
-			callback.ShowError("Pull Failed", err.Error())
+			// A failed sync phase still reports the vendors it reached
+			if flags.Mode == core.OutputJSON && result != nil && len(result.Vendors) > 0 {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				_ = enc.Encode(core.JSONOutput{
+					Status: "error",
+					Data:   map[string]interface{}{"vendors": result.Vendors},
+					Error:  &core.JSONError{Title: "Pull Failed", Message: err.Error()},
+				})
+			} else {
+				callback.ShowError("Pull Failed", err.Error())
+			}
+			// Declining to overwrite local modifications is a cancellation
+			if core.IsLocalModifications(err) {
+				os.Exit(core.ExitCancelled)
+			}
 			os.Exit(1)
 		}
 
 		// Display results
 		if flags.Mode == core.OutputJSON {
+			vendors := result.Vendors
+			if vendors == nil {
+				vendors = []core.VendorSyncResult{}
+			}
 			data := map[string]interface{}{
 				"updated":         result.Updated,
 				"synced":          result.Synced,
 				"files_written":   result.FilesWritten,
 				"files_skipped":   result.FilesSkipped,
 				"files_removed":   result.FilesRemoved,
+				"bytes_written":   result.BytesWritten,
 				"mappings_pruned": result.MappingsPruned,
+				"vendors":         vendors,
 			}
 			if len(result.Warnings) > 0 {
 				data["warnings"] = result.Warnings
 			}
+			if pullOpts.ReportUnmanaged {
+				data["unmanaged"] = result.Unmanaged
+			}
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
 			_ = enc.Encode(core.JSONOutput{
@@ -683,6 +1684,16 @@ func main() {
 			for _, w := range result.Warnings {
 				fmt.Printf("  warning: %s\n", w)
 			}
+			if pullOpts.ReportUnmanaged {
+				if len(result.Unmanaged) == 0 {
+					fmt.Println("  No unmanaged files found.")
+				} else {
+					fmt.Printf("  %s not produced by any mapping:\n", core.Pluralize(len(result.Unmanaged), "unmanaged file", "unmanaged files"))
+					for _, p := range result.Unmanaged {
+						fmt.Printf("    %s\n", p)
+					}
+				}
+			}
 		}
 
 		// Auto-commit if --commit flag is set
@@ -707,7 +1718,7 @@ func main() {
 		}
 		manager.SetUICallback(callback)
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -828,7 +1839,6 @@ func main() {
 					os.Exit(1)
 				}
 			case arg == "--verbose" || arg == "-v":
-				core.Verbose = true
 				manager.UpdateVerboseMode(true)
 			case !strings.HasPrefix(arg, "--"):
 				vendorName = arg
@@ -840,7 +1850,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			pushCallback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -912,7 +1922,25 @@ func main() {
 
 	case "validate":
 		// Parse common flags
-		flags, _ := parseCommonFlags(os.Args[2:])
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		// Parse validate-specific flags
+		checkOnly := false
+		policyPath := "" // empty = default PolicyFile location
+		for i := 0; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case arg == "--check-only":
+				checkOnly = true
+			case strings.HasPrefix(arg, "--policy="):
+				policyPath = strings.TrimPrefix(arg, "--policy=")
+			case arg == "--policy":
+				if i+1 < len(args) {
+					policyPath = args[i+1]
+					i++
+				}
+			}
+		}
 
 		// Create appropriate callback
 		var callback core.UICallback
@@ -923,11 +1951,39 @@ func main() {
 		}
 		manager.SetUICallback(callback)
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
 
+		// --check-only: consolidated pre-merge gate, fails on any issue
+		if checkOnly {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			result, err := manager.CheckOnly(ctx, policyPath)
+			if err != nil {
+				callback.ShowError("Check Failed", err.Error())
+				os.Exit(1)
+			}
+
+			if flags.Mode == core.OutputJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(result); err != nil {
+					tui.PrintError("JSON Output Failed", err.Error())
+					os.Exit(1)
+				}
+			} else {
+				fmt.Print(core.FormatCheckOnlyReport(result))
+			}
+
+			if result.Summary.Result != types.AuditResultPass {
+				os.Exit(1)
+			}
+			return
+		}
+
 		// Get config for summary
 		cfg, err := manager.GetConfig()
 		if err != nil {
@@ -952,19 +2008,7 @@ func main() {
 			// JSON output mode
 			conflictsData := make([]map[string]interface{}, 0, len(conflicts))
 			for _, conflict := range conflicts {
-				conflictsData = append(conflictsData, map[string]interface{}{
-					"path":    conflict.Path,
-					"vendor1": conflict.Vendor1,
-					"vendor2": conflict.Vendor2,
-					"mapping1": map[string]interface{}{
-						"from": conflict.Mapping1.From,
-						"to":   conflict.Mapping1.To,
-					},
-					"mapping2": map[string]interface{}{
-						"from": conflict.Mapping2.From,
-						"to":   conflict.Mapping2.To,
-					},
-				})
+				conflictsData = append(conflictsData, conflictJSON(conflict))
 			}
 
 			if len(conflicts) > 0 {
@@ -998,6 +2042,12 @@ func main() {
 				fmt.Println()
 				for _, conflict := range conflicts {
 					fmt.Printf("⚠ Conflict: %s\n", conflict.Path)
+					switch conflict.Reason {
+					case types.ConflictNestedPath:
+						fmt.Printf("  %s's destination lies inside %s's directory and is clobbered on the next sync\n", conflict.Vendor2, conflict.Vendor1)
+					case types.ConflictAutoNamed:
+						fmt.Printf("  An empty 'to' is auto-named from the basename of 'from'; set an explicit 'to' on one mapping\n")
+					}
 					fmt.Printf("  • %s: %s (remote) → %s (local)\n", conflict.Vendor1, conflict.Mapping1.From, conflict.Mapping1.To)
 					fmt.Printf("  • %s: %s (remote) → %s (local)\n", conflict.Vendor2, conflict.Mapping2.From, conflict.Mapping2.To)
 					fmt.Println()
@@ -1029,7 +2079,19 @@ func main() {
 		offline := false
 		remoteOnly := false
 		strictOnly := false
+		positionsOnly := false
+		filesOnly := false
 		complianceOverride := ""
+		groupBy := ""
+		baselineUpdate := false
+		quick := false
+		fix := false
+		timeoutFlag := ""
+		sinceFlag := ""
+		strict := false
+		failOnFlag := ""
+		var acceptPatterns []string
+		var excludeVendors []string
 
 		for i := 0; i < len(args); i++ {
 			arg := args[i]
@@ -1047,44 +2109,244 @@ func main() {
 				offline = true
 			case arg == "--remote-only":
 				remoteOnly = true
+			case arg == "--since" && i+1 < len(args):
+				i++
+				sinceFlag = args[i]
+			case strings.HasPrefix(arg, "--since="):
+				sinceFlag = strings.TrimPrefix(arg, "--since=")
 			case arg == "--strict-only":
 				strictOnly = true
+			case arg == "--strict":
+				strict = true
+			case arg == "--fail-on" && i+1 < len(args):
+				i++
+				failOnFlag = args[i]
+			case strings.HasPrefix(arg, "--fail-on="):
+				failOnFlag = strings.TrimPrefix(arg, "--fail-on=")
+			case arg == "--positions-only":
+				positionsOnly = true
+			case arg == "--files-only":
+				filesOnly = true
+			case arg == "--exclude-vendor" || strings.HasPrefix(arg, "--exclude-vendor="):
+				pattern, next := excludeVendorValue(args, i, callback)
+				excludeVendors = append(excludeVendors, pattern)
+				if next {
+					i++
+				}
 			case strings.HasPrefix(arg, "--compliance="):
 				complianceOverride = strings.TrimPrefix(arg, "--compliance=")
 			case arg == "--compliance" && i+1 < len(args):
 				i++
 				complianceOverride = args[i]
+			case arg == "--group-by" && i+1 < len(args):
+				i++
+				groupBy = args[i]
+			case strings.HasPrefix(arg, "--group-by="):
+				groupBy = strings.TrimPrefix(arg, "--group-by=")
+			case arg == "--baseline-update":
+				baselineUpdate = true
+			case arg == "--quick":
+				quick = true
+			case arg == "--fix":
+				fix = true
+			case arg == "--accept" && i+1 < len(args):
+				i++
+				acceptPatterns = append(acceptPatterns, args[i])
+			case strings.HasPrefix(arg, "--accept="):
+				acceptPatterns = append(acceptPatterns, strings.TrimPrefix(arg, "--accept="))
+			case arg == "--timeout" && i+1 < len(args):
+				i++
+				timeoutFlag = args[i]
+			case strings.HasPrefix(arg, "--timeout="):
+				timeoutFlag = strings.TrimPrefix(arg, "--timeout=")
+			}
+		}
+
+		var timeout time.Duration
+		if timeoutFlag != "" {
+			d, err := time.ParseDuration(timeoutFlag)
+			if err != nil || d <= 0 {
+				callback.ShowError("Invalid Flags", fmt.Sprintf("invalid --timeout %q (use a positive duration like 30s or 2m)", timeoutFlag))
+				os.Exit(1)
+			}
+			timeout = d
+		}
+
+		var since time.Duration
+		if sinceFlag != "" {
+			d, err := core.ParseSince(sinceFlag)
+			if err != nil {
+				callback.ShowError("Invalid Flags", err.Error())
+				os.Exit(1)
 			}
+			since = d
+		}
+
+		var failOn []string
+		if failOnFlag != "" {
+			statuses, err := core.ParseFailOn(failOnFlag)
+			if err != nil {
+				callback.ShowError("Invalid Flags", err.Error())
+				os.Exit(1)
+			}
+			failOn = statuses
+		}
+
+		// --json from parseCommonFlags also triggers JSON output
+		if flags.Mode == core.OutputJSON {
+			format = "json"
+		}
+
+		if strict && failOn != nil {
+			callback.ShowError("Invalid Flags", "--strict and --fail-on are mutually exclusive")
+			os.Exit(1)
+		}
+
+		if offline && remoteOnly {
+			callback.ShowError("Invalid Flags", "--offline and --remote-only are mutually exclusive")
+			os.Exit(1)
+		}
+
+		if offline && since > 0 {
+			callback.ShowError("Invalid Flags", "--since needs remote checks and cannot be combined with --offline")
+			os.Exit(1)
+		}
+
+		if positionsOnly && filesOnly {
+			callback.ShowError("Invalid Flags", "--positions-only and --files-only are mutually exclusive")
+			os.Exit(1)
 		}
 
-		// --json from parseCommonFlags also triggers JSON output
-		if flags.Mode == core.OutputJSON {
-			format = "json"
+		if groupBy != "" && groupBy != "vendor" {
+			callback.ShowError("Invalid Flags", fmt.Sprintf("unsupported --group-by value %q (supported: vendor)", groupBy))
+			os.Exit(1)
 		}
 
-		if offline && remoteOnly {
-			callback.ShowError("Invalid Flags", "--offline and --remote-only are mutually exclusive")
+		if groupBy != "" && remoteOnly {
+			callback.ShowError("Invalid Flags", "--group-by requires offline checks and cannot be combined with --remote-only")
+			os.Exit(1)
+		}
+
+		// --baseline-update only rewrites hashes for paths the user approved
+		if baselineUpdate != (len(acceptPatterns) > 0) {
+			callback.ShowError("Invalid Flags", "--baseline-update and --accept <glob> must be used together")
+			os.Exit(1)
+		}
+
+		// --fix restores what offline checks report as broken
+		if fix && (quick || remoteOnly || baselineUpdate) {
+			callback.ShowError("Invalid Flags", "--fix cannot be combined with --quick, --remote-only or --baseline-update")
+			os.Exit(1)
+		}
+
+		// GitHub annotations point at files, which only offline checks report
+		if format == "github" && (quick || remoteOnly) {
+			callback.ShowError("Invalid Flags", "--format github cannot be combined with --quick or --remote-only")
+			os.Exit(1)
+		}
+
+		// --quick only checks lock presence and Stats destinations, and has
+		// no per-status counts for --strict/--fail-on to act on
+		if quick && (remoteOnly || positionsOnly || filesOnly || groupBy != "" || baselineUpdate || strict || failOn != nil) {
+			callback.ShowError("Invalid Flags", "--quick cannot be combined with --remote-only, --positions-only, --files-only, --group-by, --baseline-update, --strict or --fail-on")
 			os.Exit(1)
 		}
 
-		if !core.IsVendorInitialized() {
+		if err := core.ValidateVendorPatterns(excludeVendors); err != nil {
+			callback.ShowError("Invalid Flags", err.Error())
+			os.Exit(1)
+		}
+
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
 
+		// --quick skips hashing and remote checks entirely
+		if quick {
+			quickResult, err := manager.QuickStatus(excludeVendors)
+			if err != nil {
+				callback.ShowError("Status Failed", err.Error())
+				os.Exit(1)
+			}
+			switch {
+			case format == "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(quickResult); err != nil {
+					callback.ShowError("JSON Output Failed", err.Error())
+					os.Exit(1)
+				}
+			case flags.Mode != core.OutputQuiet:
+				printQuickStatus(quickResult)
+			}
+			if quickResult.Summary.Result != "PASS" {
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
+		// Bless the approved local modifications before checking, so the
+		// report below already reflects the new baseline
+		if baselineUpdate {
+			baseline, err := manager.UpdateBaseline(acceptPatterns)
+			if err != nil {
+				callback.ShowError("Baseline Update Failed", err.Error())
+				os.Exit(1)
+			}
+			if format != "json" && flags.Mode != core.OutputQuiet {
+				for _, p := range baseline.UpdatedFiles {
+					fmt.Printf("  baseline updated: %s\n", p)
+				}
+				fmt.Printf("Updated lock hashes for %s.\n\n",
+					core.Pluralize(len(baseline.UpdatedFiles), "file", "files"))
+			}
+		}
+
 		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 		defer stop()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		// Restore broken files first, so the report below checks the result
+		var fixResult *types.VerifyFixResult
+		if fix {
+			var err error
+			fixResult, err = manager.FixVerify(ctx, core.VerifyOptions{PositionsOnly: positionsOnly, FilesOnly: filesOnly}, excludeVendors)
+			if err != nil {
+				callback.ShowError("Fix Failed", err.Error())
+				os.Exit(1)
+			}
+			if format == "table" && flags.Mode != core.OutputQuiet {
+				for _, p := range fixResult.Restored {
+					fmt.Printf("  restored: %s\n", p)
+				}
+				for _, f := range fixResult.Failed {
+					fmt.Printf("  not restored: %s (%s)\n", f.Path, f.Error)
+				}
+				fmt.Printf("Restored %s.\n\n", core.Pluralize(len(fixResult.Restored), "file", "files"))
+			}
+		}
 
 		result, err := manager.Status(ctx, core.StatusOptions{
 			Offline:            offline,
 			RemoteOnly:         remoteOnly,
 			StrictOnly:         strictOnly,
 			ComplianceOverride: complianceOverride,
+			PositionsOnly:      positionsOnly,
+			FilesOnly:          filesOnly,
+			ExcludeVendors:     excludeVendors,
+			GroupByVendor:      groupBy == "vendor",
+			Since:              since,
 		})
 		if err != nil {
 			callback.ShowError("Status Failed", err.Error())
 			os.Exit(1)
 		}
+		result.Fix = fixResult
 
 		switch {
 		case format == "json":
@@ -1094,28 +2356,26 @@ func main() {
 				callback.ShowError("JSON Output Failed", err.Error())
 				os.Exit(1)
 			}
+		case format == "github":
+			if _, err := core.WriteGitHubAnnotations(os.Stdout, result.Files); err != nil {
+				callback.ShowError("Annotation Output Failed", err.Error())
+				os.Exit(1)
+			}
 		case flags.Mode != core.OutputQuiet:
 			printStatusHuman(result)
 		}
 
-		// Exit code: 0=PASS, 1=FAIL, 2=WARN
-		switch result.Summary.Result {
-		case "PASS":
-			os.Exit(0)
-		case "WARN":
-			os.Exit(2)
-		default: // FAIL
-			os.Exit(1)
-		}
+		// Exit code: 0=PASS, 1=FAIL, 2=WARN (--strict/--fail-on pick what fails)
+		os.Exit(core.StatusExitCode(result, strict, failOn))
 
 	case "compliance":
 		// Show effective compliance levels for all vendors (Spec 075)
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
 
-		configStore := core.NewFileConfigStore(".")
+		configStore := core.NewFileConfigStore(manager.RootDir)
 		config, err := configStore.Load()
 		if err != nil {
 			tui.PrintError("Config Load Failed", err.Error())
@@ -1257,7 +2517,7 @@ func main() {
 			}
 		}
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1387,7 +2647,7 @@ func main() {
 		}
 		manager.SetUICallback(callback)
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1472,7 +2732,7 @@ func main() {
 
 	case "annotate":
 		// Retroactively attach vendor metadata as a git note to an existing commit
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1506,37 +2766,321 @@ func main() {
 			}
 		}
 
-		if err := manager.AnnotateVendorCommit(commitHash, vendorFilter); err != nil {
-			tui.PrintError("Annotate Failed", err.Error())
+		if err := manager.AnnotateVendorCommit(commitHash, vendorFilter); err != nil {
+			tui.PrintError("Annotate Failed", err.Error())
+			os.Exit(1)
+		}
+		tui.PrintSuccess("Vendor metadata attached as git note.")
+
+	case "completion":
+		// Generate shell completion script
+		if len(os.Args) < 3 {
+			tui.PrintError("Usage", "git-vendor completion <shell>\nSupported shells: bash, zsh, fish, powershell")
+			os.Exit(1)
+		}
+
+		shell := os.Args[2]
+		var script string
+
+		switch shell {
+		case "bash":
+			script = cmd.GenerateBashCompletion()
+		case "zsh":
+			script = cmd.GenerateZshCompletion()
+		case "fish":
+			script = cmd.GenerateFishCompletion()
+		case "powershell":
+			script = cmd.GeneratePowerShellCompletion()
+		default:
+			tui.PrintError("Invalid Shell", fmt.Sprintf("'%s' is not supported. Use: bash, zsh, fish, or powershell", shell))
+			os.Exit(1)
+		}
+
+		fmt.Println(script)
+
+	case "lock":
+		// Check vendor.lock against vendor.yml, or rebuild it with --regenerate
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		manager.SetUICallback(callback)
+
+		regenerate := false
+		local := false
+		includePinned := false
+		rehash := false
+		for _, arg := range args {
+			switch arg {
+			case "--regenerate":
+				regenerate = true
+			case "--local":
+				local = true
+			case "--include-pinned":
+				includePinned = true
+			case "--rehash":
+				rehash = true
+			default:
+				callback.ShowError("Invalid Flags", fmt.Sprintf("unknown flag %q\nUsage: git-vendor lock [--regenerate [--local] [--include-pinned] | --rehash] [--json]", arg))
+				os.Exit(1)
+			}
+		}
+		if local && !regenerate {
+			callback.ShowError("Invalid Flags", "--local requires --regenerate")
+			os.Exit(1)
+		}
+		if includePinned && !regenerate {
+			callback.ShowError("Invalid Flags", "--include-pinned requires --regenerate")
+			os.Exit(1)
+		}
+		if rehash && regenerate {
+			callback.ShowError("Invalid Flags", "--rehash and --regenerate cannot be combined")
+			os.Exit(1)
+		}
+
+		if !manager.IsInitialized() {
+			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		if rehash {
+			if err := manager.RehashLock(); err != nil {
+				callback.ShowError("Lock Rehash Failed", err.Error())
+				os.Exit(1)
+			}
+			callback.ShowSuccess(fmt.Sprintf("Updated the checksum of %s", manager.LockPath()))
+			os.Exit(0)
+		}
+
+		if regenerate {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			if err := manager.RegenerateLock(ctx, core.UpdateOptions{Local: local, IncludePinned: includePinned}); err != nil {
+				callback.ShowError("Lock Regeneration Failed", err.Error())
+				os.Exit(1)
+			}
+			callback.ShowSuccess(fmt.Sprintf("Regenerated %s", manager.LockPath()))
+			os.Exit(0)
+		}
+
+		lockResult, err := manager.CheckLock()
+		if err != nil {
+			callback.ShowError("Lock Check Failed", err.Error())
+			os.Exit(1)
+		}
+		switch flags.Mode {
+		case core.OutputJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(lockResult); err != nil {
+				callback.ShowError("JSON Output Failed", err.Error())
+				os.Exit(1)
+			}
+		case core.OutputNormal:
+			printLockCheck(lockResult, manager.LockPath(), manager.ConfigPath())
+		}
+		if lockResult.Summary.Result != "PASS" {
+			os.Exit(1)
+		}
+
+	case "why":
+		// Explain which vendor mapping produces a destination path
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		manager.SetUICallback(callback)
+
+		if len(args) != 1 {
+			callback.ShowError("Invalid Arguments", "Usage: git-vendor why <path> [--json]")
+			os.Exit(1)
+		}
+		if !manager.IsInitialized() {
+			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		why, err := manager.Why(args[0])
+		if err != nil {
+			callback.ShowError("Not Vendored", err.Error())
+			os.Exit(1)
+		}
+		switch flags.Mode {
+		case core.OutputJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(why); err != nil {
+				callback.ShowError("JSON Output Failed", err.Error())
+				os.Exit(1)
+			}
+		case core.OutputNormal:
+			printWhy(why)
+		}
+
+	case "tree":
+		// Render config mapping destinations as a directory tree
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		manager.SetUICallback(callback)
+
+		if len(args) > 0 {
+			callback.ShowError("Invalid Flags", fmt.Sprintf("unknown argument %q\nUsage: git-vendor tree [--json]", args[0]))
+			os.Exit(1)
+		}
+		if !manager.IsInitialized() {
+			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		tree, err := manager.Tree()
+		if err != nil {
+			callback.ShowError("Tree Failed", err.Error())
+			os.Exit(1)
+		}
+		switch flags.Mode {
+		case core.OutputJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(tree); err != nil {
+				callback.ShowError("JSON Output Failed", err.Error())
+				os.Exit(1)
+			}
+		case core.OutputNormal:
+			printVendorTree(tree)
+		}
+
+	case "graph":
+		// Emit vendors, destinations, and conflicts as a Graphviz digraph
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		manager.SetUICallback(callback)
+
+		format := core.GraphFormatDOT
+		for i := 0; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case arg == "--format" && i+1 < len(args):
+				i++
+				format = args[i]
+			case strings.HasPrefix(arg, "--format="):
+				format = strings.TrimPrefix(arg, "--format=")
+			default:
+				callback.ShowError("Invalid Flags", fmt.Sprintf("unknown argument %q\nUsage: git-vendor graph [--format dot]", arg))
+				os.Exit(1)
+			}
+		}
+		if format != core.GraphFormatDOT {
+			callback.ShowError("Invalid Flags", fmt.Sprintf("unsupported --format %q (supported: dot)", format))
+			os.Exit(1)
+		}
+		if !manager.IsInitialized() {
+			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		dot, err := manager.GraphDOT()
+		if err != nil {
+			callback.ShowError("Graph Failed", err.Error())
+			os.Exit(1)
+		}
+		fmt.Print(dot)
+
+	case "bump":
+		// Move a vendor to a new ref and re-sync it, without the edit wizard
+		flags, args := parseCommonFlags(os.Args[2:])
+
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		manager.SetUICallback(callback)
+
+		usage := "Usage: git-vendor bump <vendor> <ref> [--from <ref>] [--no-sync] [--local] [--json]"
+		bumpOpts := core.BumpOptions{}
+		var positionalArgs []string
+		for i := 0; i < len(args); i++ {
+			switch {
+			case args[i] == "--no-sync":
+				bumpOpts.NoSync = true
+			case args[i] == "--local":
+				bumpOpts.Local = true
+			case args[i] == "--from" && i+1 < len(args):
+				i++
+				bumpOpts.From = args[i]
+			case strings.HasPrefix(args[i], "--from="):
+				bumpOpts.From = strings.TrimPrefix(args[i], "--from=")
+			case strings.HasPrefix(args[i], "-"):
+				callback.ShowError("Invalid Flags", fmt.Sprintf("unknown flag %q\n%s", args[i], usage))
+				os.Exit(1)
+			default:
+				positionalArgs = append(positionalArgs, args[i])
+			}
+		}
+		if len(positionalArgs) != 2 {
+			callback.ShowError("Invalid Arguments", usage)
+			os.Exit(1)
+		}
+		bumpOpts.VendorName, bumpOpts.Ref = positionalArgs[0], positionalArgs[1]
+
+		if !manager.IsInitialized() {
+			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
-		tui.PrintSuccess("Vendor metadata attached as git note.")
 
-	case "completion":
-		// Generate shell completion script
-		if len(os.Args) < 3 {
-			tui.PrintError("Usage", "git-vendor completion <shell>\nSupported shells: bash, zsh, fish, powershell")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		bumpResult, err := manager.BumpVendor(ctx, bumpOpts)
+		if err != nil {
+			callback.ShowError("Bump Failed", err.Error())
 			os.Exit(1)
 		}
 
-		shell := os.Args[2]
-		var script string
+		switch flags.Mode {
+		case core.OutputJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(bumpResult); err != nil {
+				callback.ShowError("JSON Output Failed", err.Error())
+				os.Exit(1)
+			}
+		case core.OutputNormal:
+			callback.ShowSuccess(fmt.Sprintf("Bumped '%s': %s → %s", bumpResult.Vendor, bumpResult.From, bumpResult.To))
+			if bumpResult.Synced {
+				fmt.Printf("  Locked at %s\n", bumpResult.Commit)
+			} else {
+				fmt.Printf("  %s updated; run 'git-vendor pull %s' to sync\n", manager.ConfigPath(), bumpResult.Vendor)
+			}
+		}
 
-		switch shell {
-		case "bash":
-			script = cmd.GenerateBashCompletion()
-		case "zsh":
-			script = cmd.GenerateZshCompletion()
-		case "fish":
-			script = cmd.GenerateFishCompletion()
-		case "powershell":
-			script = cmd.GeneratePowerShellCompletion()
-		default:
-			tui.PrintError("Invalid Shell", fmt.Sprintf("'%s' is not supported. Use: bash, zsh, fish, or powershell", shell))
+	case "schema":
+		// Print the JSON Schema for vendor.yml (for editor validation/completion)
+		if len(os.Args) > 2 {
+			tui.PrintError("Usage", "git-vendor schema\nPrints the vendor.yml JSON Schema to stdout")
 			os.Exit(1)
 		}
-
-		fmt.Println(script)
+		os.Stdout.Write(core.ConfigSchema())
 
 	case "drift":
 		// Parse command-specific flags
@@ -1579,7 +3123,12 @@ func main() {
 			}
 		}
 
-		if !core.IsVendorInitialized() {
+		// JSON always carries the structured hunks of each modified file
+		if format == "json" {
+			detail = true
+		}
+
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1656,7 +3205,7 @@ func main() {
 		}
 		manager.SetUICallback(callback)
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1685,7 +3234,7 @@ func main() {
 		}
 		manager.SetUICallback(callback)
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1720,6 +3269,45 @@ func main() {
 			callback.ShowSuccess("Lockfile already up to date - no migration needed")
 		}
 
+	case "normalize":
+		// Parse common flags
+		flags, _ := parseCommonFlags(os.Args[2:])
+
+		// Create appropriate callback
+		var callback core.UICallback
+		if flags.Yes || flags.Mode != core.OutputNormal {
+			callback = tui.NewNonInteractiveTUICallback(flags)
+		} else {
+			callback = tui.NewTUICallback()
+		}
+		manager.SetUICallback(callback)
+
+		if !manager.IsInitialized() {
+			callback.ShowError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		// Rewrite vendor.yml in canonical form
+		changed, err := manager.NormalizeConfig()
+		if err != nil {
+			callback.ShowError("Normalize Failed", err.Error())
+			os.Exit(1)
+		}
+
+		switch {
+		case flags.Mode == core.OutputJSON:
+			_ = callback.FormatJSON(core.JSONOutput{
+				Status: "success",
+				Data: map[string]interface{}{
+					"changed": changed,
+				},
+			})
+		case changed:
+			callback.ShowSuccess("Normalized vendor.yml")
+		default:
+			callback.ShowSuccess("vendor.yml already canonical - no changes")
+		}
+
 	case "sbom":
 		// Parse command-specific flags
 		format := "cyclonedx" // default format
@@ -1787,7 +3375,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1807,8 +3395,8 @@ func main() {
 			Validate:    validate,
 		}
 		generator := core.NewSBOMGeneratorWithOptions(
-			core.NewFileLockStore(core.VendorDir),
-			core.NewFileConfigStore(core.VendorDir),
+			core.NewFileLockStore(manager.RootDir),
+			core.NewFileConfigStore(manager.RootDir),
 			opts,
 		)
 		output, err := generator.Generate(sbomFormat)
@@ -1870,7 +3458,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -1942,6 +3530,89 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "licenses":
+		// Parse command-specific flags
+		jsonOutput := false
+		outputFile := ""
+		showHelp := false
+
+		for i := 2; i < len(os.Args); i++ {
+			arg := os.Args[i]
+			switch {
+			case arg == "--help" || arg == "-h":
+				showHelp = true
+			case arg == "--json" || arg == "--format=json":
+				jsonOutput = true
+			case arg == "--output" && i+1 < len(os.Args):
+				outputFile = os.Args[i+1]
+				i++
+			case strings.HasPrefix(arg, "--output="):
+				outputFile = strings.TrimPrefix(arg, "--output=")
+			case arg == "-o" && i+1 < len(os.Args):
+				outputFile = os.Args[i+1]
+				i++
+			}
+		}
+
+		if showHelp {
+			fmt.Println("Generate a third-party notices report from captured license files")
+			fmt.Println()
+			fmt.Println("Usage: git-vendor licenses [options]")
+			fmt.Println()
+			fmt.Println("Options:")
+			fmt.Println("  --json           Emit structured entries instead of the text report")
+			fmt.Println("  --output <file>  Write to file instead of stdout")
+			fmt.Println("  -o <file>        Shorthand for --output")
+			fmt.Println("  --help, -h       Show this help message")
+			fmt.Println()
+			fmt.Println("Examples:")
+			fmt.Println("  git-vendor licenses -o THIRD_PARTY_NOTICES")
+			fmt.Println("  git-vendor licenses --json")
+			os.Exit(0)
+		}
+
+		if !manager.IsInitialized() {
+			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(1)
+		}
+
+		out := io.Writer(os.Stdout)
+		var file *os.File
+		if outputFile != "" {
+			f, err := os.Create(outputFile)
+			if err != nil {
+				tui.PrintError("Write Failed", err.Error())
+				os.Exit(1)
+			}
+			file = f
+			out = f
+		}
+
+		var reportErr error
+		if jsonOutput {
+			var report *types.AttributionReport
+			report, reportErr = manager.LicenseAttributions()
+			if reportErr == nil {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				reportErr = enc.Encode(report)
+			}
+		} else {
+			reportErr = manager.GenerateLicenseReport(out)
+		}
+		if file != nil {
+			if err := file.Close(); err != nil && reportErr == nil {
+				reportErr = err
+			}
+		}
+		if reportErr != nil {
+			tui.PrintError("License Report Failed", reportErr.Error())
+			os.Exit(1)
+		}
+		if outputFile != "" {
+			tui.PrintSuccess(fmt.Sprintf("Third-party notices written to %s", outputFile))
+		}
+
 	case "audit":
 		// Parse command-specific flags
 		format := "table"
@@ -1949,6 +3620,8 @@ func main() {
 		skipScan := false
 		skipLicense := false
 		skipDrift := false
+		ancestry := false
+		inventory := false
 		scanFailOn := ""
 		licenseFailOn := "deny"
 		policyPath := ""
@@ -1971,6 +3644,10 @@ func main() {
 				skipLicense = true
 			case arg == "--skip-drift":
 				skipDrift = true
+			case arg == "--ancestry":
+				ancestry = true
+			case arg == "--inventory":
+				inventory = true
 			case strings.HasPrefix(arg, "--fail-on="):
 				scanFailOn = strings.TrimPrefix(arg, "--fail-on=")
 			case arg == "--fail-on":
@@ -1998,11 +3675,10 @@ func main() {
 		}
 
 		if verbose {
-			core.Verbose = true
 			manager.UpdateVerboseMode(true)
 		}
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
 			os.Exit(1)
 		}
@@ -2016,6 +3692,8 @@ func main() {
 			SkipScan:          skipScan,
 			SkipLicense:       skipLicense,
 			SkipDrift:         skipDrift,
+			Ancestry:          ancestry,
+			Inventory:         inventory,
 			ScanFailOn:        scanFailOn,
 			LicenseFailOn:     licenseFailOn,
 			LicensePolicyPath: policyPath,
@@ -2086,7 +3764,7 @@ func main() {
 		name := positionalArgs[0]
 		url := positionalArgs[1]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2123,16 +3801,25 @@ func main() {
 		flags, args := parseCommonFlags(os.Args[2:])
 		jsonMode := flags.Mode == core.OutputJSON
 
-		if len(args) < 1 {
+		name := ""
+		dryRun := false
+		for _, arg := range args {
+			switch {
+			case arg == "--dry-run":
+				dryRun = true
+			case !strings.HasPrefix(arg, "--") && name == "":
+				name = arg
+			}
+		}
+		if name == "" {
 			if jsonMode {
-				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, "usage: git-vendor delete <name>", core.ExitInvalidArguments))
+				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, "usage: git-vendor delete <name> [--dry-run]", core.ExitInvalidArguments))
 			}
-			tui.PrintError("Usage", "git-vendor delete <name>")
+			tui.PrintError("Usage", "git-vendor delete <name> [--dry-run]")
 			os.Exit(core.ExitInvalidArguments)
 		}
-		name := args[0]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2174,6 +3861,24 @@ func main() {
 			os.Exit(core.ExitVendorNotFound)
 		}
 
+		// --dry-run lists what would be deleted and exits without confirming or deleting
+		if dryRun {
+			plan, err := manager.PlanRemoveVendor(name)
+			if err != nil {
+				if jsonMode {
+					os.Exit(core.EmitCLIError(core.ErrCodeInternalError, err.Error(), core.ExitGeneralError))
+				}
+				callback.ShowError("Error", err.Error())
+				os.Exit(core.ExitGeneralError)
+			}
+			if jsonMode {
+				core.EmitCLISuccess(map[string]interface{}{"name": name, "plan": plan})
+			} else {
+				printPrunePlan(plan, flags.Mode)
+			}
+			os.Exit(core.ExitSuccess)
+		}
+
 		// Confirmation (skipped in JSON/quiet/yes mode)
 		confirmed := callback.AskConfirmation(
 			fmt.Sprintf("Remove vendor '%s'?", name),
@@ -2181,10 +3886,10 @@ func main() {
 		)
 		if !confirmed {
 			if jsonMode {
-				os.Exit(core.EmitCLIError(core.ErrCodeInternalError, "cancelled", core.ExitGeneralError))
+				os.Exit(core.EmitCLIError(core.ErrCodeCancelled, "cancelled", core.ExitCancelled))
 			}
 			fmt.Println("Cancelled.")
-			os.Exit(core.ExitGeneralError)
+			os.Exit(core.ExitCancelled)
 		}
 
 		if err := manager.RemoveVendor(name); err != nil {
@@ -2223,7 +3928,7 @@ func main() {
 		oldName := positionalArgs[0]
 		newName := positionalArgs[1]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2253,6 +3958,129 @@ func main() {
 			tui.PrintSuccess(fmt.Sprintf("Renamed '%s' → '%s'", oldName, newName))
 		}
 
+	case "mv":
+		flags, args := parseCommonFlags(os.Args[2:])
+		jsonMode := flags.Mode == core.OutputJSON
+
+		var positionalArgs []string
+		for _, a := range args {
+			if !strings.HasPrefix(a, "--") {
+				positionalArgs = append(positionalArgs, a)
+			}
+		}
+
+		if len(positionalArgs) < 2 {
+			if jsonMode {
+				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, "usage: git-vendor mv <vendor> <new-dest>", core.ExitInvalidArguments))
+			}
+			tui.PrintError("Usage", "git-vendor mv <vendor> <new-dest>")
+			os.Exit(core.ExitInvalidArguments)
+		}
+
+		if !manager.IsInitialized() {
+			if jsonMode {
+				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
+			}
+			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(core.ExitGeneralError)
+		}
+
+		result, err := manager.MoveVendor(positionalArgs[0], positionalArgs[1])
+		if err != nil {
+			if jsonMode {
+				os.Exit(core.EmitCLIError(core.CLIErrorCodeForError(err), err.Error(), core.CLIExitCodeForError(err)))
+			}
+			tui.PrintError("Failed", err.Error())
+			os.Exit(core.CLIExitCodeForError(err))
+		}
+
+		if jsonMode {
+			core.EmitCLISuccess(result)
+		} else {
+			tui.PrintSuccess(fmt.Sprintf("Moved '%s': %s → %s (%s, %s on disk)",
+				result.VendorName, result.From, result.To,
+				core.Pluralize(result.Mappings, "mapping", "mappings"),
+				core.Pluralize(result.PathsMoved, "path", "paths")))
+		}
+
+	case "pin", "unpin":
+		flags, args := parseCommonFlags(os.Args[2:])
+		jsonMode := flags.Mode == core.OutputJSON
+		usage := "git-vendor pin <vendor>"
+		if command == "unpin" {
+			usage = "git-vendor unpin <vendor> [--ref <branch>]"
+		}
+
+		var positionalArgs []string
+		ref := ""
+		for i := 0; i < len(args); i++ {
+			switch {
+			case command == "unpin" && args[i] == "--ref" && i+1 < len(args):
+				i++
+				ref = args[i]
+			case command == "unpin" && strings.HasPrefix(args[i], "--ref="):
+				ref = strings.TrimPrefix(args[i], "--ref=")
+			case !strings.HasPrefix(args[i], "--"):
+				positionalArgs = append(positionalArgs, args[i])
+			}
+		}
+
+		if len(positionalArgs) != 1 {
+			if jsonMode {
+				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, "usage: "+usage, core.ExitInvalidArguments))
+			}
+			tui.PrintError("Usage", usage)
+			os.Exit(core.ExitInvalidArguments)
+		}
+		name := positionalArgs[0]
+
+		if !manager.IsInitialized() {
+			if jsonMode {
+				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
+			}
+			tui.PrintError("Not Initialized", core.ErrNotInitialized.Error())
+			os.Exit(core.ExitGeneralError)
+		}
+
+		var changes []core.RefChange
+		var err error
+		if command == "pin" {
+			changes, err = manager.PinVendor(name)
+		} else {
+			changes, err = manager.UnpinVendor(name, ref)
+		}
+		if err != nil {
+			if jsonMode {
+				os.Exit(core.EmitCLIError(core.CLIErrorCodeForError(err), err.Error(), core.CLIExitCodeForError(err)))
+			}
+			tui.PrintError("Failed", err.Error())
+			os.Exit(core.CLIExitCodeForError(err))
+		}
+
+		if jsonMode {
+			if changes == nil {
+				changes = []core.RefChange{}
+			}
+			core.EmitCLISuccess(map[string]interface{}{
+				"vendor":  name,
+				"changes": changes,
+			})
+		} else if len(changes) == 0 {
+			if command == "pin" {
+				tui.PrintInfo(fmt.Sprintf("'%s' is already pinned", name))
+			} else {
+				tui.PrintInfo(fmt.Sprintf("'%s' is not pinned", name))
+			}
+		} else {
+			verb := "Pinned"
+			if command == "unpin" {
+				verb = "Unpinned"
+			}
+			for _, c := range changes {
+				tui.PrintSuccess(fmt.Sprintf("%s '%s': %s → %s", verb, name, c.From, c.To))
+			}
+		}
+
 	case "add-mapping":
 		flags, args := parseCommonFlags(os.Args[2:])
 		jsonMode := flags.Mode == core.OutputJSON
@@ -2286,7 +4114,7 @@ func main() {
 		vendorName := positionalArgs[0]
 		from := positionalArgs[1]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2342,7 +4170,7 @@ func main() {
 		vendorName := positionalArgs[0]
 		from := positionalArgs[1]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2392,7 +4220,7 @@ func main() {
 
 		vendorName := positionalArgs[0]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2479,7 +4307,7 @@ func main() {
 		vendorName := positionalArgs[0]
 		from := positionalArgs[1]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2549,7 +4377,7 @@ func main() {
 
 		vendorName := positionalArgs[0]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2627,15 +4455,15 @@ func main() {
 
 		if len(positionalArgs) < 1 {
 			if jsonMode {
-				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, "usage: git-vendor check <vendor> [--json]", core.ExitInvalidArguments))
+				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, "usage: git-vendor check <vendor> [--json] (for the repo-wide pre-merge gate, run 'git-vendor validate --check-only')", core.ExitInvalidArguments))
 			}
-			tui.PrintError("Usage", "git-vendor check <vendor> [--json]")
+			tui.PrintError("Usage", "git-vendor check <vendor> [--json]\nFor the repo-wide pre-merge gate, run 'git-vendor validate --check-only'")
 			os.Exit(core.ExitInvalidArguments)
 		}
 
 		vendorName := positionalArgs[0]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2700,7 +4528,7 @@ func main() {
 
 		vendorName := positionalArgs[0]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2804,7 +4632,7 @@ func main() {
 		subCmd := args[0]
 		subArgs := args[1:]
 
-		if !core.IsVendorInitialized() {
+		if !manager.IsInitialized() {
 			if jsonMode {
 				os.Exit(core.EmitCLIError(core.ErrCodeNotInitialized, core.ErrNotInitialized.Error(), core.ExitGeneralError))
 			}
@@ -2870,6 +4698,65 @@ func main() {
 				}
 			}
 
+		case "show":
+			resolved := false
+			format := "yaml"
+			for i := 0; i < len(subArgs); i++ {
+				arg := subArgs[i]
+				switch {
+				case arg == "--resolved":
+					resolved = true
+				case arg == "--format" && i+1 < len(subArgs):
+					format = subArgs[i+1]
+					i++
+				case strings.HasPrefix(arg, "--format="):
+					format = strings.TrimPrefix(arg, "--format=")
+				}
+			}
+			if format != "yaml" && format != "json" {
+				if jsonMode {
+					os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, fmt.Sprintf("--format must be yaml or json, got '%s'", format), core.ExitInvalidArguments))
+				}
+				tui.PrintError("Invalid Flag", fmt.Sprintf("--format must be yaml or json, got '%s'", format))
+				os.Exit(core.ExitInvalidArguments)
+			}
+
+			var cfg types.VendorConfig
+			var err error
+			if resolved {
+				cfg, err = manager.EffectiveConfig()
+			} else {
+				cfg, err = manager.GetConfig()
+				cfg = core.RedactConfig(core.RestoreConfigEnv(cfg))
+			}
+			if err != nil {
+				if jsonMode {
+					os.Exit(core.EmitCLIError(core.ErrCodeConfigError, err.Error(), core.ExitGeneralError))
+				}
+				tui.PrintError("Error", err.Error())
+				os.Exit(core.ExitGeneralError)
+			}
+
+			if jsonMode {
+				format = "json"
+			}
+			out, err := core.MarshalConfigDocument(cfg, format)
+			if err != nil {
+				if jsonMode {
+					os.Exit(core.EmitCLIError(core.ErrCodeConfigError, err.Error(), core.ExitGeneralError))
+				}
+				tui.PrintError("Error", err.Error())
+				os.Exit(core.ExitGeneralError)
+			}
+			if jsonMode {
+				core.EmitCLISuccess(map[string]interface{}{
+					"resolved": resolved,
+					"config":   json.RawMessage(out),
+				})
+			} else {
+				fmt.Print(string(out))
+			}
+
 		case "get":
 			if len(subArgs) < 1 {
 				if jsonMode {
@@ -3031,9 +4918,9 @@ func main() {
 
 		default:
 			if jsonMode {
-				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, fmt.Sprintf("unknown config subcommand: %s (use get, set, list, add-mirror, remove-mirror, or list-mirrors)", subCmd), core.ExitInvalidArguments))
+				os.Exit(core.EmitCLIError(core.ErrCodeInvalidArguments, fmt.Sprintf("unknown config subcommand: %s (use get, set, list, show, add-mirror, remove-mirror, or list-mirrors)", subCmd), core.ExitInvalidArguments))
 			}
-			tui.PrintError("Usage", fmt.Sprintf("unknown config subcommand: %s\nUsage: git-vendor config <get|set|list|add-mirror|remove-mirror|list-mirrors>", subCmd))
+			tui.PrintError("Usage", fmt.Sprintf("unknown config subcommand: %s\nUsage: git-vendor config <get|set|list|show|add-mirror|remove-mirror|list-mirrors>", subCmd))
 			os.Exit(core.ExitInvalidArguments)
 		}
 
@@ -3074,7 +4961,6 @@ func main() {
 			case strings.HasPrefix(arg, "--verify-command="):
 				cascadeOpts.VerifyCommand = strings.TrimPrefix(arg, "--verify-command=")
 			case arg == "--verbose" || arg == "-v":
-				core.Verbose = true
 				manager.UpdateVerboseMode(true)
 			}
 		}
@@ -6,12 +6,17 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 // Git represents a git repository at a specific directory.
 type Git struct {
-	Dir     string // working directory
-	Verbose bool   // log commands to stderr
+	Dir     string   // working directory
+	Verbose bool     // log commands to stderr
+	Env     []string // extra "KEY=value" variables for every command; never logged
+	// Trace, when set, is called after every command with its arguments,
+	// how long it ran, and its error. Env is never passed.
+	Trace func(args []string, elapsed time.Duration, err error)
 }
 
 // New creates a Git instance for the given directory.
@@ -26,8 +31,10 @@ func (g *Git) Run(ctx context.Context, args ...string) (string, error) {
 	}
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = g.Dir
-	cmd.Env = sanitizedEnv()
+	cmd.Env = append(sanitizedEnv(), g.Env...)
+	start := time.Now()
 	out, err := cmd.Output()
+	g.trace(args, start, err)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return "", &GitError{
@@ -61,8 +68,11 @@ func (g *Git) RunSilent(ctx context.Context, args ...string) error {
 	}
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = g.Dir
-	cmd.Env = sanitizedEnv()
-	if output, err := cmd.CombinedOutput(); err != nil {
+	cmd.Env = append(sanitizedEnv(), g.Env...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	g.trace(args, start, err)
+	if err != nil {
 		return &GitError{
 			Args:   args,
 			Stderr: string(output),
@@ -72,6 +82,13 @@ func (g *Git) RunSilent(ctx context.Context, args ...string) error {
 	return nil
 }
 
+// trace reports a finished command to Trace, if set.
+func (g *Git) trace(args []string, start time.Time, err error) {
+	if g.Trace != nil {
+		g.Trace(args, time.Since(start), err)
+	}
+}
+
 // IsInstalled returns true if the git binary is available on PATH.
 func IsInstalled() bool {
 	_, err := exec.LookPath("git")
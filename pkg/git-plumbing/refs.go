@@ -3,6 +3,8 @@ package git
 import (
 	"context"
 	"errors"
+	"os/exec"
+	"strings"
 )
 
 // HEAD returns the full SHA of the current HEAD commit.
@@ -37,3 +39,26 @@ func (g *Git) ResolveRef(ctx context.Context, ref string) (string, error) {
 	}
 	return out, nil
 }
+
+// IsAncestor reports whether ancestor is reachable from descendant
+// (git merge-base --is-ancestor). An ancestor commit missing from the local
+// object store is reported as not reachable rather than as an error, since
+// objects absent after fetching descendant's history cannot be its ancestors.
+func (g *Git) IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	err := g.RunSilent(ctx, "merge-base", "--is-ancestor", ancestor, descendant)
+	if err == nil {
+		return true, nil
+	}
+	var gitErr *GitError
+	if errors.As(err, &gitErr) {
+		var exitErr *exec.ExitError
+		if errors.As(gitErr.Err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		if strings.Contains(gitErr.Stderr, "Not a valid commit name "+ancestor) ||
+			strings.Contains(gitErr.Stderr, "Not a valid object name "+ancestor) {
+			return false, nil
+		}
+	}
+	return false, err
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/EmundoT/git-vendor/internal/types"
+)
+
+// TestFormatOfflineSummary verifies the verify-count line printed by
+// status/verify in normal mode, and that remote-only runs omit it.
+func TestFormatOfflineSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary types.StatusSummary
+		want    string
+	}{
+		{
+			name: "offline counts",
+			summary: types.StatusSummary{
+				TotalFiles: 7, Verified: 3, Modified: 1, Deleted: 1, Added: 2,
+				StaleConfigs: 1, OrphanedLock: 2, Result: "FAIL",
+			},
+			want: "Summary: 3 verified, 1 modified, 1 deleted, 2 added, 1 stale, 2 orphaned",
+		},
+		{
+			name:    "truncated files appended",
+			summary: types.StatusSummary{TotalFiles: 2, Verified: 1, Truncated: 1, Result: "FAIL"},
+			want:    "Summary: 1 verified, 0 modified, 0 deleted, 0 added, 0 stale, 0 orphaned, 1 truncated",
+		},
+		{
+			name:    "coherence issues only",
+			summary: types.StatusSummary{OrphanedLock: 1, Result: "WARN"},
+			want:    "Summary: 0 verified, 0 modified, 0 deleted, 0 added, 0 stale, 1 orphaned",
+		},
+		{
+			name:    "remote-only run",
+			summary: types.StatusSummary{TotalVendors: 2, Stale: 1, Result: "WARN"},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatOfflineSummary(tt.summary); got != tt.want {
+				t.Errorf("formatOfflineSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatVendorRollup verifies a --group-by vendor row uses the same
+// count order as the summary line.
+func TestFormatVendorRollup(t *testing.T) {
+	row := types.VendorVerifySummary{Vendor: "mylib", TotalFiles: 5, Verified: 3, Modified: 1, Orphaned: 1}
+	want := "  mylib                          3 verified, 1 modified, 0 deleted, 0 added, 0 stale, 1 orphaned"
+	if got := formatVendorRollup(row); got != want {
+		t.Errorf("formatVendorRollup() = %q, want %q", got, want)
+	}
+}
+
+// TestFormatUpstreamLine verifies the remote result line, including the locked
+// and remote short hashes shown for vendors behind upstream (outdated alias).
+func TestFormatUpstreamLine(t *testing.T) {
+	stale, fresh := true, false
+	tests := []struct {
+		name   string
+		vendor types.VendorStatusDetail
+		want   string
+	}{
+		{
+			name:   "behind upstream",
+			vendor: types.VendorStatusDetail{CommitHash: "abc1234def5678", UpstreamHash: "9876543fedcba0", UpstreamStale: &stale},
+			want:   "behind upstream: locked abc1234, remote 9876543",
+		},
+		{
+			name:   "up to date",
+			vendor: types.VendorStatusDetail{CommitHash: "abc1234def5678", UpstreamHash: "abc1234def5678", UpstreamStale: &fresh},
+			want:   "up to date",
+		},
+		{
+			name:   "ls-remote failed",
+			vendor: types.VendorStatusDetail{CommitHash: "abc1234def5678", UpstreamSkipped: true},
+			want:   "upstream check skipped (network error)",
+		},
+		{
+			name:   "offline run",
+			vendor: types.VendorStatusDetail{CommitHash: "abc1234def5678"},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatUpstreamLine(tt.vendor); got != tt.want {
+				t.Errorf("formatUpstreamLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatPruneTarget verifies the per-target line of a --dry-run deletion plan.
+func TestFormatPruneTarget(t *testing.T) {
+	tests := []struct {
+		target types.PruneTarget
+		want   string
+	}{
+		{
+			target: types.PruneTarget{Kind: types.PruneKindMapping, Path: ".git-vendor/vendor.yml", Vendor: "mylib", Ref: "main", From: "src/gone.go", To: "lib/gone.go", Reason: types.PruneReasonOrphanedByConfig},
+			want:   "mapping      mylib@main src/gone.go -> lib/gone.go in .git-vendor/vendor.yml [orphaned-by-config]",
+		},
+		{
+			target: types.PruneTarget{Kind: types.PruneKindFile, Path: ".git-vendor/licenses/mylib.txt", Vendor: "mylib", Reason: types.PruneReasonRemovedVendor},
+			want:   "file         .git-vendor/licenses/mylib.txt [removed-vendor]",
+		},
+		{
+			target: types.PruneTarget{Kind: types.PruneKindLockEntry, Path: ".git-vendor/vendor.lock", Vendor: "mylib", Ref: "v1", Reason: types.PruneReasonRemovedVendor},
+			want:   "lock_entry   mylib@v1 in .git-vendor/vendor.lock [removed-vendor]",
+		},
+	}
+	for _, tt := range tests {
+		if got := formatPruneTarget(tt.target); got != tt.want {
+			t.Errorf("formatPruneTarget() = %q, want %q", got, tt.want)
+		}
+	}
+}